@@ -118,3 +118,25 @@ func (c *Client) ApplicationCharmActions(arg params.Entity) (map[string]params.A
 	}
 	return result.Actions, nil
 }
+
+// EnqueueOperations adds operations that run an action on a number of
+// units, returning the details of each operation added.
+func (c *Client) EnqueueOperations(arg params.EnqueueOperationsArgs) (params.OperationResults, error) {
+	results := params.OperationResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("operations on this version of Juju")
+	}
+	err := c.facade.FacadeCall("EnqueueOperations", arg, &results)
+	return results, err
+}
+
+// Operations returns the operations with the given ids, along with the
+// progress of each of their actions.
+func (c *Client) Operations(arg params.OperationQueryArgs) (params.OperationResults, error) {
+	results := params.OperationResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("operations on this version of Juju")
+	}
+	err := c.facade.FacadeCall("Operations", arg, &results)
+	return results, err
+}
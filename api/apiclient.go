@@ -33,6 +33,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
@@ -198,6 +199,9 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 	}
 
 	client := rpc.NewConn(jsoncodec.New(dialResult.conn), nil)
+	if opts.TraceContext.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, opts.TraceContext)
+	}
 	client.Start(ctx)
 
 	bakeryClient := opts.BakeryClient
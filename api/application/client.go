@@ -282,10 +282,23 @@ type SetCharmConfig struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]storage.Constraints `json:"storage-constraints,omitempty"`
+
+	// Units, if set, restricts the upgrade to the named units. This
+	// field is only understood by Application facade version 11 and
+	// greater.
+	Units []string
+
+	// MaxUnavailable, if positive, upgrades the application's units
+	// progressively, no more than this many at a time. This field is
+	// only understood by Application facade version 11 and greater.
+	MaxUnavailable int
 }
 
 // SetCharm sets the charm for a given application.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	if (len(cfg.Units) > 0 || cfg.MaxUnavailable > 0) && c.BestAPIVersion() < 11 {
+		return errors.NotSupportedf("upgrading units progressively with this version of Juju")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -299,9 +312,10 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 				countPtr = &count
 			}
 			storageConstraints[name] = params.StorageConstraints{
-				Pool:  cons.Pool,
-				Size:  sizePtr,
-				Count: countPtr,
+				Pool:     cons.Pool,
+				Size:     sizePtr,
+				Count:    countPtr,
+				Snapshot: cons.Snapshot,
 			}
 		}
 	}
@@ -315,6 +329,8 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceUnits:         cfg.ForceUnits,
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
+		Units:              cfg.Units,
+		MaxUnavailable:     cfg.MaxUnavailable,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
@@ -665,6 +681,20 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeEndpoints exposes the application, restricting access to the
+// ports opened for each of the given endpoints to the specified spaces
+// and CIDRs. Settings for other endpoints are left unchanged.
+func (c *Client) ExposeEndpoints(application string, exposedEndpoints map[string]params.ExposedEndpoint) error {
+	if c.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("exposing endpoints with this version of Juju")
+	}
+	args := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposedEndpoints,
+	}
+	return c.facade.FacadeCall("Expose", args, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -850,3 +880,74 @@ func (c *Client) ResolveUnitErrors(units []string, all, retry bool) error {
 	}
 	return errors.Trace(results.Combine())
 }
+
+// CharmConfigHistory returns the recent revisions of an application's
+// charm config, oldest first.
+func (c *Client) CharmConfigHistory(application string) ([]params.CharmConfigRevision, error) {
+	if c.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("CharmConfigHistory not supported by this version of Juju")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.CharmConfigHistoryResults
+	err := c.facade.FacadeCall("CharmConfigHistory", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Revisions, nil
+}
+
+// RevertCharmConfig restores an application's charm config to the values
+// it had at the given revision.
+func (c *Client) RevertCharmConfig(application string, revision int) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("RevertCharmConfig not supported by this version of Juju")
+	}
+	args := params.RevertCharmConfigArgs{
+		Args: []params.RevertCharmConfigArg{{
+			ApplicationName: application,
+			Revision:        revision,
+		}},
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("RevertCharmConfig", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// HaltCharmRollout stops any more of an application's units from being
+// upgraded to the charm being rolled out to it.
+func (c *Client) HaltCharmRollout(application string) error {
+	return c.charmRolloutCall("HaltCharmRollout", application)
+}
+
+// RollbackCharmRollout abandons the charm being rolled out to an
+// application, returning any upgraded units to the application's charm.
+func (c *Client) RollbackCharmRollout(application string) error {
+	return c.charmRolloutCall("RollbackCharmRollout", application)
+}
+
+func (c *Client) charmRolloutCall(request, application string) error {
+	if c.BestAPIVersion() < 11 {
+		return errors.NotSupportedf("%s not supported by this version of Juju", request)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall(request, args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -30,7 +32,7 @@ type applicationSuite struct {
 var _ = gc.Suite(&applicationSuite{})
 
 func newClient(f basetesting.APICallerFunc) *application.Client {
-	return application.NewClient(basetesting.BestVersionCaller{f, 8})
+	return application.NewClient(basetesting.BestVersionCaller{f, 11})
 }
 
 func newClientV4(f basetesting.APICallerFunc) *application.Client {
@@ -1221,3 +1223,169 @@ func (s *applicationSuite) TestScaleApplicationCallError(c *gc.C) {
 	})
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
+
+func (s *applicationSuite) TestCharmConfigHistory(c *gc.C) {
+	created := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Check(objType, gc.Equals, "Application")
+		c.Check(request, gc.Equals, "CharmConfigHistory")
+		c.Check(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		result := response.(*params.CharmConfigHistoryResults)
+		result.Results = []params.CharmConfigHistoryResult{{
+			Revisions: []params.CharmConfigRevision{{
+				Revision: 1,
+				Config:   map[string]interface{}{"foo": "bar"},
+				Author:   "fred",
+				Created:  created,
+			}},
+		}}
+		return nil
+	})
+	revisions, err := client.CharmConfigHistory("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, jc.DeepEquals, []params.CharmConfigRevision{{
+		Revision: 1,
+		Config:   map[string]interface{}{"foo": "bar"},
+		Author:   "fred",
+		Created:  created,
+	}})
+}
+
+func (s *applicationSuite) TestCharmConfigHistoryNotSupported(c *gc.C) {
+	client := newClientV4(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.CharmConfigHistory("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestRevertCharmConfig(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Check(objType, gc.Equals, "Application")
+		c.Check(request, gc.Equals, "RevertCharmConfig")
+		c.Check(a, jc.DeepEquals, params.RevertCharmConfigArgs{
+			Args: []params.RevertCharmConfigArg{{ApplicationName: "mysql", Revision: 3}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{
+			Error: &params.Error{Message: "settings revision 3 not found"},
+		}}
+		return nil
+	})
+	err := client.RevertCharmConfig("mysql", 3)
+	c.Assert(err, gc.ErrorMatches, "settings revision 3 not found")
+}
+
+func (s *applicationSuite) TestSetCharmUnits(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "SetCharm")
+		args, ok := a.(params.ApplicationSetCharm)
+		c.Assert(ok, jc.IsTrue)
+		c.Check(args.Units, jc.DeepEquals, []string{"mysql/0"})
+		c.Check(args.MaxUnavailable, gc.Equals, 1)
+		return nil
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "mysql",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("cs:mysql-2"),
+		},
+		Units:          []string{"mysql/0"},
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetCharmUnitsNotSupported(c *gc.C) {
+	client := newClientV4(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "mysql",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("cs:mysql-2"),
+		},
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestHaltCharmRollout(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Application")
+		c.Check(request, gc.Equals, "HaltCharmRollout")
+		c.Check(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{}}
+		return nil
+	})
+	err := client.HaltCharmRollout("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestRollbackCharmRollout(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Check(objType, gc.Equals, "Application")
+		c.Check(request, gc.Equals, "RollbackCharmRollout")
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{
+			Error: &params.Error{Message: "charm rollout not found"},
+		}}
+		return nil
+	})
+	err := client.RollbackCharmRollout("mysql")
+	c.Assert(err, gc.ErrorMatches, "charm rollout not found")
+}
+
+func (s *applicationSuite) TestRollbackCharmRolloutNotSupported(c *gc.C) {
+	client := newClientV4(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.RollbackCharmRollout("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestExposeEndpoints(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Application")
+		c.Check(request, gc.Equals, "Expose")
+		c.Check(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "mysql",
+			ExposedEndpoints: map[string]params.ExposedEndpoint{
+				"server": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+			},
+		})
+		return nil
+	})
+	err := client.ExposeEndpoints("mysql", map[string]params.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeEndpointsNotSupported(c *gc.C) {
+	client := newClientV4(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.ExposeEndpoints("mysql", map[string]params.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -53,14 +53,27 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, should be a time in the past - only records with
+	// a log time on or before EndTime will be returned, and the stream
+	// is closed once they have been sent.
+	EndTime time.Time
+	// IncludeMessage lists regular expressions matched against the log
+	// message. If any are set, only messages matching at least one of
+	// them are included.
+	IncludeMessage []string
+	// ExcludeMessage lists regular expressions matched against the log
+	// message. Messages matching any of them are excluded.
+	ExcludeMessage []string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
 	attrs := url.Values{
-		"includeEntity": args.IncludeEntity,
-		"includeModule": args.IncludeModule,
-		"excludeEntity": args.ExcludeEntity,
-		"excludeModule": args.ExcludeModule,
+		"includeEntity":  args.IncludeEntity,
+		"includeModule":  args.IncludeModule,
+		"excludeEntity":  args.ExcludeEntity,
+		"excludeModule":  args.ExcludeModule,
+		"includeMessage": args.IncludeMessage,
+		"excludeMessage": args.ExcludeMessage,
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
@@ -80,6 +93,9 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
 	return attrs
 }
 
@@ -10,7 +10,7 @@ import (
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/sinkconfig"
 	"github.com/juju/juju/watcher"
 )
 
@@ -52,23 +52,23 @@ func (e *ModelWatcher) ModelConfig() (*config.Config, error) {
 }
 
 // WatchForLogForwardConfigChanges return a NotifyWatcher waiting for the
-// log forward syslog configuration to change.
+// log forward configuration to change.
 func (e *ModelWatcher) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error) {
 	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
 	// For now, we'll piggyback off the ModelConfig API.
 	return e.WatchForModelConfigChanges()
 }
 
-// LogForwardConfig returns the current log forward syslog configuration.
-func (e *ModelWatcher) LogForwardConfig() (*syslog.RawConfig, bool, error) {
+// LogForwardSinks returns the current configuration of each log
+// forwarding sink.
+func (e *ModelWatcher) LogForwardSinks() ([]sinkconfig.Config, error) {
 	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
 	// For now, we'll piggyback off the ModelConfig API.
 	modelConfig, err := e.ModelConfig()
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
-	cfg, ok := modelConfig.LogFwdSyslog()
-	return cfg, ok, nil
+	return modelConfig.LogFwdSinks(), nil
 }
 
 // UpdateStatusHookInterval returns the current update status hook interval.
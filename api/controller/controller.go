@@ -297,16 +297,60 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationDryRun runs all the checks that would be made when
+// migrating the specified model, without starting a migration. It
+// returns every problem that would prevent the migration.
+func (c *Client) MigrationDryRun(spec MigrationSpec) ([]string, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("migration dry runs on this controller")
+	}
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	response := params.MigrationDryRunResults{}
+	if err := c.facade.FacadeCall("MigrationDryRun", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Blockers, nil
+}
+
+func makeInitiateMigrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -318,19 +362,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:     macsJSON,
 			},
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
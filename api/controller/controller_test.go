@@ -170,6 +170,56 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestMigrationDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationDryRunResults)
+			*out = params.MigrationDryRunResults{
+				Results: []params.MigrationDryRunResult{{
+					Blockers: []string{"source prechecks failed: model is dying"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	blockers, err := client.MigrationDryRun(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, jc.DeepEquals, []string{"source prechecks failed: model is dying"})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationDryRun", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationDryRunError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.MigrationDryRunResults)
+			*out = params.MigrationDryRunResults{
+				Results: []params.MigrationDryRunResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationDryRun(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestMigrationDryRunNotSupported(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	_, err := client.MigrationDryRun(makeSpec())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
@@ -11,14 +11,14 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       3,
 	"ActionPruner":                 1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  8,
+	"Application":                  11,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
 	"Backups":                      2,
@@ -34,7 +34,7 @@ var facadeVersions = map[string]int{
 	"Cleaner":                      2,
 	"Client":                       2,
 	"Cloud":                        2,
-	"Controller":                   5,
+	"Controller":                   6,
 	"CredentialManager":            1,
 	"CredentialValidator":          1,
 	"CrossController":              1,
@@ -45,7 +45,7 @@ var facadeVersions = map[string]int{
 	"ExternalControllerUpdater":    1,
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   5,
+	"Firewaller":                   6,
 	"FirewallRules":                1,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
@@ -71,17 +71,19 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
-	"ModelConfig":                  2,
+	"MigrationTarget":              2,
+	"ModelConfig":                  3,
 	"ModelManager":                 4,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
+	"OperationRunner":              1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
 	"Provisioner":                  6,
 	"ProxyUpdater":                 2,
+	"Quota":                        1,
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
@@ -90,17 +92,19 @@ var facadeVersions = map[string]int{
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Secrets":                      1,
+	"SecretsManager":               1,
 	"Singular":                     2,
 	"Spaces":                       3,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      4,
-	"StorageProvisioner":           4,
+	"Storage":                      6,
+	"StorageProvisioner":           6,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       8,
+	"Uniter":                       12,
 	"Upgrader":                     1,
 	"UpgradeSeries":                1,
 	"UserManager":                  2,
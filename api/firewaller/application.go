@@ -75,3 +75,34 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposeInfo returns whether this application is exposed and, if so,
+// the expose settings of each of its endpoints, keyed by endpoint name.
+// The empty name refers to all endpoints without settings of their own.
+// If the application is exposed without any settings, its opened ports
+// may be accessed from anywhere.
+//
+// Controllers that don't support per-endpoint settings report only
+// whether the application is exposed.
+func (s *Application) ExposeInfo() (bool, map[string]params.ExposedEndpoint, error) {
+	if s.st.BestAPIVersion() < 6 {
+		exposed, err := s.IsExposed()
+		return exposed, nil, err
+	}
+	var results params.ExposeInfoResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposeInfo", args, &results)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(results.Results) != 1 {
+		return false, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, nil, result.Error
+	}
+	return result.Exposed, result.ExposedEndpoints, nil
+}
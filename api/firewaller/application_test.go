@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/watcher/watchertest"
 )
 
@@ -91,3 +92,22 @@ func (s *applicationSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *applicationSuite) TestExposeInfo(c *gc.C) {
+	exposed, exposedEndpoints, err := s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsFalse)
+	c.Assert(exposedEndpoints, gc.IsNil)
+
+	err = s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	exposed, exposedEndpoints, err = s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsTrue)
+	c.Assert(exposedEndpoints, jc.DeepEquals, map[string]params.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+}
@@ -6,6 +6,7 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/collections/set"
 	"gopkg.in/juju/names.v2"
 
 	apiwatcher "github.com/juju/juju/api/watcher"
@@ -111,6 +112,29 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 // OpenedPorts returns a map of network.PortRange to unit tag for all opened
 // port ranges on the machine for the subnet matching given subnetTag.
 func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+	ports, err := m.OpenedPortEndpoints(subnetTag)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[network.PortRange]names.UnitTag)
+	for portRange, unitEndpoints := range ports {
+		result[portRange] = unitEndpoints.Unit
+	}
+	return result, nil
+}
+
+// UnitEndpoints holds the tag of the unit that opened a port range and
+// the names of the endpoints the range was opened for. An empty name
+// means the range was opened for all of the endpoints.
+type UnitEndpoints struct {
+	Unit      names.UnitTag
+	Endpoints set.Strings
+}
+
+// OpenedPortEndpoints returns a map of network.PortRange to the unit and
+// endpoints for all opened port ranges on the machine for the subnet
+// matching given subnetTag.
+func (m *Machine) OpenedPortEndpoints(subnetTag names.SubnetTag) (map[network.PortRange]UnitEndpoints, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +157,22 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[network.PortRange]UnitEndpoints)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		portRange := ports.PortRange.NetworkPortRange()
+		unitEndpoints, ok := endResult[portRange]
+		if !ok {
+			unitEndpoints = UnitEndpoints{
+				Unit:      unitTag,
+				Endpoints: set.NewStrings(),
+			}
+			endResult[portRange] = unitEndpoints
+		}
+		unitEndpoints.Endpoints.Add(ports.Endpoint)
 	}
 	return endResult, nil
 }
@@ -135,6 +135,27 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 	})
 }
 
+func (s *machineSuite) TestOpenedPortEndpoints(c *gc.C) {
+	unitTag := s.units[0].Tag().(names.UnitTag)
+
+	err := s.units[0].OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenEndpointPorts("db", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPort("tcp", 1234)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := s.apiMachine.OpenedPortEndpoints(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 2)
+	http := ports[network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"}]
+	c.Check(http.Unit, gc.Equals, unitTag)
+	c.Check(http.Endpoints.SortedValues(), jc.DeepEquals, []string{"db", "url"})
+	other := ports[network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}]
+	c.Check(other.Unit, gc.Equals, unitTag)
+	c.Check(other.Endpoints.SortedValues(), jc.DeepEquals, []string{""})
+}
+
 func (s *machineSuite) TestIsManual(c *gc.C) {
 	answer, err := s.machines[0].IsManual()
 	c.Assert(err, jc.ErrorIsNil)
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/api/unitassigner"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/api/upgrader"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc/jsoncodec"
 )
@@ -174,6 +175,12 @@ type DialOpts struct {
 	// Clock is used as a time source for retries.
 	// If it is nil, clock.WallClock will be used.
 	Clock clock.Clock
+
+	// TraceContext, if valid, identifies the trace that requests
+	// made over the connection are part of. It is propagated to
+	// the API server so that the spans it records for the requests
+	// can be linked to the client's trace.
+	TraceContext trace.SpanContext
 }
 
 // IPAddrResolver implements a resolved from host name to the
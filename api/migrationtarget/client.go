@@ -60,6 +60,21 @@ func (c *Client) Import(bytes []byte) error {
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
+// ValidateImport asks the target controller whether the serialized
+// model could be imported, without importing it. It returns every
+// problem that would block the import.
+func (c *Client) ValidateImport(bytes []byte) ([]string, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("validating imports on this controller")
+	}
+	serialized := params.SerializedModel{Bytes: bytes}
+	var result params.ImportValidationResult
+	if err := c.caller.FacadeCall("ValidateImport", serialized, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Blockers, nil
+}
+
 // Abort removes all data relating to a previously imported model.
 func (c *Client) Abort(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
@@ -89,6 +89,35 @@ func (s *ClientSuite) TestImport(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestValidateImport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			*(result.(*params.ImportValidationResult)) = params.ImportValidationResult{
+				Blockers: []string{"cloud \"aws\" not found"},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	blockers, err := client.ValidateImport([]byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, jc.DeepEquals, []string{`cloud "aws" not found`})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ValidateImport", []interface{}{"", params.SerializedModel{Bytes: []byte("foo")}}},
+	})
+}
+
+func (s *ClientSuite) TestValidateImportNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.ValidateImport([]byte("foo"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -103,3 +103,27 @@ func (c *Client) Sequences() (map[string]int, error) {
 	}
 	return result.Sequences, nil
 }
+
+// ModelConfigHistory returns the recorded revisions of the model's
+// config, oldest first.
+func (c *Client) ModelConfigHistory() ([]params.ModelConfigRevision, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("ModelConfigHistory on v%d facade", c.BestAPIVersion())
+	}
+	var result params.ModelConfigHistoryResult
+	err := c.facade.FacadeCall("ModelConfigHistory", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Revisions, nil
+}
+
+// RevertModelConfig restores the model's config to the values it had
+// at the given revision.
+func (c *Client) RevertModelConfig(revision int) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("RevertModelConfig on v%d facade", c.BestAPIVersion())
+	}
+	args := params.RevertModelConfig{Revision: revision}
+	return c.facade.FacadeCall("RevertModelConfig", args, nil)
+}
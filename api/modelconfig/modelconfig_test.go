@@ -4,6 +4,8 @@
 package modelconfig_test
 
 import (
+	"time"
+
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -220,3 +222,75 @@ func (s *modelconfigSuite) TestSequences(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(sequences, jc.DeepEquals, map[string]int{"foo": 5, "bar": 2})
 }
+
+func (s *modelconfigSuite) TestModelConfigHistoryV2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 2}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.ModelConfigHistory()
+	c.Assert(err, gc.ErrorMatches, "ModelConfigHistory on v2 facade not supported")
+	err = client.RevertModelConfig(1)
+	c.Assert(err, gc.ErrorMatches, "RevertModelConfig on v2 facade not supported")
+}
+
+func (s *modelconfigSuite) TestModelConfigHistory(c *gc.C) {
+	created := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ModelConfigHistory")
+				c.Check(a, gc.IsNil)
+				results := result.(*params.ModelConfigHistoryResult)
+				results.Revisions = []params.ModelConfigRevision{{
+					Revision: 1,
+					Config:   map[string]interface{}{"foo": "bar"},
+					Author:   "bob",
+					Created:  created,
+				}}
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	revisions, err := client.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, jc.DeepEquals, []params.ModelConfigRevision{{
+		Revision: 1,
+		Config:   map[string]interface{}{"foo": "bar"},
+		Author:   "bob",
+		Created:  created,
+	}})
+}
+
+func (s *modelconfigSuite) TestRevertModelConfig(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RevertModelConfig")
+				c.Check(a, jc.DeepEquals, params.RevertModelConfig{Revision: 2})
+				called = true
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.RevertModelConfig(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
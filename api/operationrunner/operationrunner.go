@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationrunner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+const operationRunnerFacade = "OperationRunner"
+
+// Progress describes the state of the model's operations after they
+// have been advanced.
+type Progress struct {
+	// Running is the number of operations with actions still to run
+	// or finish.
+	Running int
+
+	// NextScheduled is when the earliest operation that is waiting
+	// for its scheduled time is due to start, or the zero time if
+	// there is no such operation.
+	NextScheduled time.Time
+}
+
+// API provides access to the OperationRunner API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side OperationRunner facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, operationRunnerFacade)
+	return &API{facade: facadeCaller}
+}
+
+// AdvanceOperations calls the server-side AdvanceOperations method.
+func (api *API) AdvanceOperations() (Progress, error) {
+	var result params.OperationProgressResult
+	if err := api.facade.FacadeCall("AdvanceOperations", nil, &result); err != nil {
+		return Progress{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return Progress{}, result.Error
+	}
+	progress := Progress{Running: result.Running}
+	if result.NextScheduled != nil {
+		progress.NextScheduled = *result.NextScheduled
+	}
+	return progress, nil
+}
+
+// WatchOperations calls the server-side WatchOperations method.
+func (api *API) WatchOperations() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := api.facade.FacadeCall("WatchOperations", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result), nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationrunner_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/operationrunner"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type OperationRunnerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&OperationRunnerSuite{})
+
+func (s *OperationRunnerSuite) TestAdvanceOperations(c *gc.C) {
+	next := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "OperationRunner")
+		c.Check(request, gc.Equals, "AdvanceOperations")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.OperationProgressResult)) = params.OperationProgressResult{
+			Running:       3,
+			NextScheduled: &next,
+		}
+		return nil
+	})
+	api := operationrunner.NewAPI(apiCaller)
+	progress, err := api.AdvanceOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress, jc.DeepEquals, operationrunner.Progress{Running: 3, NextScheduled: next})
+}
+
+func (s *OperationRunnerSuite) TestAdvanceOperationsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.OperationProgressResult)) = params.OperationProgressResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	api := operationrunner.NewAPI(apiCaller)
+	_, err := api.AdvanceOperations()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *OperationRunnerSuite) TestWatchOperationsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchOperations")
+		return errors.New("kaboom")
+	})
+	api := operationrunner.NewAPI(apiCaller)
+	_, err := api.WatchOperations()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
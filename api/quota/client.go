@@ -0,0 +1,52 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package quota implements the client-side API facade used to inspect
+// and change the resource quota of a model.
+package quota
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/quota"
+)
+
+// Client allows access to the Quota API facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the Quota API.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Quota")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Quota returns the resource limits placed on the model, and the
+// amount of each resource the model is using.
+func (c *Client) Quota() (quota.Quota, quota.Usage, error) {
+	var result params.ModelQuota
+	if err := c.facade.FacadeCall("Quota", nil, &result); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	limits := make(quota.Quota, len(result.Limits))
+	for resource, limit := range result.Limits {
+		limits[resource] = limit
+	}
+	usage := make(quota.Usage, len(result.Usage))
+	for resource, amount := range result.Usage {
+		usage[resource] = amount
+	}
+	return limits, usage, nil
+}
+
+// SetQuota replaces the resource limits placed on the model.
+func (c *Client) SetQuota(q quota.Quota) error {
+	err := c.facade.FacadeCall("SetQuota", params.SetModelQuotaArgs{
+		Limits: q,
+	}, nil)
+	return errors.Trace(err)
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/quota"
+	"github.com/juju/juju/apiserver/params"
+	corequota "github.com/juju/juju/core/quota"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestQuota(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "Quota")
+		c.Check(request, gc.Equals, "Quota")
+		c.Check(args, gc.IsNil)
+		*response.(*params.ModelQuota) = params.ModelQuota{
+			Limits: map[string]uint64{"machines": 10},
+			Usage:  map[string]uint64{"machines": 3, "memory": 2048},
+		}
+		return nil
+	})
+	client := quota.NewClient(apiCaller)
+	limits, usage, err := client.Quota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits, jc.DeepEquals, corequota.Quota{"machines": 10})
+	c.Assert(usage, jc.DeepEquals, corequota.Usage{"machines": 3, "memory": 2048})
+}
+
+func (s *ClientSuite) TestSetQuota(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "Quota")
+		c.Check(request, gc.Equals, "SetQuota")
+		c.Check(args, jc.DeepEquals, params.SetModelQuotaArgs{
+			Limits: map[string]uint64{"units": 20},
+		})
+		return errors.New("boom")
+	})
+	client := quota.NewClient(apiCaller)
+	err := client.SetQuota(corequota.Quota{"units": 20})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
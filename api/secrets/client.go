@@ -0,0 +1,94 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets implements the client-side API facade used to
+// inspect the secrets in a model.
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+)
+
+// Client allows access to the Secrets API facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the Secrets API.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Secrets")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// SecretDetails holds the details of a secret returned by ListSecrets.
+type SecretDetails struct {
+	Metadata secrets.Metadata
+
+	// Value holds the latest value of the secret, if it was requested
+	// and could be read.
+	Value secrets.Value
+
+	// Error holds any error encountered reading the secret's value.
+	Error error
+}
+
+// ListSecrets returns the details of the secrets in the model, or of the
+// secrets with the given IDs if any are specified. If showSecrets is
+// true, the latest value of each secret is included.
+func (c *Client) ListSecrets(showSecrets bool, ids ...string) ([]SecretDetails, error) {
+	var results params.ListSecretResults
+	err := c.facade.FacadeCall("ListSecrets", params.ListSecretsArgs{
+		ShowSecrets: showSecrets,
+		IDs:         ids,
+	}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	details := make([]SecretDetails, len(results.Results))
+	for i, r := range results.Results {
+		owner, err := names.ParseApplicationTag(r.OwnerTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		d := SecretDetails{
+			Metadata: secrets.Metadata{
+				ID:          r.ID,
+				Owner:       owner.Id(),
+				Name:        r.Name,
+				Description: r.Description,
+				Revision:    r.Revision,
+				CreateTime:  r.CreateTime,
+				UpdateTime:  r.UpdateTime,
+			},
+		}
+		for _, consumer := range r.Consumers {
+			app, err := names.ParseApplicationTag(consumer.ApplicationTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			relation, err := names.ParseRelationTag(consumer.RelationTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			d.Metadata.Consumers = append(d.Metadata.Consumers, secrets.Consumer{
+				Application: app.Id(),
+				RelationKey: relation.Id(),
+			})
+		}
+		if r.Value != nil {
+			if r.Value.Error != nil {
+				d.Error = r.Value.Error
+			} else {
+				d.Value = r.Value.Data
+			}
+		}
+		details[i] = d
+	}
+	return details, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	coresecrets "github.com/juju/juju/core/secrets"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestListSecrets(c *gc.C) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "ListSecrets")
+		c.Check(args, jc.DeepEquals, params.ListSecretsArgs{
+			ShowSecrets: true,
+			IDs:         []string{"secret:mysql/password"},
+		})
+		*response.(*params.ListSecretResults) = params.ListSecretResults{
+			Results: []params.ListSecretResult{{
+				ID:          "secret:mysql/password",
+				OwnerTag:    "application-mysql",
+				Name:        "password",
+				Description: "root password",
+				Revision:    2,
+				Consumers: []params.SecretConsumer{{
+					ApplicationTag: "application-wordpress",
+					RelationTag:    "relation-wordpress.db#mysql.server",
+				}},
+				CreateTime: now,
+				UpdateTime: now,
+				Value: &params.SecretValueResult{
+					Data: map[string]string{"password": "s3cret"},
+				},
+			}},
+		}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets(true, "secret:mysql/password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []secrets.SecretDetails{{
+		Metadata: coresecrets.Metadata{
+			ID:          "secret:mysql/password",
+			Owner:       "mysql",
+			Name:        "password",
+			Description: "root password",
+			Revision:    2,
+			Consumers: []coresecrets.Consumer{{
+				Application: "wordpress",
+				RelationKey: "wordpress:db mysql:server",
+			}},
+			CreateTime: now,
+			UpdateTime: now,
+		},
+		Value: coresecrets.Value{"password": "s3cret"},
+	}})
+}
+
+func (s *ClientSuite) TestListSecretsValueError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.ListSecretResults) = params.ListSecretResults{
+			Results: []params.ListSecretResult{{
+				ID:       "secret:mysql/password",
+				OwnerTag: "application-mysql",
+				Value: &params.SecretValueResult{
+					Error: &params.Error{Message: "boom"},
+				},
+			}},
+		}
+		return nil
+	})
+	result, err := secrets.NewClient(apiCaller).ListSecrets(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 1)
+	c.Assert(result[0].Value, gc.IsNil)
+	c.Assert(result[0].Error, gc.ErrorMatches, "boom")
+}
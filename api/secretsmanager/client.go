@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secretsmanager implements the client-side API facade used
+// by unit agents to create, read and share secrets.
+package secretsmanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+)
+
+// Client provides access to the SecretsManager API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client-side SecretsManager facade.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, "SecretsManager"),
+	}
+}
+
+// CreateSecret creates a secret owned by the unit's application, or adds
+// a new revision to it if it already exists, and grants access to it to
+// the remote applications of the given relations. The ID of the secret
+// is returned.
+func (c *Client) CreateSecret(
+	name, description string, value secrets.Value, relations ...names.RelationTag,
+) (string, error) {
+	arg := params.CreateSecretArg{
+		Name:        name,
+		Description: description,
+		Data:        value,
+	}
+	for _, tag := range relations {
+		arg.RelationTags = append(arg.RelationTags, tag.String())
+	}
+	var results params.StringResults
+	err := c.facade.FacadeCall("CreateSecrets", params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{arg},
+	}, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Result, nil
+}
+
+// GetSecretValue returns the value of the given revision of a secret. A
+// zero revision denotes the latest revision.
+func (c *Client) GetSecretValue(id string, revision int) (secrets.Value, error) {
+	var results params.SecretValueResults
+	err := c.facade.FacadeCall("GetSecretValues", params.GetSecretArgs{
+		Args: []params.GetSecretArg{{ID: id, Revision: revision}},
+	}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Data, nil
+}
+
+// RevokeSecret revokes the access to a secret that was granted over the
+// given relation. If relation is nil, all access granted to other
+// applications is revoked.
+func (c *Client) RevokeSecret(id string, relation *names.RelationTag) error {
+	arg := params.RevokeSecretArg{ID: id}
+	if relation != nil {
+		arg.RelationTag = relation.String()
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("RevokeSecrets", params.RevokeSecretArgs{
+		Args: []params.RevokeSecretArg{arg},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
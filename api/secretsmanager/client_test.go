@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secretsmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) newClient(c *gc.C, stub *testing.Stub, result interface{}) *secretsmanager.Client {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		switch response := response.(type) {
+		case *params.StringResults:
+			*response = result.(params.StringResults)
+		case *params.SecretValueResults:
+			*response = result.(params.SecretValueResults)
+		case *params.ErrorResults:
+			*response = result.(params.ErrorResults)
+		}
+		return stub.NextErr()
+	})
+	return secretsmanager.NewClient(apiCaller)
+}
+
+func (s *ClientSuite) TestCreateSecret(c *gc.C) {
+	var stub testing.Stub
+	client := s.newClient(c, &stub, params.StringResults{
+		Results: []params.StringResult{{Result: "secret:mysql/password"}},
+	})
+	relation := names.NewRelationTag("wordpress:db mysql:server")
+	id, err := client.CreateSecret("password", "root password", secrets.Value{"password": "s3cret"}, relation)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "secret:mysql/password")
+	stub.CheckCalls(c, []testing.StubCall{{"CreateSecrets", []interface{}{params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			Name:         "password",
+			Description:  "root password",
+			Data:         map[string]string{"password": "s3cret"},
+			RelationTags: []string{relation.String()},
+		}},
+	}}}})
+}
+
+func (s *ClientSuite) TestGetSecretValue(c *gc.C) {
+	var stub testing.Stub
+	client := s.newClient(c, &stub, params.SecretValueResults{
+		Results: []params.SecretValueResult{{Data: map[string]string{"password": "s3cret"}}},
+	})
+	value, err := client.GetSecretValue("secret:mysql/password", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, secrets.Value{"password": "s3cret"})
+	stub.CheckCalls(c, []testing.StubCall{{"GetSecretValues", []interface{}{params.GetSecretArgs{
+		Args: []params.GetSecretArg{{ID: "secret:mysql/password", Revision: 2}},
+	}}}})
+}
+
+func (s *ClientSuite) TestGetSecretValueError(c *gc.C) {
+	var stub testing.Stub
+	client := s.newClient(c, &stub, params.SecretValueResults{
+		Results: []params.SecretValueResult{{Error: &params.Error{
+			Message: "permission denied",
+			Code:    params.CodeUnauthorized,
+		}}},
+	})
+	_, err := client.GetSecretValue("secret:mysql/password", 0)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(params.IsCodeUnauthorized(err), jc.IsTrue)
+}
+
+func (s *ClientSuite) TestRevokeSecret(c *gc.C) {
+	var stub testing.Stub
+	client := s.newClient(c, &stub, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	relation := names.NewRelationTag("wordpress:db mysql:server")
+	err := client.RevokeSecret("secret:mysql/password", &relation)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.RevokeSecret("secret:mysql/password", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{
+		{"RevokeSecrets", []interface{}{params.RevokeSecretArgs{
+			Args: []params.RevokeSecretArg{{ID: "secret:mysql/password", RelationTag: relation.String()}},
+		}}},
+		{"RevokeSecrets", []interface{}{params.RevokeSecretArgs{
+			Args: []params.RevokeSecretArg{{ID: "secret:mysql/password"}},
+		}}},
+	})
+}
@@ -229,6 +229,106 @@ func (c *Client) Remove(storageIds []string, destroyAttachments, destroyStorage
 	return results.Results, nil
 }
 
+// Resize requests that the specified storage instance be grown to the
+// given size in MiB.
+func (c *Client) Resize(storageId string, size uint64) error {
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("resizing storage on this version of Juju")
+	}
+	args := params.ResizeStorage{[]params.ResizeStorageInstance{{
+		Tag:  names.NewStorageTag(storageId).String(),
+		Size: size,
+	}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Resize", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CreateSnapshots requests snapshots of the volumes assigned to the
+// specified storage instances. The result for each storage instance
+// holds the ID of its new snapshot.
+func (c *Client) CreateSnapshots(storageIds []string) ([]params.StringResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("snapshotting storage on this version of Juju")
+	}
+	entities := make([]params.Entity, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		entities[i].Tag = names.NewStorageTag(id).String()
+	}
+	var results params.StringResults
+	if err := c.facade.FacadeCall("CreateSnapshots", params.Entities{entities}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ListSnapshots lists the volume snapshots taken of the specified
+// storage instances. If no storage instances are specified, all
+// volume snapshots in the model are returned.
+func (c *Client) ListSnapshots(storageIds []string) ([]params.VolumeSnapshotDetails, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("snapshotting storage on this version of Juju")
+	}
+	var filter params.VolumeSnapshotFilter
+	for _, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		filter.StorageTags = append(filter.StorageTags, names.NewStorageTag(id).String())
+	}
+	args := params.VolumeSnapshotFilters{[]params.VolumeSnapshotFilter{filter}}
+	var results params.VolumeSnapshotDetailsListResults
+	if err := c.facade.FacadeCall("ListSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
+// RemoveSnapshots removes the specified volume snapshots from the
+// model, destroying them in the storage provider.
+func (c *Client) RemoveSnapshots(snapshotIds []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("snapshotting storage on this version of Juju")
+	}
+	for _, id := range snapshotIds {
+		if !storage.IsValidSnapshotId(id) {
+			return nil, errors.NotValidf("snapshot ID %q", id)
+		}
+	}
+	var results params.ErrorResults
+	args := params.VolumeSnapshotIds{Ids: snapshotIds}
+	if err := c.facade.FacadeCall("RemoveSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(snapshotIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(snapshotIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
 // Detach detaches the specified storage entities.
 func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
 	results := params.ErrorResults{}
@@ -622,6 +622,179 @@ func (s *storageMockSuite) TestRemove(c *gc.C) {
 	c.Assert(results[1].Error, jc.DeepEquals, &params.Error{Message: "baz"})
 }
 
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "Resize")
+				c.Check(a, jc.DeepEquals, params.ResizeStorage{[]params.ResizeStorageInstance{
+					{Tag: "storage-foo-0", Size: 2048},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestResizeNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "resizing storage on this version of Juju not supported")
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CreateSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "storage-foo-0"}, {Tag: "storage-bar-1"},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+				results := result.(*params.StringResults)
+				results.Results = []params.StringResult{
+					{Result: "0/0"},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.CreateSnapshots([]string{"foo/0", "bar/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StringResult{
+		{Result: "0/0"},
+		{Error: &params.Error{Message: "baz"}},
+	})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"foo/0"})
+	c.Assert(err, gc.ErrorMatches, "snapshotting storage on this version of Juju not supported")
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	details := []params.VolumeSnapshotDetails{{
+		Id:         "0/0",
+		StorageTag: "storage-foo-0",
+		VolumeTag:  "volume-0-0",
+		Pool:       "loop",
+		Size:       1024,
+		SnapshotId: "snapshot-0-0",
+		Life:       params.Alive,
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ListSnapshots")
+				c.Check(a, jc.DeepEquals, params.VolumeSnapshotFilters{[]params.VolumeSnapshotFilter{{
+					StorageTags: []string{"storage-foo-0"},
+				}}})
+				c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsListResults{})
+				results := result.(*params.VolumeSnapshotDetailsListResults)
+				results.Results = []params.VolumeSnapshotDetailsListResult{{Result: details}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	found, err := client.ListSnapshots([]string{"foo/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, details)
+}
+
+func (s *storageMockSuite) TestRemoveSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RemoveSnapshots")
+				c.Check(a, jc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"0/0", "1"}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.RemoveSnapshots([]string{"0/0", "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "baz"}},
+	})
+
+	_, err = client.RemoveSnapshots([]string{"foo/0"})
+	c.Assert(err, gc.ErrorMatches, `snapshot ID "foo/0" not valid`)
+}
+
 func (s *storageMockSuite) TestRemoveDestroyAttachments(c *gc.C) {
 	apiCaller := basetesting.BestVersionCaller{
 		APICallerFunc: basetesting.APICallerFunc(
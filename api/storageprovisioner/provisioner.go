@@ -88,6 +88,19 @@ func (st *State) WatchVolumes(scope names.Tag) (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchVolumes", scope)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the specified tag, so that the volumes which have been
+// requested to be resized may be identified.
+func (st *State) WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeResizes", scope)
+}
+
+// WatchVolumeSnapshots watches for lifecycle changes to volume snapshots
+// scoped to the entity with the specified tag.
+func (st *State) WatchVolumeSnapshots(scope names.Tag) (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeSnapshots", scope)
+}
+
 // WatchVolumes watches for lifecycle changes to volumes scoped to the
 // entity with the specified tag.
 func (st *State) WatchFilesystems(scope names.Tag) (watcher.StringsWatcher, error) {
@@ -271,6 +284,42 @@ func (st *State) RemoveVolumeParams(tags []names.VolumeTag) ([]params.RemoveVolu
 	return results.Results, nil
 }
 
+// ResizeVolumeParams returns the parameters for resizing the volumes
+// with the specified tags. The result for a volume is nil if it does
+// not need to be resized.
+func (st *State) ResizeVolumeParams(tags []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ResizeVolumeParamsResults
+	err := st.facade.FacadeCall("ResizeVolumeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking, or destroying,
+// the volume snapshots with the specified IDs.
+func (st *State) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.VolumeSnapshotParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -355,6 +404,36 @@ func (st *State) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, e
 	return results.Results, nil
 }
 
+// SetVolumeSnapshotInfo records the details of newly taken volume snapshots.
+func (st *State) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshotInfo) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshotInfos{Snapshots: snapshots}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshotInfo", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// RemoveVolumeSnapshots removes the volume snapshots with the specified
+// IDs from state. The snapshots must be Dying, and must have been
+// destroyed by the storage provider.
+func (st *State) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("RemoveVolumeSnapshots", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (st *State) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	args := params.Filesystems{Filesystems: filesystems}
@@ -70,6 +70,60 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	c.Check(callCount, gc.Equals, 1)
 }
 
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchVolumeResizes")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-123"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+		*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchVolumeSnapshots")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-123"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+		*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeSnapshots(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
 func (s *provisionerSuite) TestWatchFilesystems(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -439,6 +493,138 @@ func (s *provisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	}})
 }
 
+func (s *provisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ResizeVolumeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}, {"volume-101"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ResizeVolumeParamsResults{})
+		*(result.(*params.ResizeVolumeParamsResults)) = params.ResizeVolumeParamsResults{
+			Results: []params.ResizeVolumeParamsResult{{
+				Result: &params.ResizeVolumeParams{
+					VolumeTag: "volume-100",
+					Provider:  "foo",
+					VolumeId:  "bar",
+					Size:      2048,
+				},
+			}, {}},
+		}
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.ResizeVolumeParams([]names.VolumeTag{
+		names.NewVolumeTag("100"),
+		names.NewVolumeTag("101"),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(resizeParams, jc.DeepEquals, []params.ResizeVolumeParamsResult{{
+		Result: &params.ResizeVolumeParams{
+			VolumeTag: "volume-100",
+			Provider:  "foo",
+			VolumeId:  "bar",
+			Size:      2048,
+		},
+	}, {}})
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeSnapshotParams")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"100", "101"}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotParamsResults{})
+		*(result.(*params.VolumeSnapshotParamsResults)) = params.VolumeSnapshotParamsResults{
+			Results: []params.VolumeSnapshotParamsResult{{
+				Result: &params.VolumeSnapshotParams{
+					Id:        "100",
+					Life:      params.Alive,
+					VolumeTag: "volume-100",
+					Provider:  "foo",
+					VolumeId:  "bar",
+					Size:      2048,
+				},
+			}, {}},
+		}
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotParams, err := st.VolumeSnapshotParams([]string{"100", "101"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(snapshotParams, jc.DeepEquals, []params.VolumeSnapshotParamsResult{{
+		Result: &params.VolumeSnapshotParams{
+			Id:        "100",
+			Life:      params.Alive,
+			VolumeTag: "volume-100",
+			Provider:  "foo",
+			VolumeId:  "bar",
+			Size:      2048,
+		},
+	}, {}})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	var callCount int
+	snapshots := []params.VolumeSnapshotInfo{{
+		Id:         "100",
+		SnapshotId: "snap-100",
+		Size:       2048,
+	}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetVolumeSnapshotInfo")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotInfos{Snapshots: snapshots})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "FAIL"}}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetVolumeSnapshotInfo(snapshots)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestRemoveVolumeSnapshots(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RemoveVolumeSnapshots")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"100"}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "FAIL"}}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.RemoveVolumeSnapshots([]string{"100"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.ErrorMatches, "FAIL")
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
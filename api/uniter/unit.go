@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -457,25 +459,33 @@ func (u *Unit) AvailabilityZone() (string, error) {
 // OpenPorts sets the policy of the port range with protocol to be
 // opened.
 func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
-	var result params.ErrorResults
-	args := params.EntitiesPortRanges{
-		Entities: []params.EntityPortRange{{
-			Tag:      u.tag.String(),
-			Protocol: protocol,
-			FromPort: fromPort,
-			ToPort:   toPort,
-		}},
-	}
-	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
-	if err != nil {
-		return err
-	}
-	return result.OneError()
+	return u.OpenEndpointPorts("", protocol, fromPort, toPort)
 }
 
 // ClosePorts sets the policy of the port range with protocol to be
 // closed.
 func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
+	return u.CloseEndpointPorts("", protocol, fromPort, toPort)
+}
+
+// OpenEndpointPorts sets the policy of the port range with protocol to
+// be opened for the given endpoint. An empty endpoint opens the range
+// for all of the application's endpoints.
+func (u *Unit) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return u.changePorts("OpenPorts", endpoint, protocol, fromPort, toPort)
+}
+
+// CloseEndpointPorts sets the policy of the port range with protocol
+// opened for the given endpoint to be closed. An empty endpoint refers
+// to the range opened for all of the application's endpoints.
+func (u *Unit) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return u.changePorts("ClosePorts", endpoint, protocol, fromPort, toPort)
+}
+
+func (u *Unit) changePorts(method, endpoint, protocol string, fromPort, toPort int) error {
+	if endpoint != "" && u.st.facade.BestAPIVersion() < 12 {
+		return errors.NotSupportedf("opening or closing ports for an endpoint on v%d facade", u.st.facade.BestAPIVersion())
+	}
 	var result params.ErrorResults
 	args := params.EntitiesPortRanges{
 		Entities: []params.EntityPortRange{{
@@ -483,9 +493,10 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
 			Protocol: protocol,
 			FromPort: fromPort,
 			ToPort:   toPort,
+			Endpoint: endpoint,
 		}},
 	}
-	err := u.st.facade.FacadeCall("ClosePorts", args, &result)
+	err := u.st.facade.FacadeCall(method, args, &result)
 	if err != nil {
 		return err
 	}
@@ -543,6 +554,39 @@ func (u *Unit) SetCharmURL(curl *charm.URL) error {
 	return result.OneError()
 }
 
+// TargetCharmURL returns the URL of the charm the unit should be running,
+// and whether it should upgrade to that charm even if it is in an error
+// state. This is the application's charm, unless the unit has been chosen
+// to run a charm being rolled out to the application.
+func (u *Unit) TargetCharmURL() (*charm.URL, bool, error) {
+	if u.st.facade.BestAPIVersion() < 11 {
+		// Charms can't be rolled out to units, so they all run
+		// the application's charm.
+		app := &Application{st: u.st, tag: u.ApplicationTag()}
+		return app.CharmURL()
+	}
+	var results params.StringBoolResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("TargetCharmURL", args, &results)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	curl, err := charm.ParseURL(result.Result)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return curl, result.Ok, nil
+}
+
 // ClearResolved removes any resolved setting on the unit.
 func (u *Unit) ClearResolved() error {
 	var result params.ErrorResults
@@ -814,3 +858,73 @@ func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]param
 
 	return results.Results, nil
 }
+
+// State returns the persistent charm state stored on the controller
+// for the unit.
+func (u *Unit) State() (map[string]string, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return nil, errors.NotImplementedf("unit state")
+	}
+	var results params.UnitStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("UnitState", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.State == nil {
+		return map[string]string{}, nil
+	}
+	return result.State, nil
+}
+
+// SetState replaces the persistent charm state stored on the
+// controller for the unit.
+func (u *Unit) SetState(state map[string]string) error {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return errors.NotImplementedf("unit state")
+	}
+	var results params.ErrorResults
+	args := params.SetUnitStateArgs{
+		Args: []params.SetUnitStateArg{{Tag: u.tag.String(), State: state}},
+	}
+	err := u.st.facade.FacadeCall("SetUnitState", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// HookTimeouts returns the maximum time a hook may run for the unit
+// before it is killed, and how long a hook may run before it is
+// reported as long-running. A zero timeout means hooks are never
+// timed out.
+func (u *Unit) HookTimeouts() (timeout, warningThreshold time.Duration, _ error) {
+	if u.st.facade.BestAPIVersion() < 10 {
+		return 0, 0, errors.NotImplementedf("hook timeouts")
+	}
+	var results params.HookTimeoutsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("HookTimeouts", args, &results)
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, 0, result.Error
+	}
+	return result.Timeout, result.WarningThreshold, nil
+}
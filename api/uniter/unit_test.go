@@ -452,6 +452,36 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestOpenCloseEndpointPortRanges(c *gc.C) {
+	err := s.apiUnit.OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.OpenEndpointPorts("db", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := s.wordpressUnit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.DeepEquals, []network.PortRange{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80},
+	})
+
+	err = s.apiUnit.CloseEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = s.wordpressUnit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 1)
+
+	err = s.apiUnit.CloseEndpointPorts("db", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = s.wordpressUnit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 0)
+}
+
+func (s *unitSuite) TestOpenEndpointPortsUnknownEndpoint(c *gc.C) {
+	err := s.apiUnit.OpenEndpointPorts("bogus", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `.*endpoint "bogus" not valid`)
+}
+
 func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	// No charm URL set yet.
 	curl, ok := s.wordpressUnit.CharmURL()
@@ -471,6 +501,29 @@ func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	c.Assert(curl.String(), gc.Equals, s.wordpressCharm.String())
 }
 
+func (s *unitSuite) TestTargetCharmURL(c *gc.C) {
+	curl, force, err := s.apiUnit.TargetCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, gc.DeepEquals, s.wordpressCharm.URL())
+	c.Assert(force, jc.IsFalse)
+
+	newCharm := s.Factory.MakeCharm(c, &jujufactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err = s.wordpressApplication.SetCharm(state.SetCharmConfig{
+		Charm:      newCharm,
+		ForceUnits: true,
+		Units:      []string{s.wordpressUnit.Name()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl, force, err = s.apiUnit.TargetCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, gc.DeepEquals, newCharm.URL())
+	c.Assert(force, jc.IsTrue)
+}
+
 func (s *unitSuite) TestNetworkInfo(c *gc.C) {
 	var called int
 	relId := 2
@@ -899,6 +952,39 @@ func (s *unitSuite) TestAddMetricsResultError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error adding metrics")
 }
 
+func (s *unitSuite) TestState(c *gc.C) {
+	st, err := s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.HasLen, 0)
+
+	err = s.wordpressUnit.SetState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	st, err = s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *unitSuite) TestSetState(c *gc.C) {
+	err := s.apiUnit.SetState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	st, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *unitSuite) TestHookTimeouts(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"hook-timeout":           "45m",
+		"hook-warning-threshold": "15m",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	timeout, warningThreshold, err := s.apiUnit.HookTimeouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, 45*time.Minute)
+	c.Assert(warningThreshold, gc.Equals, 15*time.Minute)
+}
+
 func (s *unitSuite) TestMeterStatus(c *gc.C) {
 	uniter.PatchUnitResponse(s, s.apiUnit, "GetMeterStatus",
 		func(results interface{}) error {
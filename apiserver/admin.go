@@ -4,6 +4,7 @@
 package apiserver
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
@@ -114,7 +116,7 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 
 	// apiRoot is the API root exposed to the client after login.
 	var apiRoot rpc.Root = newAPIRoot(
-		a.root.state,
+		a.tracedState(),
 		a.root.shared,
 		a.srv.facades,
 		a.root.resources,
@@ -151,6 +153,15 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	recorderFactory := observer.NewRecorderFactory(
 		a.apiObserver, auditRecorder, auditConfig.CaptureAPIArgs)
 
+	// Controller agents connect to every model, and anonymous logins
+	// are made on behalf of other controllers, so neither is limited.
+	if !authResult.anonymousLogin && !authResult.controllerMachineLogin {
+		apiRoot, err = a.srv.entityLimiter.connect(apiRoot, a.root.modelUUID, a.root.entity.Tag())
+		if err != nil {
+			return fail, errors.Trace(err)
+		}
+	}
+
 	a.root.rpcConn.ServeRoot(apiRoot, recorderFactory, serverError)
 	return params.LoginResult{
 		Servers:       params.FromNetworkHostsPorts(hostPorts),
@@ -163,6 +174,22 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	}, nil
 }
 
+// tracedState returns the State used to serve the client's requests
+// after login. If the client is making its requests as part of a
+// trace, the State's transactions are recorded in the trace too.
+func (a *admin) tracedState() *state.State {
+	st := a.root.state
+	if a.srv.tracer == nil {
+		return st
+	}
+	sc := a.root.rpcConn.TraceContext()
+	if !sc.IsValid() {
+		return st
+	}
+	ctx := trace.ContextWithTracer(context.Background(), a.srv.tracer)
+	return st.WithTraceContext(trace.ContextWithSpanContext(ctx, sc))
+}
+
 func (a *admin) getAuditRecorder(req params.LoginRequest, authResult *authResult, cfg auditlog.Config) (*auditlog.Recorder, error) {
 	if !authResult.userLogin || !cfg.Enabled {
 		return nil, nil
@@ -35,6 +35,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/reboot"
 	"github.com/juju/juju/apiserver/facades/agent/resourceshookcontext"
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
+	"github.com/juju/juju/apiserver/facades/agent/secretsmanager"
 	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner"
 	"github.com/juju/juju/apiserver/facades/agent/unitassigner"
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
@@ -62,7 +63,9 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/modelmanager"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/payloads"
+	"github.com/juju/juju/apiserver/facades/client/quota" // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/resources"
+	"github.com/juju/juju/apiserver/facades/client/secrets"   // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/storage"
@@ -89,6 +92,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
+	"github.com/juju/juju/apiserver/facades/controller/operationrunner"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
@@ -126,7 +130,8 @@ func AllFacades() *facade.Registry {
 		}
 	}
 
-	reg("Action", 2, action.NewActionAPI)
+	reg("Action", 2, action.NewActionAPIV2)
+	reg("Action", 3, action.NewActionAPI) // adds EnqueueOperations and Operations
 	reg("ActionPruner", 1, actionpruner.NewAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
@@ -143,6 +148,9 @@ func AllFacades() *facade.Registry {
 	reg("Application", 6, application.NewFacadeV6)
 	reg("Application", 7, application.NewFacadeV7)
 	reg("Application", 8, application.NewFacadeV8)
+	reg("Application", 9, application.NewFacadeV9)   // adds CharmConfigHistory & RevertCharmConfig
+	reg("Application", 10, application.NewFacadeV10) // adds exposed endpoints to Expose
+	reg("Application", 11, application.NewFacadeV11) // adds charm rollouts
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
@@ -171,6 +179,7 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
 	reg("Controller", 5, controller.NewControllerAPIv5)
+	reg("Controller", 6, controller.NewControllerAPIv6) // Adds MigrationDryRun
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("CredentialManager", 1, credentialmanager.NewCredentialManagerAPI)
@@ -183,6 +192,7 @@ func AllFacades() *facade.Registry {
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
+	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6) // adds GetExposeInfo
 	reg("FirewallRules", 1, firewallrules.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
@@ -218,15 +228,18 @@ func AllFacades() *facade.Registry {
 	reg("MigrationFlag", 1, migrationflag.NewFacade)
 	reg("MigrationMaster", 1, migrationmaster.NewFacade)
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
-	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 1, migrationtarget.NewFacadeV1)
+	reg("MigrationTarget", 2, migrationtarget.NewFacade) // Adds ValidateImport
 
 	reg("ModelConfig", 1, modelconfig.NewFacadeV1)
 	reg("ModelConfig", 2, modelconfig.NewFacadeV2)
+	reg("ModelConfig", 3, modelconfig.NewFacadeV3)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
+	reg("OperationRunner", 1, operationrunner.NewOperationRunnerAPI)
 	reg("Payloads", 1, payloads.NewFacade)
 	regHookContext(
 		"PayloadsHookContext", 1,
@@ -243,6 +256,7 @@ func AllFacades() *facade.Registry {
 	reg("ProxyUpdater", 1, proxyupdater.NewFacadeV1)
 	reg("ProxyUpdater", 2, proxyupdater.NewFacadeV2)
 	reg("Reboot", 2, reboot.NewRebootAPI)
+	reg("Quota", 1, quota.NewQuotaAPI)
 	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPI)
 
 	reg("Resources", 1, resources.NewPublicFacade)
@@ -250,6 +264,8 @@ func AllFacades() *facade.Registry {
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
+	reg("Secrets", 1, secrets.NewSecretsAPI)
+	reg("SecretsManager", 1, secretsmanager.NewSecretsManagerAPI)
 	reg("Singular", 2, singular.NewExternalFacade)
 
 	reg("SSHClient", 1, sshclient.NewFacade)
@@ -262,9 +278,13 @@ func AllFacades() *facade.Registry {
 
 	reg("Storage", 3, storage.NewFacadeV3)
 	reg("Storage", 4, storage.NewFacadeV4) // changes Destroy() method signature.
+	reg("Storage", 5, storage.NewFacadeV5) // adds Resize.
+	reg("Storage", 6, storage.NewFacadeV6) // adds CreateSnapshots, ListSnapshots and RemoveSnapshots.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
+	reg("StorageProvisioner", 5, storageprovisioner.NewFacadeV5) // adds volume resize methods.
+	reg("StorageProvisioner", 6, storageprovisioner.NewFacadeV6) // adds volume snapshot methods.
 	reg("Subnets", 2, subnets.NewAPI)
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
@@ -273,7 +293,11 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 5, uniter.NewUniterAPIV5)
 	reg("Uniter", 6, uniter.NewUniterAPIV6)
 	reg("Uniter", 7, uniter.NewUniterAPIV7)
-	reg("Uniter", 8, uniter.NewUniterAPI)
+	reg("Uniter", 8, uniter.NewUniterAPIV8)
+	reg("Uniter", 9, uniter.NewUniterAPIV9)
+	reg("Uniter", 10, uniter.NewUniterAPIV10) // adds HookTimeouts
+	reg("Uniter", 11, uniter.NewUniterAPIV11) // adds TargetCharmURL
+	reg("Uniter", 12, uniter.NewUniterAPI)    // adds endpoint port ranges
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UpgradeSeries", 1, upgradeseries.NewAPI)
@@ -36,7 +36,9 @@ import (
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/websocket"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
@@ -62,6 +64,7 @@ type Server struct {
 	tag                    names.Tag
 	dataDir                string
 	logDir                 string
+	secretsKey             string
 	limiter                utils.Limiter
 	loginRetryPause        time.Duration
 	facades                *facade.Registry
@@ -81,6 +84,8 @@ type Server struct {
 	upgradeComplete        func() bool
 	restoreStatus          func() state.RestoreStatus
 	mux                    *apiserverhttp.Mux
+	tracer                 *trace.Tracer
+	entityLimiter          *entityLimiter
 
 	// mu guards the fields below it.
 	mu sync.Mutex
@@ -164,6 +169,25 @@ type ServerConfig struct {
 
 	// PrometheusRegisterer registers Prometheus collectors.
 	PrometheusRegisterer prometheus.Registerer
+
+	// Tracer, if non-nil, records spans for API requests that
+	// clients have made part of a trace. The server closes the
+	// tracer when it stops.
+	Tracer *trace.Tracer
+
+	// EntityLimits holds the limits placed on the API requests and
+	// connections made by each authenticated user and agent.
+	EntityLimits EntityLimitConfig
+
+	// LeaseManager is the controller-wide lease manager backed by
+	// raft. Facades use it for leadership and singular leases.
+	LeaseManager lease.Manager
+
+	// SecretsKey holds the base64-encoded key used to encrypt the
+	// secret values stored in the controller database. It is empty
+	// on controllers bootstrapped without one, in which case secrets
+	// can't be created or read.
+	SecretsKey string
 }
 
 // Validate validates the API server configuration.
@@ -198,6 +222,9 @@ func (c ServerConfig) Validate() error {
 	if c.GetAuditConfig == nil {
 		return errors.NotValidf("missing GetAuditConfig")
 	}
+	if c.LeaseManager == nil {
+		return errors.NotValidf("missing LeaseManager")
+	}
 	if err := c.RateLimitConfig.Validate(); err != nil {
 		return errors.Annotate(err, "validating rate limit configuration")
 	}
@@ -206,6 +233,9 @@ func (c ServerConfig) Validate() error {
 			return errors.Annotate(err, "validating logsink configuration")
 		}
 	}
+	if err := c.EntityLimits.Validate(); err != nil {
+		return errors.Annotate(err, "validating entity limit configuration")
+	}
 	return nil
 }
 
@@ -240,10 +270,11 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		cfg.RateLimitConfig.LoginRateLimit, cfg.RateLimitConfig.LoginMinPause,
 		cfg.RateLimitConfig.LoginMaxPause, clock.WallClock)
 	shared, err := newSharedServerContex(sharedServerConfig{
-		statePool:  cfg.StatePool,
-		centralHub: cfg.Hub,
-		presence:   cfg.Presence,
-		logger:     loggo.GetLogger("juju.apiserver"),
+		statePool:    cfg.StatePool,
+		centralHub:   cfg.Hub,
+		presence:     cfg.Presence,
+		leaseManager: cfg.LeaseManager,
+		logger:       loggo.GetLogger("juju.apiserver"),
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -256,6 +287,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		tag:                           cfg.Tag,
 		dataDir:                       cfg.DataDir,
 		logDir:                        cfg.LogDir,
+		secretsKey:                    cfg.SecretsKey,
 		limiter:                       limiter,
 		loginRetryPause:               cfg.RateLimitConfig.LoginRetryPause,
 		upgradeComplete:               cfg.UpgradeComplete,
@@ -272,6 +304,8 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 			Clock:  cfg.Clock,
 		},
 		getAuditConfig: cfg.GetAuditConfig,
+		tracer:         cfg.Tracer,
+		entityLimiter:  newEntityLimiter(cfg.EntityLimits, cfg.Clock),
 		dbloggers: dbloggers{
 			clock:                 cfg.Clock,
 			dbLoggerBufferSize:    cfg.LogSinkConfig.DBLoggerBufferSize,
@@ -310,6 +344,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 	srv.tomb.Go(func() error {
 		defer srv.dbloggers.dispose()
 		defer srv.logSinkWriter.Close()
+		defer srv.closeTracer()
 		defer srv.shared.Close()
 		defer unsubscribe()
 		return srv.loop(ready)
@@ -325,6 +360,15 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 	return srv, nil
 }
 
+func (srv *Server) closeTracer() {
+	if srv.tracer == nil {
+		return
+	}
+	if err := srv.tracer.Close(); err != nil {
+		logger.Warningf("closing tracer: %v", err)
+	}
+}
+
 type metricAdaptor struct {
 	srv *Server
 }
@@ -341,6 +385,14 @@ func (a *metricAdaptor) ConcurrentLoginAttempts() int64 {
 	return a.srv.LoginAttempts()
 }
 
+func (a *metricAdaptor) RateLimitedRequests() int64 {
+	return a.srv.entityLimiter.LimitedRequests()
+}
+
+func (a *metricAdaptor) RejectedConnections() int64 {
+	return a.srv.entityLimiter.RejectedConnections()
+}
+
 func (a *metricAdaptor) ConnectionPauseTime() time.Duration {
 	//return a.srv.lis.(*throttlingListener).pauseTime()
 	return 0 // XXX
@@ -858,6 +910,9 @@ func (srv *Server) serveConn(
 		}
 		conn.ServeRoot(newAdminRoot(h, adminAPIs), recorderFactory, serverError)
 	}
+	if srv.tracer != nil {
+		ctx = trace.ContextWithTracer(ctx, srv.tracer)
+	}
 	conn.Start(ctx)
 	select {
 	case <-conn.Dead():
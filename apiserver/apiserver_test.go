@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/apiserver/observer/fakeobserver"
 	"github.com/juju/juju/apiserver/stateauthenticator"
 	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/testserver"
 	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/presence"
 	psapiserver "github.com/juju/juju/pubsub/apiserver"
@@ -72,6 +73,10 @@ func (s *apiserverConfigFixture) SetUpTest(c *gc.C) {
 	s.tlsConfig.Certificates = []tls.Certificate{*coretesting.ServerTLSCert}
 	s.mux = apiserverhttp.NewMux()
 
+	leaseManager, err := testserver.NewLeaseManager(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, leaseManager) })
+
 	machineTag := names.NewMachineTag("0")
 	s.config = apiserver.ServerConfig{
 		StatePool:       s.StatePool,
@@ -90,6 +95,7 @@ func (s *apiserverConfigFixture) SetUpTest(c *gc.C) {
 		RestoreStatus: func() state.RestoreStatus {
 			return state.RestoreNotActive
 		},
+		LeaseManager: leaseManager,
 		RegisterIntrospectionHandlers: func(f func(path string, h http.Handler)) {
 			f("navel", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				io.WriteString(w, "gazing")
@@ -19,6 +19,8 @@ type ServerMetricsSource interface {
 	ConnectionCount() int64
 	ConcurrentLoginAttempts() int64
 	ConnectionPauseTime() time.Duration
+	RateLimitedRequests() int64
+	RejectedConnections() int64
 }
 
 // Collector is a prometheus.Collector that collects metrics based
@@ -30,6 +32,8 @@ type Collector struct {
 	connectionCountGauge     prometheus.Gauge
 	connectionPauseTimeGauge prometheus.Gauge
 	concurrentLoginsGauge    prometheus.Gauge
+	rateLimitedCounter       prometheus.Counter
+	rejectedConnCounter      prometheus.Counter
 }
 
 // NewMetricsCollector returns a new Collector.
@@ -56,6 +60,16 @@ func NewMetricsCollector(src ServerMetricsSource) *Collector {
 			Name:      "active_login_attempts",
 			Help:      "Current number of active agent login attempts",
 		}),
+		rateLimitedCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Name:      "rate_limited_requests_total",
+			Help:      "Total number of API requests rejected because a user or agent exceeded its request rate",
+		}),
+		rejectedConnCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Name:      "rejected_connections_total",
+			Help:      "Total number of API logins rejected because a user or agent had too many connections",
+		}),
 	}
 }
 
@@ -65,6 +79,8 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.connectionCountGauge.Describe(ch)
 	c.connectionPauseTimeGauge.Describe(ch)
 	c.concurrentLoginsGauge.Describe(ch)
+	c.rateLimitedCounter.Describe(ch)
+	c.rejectedConnCounter.Describe(ch)
 }
 
 // Collect is part of the prometheus.Collector interface.
@@ -81,4 +97,14 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.connectionCountGauge.Collect(ch)
 	c.connectionPauseTimeGauge.Collect(ch)
 	c.concurrentLoginsGauge.Collect(ch)
+	ch <- prometheus.MustNewConstMetric(
+		c.rateLimitedCounter.Desc(),
+		prometheus.CounterValue,
+		float64(c.src.RateLimitedRequests()),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.rejectedConnCounter.Desc(),
+		prometheus.CounterValue,
+		float64(c.src.RejectedConnections()),
+	)
 }
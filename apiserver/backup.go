@@ -25,7 +25,8 @@ var newBackups = func(st *state.State, m *state.Model) (backups.Backups, io.Clos
 		*state.Model
 	}{st, m}
 	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+	openTarget := backups.NewControllerTargetOpener(backend)
+	return backups.NewBackupsWithTargets(stor, nil, openTarget), stor
 }
 
 // backupHandler handles backup requests.
@@ -29,14 +29,15 @@ func NewControllerConfig(st state.ControllerAccessor) *ControllerConfigAPI {
 	}
 }
 
-// ControllerConfig returns the controller's configuration.
+// ControllerConfig returns the controller's configuration, without
+// any credentials it holds.
 func (s *ControllerConfigAPI) ControllerConfig() (params.ControllerConfigResult, error) {
 	result := params.ControllerConfigResult{}
 	config, err := s.st.ControllerConfig()
 	if err != nil {
 		return result, err
 	}
-	result.Config = params.ControllerConfig(config)
+	result.Config = params.ControllerConfig(config.WithoutSecrets())
 	return result, nil
 }
 
@@ -44,6 +44,8 @@ func (f *fakeControllerAccessor) ControllerConfig() (controller.Config, error) {
 		controller.CACertKey:         testing.CACert,
 		controller.APIPort:           4321,
 		controller.StatePort:         1234,
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
 	}, nil
 }
 
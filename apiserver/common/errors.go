@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/state"
 )
 
@@ -243,6 +244,8 @@ func ServerError(err error) *params.Error {
 		code = params.CodeNotImplemented
 	case state.IsIncompatibleSeriesError(err):
 		code = params.CodeIncompatibleSeries
+	case quota.IsExceeded(err):
+		code = params.CodeQuotaExceeded
 	default:
 		if err, ok := err.(*DischargeRequiredError); ok {
 			code = params.CodeDischargeRequired
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -190,6 +191,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeModelNotFound,
 	status:     http.StatusNotFound,
 	helperFunc: params.IsCodeModelNotFound,
+}, {
+	err:        &quota.ExceededError{Resource: quota.Units, Limit: 1, Used: 1, Requested: 1},
+	code:       params.CodeQuotaExceeded,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeQuotaExceeded,
 }, {
 	err:    nil,
 	code:   "",
@@ -239,6 +245,7 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeMachineHasAttachedStorage,
 			params.CodeDischargeRequired,
 			params.CodeModelNotFound,
+			params.CodeQuotaExceeded,
 			params.CodeRetry:
 			continue
 		case params.CodeOperationBlocked:
@@ -134,7 +134,7 @@ func (s *serviceStatusGetterSuite) SetUpTest(c *gc.C) {
 
 	s.getter = common.NewApplicationStatusGetter(s.State, func() (common.AuthFunc, error) {
 		return s.authFunc, nil
-	})
+	}, s.State.LeadershipChecker())
 }
 
 func (s *serviceStatusGetterSuite) TestUnauthorized(c *gc.C) {
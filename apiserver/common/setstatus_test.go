@@ -153,7 +153,7 @@ func (s *serviceStatusSetterSuite) SetUpTest(c *gc.C) {
 
 	s.setter = common.NewServiceStatusSetter(s.State, func() (common.AuthFunc, error) {
 		return s.authFunc, nil
-	})
+	}, s.State.LeadershipChecker())
 }
 
 func (s *serviceStatusSetterSuite) TestUnauthorized(c *gc.C) {
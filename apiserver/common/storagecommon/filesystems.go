@@ -27,9 +27,11 @@ func FilesystemParams(
 
 	var pool string
 	var size uint64
+	var fromSnapshot bool
 	if stateFilesystemParams, ok := f.Params(); ok {
 		pool = stateFilesystemParams.Pool
 		size = stateFilesystemParams.Size
+		fromSnapshot = stateFilesystemParams.Snapshot != ""
 	} else {
 		filesystemInfo, err := f.Info()
 		if err != nil {
@@ -56,6 +58,7 @@ func FilesystemParams(
 		cfg.Attrs(),
 		filesystemTags,
 		nil, // attachment params set by the caller
+		fromSnapshot,
 	}
 
 	volumeTag, err := f.Volume()
@@ -77,7 +80,9 @@ func FilesystemToState(v params.Filesystem) (names.FilesystemTag, state.Filesyst
 	}
 	return filesystemTag, state.FilesystemInfo{
 		v.Info.Size,
-		"", // pool is set by state
+		// The pool is set by state when the filesystem is first
+		// provisioned, and must match thereafter.
+		v.Info.Pool,
 		v.Info.FilesystemId,
 	}, nil
 }
@@ -149,8 +149,9 @@ func volumeStorageAttachmentInfo(
 		return nil, errors.Trace(err)
 	}
 	return &storage.StorageAttachmentInfo{
-		storage.StorageKindBlock,
-		devicePath,
+		Kind:     storage.StorageKindBlock,
+		Location: devicePath,
+		Size:     blockDevice.Size,
 	}, nil
 }
 
@@ -178,9 +179,16 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
+	var size uint64
+	if filesystemInfo, err := filesystem.Info(); err == nil {
+		size = filesystemInfo.Size
+	} else if !errors.IsNotProvisioned(err) {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
 	return &storage.StorageAttachmentInfo{
-		storage.StorageKindFilesystem,
-		filesystemAttachmentInfo.MountPoint,
+		Kind:     storage.StorageKindFilesystem,
+		Location: filesystemAttachmentInfo.MountPoint,
+		Size:     size,
 	}, nil
 }
 
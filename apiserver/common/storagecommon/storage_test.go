@@ -137,6 +137,7 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoMatchingBloc
 	}, {
 		DeviceName: "sdb",
 		BusAddress: s.volumeAttachment.info.BusAddress,
+		Size:       2048,
 	}}
 	info, err := storagecommon.StorageAttachmentInfo(s.st, s.st, s.st, s.storageAttachment, s.machineTag)
 	c.Assert(err, jc.ErrorIsNil)
@@ -144,6 +145,7 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoMatchingBloc
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: "/dev/sdb",
+		Size:     2048,
 	})
 }
 
@@ -220,6 +222,20 @@ func (s *FilesystemStorageAttachmentInfoSuite) TestStorageAttachmentInfo(c *gc.C
 	info, err := storagecommon.StorageAttachmentInfo(s.st, s.st, s.st, s.storageAttachment, s.hostTag)
 	c.Assert(err, jc.ErrorIsNil)
 	s.st.CheckCallNames(c, "StorageInstance", "StorageInstanceFilesystem", "FilesystemAttachment")
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindFilesystem,
+		Location: "/path/to/here",
+		Size:     1024,
+	})
+}
+
+func (s *FilesystemStorageAttachmentInfoSuite) TestStorageAttachmentInfoFilesystemNotProvisioned(c *gc.C) {
+	// A filesystem that has been attached, but whose provisioning has
+	// not been recorded, has no known size.
+	s.filesystem.info = nil
+	s.filesystemAttachment.info.MountPoint = "/path/to/here"
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.st, s.st, s.storageAttachment, s.hostTag)
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindFilesystem,
 		Location: "/path/to/here",
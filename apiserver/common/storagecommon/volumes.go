@@ -25,11 +25,12 @@ func VolumeParams(
 	registry storage.ProviderRegistry,
 ) (params.VolumeParams, error) {
 
-	var pool string
+	var pool, snapshotId string
 	var size uint64
 	if stateVolumeParams, ok := v.Params(); ok {
 		pool = stateVolumeParams.Pool
 		size = stateVolumeParams.Size
+		snapshotId = stateVolumeParams.SnapshotId
 	} else {
 		volumeInfo, err := v.Info()
 		if err != nil {
@@ -55,6 +56,7 @@ func VolumeParams(
 		cfg.Attrs(),
 		volumeTags,
 		nil, // attachment params set by the caller
+		snapshotId,
 	}, nil
 }
 
@@ -106,7 +108,9 @@ func VolumeToState(v params.Volume) (names.VolumeTag, state.VolumeInfo, error) {
 		v.Info.HardwareId,
 		v.Info.WWN,
 		v.Info.Size,
-		"", // pool is set by state
+		// The pool is set by state when the volume is first
+		// provisioned, and must match thereafter.
+		v.Info.Pool,
 		v.Info.VolumeId,
 		v.Info.Persistent,
 	}, nil
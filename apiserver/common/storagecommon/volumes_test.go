@@ -89,3 +89,20 @@ func (*volumesSuite) TestVolumeParamsStorageTags(c *gc.C) {
 		},
 	})
 }
+
+func (*volumesSuite) TestVolumeParamsSnapshot(c *gc.C) {
+	tag := names.NewVolumeTag("100")
+	p, err := storagecommon.VolumeParams(
+		&fakeVolume{tag: tag, params: &state.VolumeParams{
+			Pool: "loop", Size: 1024, Snapshot: "0/1", SnapshotId: "snapshot-0-1",
+		}},
+		nil, // StorageInstance
+		testing.ModelTag.Id(),
+		testing.ControllerTag.Id(),
+		testing.CustomModelConfig(c, nil),
+		&fakePoolManager{},
+		provider.CommonStorageProviders(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.SnapshotId, gc.Equals, "snapshot-0-1")
+}
@@ -127,3 +127,60 @@ func DefaultLogSinkConfig() LogSinkConfig {
 		RateLimitRefill:       defaultLogSinkRateLimitRefill,
 	}
 }
+
+// EntityLimitConfig holds the limits placed on the API requests and
+// connections made by each authenticated entity. The zero value
+// places no limits.
+type EntityLimitConfig struct {
+	// User holds the limits applied to each user.
+	User EntityLimits
+
+	// Agent holds the limits applied to each machine and unit agent.
+	Agent EntityLimits
+}
+
+// Validate validates the entity limit configuration.
+func (cfg EntityLimitConfig) Validate() error {
+	if err := cfg.User.Validate(); err != nil {
+		return errors.Annotate(err, "user limits")
+	}
+	if err := cfg.Agent.Validate(); err != nil {
+		return errors.Annotate(err, "agent limits")
+	}
+	return nil
+}
+
+// EntityLimits holds the limits placed on a single entity.
+type EntityLimits struct {
+	// RequestRate is the number of requests per second an entity
+	// may make once its burst allowance has been spent. Requests are
+	// not limited if it is zero.
+	RequestRate int
+
+	// RequestBurst is the number of requests an entity may make in
+	// a burst above RequestRate.
+	RequestBurst int
+
+	// MaxConnections is the number of connections an entity may
+	// have open at once. Connections are not limited if it is zero.
+	MaxConnections int
+}
+
+// Validate validates the entity limits.
+func (l EntityLimits) Validate() error {
+	if l.RequestRate < 0 {
+		return errors.NotValidf("RequestRate %d < 0", l.RequestRate)
+	}
+	if l.RequestRate > int(time.Second) {
+		// The interval between requests must be at least
+		// a nanosecond.
+		return errors.NotValidf("RequestRate %d > %d", l.RequestRate, int(time.Second))
+	}
+	if l.RequestRate > 0 && l.RequestBurst <= 0 {
+		return errors.NotValidf("RequestBurst %d <= 0", l.RequestBurst)
+	}
+	if l.MaxConnections < 0 {
+		return errors.NotValidf("MaxConnections %d < 0", l.MaxConnections)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -174,16 +175,19 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime      time.Time
+	endTime        time.Time
+	maxLines       uint
+	fromTheStart   bool
+	noTail         bool
+	backlog        uint
+	filterLevel    loggo.Level
+	includeEntity  []string
+	excludeEntity  []string
+	includeModule  []string
+	excludeModule  []string
+	includeMessage []string
+	excludeMessage []string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -239,10 +243,31 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if !params.startTime.IsZero() && endTime.Before(params.startTime) {
+			return params, errors.Errorf("end time %q is before start time", value)
+		}
+		params.endTime = endTime
+	}
+
+	for _, key := range []string{"includeMessage", "excludeMessage"} {
+		for _, value := range queryMap[key] {
+			if _, err := regexp.Compile(value); err != nil {
+				return params, errors.Errorf("%s value %q is not a valid regular expression", key, value)
+			}
+		}
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
+	params.includeMessage = queryMap["includeMessage"]
+	params.excludeMessage = queryMap["excludeMessage"]
 
 	return params, nil
 }
@@ -61,14 +61,17 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 	params := state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		StartTime:     reqParams.startTime,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		MinLevel:       reqParams.filterLevel,
+		NoTail:         reqParams.noTail,
+		StartTime:      reqParams.startTime,
+		EndTime:        reqParams.endTime,
+		InitialLines:   int(reqParams.backlog),
+		IncludeEntity:  reqParams.includeEntity,
+		ExcludeEntity:  reqParams.excludeEntity,
+		IncludeModule:  reqParams.includeModule,
+		ExcludeModule:  reqParams.excludeModule,
+		IncludeMessage: reqParams.includeMessage,
+		ExcludeMessage: reqParams.excludeMessage,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -35,6 +35,25 @@ func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
 	websockettest.AssertWebsocketClosed(c, conn)
 }
 
+func (s *debugLogDBSuite) TestBadMessagePattern(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{"includeMessage": {"foo("}})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `includeMessage value "foo\(" is not a valid regular expression`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *debugLogDBSuite) TestEndTimeBeforeStartTime(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{
+		"startTime": {"2018-09-01T12:00:00Z"},
+		"endTime":   {"2018-09-01T11:00:00Z"},
+	})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `end time "2018-09-01T11:00:00Z" is before start time`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
 func (s *debugLogDBSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL("http", nil).String()
 	apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
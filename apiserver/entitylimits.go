@@ -0,0 +1,183 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/ratelimit"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+// entityLimiter enforces the per-entity request rate and connection
+// limits. An entity's connections share a single token bucket, so
+// opening more connections does not raise its request rate.
+type entityLimiter struct {
+	config EntityLimitConfig
+	clock  clock.Clock
+
+	// limitedRequests and rejectedConnections are accessed
+	// atomically.
+	limitedRequests     int64
+	rejectedConnections int64
+
+	// mu guards entities.
+	mu       sync.Mutex
+	entities map[string]*entityUsage
+}
+
+// entityUsage records the connections and requests of a single
+// entity.
+type entityUsage struct {
+	connections int
+	bucket      *ratelimit.Bucket
+}
+
+func newEntityLimiter(config EntityLimitConfig, clock clock.Clock) *entityLimiter {
+	return &entityLimiter{
+		config:   config,
+		clock:    clock,
+		entities: make(map[string]*entityUsage),
+	}
+}
+
+// limits returns the limits that apply to the entity with the given
+// tag.
+func (l *entityLimiter) limits(tag names.Tag) EntityLimits {
+	if tag.Kind() == names.UserTagKind {
+		return l.config.User
+	}
+	return l.config.Agent
+}
+
+// entityKey returns the key under which the usage of the entity with
+// the given tag is recorded. Users are limited across all models,
+// whereas agent tags are only unique within a model.
+func entityKey(modelUUID string, tag names.Tag) string {
+	if tag.Kind() == names.UserTagKind {
+		return tag.String()
+	}
+	return modelUUID + ":" + tag.String()
+}
+
+// connect records a new connection by the entity with the given tag,
+// returning a root that limits the rate of requests made over it. An
+// error with the retry code is returned if the entity already has as
+// many connections as it is allowed.
+//
+// The connection is released when the returned root is killed.
+func (l *entityLimiter) connect(root rpc.Root, modelUUID string, tag names.Tag) (rpc.Root, error) {
+	limits := l.limits(tag)
+	if limits.RequestRate == 0 && limits.MaxConnections == 0 {
+		return root, nil
+	}
+	key := entityKey(modelUUID, tag)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	usage, ok := l.entities[key]
+	if !ok {
+		usage = &entityUsage{}
+		if limits.RequestRate > 0 {
+			usage.bucket = ratelimit.NewBucketWithClock(
+				time.Second/time.Duration(limits.RequestRate),
+				int64(limits.RequestBurst),
+				ratelimitClock{l.clock},
+			)
+		}
+	}
+	if limits.MaxConnections > 0 && usage.connections >= limits.MaxConnections {
+		atomic.AddInt64(&l.rejectedConnections, 1)
+		return nil, &params.Error{
+			Code:    params.CodeRetry,
+			Message: fmt.Sprintf("too many API connections for %s", names.ReadableString(tag)),
+		}
+	}
+	usage.connections++
+	l.entities[key] = usage
+	return &limitedRoot{
+		Root:    root,
+		limiter: l,
+		key:     key,
+		tag:     tag,
+		bucket:  usage.bucket,
+	}, nil
+}
+
+// release records that a connection made by the entity with the given
+// key has been closed.
+func (l *entityLimiter) release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	usage, ok := l.entities[key]
+	if !ok {
+		return
+	}
+	usage.connections--
+	if usage.connections <= 0 {
+		delete(l.entities, key)
+	}
+}
+
+// LimitedRequests returns the number of requests rejected because an
+// entity exceeded its request rate.
+func (l *entityLimiter) LimitedRequests() int64 {
+	return atomic.LoadInt64(&l.limitedRequests)
+}
+
+// RejectedConnections returns the number of connections rejected
+// because an entity had too many connections open.
+func (l *entityLimiter) RejectedConnections() int64 {
+	return atomic.LoadInt64(&l.rejectedConnections)
+}
+
+// limitedRoot wraps an rpc.Root so that requests are rejected with
+// the retry code once the entity's request rate has been exceeded.
+type limitedRoot struct {
+	rpc.Root
+	limiter *entityLimiter
+	key     string
+	tag     names.Tag
+	bucket  *ratelimit.Bucket
+	once    sync.Once
+}
+
+// FindMethod implements rpc.Root.
+func (r *limitedRoot) FindMethod(facadeName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	if r.bucket != nil && r.bucket.TakeAvailable(1) == 0 {
+		atomic.AddInt64(&r.limiter.limitedRequests, 1)
+		return nil, &params.Error{
+			Code:    params.CodeRetry,
+			Message: fmt.Sprintf("API request rate limit exceeded for %s", names.ReadableString(r.tag)),
+		}
+	}
+	return r.Root.FindMethod(facadeName, version, methodName)
+}
+
+// Kill implements rpc.Root. The connection is released the first
+// time the root is killed.
+func (r *limitedRoot) Kill() {
+	r.Root.Kill()
+	r.once.Do(func() {
+		r.limiter.release(r.key)
+	})
+}
+
+// ratelimitClock adapts clock.Clock to ratelimit.Clock.
+type ratelimitClock struct {
+	clock.Clock
+}
+
+// Sleep is defined by the ratelimit.Clock interface.
+func (c ratelimitClock) Sleep(d time.Duration) {
+	<-c.Clock.After(d)
+}
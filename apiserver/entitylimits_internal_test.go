@@ -0,0 +1,137 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+type entityLimiterSuite struct {
+	testing.IsolationSuite
+	clock   *testing.Clock
+	limiter *entityLimiter
+}
+
+var _ = gc.Suite(&entityLimiterSuite{})
+
+const testModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *entityLimiterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+	s.limiter = newEntityLimiter(EntityLimitConfig{
+		User:  EntityLimits{RequestRate: 10, RequestBurst: 2, MaxConnections: 2},
+		Agent: EntityLimits{RequestRate: 1, RequestBurst: 1, MaxConnections: 1},
+	}, s.clock)
+}
+
+func (s *entityLimiterSuite) connect(c *gc.C, tag names.Tag) (*stubRoot, *limitedRoot) {
+	root := &stubRoot{}
+	limited, err := s.limiter.connect(root, testModelUUID, tag)
+	c.Assert(err, jc.ErrorIsNil)
+	return root, limited.(*limitedRoot)
+}
+
+func (s *entityLimiterSuite) TestRequestRate(c *gc.C) {
+	root, limited := s.connect(c, names.NewUserTag("bob"))
+	for i := 0; i < 2; i++ {
+		_, err := limited.FindMethod("Client", 1, "FullStatus")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err := limited.FindMethod("Client", 1, "FullStatus")
+	c.Assert(err, gc.ErrorMatches, "API request rate limit exceeded for bob")
+	c.Assert(params.ErrCode(err), gc.Equals, params.CodeRetry)
+	c.Assert(s.limiter.LimitedRequests(), gc.Equals, int64(1))
+	root.CheckCallNames(c, "FindMethod", "FindMethod")
+
+	// Tokens are added back at the configured rate.
+	s.clock.Advance(100 * time.Millisecond)
+	_, err = limited.FindMethod("Client", 1, "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *entityLimiterSuite) TestRequestRateSharedBetweenConnections(c *gc.C) {
+	_, first := s.connect(c, names.NewUserTag("bob"))
+	_, second := s.connect(c, names.NewUserTag("bob"))
+	_, err := first.FindMethod("Client", 1, "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = second.FindMethod("Client", 1, "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = first.FindMethod("Client", 1, "FullStatus")
+	c.Assert(err, gc.ErrorMatches, "API request rate limit exceeded for bob")
+
+	// Other users have their own allowance.
+	_, other := s.connect(c, names.NewUserTag("mary"))
+	_, err = other.FindMethod("Client", 1, "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *entityLimiterSuite) TestMaxConnections(c *gc.C) {
+	tag := names.NewMachineTag("0")
+	root, limited := s.connect(c, tag)
+
+	_, err := s.limiter.connect(&stubRoot{}, testModelUUID, tag)
+	c.Assert(err, gc.ErrorMatches, "too many API connections for machine 0")
+	c.Assert(params.ErrCode(err), gc.Equals, params.CodeRetry)
+	c.Assert(s.limiter.RejectedConnections(), gc.Equals, int64(1))
+
+	// The same machine in another model is a different agent.
+	_, err = s.limiter.connect(&stubRoot{}, "another-model", tag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Killing the root releases the connection, however many times
+	// it is killed.
+	limited.Kill()
+	limited.Kill()
+	root.CheckCallNames(c, "Kill", "Kill")
+	s.connect(c, tag)
+	_, err = s.limiter.connect(&stubRoot{}, testModelUUID, tag)
+	c.Assert(err, gc.ErrorMatches, "too many API connections for machine 0")
+}
+
+func (s *entityLimiterSuite) TestNoLimits(c *gc.C) {
+	limiter := newEntityLimiter(EntityLimitConfig{}, s.clock)
+	root := &stubRoot{}
+	for i := 0; i < 10; i++ {
+		limited, err := limiter.connect(root, testModelUUID, names.NewUnitTag("mysql/0"))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(limited, gc.Equals, root)
+	}
+}
+
+func (s *entityLimiterSuite) TestValidate(c *gc.C) {
+	err := EntityLimitConfig{
+		User: EntityLimits{RequestRate: 10},
+	}.Validate()
+	c.Assert(err, gc.ErrorMatches, "user limits: RequestBurst 0 <= 0 not valid")
+	err = EntityLimitConfig{
+		Agent: EntityLimits{MaxConnections: -1},
+	}.Validate()
+	c.Assert(err, gc.ErrorMatches, "agent limits: MaxConnections -1 < 0 not valid")
+	err = EntityLimitConfig{
+		User: EntityLimits{RequestRate: 2000000000, RequestBurst: 1},
+	}.Validate()
+	c.Assert(err, gc.ErrorMatches, "user limits: RequestRate 2000000000 > 1000000000 not valid")
+}
+
+type stubRoot struct {
+	testing.Stub
+}
+
+func (r *stubRoot) FindMethod(facadeName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	r.AddCall("FindMethod", facadeName, version, methodName)
+	return nil, r.NextErr()
+}
+
+func (r *stubRoot) Kill() {
+	r.AddCall("Kill")
+}
@@ -4,7 +4,11 @@
 package facadetest
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/state"
 )
 
@@ -17,6 +21,13 @@ type Context struct {
 	State_     *state.State
 	StatePool_ *state.StatePool
 	ID_        string
+
+	LeadershipClaimer_      leadership.Claimer
+	ModelLeadershipClaimer_ func(modelUUID string) (leadership.Claimer, error)
+	LeadershipChecker_      leadership.Checker
+	ApplicationLeaders_     map[string]string
+	SingularClaimer_        lease.Claimer
+
 	// Identity is not part of the facade.Context interface, but is instead
 	// used to make sure that the context objects are the same.
 	Identity string
@@ -52,6 +63,57 @@ func (context Context) StatePool() *state.StatePool {
 	return context.StatePool_
 }
 
+// LeadershipClaimer is part of the facade.Context interface. If
+// LeadershipClaimer_ isn't set, the state's claimer is used.
+func (context Context) LeadershipClaimer() leadership.Claimer {
+	if context.LeadershipClaimer_ != nil {
+		return context.LeadershipClaimer_
+	}
+	return context.State_.LeadershipClaimer()
+}
+
+// ModelLeadershipClaimer is part of the facade.Context interface. If
+// ModelLeadershipClaimer_ isn't set, the claimer of the model's state
+// in StatePool_ is used.
+func (context Context) ModelLeadershipClaimer(modelUUID string) (leadership.Claimer, error) {
+	if context.ModelLeadershipClaimer_ != nil {
+		return context.ModelLeadershipClaimer_(modelUUID)
+	}
+	st, err := context.StatePool_.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+	return st.LeadershipClaimer(), nil
+}
+
+// LeadershipChecker is part of the facade.Context interface. If
+// LeadershipChecker_ isn't set, the state's checker is used.
+func (context Context) LeadershipChecker() leadership.Checker {
+	if context.LeadershipChecker_ != nil {
+		return context.LeadershipChecker_
+	}
+	return context.State_.LeadershipChecker()
+}
+
+// ApplicationLeaders is part of the facade.Context interface. If
+// ApplicationLeaders_ isn't set, the state's leaders are returned.
+func (context Context) ApplicationLeaders() (map[string]string, error) {
+	if context.ApplicationLeaders_ != nil {
+		return context.ApplicationLeaders_, nil
+	}
+	return context.State_.ApplicationLeaders()
+}
+
+// SingularClaimer is part of the facade.Context interface. If
+// SingularClaimer_ isn't set, the state's claimer is used.
+func (context Context) SingularClaimer() lease.Claimer {
+	if context.SingularClaimer_ != nil {
+		return context.SingularClaimer_
+	}
+	return context.State_.SingularClaimer()
+}
+
 // ID is part of the facade.Context interface.
 func (context Context) ID() string {
 	return context.ID_
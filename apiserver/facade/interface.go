@@ -6,6 +6,8 @@ package facade
 import (
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
@@ -70,6 +72,27 @@ type Context interface {
 	// At least at this stage, facades only need to publish events.
 	Hub() Hub
 
+	// LeadershipClaimer returns a leadership.Claimer for this
+	// context's model, backed by the controller's lease manager.
+	LeadershipClaimer() leadership.Claimer
+
+	// ModelLeadershipClaimer returns a leadership.Claimer for the
+	// model with the given UUID, backed by the controller's lease
+	// manager.
+	ModelLeadershipClaimer(modelUUID string) (leadership.Claimer, error)
+
+	// LeadershipChecker returns a leadership.Checker for this
+	// context's model, backed by the controller's lease manager.
+	LeadershipChecker() leadership.Checker
+
+	// ApplicationLeaders returns the leader unit of each application
+	// in this context's model.
+	ApplicationLeaders() (map[string]string, error)
+
+	// SingularClaimer returns a lease.Claimer for singular leases
+	// in this context's model.
+	SingularClaimer() lease.Claimer
+
 	// ID returns a string that should almost always be "", unless
 	// this is a watcher facade, in which case it exists in lieu of
 	// actual arguments in the Next() call, and is used as a key
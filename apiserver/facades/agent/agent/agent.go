@@ -123,6 +123,11 @@ func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err e
 		SharedSecret:   info.SharedSecret,
 		SystemIdentity: info.SystemIdentity,
 	}
+	// The secrets key isn't stored in the database, so new
+	// controllers are given the key this API server holds.
+	if secretsKey, ok := api.resources.Get("secretsKey").(common.StringResource); ok {
+		result.SecretsKey = secretsKey.String()
+	}
 
 	return result, nil
 }
@@ -276,3 +276,17 @@ func (s *agentSuite) TestWatchAuthError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 }
+
+func (s *agentSuite) TestStateServingInfoSecretsKey(c *gc.C) {
+	err := s.resources.RegisterNamed("secretsKey", common.StringResource("c2VjcmV0cy1rZXk="))
+	c.Assert(err, jc.ErrorIsNil)
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:        s.machine0.Tag(),
+		Controller: true,
+	}
+	api, err := agent.NewAgentAPIV2(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := api.StateServingInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.SecretsKey, gc.Equals, "c2VjcmV0cy1rZXk=")
+}
@@ -34,7 +34,7 @@ const (
 // NewLeadershipServiceFacade constructs a new LeadershipService and presents
 // a signature that can be used for facade registration.
 func NewLeadershipServiceFacade(context facade.Context) (LeadershipService, error) {
-	return NewLeadershipService(context.State().LeadershipClaimer(), context.Auth())
+	return NewLeadershipService(context.LeadershipClaimer(), context.Auth())
 }
 
 // NewLeadershipService constructs a new LeadershipService.
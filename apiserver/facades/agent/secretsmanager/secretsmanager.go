@@ -0,0 +1,166 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secretsmanager implements the API facade used by unit agents
+// to create, read and share secrets.
+package secretsmanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+)
+
+// SecretsBackend defines the state functionality used by the
+// secretsmanager facade.
+type SecretsBackend interface {
+	CreateSecret(state.CreateSecretParams) (*secrets.Metadata, error)
+	UpdateSecret(id string, value secrets.Value) (*secrets.Metadata, error)
+	Secret(id string) (*secrets.Metadata, error)
+	SecretValue(id string, revision int) (secrets.Value, error)
+	GrantSecretAccess(id, relationKey string) error
+	RevokeSecretAccess(id, relationKey string) error
+}
+
+// SecretsManagerAPI is the implementation of the SecretsManager facade.
+type SecretsManagerAPI struct {
+	backend     SecretsBackend
+	application string
+}
+
+// NewSecretsManagerAPI creates a SecretsManagerAPI that stores secret
+// values in the controller database.
+func NewSecretsManagerAPI(context facade.Context) (*SecretsManagerAPI, error) {
+	st := context.State()
+	secretsKey, _ := context.Resources().Get("secretsKey").(common.StringResource)
+	backend := state.NewSecrets(st, state.NewControllerSecretsBackend(st, secretsKey.String()))
+	return NewAPI(backend, context.Auth())
+}
+
+// NewAPI creates a SecretsManagerAPI using the given backend.
+func NewAPI(backend SecretsBackend, authorizer facade.Authorizer) (*SecretsManagerAPI, error) {
+	if !authorizer.AuthUnitAgent() {
+		return nil, common.ErrPerm
+	}
+	application, err := names.UnitApplication(authorizer.GetAuthTag().Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SecretsManagerAPI{
+		backend:     backend,
+		application: application,
+	}, nil
+}
+
+// CreateSecrets creates secrets owned by the calling unit's
+// application, or adds new revisions to them if they already exist,
+// and grants access to them over the given relations. The IDs of the
+// secrets are returned.
+func (s *SecretsManagerAPI) CreateSecrets(args params.CreateSecretArgs) (params.StringResults, error) {
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		id, err := s.createSecret(arg)
+		results.Results[i].Result = id
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (s *SecretsManagerAPI) createSecret(arg params.CreateSecretArg) (string, error) {
+	relationKeys := make([]string, len(arg.RelationTags))
+	for i, tagString := range arg.RelationTags {
+		tag, err := names.ParseRelationTag(tagString)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		relationKeys[i] = tag.Id()
+	}
+	md, err := s.backend.CreateSecret(state.CreateSecretParams{
+		Owner:       s.application,
+		Name:        arg.Name,
+		Description: arg.Description,
+		Value:       arg.Data,
+	})
+	if errors.IsAlreadyExists(err) {
+		md, err = s.backend.UpdateSecret(secrets.NewID(s.application, arg.Name), arg.Data)
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, key := range relationKeys {
+		if err := s.backend.GrantSecretAccess(md.ID, key); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	return md.ID, nil
+}
+
+// GetSecretValues returns the values of the given secrets. The calling
+// unit's application must own the secrets, or have been granted access
+// to them.
+func (s *SecretsManagerAPI) GetSecretValues(args params.GetSecretArgs) (params.SecretValueResults, error) {
+	results := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		value, err := s.getSecretValue(arg)
+		results.Results[i].Data = value
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (s *SecretsManagerAPI) getSecretValue(arg params.GetSecretArg) (secrets.Value, error) {
+	md, err := s.backend.Secret(arg.ID)
+	if errors.IsNotFound(err) {
+		// Don't reveal the existence of secrets that the
+		// caller cannot read.
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !md.CanRead(s.application) {
+		return nil, common.ErrPerm
+	}
+	return s.backend.SecretValue(arg.ID, arg.Revision)
+}
+
+// RevokeSecrets revokes access to secrets owned by the calling unit's
+// application.
+func (s *SecretsManagerAPI) RevokeSecrets(args params.RevokeSecretArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		results.Results[i].Error = common.ServerError(s.revokeSecret(arg))
+	}
+	return results, nil
+}
+
+func (s *SecretsManagerAPI) revokeSecret(arg params.RevokeSecretArg) error {
+	var relationKey string
+	if arg.RelationTag != "" {
+		tag, err := names.ParseRelationTag(arg.RelationTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		relationKey = tag.Id()
+	}
+	md, err := s.backend.Secret(arg.ID)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if md.Owner != s.application {
+		return common.ErrPerm
+	}
+	return s.backend.RevokeSecretAccess(arg.ID, relationKey)
+}
@@ -0,0 +1,199 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/secretsmanager"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+)
+
+type SecretsManagerSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockSecretsBackend
+	authorizer apiservertesting.FakeAuthorizer
+	facade     *secretsmanager.SecretsManagerAPI
+}
+
+var _ = gc.Suite(&SecretsManagerSuite{})
+
+func (s *SecretsManagerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockSecretsBackend{
+		secrets: map[string]*secrets.Metadata{
+			"secret:mysql/password": {
+				ID:       "secret:mysql/password",
+				Owner:    "mysql",
+				Name:     "password",
+				Revision: 1,
+				Consumers: []secrets.Consumer{{
+					Application: "wordpress",
+					RelationKey: "wordpress:db mysql:server",
+				}},
+			},
+			"secret:mediawiki/key": {
+				ID:       "secret:mediawiki/key",
+				Owner:    "mediawiki",
+				Name:     "key",
+				Revision: 1,
+			},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: names.NewUnitTag("mysql/0")}
+	facade, err := secretsmanager.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *SecretsManagerSuite) TestNewAPIRequiresUnitAgent(c *gc.C) {
+	_, err := secretsmanager.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *SecretsManagerSuite) TestCreateSecrets(c *gc.C) {
+	results, err := s.facade.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			Name:         "api-key",
+			Description:  "an api key",
+			Data:         map[string]string{"key": "abc"},
+			RelationTags: []string{names.NewRelationTag("wordpress:db mysql:server").String()},
+		}, {
+			Name: "password",
+			Data: map[string]string{"password": "n3w"},
+		}, {
+			Name:         "bad",
+			Data:         map[string]string{"a": "b"},
+			RelationTags: []string{"machine-0"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.StringResult{Result: "secret:mysql/api-key"})
+	c.Assert(results.Results[1], jc.DeepEquals, params.StringResult{Result: "secret:mysql/password"})
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid relation tag`)
+
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"CreateSecret", []interface{}{state.CreateSecretParams{
+			Owner:       "mysql",
+			Name:        "api-key",
+			Description: "an api key",
+			Value:       secrets.Value{"key": "abc"},
+		}}},
+		{"GrantSecretAccess", []interface{}{"secret:mysql/api-key", "wordpress:db mysql:server"}},
+		{"CreateSecret", []interface{}{state.CreateSecretParams{
+			Owner: "mysql",
+			Name:  "password",
+			Value: secrets.Value{"password": "n3w"},
+		}}},
+		{"UpdateSecret", []interface{}{"secret:mysql/password", secrets.Value{"password": "n3w"}}},
+	})
+}
+
+func (s *SecretsManagerSuite) TestGetSecretValues(c *gc.C) {
+	results, err := s.facade.GetSecretValues(params.GetSecretArgs{
+		Args: []params.GetSecretArg{
+			{ID: "secret:mysql/password"},
+			{ID: "secret:mediawiki/key"},
+			{ID: "secret:mysql/missing"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.SecretValueResults{
+		Results: []params.SecretValueResult{
+			{Data: map[string]string{"value": "secret:mysql/password#0"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *SecretsManagerSuite) TestGetSecretValuesConsumer(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("wordpress/1")
+	facade, err := secretsmanager.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := facade.GetSecretValues(params.GetSecretArgs{
+		Args: []params.GetSecretArg{{ID: "secret:mysql/password", Revision: 1}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Data, jc.DeepEquals, map[string]string{"value": "secret:mysql/password#1"})
+}
+
+func (s *SecretsManagerSuite) TestRevokeSecrets(c *gc.C) {
+	results, err := s.facade.RevokeSecrets(params.RevokeSecretArgs{
+		Args: []params.RevokeSecretArg{
+			{ID: "secret:mysql/password", RelationTag: names.NewRelationTag("wordpress:db mysql:server").String()},
+			{ID: "secret:mysql/password"},
+			{ID: "secret:mediawiki/key"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.CheckCallNames(c, "Secret", "RevokeSecretAccess", "Secret", "RevokeSecretAccess", "Secret")
+	s.backend.CheckCall(c, 1, "RevokeSecretAccess", "secret:mysql/password", "wordpress:db mysql:server")
+	s.backend.CheckCall(c, 3, "RevokeSecretAccess", "secret:mysql/password", "")
+}
+
+type mockSecretsBackend struct {
+	testing.Stub
+	secrets map[string]*secrets.Metadata
+}
+
+func (b *mockSecretsBackend) CreateSecret(p state.CreateSecretParams) (*secrets.Metadata, error) {
+	b.AddCall("CreateSecret", p)
+	id := secrets.NewID(p.Owner, p.Name)
+	if _, ok := b.secrets[id]; ok {
+		return nil, errors.AlreadyExistsf("secret %q", id)
+	}
+	return &secrets.Metadata{ID: id, Owner: p.Owner, Name: p.Name, Revision: 1}, b.NextErr()
+}
+
+func (b *mockSecretsBackend) UpdateSecret(id string, value secrets.Value) (*secrets.Metadata, error) {
+	b.AddCall("UpdateSecret", id, value)
+	return b.secrets[id], b.NextErr()
+}
+
+func (b *mockSecretsBackend) Secret(id string) (*secrets.Metadata, error) {
+	b.AddCall("Secret", id)
+	md, ok := b.secrets[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	return md, b.NextErr()
+}
+
+func (b *mockSecretsBackend) SecretValue(id string, revision int) (secrets.Value, error) {
+	b.AddCall("SecretValue", id, revision)
+	return secrets.Value{"value": fmt.Sprintf("%s#%d", id, revision)}, b.NextErr()
+}
+
+func (b *mockSecretsBackend) GrantSecretAccess(id, relationKey string) error {
+	b.AddCall("GrantSecretAccess", id, relationKey)
+	return b.NextErr()
+}
+
+func (b *mockSecretsBackend) RevokeSecretAccess(id, relationKey string) error {
+	b.AddCall("RevokeSecretAccess", id, relationKey)
+	return b.NextErr()
+}
@@ -13,6 +13,7 @@ import (
 // filesystem watchers to use.
 type Backend interface {
 	Filesystem(names.FilesystemTag) (state.Filesystem, error)
+	FilesystemAttachment(names.Tag, names.FilesystemTag) (state.FilesystemAttachment, error)
 	VolumeAttachment(names.Tag, names.VolumeTag) (state.VolumeAttachment, error)
 	WatchMachineFilesystems(names.MachineTag) state.StringsWatcher
 	WatchUnitFilesystems(tag names.ApplicationTag) state.StringsWatcher
@@ -21,6 +21,7 @@ type mockBackend struct {
 	modelVolumeAttachmentsW       *watchertest.StringsWatcher
 
 	filesystems               map[string]*mockFilesystem
+	filesystemAttachments     map[string]*mockFilesystemAttachment
 	volumeAttachments         map[string]*mockVolumeAttachment
 	volumeAttachmentRequested chan names.VolumeTag
 }
@@ -32,6 +33,19 @@ func (b *mockBackend) Filesystem(tag names.FilesystemTag) (state.Filesystem, err
 	return nil, errors.NotFoundf("filesystem %s", tag.Id())
 }
 
+func (b *mockBackend) FilesystemAttachment(host names.Tag, f names.FilesystemTag) (state.FilesystemAttachment, error) {
+	if host.Id() != "0" {
+		// The tests all operate on host "0", and the watchers
+		// should ignore attachments for other machines, so we
+		// should never get here.
+		return nil, errors.Errorf("should not get here, unexpected host %v", host)
+	}
+	if a, ok := b.filesystemAttachments[f.Id()]; ok {
+		return a, nil
+	}
+	return nil, errors.NotFoundf("attachment for filesystem %s to host %s", f.Id(), host.Id())
+}
+
 func (b *mockBackend) VolumeAttachment(host names.Tag, v names.VolumeTag) (state.VolumeAttachment, error) {
 	if host.Id() != "0" && host.Id() != "mariadb/0" {
 		// The tests all operate on host "0" or "mariadb/0", and the watchers
@@ -88,7 +102,12 @@ func newStringsWatcher() *watchertest.StringsWatcher {
 
 type mockFilesystem struct {
 	state.Filesystem
-	volume names.VolumeTag
+	volume      names.VolumeTag
+	hostManaged bool
+}
+
+func (f *mockFilesystem) HostManaged() bool {
+	return f.hostManaged
 }
 
 func (f *mockFilesystem) Volume() (names.VolumeTag, error) {
@@ -98,6 +117,15 @@ func (f *mockFilesystem) Volume() (names.VolumeTag, error) {
 	return f.volume, nil
 }
 
+type mockFilesystemAttachment struct {
+	state.FilesystemAttachment
+	life state.Life
+}
+
+func (a *mockFilesystemAttachment) Life() state.Life {
+	return a.life
+}
+
 type mockVolumeAttachment struct {
 	state.VolumeAttachment
 	life state.Life
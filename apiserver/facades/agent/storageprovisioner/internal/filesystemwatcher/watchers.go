@@ -18,40 +18,53 @@ import (
 // of the storageprovisioner worker. The model-level storageprovisioner watches
 // model-scoped filesystems that have no backing volume. The host-level worker
 // watches both host-scoped filesystems, and model-scoped filesystems whose
-// backing volumes are attached to the host.
+// backing volumes are attached to the host. Host-managed (shared) filesystems
+// are watched by the host-level workers of each host they are attached to.
 type Watchers struct {
 	Backend Backend
 }
 
 // WatchModelManagedFilesystems returns a strings watcher that reports
-// model-scoped filesystems that have no backing volume. Volume-backed
-// filesystems are always managed by the host to which they are attached.
+// model-scoped filesystems that have no backing volume, and are not
+// host-managed. Volume-backed filesystems are always managed by the host
+// to which they are attached.
 func (fw Watchers) WatchModelManagedFilesystems() state.StringsWatcher {
 	return newFilteredStringsWatcher(fw.Backend.WatchModelFilesystems(), func(id string) (bool, error) {
-		f, err := fw.Backend.Filesystem(names.NewFilesystemTag(id))
-		if errors.IsNotFound(err) {
-			return false, nil
-		} else if err != nil {
-			return false, errors.Trace(err)
-		}
-		_, err = f.Volume()
-		return err == state.ErrNoBackingVolume, nil
+		return fw.modelManaged(names.NewFilesystemTag(id))
 	})
 }
 
+// modelManaged reports whether or not the filesystem with the
+// specified tag is managed by the model-level storageprovisioner.
+func (fw Watchers) modelManaged(tag names.FilesystemTag) (bool, error) {
+	f, err := fw.Backend.Filesystem(tag)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if f.HostManaged() {
+		return false, nil
+	}
+	_, err = f.Volume()
+	return err == state.ErrNoBackingVolume, nil
+}
+
 // WatchUnitManagedFilesystems returns a strings watcher that reports both
 // unit-scoped filesystems, and model-scoped, volume-backed filesystems
 // that are attached to units of the specified application.
 func (fw Watchers) WatchUnitManagedFilesystems(app names.ApplicationTag) state.StringsWatcher {
 	w := &hostFilesystemsWatcher{
-		stringsWatcherBase:     stringsWatcherBase{out: make(chan []string)},
-		backend:                fw.Backend,
-		changes:                set.NewStrings(),
-		hostFilesystems:        fw.Backend.WatchUnitFilesystems(app),
-		modelFilesystems:       fw.Backend.WatchModelFilesystems(),
-		modelVolumeAttachments: fw.Backend.WatchModelVolumeAttachments(),
-		modelVolumesAttached:   names.NewSet(),
-		modelVolumeFilesystems: make(map[names.VolumeTag]names.FilesystemTag),
+		stringsWatcherBase:         stringsWatcherBase{out: make(chan []string)},
+		backend:                    fw.Backend,
+		changes:                    set.NewStrings(),
+		hostFilesystems:            fw.Backend.WatchUnitFilesystems(app),
+		modelFilesystems:           fw.Backend.WatchModelFilesystems(),
+		modelFilesystemAttachments: fw.Backend.WatchModelFilesystemAttachments(),
+		modelVolumeAttachments:     fw.Backend.WatchModelVolumeAttachments(),
+		modelVolumesAttached:       names.NewSet(),
+		modelVolumeFilesystems:     make(map[names.VolumeTag]names.FilesystemTag),
+		hostManagedAttached:        names.NewSet(),
 		hostMatch: func(tag names.Tag) (bool, error) {
 			entity, err := names.UnitApplication(tag.Id())
 			if err != nil {
@@ -63,25 +76,28 @@ func (fw Watchers) WatchUnitManagedFilesystems(app names.ApplicationTag) state.S
 	w.tomb.Go(func() error {
 		defer watcher.Stop(w.hostFilesystems, &w.tomb)
 		defer watcher.Stop(w.modelFilesystems, &w.tomb)
+		defer watcher.Stop(w.modelFilesystemAttachments, &w.tomb)
 		defer watcher.Stop(w.modelVolumeAttachments, &w.tomb)
 		return w.loop()
 	})
 	return w
 }
 
-// WatchMachineManagedFilesystems returns a strings watcher that reports both
-// machine-scoped filesystems, and model-scoped, volume-backed filesystems
-// that are attached to the specified machine.
+// WatchMachineManagedFilesystems returns a strings watcher that reports
+// machine-scoped filesystems, and model-scoped, volume-backed or host-managed
+// filesystems that are attached to the specified machine.
 func (fw Watchers) WatchMachineManagedFilesystems(m names.MachineTag) state.StringsWatcher {
 	w := &hostFilesystemsWatcher{
-		stringsWatcherBase:     stringsWatcherBase{out: make(chan []string)},
-		backend:                fw.Backend,
-		changes:                set.NewStrings(),
-		hostFilesystems:        fw.Backend.WatchMachineFilesystems(m),
-		modelFilesystems:       fw.Backend.WatchModelFilesystems(),
-		modelVolumeAttachments: fw.Backend.WatchModelVolumeAttachments(),
-		modelVolumesAttached:   names.NewSet(),
-		modelVolumeFilesystems: make(map[names.VolumeTag]names.FilesystemTag),
+		stringsWatcherBase:         stringsWatcherBase{out: make(chan []string)},
+		backend:                    fw.Backend,
+		changes:                    set.NewStrings(),
+		hostFilesystems:            fw.Backend.WatchMachineFilesystems(m),
+		modelFilesystems:           fw.Backend.WatchModelFilesystems(),
+		modelFilesystemAttachments: fw.Backend.WatchModelFilesystemAttachments(),
+		modelVolumeAttachments:     fw.Backend.WatchModelVolumeAttachments(),
+		modelVolumesAttached:       names.NewSet(),
+		modelVolumeFilesystems:     make(map[names.VolumeTag]names.FilesystemTag),
+		hostManagedAttached:        names.NewSet(),
 		hostMatch: func(tag names.Tag) (bool, error) {
 			return tag == m, nil
 		},
@@ -89,14 +105,15 @@ func (fw Watchers) WatchMachineManagedFilesystems(m names.MachineTag) state.Stri
 	w.tomb.Go(func() error {
 		defer watcher.Stop(w.hostFilesystems, &w.tomb)
 		defer watcher.Stop(w.modelFilesystems, &w.tomb)
+		defer watcher.Stop(w.modelFilesystemAttachments, &w.tomb)
 		defer watcher.Stop(w.modelVolumeAttachments, &w.tomb)
 		return w.loop()
 	})
 	return w
 }
 
-// hostFilesystemsWatcher is a strings watcher that reports both
-// host-scoped filesystems, and model-scoped, volume-backed filesystems
+// hostFilesystemsWatcher is a strings watcher that reports host-scoped
+// filesystems, and model-scoped, volume-backed or host-managed filesystems
 // that are attached to the specified host.
 //
 // NOTE(axw) we use the existence of the *volume* attachment rather than
@@ -104,14 +121,16 @@ func (fw Watchers) WatchMachineManagedFilesystems(m names.MachineTag) state.Stri
 // before the filesystem, but the volume attachment cannot.
 type hostFilesystemsWatcher struct {
 	stringsWatcherBase
-	changes                set.Strings
-	backend                Backend
-	hostFilesystems        state.StringsWatcher
-	modelFilesystems       state.StringsWatcher
-	modelVolumeAttachments state.StringsWatcher
-	modelVolumesAttached   names.Set
-	modelVolumeFilesystems map[names.VolumeTag]names.FilesystemTag
-	hostMatch              func(names.Tag) (bool, error)
+	changes                    set.Strings
+	backend                    Backend
+	hostFilesystems            state.StringsWatcher
+	modelFilesystems           state.StringsWatcher
+	modelFilesystemAttachments state.StringsWatcher
+	modelVolumeAttachments     state.StringsWatcher
+	modelVolumesAttached       names.Set
+	modelVolumeFilesystems     map[names.VolumeTag]names.FilesystemTag
+	hostManagedAttached        names.Set
+	hostMatch                  func(names.Tag) (bool, error)
 }
 
 func (w *hostFilesystemsWatcher) loop() error {
@@ -119,6 +138,7 @@ func (w *hostFilesystemsWatcher) loop() error {
 	var out chan<- []string
 	var hostFilesystemsReceived bool
 	var modelFilesystemsReceived bool
+	var modelFilesystemAttachmentsReceived bool
 	var modelVolumeAttachmentsReceived bool
 	var sentFirst bool
 	for {
@@ -144,6 +164,27 @@ func (w *hostFilesystemsWatcher) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case values, ok := <-w.modelFilesystemAttachments.Changes():
+			if !ok {
+				return watcher.EnsureErr(w.modelFilesystemAttachments)
+			}
+			modelFilesystemAttachmentsReceived = true
+			for _, id := range values {
+				hostTag, filesystemTag, err := state.ParseFilesystemAttachmentId(id)
+				if err != nil {
+					return errors.Annotate(err, "parsing filesystem attachment ID")
+				}
+				match, err := w.hostMatch(hostTag)
+				if err != nil {
+					return errors.Annotate(err, "parsing filesystem host tag")
+				}
+				if !match {
+					continue
+				}
+				if err := w.modelFilesystemAttachmentChanged(hostTag, filesystemTag); err != nil {
+					return errors.Trace(err)
+				}
+			}
 		case values, ok := <-w.modelVolumeAttachments.Changes():
 			if !ok {
 				return watcher.EnsureErr(w.modelVolumeAttachments)
@@ -175,6 +216,7 @@ func (w *hostFilesystemsWatcher) loop() error {
 		// event, which is expected of all watchers.
 		if hostFilesystemsReceived &&
 			modelFilesystemsReceived &&
+			modelFilesystemAttachmentsReceived &&
 			modelVolumeAttachmentsReceived &&
 			(!sentFirst || len(w.changes) > 0) {
 			sentFirst = true
@@ -191,6 +233,12 @@ func (w *hostFilesystemsWatcher) modelFilesystemChanged(filesystemTag names.File
 	} else if err != nil {
 		return errors.Annotate(err, "getting filesystem")
 	}
+	if filesystem.HostManaged() {
+		if w.hostManagedAttached.Contains(filesystemTag) {
+			w.changes.Add(filesystemTag.Id())
+		}
+		return nil
+	}
 	volumeTag, err := filesystem.Volume()
 	if err == state.ErrNoBackingVolume {
 		// Filesystem has no backing volume: nothing more to do.
@@ -205,6 +253,37 @@ func (w *hostFilesystemsWatcher) modelFilesystemChanged(filesystemTag names.File
 	return nil
 }
 
+// modelFilesystemAttachmentChanged records whether or not a host-managed
+// filesystem is attached to the host. Each host to which a host-managed
+// filesystem is attached manages the filesystem on its own behalf.
+func (w *hostFilesystemsWatcher) modelFilesystemAttachmentChanged(hostTag names.Tag, filesystemTag names.FilesystemTag) error {
+	filesystem, err := w.backend.Filesystem(filesystemTag)
+	if errors.IsNotFound(err) {
+		// Filesystem removed: nothing more to do.
+		w.hostManagedAttached.Remove(filesystemTag)
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "getting filesystem")
+	}
+	if !filesystem.HostManaged() {
+		return nil
+	}
+	fa, err := w.backend.FilesystemAttachment(hostTag, filesystemTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "getting filesystem attachment")
+	}
+	if errors.IsNotFound(err) || fa.Life() == state.Dead {
+		// The filesystem is no longer attached to the host,
+		// so the host is no longer responsible for it.
+		w.hostManagedAttached.Remove(filesystemTag)
+		w.changes.Remove(filesystemTag.Id())
+		return nil
+	}
+	w.hostManagedAttached.Add(filesystemTag)
+	w.changes.Add(filesystemTag.Id())
+	return nil
+}
+
 func (w *hostFilesystemsWatcher) modelVolumeAttachmentChanged(hostTag names.Tag, volumeTag names.VolumeTag) error {
 	va, err := w.backend.VolumeAttachment(hostTag, volumeTag)
 	if err != nil && !errors.IsNotFound(err) {
@@ -232,29 +311,22 @@ func (w *hostFilesystemsWatcher) modelVolumeAttachmentChanged(hostTag names.Tag,
 
 // WatchModelManagedFilesystemAttachments returns a strings watcher that
 // reports lifecycle changes to attachments of model-scoped filesystem that
-// have no backing volume. Volume-backed filesystems are always managed by
-// the host to which they are attached.
+// have no backing volume, and are not host-managed. Volume-backed filesystems
+// are always managed by the host to which they are attached.
 func (fw Watchers) WatchModelManagedFilesystemAttachments() state.StringsWatcher {
 	return newFilteredStringsWatcher(fw.Backend.WatchModelFilesystemAttachments(), func(id string) (bool, error) {
 		_, filesystemTag, err := state.ParseFilesystemAttachmentId(id)
 		if err != nil {
 			return false, errors.Annotate(err, "parsing filesystem attachment ID")
 		}
-		f, err := fw.Backend.Filesystem(filesystemTag)
-		if errors.IsNotFound(err) {
-			return false, nil
-		} else if err != nil {
-			return false, errors.Trace(err)
-		}
-		_, err = f.Volume()
-		return err == state.ErrNoBackingVolume, nil
+		return fw.modelManaged(filesystemTag)
 	})
 }
 
 // WatchMachineManagedFilesystemAttachments returns a strings watcher that
-// reports lifecycle changes for attachments to both machine-scoped filesystems,
-// and model-scoped, volume-backed filesystems that are attached to the
-// specified machine.
+// reports lifecycle changes for attachments to machine-scoped filesystems,
+// and model-scoped, volume-backed or host-managed filesystems that are
+// attached to the specified machine.
 func (fw Watchers) WatchMachineManagedFilesystemAttachments(m names.MachineTag) state.StringsWatcher {
 	w := &hostFilesystemAttachmentsWatcher{
 		stringsWatcherBase:               stringsWatcherBase{out: make(chan []string)},
@@ -421,6 +493,12 @@ func (w *hostFilesystemAttachmentsWatcher) modelFilesystemAttachmentChanged(
 	} else if err != nil {
 		return errors.Annotate(err, "getting filesystem")
 	}
+	if filesystem.HostManaged() {
+		// Host-managed filesystems are attached by the host,
+		// independently of any volume.
+		w.changes.Add(filesystemAttachmentId)
+		return nil
+	}
 	volumeTag, err := filesystem.Volume()
 	if err == state.ErrNoBackingVolume {
 		// Filesystem has no backing volume: nothing more to do.
@@ -40,6 +40,11 @@ func (s *WatchersSuite) SetUpTest(c *gc.C) {
 			"1": {volume: names.NewVolumeTag("1")},
 			// filesystem 2 is backed by volume 2.
 			"2": {volume: names.NewVolumeTag("2")},
+			// filesystem 3 is host-managed.
+			"3": {hostManaged: true},
+		},
+		filesystemAttachments: map[string]*mockFilesystemAttachment{
+			"3": {life: state.Alive},
 		},
 		volumeAttachments: map[string]*mockVolumeAttachment{
 			"1": {life: state.Alive},
@@ -68,6 +73,17 @@ func (s *WatchersSuite) TestWatchModelManagedFilesystems(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *WatchersSuite) TestWatchModelManagedFilesystemsHostManaged(c *gc.C) {
+	w := s.watchers.WatchModelManagedFilesystems()
+	defer statetesting.AssertKillAndWait(c, w)
+	s.backend.modelFilesystemsW.C <- []string{"0", "3"}
+
+	// Filesystem 3 is host-managed, so should not be reported.
+	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+}
+
 func (s *WatchersSuite) TestWatchModelManagedFilesystemsWatcherErrorsPropagate(c *gc.C) {
 	w := s.watchers.WatchModelManagedFilesystems()
 	s.backend.modelFilesystemsW.T.Kill(errors.New("rah"))
@@ -85,6 +101,17 @@ func (s *WatchersSuite) TestWatchModelManagedFilesystemAttachments(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *WatchersSuite) TestWatchModelManagedFilesystemAttachmentsHostManaged(c *gc.C) {
+	w := s.watchers.WatchModelManagedFilesystemAttachments()
+	defer statetesting.AssertKillAndWait(c, w)
+	s.backend.modelFilesystemAttachmentsW.C <- []string{"0:0", "0:3", "1:3"}
+
+	// Filesystem 3 is host-managed, so should not be reported.
+	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertChangeInSingleEvent("0:0")
+	wc.AssertNoChange()
+}
+
 func (s *WatchersSuite) TestWatchModelManagedFilesystemAttachmentsWatcherErrorsPropagate(c *gc.C) {
 	w := s.watchers.WatchModelManagedFilesystemAttachments()
 	s.backend.modelFilesystemAttachmentsW.T.Kill(errors.New("rah"))
@@ -98,6 +125,7 @@ func (s *WatchersSuite) TestWatchMachineManagedFilesystems(c *gc.C) {
 	s.backend.machineFilesystemsW.C <- []string{"0/2", "0/3"}
 	s.backend.modelVolumeAttachmentsW.C <- []string{"0:1", "0:2", "1:3"}
 
+	s.backend.modelFilesystemAttachmentsW.C <- []string{}
 	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
 	wc.AssertChangeInSingleEvent("0/2", "0/3", "1")
 	wc.AssertNoChange()
@@ -119,6 +147,7 @@ func (s *WatchersSuite) TestWatchMachineManagedFilesystemsVolumeAttachedFirst(c
 	s.backend.modelFilesystemsW.C <- []string{"0", "1"}
 	s.backend.machineFilesystemsW.C <- []string{"0/2", "0/3"}
 
+	s.backend.modelFilesystemAttachmentsW.C <- []string{}
 	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
 	wc.AssertChangeInSingleEvent("0/2", "0/3", "1")
 	wc.AssertNoChange()
@@ -132,6 +161,7 @@ func (s *WatchersSuite) TestWatchMachineManagedFilesystemsVolumeAttachedLater(c
 	// No volumes are attached to begin with.
 	s.backend.modelVolumeAttachmentsW.C <- []string{}
 
+	s.backend.modelFilesystemAttachmentsW.C <- []string{}
 	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
 	wc.AssertChangeInSingleEvent("0/2", "0/3")
 	wc.AssertNoChange()
@@ -146,6 +176,7 @@ func (s *WatchersSuite) TestWatchMachineManagedFilesystemsVolumeAttachmentDead(c
 	defer statetesting.AssertKillAndWait(c, w)
 
 	s.backend.machineFilesystemsW.C <- []string{}
+	s.backend.modelFilesystemAttachmentsW.C <- []string{}
 	// Volume-backed filesystems 1 and 2 change.
 	s.backend.modelFilesystemsW.C <- []string{"1", "2"}
 	// The volumes are attached initially...
@@ -169,6 +200,53 @@ func (s *WatchersSuite) TestWatchMachineManagedFilesystemsVolumeAttachmentDead(c
 	wc.AssertNoChange()
 }
 
+func (s *WatchersSuite) TestWatchMachineManagedFilesystemsHostManaged(c *gc.C) {
+	w := s.watchers.WatchMachineManagedFilesystems(names.NewMachineTag("0"))
+	defer statetesting.AssertKillAndWait(c, w)
+	s.backend.modelFilesystemsW.C <- []string{"0", "3"}
+	s.backend.machineFilesystemsW.C <- []string{}
+	s.backend.modelVolumeAttachmentsW.C <- []string{}
+	// Filesystem 3 is attached to machines 0 and 1; only
+	// the attachment to machine 0 is relevant.
+	s.backend.modelFilesystemAttachmentsW.C <- []string{"0:3", "1:3"}
+
+	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertChangeInSingleEvent("3")
+	wc.AssertNoChange()
+
+	// A change to the filesystem is reported while it remains attached.
+	s.backend.modelFilesystemsW.C <- []string{"3"}
+	wc.AssertChangeInSingleEvent("3")
+	wc.AssertNoChange()
+}
+
+func (s *WatchersSuite) TestWatchMachineManagedFilesystemsHostManagedDetached(c *gc.C) {
+	w := s.watchers.WatchMachineManagedFilesystems(names.NewMachineTag("0"))
+	defer statetesting.AssertKillAndWait(c, w)
+	delete(s.backend.filesystemAttachments, "3")
+	s.backend.modelFilesystemsW.C <- []string{"3"}
+	s.backend.machineFilesystemsW.C <- []string{}
+	s.backend.modelVolumeAttachmentsW.C <- []string{}
+	s.backend.modelFilesystemAttachmentsW.C <- []string{"0:3"}
+
+	// Filesystem 3 is not attached to machine 0.
+	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertChangeInSingleEvent()
+	wc.AssertNoChange()
+}
+
+func (s *WatchersSuite) TestWatchMachineManagedFilesystemAttachmentsHostManaged(c *gc.C) {
+	w := s.watchers.WatchMachineManagedFilesystemAttachments(names.NewMachineTag("0"))
+	defer statetesting.AssertKillAndWait(c, w)
+	s.backend.modelFilesystemAttachmentsW.C <- []string{"0:0", "0:3", "1:3"}
+	s.backend.machineFilesystemAttachmentsW.C <- []string{}
+	s.backend.modelVolumeAttachmentsW.C <- []string{}
+
+	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertChangeInSingleEvent("0:3")
+	wc.AssertNoChange()
+}
+
 func (s *WatchersSuite) TestWatchMachineManagedFilesystemAttachments(c *gc.C) {
 	w := s.watchers.WatchMachineManagedFilesystemAttachments(names.NewMachineTag("0"))
 	defer statetesting.AssertKillAndWait(c, w)
@@ -254,6 +332,7 @@ func (s *WatchersSuite) TestWatchUnitManagedFilesystems(c *gc.C) {
 	s.backend.unitFilesystemsW.C <- []string{"mariadb/0/2", "mariadb/0/3"}
 	s.backend.modelVolumeAttachmentsW.C <- []string{"mariadb/0:1", "mariadb/0:2", "mysql/1:3"}
 
+	s.backend.modelFilesystemAttachmentsW.C <- []string{}
 	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
 	wc.AssertChangeInSingleEvent("1", "mariadb/0/2", "mariadb/0/3")
 	wc.AssertNoChange()
@@ -50,6 +50,24 @@ func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.
 	return NewStorageProvisionerAPIv4(v3), nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIv5, error) {
+	v4, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProvisionerAPIv5(v4), nil
+}
+
+// NewFacadeV6 provides the signature required for facade registration.
+func NewFacadeV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIv6, error) {
+	v5, err := NewFacadeV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProvisionerAPIv6(v5), nil
+}
+
 type Backend interface {
 	state.EntityFinder
 	state.ModelAccessor
@@ -74,6 +92,10 @@ type StorageBackend interface {
 	WatchModelVolumes() state.StringsWatcher
 	WatchModelVolumeAttachments() state.StringsWatcher
 	WatchMachineVolumes(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeSnapshots() state.StringsWatcher
+	WatchMachineVolumeSnapshots(names.MachineTag) state.StringsWatcher
 	WatchMachineVolumeAttachments(names.MachineTag) state.StringsWatcher
 	WatchUnitVolumeAttachments(tag names.ApplicationTag) state.StringsWatcher
 	WatchVolumeAttachment(names.Tag, names.VolumeTag) state.NotifyWatcher
@@ -87,15 +109,18 @@ type StorageBackend interface {
 
 	Filesystem(names.FilesystemTag) (state.Filesystem, error)
 	FilesystemAttachment(names.Tag, names.FilesystemTag) (state.FilesystemAttachment, error)
+	FilesystemAttachments(names.FilesystemTag) ([]state.FilesystemAttachment, error)
 
 	Volume(names.VolumeTag) (state.Volume, error)
 	VolumeAttachment(names.Tag, names.VolumeTag) (state.VolumeAttachment, error)
 	VolumeAttachments(names.VolumeTag) ([]state.VolumeAttachment, error)
+	VolumeSnapshot(string) (state.VolumeSnapshot, error)
 
 	RemoveFilesystem(names.FilesystemTag) error
 	RemoveFilesystemAttachment(names.Tag, names.FilesystemTag) error
 	RemoveVolume(names.VolumeTag) error
 	RemoveVolumeAttachment(names.Tag, names.VolumeTag) error
+	RemoveVolumeSnapshot(string) error
 	DetachFilesystem(names.Tag, names.FilesystemTag) error
 	DestroyFilesystem(names.FilesystemTag) error
 	DetachVolume(names.Tag, names.VolumeTag) error
@@ -105,6 +130,7 @@ type StorageBackend interface {
 	SetFilesystemAttachmentInfo(names.Tag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.Tag, names.VolumeTag, state.VolumeAttachmentInfo) error
+	SetVolumeSnapshotInfo(string, state.VolumeSnapshotInfo) error
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
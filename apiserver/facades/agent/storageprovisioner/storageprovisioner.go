@@ -22,6 +22,16 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.storageprovisioner")
 
+// StorageProvisionerAPIv6 provides the StorageProvisioner API v6 facade.
+type StorageProvisionerAPIv6 struct {
+	*StorageProvisionerAPIv5
+}
+
+// StorageProvisionerAPIv5 provides the StorageProvisioner API v5 facade.
+type StorageProvisionerAPIv5 struct {
+	*StorageProvisionerAPIv4
+}
+
 // StorageProvisionerAPIv4 provides the StorageProvisioner API v4 facade.
 type StorageProvisionerAPIv4 struct {
 	*StorageProvisionerAPIv3
@@ -47,6 +57,16 @@ type StorageProvisionerAPIv3 struct {
 	getAttachmentAuthFunc    func() (func(names.Tag, names.Tag) bool, error)
 }
 
+// NewStorageProvisionerAPIv6 creates a new server-side StorageProvisioner v6 facade.
+func NewStorageProvisionerAPIv6(v5 *StorageProvisionerAPIv5) *StorageProvisionerAPIv6 {
+	return &StorageProvisionerAPIv6{v5}
+}
+
+// NewStorageProvisionerAPIv5 creates a new server-side StorageProvisioner v5 facade.
+func NewStorageProvisionerAPIv5(v4 *StorageProvisionerAPIv4) *StorageProvisionerAPIv5 {
+	return &StorageProvisionerAPIv5{v4}
+}
+
 // NewStorageProvisionerAPIv4 creates a new server-side StorageProvisioner v4 facade.
 func NewStorageProvisionerAPIv4(v3 *StorageProvisionerAPIv3) *StorageProvisionerAPIv4 {
 	return &StorageProvisionerAPIv4{v3}
@@ -117,6 +137,20 @@ func NewStorageProvisionerAPIv3(
 			if err != nil {
 				return false
 			}
+			if f.HostManaged() {
+				// The filesystem is managed by each of the
+				// machines that it is attached to.
+				filesystemAttachments, err := sb.FilesystemAttachments(tag)
+				if err != nil {
+					return false
+				}
+				for _, a := range filesystemAttachments {
+					if canAccessStorageMachine(a.Host(), false) {
+						return true
+					}
+				}
+				return false
+			}
 			volumeTag, err := f.Volume()
 			if err == nil {
 				// The filesystem has a backing volume. If the
@@ -319,6 +353,19 @@ func (s *StorageProvisionerAPIv3) WatchVolumes(args params.Entities) (params.Str
 	return s.watchStorageEntities(args, s.sb.WatchModelVolumes, s.sb.WatchMachineVolumes, nil)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that the volumes which
+// have been requested to be resized may be identified.
+func (s *StorageProvisionerAPIv5) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelVolumeResizes, s.sb.WatchMachineVolumeResizes, nil)
+}
+
+// WatchVolumeSnapshots watches for changes to volume snapshots scoped
+// to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPIv6) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelVolumeSnapshots, s.sb.WatchMachineVolumeSnapshots, nil)
+}
+
 // WatchFilesystems watches for changes to filesystems scoped
 // to the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPIv3) WatchFilesystems(args params.Entities) (params.StringsWatchResults, error) {
@@ -765,6 +812,164 @@ func (s *StorageProvisionerAPIv4) RemoveVolumeParams(args params.Entities) (para
 	return results, nil
 }
 
+// ResizeVolumeParams returns the parameters for resizing the volumes
+// with the specified tags. The result for a volume is nil if the volume
+// has not been requested to be grown, has already been grown, or no
+// longer exists.
+func (s *StorageProvisionerAPIv5) ResizeVolumeParams(args params.Entities) (params.ResizeVolumeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ResizeVolumeParamsResults{}, err
+	}
+	results := params.ResizeVolumeParamsResults{
+		Results: make([]params.ResizeVolumeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (*params.ResizeVolumeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return nil, common.ErrPerm
+		}
+		volume, err := s.sb.Volume(tag)
+		if errors.IsNotFound(err) {
+			// The volume has been removed, so
+			// there is nothing to resize.
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		size, ok := volume.RequestedSize()
+		if !ok || volume.Life() != state.Alive {
+			return nil, nil
+		}
+		volumeInfo, err := volume.Info()
+		if errors.IsNotProvisioned(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if volumeInfo.Size >= size {
+			return nil, nil
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			volumeInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &params.ResizeVolumeParams{
+			VolumeTag: tag.String(),
+			Provider:  string(provider),
+			VolumeId:  volumeInfo.VolumeId,
+			Size:      size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.ResizeVolumeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking, or destroying,
+// the volume snapshots with the specified IDs. The result for a snapshot
+// is nil if the snapshot no longer exists.
+func (s *StorageProvisionerAPIv6) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	modelCfg, err := s.st.ModelConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	controllerCfg, err := s.st.ControllerConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	one := func(id string) (*params.VolumeSnapshotParams, error) {
+		// Snapshot IDs share the syntax, and scope, of volume IDs.
+		if !names.IsValidVolume(id) || !canAccess(names.NewVolumeTag(id)) {
+			return nil, common.ErrPerm
+		}
+		snapshot, err := s.sb.VolumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			// The snapshot has been removed, so
+			// there is nothing to do.
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		provider, cfg, err := storagecommon.StoragePoolConfig(
+			snapshot.Pool(), s.poolManager, s.registry,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &params.VolumeSnapshotParams{
+			Id:         id,
+			Life:       params.Life(snapshot.Life().String()),
+			VolumeTag:  snapshot.Volume().String(),
+			Provider:   string(provider),
+			Attributes: cfg.Attrs(),
+			Size:       snapshot.Size(),
+		}
+		if info, err := snapshot.Info(); err == nil {
+			result.SnapshotId = info.SnapshotId
+			result.Size = info.Size
+		} else if !errors.IsNotProvisioned(err) {
+			return nil, err
+		}
+		// The volume may have been removed since the snapshot was
+		// taken, in which case there is no volume ID to report.
+		volume, err := s.sb.Volume(snapshot.Volume())
+		if err == nil {
+			if volumeInfo, err := volume.Info(); err == nil {
+				result.VolumeId = volumeInfo.VolumeId
+			}
+		} else if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		var storageInstance state.StorageInstance
+		if storageTag, ok := snapshot.StorageInstance(); ok {
+			storageInstance, err = s.sb.StorageInstance(storageTag)
+			if errors.IsNotFound(err) {
+				// The storage has been removed; snapshots
+				// outlive the storage they were taken of.
+				storageInstance = nil
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		result.Tags, err = storagecommon.StorageTags(
+			storageInstance, modelCfg.UUID(), controllerCfg.ControllerUUID(), modelCfg,
+		)
+		if err != nil {
+			return nil, errors.Annotate(err, "computing storage tags")
+		}
+		return result, nil
+	}
+	for i, id := range args.Ids {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPIv3) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -1172,6 +1377,58 @@ func (s *StorageProvisionerAPIv3) SetVolumeInfo(args params.Volumes) (params.Err
 	return results, nil
 }
 
+// SetVolumeSnapshotInfo records the details of newly taken volume snapshots.
+func (s *StorageProvisionerAPIv6) SetVolumeSnapshotInfo(args params.VolumeSnapshotInfos) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Snapshots)),
+	}
+	one := func(arg params.VolumeSnapshotInfo) error {
+		if !names.IsValidVolume(arg.Id) || !canAccess(names.NewVolumeTag(arg.Id)) {
+			return common.ErrPerm
+		}
+		err := s.sb.SetVolumeSnapshotInfo(arg.Id, state.VolumeSnapshotInfo{
+			SnapshotId: arg.SnapshotId,
+			Size:       arg.Size,
+		})
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Snapshots {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveVolumeSnapshots removes the volume snapshots with the specified
+// IDs from state. The snapshots must be Dying.
+func (s *StorageProvisionerAPIv6) RemoveVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(id string) error {
+		if !names.IsValidVolume(id) || !canAccess(names.NewVolumeTag(id)) {
+			return common.ErrPerm
+		}
+		return s.sb.RemoveVolumeSnapshot(id)
+	}
+	for i, id := range args.Ids {
+		err := one(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (s *StorageProvisionerAPIv3) SetFilesystemInfo(args params.Filesystems) (params.ErrorResults, error) {
 	canAccessFilesystem, err := s.getStorageEntityAuthFunc()
@@ -54,7 +54,7 @@ type provisionerSuite struct {
 
 	resources      *common.Resources
 	authorizer     *apiservertesting.FakeAuthorizer
-	api            *storageprovisioner.StorageProvisionerAPIv4
+	api            *storageprovisioner.StorageProvisionerAPIv6
 	storageBackend storageprovisioner.StorageBackend
 }
 
@@ -85,7 +85,7 @@ func (s *iaasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv6(storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3)))
 }
 
 func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
@@ -120,7 +120,7 @@ func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv6(storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3)))
 }
 
 func (s *provisionerSuite) TestNewStorageProvisionerAPINonMachine(c *gc.C) {
@@ -627,6 +627,190 @@ func (s *iaasProvisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	})
 }
 
+func (s *iaasProvisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	s.setupVolumes(c)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.ResizeVolumeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-0-0"},
+			{"volume-2"},
+			{"volume-1"},
+			{"volume-42"},
+			{"volume-1-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ResizeVolumeParamsResults{
+		Results: []params.ResizeVolumeParamsResult{{
+			Result: &params.ResizeVolumeParams{
+				VolumeTag: "volume-0-0",
+				Provider:  "machinescoped",
+				VolumeId:  "abc",
+				Size:      2048,
+			},
+		}, {
+			// Volume 2 has not been requested to be resized.
+		}, {
+			// Volume 1 has not been provisioned.
+		}, {
+			// Volume 42 does not exist.
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+
+	// Once the volume has been grown, there is nothing more to do.
+	err = s.storageBackend.SetVolumeInfo(names.NewVolumeTag("0/0"), state.VolumeInfo{
+		HardwareId: "123",
+		VolumeId:   "abc",
+		Size:       2048,
+		Persistent: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.api.ResizeVolumeParams(params.Entities{
+		Entities: []params.Entity{{"volume-0-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ResizeVolumeParamsResults{
+		Results: []params.ResizeVolumeParamsResult{{}},
+	})
+}
+
+// setupVolumeSnapshot deploys an application with block storage,
+// provisions its volume, and records a request to snapshot it.
+func (s *iaasProvisionerSuite) setupVolumeSnapshot(c *gc.C) string {
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
+			Name: "storage-block",
+		}),
+		Storage: map[string]state.StorageConstraints{
+			"data": {
+				Count: 1,
+				Size:  1024,
+				Pool:  "modelscoped",
+			},
+		},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: application,
+	})
+	storageTag := names.NewStorageTag("data/0")
+	storageVolume, err := s.storageBackend.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeInfo(storageVolume.VolumeTag(), state.VolumeInfo{
+		VolumeId:   "zing",
+		Size:       1024,
+		Persistent: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := sb.CreateVolumeSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return id
+}
+
+func (s *iaasProvisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	id := s.setupVolumeSnapshot(c)
+
+	expectedTags := map[string]string{
+		tags.JujuController:      testing.ControllerTag.Id(),
+		tags.JujuModel:           testing.ModelTag.Id(),
+		tags.JujuStorageInstance: "data/0",
+		tags.JujuStorageOwner:    "storage-block/0",
+	}
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{id, "42", "invalid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{{
+			Result: &params.VolumeSnapshotParams{
+				Id:        id,
+				Life:      params.Alive,
+				VolumeTag: "volume-0",
+				Provider:  "modelscoped",
+				VolumeId:  "zing",
+				Size:      1024,
+				Tags:      expectedTags,
+			},
+		}, {
+			// Snapshot 42 does not exist.
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+
+	// Once the snapshot has been taken, its ID is reported
+	// so that it may later be destroyed.
+	setResults, err := s.api.SetVolumeSnapshotInfo(params.VolumeSnapshotInfos{
+		Snapshots: []params.VolumeSnapshotInfo{
+			{Id: id, SnapshotId: "snap-zing", Size: 1024},
+			{Id: "42", SnapshotId: "snap-42", Size: 1024},
+			{Id: "invalid", SnapshotId: "snap-invalid", Size: 1024},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+	results, err = s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{Ids: []string{id}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{{
+			Result: &params.VolumeSnapshotParams{
+				Id:         id,
+				Life:       params.Alive,
+				VolumeTag:  "volume-0",
+				Provider:   "modelscoped",
+				VolumeId:   "zing",
+				Size:       1024,
+				Tags:       expectedTags,
+				SnapshotId: "snap-zing",
+			},
+		}},
+	})
+}
+
+func (s *iaasProvisionerSuite) TestRemoveVolumeSnapshots(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	id := s.setupVolumeSnapshot(c)
+
+	results, err := s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{id, "invalid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Message: `cannot remove volume snapshot "0": volume snapshot is not dying`}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{Ids: []string{id}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	_, err = sb.VolumeSnapshot(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *iaasProvisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -1018,6 +1202,42 @@ func (s *caasProvisionerSuite) TestWatchApplications(c *gc.C) {
 	}
 }
 
+func (s *iaasProvisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.Model.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[1].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1", "2", "3", "4"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	wc := statetesting.NewStringsWatcherC(c, s.State, v0Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	// Requesting a resize triggers a change.
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("0/0")
+	wc.AssertNoChange()
+}
+
 func (s *iaasProvisionerSuite) TestWatchVolumes(c *gc.C) {
 	// Only IAAS models support block storage right now.
 	s.setupVolumes(c)
@@ -1122,6 +1342,35 @@ func (s *iaasProvisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *iaasProvisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	id := s.setupVolumeSnapshot(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{s.Model.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{id}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop it when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *iaasProvisionerSuite) TestWatchFilesystems(c *gc.C) {
 	s.setupFilesystems(c)
 	c.Assert(s.resources.Count(), gc.Equals, 0)
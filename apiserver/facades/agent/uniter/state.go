@@ -22,6 +22,7 @@ type storageInterface interface {
 	RemoveStorageAttachment(names.StorageTag, names.UnitTag) error
 	DestroyUnitStorageAttachments(names.UnitTag) error
 	StorageAttachment(names.StorageTag, names.UnitTag) (state.StorageAttachment, error)
+	StorageAttachments(names.StorageTag) ([]state.StorageAttachment, error)
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error)
 	WatchStorageAttachments(names.UnitTag) state.StringsWatcher
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) state.NotifyWatcher
@@ -39,6 +40,7 @@ type storageFilesystemInterface interface {
 	StorageInstanceFilesystem(names.StorageTag) (state.Filesystem, error)
 	FilesystemAttachment(names.Tag, names.FilesystemTag) (state.FilesystemAttachment, error)
 	WatchFilesystemAttachment(names.Tag, names.FilesystemTag) state.NotifyWatcher
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
 }
 
 var getStorageState = func(st *state.State) (storageAccess, error) {
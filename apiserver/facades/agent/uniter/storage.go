@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/storage"
 )
 
 // StorageAPI provides access to the Storage API facade.
@@ -172,21 +173,7 @@ func (s *StorageAPI) getOneStateStorageAttachment(canAccess common.AuthFunc, id
 }
 
 func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.StorageAttachment) (params.StorageAttachment, error) {
-	var hostTag names.Tag
-	hostTag = stateStorageAttachment.Unit()
-	u, err := s.backend.Unit(hostTag.Id())
-	if err != nil {
-		return params.StorageAttachment{}, err
-	}
-	if u.ShouldBeAssigned() {
-		hostTag, err = unitAssignedMachine(s.backend, stateStorageAttachment.Unit())
-		if err != nil {
-			return params.StorageAttachment{}, err
-		}
-	}
-
-	info, err := storagecommon.StorageAttachmentInfo(
-		s.storage, s.storage.VolumeAccess(), s.storage.FilesystemAccess(), stateStorageAttachment, hostTag)
+	info, err := s.storageAttachmentInfo(stateStorageAttachment)
 	if err != nil {
 		return params.StorageAttachment{}, err
 	}
@@ -195,8 +182,17 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 		return params.StorageAttachment{}, err
 	}
 	var ownerTag string
+	var unitLocations map[string]string
 	if owner, ok := stateStorageInstance.Owner(); ok {
 		ownerTag = owner.String()
+		if owner.Kind() == names.ApplicationTagKind {
+			// Storage owned by an application is shared
+			// by all of the application's units.
+			unitLocations, err = s.sharedStorageUnitLocations(stateStorageInstance.StorageTag())
+			if err != nil {
+				return params.StorageAttachment{}, err
+			}
+		}
 	}
 	return params.StorageAttachment{
 		stateStorageAttachment.StorageInstance().String(),
@@ -205,9 +201,57 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 		params.StorageKind(stateStorageInstance.Kind()),
 		info.Location,
 		params.Life(stateStorageAttachment.Life().String()),
+		info.Size,
+		unitLocations,
 	}, nil
 }
 
+// storageAttachmentInfo returns the location and size of the storage
+// attachment on the host of its unit.
+func (s *StorageAPI) storageAttachmentInfo(stateStorageAttachment state.StorageAttachment) (*storage.StorageAttachmentInfo, error) {
+	var hostTag names.Tag
+	hostTag = stateStorageAttachment.Unit()
+	u, err := s.backend.Unit(hostTag.Id())
+	if err != nil {
+		return nil, err
+	}
+	if u.ShouldBeAssigned() {
+		hostTag, err = unitAssignedMachine(s.backend, stateStorageAttachment.Unit())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return storagecommon.StorageAttachmentInfo(
+		s.storage, s.storage.VolumeAccess(), s.storage.FilesystemAccess(), stateStorageAttachment, hostTag)
+}
+
+// sharedStorageUnitLocations returns the location of the shared storage
+// instance on each unit that it is attached to, keyed by unit tag. Units
+// for which the storage is not yet provisioned are omitted.
+func (s *StorageAPI) sharedStorageUnitLocations(storageTag names.StorageTag) (map[string]string, error) {
+	stateStorageAttachments, err := s.storage.StorageAttachments(storageTag)
+	if err != nil {
+		return nil, err
+	}
+	unitLocations := make(map[string]string)
+	for _, stateStorageAttachment := range stateStorageAttachments {
+		info, err := s.storageAttachmentInfo(stateStorageAttachment)
+		if errors.IsNotProvisioned(err) || errors.IsNotAssigned(err) || errors.IsNotFound(err) {
+			// The unit has not been assigned to a machine,
+			// or the storage has not yet been attached there.
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(
+				err, "getting location of %s on %s",
+				names.ReadableString(storageTag),
+				names.ReadableString(stateStorageAttachment.Unit()),
+			)
+		}
+		unitLocations[stateStorageAttachment.Unit().String()] = info.Location
+	}
+	return unitLocations, nil
+}
+
 // WatchUnitStorageAttachments creates watchers for a collection of units,
 // each of which can be used to watch for lifecycle changes to the corresponding
 // unit's storage attachments.
@@ -481,8 +525,11 @@ func watchStorageAttachment(
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage filesystem")
 		}
+		// We watch the filesystem as well as the attachment,
+		// so that the unit observes the filesystem growing.
 		watchers = []state.NotifyWatcher{
 			stFile.WatchFilesystemAttachment(hostTag, filesystem.FilesystemTag()),
+			stFile.WatchFilesystem(filesystem.FilesystemTag()),
 		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
@@ -173,6 +173,10 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		changes: make(chan struct{}, 1),
 	}
 	filesystemWatcher.changes <- struct{}{}
+	filesystemInfoWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	filesystemInfoWatcher.changes <- struct{}{}
 	var calls []string
 	st := &mockStorageState{
 		assignedMachine: assignedMachine,
@@ -198,6 +202,11 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 			c.Assert(f, gc.DeepEquals, filesystemTag)
 			return filesystemWatcher
 		},
+		watchFilesystem: func(f names.FilesystemTag) state.NotifyWatcher {
+			calls = append(calls, "WatchFilesystem")
+			c.Assert(f, gc.DeepEquals, filesystemTag)
+			return filesystemInfoWatcher
+		},
 	}
 
 	storage, err := uniter.NewStorageAPI(st, st, resources, getCanAccess)
@@ -218,10 +227,101 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		"StorageInstance",
 		"StorageInstanceFilesystem",
 		"WatchFilesystemAttachment",
+		"WatchFilesystem",
 		"WatchStorageAttachment",
 	})
 }
 
+func (s *storageSuite) TestStorageAttachmentsShared(c *gc.C) {
+	getCanAccess := func() (common.AuthFunc, error) {
+		return func(names.Tag) bool {
+			return true
+		}, nil
+	}
+	storageTag := names.NewStorageTag("shared/0")
+	filesystemTag := names.NewFilesystemTag("7")
+	units := []names.UnitTag{
+		names.NewUnitTag("wordpress/0"),
+		names.NewUnitTag("wordpress/1"),
+		names.NewUnitTag("wordpress/2"),
+	}
+	storageInstance := &mockStorageInstance{
+		tag:   storageTag,
+		owner: names.NewApplicationTag("wordpress"),
+		kind:  state.StorageKindFilesystem,
+	}
+	filesystem := &mockFilesystem{
+		tag:  filesystemTag,
+		info: &state.FilesystemInfo{Size: 1024},
+	}
+	mountPoints := map[names.Tag]string{
+		names.NewMachineTag("0"): "/srv/shared",
+		names.NewMachineTag("1"): "/var/lib/shared",
+	}
+	st := &mockStorageState{
+		// wordpress/2 has not yet been assigned to a machine.
+		unitAssignedMachines: map[string]string{
+			"wordpress/0": "0",
+			"wordpress/1": "1",
+		},
+		storageInstance: func(s names.StorageTag) (state.StorageInstance, error) {
+			c.Assert(s, gc.Equals, storageTag)
+			return storageInstance, nil
+		},
+		storageInstanceFilesystem: func(s names.StorageTag) (state.Filesystem, error) {
+			c.Assert(s, gc.Equals, storageTag)
+			return filesystem, nil
+		},
+		storageAttachment: func(s names.StorageTag, u names.UnitTag) (state.StorageAttachment, error) {
+			return &mockStorageAttachment{storage: s, unit: u}, nil
+		},
+		storageAttachments: func(s names.StorageTag) ([]state.StorageAttachment, error) {
+			attachments := make([]state.StorageAttachment, len(units))
+			for i, u := range units {
+				attachments[i] = &mockStorageAttachment{storage: s, unit: u}
+			}
+			return attachments, nil
+		},
+		filesystemAttachment: func(host names.Tag, f names.FilesystemTag) (state.FilesystemAttachment, error) {
+			c.Assert(f, gc.Equals, filesystemTag)
+			mountPoint, ok := mountPoints[host]
+			if !ok {
+				return nil, errors.NotFoundf("filesystem attachment")
+			}
+			return &mockFilesystemAttachment{
+				info: &state.FilesystemAttachmentInfo{MountPoint: mountPoint},
+			}, nil
+		},
+	}
+
+	storage, err := uniter.NewStorageAPI(st, st, common.NewResources(), getCanAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := storage.StorageAttachments(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{{
+			StorageTag: storageTag.String(),
+			UnitTag:    units[1].String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StorageAttachmentResults{
+		Results: []params.StorageAttachmentResult{{
+			Result: params.StorageAttachment{
+				StorageTag: storageTag.String(),
+				OwnerTag:   "application-wordpress",
+				UnitTag:    units[1].String(),
+				Kind:       params.StorageKindFilesystem,
+				Location:   "/var/lib/shared",
+				Life:       params.Alive,
+				Size:       1024,
+				UnitLocations: map[string]string{
+					"unit-wordpress-0": "/srv/shared",
+					"unit-wordpress-1": "/var/lib/shared",
+				},
+			},
+		}},
+	})
+}
+
 func (s *storageSuite) TestDestroyUnitStorageAttachments(c *gc.C) {
 	resources := common.NewResources()
 	getCanAccess := func() (common.AuthFunc, error) {
@@ -476,6 +576,7 @@ func (u *mockUnit) StorageConstraints() (map[string]state.StorageConstraints, er
 type mockStorageState struct {
 	unitStorageConstraints map[string]state.StorageConstraints
 	assignedMachine        string
+	unitAssignedMachines   map[string]string
 
 	uniter.Backend
 	uniter.StorageStateInterface
@@ -486,9 +587,13 @@ type mockStorageState struct {
 	storageInstance               func(names.StorageTag) (state.StorageInstance, error)
 	storageInstanceFilesystem     func(names.StorageTag) (state.Filesystem, error)
 	storageInstanceVolume         func(names.StorageTag) (state.Volume, error)
+	storageAttachment             func(names.StorageTag, names.UnitTag) (state.StorageAttachment, error)
+	storageAttachments            func(names.StorageTag) ([]state.StorageAttachment, error)
+	filesystemAttachment          func(names.Tag, names.FilesystemTag) (state.FilesystemAttachment, error)
 	watchStorageAttachments       func(names.UnitTag) state.StringsWatcher
 	watchStorageAttachment        func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment     func(names.Tag, names.FilesystemTag) state.NotifyWatcher
+	watchFilesystem               func(names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.Tag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
 	addUnitStorage                func(u names.UnitTag, name string, cons state.StorageConstraints) error
@@ -503,8 +608,12 @@ func (m *mockStorageState) FilesystemAccess() uniter.StorageFilesystemInterface
 }
 
 func (m *mockStorageState) Unit(name string) (uniter.Unit, error) {
+	assignedMachine := m.assignedMachine
+	if m.unitAssignedMachines != nil {
+		assignedMachine = m.unitAssignedMachines[name]
+	}
 	return &mockUnit{
-		assignedMachine:    m.assignedMachine,
+		assignedMachine:    assignedMachine,
 		storageConstraints: m.unitStorageConstraints}, nil
 }
 
@@ -520,6 +629,18 @@ func (m *mockStorageState) StorageInstance(s names.StorageTag) (state.StorageIns
 	return m.storageInstance(s)
 }
 
+func (m *mockStorageState) StorageAttachment(s names.StorageTag, u names.UnitTag) (state.StorageAttachment, error) {
+	return m.storageAttachment(s, u)
+}
+
+func (m *mockStorageState) StorageAttachments(s names.StorageTag) ([]state.StorageAttachment, error) {
+	return m.storageAttachments(s)
+}
+
+func (m *mockStorageState) FilesystemAttachment(hostTag names.Tag, f names.FilesystemTag) (state.FilesystemAttachment, error) {
+	return m.filesystemAttachment(hostTag, f)
+}
+
 func (m *mockStorageState) StorageInstanceFilesystem(s names.StorageTag) (state.Filesystem, error) {
 	return m.storageInstanceFilesystem(s)
 }
@@ -540,6 +661,10 @@ func (m *mockStorageState) WatchFilesystemAttachment(hostTag names.Tag, f names.
 	return m.watchFilesystemAttachment(hostTag, f)
 }
 
+func (m *mockStorageState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return m.watchFilesystem(f)
+}
+
 func (m *mockStorageState) WatchVolumeAttachment(hostTag names.Tag, v names.VolumeTag) state.NotifyWatcher {
 	return m.watchVolumeAttachment(hostTag, v)
 }
@@ -598,22 +723,70 @@ func (m *mockVolume) VolumeTag() names.VolumeTag {
 
 type mockFilesystem struct {
 	state.Filesystem
-	tag names.FilesystemTag
+	tag  names.FilesystemTag
+	info *state.FilesystemInfo
 }
 
 func (m *mockFilesystem) FilesystemTag() names.FilesystemTag {
 	return m.tag
 }
 
+func (m *mockFilesystem) Info() (state.FilesystemInfo, error) {
+	if m.info == nil {
+		return state.FilesystemInfo{}, errors.NotProvisionedf("filesystem %v", m.tag.Id())
+	}
+	return *m.info, nil
+}
+
+type mockFilesystemAttachment struct {
+	state.FilesystemAttachment
+	info *state.FilesystemAttachmentInfo
+}
+
+func (m *mockFilesystemAttachment) Info() (state.FilesystemAttachmentInfo, error) {
+	if m.info == nil {
+		return state.FilesystemAttachmentInfo{}, errors.NotProvisionedf("filesystem attachment")
+	}
+	return *m.info, nil
+}
+
 type mockStorageInstance struct {
 	state.StorageInstance
-	kind state.StorageKind
+	tag   names.StorageTag
+	owner names.Tag
+	kind  state.StorageKind
+}
+
+func (m *mockStorageInstance) StorageTag() names.StorageTag {
+	return m.tag
+}
+
+func (m *mockStorageInstance) Owner() (names.Tag, bool) {
+	return m.owner, m.owner != nil
 }
 
 func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
+type mockStorageAttachment struct {
+	state.StorageAttachment
+	storage names.StorageTag
+	unit    names.UnitTag
+}
+
+func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
+	return m.storage
+}
+
+func (m *mockStorageAttachment) Unit() names.UnitTag {
+	return m.unit
+}
+
+func (m *mockStorageAttachment) Life() state.Life {
+	return state.Alive
+}
+
 type watchStorageAttachmentSuite struct {
 	storageTag               names.StorageTag
 	machineTag               names.MachineTag
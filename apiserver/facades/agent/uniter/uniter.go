@@ -8,6 +8,7 @@ package uniter
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -35,7 +36,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v8) of the Uniter API.
+// UniterAPI implements the latest version (v11) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -51,6 +52,7 @@ type UniterAPI struct {
 	st                *state.State
 	auth              facade.Authorizer
 	resources         facade.Resources
+	leadershipChecker leadership.Checker
 	accessUnit        common.GetAuthFunc
 	accessApplication common.GetAuthFunc
 	accessMachine     common.GetAuthFunc
@@ -63,9 +65,33 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV11 adds TargetCharmURL, and doesn't support opening or
+// closing ports for specific endpoints.
+type UniterAPIV11 struct {
+	UniterAPI
+}
+
+// UniterAPIV10 adds HookTimeouts, and doesn't have the new
+// TargetCharmURL method.
+type UniterAPIV10 struct {
+	UniterAPIV11
+}
+
+// UniterAPIV9 adds UnitState and SetUnitState, and doesn't have the
+// new HookTimeouts method.
+type UniterAPIV9 struct {
+	UniterAPIV10
+}
+
+// UniterAPIV8 adds SetPodSpec, and doesn't have the new UnitState or
+// SetUnitState methods.
+type UniterAPIV8 struct {
+	UniterAPIV9
+}
+
 // UniterAPIV7 adds CMR support to NetworkInfo.
 type UniterAPIV7 struct {
-	UniterAPI
+	UniterAPIV8
 }
 
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
@@ -88,7 +114,11 @@ type UniterAPIV4 struct {
 }
 
 // NewUniterAPI creates a new instance of the core Uniter API.
-func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPI, error) {
+func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
+	st := context.State()
+	resources := context.Resources()
+	authorizer := context.Auth()
+	leadershipChecker := context.LeadershipChecker()
 	if !authorizer.AuthUnitAgent() && !authorizer.AuthApplicationAgent() {
 		return nil, common.ErrPerm
 	}
@@ -225,16 +255,17 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 		ModelWatcher:               common.NewModelWatcher(m, resources, authorizer),
 		RebootRequester:            common.NewRebootRequester(st, accessMachine),
 		UpgradeSeriesAPI:           common.NewExternalUpgradeSeriesAPI(st, resources, authorizer, accessMachine, accessUnit, logger),
-		LeadershipSettingsAccessor: leadershipSettingsAccessorFactory(st, resources, authorizer),
+		LeadershipSettingsAccessor: leadershipSettingsAccessorFactory(st, resources, authorizer, leadershipChecker),
 		MeterStatus:                msAPI,
 		// TODO(fwereade): so *every* unit should be allowed to get/set its
 		// own status *and* its application's? This is not a pleasing arrangement.
-		StatusAPI: NewStatusAPI(st, accessUnitOrApplication),
+		StatusAPI: NewStatusAPI(st, accessUnitOrApplication, leadershipChecker),
 
 		st:                st,
 		m:                 m,
 		auth:              authorizer,
 		resources:         resources,
+		leadershipChecker: leadershipChecker,
 		accessUnit:        accessUnit,
 		accessApplication: accessApplication,
 		accessMachine:     accessMachine,
@@ -244,20 +275,64 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// NewUniterAPIV11 creates an instance of the V11 uniter API.
+func NewUniterAPIV11(context facade.Context) (*UniterAPIV11, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV11{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV10 creates an instance of the V10 uniter API.
+func NewUniterAPIV10(context facade.Context) (*UniterAPIV10, error) {
+	uniterAPI, err := NewUniterAPIV11(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV10{
+		UniterAPIV11: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV9 creates an instance of the V9 uniter API.
+func NewUniterAPIV9(context facade.Context) (*UniterAPIV9, error) {
+	uniterAPI, err := NewUniterAPIV10(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV9{
+		UniterAPIV10: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV8 creates an instance of the V8 uniter API.
+func NewUniterAPIV8(context facade.Context) (*UniterAPIV8, error) {
+	uniterAPI, err := NewUniterAPIV9(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{
+		UniterAPIV9: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV7 creates an instance of the V7 uniter API.
-func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+func NewUniterAPIV7(context facade.Context) (*UniterAPIV7, error) {
+	uniterAPI, err := NewUniterAPIV8(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV7{
-		UniterAPI: *uniterAPI,
+		UniterAPIV8: *uniterAPI,
 	}, nil
 }
 
 // NewUniterAPIV6 creates an instance of the V6 uniter API.
-func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
-	uniterAPI, err := NewUniterAPIV7(st, resources, authorizer)
+func NewUniterAPIV6(context facade.Context) (*UniterAPIV6, error) {
+	uniterAPI, err := NewUniterAPIV7(context)
 	if err != nil {
 		return nil, err
 	}
@@ -267,8 +342,8 @@ func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer faca
 }
 
 // NewUniterAPIV5 creates an instance of the V5 uniter API.
-func NewUniterAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV5, error) {
-	uniterAPI, err := NewUniterAPIV6(st, resources, authorizer)
+func NewUniterAPIV5(context facade.Context) (*UniterAPIV5, error) {
+	uniterAPI, err := NewUniterAPIV6(context)
 	if err != nil {
 		return nil, err
 	}
@@ -278,8 +353,8 @@ func NewUniterAPIV5(st *state.State, resources facade.Resources, authorizer faca
 }
 
 // NewUniterAPIV4 creates an instance of the V4 uniter API.
-func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV4, error) {
-	uniterAPI, err := NewUniterAPIV5(st, resources, authorizer)
+func NewUniterAPIV4(context facade.Context) (*UniterAPIV4, error) {
+	uniterAPI, err := NewUniterAPIV5(context)
 	if err != nil {
 		return nil, err
 	}
@@ -760,6 +835,47 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 	return result, nil
 }
 
+// TargetCharmURL isn't on the v10 API.
+func (u *UniterAPIV10) TargetCharmURL(_, _ struct{}) {}
+
+// TargetCharmURL returns the URL of the charm each given unit should be
+// running, and whether the unit should upgrade to it even if it is in
+// an error state. This is the application's charm, unless the unit has
+// been chosen to run a charm being rolled out to the application.
+func (u *UniterAPI) TargetCharmURL(args params.Entities) (params.StringBoolResults, error) {
+	result := params.StringBoolResults{
+		Results: make([]params.StringBoolResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringBoolResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		curl, force, err := unit.TargetCharmURL()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = curl.String()
+		result.Results[i].Ok = force
+	}
+	return result, nil
+}
+
 // SetCharmURL sets the charm URL for each given unit. An error will
 // be returned if a unit is dead, or the charm URL is not know.
 func (u *UniterAPI) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResults, error) {
@@ -863,7 +979,8 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 }
 
 // OpenPorts sets the policy of the port range with protocol to be
-// opened, for all given units.
+// opened, for all given units. If an endpoint is given, the range is
+// opened for that endpoint only.
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -883,7 +1000,7 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.OpenEndpointPorts(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -892,7 +1009,8 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 }
 
 // ClosePorts sets the policy of the port range with protocol to be
-// closed, for all given units.
+// closed, for all given units. If an endpoint is given, only the range
+// opened for that endpoint is closed.
 func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -912,7 +1030,7 @@ func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResu
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.ClosePorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.CloseEndpointPorts(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1538,7 +1656,7 @@ func (u *UniterAPI) SetRelationStatus(args params.RelationStatusArgs) (params.Er
 		return unit, nil
 	}
 
-	checker := u.st.LeadershipChecker()
+	checker := u.leadershipChecker
 	changeOne := func(arg params.RelationStatusArg) error {
 		// TODO(wallyworld) - the token should be passed to SetStatus() but the
 		// interface method doesn't allow for that yet.
@@ -1881,6 +1999,7 @@ func leadershipSettingsAccessorFactory(
 	st *state.State,
 	resources facade.Resources,
 	auth facade.Authorizer,
+	leadershipChecker leadership.Checker,
 ) *leadershipapiserver.LeadershipSettingsAccessor {
 	registerWatcher := func(applicationId string) (string, error) {
 		application, err := st.Application(applicationId)
@@ -1911,7 +2030,7 @@ func leadershipSettingsAccessorFactory(
 		auth,
 		registerWatcher,
 		getSettings,
-		st.LeadershipChecker().LeadershipCheck,
+		leadershipChecker.LeadershipCheck,
 		writeSettings,
 	)
 }
@@ -2487,6 +2606,141 @@ func (u *UniterAPI) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResult
 	return results, nil
 }
 
+// UnitState isn't on the v8 API.
+func (u *UniterAPIV8) UnitState(_, _ struct{}) {}
+
+// SetUnitState isn't on the v8 API.
+func (u *UniterAPIV8) SetUnitState(_, _ struct{}) {}
+
+// UnitState returns the persistent charm state for each given unit.
+func (u *UniterAPI) UnitState(args params.Entities) (params.UnitStateResults, error) {
+	result := params.UnitStateResults{
+		Results: make([]params.UnitStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UnitStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.State, err = unit.State()
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// SetUnitState replaces the persistent charm state for each given
+// unit. The size of each unit's state is limited by quotas enforced
+// by the controller.
+func (u *UniterAPI) SetUnitState(args params.SetUnitStateArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.Error = common.ServerError(unit.SetState(arg.State))
+	}
+	return result, nil
+}
+
+// HookTimeouts isn't on the v9 API.
+func (u *UniterAPIV9) HookTimeouts(_, _ struct{}) {}
+
+// HookTimeouts returns the effective hook timeout and long-running
+// hook warning threshold for each given unit. An application's
+// hook-timeout config overrides the model's hook-timeout.
+func (u *UniterAPI) HookTimeouts(args params.Entities) (params.HookTimeoutsResults, error) {
+	result := params.HookTimeoutsResults{
+		Results: make([]params.HookTimeoutsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.HookTimeoutsResults{}, err
+	}
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.HookTimeoutsResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		timeout, err := u.hookTimeout(tag, cfg.HookTimeout())
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.Timeout = timeout
+		resultItem.WarningThreshold = cfg.HookWarningThreshold()
+	}
+	return result, nil
+}
+
+// hookTimeout returns the hook timeout configured on the unit's
+// application, or modelTimeout if the application doesn't set one.
+func (u *UniterAPI) hookTimeout(tag names.UnitTag, modelTimeout time.Duration) (time.Duration, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	raw := appConfig.GetString(application.HookTimeoutConfigOptionName, "")
+	if raw == "" {
+		return modelTimeout, nil
+	}
+	// Value has already been validated.
+	timeout, _ := time.ParseDuration(raw)
+	return timeout, nil
+}
+
 // CloudSpec returns the cloud spec used by the model in which the
 // authenticated unit or application resides.
 // A check is made beforehand to ensure that the request is made by an entity
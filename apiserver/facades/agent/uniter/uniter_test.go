@@ -5,9 +5,11 @@ package uniter_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -20,10 +22,12 @@ import (
 	apiuniter "github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
+	"github.com/juju/juju/apiserver/facade/facadetest"
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -121,11 +125,11 @@ func (s *uniterSuiteBase) SetUpTest(c *gc.C) {
 	s.resources = common.NewResources()
 	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
 
-	uniterAPI, err := uniter.NewUniterAPI(
-		s.State,
-		s.resources,
-		s.authorizer,
-	)
+	uniterAPI, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.uniter = uniterAPI
 }
@@ -153,7 +157,11 @@ var _ = gc.Suite(&uniterSuite{})
 func (s *uniterSuite) TestUniterFailsWithNonUnitAgentUser(c *gc.C) {
 	anAuthorizer := s.authorizer
 	anAuthorizer.Tag = names.NewMachineTag("9")
-	_, err := uniter.NewUniterAPI(s.State, s.resources, anAuthorizer)
+	_, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      anAuthorizer,
+	})
 	c.Assert(err, gc.NotNil)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
@@ -668,7 +676,11 @@ func (s *uniterSuite) TestGetPrincipal(c *gc.C) {
 	// Now try as subordinate's agent.
 	subAuthorizer := s.authorizer
 	subAuthorizer.Tag = subordinate.Tag()
-	subUniter, err := uniter.NewUniterAPI(s.State, s.resources, subAuthorizer)
+	subUniter, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      subAuthorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err = subUniter.GetPrincipal(args)
@@ -818,6 +830,34 @@ func (s *uniterSuite) TestCharmURL(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestTargetCharmURL(c *gc.C) {
+	newCharm := s.Factory.MakeCharm(c, &jujufactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err := s.wordpress.SetCharm(state.SetCharmConfig{
+		Charm:      newCharm,
+		ForceUnits: true,
+		Units:      []string{s.wordpressUnit.Name()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.TargetCharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: newCharm.String(), Ok: true},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *uniterSuite) TestSetCharmURL(c *gc.C) {
 	_, ok := s.wordpressUnit.CharmURL()
 	c.Assert(ok, jc.IsFalse)
@@ -903,6 +943,107 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestUnitState(c *gc.C) {
+	err := s.wordpressUnit.SetState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.UnitState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UnitStateResults{
+		Results: []params.UnitStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{State: map[string]string{"foo": "bar"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSetUnitState(c *gc.C) {
+	args := params.SetUnitStateArgs{Args: []params.SetUnitStateArg{
+		{Tag: "unit-mysql-0", State: map[string]string{"a": "b"}},
+		{Tag: "unit-wordpress-0", State: map[string]string{"foo": "bar"}},
+		{Tag: "unit-foo-42", State: map[string]string{"c": "d"}},
+	}}
+	result, err := s.uniter.SetUnitState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	st, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *uniterSuite) TestSetUnitStateQuotaExceeded(c *gc.C) {
+	args := params.SetUnitStateArgs{Args: []params.SetUnitStateArg{{
+		Tag:   "unit-wordpress-0",
+		State: map[string]string{"big": strings.Repeat("x", state.MaxUnitStateSize)},
+	}}}
+	result, err := s.uniter.SetUnitState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches,
+		`unit state of 65539 bytes exceeds the quota of 65536 bytes`)
+}
+
+func (s *uniterSuite) TestHookTimeouts(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		config.HookTimeout:          "30m",
+		config.HookWarningThreshold: "5m",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.HookTimeouts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.HookTimeoutsResults{
+		Results: []params.HookTimeoutsResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Timeout: 30 * time.Minute, WarningThreshold: 5 * time.Minute},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestHookTimeoutsApplicationOverride(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		config.HookTimeout: "30m",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		application.HookTimeoutConfigOptionName: "2h",
+	}, nil, environschema.Fields{
+		application.HookTimeoutConfigOptionName: {Type: environschema.Tstring},
+	}, schema.Defaults{
+		application.HookTimeoutConfigOptionName: "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.HookTimeouts(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.HookTimeoutsResults{
+		Results: []params.HookTimeoutsResult{
+			{Timeout: 2 * time.Hour, WarningThreshold: 10 * time.Minute},
+		},
+	})
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -1209,11 +1350,11 @@ func (s *uniterSuite) TestWatchSubordinateUnitRelations(c *gc.C) {
 
 	subAuthorizer := s.authorizer
 	subAuthorizer.Tag = mysqlLogUnit.Tag()
-	api, err := uniter.NewUniterAPI(
-		s.State,
-		s.resources,
-		subAuthorizer,
-	)
+	api, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      subAuthorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := api.WatchUnitRelations(params.Entities{
@@ -1275,11 +1416,11 @@ func (s *uniterSuite) TestWatchUnitRelationsSubordinateWithGlobalEndpoint(c *gc.
 
 	subAuthorizer := s.authorizer
 	subAuthorizer.Tag = mysqlLogUnit.Tag()
-	api, err := uniter.NewUniterAPI(
-		s.State,
-		s.resources,
-		subAuthorizer,
-	)
+	api, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      subAuthorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := api.WatchUnitRelations(params.Entities{
@@ -1339,11 +1480,11 @@ func (s *uniterSuite) TestWatchUnitRelationsWithSubSubRelation(c *gc.C) {
 
 	subAuthorizer := s.authorizer
 	subAuthorizer.Tag = monUnit.Tag()
-	api, err := uniter.NewUniterAPI(
-		s.State,
-		s.resources,
-		subAuthorizer,
-	)
+	api, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      subAuthorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := api.WatchUnitRelations(params.Entities{
@@ -1529,7 +1670,11 @@ func (s *uniterSuite) TestActionsWrongUnit(c *gc.C) {
 	mysqlUnitAuthorizer := apiservertesting.FakeAuthorizer{
 		Tag: s.mysqlUnit.Tag(),
 	}
-	mysqlUnitFacade, err := uniter.NewUniterAPI(s.State, s.resources, mysqlUnitAuthorizer)
+	mysqlUnitFacade, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      mysqlUnitAuthorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
@@ -1870,11 +2015,11 @@ func (s *uniterSuite) TestEnterScopeIgnoredForInvalidPrincipals(c *gc.C) {
 
 	subAuthorizer := s.authorizer
 	subAuthorizer.Tag = wpLoggingU.Tag()
-	api, err := uniter.NewUniterAPI(
-		s.State,
-		s.resources,
-		subAuthorizer,
-	)
+	api, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      subAuthorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Count how many relationscopes records there are beforehand.
@@ -2460,11 +2605,11 @@ func (s *uniterSuite) TestWatchCAASUnitAddresses(c *gc.C) {
 		{Tag: "application-gitlab"},
 	}}
 
-	uniterAPI, err := uniter.NewUniterAPI(
-		cm.State(),
-		s.resources,
-		s.authorizer,
-	)
+	uniterAPI, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     cm.State(),
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := uniterAPI.WatchUnitAddresses(args)
@@ -2908,7 +3053,11 @@ func (s *uniterSuite) TestModelEgressSubnets(c *gc.C) {
 func (s *uniterSuite) makeMysqlUniter(c *gc.C) *uniter.UniterAPI {
 	authorizer := s.authorizer
 	authorizer.Tag = s.mysqlUnit.Tag()
-	result, err := uniter.NewUniterAPI(s.State, s.resources, authorizer)
+	result, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	return result
 }
@@ -2938,7 +3087,11 @@ func (s *uniterSuite) TestV4WatchApplicationRelations(c *gc.C) {
 		{Tag: "application-wordpress"},
 		{Tag: "application-foo"},
 	}}
-	apiV4, err := uniter.NewUniterAPIV4(s.State, s.resources, s.authorizer)
+	apiV4, err := uniter.NewUniterAPIV4(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	result, err := apiV4.WatchApplicationRelations(args)
 	c.Assert(err, jc.ErrorIsNil)
@@ -2968,7 +3121,11 @@ func (s *uniterSuite) TestV5Relation(c *gc.C) {
 	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
 		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
 	}}
-	apiV5, err := uniter.NewUniterAPIV5(s.State, s.resources, s.authorizer)
+	apiV5, err := uniter.NewUniterAPIV5(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	result, err := apiV5.Relation(args)
 	c.Assert(err, jc.ErrorIsNil)
@@ -2993,7 +3150,11 @@ func (s *uniterSuite) TestV5RelationById(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	args := params.RelationIds{RelationIds: []int{rel.Id()}}
-	apiV5, err := uniter.NewUniterAPIV5(s.State, s.resources, s.authorizer)
+	apiV5, err := uniter.NewUniterAPIV5(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	result, err := apiV5.RelationById(args)
 	c.Assert(err, jc.ErrorIsNil)
@@ -3116,11 +3277,11 @@ func (s *unitMetricBatchesSuite) SetUpTest(c *gc.C) {
 		Tag: s.meteredUnit.Tag(),
 	}
 	var err error
-	s.uniter, err = uniter.NewUniterAPI(
-		s.State,
-		s.resources,
-		meteredAuthorizer,
-	)
+	s.uniter, err = uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      meteredAuthorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.ModelWatcherTest = commontesting.NewModelWatcherTest(
@@ -3309,11 +3470,11 @@ func (s *uniterNetworkConfigSuite) SetUpTest(c *gc.C) {
 	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
 
 	s.setupUniterAPIForUnit(c, s.wordpressUnit)
-	uniterAPIV4, err := uniter.NewUniterAPIV4(
-		s.State,
-		s.resources,
-		s.authorizer,
-	)
+	uniterAPIV4, err := uniter.NewUniterAPIV4(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.uniterv4 = uniterAPIV4
 }
@@ -3381,11 +3542,11 @@ func (s *uniterNetworkConfigSuite) setupUniterAPIForUnit(c *gc.C, givenUnit *sta
 	}
 
 	var err error
-	s.uniterv4, err = uniter.NewUniterAPIV4(
-		s.State,
-		s.resources,
-		s.authorizer,
-	)
+	s.uniterv4, err = uniter.NewUniterAPIV4(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -3665,11 +3826,11 @@ func (s *uniterNetworkInfoSuite) setupUniterAPIForUnit(c *gc.C, givenUnit *state
 	}
 
 	var err error
-	s.uniter, err = uniter.NewUniterAPI(
-		s.State,
-		s.resources,
-		s.authorizer,
-	)
+	s.uniter, err = uniter.NewUniterAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -4039,7 +4200,11 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoV6Results(c *gc.C) {
 		},
 	}
 
-	apiV6, err := uniter.NewUniterAPIV6(s.State, s.resources, s.authorizer)
+	apiV6, err := uniter.NewUniterAPIV6(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := apiV6.NetworkInfo(args)
@@ -4098,11 +4263,11 @@ func (s *uniterSuite) TestNetworkInfoCAASModelRelation(c *gc.C) {
 		IngressAddresses: []string{"54.32.1.2", "192.168.1.2", "10.0.0.1"},
 	}
 
-	uniterAPI, err := uniter.NewUniterAPI(
-		st,
-		s.resources,
-		s.authorizer,
-	)
+	uniterAPI, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     st,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := uniterAPI.NetworkInfo(args)
@@ -4150,11 +4315,11 @@ func (s *uniterSuite) TestNetworkInfoCAASModelNoRelation(c *gc.C) {
 		IngressAddresses: []string{"54.32.1.2", "192.168.1.2", "10.0.0.1"},
 	}
 
-	uniterAPI, err := uniter.NewUniterAPI(
-		st,
-		s.resources,
-		s.authorizer,
-	)
+	uniterAPI, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:     st,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := uniterAPI.NetworkInfo(args)
@@ -0,0 +1,139 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// EnqueueOperations adds operations that run an action on a number of
+// units, with limits on how many of the units run it at once. The
+// actions are enqueued by the operation runner worker.
+func (a *ActionAPI) EnqueueOperations(args params.EnqueueOperationsArgs) (params.OperationResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+
+	results := params.OperationResults{Results: make([]params.OperationResult, len(args.Operations))}
+	for i, arg := range args.Operations {
+		op, err := a.enqueueOperation(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = makeOperationResult(op)
+	}
+	return results, nil
+}
+
+func (a *ActionAPI) enqueueOperation(arg params.EnqueueOperationArgs) (*state.Operation, error) {
+	opArgs := state.OperationArgs{
+		ActionName:    arg.Name,
+		Parameters:    arg.Parameters,
+		MaxParallel:   arg.MaxParallel,
+		BatchSize:     arg.BatchSize,
+		StopOnFailure: arg.StopOnFailure,
+	}
+	if arg.Application != "" {
+		tag, err := names.ParseApplicationTag(arg.Application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		opArgs.Application = tag.Id()
+	}
+	for _, receiver := range arg.Receivers {
+		tag, err := names.ParseUnitTag(receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		opArgs.Units = append(opArgs.Units, tag.Id())
+	}
+	if arg.Scheduled != nil {
+		opArgs.Scheduled = *arg.Scheduled
+	}
+	return a.model.AddOperation(opArgs)
+}
+
+// Operations returns the operations with the given ids, along with the
+// progress of their actions.
+func (a *ActionAPI) Operations(args params.OperationQueryArgs) (params.OperationResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+
+	results := params.OperationResults{Results: make([]params.OperationResult, len(args.Ids))}
+	for i, id := range args.Ids {
+		op, err := a.model.Operation(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = makeOperationResult(op)
+	}
+	return results, nil
+}
+
+func makeOperationResult(op *state.Operation) params.OperationResult {
+	result := params.OperationResult{
+		Id:            op.Id(),
+		Name:          op.ActionName(),
+		Parameters:    op.Parameters(),
+		MaxParallel:   op.MaxParallel(),
+		BatchSize:     op.BatchSize(),
+		StopOnFailure: op.StopOnFailure(),
+		Enqueued:      op.Enqueued(),
+		Started:       op.Started(),
+		Completed:     op.Completed(),
+		Status:        string(op.Status()),
+		Message:       op.Message(),
+	}
+	if op.Application() != "" {
+		result.Application = names.NewApplicationTag(op.Application()).String()
+	}
+	if scheduled := op.Scheduled(); !scheduled.IsZero() {
+		result.Scheduled = &scheduled
+	}
+	for _, t := range op.Tasks() {
+		task := params.OperationTask{
+			Receiver: names.NewUnitTag(t.Unit).String(),
+			Status:   string(t.Status),
+			Message:  t.Message,
+		}
+		if t.ActionId != "" {
+			task.Action = names.NewActionTag(t.ActionId).String()
+		}
+		result.Tasks = append(result.Tasks, task)
+	}
+	return result
+}
+
+// ActionAPIV2 implements version 2 of the Action facade, which doesn't
+// support operations.
+type ActionAPIV2 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV2{api}, nil
+}
+
+// EnqueueOperations isn't on the V2 API.
+func (*ActionAPIV2) EnqueueOperations(_, _ struct{}) {}
+
+// Operations isn't on the V2 API.
+func (*ActionAPIV2) Operations(_, _ struct{}) {}
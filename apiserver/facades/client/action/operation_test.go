@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) TestBlockEnqueueOperations(c *gc.C) {
+	s.BlockAllChanges(c, "EnqueueOperations")
+	_, err := s.action.EnqueueOperations(params.EnqueueOperationsArgs{})
+	s.AssertBlocked(c, err, "EnqueueOperations")
+}
+
+func (s *actionSuite) TestEnqueueOperations(c *gc.C) {
+	scheduled := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	res, err := s.action.EnqueueOperations(params.EnqueueOperationsArgs{
+		Operations: []params.EnqueueOperationArgs{{
+			Name:          "fakeaction",
+			Application:   s.wordpress.Tag().String(),
+			MaxParallel:   1,
+			BatchSize:     2,
+			StopOnFailure: true,
+			Scheduled:     &scheduled,
+		}, {
+			Name:      "fakeaction",
+			Receivers: []string{s.mysqlUnit.Tag().String()},
+		}, {
+			Name:        "fakeaction",
+			Application: s.wordpressUnit.Tag().String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+
+	result := res.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Enqueued.IsZero(), jc.IsFalse)
+	result.Enqueued = time.Time{}
+	c.Assert(result, jc.DeepEquals, params.OperationResult{
+		Id:            "0",
+		Name:          "fakeaction",
+		Application:   "application-wordpress",
+		MaxParallel:   1,
+		BatchSize:     2,
+		StopOnFailure: true,
+		Scheduled:     &scheduled,
+		Status:        "pending",
+		Tasks: []params.OperationTask{
+			{Receiver: s.wordpressUnit.Tag().String()},
+		},
+	})
+
+	c.Assert(res.Results[1].Error, gc.IsNil)
+	c.Assert(res.Results[1].Tasks, jc.DeepEquals, []params.OperationTask{
+		{Receiver: s.mysqlUnit.Tag().String()},
+	})
+	c.Assert(res.Results[2].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid application tag`)
+}
+
+func (s *actionSuite) TestOperations(c *gc.C) {
+	res, err := s.action.EnqueueOperations(params.EnqueueOperationsArgs{
+		Operations: []params.EnqueueOperationArgs{{
+			Name:      "fakeaction",
+			Receivers: []string{s.wordpressUnit.Tag().String()},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+
+	ops, err := s.action.Operations(params.OperationQueryArgs{
+		Ids: []string{res.Results[0].Id, "42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops.Results, gc.HasLen, 2)
+	c.Assert(ops.Results[0].Id, gc.Equals, res.Results[0].Id)
+	c.Assert(ops.Results[0].Status, gc.Equals, "pending")
+	c.Assert(ops.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
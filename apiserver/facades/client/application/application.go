@@ -62,6 +62,21 @@ type APIv7 struct {
 
 // APIv8 provides the Application API facade for version 8.
 type APIv8 struct {
+	*APIv9
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
+	*APIv10
+}
+
+// APIv10 provides the Application API facade for version 10.
+type APIv10 struct {
+	*APIv11
+}
+
+// APIv11 provides the Application API facade for version 11.
+type APIv11 struct {
 	*APIBase
 }
 
@@ -130,13 +145,43 @@ func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
 }
 
 func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV9(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv8{api}, nil
 }
 
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
+
+// NewFacadeV10 provides the signature required for facade registration
+// for version 10.
+func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
+	api, err := NewFacadeV11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv10{api}, nil
+}
+
+// NewFacadeV11 provides the signature required for facade registration
+// for version 11.
+func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv11{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	model, err := ctx.State().Model()
 	if err != nil {
@@ -205,6 +250,12 @@ func (api *APIBase) checkCanWrite() error {
 	return api.checkPermission(api.modelTag, permission.WriteAccess)
 }
 
+// author returns the name of the user making the API call, for
+// recording in the config history.
+func (api *APIBase) author() string {
+	return api.authorizer.GetAuthTag().Id()
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *APIBase) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -324,7 +375,7 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		return AddTrustSchemaAndDefaults(hookTimeoutFields, hookTimeoutDefaults)
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -332,6 +383,10 @@ func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, s
 	if err != nil {
 		return nil, nil, err
 	}
+	schema, defaults, err = addHookTimeoutSchemaAndDefaults(schema, defaults)
+	if err != nil {
+		return nil, nil, err
+	}
 	return AddTrustSchemaAndDefaults(schema, defaults)
 }
 
@@ -356,6 +411,9 @@ func splitApplicationAndCharmConfig(modelType state.ModelType, inConfig map[stri
 			charmConfig[k] = v
 		}
 	}
+	if err := validateHookTimeout(appConfigAttrs); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	return appConfigAttrs, charmConfig, nil
 }
 
@@ -480,8 +538,9 @@ func deployApplication(
 }
 
 // ApplicationSetSettingsStrings updates the settings for the given application,
-// taking the configuration from a map of strings.
-func ApplicationSetSettingsStrings(application Application, settings map[string]string) error {
+// taking the configuration from a map of strings. The change is recorded
+// in the application's config history as made by the named user.
+func ApplicationSetSettingsStrings(application Application, settings map[string]string, author string) error {
 	ch, _, err := application.Charm()
 	if err != nil {
 		return errors.Trace(err)
@@ -491,7 +550,7 @@ func ApplicationSetSettingsStrings(application Application, settings map[string]
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return application.UpdateCharmConfig(changes)
+	return application.UpdateCharmConfigBy(author, changes)
 }
 
 // parseSettingsCompatible parses setting strings in a way that is
@@ -559,6 +618,8 @@ func (api *APIBase) Update(args params.ApplicationUpdate) error {
 			args.ForceCharmURL,
 			nil, // resource IDs
 			nil, // storage constraints
+			nil, // units
+			0,   // max unavailable
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -571,11 +632,11 @@ func (api *APIBase) Update(args params.ApplicationUpdate) error {
 	}
 	// Set up application's settings.
 	if args.SettingsYAML != "" {
-		if err = applicationSetCharmConfigYAML(args.ApplicationName, app, args.SettingsYAML); err != nil {
+		if err = applicationSetCharmConfigYAML(args.ApplicationName, app, args.SettingsYAML, api.author()); err != nil {
 			return errors.Annotate(err, "setting configuration from YAML")
 		}
 	} else if len(args.SettingsStrings) > 0 {
-		if err = ApplicationSetSettingsStrings(app, args.SettingsStrings); err != nil {
+		if err = ApplicationSetSettingsStrings(app, args.SettingsStrings, api.author()); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -659,6 +720,8 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 		args.ForceUnits,
 		args.ResourceIDs,
 		args.StorageConstraints,
+		args.Units,
+		args.MaxUnavailable,
 	)
 }
 
@@ -716,6 +779,8 @@ func (api *APIBase) applicationSetCharm(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
+	units []string,
+	maxUnavailable int,
 ) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
@@ -738,7 +803,7 @@ func (api *APIBase) applicationSetCharm(
 	if len(storageConstraints) > 0 {
 		stateStorageConstraints = make(map[string]state.StorageConstraints)
 		for name, cons := range storageConstraints {
-			stateCons := state.StorageConstraints{Pool: cons.Pool}
+			stateCons := state.StorageConstraints{Pool: cons.Pool, Snapshot: cons.Snapshot}
 			if cons.Size != nil {
 				stateCons.Size = *cons.Size
 			}
@@ -756,6 +821,8 @@ func (api *APIBase) applicationSetCharm(
 		ForceUnits:         forceUnits,
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
+		Units:              units,
+		MaxUnavailable:     maxUnavailable,
 	}
 	return application.SetCharm(cfg)
 }
@@ -790,7 +857,7 @@ func charmConfigFromGetYaml(yamlContents map[string]interface{}) (charm.Settings
 
 // applicationSetCharmConfigYAML updates the charm config for the
 // given application, taking the configuration from a YAML string.
-func applicationSetCharmConfigYAML(appName string, application Application, settings string) error {
+func applicationSetCharmConfigYAML(appName string, application Application, settings, author string) error {
 	b := []byte(settings)
 	var all map[string]interface{}
 	if err := goyaml.Unmarshal(b, &all); err != nil {
@@ -802,7 +869,7 @@ func applicationSetCharmConfigYAML(appName string, application Application, sett
 		if err != nil {
 			return errors.Annotate(err, "processing YAML generated by get")
 		}
-		return errors.Annotate(application.UpdateCharmConfig(changes), "updating settings with application YAML")
+		return errors.Annotate(application.UpdateCharmConfigBy(author, changes), "updating settings with application YAML")
 	}
 
 	ch, _, err := application.Charm()
@@ -814,7 +881,7 @@ func applicationSetCharmConfigYAML(appName string, application Application, sett
 	if err != nil {
 		return errors.Annotate(err, "creating config from YAML")
 	}
-	return errors.Annotate(application.UpdateCharmConfig(changes), "updating settings")
+	return errors.Annotate(application.UpdateCharmConfigBy(author, changes), "updating settings")
 }
 
 // GetCharmURL returns the charm URL the given application is
@@ -855,7 +922,7 @@ func (api *APIBase) Set(p params.ApplicationSet) error {
 		return err
 	}
 
-	return app.UpdateCharmConfig(changes)
+	return app.UpdateCharmConfigBy(api.author(), changes)
 
 }
 
@@ -875,7 +942,7 @@ func (api *APIBase) Unset(p params.ApplicationUnset) error {
 	for _, option := range p.Options {
 		settings[option] = nil
 	}
-	return app.UpdateCharmConfig(settings)
+	return app.UpdateCharmConfigBy(api.author(), settings)
 }
 
 // CharmRelations implements the server side of Application.CharmRelations.
@@ -924,7 +991,20 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 					"juju config %s %s=<value>", caas.JujuExternalHostNameKey, args.ApplicationName, caas.JujuExternalHostNameKey)
 		}
 	}
-	return app.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.SetExposed()
+	}
+	if api.modelType == state.ModelTypeCAAS {
+		return errors.NotSupportedf("exposing endpoints of a CAAS application")
+	}
+	exposedEndpoints := make(map[string]state.ExposedEndpoint, len(args.ExposedEndpoints))
+	for name, ep := range args.ExposedEndpoints {
+		exposedEndpoints[name] = state.ExposedEndpoint{
+			ExposeToSpaces: ep.ExposeToSpaces,
+			ExposeToCIDRs:  ep.ExposeToCIDRs,
+		}
+	}
+	return app.MergeExposeSettings(exposedEndpoints)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -1793,7 +1873,7 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 		if err != nil {
 			return err
 		}
-		if err := app.UpdateCharmConfig(charmConfigChanges); err != nil {
+		if err := app.UpdateCharmConfigBy(api.author(), charmConfigChanges); err != nil {
 			return errors.Annotate(err, "updating application charm settings")
 		}
 	}
@@ -1848,7 +1928,7 @@ func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
 		}
 	}
 	if len(charmSettings) > 0 {
-		if err := app.UpdateCharmConfig(charmSettings); err != nil {
+		if err := app.UpdateCharmConfigBy(api.author(), charmSettings); err != nil {
 			return errors.Annotate(err, "updating application charm settings")
 		}
 	}
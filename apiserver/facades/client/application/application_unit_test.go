@@ -39,7 +39,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv8
+	api          *application.APIv11
 }
 
 var _ = gc.Suite(&ApplicationSuite{})
@@ -61,7 +61,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv8{api}
+	s.api = &application.APIv11{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -1073,18 +1073,46 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	c.Assert(result.OneError(), jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfig")
+	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfigBy")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, defaults, err = application.AddHookTimeoutSchemaAndDefaults(schema, defaults)
+	c.Assert(err, jc.ErrorIsNil)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
 	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
 	}, []string(nil), schema, defaults)
-	app.CheckCall(c, 1, "UpdateCharmConfig", charm.Settings{"stringOption": "stringVal"})
+	app.CheckCall(c, 1, "UpdateCharmConfigBy", "admin", charm.Settings{"stringOption": "stringVal"})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigHookTimeout(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"hook-timeout": "30m"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UpdateApplicationConfig")
+	c.Assert(app.Calls()[0].Args[0], jc.DeepEquals, coreapplication.ConfigAttributes{
+		"hook-timeout": "30m",
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidHookTimeout(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"hook-timeout": "-1m"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `negative hook-timeout "-1m" not valid`)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
 }
 
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
@@ -1117,17 +1145,19 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfig")
+	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfigBy")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, defaults, err = application.AddHookTimeoutSchemaAndDefaults(schema, defaults)
+	c.Assert(err, jc.ErrorIsNil)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
 	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes(nil),
 		[]string{"juju-external-hostname"}, schema, defaults)
-	app.CheckCall(c, 1, "UpdateCharmConfig", charm.Settings{"stringVal": nil})
+	app.CheckCall(c, 1, "UpdateCharmConfigBy", "admin", charm.Settings{"stringVal": nil})
 }
 
 func (s *ApplicationSuite) TestBlockUnsetApplicationConfig(c *gc.C) {
@@ -1224,3 +1254,42 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	app.CheckCallNames(c, "ApplicationConfig", "SetExposed")
 }
+
+func (s *ApplicationSuite) TestExpose(c *gc.C) {
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.applications["postgresql"].CheckCallNames(c, "SetExposed")
+}
+
+func (s *ApplicationSuite) TestExposeEndpoints(c *gc.C) {
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"":      {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+			"admin": {ExposeToSpaces: []string{"internal"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "MergeExposeSettings")
+	app.CheckCall(c, 0, "MergeExposeSettings", map[string]state.ExposedEndpoint{
+		"":      {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"admin": {ExposeToSpaces: []string{"internal"}},
+	})
+}
+
+func (s *ApplicationSuite) TestCAASExposeEndpointsNotSupported(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{"juju-external-hostname": "exthost"}
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "exposing endpoints of a CAAS application not supported")
+	app.CheckCallNames(c, "ApplicationConfig")
+}
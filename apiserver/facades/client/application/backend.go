@@ -74,10 +74,15 @@ type Application interface {
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
 	SetExposed() error
+	MergeExposeSettings(map[string]state.ExposedEndpoint) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
 	UpdateApplicationSeries(string, bool) error
-	UpdateCharmConfig(charm.Settings) error
+	UpdateCharmConfigBy(string, charm.Settings) error
+	CharmConfigHistory() ([]state.SettingsRevision, error)
+	RevertCharmConfig(int, string) error
+	HaltCharmRollout() error
+	RollbackCharmRollout() error
 	ApplicationConfig() (application.ConfigAttributes, error)
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
 	Scale(int) error
@@ -0,0 +1,56 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// HaltCharmRollout isn't on the v10 API.
+func (u *APIv10) HaltCharmRollout(_, _ struct{}) {}
+
+// RollbackCharmRollout isn't on the v10 API.
+func (u *APIv10) RollbackCharmRollout(_, _ struct{}) {}
+
+// HaltCharmRollout stops any more units of each of the given
+// applications from being upgraded to the charm being rolled out.
+func (api *APIBase) HaltCharmRollout(args params.Entities) (params.ErrorResults, error) {
+	return api.charmRolloutOp(args, Application.HaltCharmRollout)
+}
+
+// RollbackCharmRollout abandons the charm being rolled out to each
+// of the given applications, returning any units that were upgraded
+// to the application's charm.
+func (api *APIBase) RollbackCharmRollout(args params.Entities) (params.ErrorResults, error) {
+	return api.charmRolloutOp(args, Application.RollbackCharmRollout)
+}
+
+func (api *APIBase) charmRolloutOp(args params.Entities, op func(Application) error) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err == nil {
+			err = op(app)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
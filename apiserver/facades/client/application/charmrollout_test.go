@@ -0,0 +1,74 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *ApplicationSuite) TestSetCharmUnits(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Units:           []string{"postgresql/0"},
+		MaxUnavailable:  2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "SetCharm")
+	app.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
+		Charm:          &state.Charm{},
+		Units:          []string{"postgresql/0"},
+		MaxUnavailable: 2,
+	})
+}
+
+func (s *ApplicationSuite) TestHaltCharmRollout(c *gc.C) {
+	results, err := s.api.HaltCharmRollout(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-missing"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+	s.backend.applications["postgresql"].CheckCallNames(c, "HaltCharmRollout")
+}
+
+func (s *ApplicationSuite) TestRollbackCharmRollout(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.SetErrors(errors.NotFoundf("charm rollout"))
+	results, err := s.api.RollbackCharmRollout(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "charm rollout not found")
+	app.CheckCallNames(c, "RollbackCharmRollout")
+}
+
+func (s *ApplicationSuite) TestCharmRolloutPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.RollbackCharmRollout(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestBlockHaltCharmRollout(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.HaltCharmRollout(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
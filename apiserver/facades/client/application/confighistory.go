@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// CharmConfigHistory isn't on the v8 API.
+func (u *APIv8) CharmConfigHistory(_, _ struct{}) {}
+
+// RevertCharmConfig isn't on the v8 API.
+func (u *APIv8) RevertCharmConfig(_, _ struct{}) {}
+
+// CharmConfigHistory returns the recent revisions of the charm config of
+// each of the given applications, oldest first.
+func (api *APIBase) CharmConfigHistory(args params.Entities) (params.CharmConfigHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.CharmConfigHistoryResults{}, errors.Trace(err)
+	}
+	results := params.CharmConfigHistoryResults{
+		Results: make([]params.CharmConfigHistoryResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		revisions, err := api.charmConfigHistory(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Revisions = revisions
+	}
+	return results, nil
+}
+
+func (api *APIBase) charmConfigHistory(tagString string) ([]params.CharmConfigRevision, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history, err := app.CharmConfigHistory()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	revisions := make([]params.CharmConfigRevision, len(history))
+	for i, rev := range history {
+		revisions[i] = params.CharmConfigRevision{
+			Revision: rev.Revision,
+			Config:   rev.Settings,
+			Author:   rev.Author,
+			Created:  rev.Created,
+		}
+	}
+	return revisions, nil
+}
+
+// RevertCharmConfig restores the charm config of each of the given
+// applications to the values it had at an earlier revision.
+func (api *APIBase) RevertCharmConfig(args params.RevertCharmConfigArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		app, err := api.backend.Application(arg.ApplicationName)
+		if err == nil {
+			err = app.RevertCharmConfig(arg.Revision, api.author())
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *ApplicationSuite) TestCharmConfigHistory(c *gc.C) {
+	created := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	app := s.backend.applications["postgresql"]
+	app.configHistory = []state.SettingsRevision{{
+		Revision: 1,
+		Settings: map[string]interface{}{"stringOption": "a"},
+		Created:  created,
+	}, {
+		Revision: 2,
+		Settings: map[string]interface{}{"stringOption": "b"},
+		Author:   "admin",
+		Created:  created.Add(time.Hour),
+	}}
+
+	results, err := s.api.CharmConfigHistory(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-missing"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.CharmConfigHistoryResult{
+		Revisions: []params.CharmConfigRevision{{
+			Revision: 1,
+			Config:   map[string]interface{}{"stringOption": "a"},
+			Created:  created,
+		}, {
+			Revision: 2,
+			Config:   map[string]interface{}{"stringOption": "b"},
+			Author:   "admin",
+			Created:  created.Add(time.Hour),
+		}},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+	app.CheckCallNames(c, "CharmConfigHistory")
+}
+
+func (s *ApplicationSuite) TestCharmConfigHistoryPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.CharmConfigHistory(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestRevertCharmConfig(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.SetErrors(nil, errors.NotFoundf("settings revision 9"))
+	results, err := s.api.RevertCharmConfig(params.RevertCharmConfigArgs{
+		Args: []params.RevertCharmConfigArg{
+			{ApplicationName: "postgresql", Revision: 3},
+			{ApplicationName: "postgresql", Revision: 9},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "settings revision 9 not found")
+	app.CheckCall(c, 0, "RevertCharmConfig", 3, "admin")
+	app.CheckCall(c, 1, "RevertCharmConfig", 9, "admin")
+}
+
+func (s *ApplicationSuite) TestBlockRevertCharmConfig(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.RevertCharmConfig(params.RevertCharmConfigArgs{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
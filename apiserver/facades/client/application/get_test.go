@@ -29,7 +29,7 @@ import (
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
-	applicationAPI *application.APIv8
+	applicationAPI *application.APIv11
 	authorizer     apiservertesting.FakeAuthorizer
 }
 
@@ -57,12 +57,12 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		application.DeployApplication,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv8{api}
+	s.applicationAPI = &application.APIv11{api}
 }
 
 func (s *getSuite) TestClientApplicationGetSmoketestV4(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{s.applicationAPI}}}}
+	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{s.applicationAPI}}}}}}}
 	results, err := v4.Get(params.ApplicationGet{"wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -82,7 +82,7 @@ func (s *getSuite) TestClientApplicationGetSmoketestV4(c *gc.C) {
 
 func (s *getSuite) TestClientApplicationGetSmoketestV5(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v5 := &application.APIv5{&application.APIv6{&application.APIv7{s.applicationAPI}}}
+	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{s.applicationAPI}}}}}}
 	results, err := v5.Get(params.ApplicationGet{"wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -119,6 +119,13 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmoketest(c *gc.C) {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"hook-timeout": map[string]interface{}{
+				"default":     "",
+				"description": "Maximum time a charm hook may run before it is killed",
+				"source":      "default",
+				"type":        environschema.Tstring,
+				"value":       "",
+			},
 			"trust": map[string]interface{}{
 				"default":     false,
 				"description": "Does this application have access to trusted credentials",
@@ -141,6 +148,8 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmoketest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 
+	schemaFields, defaults, err = application.AddHookTimeoutSchemaAndDefaults(schemaFields, defaults)
+	c.Assert(err, jc.ErrorIsNil)
 	schemaFields, defaults, err = application.AddTrustSchemaAndDefaults(schemaFields, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -194,9 +203,9 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmoketest(c *gc.C) {
 		application.DeployApplication,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV8 := &application.APIv8{api}
+	apiV11 := &application.APIv11{api}
 
-	results, err := apiV8.Get(params.ApplicationGet{"dashboard4miner"})
+	results, err := apiV11.Get(params.ApplicationGet{"dashboard4miner"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, params.ApplicationGetResults{
 		Application: "dashboard4miner",
@@ -267,6 +276,13 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"hook-timeout": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "Maximum time a charm hook may run before it is killed",
+				"source":      "default",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -325,6 +341,13 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"hook-timeout": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "Maximum time a charm hook may run before it is killed",
+				"source":      "default",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -342,6 +365,13 @@ var getTests = []struct {
 		CharmConfig: map[string]interface{}{},
 		Series:      "quantal",
 		ApplicationConfig: map[string]interface{}{
+			"hook-timeout": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "Maximum time a charm hook may run before it is killed",
+				"source":      "default",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
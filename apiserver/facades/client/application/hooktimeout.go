@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+)
+
+// HookTimeoutConfigOptionName is the option name used to override the
+// model's hook timeout in application configuration. An empty value
+// means the model's hook-timeout applies.
+const HookTimeoutConfigOptionName = "hook-timeout"
+
+var hookTimeoutFields = environschema.Fields{
+	HookTimeoutConfigOptionName: {
+		Description: "Maximum time a charm hook may run before it is killed",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+var hookTimeoutDefaults = schema.Defaults{
+	HookTimeoutConfigOptionName: "",
+}
+
+// addHookTimeoutSchemaAndDefaults adds the hook timeout schema fields and
+// defaults to an existing set of schema fields and defaults.
+func addHookTimeoutSchemaAndDefaults(extra environschema.Fields, defaults schema.Defaults) (environschema.Fields, schema.Defaults, error) {
+	fields := make(environschema.Fields)
+	for name, field := range hookTimeoutFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := hookTimeoutFields[name]; ok {
+			return nil, nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	newDefaults := make(schema.Defaults)
+	for key, value := range hookTimeoutDefaults {
+		newDefaults[key] = value
+	}
+	for key, value := range defaults {
+		newDefaults[key] = value
+	}
+	return fields, newDefaults, nil
+}
+
+// validateHookTimeout checks that any hook timeout in the supplied
+// application config is a valid, non-negative duration.
+func validateHookTimeout(appConfigAttrs map[string]interface{}) error {
+	v, ok := appConfigAttrs[HookTimeoutConfigOptionName].(string)
+	if !ok || v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return errors.NotValidf("%s %q", HookTimeoutConfigOptionName, v)
+	}
+	if d < 0 {
+		return errors.NotValidf("negative %s %q", HookTimeoutConfigOptionName, v)
+	}
+	return nil
+}
@@ -78,6 +78,8 @@ type mockApplication struct {
 	units       []*mockUnit
 	addedUnit   mockUnit
 	config      coreapplication.ConfigAttributes
+
+	configHistory []state.SettingsRevision
 }
 
 func (m *mockApplication) Name() string {
@@ -170,8 +172,28 @@ func (a *mockApplication) UpdateApplicationConfig(
 	return a.NextErr()
 }
 
-func (a *mockApplication) UpdateCharmConfig(settings charm.Settings) error {
-	a.MethodCall(a, "UpdateCharmConfig", settings)
+func (a *mockApplication) UpdateCharmConfigBy(author string, settings charm.Settings) error {
+	a.MethodCall(a, "UpdateCharmConfigBy", author, settings)
+	return a.NextErr()
+}
+
+func (a *mockApplication) CharmConfigHistory() ([]state.SettingsRevision, error) {
+	a.MethodCall(a, "CharmConfigHistory")
+	return a.configHistory, a.NextErr()
+}
+
+func (a *mockApplication) RevertCharmConfig(revision int, author string) error {
+	a.MethodCall(a, "RevertCharmConfig", revision, author)
+	return a.NextErr()
+}
+
+func (a *mockApplication) HaltCharmRollout() error {
+	a.MethodCall(a, "HaltCharmRollout")
+	return a.NextErr()
+}
+
+func (a *mockApplication) RollbackCharmRollout() error {
+	a.MethodCall(a, "RollbackCharmRollout")
 	return a.NextErr()
 }
 
@@ -180,6 +202,11 @@ func (a *mockApplication) SetExposed() error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) MergeExposeSettings(exposedEndpoints map[string]state.ExposedEndpoint) error {
+	a.MethodCall(a, "MergeExposeSettings", exposedEndpoints)
+	return a.NextErr()
+}
+
 type mockRemoteApplication struct {
 	jtesting.Stub
 	name           string
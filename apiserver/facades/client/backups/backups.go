@@ -126,7 +126,10 @@ func extractResourceValue(resources facade.Resources, key string) (string, error
 
 var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
 	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+	// Archives written by scheduled backups may live in a backup
+	// target rather than in the controller's storage.
+	openTarget := backups.NewControllerTargetOpener(backend)
+	return backups.NewBackupsWithTargets(stor, nil, openTarget), stor
 }
 
 // CreateResult updates the result with the information in the
@@ -148,6 +151,7 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 		result.Finished = *meta.Finished
 	}
 	result.Notes = meta.Notes
+	result.Target = meta.Target
 
 	result.Model = meta.Origin.Model
 	result.Machine = meta.Origin.Machine
@@ -183,6 +187,7 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.Target = result.Target
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
@@ -36,3 +36,13 @@ func (s *backupsSuite) TestListError(c *gc.C) {
 
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) TestListTarget(c *gc.C) {
+	s.meta.Target = "s3://juju-backups/prod"
+	s.setBackups(c, s.meta, "")
+	result, err := s.api.List(params.BackupsListArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(result.List, gc.HasLen, 1)
+	c.Check(result.List[0].Target, gc.Equals, "s3://juju-backups/prod")
+}
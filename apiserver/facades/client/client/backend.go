@@ -98,7 +98,15 @@ type Unit interface {
 // removed once all relevant methods are moved from state to model.
 type stateShim struct {
 	*state.State
-	model *state.Model
+	model   *state.Model
+	leaders func() (map[string]string, error)
+}
+
+// ApplicationLeaders is part of the Backend interface. Leaders are
+// read through the API server's lease manager rather than the
+// state's own.
+func (s *stateShim) ApplicationLeaders() (map[string]string, error) {
+	return s.leaders()
 }
 
 func (s stateShim) UpdateModelConfig(u map[string]interface{}, r []string, a ...state.ValidateConfigFunc) error {
@@ -159,7 +159,7 @@ func newFacade(ctx facade.Context) (*Client, error) {
 	}
 
 	return NewClient(
-		&stateShim{st, model},
+		&stateShim{st, model, ctx.ApplicationLeaders},
 		&poolShim{ctx.StatePool()},
 		&modelconfig.ModelConfigAPIV1{modelConfigAPI},
 		resources,
@@ -418,6 +418,18 @@ func (c *Client) modelStatus() (params.ModelStatusInfo, error) {
 		info.MeterStatus = params.MeterStatus{Color: strings.ToLower(ms.Code.String()), Message: ms.Info}
 	}
 
+	limits, err := m.Quota()
+	if err != nil {
+		return params.ModelStatusInfo{}, errors.Annotate(err, "cannot obtain model quota")
+	}
+	if len(limits) > 0 {
+		usage, err := m.QuotaUsage()
+		if err != nil {
+			return params.ModelStatusInfo{}, errors.Annotate(err, "cannot obtain model quota usage")
+		}
+		info.Quota = &params.ModelQuota{Limits: limits, Usage: usage}
+	}
+
 	return info, nil
 }
 
@@ -975,6 +987,15 @@ func (context *statusContext) processApplication(application *state.Application)
 		Life:         processLife(application),
 		CharmVersion: applicationCharm.Version(),
 	}
+	if exposedEndpoints := application.ExposedEndpoints(); len(exposedEndpoints) > 0 {
+		processedStatus.ExposedEndpoints = make(map[string]params.ExposedEndpoint, len(exposedEndpoints))
+		for name, ep := range exposedEndpoints {
+			processedStatus.ExposedEndpoints[name] = params.ExposedEndpoint{
+				ExposeToSpaces: ep.ExposeToSpaces,
+				ExposeToCIDRs:  ep.ExposeToCIDRs,
+			}
+		}
+	}
 
 	if latestCharm, ok := context.allAppsUnitsCharmBindings.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
 		if latestCharm.Revision() > applicationCharm.URL().Revision {
@@ -991,6 +1012,14 @@ func (context *statusContext) processApplication(application *state.Application)
 	if application.IsPrincipal() {
 		processedStatus.Units = context.processUnits(units, applicationCharm.URL().String())
 	}
+	if rollout, ok := application.CharmRollout(); ok {
+		processedStatus.CharmRollout = &params.CharmRolloutStatus{
+			Charm:          rollout.CharmURL.String(),
+			MaxUnavailable: rollout.MaxUnavailable,
+			Halted:         rollout.Halted,
+		}
+		context.processUnitTargetCharms(units, processedStatus.Units)
+	}
 	var unitNames []string
 	for _, unit := range units {
 		unitNames = append(unitNames, unit.Name())
@@ -1166,6 +1195,27 @@ func (context *statusContext) processUnits(units map[string]*state.Unit, applica
 	return unitsMap
 }
 
+// processUnitTargetCharms records the charm each unit has been chosen
+// to upgrade to while a charm is being rolled out to its application.
+func (context *statusContext) processUnitTargetCharms(units map[string]*state.Unit, unitsMap map[string]params.UnitStatus) {
+	for name, unitStatus := range unitsMap {
+		unit, ok := units[name]
+		if !ok {
+			continue
+		}
+		target, _, err := unit.TargetCharmURL()
+		if err != nil {
+			logger.Debugf("error fetching target charm for unit %q: %v", name, err)
+			continue
+		}
+		curl, _ := unit.CharmURL()
+		if target != nil && (curl == nil || *curl != *target) {
+			unitStatus.TargetCharm = target.String()
+			unitsMap[name] = unitStatus
+		}
+	}
+}
+
 func (context *statusContext) processUnit(unit *state.Unit, applicationCharm string) params.UnitStatus {
 	var result params.UnitStatus
 	if unit.ShouldBeAssigned() {
@@ -325,6 +325,43 @@ func (s *statusUnitTestSuite) TestWorkloadVersionOkWithUnset(c *gc.C) {
 	checkUnitVersion(c, appStatus, unit, "")
 }
 
+func (s *statusUnitTestSuite) TestCharmRollout(c *gc.C) {
+	oldCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql", URL: "cs:quantal/mysql-1"})
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql", URL: "cs:quantal/mysql-2"})
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: oldCharm})
+	var units []*state.Unit
+	for i := 0; i < 3; i++ {
+		unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application, SetCharmURL: true})
+		units = append(units, unit)
+	}
+	err := application.SetCharm(state.SetCharmConfig{
+		Charm: newCharm,
+		Units: []string{units[0].Name(), units[1].Name()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = units[0].SetCharmURL(newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, found := status.Applications[application.Name()]
+	c.Assert(found, jc.IsTrue)
+	c.Check(appStatus.Charm, gc.Equals, "cs:quantal/mysql-1")
+	c.Check(appStatus.CharmRollout, jc.DeepEquals, &params.CharmRolloutStatus{
+		Charm: "cs:quantal/mysql-2",
+	})
+	upgraded := appStatus.Units[units[0].Name()]
+	c.Check(upgraded.Charm, gc.Equals, "cs:quantal/mysql-2")
+	c.Check(upgraded.TargetCharm, gc.Equals, "")
+	upgrading := appStatus.Units[units[1].Name()]
+	c.Check(upgrading.Charm, gc.Equals, "")
+	c.Check(upgrading.TargetCharm, gc.Equals, "cs:quantal/mysql-2")
+	waiting := appStatus.Units[units[2].Name()]
+	c.Check(waiting.Charm, gc.Equals, "")
+	c.Check(waiting.TargetCharm, gc.Equals, "")
+}
+
 func (s *statusUnitTestSuite) TestMigrationInProgress(c *gc.C) {
 
 	// Create a host model because controller models can't be migrated.
@@ -7,8 +7,10 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/txn"
@@ -45,11 +47,18 @@ type ControllerAPI struct {
 	hub        facade.Hub
 }
 
+// ControllerAPIv5 provides the v5 Controller API. The only difference
+// between this and v6 is that v5 doesn't have the MigrationDryRun
+// method.
+type ControllerAPIv5 struct {
+	*ControllerAPI
+}
+
 // ControllerAPIv4 provides the v4 Controller API. The only difference
 // between this and v5 is that v4 doesn't have the
 // UpdateControllerConfig method.
 type ControllerAPIv4 struct {
-	*ControllerAPI
+	*ControllerAPIv5
 }
 
 // ControllerAPIv3 provides the v3 Controller API.
@@ -57,8 +66,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv5 creates a new ControllerAPIv5.
-func NewControllerAPIv5(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv6 creates a new ControllerAPIv6.
+func NewControllerAPIv6(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -76,6 +85,15 @@ func NewControllerAPIv5(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v6, err := NewControllerAPIv6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v6}, nil
+}
+
 // NewControllerAPIv4 creates a new ControllerAPIv4.
 func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
 	v5, err := NewControllerAPIv5(ctx)
@@ -428,38 +446,96 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, targetInfo, err := c.migrationSource(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Release()
 
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.statePool.Get(modelTag.Id())
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// MigrationDryRun runs every check that would be made when migrating
+// each of the specified models, without starting a migration or
+// changing either controller. All of the problems that would block
+// each migration are reported.
+func (c *ControllerAPI) MigrationDryRun(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationDryRunResults, error,
+) {
+	out := params.MigrationDryRunResults{
+		Results: make([]params.MigrationDryRunResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		blockers, err := c.dryRunOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Blockers = blockers
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) dryRunOneMigration(spec params.MigrationSpec) ([]string, error) {
+	hostedState, targetInfo, err := c.migrationSource(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	defer hostedState.Release()
+	blockers, err := runMigrationDryRun(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+	return blockers, errors.Trace(err)
+}
+
+// migrationSource returns the state of the model to be migrated and
+// the details of the controller it would be migrated to. The caller
+// must release the state.
+func (c *ControllerAPI) migrationSource(spec params.MigrationSpec) (*state.PooledState, coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, empty, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return nil, empty, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return nil, empty, errors.NotFoundf("model")
+	}
 
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return nil, empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return nil, empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return nil, empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
 	targetInfo := coremigration.TargetInfo{
@@ -471,20 +547,11 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Macaroons:     macs,
 	}
 
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+	hostedState, err := c.statePool.Get(modelTag.Id())
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, empty, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	return hostedState, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -557,6 +624,9 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 // ConfigSet isn't on the v4 API.
 func (c *ControllerAPIv4) ConfigSet(_, _ struct{}) {}
 
+// MigrationDryRun isn't on the v5 API.
+func (c *ControllerAPIv5) MigrationDryRun(_, _ struct{}) {}
+
 // runMigrationPrechecks runs prechecks on the migration and updates
 // information in targetInfo as needed based on information
 // retrieved from the target controller.
@@ -573,29 +643,97 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 	}
 
 	// Check target controller.
-	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	client, closer, err := openMigrationTarget(targetInfo)
 	if err != nil {
-		return errors.Annotate(err, "connect to target controller")
+		return errors.Trace(err)
 	}
-	defer conn.Close()
+	defer closer()
 	modelInfo, err := makeModelInfo(st, ctlrSt)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	err = client.Prechecks(modelInfo)
+	return errors.Annotate(err, "target prechecks failed")
+}
+
+// runMigrationDryRun runs the same checks as runMigrationPrechecks,
+// then exports the model and asks the target controller whether it
+// could import it. Rather than stopping at the first failure, it
+// returns every problem found.
+var runMigrationDryRun = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) ([]string, error) {
+	var blockers []string
+
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	if err := migration.SourcePrecheck(backend, modelPresence, controllerPresence); err != nil {
+		blockers = append(blockers, fmt.Sprintf("source prechecks failed: %v", err))
+	}
+
+	// Check target controller.
+	client, closer, err := openMigrationTarget(targetInfo)
+	if err != nil {
+		// Nothing else can be checked without the target.
+		return append(blockers, err.Error()), nil
+	}
+	defer closer()
+	modelInfo, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := client.Prechecks(modelInfo); err != nil {
+		blockers = append(blockers, fmt.Sprintf("target prechecks failed: %v", err))
+	}
+
+	// Check the model can be exported, and imported by the target.
+	// Application leaders don't affect whether the model can be
+	// imported, so they're left out.
+	model, err := st.ExportPartial(state.ExportConfig{})
+	if err != nil {
+		return append(blockers, fmt.Sprintf("model export failed: %v", err)), nil
+	}
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return append(blockers, fmt.Sprintf("model serialization failed: %v", err)), nil
+	}
+	importBlockers, err := client.ValidateImport(bytes)
+	if errors.IsNotSupported(err) {
+		return nil, errors.New("target controller API version is too old for a dry run")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "validating import on target controller")
+	}
+	for _, blocker := range importBlockers {
+		blockers = append(blockers, "target import validation failed: "+blocker)
+	}
+	return blockers, nil
+}
+
+// openMigrationTarget connects to the target controller, filling in
+// its CA certificate in targetInfo if it isn't already known. The
+// returned function closes the connection.
+func openMigrationTarget(targetInfo *coremigration.TargetInfo) (*migrationtarget.Client, func(), error) {
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "connect to target controller")
+	}
 	client := migrationtarget.NewClient(conn)
 	if targetInfo.CACert == "" {
 		targetInfo.CACert, err = client.CACert()
 		if err != nil {
+			conn.Close()
 			if !params.IsCodeNotImplemented(err) {
-				return errors.Annotatef(err, "cannot retrieve CA certificate")
+				return nil, nil, errors.Annotatef(err, "cannot retrieve CA certificate")
 			}
 			// If the call's not implemented, it indicates an earlier version
 			// of the controller, which we can't migrate to.
-			return errors.New("controller API version is too old")
+			return nil, nil, errors.New("controller API version is too old")
 		}
 	}
-	err = client.Prechecks(modelInfo)
-	return errors.Annotate(err, "target prechecks failed")
+	return client, func() { conn.Close() }, nil
 }
 
 func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, error) {
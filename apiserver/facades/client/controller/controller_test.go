@@ -64,7 +64,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 	}
 	s.hub = pubsub.NewStructuredHub(nil)
 
-	controller, err := controller.NewControllerAPIv5(
+	controller, err := controller.NewControllerAPIv6(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -570,6 +570,93 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestMigrationDryRun(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetDryRunResult(s, []string{"source prechecks failed: model is dying", `target import validation failed: cloud "aws" not found`}, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: m.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert",
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0].ModelTag, gc.Equals, m.ModelTag().String())
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].Blockers, jc.DeepEquals, []string{
+		"source prechecks failed: model is dying",
+		`target import validation failed: cloud "aws" not found`,
+	})
+
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	// No migration was started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestMigrationDryRunError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetDryRunResult(s, nil, errors.New("boom"))
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+			},
+		}},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+	c.Check(out.Results[0].Blockers, gc.HasLen, 0)
+}
+
+func (s *controllerSuite) TestMigrationDryRunRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	endpoint, err := controller.NewControllerAPIv6(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.MigrationDryRun(params.InitiateMigrationArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -931,7 +1018,7 @@ func (s *controllerSuite) TestConfigSetRequiresSuperUser(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: user.Tag(),
 	}
-	endpoint, err := controller.NewControllerAPIv5(
+	endpoint, err := controller.NewControllerAPIv6(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -18,3 +18,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetDryRunResult(p patcher, blockers []string, err error) {
+	p.PatchValue(&runMigrationDryRun, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) ([]string, error) {
+		return blockers, err
+	})
+}
@@ -18,7 +18,9 @@ type Backend interface {
 	ControllerTag() names.ControllerTag
 	ModelTag() names.ModelTag
 	ModelConfigValues() (config.ConfigValues, error)
-	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	UpdateModelConfigBy(string, map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	ModelConfigHistory() ([]state.SettingsRevision, error)
+	RevertModelConfig(int, string, ...state.ValidateConfigFunc) error
 	Sequences() (map[string]int, error)
 	SetSLA(level, owner string, credentials []byte) error
 	SLALevel() (string, error)
@@ -29,8 +31,16 @@ type stateShim struct {
 	model *state.Model
 }
 
-func (st stateShim) UpdateModelConfig(u map[string]interface{}, r []string, a ...state.ValidateConfigFunc) error {
-	return st.model.UpdateModelConfig(u, r, a...)
+func (st stateShim) UpdateModelConfigBy(author string, u map[string]interface{}, r []string, a ...state.ValidateConfigFunc) error {
+	return st.model.UpdateModelConfigBy(author, u, r, a...)
+}
+
+func (st stateShim) ModelConfigHistory() ([]state.SettingsRevision, error) {
+	return st.model.ModelConfigHistory()
+}
+
+func (st stateShim) RevertModelConfig(revision int, author string, a ...state.ValidateConfigFunc) error {
+	return st.model.RevertModelConfig(revision, author, a...)
 }
 
 func (st stateShim) ModelConfigValues() (config.ConfigValues, error) {
@@ -14,8 +14,8 @@ import (
 	"github.com/juju/juju/permission"
 )
 
-// NewFacade is used for API registration.
-func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+// NewFacadeV3 is used for API registration.
+func NewFacadeV3(ctx facade.Context) (*ModelConfigAPIV3, error) {
 	auth := ctx.Auth()
 
 	model, err := ctx.State().Model()
@@ -25,6 +25,15 @@ func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
 	return NewModelConfigAPI(NewStateBackend(model), auth)
 }
 
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+	api, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 // NewFacadeV1 is used for API registration.
 func NewFacadeV1(ctx facade.Context) (*ModelConfigAPIV1, error) {
 	api, err := NewFacadeV2(ctx)
@@ -35,25 +44,30 @@ func NewFacadeV1(ctx facade.Context) (*ModelConfigAPIV1, error) {
 }
 
 // ModelConfigAPI provides the base implementation of the methods
-// for the V2 and V1 api calls.
+// for the V3, V2 and V1 api calls.
 type ModelConfigAPI struct {
 	backend Backend
 	auth    facade.Authorizer
 	check   *common.BlockChecker
 }
 
-// ModelConfigAPIV2 is currently the latest.
-type ModelConfigAPIV2 struct {
+// ModelConfigAPIV3 is currently the latest.
+type ModelConfigAPIV3 struct {
 	*ModelConfigAPI
 }
 
+// ModelConfigAPIV2 hides V3 functionality
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPIV3
+}
+
 // ModelConfigAPIV1 hides V2 functionality
 type ModelConfigAPIV1 struct {
 	*ModelConfigAPIV2
 }
 
 // NewModelConfigAPI creates a new instance of the ModelConfig Facade.
-func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV2, error) {
+func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV3, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
 	}
@@ -62,7 +76,7 @@ func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelCon
 		auth:    authorizer,
 		check:   common.NewBlockChecker(backend),
 	}
-	return &ModelConfigAPIV2{client}, nil
+	return &ModelConfigAPIV3{client}, nil
 }
 
 func (c *ModelConfigAPI) checkCanWrite() error {
@@ -151,40 +165,9 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 		}
 		return nil
 	}
-	// Only controller admins can set trace level debugging on a model.
-	checkLogTrace := func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
-		spec, ok := updateAttrs["logging-config"]
-		if !ok {
-			return nil
-		}
-		logCfg, err := loggo.ParseConfigString(spec.(string))
-		if err != nil {
-			return errors.Trace(err)
-		}
-		// Does at least one package have TRACE level logging requested.
-		haveTrace := false
-		for _, level := range logCfg {
-			haveTrace = level == loggo.TRACE
-			if haveTrace {
-				break
-			}
-		}
-		// No TRACE level requested, so no need to check for admin.
-		if !haveTrace {
-			return nil
-		}
-		if err := c.isControllerAdmin(); err != nil {
-			if errors.Cause(err) != common.ErrPerm {
-				return errors.Trace(err)
-			}
-			return errors.New("only controller admins can set a model's logging level to TRACE")
-		}
-		return nil
-	}
-
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
-	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace)
+	return c.backend.UpdateModelConfigBy(c.author(), attrs, nil, checkAgentVersion, c.checkLogTrace)
 }
 
 // ModelUnset implements the server-side part of the
@@ -196,7 +179,88 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	return c.backend.UpdateModelConfig(nil, args.Keys)
+	return c.backend.UpdateModelConfigBy(c.author(), nil, args.Keys)
+}
+
+// ModelConfigHistory returns the recorded revisions of the model's
+// config, oldest first.
+func (c *ModelConfigAPI) ModelConfigHistory() (params.ModelConfigHistoryResult, error) {
+	result := params.ModelConfigHistoryResult{}
+	if err := c.canReadModel(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	history, err := c.backend.ModelConfigHistory()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Revisions = make([]params.ModelConfigRevision, len(history))
+	for i, rev := range history {
+		// As in ModelGet, authorized keys are left out.
+		cfg := make(map[string]interface{})
+		for attr, val := range rev.Settings {
+			if attr != config.AuthorizedKeysKey {
+				cfg[attr] = val
+			}
+		}
+		result.Revisions[i] = params.ModelConfigRevision{
+			Revision: rev.Revision,
+			Config:   cfg,
+			Author:   rev.Author,
+			Created:  rev.Created,
+		}
+	}
+	return result, nil
+}
+
+// RevertModelConfig restores the model's config to the values it had at
+// an earlier revision. The agent version is not reverted.
+func (c *ModelConfigAPI) RevertModelConfig(args params.RevertModelConfig) error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.backend.RevertModelConfig(args.Revision, c.author(), c.checkLogTrace)
+}
+
+// author returns the name of the user making changes to the model
+// config, which is recorded in the config history.
+func (c *ModelConfigAPI) author() string {
+	return c.auth.GetAuthTag().Id()
+}
+
+// checkLogTrace ensures that only controller admins can set trace level
+// debugging on a model.
+func (c *ModelConfigAPI) checkLogTrace(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
+	spec, ok := updateAttrs["logging-config"]
+	if !ok {
+		return nil
+	}
+	logCfg, err := loggo.ParseConfigString(spec.(string))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Does at least one package have TRACE level logging requested.
+	haveTrace := false
+	for _, level := range logCfg {
+		haveTrace = level == loggo.TRACE
+		if haveTrace {
+			break
+		}
+	}
+	// No TRACE level requested, so no need to check for admin.
+	if !haveTrace {
+		return nil
+	}
+	if err := c.isControllerAdmin(); err != nil {
+		if errors.Cause(err) != common.ErrPerm {
+			return errors.Trace(err)
+		}
+		return errors.New("only controller admins can set a model's logging level to TRACE")
+	}
+	return nil
 }
 
 // SetSLALevel sets the sla level on the model.
@@ -241,3 +305,9 @@ func (c *ModelConfigAPI) Sequences() (params.ModelSequencesResult, error) {
 
 // Sequences isn't on the V1 API.
 func (a *ModelConfigAPIV1) Sequences(_, _ struct{}) {}
+
+// ModelConfigHistory isn't on the V2 API.
+func (a *ModelConfigAPIV2) ModelConfigHistory(_, _ struct{}) {}
+
+// RevertModelConfig isn't on the V2 API.
+func (a *ModelConfigAPIV2) RevertModelConfig(_, _ struct{}) {}
@@ -4,6 +4,8 @@
 package modelconfig_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -24,7 +26,7 @@ type modelconfigSuite struct {
 	gitjujutesting.IsolationSuite
 	backend    *mockBackend
 	authorizer apiservertesting.FakeAuthorizer
-	api        *modelconfig.ModelConfigAPIV2
+	api        *modelconfig.ModelConfigAPIV3
 }
 
 var _ = gc.Suite(&modelconfigSuite{})
@@ -79,6 +81,7 @@ func (s *modelconfigSuite) TestModelSet(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertConfigValue(c, "some-key", "value")
 	s.assertConfigValue(c, "other-key", "other value")
+	c.Assert(s.backend.author, gc.Equals, "bruce")
 }
 
 func (s *modelconfigSuite) blockAllChanges(c *gc.C, msg string) {
@@ -176,7 +179,7 @@ func (s *modelconfigSuite) TestUserCannotSetLogTrace(c *gc.C) {
 }
 
 func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
-	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil)
+	err := s.backend.UpdateModelConfigBy("", map[string]interface{}{"abc": 123}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	args := params.ModelUnset{[]string{"abc"}}
@@ -186,7 +189,7 @@ func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
 }
 
 func (s *modelconfigSuite) TestBlockModelUnset(c *gc.C) {
-	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil)
+	err := s.backend.UpdateModelConfigBy("", map[string]interface{}{"abc": 123}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.blockAllChanges(c, "TestBlockModelUnset")
 
@@ -202,16 +205,74 @@ func (s *modelconfigSuite) TestModelUnsetMissing(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelConfigHistory(c *gc.C) {
+	created := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.backend.history = []state.SettingsRevision{{
+		Revision: 1,
+		Settings: map[string]interface{}{
+			"ftp-proxy":       "http://proxy",
+			"authorized-keys": testing.FakeAuthKeys,
+		},
+		Created: created,
+	}, {
+		Revision: 2,
+		Settings: map[string]interface{}{
+			"ftp-proxy":       "http://other-proxy",
+			"authorized-keys": testing.FakeAuthKeys,
+		},
+		Author:  "bob",
+		Created: created.Add(time.Hour),
+	}}
+
+	result, err := s.api.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelConfigHistoryResult{
+		Revisions: []params.ModelConfigRevision{{
+			Revision: 1,
+			Config:   map[string]interface{}{"ftp-proxy": "http://proxy"},
+			Created:  created,
+		}, {
+			Revision: 2,
+			Config:   map[string]interface{}{"ftp-proxy": "http://other-proxy"},
+			Author:   "bob",
+			Created:  created.Add(time.Hour),
+		}},
+	})
+}
+
+func (s *modelconfigSuite) TestRevertModelConfig(c *gc.C) {
+	err := s.api.RevertModelConfig(params.RevertModelConfig{Revision: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.reverted, gc.Equals, 3)
+	c.Assert(s.backend.author, gc.Equals, "bruce")
+}
+
+func (s *modelconfigSuite) TestRevertModelConfigReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	err := s.api.RevertModelConfig(params.RevertModelConfig{Revision: 3})
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
+	c.Assert(s.backend.reverted, gc.Equals, 0)
+}
+
+func (s *modelconfigSuite) TestBlockRevertModelConfig(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockRevertModelConfig")
+	err := s.api.RevertModelConfig(params.RevertModelConfig{Revision: 3})
+	s.assertBlocked(c, err, "TestBlockRevertModelConfig")
+}
+
 func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	err := s.api.SetSLALevel(params.ModelSLA{params.ModelSLAInfo{"level", "bob"}, []byte("foobar")})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 type mockBackend struct {
-	cfg config.ConfigValues
-	old *config.Config
-	b   state.BlockType
-	msg string
+	cfg      config.ConfigValues
+	old      *config.Config
+	b        state.BlockType
+	msg      string
+	author   string
+	history  []state.SettingsRevision
+	reverted int
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
@@ -222,7 +283,8 @@ func (m *mockBackend) Sequences() (map[string]int, error) {
 	return nil, nil
 }
 
-func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []string, validate ...state.ValidateConfigFunc) error {
+func (m *mockBackend) UpdateModelConfigBy(author string, update map[string]interface{}, remove []string, validate ...state.ValidateConfigFunc) error {
+	m.author = author
 	for _, validateFunc := range validate {
 		if err := validateFunc(update, remove, m.old); err != nil {
 			return err
@@ -237,6 +299,16 @@ func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []
 	return nil
 }
 
+func (m *mockBackend) ModelConfigHistory() ([]state.SettingsRevision, error) {
+	return m.history, nil
+}
+
+func (m *mockBackend) RevertModelConfig(revision int, author string, validate ...state.ValidateConfigFunc) error {
+	m.reverted = revision
+	m.author = author
+	return nil
+}
+
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	if m.b == t {
 		return &mockBlock{t: t, m: m.msg}, true, nil
@@ -589,12 +589,8 @@ func (st *mockState) ControllerModelTag() names.ModelTag {
 	return st.controllerModel.tag
 }
 
-func (st *mockState) Export() (description.Model, error) {
-	return &fakeModelDescription{UUID: st.model.UUID()}, nil
-}
-
 func (st *mockState) ExportPartial(state.ExportConfig) (description.Model, error) {
-	return st.Export()
+	return &fakeModelDescription{UUID: st.model.UUID()}, nil
 }
 
 func (st *mockState) AllModelUUIDs() ([]string, error) {
@@ -0,0 +1,91 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package quota implements the API facade used by clients to inspect
+// and change the resource quota of a model.
+package quota
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/permission"
+)
+
+// Backend defines the state functionality used by the quota facade.
+// It is implemented by *state.Model.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ControllerTag() names.ControllerTag
+	Quota() (quota.Quota, error)
+	QuotaUsage() (quota.Usage, error)
+	SetQuota(quota.Quota) error
+}
+
+// QuotaAPI is the implementation of the Quota facade.
+type QuotaAPI struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewQuotaAPI creates a QuotaAPI for the model of the connection.
+func NewQuotaAPI(context facade.Context) (*QuotaAPI, error) {
+	model, err := context.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(model, context.Auth())
+}
+
+// NewAPI creates a QuotaAPI using the given backend.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*QuotaAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &QuotaAPI{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// Quota returns the resource limits placed on the model, and the
+// amount of each resource the model is using.
+func (api *QuotaAPI) Quota() (params.ModelQuota, error) {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.ModelQuota{}, errors.Trace(err)
+	}
+	if !canRead {
+		return params.ModelQuota{}, common.ErrPerm
+	}
+	limits, err := api.backend.Quota()
+	if err != nil {
+		return params.ModelQuota{}, errors.Trace(err)
+	}
+	usage, err := api.backend.QuotaUsage()
+	if err != nil {
+		return params.ModelQuota{}, errors.Trace(err)
+	}
+	return params.ModelQuota{
+		Limits: limits,
+		Usage:  usage,
+	}, nil
+}
+
+// SetQuota replaces the resource limits placed on the model. Only
+// controller administrators may change a model's quota, so that the
+// users of a model cannot lift the limits placed on them.
+func (api *QuotaAPI) SetQuota(args params.SetModelQuotaArgs) error {
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return errors.Trace(api.backend.SetQuota(quota.Quota(args.Limits)))
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/quota"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	corequota "github.com/juju/juju/core/quota"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type QuotaSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&QuotaSuite{})
+
+func (s *QuotaSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		quota: corequota.Quota{corequota.Machines: 10},
+		usage: corequota.Usage{corequota.Machines: 3, corequota.Units: 5},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *QuotaSuite) newAPI(c *gc.C) *quota.QuotaAPI {
+	api, err := quota.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *QuotaSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := quota.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *QuotaSuite) TestQuota(c *gc.C) {
+	result, err := s.newAPI(c).Quota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelQuota{
+		Limits: map[string]uint64{"machines": 10},
+		Usage:  map[string]uint64{"machines": 3, "units": 5},
+	})
+	s.backend.CheckCallNames(c, "Quota", "QuotaUsage")
+}
+
+func (s *QuotaSuite) TestQuotaRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.newAPI(c).Quota()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *QuotaSuite) TestQuotaError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.newAPI(c).Quota()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *QuotaSuite) TestSetQuota(c *gc.C) {
+	err := s.newAPI(c).SetQuota(params.SetModelQuotaArgs{
+		Limits: map[string]uint64{"units": 20},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"SetQuota", []interface{}{corequota.Quota{"units": 20}}},
+	})
+}
+
+func (s *QuotaSuite) TestSetQuotaRequiresSuperuser(c *gc.C) {
+	// A model admin may not lift the limits placed on their model.
+	s.authorizer.Tag = names.NewUserTag("admin-" + coretesting.ModelTag.String())
+	s.authorizer.AdminTag = names.NewUserTag("superuser")
+	err := s.newAPI(c).SetQuota(params.SetModelQuotaArgs{
+		Limits: map[string]uint64{"units": 20},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	quota corequota.Quota
+	usage corequota.Usage
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) Quota() (corequota.Quota, error) {
+	b.AddCall("Quota")
+	return b.quota, b.NextErr()
+}
+
+func (b *mockBackend) QuotaUsage() (corequota.Usage, error) {
+	b.AddCall("QuotaUsage")
+	return b.usage, b.NextErr()
+}
+
+func (b *mockBackend) SetQuota(q corequota.Quota) error {
+	b.AddCall("SetQuota", q)
+	return b.NextErr()
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets implements the API facade used by clients to
+// inspect the secrets in a model.
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// SecretsBackend defines the state functionality used by the secrets
+// facade.
+type SecretsBackend interface {
+	ListSecrets(owner string) ([]*secrets.Metadata, error)
+	Secret(id string) (*secrets.Metadata, error)
+	SecretValue(id string, revision int) (secrets.Value, error)
+}
+
+// SecretsAPI is the implementation of the Secrets facade.
+type SecretsAPI struct {
+	backend    SecretsBackend
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+}
+
+// NewSecretsAPI creates a SecretsAPI that reads secret values from the
+// controller database.
+func NewSecretsAPI(context facade.Context) (*SecretsAPI, error) {
+	st := context.State()
+	secretsKey, _ := context.Resources().Get("secretsKey").(common.StringResource)
+	backend := state.NewSecrets(st, state.NewControllerSecretsBackend(st, secretsKey.String()))
+	return NewAPI(backend, context.Auth(), st.ModelTag())
+}
+
+// NewAPI creates a SecretsAPI using the given backend.
+func NewAPI(backend SecretsBackend, authorizer facade.Authorizer, modelTag names.ModelTag) (*SecretsAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &SecretsAPI{
+		backend:    backend,
+		authorizer: authorizer,
+		modelTag:   modelTag,
+	}, nil
+}
+
+func (s *SecretsAPI) checkCanRead() error {
+	canRead, err := s.authorizer.HasPermission(permission.ReadAccess, s.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (s *SecretsAPI) checkIsAdmin() error {
+	isAdmin, err := s.authorizer.HasPermission(permission.AdminAccess, s.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ListSecrets returns the details of the secrets in the model. Secret
+// values are only included if requested, and only for model admins.
+func (s *SecretsAPI) ListSecrets(args params.ListSecretsArgs) (params.ListSecretResults, error) {
+	result := params.ListSecretResults{}
+	if err := s.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.ShowSecrets {
+		if err := s.checkIsAdmin(); err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+
+	var metadata []*secrets.Metadata
+	if len(args.IDs) == 0 {
+		var err error
+		metadata, err = s.backend.ListSecrets("")
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+	for _, id := range args.IDs {
+		md, err := s.backend.Secret(id)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		metadata = append(metadata, md)
+	}
+
+	result.Results = make([]params.ListSecretResult, len(metadata))
+	for i, md := range metadata {
+		r := params.ListSecretResult{
+			ID:          md.ID,
+			OwnerTag:    names.NewApplicationTag(md.Owner).String(),
+			Name:        md.Name,
+			Description: md.Description,
+			Revision:    md.Revision,
+			CreateTime:  md.CreateTime,
+			UpdateTime:  md.UpdateTime,
+		}
+		for _, consumer := range md.Consumers {
+			r.Consumers = append(r.Consumers, params.SecretConsumer{
+				ApplicationTag: names.NewApplicationTag(consumer.Application).String(),
+				RelationTag:    names.NewRelationTag(consumer.RelationKey).String(),
+			})
+		}
+		if args.ShowSecrets {
+			value, err := s.backend.SecretValue(md.ID, md.Revision)
+			r.Value = &params.SecretValueResult{
+				Data:  value,
+				Error: common.ServerError(err),
+			}
+		}
+		result.Results[i] = r
+	}
+	return result, nil
+}
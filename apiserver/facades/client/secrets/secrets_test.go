@@ -0,0 +1,145 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coresecrets "github.com/juju/juju/core/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockSecretsBackend
+	authorizer apiservertesting.FakeAuthorizer
+	now        time.Time
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.now = time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.backend = &mockSecretsBackend{
+		secrets: []*coresecrets.Metadata{{
+			ID:          "secret:mysql/password",
+			Owner:       "mysql",
+			Name:        "password",
+			Description: "root password",
+			Revision:    2,
+			Consumers: []coresecrets.Consumer{{
+				Application: "wordpress",
+				RelationKey: "wordpress:db mysql:server",
+			}},
+			CreateTime: s.now,
+			UpdateTime: s.now.Add(time.Hour),
+		}},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("fred"),
+		AdminTag: names.NewUserTag("fred"),
+	}
+}
+
+func (s *SecretsSuite) newAPI(c *gc.C) *secrets.SecretsAPI {
+	api, err := secrets.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *SecretsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := secrets.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUnitTag("mysql/0"),
+	}, coretesting.ModelTag)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *SecretsSuite) expectedResult() params.ListSecretResult {
+	return params.ListSecretResult{
+		ID:          "secret:mysql/password",
+		OwnerTag:    "application-mysql",
+		Name:        "password",
+		Description: "root password",
+		Revision:    2,
+		Consumers: []params.SecretConsumer{{
+			ApplicationTag: "application-wordpress",
+			RelationTag:    "relation-wordpress.db#mysql.server",
+		}},
+		CreateTime: s.now,
+		UpdateTime: s.now.Add(time.Hour),
+	}
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	results, err := s.newAPI(c).ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ListSecretResults{
+		Results: []params.ListSecretResult{s.expectedResult()},
+	})
+	s.backend.CheckCallNames(c, "ListSecrets")
+}
+
+func (s *SecretsSuite) TestListSecretsShowSecrets(c *gc.C) {
+	results, err := s.newAPI(c).ListSecrets(params.ListSecretsArgs{
+		ShowSecrets: true,
+		IDs:         []string{"secret:mysql/password"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := s.expectedResult()
+	expected.Value = &params.SecretValueResult{
+		Data: map[string]string{"password": "s3cret"},
+	}
+	c.Assert(results, jc.DeepEquals, params.ListSecretResults{
+		Results: []params.ListSecretResult{expected},
+	})
+	s.backend.CheckCallNames(c, "Secret", "SecretValue")
+	s.backend.CheckCall(c, 1, "SecretValue", "secret:mysql/password", 2)
+}
+
+func (s *SecretsSuite) TestListSecretsReadOnlyUser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	api := s.newAPI(c)
+	_, err := api.ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.ListSecrets(params.ListSecretsArgs{ShowSecrets: true})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *SecretsSuite) TestListSecretsNoAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+type mockSecretsBackend struct {
+	testing.Stub
+	secrets []*coresecrets.Metadata
+}
+
+func (b *mockSecretsBackend) ListSecrets(owner string) ([]*coresecrets.Metadata, error) {
+	b.AddCall("ListSecrets", owner)
+	return b.secrets, b.NextErr()
+}
+
+func (b *mockSecretsBackend) Secret(id string) (*coresecrets.Metadata, error) {
+	b.AddCall("Secret", id)
+	return b.secrets[0], b.NextErr()
+}
+
+func (b *mockSecretsBackend) SecretValue(id string, revision int) (coresecrets.Value, error) {
+	b.AddCall("SecretValue", id, revision)
+	return coresecrets.Value{"password": "s3cret"}, b.NextErr()
+}
@@ -27,6 +27,8 @@ type baseStorageSuite struct {
 	authorizer apiservertesting.FakeAuthorizer
 
 	api             *storage.APIv4
+	apiv5           *storage.APIv5
+	apiv6           *storage.APIv6
 	apiv3           *storage.APIv3
 	storageAccessor *mockStorageAccessor
 	state           *mockState
@@ -42,6 +44,7 @@ type baseStorageSuite struct {
 	filesystemTag        names.FilesystemTag
 	filesystem           *mockFilesystem
 	filesystemAttachment *mockFilesystemAttachment
+	volumeSnapshots      []state.VolumeSnapshot
 	stub                 testing.Stub
 
 	registry    jujustorage.StaticProviderRegistry
@@ -68,6 +71,10 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	var err error
 	s.api, err = storage.NewAPIv4(s.state, s.storageAccessor, s.registry, s.poolManager, s.resources, s.authorizer, s.callContext)
 	c.Assert(err, jc.ErrorIsNil)
+	s.apiv5, err = storage.NewAPIv5(s.state, s.storageAccessor, s.registry, s.poolManager, s.resources, s.authorizer, s.callContext)
+	c.Assert(err, jc.ErrorIsNil)
+	s.apiv6, err = storage.NewAPIv6(s.state, s.storageAccessor, s.registry, s.poolManager, s.resources, s.authorizer, s.callContext)
+	c.Assert(err, jc.ErrorIsNil)
 	s.apiv3, err = storage.NewAPIv3(s.state, s.storageAccessor, s.registry, s.poolManager, s.resources, s.authorizer, s.callContext)
 	c.Assert(err, jc.ErrorIsNil)
 }
@@ -99,7 +106,11 @@ const (
 	detachStorageCall                       = "detachStorage"
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	createVolumeSnapshotCall                = "createVolumeSnapshot"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
+	destroyVolumeSnapshotCall               = "destroyVolumeSnapshot"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -279,10 +290,26 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(releaseStorageInstanceCall, tag, destroyAttached)
 			return errors.New("cannae do it")
 		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageInstanceCall, tag, size)
+			return s.stub.NextErr()
+		},
 		addExistingFilesystem: func(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		createVolumeSnapshot: func(tag names.StorageTag) (string, error) {
+			s.stub.AddCall(createVolumeSnapshotCall, tag)
+			return "0/1", s.stub.NextErr()
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.stub.AddCall(allVolumeSnapshotsCall)
+			return s.volumeSnapshots, s.stub.NextErr()
+		},
+		destroyVolumeSnapshot: func(id string) error {
+			s.stub.AddCall(destroyVolumeSnapshotCall, id)
+			return s.stub.NextErr()
+		},
 	}
 }
 
@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -58,9 +60,13 @@ type mockStorageAccessor struct {
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	destroyStorageInstance              func(names.StorageTag, bool) error
 	releaseStorageInstance              func(names.StorageTag, bool) error
+	resizeStorageInstance               func(names.StorageTag, uint64) error
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	createVolumeSnapshot                func(names.StorageTag) (string, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	destroyVolumeSnapshot               func(string) error
 }
 
 func (st *mockStorageAccessor) VolumeAccess() storage.StorageVolume {
@@ -158,6 +164,22 @@ func (st *mockStorageAccessor) ReleaseStorageInstance(tag names.StorageTag, dest
 	return st.releaseStorageInstance(tag, destroyAttached)
 }
 
+func (st *mockStorageAccessor) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
+func (st *mockStorageAccessor) CreateVolumeSnapshot(tag names.StorageTag) (string, error) {
+	return st.createVolumeSnapshot(tag)
+}
+
+func (st *mockStorageAccessor) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockStorageAccessor) DestroyVolumeSnapshot(id string) error {
+	return st.destroyVolumeSnapshot(id)
+}
+
 func (st *mockStorageAccessor) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageAttachment, error) {
 	panic("should not be called")
 }
@@ -407,3 +429,48 @@ func (st *mockState) Unit(unitName string) (storage.Unit, error) {
 	}
 	return nil, errors.NotFoundf(unitName)
 }
+
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id      string
+	storage names.StorageTag
+	volume  names.VolumeTag
+	life    state.Life
+	created time.Time
+	info    *state.VolumeSnapshotInfo
+}
+
+func (s *mockVolumeSnapshot) Id() string {
+	return s.id
+}
+
+func (s *mockVolumeSnapshot) Life() state.Life {
+	return s.life
+}
+
+func (s *mockVolumeSnapshot) StorageInstance() (names.StorageTag, bool) {
+	return s.storage, s.storage != names.StorageTag{}
+}
+
+func (s *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return s.volume
+}
+
+func (s *mockVolumeSnapshot) Pool() string {
+	return "loop-pool"
+}
+
+func (s *mockVolumeSnapshot) Size() uint64 {
+	return 1024
+}
+
+func (s *mockVolumeSnapshot) Created() time.Time {
+	return s.created
+}
+
+func (s *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	if s.info == nil {
+		return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.id)
+	}
+	return *s.info, nil
+}
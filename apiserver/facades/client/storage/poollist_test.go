@@ -48,6 +48,26 @@ func (s *poolSuite) TestList(c *gc.C) {
 	c.Assert(one.Result[0].Provider, gc.Equals, string(provider.LoopProviderType))
 }
 
+func (s *poolSuite) TestListOmitsSecrets(c *gc.C) {
+	var err error
+	s.baseStorageSuite.pools["shared"], err = storage.NewConfig(
+		"shared", provider.AzureFileProviderType, map[string]interface{}{
+			"account": "jujustore",
+			"key":     "c2VjcmV0",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.api.ListPools(params.StoragePoolFilters{[]params.StoragePoolFilter{{}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, jc.DeepEquals, []params.StoragePool{{
+		Name:     "shared",
+		Provider: "azurefile",
+		Attrs:    map[string]interface{}{"account": "jujustore"},
+	}})
+}
+
 func (s *poolSuite) TestListManyResults(c *gc.C) {
 	s.registry.Providers["static"] = nil
 	s.createPools(c, 2)
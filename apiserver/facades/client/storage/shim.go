@@ -21,6 +21,32 @@ import (
 // to change any part of it so that it were no longer *obviously* and
 // *trivially* correct, you would be Doing It Wrong.
 
+// NewFacadeV6 provides the signature required for facade registration.
+func NewFacadeV6(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv6, error) {
+	v5, err := NewFacadeV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv6{v5}, nil
+}
+
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	v4, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{v4}, nil
+}
+
 // NewFacadeV4 provides the signature required for facade registration.
 func NewFacadeV4(
 	st *state.State,
@@ -112,6 +138,21 @@ type storageInterface interface {
 
 	// ReleaseStorageInstance releases the storage instance with the specified tag.
 	ReleaseStorageInstance(names.StorageTag, bool) error
+
+	// ResizeStorageInstance requests that the storage instance with the
+	// specified tag be grown to the specified size in MiB.
+	ResizeStorageInstance(names.StorageTag, uint64) error
+
+	// CreateVolumeSnapshot requests a snapshot of the volume assigned
+	// to the storage instance with the specified tag.
+	CreateVolumeSnapshot(names.StorageTag) (string, error)
+
+	// AllVolumeSnapshots returns all volume snapshots in the model.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// DestroyVolumeSnapshot destroys the volume snapshot with the
+	// specified ID.
+	DestroyVolumeSnapshot(string) error
 }
 
 type storageVolume interface {
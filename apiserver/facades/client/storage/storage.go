@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 )
 
 // APIv3 implements the storage v3 API.
@@ -38,6 +39,50 @@ type APIv4 struct {
 	*APIv3
 }
 
+// APIv5 implements the storage v5 API.
+type APIv5 struct {
+	*APIv4
+}
+
+// APIv6 implements the storage v6 API.
+type APIv6 struct {
+	*APIv5
+}
+
+// NewAPIv6 returns a new storage v6 API facade.
+func NewAPIv6(
+	backend backend,
+	storageAccess storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	callContext context.ProviderCallContext,
+) (*APIv6, error) {
+	apiv5, err := NewAPIv5(backend, storageAccess, registry, pm, resources, authorizer, callContext)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv6{apiv5}, nil
+}
+
+// NewAPIv5 returns a new storage v5 API facade.
+func NewAPIv5(
+	backend backend,
+	storageAccess storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	callContext context.ProviderCallContext,
+) (*APIv5, error) {
+	apiv4, err := NewAPIv4(backend, storageAccess, registry, pm, resources, authorizer, callContext)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv5{apiv4}, nil
+}
+
 // NewAPIv4 returns a new storage v4 API facade.
 func NewAPIv4(
 	backend backend,
@@ -361,6 +406,12 @@ func filterProviders(
 	return all
 }
 
+// secretPoolAttributes holds, by provider type, the pool attributes
+// that hold credentials, which aren't returned when listing pools.
+var secretPoolAttributes = map[storage.ProviderType][]string{
+	provider.AzureFileProviderType: {provider.AzureFileKey},
+}
+
 func filterPools(
 	pools []*storage.Config,
 	matches func(n, p string) bool,
@@ -371,10 +422,14 @@ func filterPools(
 	all := make([]params.StoragePool, 0, len(pools))
 	for _, p := range pools {
 		if matches(p.Name(), string(p.Provider())) {
+			attrs := p.Attrs()
+			for _, name := range secretPoolAttributes[p.Provider()] {
+				delete(attrs, name)
+			}
 			all = append(all, params.StoragePool{
 				Name:     p.Name(),
 				Provider: string(p.Provider()),
-				Attrs:    p.Attrs(),
+				Attrs:    attrs,
 			})
 		}
 	}
@@ -782,7 +837,7 @@ func (a *APIv3) addToUnit(args params.StoragesAddParams) (params.AddStorageResul
 	}
 
 	paramsToState := func(p params.StorageConstraints) state.StorageConstraints {
-		s := state.StorageConstraints{Pool: p.Pool}
+		s := state.StorageConstraints{Pool: p.Pool, Snapshot: p.Snapshot}
 		if p.Size != nil {
 			s.Size = *p.Size
 		}
@@ -872,6 +927,137 @@ func (a *APIv3) remove(args params.RemoveStorage) (params.ErrorResults, error) {
 	return params.ErrorResults{result}, nil
 }
 
+// Resize requests that the specified storage instances be grown to the
+// given sizes. The storage provisioner grows the underlying volumes, and
+// the filesystems on them, while the storage remains attached.
+func (a *APIv5) Resize(args params.ResizeStorage) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].Error = common.ServerError(
+			a.storageAccess.ResizeStorageInstance(tag, arg.Size),
+		)
+	}
+	return params.ErrorResults{result}, nil
+}
+
+// CreateSnapshots requests snapshots of the volumes assigned to the
+// specified storage instances, returning the new snapshots' IDs. The
+// storage provisioner takes the snapshots.
+func (a *APIv6) CreateSnapshots(args params.Entities) (params.StringResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.StringResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		id, err := a.storageAccess.CreateVolumeSnapshot(tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].Result = id
+	}
+	return params.StringResults{result}, nil
+}
+
+// ListSnapshots returns a list of volume snapshots in the model that
+// match each specified filter. An empty filter matches all snapshots.
+func (a *APIv6) ListSnapshots(args params.VolumeSnapshotFilters) (params.VolumeSnapshotDetailsListResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.VolumeSnapshotDetailsListResults{}, errors.Trace(err)
+	}
+	snapshots, err := a.storageAccess.AllVolumeSnapshots()
+	if err != nil {
+		return params.VolumeSnapshotDetailsListResults{}, errors.Trace(err)
+	}
+
+	results := params.VolumeSnapshotDetailsListResults{
+		Results: make([]params.VolumeSnapshotDetailsListResult, len(args.Filters)),
+	}
+	for i, filter := range args.Filters {
+		storageTags := set.NewStrings(filter.StorageTags...)
+		var details []params.VolumeSnapshotDetails
+		for _, s := range snapshots {
+			var storageTag string
+			if tag, ok := s.StorageInstance(); ok {
+				storageTag = tag.String()
+			}
+			if !storageTags.IsEmpty() && !storageTags.Contains(storageTag) {
+				continue
+			}
+			details = append(details, volumeSnapshotDetails(s, storageTag))
+		}
+		results.Results[i].Result = details
+	}
+	return results, nil
+}
+
+func volumeSnapshotDetails(s state.VolumeSnapshot, storageTag string) params.VolumeSnapshotDetails {
+	details := params.VolumeSnapshotDetails{
+		Id:         s.Id(),
+		StorageTag: storageTag,
+		VolumeTag:  s.Volume().String(),
+		Pool:       s.Pool(),
+		Size:       s.Size(),
+		Life:       params.Life(s.Life().String()),
+		Created:    s.Created(),
+	}
+	if info, err := s.Info(); err == nil {
+		details.SnapshotId = info.SnapshotId
+	}
+	return details
+}
+
+// RemoveSnapshots sets the specified volume snapshots to Dying. The
+// storage provisioner destroys the snapshots, and then removes them
+// from the model.
+func (a *APIv6) RemoveSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		if !storage.IsValidSnapshotId(id) {
+			result[i].Error = common.ServerError(errors.NotValidf("snapshot ID %q", id))
+			continue
+		}
+		result[i].Error = common.ServerError(
+			a.storageAccess.DestroyVolumeSnapshot(id),
+		)
+	}
+	return params.ErrorResults{result}, nil
+}
+
 // Detach sets the specified storage attachments to Dying, unless they are
 // already Dying or Dead. Any associated, persistent storage will remain
 // alive.
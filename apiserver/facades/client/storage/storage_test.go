@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -343,6 +344,137 @@ func (s *storageSuite) TestRemove(c *gc.C) {
 	s.stub.CheckCall(c, 4, releaseStorageInstanceCall, names.NewStorageTag("foo/1"), true)
 }
 
+func (s *storageSuite) TestResize(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotValidf("new size"))
+	results, err := s.apiv5.Resize(params.ResizeStorage{[]params.ResizeStorageInstance{
+		{Tag: "storage-data-0", Size: 2048},
+		{Tag: "storage-data-1", Size: 512},
+		{Tag: "volume-0", Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "new size not valid"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{resizeStorageInstanceCall, []interface{}{s.storageTag, uint64(2048)}},
+		{resizeStorageInstanceCall, []interface{}{names.NewStorageTag("data/1"), uint64(512)}},
+	})
+}
+
+func (s *storageSuite) TestResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "resize blocked")
+	_, err := s.apiv5.Resize(params.ResizeStorage{[]params.ResizeStorageInstance{
+		{Tag: "storage-data-0", Size: 2048},
+	}})
+	s.assertBlocked(c, err, "resize blocked")
+}
+
+func (s *storageSuite) TestCreateSnapshots(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotSupportedf("snapshotting filesystem storage"))
+	results, err := s.apiv6.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+		{Tag: "storage-data-1"},
+		{Tag: "volume-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StringResult{
+		{Result: "0/1"},
+		{Error: &params.Error{
+			Code:    params.CodeNotSupported,
+			Message: "snapshotting filesystem storage not supported",
+		}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{createVolumeSnapshotCall, []interface{}{s.storageTag}},
+		{createVolumeSnapshotCall, []interface{}{names.NewStorageTag("data/1")}},
+	})
+}
+
+func (s *storageSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "snapshot blocked")
+	_, err := s.apiv6.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+	}})
+	s.assertBlocked(c, err, "snapshot blocked")
+}
+
+func (s *storageSuite) TestListSnapshots(c *gc.C) {
+	created := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.volumeSnapshots = []state.VolumeSnapshot{
+		&mockVolumeSnapshot{
+			id:      "0/1",
+			storage: s.storageTag,
+			volume:  names.NewVolumeTag("0/0"),
+			life:    state.Alive,
+			created: created,
+			info:    &state.VolumeSnapshotInfo{SnapshotId: "snapshot-0-1", Size: 1024},
+		},
+		&mockVolumeSnapshot{
+			id:      "2",
+			storage: names.NewStorageTag("data/1"),
+			volume:  names.NewVolumeTag("3"),
+			life:    state.Dying,
+			created: created,
+		},
+	}
+	results, err := s.apiv6.ListSnapshots(params.VolumeSnapshotFilters{[]params.VolumeSnapshotFilter{
+		{},
+		{StorageTags: []string{"storage-data-1"}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	first := params.VolumeSnapshotDetails{
+		Id:         "0/1",
+		StorageTag: "storage-data-0",
+		VolumeTag:  "volume-0-0",
+		Pool:       "loop-pool",
+		Size:       1024,
+		SnapshotId: "snapshot-0-1",
+		Life:       params.Alive,
+		Created:    created,
+	}
+	second := params.VolumeSnapshotDetails{
+		Id:         "2",
+		StorageTag: "storage-data-1",
+		VolumeTag:  "volume-3",
+		Pool:       "loop-pool",
+		Size:       1024,
+		Life:       params.Dying,
+		Created:    created,
+	}
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotDetailsListResult{
+		{Result: []params.VolumeSnapshotDetails{first, second}},
+		{Result: []params.VolumeSnapshotDetails{second}},
+	})
+	s.stub.CheckCallNames(c, allVolumeSnapshotsCall)
+}
+
+func (s *storageSuite) TestRemoveSnapshots(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotFoundf("volume snapshot %q", "1"))
+	results, err := s.apiv6.RemoveSnapshots(params.VolumeSnapshotIds{[]string{
+		"0/1", "1", "snap",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{
+			Code:    params.CodeNotFound,
+			Message: `volume snapshot "1" not found`,
+		}},
+		{Error: &params.Error{Message: `snapshot ID "snap" not valid`}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.RemoveBlock}},
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{destroyVolumeSnapshotCall, []interface{}{"0/1"}},
+		{destroyVolumeSnapshotCall, []interface{}{"1"}},
+	})
+}
+
 func (s *storageSuite) TestDestroyV3(c *gc.C) {
 	results, err := s.apiv3.Destroy(params.Entities{[]params.Entity{
 		{Tag: "storage-foo-0"},
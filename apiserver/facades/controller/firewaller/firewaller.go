@@ -4,6 +4,8 @@
 package firewaller
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -51,6 +53,11 @@ type FirewallerAPIV5 struct {
 	*FirewallerAPIV4
 }
 
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+}
+
 // NewStateFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -90,6 +97,17 @@ func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{
+		FirewallerAPIV5: facadev5,
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -209,7 +227,7 @@ func (f *FirewallerAPIV3) watchOneModelOpenedPorts(tag names.Tag) (string, []str
 
 // GetMachinePorts returns the port ranges opened on a machine for the specified
 // subnet as a map mapping port ranges to the tags of the units that opened
-// them.
+// them, along with the endpoints they were opened for.
 func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (params.MachinePortsResults, error) {
 	result := params.MachinePortsResults{
 		Results: make([]params.MachinePortsResult, len(args.Params)),
@@ -243,26 +261,43 @@ func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (param
 			continue
 		}
 		if ports != nil {
-			portRangeMap := ports.AllPortRanges()
-			var portRanges []network.PortRange
-			for portRange := range portRangeMap {
-				portRanges = append(portRanges, portRange)
-			}
-			network.SortPortRanges(portRanges)
-
-			for _, portRange := range portRanges {
-				unitTag := names.NewUnitTag(portRangeMap[portRange]).String()
-				result.Results[i].Ports = append(result.Results[i].Ports,
-					params.MachinePortRange{
-						UnitTag:   unitTag,
-						PortRange: params.FromNetworkPortRange(portRange),
-					})
-			}
+			result.Results[i].Ports = machinePortRanges(ports.AllPorts())
 		}
 	}
 	return result, nil
 }
 
+// machinePortRanges returns the given port ranges sorted by port range
+// and then by endpoint.
+func machinePortRanges(ports []state.PortRange) []params.MachinePortRange {
+	endpointRanges := make(map[network.PortRange][]state.PortRange)
+	var portRanges []network.PortRange
+	for _, portRange := range ports {
+		rawRange := portRange.NetworkPortRange()
+		if _, ok := endpointRanges[rawRange]; !ok {
+			portRanges = append(portRanges, rawRange)
+		}
+		endpointRanges[rawRange] = append(endpointRanges[rawRange], portRange)
+	}
+	network.SortPortRanges(portRanges)
+
+	var result []params.MachinePortRange
+	for _, rawRange := range portRanges {
+		ranges := endpointRanges[rawRange]
+		sort.Slice(ranges, func(i, j int) bool {
+			return ranges[i].Endpoint < ranges[j].Endpoint
+		})
+		for _, portRange := range ranges {
+			result = append(result, params.MachinePortRange{
+				UnitTag:   names.NewUnitTag(portRange.UnitName).String(),
+				PortRange: params.FromNetworkPortRange(rawRange),
+				Endpoint:  portRange.Endpoint,
+			})
+		}
+	}
+	return result
+}
+
 // GetMachineActiveSubnets returns the tags of the all subnets that each machine
 // (in args) has open ports on.
 func (f *FirewallerAPIV3) GetMachineActiveSubnets(args params.Entities) (params.StringsResults, error) {
@@ -532,3 +567,58 @@ func (f *FirewallerAPIV5) AreManuallyProvisioned(args params.Entities) (params.B
 	}
 	return result, nil
 }
+
+// GetExposeInfo returns whether each given application is exposed and,
+// if so, the spaces and CIDRs that may access the ports opened for each
+// of its endpoints.
+func (f *FirewallerAPIV6) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i], err = f.exposeInfo(application)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (f *FirewallerAPIV6) exposeInfo(application *state.Application) (params.ExposeInfoResult, error) {
+	if !application.IsExposed() {
+		return params.ExposeInfoResult{}, nil
+	}
+	exposedEndpoints := application.ExposedEndpoints()
+	if len(exposedEndpoints) == 0 {
+		return params.ExposeInfoResult{Exposed: true}, nil
+	}
+	result := params.ExposeInfoResult{
+		Exposed:          true,
+		ExposedEndpoints: make(map[string]params.ExposedEndpoint, len(exposedEndpoints)),
+	}
+	for name, ep := range exposedEndpoints {
+		cidrs := append([]string(nil), ep.ExposeToCIDRs...)
+		for _, spaceName := range ep.ExposeToSpaces {
+			spaceCIDRs, err := f.st.SpaceSubnetCIDRs(spaceName)
+			if err != nil {
+				return params.ExposeInfoResult{}, errors.Trace(err)
+			}
+			cidrs = append(cidrs, spaceCIDRs...)
+		}
+		result.ExposedEndpoints[name] = params.ExposedEndpoint{
+			ExposeToSpaces: ep.ExposeToSpaces,
+			ExposeToCIDRs:  cidrs,
+		}
+	}
+	return result, nil
+}
@@ -197,6 +197,40 @@ func (s *firewallerSuite) TestGetMachinePorts(c *gc.C) {
 
 }
 
+func (s *firewallerSuite) TestGetMachinePortsWithEndpoints(c *gc.C) {
+	err := s.units[0].OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenEndpointPorts("db", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPort("tcp", 22)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.MachinePortsParams{
+		Params: []params.MachinePorts{
+			{MachineTag: s.machines[0].Tag().String(), SubnetTag: ""},
+		},
+	}
+	result, err := s.firewaller.GetMachinePorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	unit0Tag := s.units[0].Tag().String()
+	c.Assert(result, jc.DeepEquals, params.MachinePortsResults{
+		Results: []params.MachinePortsResult{{
+			Ports: []params.MachinePortRange{{
+				UnitTag:   unit0Tag,
+				PortRange: params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+			}, {
+				UnitTag:   unit0Tag,
+				PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				Endpoint:  "db",
+			}, {
+				UnitTag:   unit0Tag,
+				PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				Endpoint:  "url",
+			}},
+		}},
+	})
+}
+
 func (s *firewallerSuite) TestGetMachineActiveSubnets(c *gc.C) {
 	s.openPorts(c)
 
@@ -270,3 +304,52 @@ func (s *firewallerSuite) TestAreManuallyProvisioned(c *gc.C) {
 		},
 	})
 }
+
+func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
+	_, err := s.State.AddSpace("admin", "", []string{"10.20.30.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"":    {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+		"url": {ExposeToSpaces: []string{"admin"}, ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+		{Tag: mysql.Tag().String()},
+	}})
+	result, err := apiv6.GetExposeInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{
+			{
+				Exposed: true,
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"": {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+					"url": {
+						ExposeToSpaces: []string{"admin"},
+						ExposeToCIDRs:  []string{"10.0.0.0/24", "10.20.30.0/24"},
+					},
+				},
+			},
+			{Exposed: true},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
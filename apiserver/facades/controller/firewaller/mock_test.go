@@ -112,6 +112,12 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 	return nil, errors.NotImplementedf("FindEntity")
 }
 
+func (st *mockState) SpaceSubnetCIDRs(spaceName string) ([]string, error) {
+	st.MethodCall(st, "SpaceSubnetCIDRs", spaceName)
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil, errors.NotImplementedf("SpaceSubnetCIDRs")
+}
+
 func (st *mockState) FirewallRule(service state.WellKnownServiceType) (*state.FirewallRule, error) {
 	r, ok := st.firewallRules[service]
 	if !ok {
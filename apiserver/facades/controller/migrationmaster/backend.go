@@ -4,10 +4,10 @@
 package migrationmaster
 
 import (
+	"github.com/juju/description"
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
-	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
 
@@ -22,5 +22,7 @@ type Backend interface {
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
 
-	migration.StateExporter
+	// Export generates an abstract representation of the model,
+	// including its application leaders.
+	Export() (description.Model, error)
 }
@@ -4,6 +4,7 @@
 package migrationmaster
 
 import (
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
@@ -22,7 +23,7 @@ func NewFacade(ctx facade.Context) (*API, error) {
 		return nil, errors.Annotate(err, "creating precheck backend")
 	}
 	return NewAPI(
-		&backendShim{ctx.State()},
+		&backendShim{ctx.State(), ctx.ApplicationLeaders},
 		precheckBackend,
 		migration.PoolShim(ctx.StatePool()),
 		ctx.Resources(),
@@ -35,6 +36,17 @@ func NewFacade(ctx facade.Context) (*API, error) {
 // untested, but is simple enough to be verified by inspection.
 type backendShim struct {
 	*state.State
+	leaders func() (map[string]string, error)
+}
+
+// Export implements Backend. The application leaders are read from
+// the controller's lease manager.
+func (s *backendShim) Export() (description.Model, error) {
+	leaders, err := s.leaders()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.State.Export(leaders)
 }
 
 // ModelName implements Backend.
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/collections/set"
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -32,14 +33,29 @@ type API struct {
 	resources   facade.Resources
 	presence    facade.Presence
 	getEnviron  stateenvirons.NewEnvironFunc
+	getClaimer  migration.ClaimerFunc
 	callContext context.ProviderCallContext
 }
 
+// APIv1 implements the v1 API. It doesn't have ValidateImport.
+type APIv1 struct {
+	*API
+}
+
 // NewFacade is used for API registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	return NewAPI(ctx, stateenvirons.GetNewEnvironFunc(environs.New), state.CallContext(ctx.State()))
 }
 
+// NewFacadeV1 is used for v1 API registration.
+func NewFacadeV1(ctx facade.Context) (*APIv1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, callCtx context.ProviderCallContext) (*API, error) {
@@ -55,6 +71,7 @@ func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, callCtx
 		resources:   ctx.Resources(),
 		presence:    ctx.Presence(),
 		getEnviron:  getEnviron,
+		getClaimer:  ctx.ModelLeadershipClaimer,
 		callContext: callCtx,
 	}, nil
 }
@@ -106,7 +123,7 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
-	_, st, err := migration.ImportModel(api.state, serialized.Bytes)
+	_, st, err := migration.ImportModel(api.state, api.getClaimer, serialized.Bytes)
 	if err != nil {
 		return err
 	}
@@ -117,6 +134,23 @@ func (api *API) Import(serialized params.SerializedModel) error {
 	return err
 }
 
+// ValidateImport checks whether the serialized model could be imported
+// by Import, without changing anything. Every problem found is
+// reported, rather than just the first.
+func (api *API) ValidateImport(serialized params.SerializedModel) (params.ImportValidationResult, error) {
+	var result params.ImportValidationResult
+	model, err := description.Deserialize(serialized.Bytes)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	backend := migration.ImportValidationShim(api.pool.SystemState())
+	result.Blockers, err = migration.ValidateImport(backend, model)
+	return result, errors.Trace(err)
+}
+
+// ValidateImport isn't on the v1 API.
+func (api *APIv1) ValidateImport(_, _ struct{}) {}
+
 func (api *API) getModel(modelTag string) (*state.Model, func(), error) {
 	tag, err := names.ParseModelTag(modelTag)
 	if err != nil {
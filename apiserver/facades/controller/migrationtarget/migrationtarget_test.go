@@ -62,7 +62,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 }
 
 func (s *Suite) TestFacadeRegistered(c *gc.C) {
-	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 1)
+	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 2)
 	c.Assert(err, jc.ErrorIsNil)
 
 	api, err := factory(&facadetest.Context{
@@ -74,6 +74,19 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
+func (s *Suite) TestFacadeV1Registered(c *gc.C) {
+	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIv1))
+}
+
 func (s *Suite) TestNotUser(c *gc.C) {
 	s.authorizer.Tag = names.NewMachineTag("0")
 	_, err := s.newAPI(nil)
@@ -139,6 +152,39 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestValidateImport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, bytes := s.makeExportedModel(c)
+	result, err := api.ValidateImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Blockers, gc.HasLen, 0)
+
+	// Nothing was imported.
+	uuids, err := s.State.AllModelUUIDs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuids, gc.HasLen, 1)
+}
+
+func (s *Suite) TestValidateImportBlocked(c *gc.C) {
+	api := s.mustNewAPI(c)
+	model, err := s.State.Export(map[string]string{})
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.ValidateImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Blockers, jc.DeepEquals, []string{
+		"model with same UUID already exists (" + s.State.ModelUUID() + ")",
+	})
+}
+
+func (s *Suite) TestValidateImportBadBytes(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.ValidateImport(params.SerializedModel{Bytes: []byte("rubbish")})
+	c.Assert(err, gc.NotNil)
+}
+
 func (s *Suite) TestAbort(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -442,7 +488,7 @@ func (s *Suite) mustNewAPIWithModel(c *gc.C, env environs.Environ) *migrationtar
 }
 
 func (s *Suite) makeExportedModel(c *gc.C) (string, []byte) {
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	newUUID := utils.MustNewUUID().String()
@@ -0,0 +1,18 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationrunner
+
+import (
+	"github.com/juju/juju/state"
+)
+
+type Patcher interface {
+	PatchValue(ptr, value interface{})
+}
+
+func PatchState(p Patcher, st StateInterface) {
+	p.PatchValue(&getState, func(*state.State) (StateInterface, error) {
+		return st, nil
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package operationrunner implements the API used by the operation
+// runner worker.
+package operationrunner
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// OperationRunnerAPI implements the API used by the operation runner
+// worker.
+type OperationRunnerAPI struct {
+	st        StateInterface
+	resources facade.Resources
+}
+
+// NewOperationRunnerAPI creates a new instance of the OperationRunner
+// API.
+func NewOperationRunnerAPI(
+	st *state.State,
+	res facade.Resources,
+	authorizer facade.Authorizer,
+) (*OperationRunnerAPI, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	shim, err := getState(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OperationRunnerAPI{
+		st:        shim,
+		resources: res,
+	}, nil
+}
+
+// WatchOperations returns a watcher that fires when operations are
+// added or changed.
+func (api *OperationRunnerAPI) WatchOperations() (params.NotifyWatchResult, error) {
+	watch := api.st.WatchOperations()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
+
+// AdvanceOperations enqueues the next actions for each unfinished
+// operation, and reports how many operations are still running and
+// when the next scheduled one is due.
+func (api *OperationRunnerAPI) AdvanceOperations() (params.OperationProgressResult, error) {
+	progress, err := api.st.AdvanceOperations()
+	if err != nil {
+		return params.OperationProgressResult{Error: common.ServerError(err)}, nil
+	}
+	result := params.OperationProgressResult{Running: progress.Running}
+	if !progress.NextScheduled.IsZero() {
+		result.NextScheduled = &progress.NextScheduled
+	}
+	return result, nil
+}
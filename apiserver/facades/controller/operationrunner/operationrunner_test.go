@@ -0,0 +1,133 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationrunner_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/operationrunner"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type OperationRunnerSuite struct {
+	coretesting.BaseSuite
+
+	st         *mockState
+	api        *operationrunner.OperationRunnerAPI
+	authoriser apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&OperationRunnerSuite{})
+
+func (s *OperationRunnerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.authoriser = apiservertesting.FakeAuthorizer{
+		Controller: true,
+	}
+	s.st = &mockState{Stub: &testing.Stub{}}
+	operationrunner.PatchState(s, s.st)
+	var err error
+	res := common.NewResources()
+	s.AddCleanup(func(*gc.C) { res.StopAll() })
+	s.api, err = operationrunner.NewOperationRunnerAPI(nil, res, s.authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *OperationRunnerSuite) TestNewAPIRequiresController(c *gc.C) {
+	anAuthoriser := s.authoriser
+	anAuthoriser.Controller = false
+	api, err := operationrunner.NewOperationRunnerAPI(nil, nil, anAuthoriser)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *OperationRunnerSuite) TestWatchOperations(c *gc.C) {
+	result, err := s.api.WatchOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Not(gc.Equals), "")
+	s.st.CheckCallNames(c, "WatchOperations")
+}
+
+func (s *OperationRunnerSuite) TestWatchOperationsFailure(c *gc.C) {
+	s.st.SetErrors(errors.New("boom!"))
+	s.st.watchFails = true
+
+	result, err := s.api.WatchOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom!")
+}
+
+func (s *OperationRunnerSuite) TestAdvanceOperations(c *gc.C) {
+	next := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.st.progress = state.OperationProgress{Running: 2, NextScheduled: next}
+
+	result, err := s.api.AdvanceOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.OperationProgressResult{
+		Running:       2,
+		NextScheduled: &next,
+	})
+	s.st.CheckCallNames(c, "AdvanceOperations")
+}
+
+func (s *OperationRunnerSuite) TestAdvanceOperationsNothingScheduled(c *gc.C) {
+	result, err := s.api.AdvanceOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.OperationProgressResult{})
+}
+
+func (s *OperationRunnerSuite) TestAdvanceOperationsFailure(c *gc.C) {
+	s.st.SetErrors(errors.New("boom!"))
+	result, err := s.api.AdvanceOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom!")
+}
+
+type mockState struct {
+	*testing.Stub
+	watchFails bool
+	progress   state.OperationProgress
+}
+
+func (st *mockState) WatchOperations() state.NotifyWatcher {
+	st.MethodCall(st, "WatchOperations")
+	w := &mockWatcher{
+		out: make(chan struct{}, 1),
+		st:  st,
+	}
+	if st.watchFails {
+		close(w.out)
+	} else {
+		w.out <- struct{}{}
+	}
+	return w
+}
+
+func (st *mockState) AdvanceOperations() (state.OperationProgress, error) {
+	st.MethodCall(st, "AdvanceOperations")
+	return st.progress, st.NextErr()
+}
+
+type mockWatcher struct {
+	out chan struct{}
+	st  *mockState
+}
+
+func (w *mockWatcher) Changes() <-chan struct{} { return w.out }
+func (w *mockWatcher) Stop() error              { return nil }
+func (w *mockWatcher) Kill()                    {}
+func (w *mockWatcher) Wait() error              { return nil }
+func (w *mockWatcher) Err() error               { return w.st.NextErr() }
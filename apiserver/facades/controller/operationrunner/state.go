@@ -0,0 +1,34 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationrunner
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// StateInterface holds the state methods used by the operation
+// runner facade.
+type StateInterface interface {
+	WatchOperations() state.NotifyWatcher
+	AdvanceOperations() (state.OperationProgress, error)
+}
+
+type stateShim struct {
+	*state.State
+	model *state.Model
+}
+
+func (s stateShim) AdvanceOperations() (state.OperationProgress, error) {
+	return s.model.AdvanceOperations()
+}
+
+var getState = func(st *state.State) (StateInterface, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return stateShim{st, model}, nil
+}
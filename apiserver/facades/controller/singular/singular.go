@@ -27,17 +27,18 @@ func NewExternalFacade(context facade.Context) (*Facade, error) {
 		return nil, err
 	}
 
-	backend := getBackend(st, m.ModelTag())
+	backend := getBackend(st, m.ModelTag(), context.SingularClaimer())
 	return NewFacade(backend, auth)
 }
 
-var getBackend = func(st *state.State, modelTag names.ModelTag) Backend {
-	return &stateBackend{st, modelTag}
+var getBackend = func(st *state.State, modelTag names.ModelTag, claimer lease.Claimer) Backend {
+	return &stateBackend{st, modelTag, claimer}
 }
 
 type stateBackend struct {
 	*state.State
 	modelTag names.ModelTag
+	claimer  lease.Claimer
 }
 
 // ModelTag is part of the Backend interface.
@@ -45,6 +46,11 @@ func (b *stateBackend) ModelTag() names.ModelTag {
 	return b.modelTag
 }
 
+// SingularClaimer is part of the Backend interface.
+func (b *stateBackend) SingularClaimer() lease.Claimer {
+	return b.claimer
+}
+
 // Backend supplies capabilities required by a Facade.
 type Backend interface {
 	// ControllerTag tells the Facade which controller it should consider
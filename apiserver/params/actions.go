@@ -143,3 +143,68 @@ type ActionPruneArgs struct {
 	MaxHistoryTime time.Duration `json:"max-history-time"`
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
+
+// EnqueueOperationsArgs holds operations to add, for bulk requests.
+type EnqueueOperationsArgs struct {
+	Operations []EnqueueOperationArgs `json:"operations"`
+}
+
+// EnqueueOperationArgs describes an action to run on a number of units
+// as a single operation, limiting how many units run it at once.
+// Exactly one of Application and Receivers should be set.
+type EnqueueOperationArgs struct {
+	Name          string                 `json:"name"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty"`
+	Application   string                 `json:"application,omitempty"`
+	Receivers     []string               `json:"receivers,omitempty"`
+	MaxParallel   int                    `json:"max-parallel,omitempty"`
+	BatchSize     int                    `json:"batch-size,omitempty"`
+	StopOnFailure bool                   `json:"stop-on-failure,omitempty"`
+	Scheduled     *time.Time             `json:"scheduled,omitempty"`
+}
+
+// OperationQueryArgs holds the ids of operations to fetch.
+type OperationQueryArgs struct {
+	Ids []string `json:"ids"`
+}
+
+// OperationResults holds the results of operation requests.
+type OperationResults struct {
+	Results []OperationResult `json:"results"`
+}
+
+// OperationResult describes an operation and the progress of its
+// actions.
+type OperationResult struct {
+	Id            string                 `json:"id,omitempty"`
+	Name          string                 `json:"name,omitempty"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty"`
+	Application   string                 `json:"application,omitempty"`
+	MaxParallel   int                    `json:"max-parallel,omitempty"`
+	BatchSize     int                    `json:"batch-size,omitempty"`
+	StopOnFailure bool                   `json:"stop-on-failure,omitempty"`
+	Scheduled     *time.Time             `json:"scheduled,omitempty"`
+	Enqueued      time.Time              `json:"enqueued,omitempty"`
+	Started       time.Time              `json:"started,omitempty"`
+	Completed     time.Time              `json:"completed,omitempty"`
+	Status        string                 `json:"status,omitempty"`
+	Message       string                 `json:"message,omitempty"`
+	Tasks         []OperationTask        `json:"tasks,omitempty"`
+	Error         *Error                 `json:"error,omitempty"`
+}
+
+// OperationTask describes the progress of an operation on one unit.
+// Action is the tag of the action enqueued on the unit, if any.
+type OperationTask struct {
+	Receiver string `json:"receiver"`
+	Action   string `json:"action,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// OperationProgressResult holds the result of advancing operations.
+type OperationProgressResult struct {
+	Running       int        `json:"running"`
+	NextScheduled *time.Time `json:"next-scheduled,omitempty"`
+	Error         *Error     `json:"error,omitempty"`
+}
@@ -98,6 +98,7 @@ const (
 	CodeRedirect                  = "redirection required"
 	CodeRetry                     = "retry"
 	CodeIncompatibleSeries        = "incompatible series"
+	CodeQuotaExceeded             = "quota exceeded"
 )
 
 // ErrCode returns the error code associated with
@@ -260,6 +261,10 @@ func IsCodeIncompatibleSeries(err error) bool {
 	return ErrCode(err) == CodeIncompatibleSeries
 }
 
+func IsCodeQuotaExceeded(err error) bool {
+	return ErrCode(err) == CodeQuotaExceeded
+}
+
 func IsCodeForbidden(err error) bool {
 	return ErrCode(err) == CodeForbidden
 }
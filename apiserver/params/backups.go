@@ -64,6 +64,7 @@ type BackupsMetadataResult struct {
 	Started  time.Time      `json:"started"`
 	Finished time.Time      `json:"finished"` // May be zero...
 	Notes    string         `json:"notes"`
+	Target   string         `json:"target,omitempty"`
 	Model    string         `json:"model"`
 	Machine  string         `json:"machine"`
 	Hostname string         `json:"hostname"`
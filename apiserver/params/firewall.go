@@ -60,3 +60,25 @@ func (v KnownServiceValue) Validate() error {
 	}
 	return errors.NotValidf("known service %q", v)
 }
+
+// ExposeInfoResults holds the expose details of a number of
+// applications.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
+// ExposeInfoResult holds whether an application is exposed and, if so,
+// who may access the ports opened for each of its endpoints.
+type ExposeInfoResult struct {
+	Exposed bool `json:"exposed,omitempty"`
+
+	// ExposedEndpoints holds the expose settings of each endpoint,
+	// keyed by endpoint name; the empty name refers to all endpoints
+	// without settings of their own. The CIDRs of the subnets in each
+	// space are included in ExposeToCIDRs. If the application is
+	// exposed without any settings, its ports are accessible from
+	// anywhere.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
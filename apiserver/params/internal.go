@@ -836,6 +836,44 @@ type SetPodSpecParams struct {
 	Specs []EntityString `json:"specs"`
 }
 
+// UnitStateResults holds the results of a UnitState API call.
+type UnitStateResults struct {
+	Results []UnitStateResult `json:"results"`
+}
+
+// UnitStateResult holds the persistent charm state of a unit, or an
+// error.
+type UnitStateResult struct {
+	State map[string]string `json:"state,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// SetUnitStateArgs holds the arguments for replacing the persistent
+// charm state of a set of units.
+type SetUnitStateArgs struct {
+	Args []SetUnitStateArg `json:"args"`
+}
+
+// SetUnitStateArg holds the persistent charm state to set for a unit.
+type SetUnitStateArg struct {
+	Tag   string            `json:"tag"`
+	State map[string]string `json:"state"`
+}
+
+// HookTimeoutsResults holds the results of a HookTimeouts API call.
+type HookTimeoutsResults struct {
+	Results []HookTimeoutsResult `json:"results"`
+}
+
+// HookTimeoutsResult holds the effective hook timeout and warning
+// threshold for a unit, or an error. A zero Timeout means hooks are
+// never timed out.
+type HookTimeoutsResult struct {
+	Timeout          time.Duration `json:"timeout"`
+	WarningThreshold time.Duration `json:"warning-threshold"`
+	Error            *Error        `json:"error,omitempty"`
+}
+
 // GoalStateResults holds the results of GoalStates API call
 type GoalStateResults struct {
 	Results []GoalStateResult `json:"results"`
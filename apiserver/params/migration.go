@@ -52,6 +52,28 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationDryRunResults is used to return the reports of one or more
+// migration dry runs.
+type MigrationDryRunResults struct {
+	Results []MigrationDryRunResult `json:"results"`
+}
+
+// MigrationDryRunResult reports everything that would prevent a single
+// model from being migrated. Error is set if the checks could not be
+// run at all.
+type MigrationDryRunResult struct {
+	ModelTag string   `json:"model-tag"`
+	Blockers []string `json:"blockers,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
+// ImportValidationResult holds every problem found when checking
+// whether a serialized model could be imported by the target
+// controller.
+type ImportValidationResult struct {
+	Blockers []string `json:"blockers,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
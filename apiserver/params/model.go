@@ -77,6 +77,26 @@ type ModelUnset struct {
 	Keys []string `json:"keys"`
 }
 
+// ModelConfigHistoryResult holds the recorded revisions of a model's
+// config, oldest first.
+type ModelConfigHistoryResult struct {
+	Revisions []ModelConfigRevision `json:"revisions"`
+}
+
+// ModelConfigRevision holds a model's config as it was after a change.
+type ModelConfigRevision struct {
+	Revision int                    `json:"revision"`
+	Config   map[string]interface{} `json:"config"`
+	Author   string                 `json:"author,omitempty"`
+	Created  time.Time              `json:"created"`
+}
+
+// RevertModelConfig contains the arguments for the RevertModelConfig
+// client API call.
+type RevertModelConfig struct {
+	Revision int `json:"revision"`
+}
+
 // ModelSLA contains the arguments for the SetSLALevel client API
 // call.
 type ModelSLA struct {
@@ -263,12 +263,14 @@ type EntitiesPorts struct {
 	Entities []EntityPort `json:"entities"`
 }
 
-// EntityPortRange holds an entity's tag, a protocol and a port range.
+// EntityPortRange holds an entity's tag, a protocol and a port range,
+// and optionally the endpoint the range applies to.
 type EntityPortRange struct {
 	Tag      string `json:"tag"`
 	Protocol string `json:"protocol"`
 	FromPort int    `json:"from-port"`
 	ToPort   int    `json:"to-port"`
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // EntitiesPortRanges holds the parameters for making an OpenPorts or
@@ -436,11 +438,13 @@ type MachineAddressesResults struct {
 }
 
 // MachinePortRange holds a single port range open on a machine for
-// the given unit and relation tags.
+// the given unit and relation tags, and the endpoint it was opened for
+// if any.
 type MachinePortRange struct {
 	UnitTag     string    `json:"unit-tag"`
 	RelationTag string    `json:"relation-tag"`
 	PortRange   PortRange `json:"port-range"`
+	Endpoint    string    `json:"endpoint,omitempty"`
 }
 
 // MachinePorts holds a machine and subnet tags. It's used when referring to
@@ -385,11 +385,34 @@ type ApplicationSetCharm struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]StorageConstraints `json:"storage-constraints,omitempty"`
+
+	// Units, if set, restricts the upgrade to the named units. The
+	// application's charm changes once all of its units have been
+	// upgraded. This field is only understood by Application facade
+	// version 11 and greater.
+	Units []string `json:"units,omitempty"`
+
+	// MaxUnavailable, if positive, upgrades the application's units
+	// progressively, no more than this many at a time. This field is
+	// only understood by Application facade version 11 and greater.
+	MaxUnavailable int `json:"max-unavailable,omitempty"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints, if set, restricts access to the ports opened
+	// for each of the named endpoints. The empty endpoint name refers
+	// to all endpoints without settings of their own.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint holds the spaces and CIDRs that may access the ports
+// opened for an endpoint of an exposed application.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -442,6 +465,41 @@ type ApplicationConfigUnsetArgs struct {
 	Args []ApplicationUnset
 }
 
+// CharmConfigHistoryResults holds the results of a CharmConfigHistory
+// call.
+type CharmConfigHistoryResults struct {
+	Results []CharmConfigHistoryResult `json:"results"`
+}
+
+// CharmConfigHistoryResult holds the recorded charm config revisions of
+// an application, oldest first, or an error.
+type CharmConfigHistoryResult struct {
+	Revisions []CharmConfigRevision `json:"revisions,omitempty"`
+	Error     *Error                `json:"error,omitempty"`
+}
+
+// CharmConfigRevision holds an application's charm config as it was
+// after a change.
+type CharmConfigRevision struct {
+	Revision int                    `json:"revision"`
+	Config   map[string]interface{} `json:"config"`
+	Author   string                 `json:"author,omitempty"`
+	Created  time.Time              `json:"created"`
+}
+
+// RevertCharmConfigArgs holds the parameters for reverting the charm
+// config of a number of applications.
+type RevertCharmConfigArgs struct {
+	Args []RevertCharmConfigArg `json:"args"`
+}
+
+// RevertCharmConfigArg holds the parameters for reverting an
+// application's charm config to an earlier revision.
+type RevertCharmConfigArg struct {
+	ApplicationName string `json:"application"`
+	Revision        int    `json:"revision"`
+}
+
 // ApplicationCharmRelations holds parameters for making the application CharmRelations call.
 type ApplicationCharmRelations struct {
 	ApplicationName string `json:"application"`
@@ -770,6 +828,10 @@ type StateServingInfo struct {
 	// this will be passed as the KeyFile argument to MongoDB
 	SharedSecret   string `json:"shared-secret"`
 	SystemIdentity string `json:"system-identity"`
+	// SecretsKey is the base64-encoded key used to encrypt secret
+	// values stored in the controller database. It is kept out of
+	// the database itself.
+	SecretsKey string `json:"secrets-key,omitempty"`
 }
 
 // IsMasterResult holds the result of an IsMaster API call.
@@ -0,0 +1,18 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// ModelQuota holds the resource limits placed on a model, and the
+// amount of each resource the model is using. Memory and storage are
+// measured in MiB.
+type ModelQuota struct {
+	Limits map[string]uint64 `json:"limits"`
+	Usage  map[string]uint64 `json:"usage"`
+}
+
+// SetModelQuotaArgs holds the args for replacing the resource limits
+// placed on a model.
+type SetModelQuotaArgs struct {
+	Limits map[string]uint64 `json:"limits"`
+}
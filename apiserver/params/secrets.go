@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// CreateSecretArgs holds the args for creating or updating secrets.
+type CreateSecretArgs struct {
+	Args []CreateSecretArg `json:"args"`
+}
+
+// CreateSecretArg holds the args for creating or updating a secret
+// owned by the calling unit's application. If the secret already
+// exists, a new revision is created with the supplied data.
+type CreateSecretArg struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Data        map[string]string `json:"data"`
+
+	// RelationTags holds the relations over which access to the
+	// secret is granted to the remote application.
+	RelationTags []string `json:"relation-tags,omitempty"`
+}
+
+// GetSecretArgs holds the args for getting secret values.
+type GetSecretArgs struct {
+	Args []GetSecretArg `json:"args"`
+}
+
+// GetSecretArg holds the args for getting a secret value. A zero
+// revision denotes the latest revision.
+type GetSecretArg struct {
+	ID       string `json:"id"`
+	Revision int    `json:"revision,omitempty"`
+}
+
+// SecretValueResults holds secret value results.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SecretValueResult holds a secret value or an error.
+type SecretValueResult struct {
+	Data  map[string]string `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// RevokeSecretArgs holds the args for revoking access to secrets.
+type RevokeSecretArgs struct {
+	Args []RevokeSecretArg `json:"args"`
+}
+
+// RevokeSecretArg holds the args for revoking access to a secret. If
+// RelationTag is empty, all access granted to other applications is
+// revoked.
+type RevokeSecretArg struct {
+	ID          string `json:"id"`
+	RelationTag string `json:"relation-tag,omitempty"`
+}
+
+// ListSecretsArgs holds the args for listing secrets.
+type ListSecretsArgs struct {
+	// ShowSecrets, if true, includes the latest value of each secret
+	// in the results. It requires model admin access.
+	ShowSecrets bool `json:"show-secrets"`
+
+	// IDs, if not empty, restricts the results to the given secrets.
+	IDs []string `json:"ids,omitempty"`
+}
+
+// ListSecretResults holds the results of listing secrets.
+type ListSecretResults struct {
+	Results []ListSecretResult `json:"results"`
+}
+
+// ListSecretResult holds the details of a secret.
+type ListSecretResult struct {
+	ID          string             `json:"id"`
+	OwnerTag    string             `json:"owner-tag"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Revision    int                `json:"revision"`
+	Consumers   []SecretConsumer   `json:"consumers,omitempty"`
+	CreateTime  time.Time          `json:"create-time"`
+	UpdateTime  time.Time          `json:"update-time"`
+	Value       *SecretValueResult `json:"value,omitempty"`
+}
+
+// SecretConsumer describes an application that has been granted
+// access to a secret.
+type SecretConsumer struct {
+	ApplicationTag string `json:"application-tag"`
+	RelationTag    string `json:"relation-tag"`
+}
@@ -52,6 +52,10 @@ type ModelStatusInfo struct {
 	ModelStatus      DetailedStatus `json:"model-status"`
 	MeterStatus      MeterStatus    `json:"meter-status"`
 	SLA              string         `json:"sla"`
+
+	// Quota holds the model's resource quota and usage, if the model
+	// has a quota.
+	Quota *ModelQuota `json:"quota,omitempty"`
 }
 
 // NetworkInterfaceStatus holds a /etc/network/interfaces-type data and the
@@ -132,11 +136,27 @@ type ApplicationStatus struct {
 	CharmVersion     string                 `json:"charm-verion"`
 	EndpointBindings map[string]string      `json:"endpoint-bindings"`
 
+	// ExposedEndpoints holds the expose settings of each endpoint of
+	// an exposed application, if any were specified.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+
+	// CharmRollout holds details of a charm being rolled out to the
+	// application's units, if any.
+	CharmRollout *CharmRolloutStatus `json:"charm-rollout,omitempty"`
+
 	// The following are for CAAS models.
 	ProviderId    string `json:"provider-id,omitempty"`
 	PublicAddress string `json:"public-address"`
 }
 
+// CharmRolloutStatus holds status info about a charm being rolled out
+// to an application's units.
+type CharmRolloutStatus struct {
+	Charm          string `json:"charm"`
+	MaxUnavailable int    `json:"max-unavailable,omitempty"`
+	Halted         bool   `json:"halted,omitempty"`
+}
+
 // RemoteApplicationStatus holds status info about a remote application.
 type RemoteApplicationStatus struct {
 	Err       error               `json:"err,omitempty"`
@@ -181,6 +201,10 @@ type UnitStatus struct {
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
 
+	// TargetCharm holds the charm the unit is upgrading to, if it
+	// has been chosen to run a charm being rolled out.
+	TargetCharm string `json:"target-charm,omitempty"`
+
 	// The following are for CAAS models.
 	ProviderId string `json:"provider-id,omitempty"`
 	Address    string `json:"address,omitempty"`
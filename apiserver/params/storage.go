@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,14 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size of the attached storage in MiB, if known.
+	Size uint64 `json:"size,omitempty"`
+
+	// UnitLocations holds the location of shared storage on each of
+	// the units it is attached to and provisioned for, keyed by unit
+	// tag. It is empty for storage that is not shared.
+	UnitLocations map[string]string `json:"unit-locations,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -216,6 +228,7 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
 }
 
 // RemoveVolumeParams holds the parameters for destroying or releasing a
@@ -232,6 +245,89 @@ type RemoveVolumeParams struct {
 	Destroy bool `json:"destroy,omitempty"`
 }
 
+// ResizeVolumeParams holds the parameters for growing a storage volume.
+type ResizeVolumeParams struct {
+	// VolumeTag is the tag of the volume to be resized.
+	VolumeTag string `json:"volume-tag"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Size is the size, in MiB, that the volume should be grown to.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshotIds holds the IDs of volume snapshots.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotParams holds the parameters for taking, or destroying,
+// a snapshot of a storage volume.
+type VolumeSnapshotParams struct {
+	// Id is the snapshot's unique ID.
+	Id string `json:"id"`
+
+	// Life is the lifecycle state of the snapshot.
+	Life Life `json:"life"`
+
+	// VolumeTag is the tag of the volume the snapshot is taken of.
+	VolumeTag string `json:"volume-tag"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// Attributes is the configuration of the volume's storage pool.
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Size is the size of the volume, in MiB.
+	Size uint64 `json:"size"`
+
+	// Tags is the set of tags to set on the snapshot.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot,
+	// if it has been taken.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds parameters for taking, or destroying,
+// a volume snapshot.
+type VolumeSnapshotParamsResult struct {
+	Result *VolumeSnapshotParams `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds parameters for taking, or destroying,
+// multiple volume snapshots.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotInfo holds the storage provider's information about a
+// volume snapshot that has been taken.
+type VolumeSnapshotInfo struct {
+	// Id is the snapshot's unique ID.
+	Id string `json:"id"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// Size is the size of the volume the snapshot was taken of, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshotInfos holds information about multiple volume snapshots.
+type VolumeSnapshotInfos struct {
+	Snapshots []VolumeSnapshotInfo `json:"snapshots"`
+}
+
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
@@ -301,6 +397,18 @@ type RemoveVolumeParamsResults struct {
 	Results []RemoveVolumeParamsResult `json:"results,omitempty"`
 }
 
+// ResizeVolumeParamsResult holds parameters for resizing a volume. The
+// result is nil if the volume does not need to be resized.
+type ResizeVolumeParamsResult struct {
+	Result *ResizeVolumeParams `json:"result,omitempty"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// ResizeVolumeParamsResults holds parameters for resizing multiple volumes.
+type ResizeVolumeParamsResults struct {
+	Results []ResizeVolumeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -365,6 +473,7 @@ type FilesystemParams struct {
 	Attributes    map[string]interface{}      `json:"attributes,omitempty"`
 	Tags          map[string]string           `json:"tags,omitempty"`
 	Attachment    *FilesystemAttachmentParams `json:"attachment,omitempty"`
+	FromSnapshot  bool                        `json:"from-snapshot,omitempty"`
 }
 
 // RemoveFilesystemParams holds the parameters for destroying or releasing
@@ -590,6 +699,18 @@ type VolumeFilters struct {
 	Filters []VolumeFilter `json:"filters,omitempty"`
 }
 
+// VolumeSnapshotFilter holds a filter for the volume snapshot list
+// API call.
+type VolumeSnapshotFilter struct {
+	// StorageTags are storage instance tags to filter on.
+	StorageTags []string `json:"storage-tags,omitempty"`
+}
+
+// VolumeSnapshotFilters holds a collection of volume snapshot filters.
+type VolumeSnapshotFilters struct {
+	Filters []VolumeSnapshotFilter `json:"filters,omitempty"`
+}
+
 // FilesystemFilter holds a filter for filter list API call.
 type FilesystemFilter struct {
 	// Machines are machine tags to filter on.
@@ -683,6 +804,51 @@ type VolumeDetailsListResults struct {
 	Results []VolumeDetailsListResult `json:"results,omitempty"`
 }
 
+// VolumeSnapshotDetails describes a volume snapshot in the model
+// for the purpose of storage snapshot CLI commands.
+type VolumeSnapshotDetails struct {
+	// Id is the snapshot's unique ID.
+	Id string `json:"id"`
+
+	// StorageTag is the tag of the storage instance whose volume
+	// the snapshot was taken of, if any.
+	StorageTag string `json:"storage-tag,omitempty"`
+
+	// VolumeTag is the tag of the volume the snapshot was taken of.
+	VolumeTag string `json:"volume-tag"`
+
+	// Pool is the storage pool of the volume the snapshot was
+	// taken of.
+	Pool string `json:"pool"`
+
+	// Size is the size of the volume the snapshot was taken of,
+	// in MiB.
+	Size uint64 `json:"size"`
+
+	// SnapshotId is the storage provider's unique ID for the
+	// snapshot, if it has been taken.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+
+	// Life contains the lifecycle state of the snapshot.
+	Life Life `json:"life"`
+
+	// Created is the time at which the snapshot was requested.
+	Created time.Time `json:"created"`
+}
+
+// VolumeSnapshotDetailsListResult holds a collection of volume
+// snapshot details.
+type VolumeSnapshotDetailsListResult struct {
+	Result []VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                  `json:"error,omitempty"`
+}
+
+// VolumeSnapshotDetailsListResults holds a collection of collections
+// of volume snapshot details.
+type VolumeSnapshotDetailsListResults struct {
+	Results []VolumeSnapshotDetailsListResult `json:"results,omitempty"`
+}
+
 // FilesystemDetails describes a storage filesystem in the model
 // for the purpose of filesystem CLI commands.
 //
@@ -773,6 +939,10 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count *uint64 `json:"count,omitempty"`
+
+	// Snapshot is the ID of the volume snapshot from which to create
+	// the storage instance, if any.
+	Snapshot string `json:"snapshot,omitempty"`
 }
 
 // StorageAddParams holds storage details to add to a unit dynamically.
@@ -813,6 +983,22 @@ type RemoveStorageInstance struct {
 	DestroyStorage bool `json:"destroy-storage,omitempty"`
 }
 
+// ResizeStorage holds the parameters for resizing storage instances.
+type ResizeStorage struct {
+	Storage []ResizeStorageInstance `json:"storage"`
+}
+
+// ResizeStorageInstance holds the parameters for resizing a storage
+// instance.
+type ResizeStorageInstance struct {
+	// Tag is the tag of the storage instance to be resized.
+	Tag string `json:"tag"`
+
+	// Size is the new size of the storage instance in MiB. Storage
+	// may only be grown.
+	Size uint64 `json:"size"`
+}
+
 // BulkImportStorageParams contains the parameters for importing a collection
 // of storage entities.
 type BulkImportStorageParams struct {
@@ -17,6 +17,8 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
@@ -92,6 +94,9 @@ func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID st
 	if err := r.resources.RegisterNamed("logDir", common.StringResource(srv.logDir)); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := r.resources.RegisterNamed("secretsKey", common.StringResource(srv.secretsKey)); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// Facades involved with managing application offers need the auth context
 	// to mint and validate macaroons.
@@ -418,6 +423,31 @@ func (ctx *facadeContext) Hub() facade.Hub {
 	return ctx.r.shared.centralHub
 }
 
+// LeadershipClaimer is part of the facade.Context interface.
+func (ctx *facadeContext) LeadershipClaimer() leadership.Claimer {
+	return state.NewLeadershipClaimer(ctx.r.shared.leaseManager, ctx.r.state.ModelUUID())
+}
+
+// ModelLeadershipClaimer is part of the facade.Context interface.
+func (ctx *facadeContext) ModelLeadershipClaimer(modelUUID string) (leadership.Claimer, error) {
+	return state.NewLeadershipClaimer(ctx.r.shared.leaseManager, modelUUID), nil
+}
+
+// LeadershipChecker is part of the facade.Context interface.
+func (ctx *facadeContext) LeadershipChecker() leadership.Checker {
+	return state.NewLeadershipChecker(ctx.r.shared.leaseManager, ctx.r.state.ModelUUID())
+}
+
+// ApplicationLeaders is part of the facade.Context interface.
+func (ctx *facadeContext) ApplicationLeaders() (map[string]string, error) {
+	return state.ApplicationLeadersFromManager(ctx.r.shared.leaseManager, ctx.r.state.ModelUUID())
+}
+
+// SingularClaimer is part of the facade.Context interface.
+func (ctx *facadeContext) SingularClaimer() lease.Claimer {
+	return state.NewSingularClaimer(ctx.r.shared.leaseManager, ctx.r.state.ModelUUID())
+}
+
 // State is part of of the facade.Context interface.
 func (ctx *facadeContext) State() *state.State {
 	return ctx.r.state
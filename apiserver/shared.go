@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/pubsub/apiserver"
@@ -33,10 +34,11 @@ type SharedHub interface {
 // All attributes in the context should be goroutine aware themselves, like the state pool, hub, and
 // presence, or protected and only accessed through methods on this context object.
 type sharedServerContext struct {
-	statePool  *state.StatePool
-	centralHub SharedHub
-	presence   presence.Recorder
-	logger     loggo.Logger
+	statePool    *state.StatePool
+	centralHub   SharedHub
+	presence     presence.Recorder
+	leaseManager lease.Manager
+	logger       loggo.Logger
 
 	featuresMutex sync.RWMutex
 	features      set.Strings
@@ -45,10 +47,11 @@ type sharedServerContext struct {
 }
 
 type sharedServerConfig struct {
-	statePool  *state.StatePool
-	centralHub SharedHub
-	presence   presence.Recorder
-	logger     loggo.Logger
+	statePool    *state.StatePool
+	centralHub   SharedHub
+	presence     presence.Recorder
+	leaseManager lease.Manager
+	logger       loggo.Logger
 }
 
 func (c *sharedServerConfig) validate() error {
@@ -69,10 +72,11 @@ func newSharedServerContex(config sharedServerConfig) (*sharedServerContext, err
 		return nil, errors.Trace(err)
 	}
 	ctx := &sharedServerContext{
-		statePool:  config.statePool,
-		centralHub: config.centralHub,
-		presence:   config.presence,
-		logger:     config.logger,
+		statePool:    config.statePool,
+		centralHub:   config.centralHub,
+		presence:     config.presence,
+		leaseManager: config.leaseManager,
+		logger:       config.logger,
 	}
 	controllerConfig, err := ctx.statePool.SystemState().ControllerConfig()
 	if err != nil {
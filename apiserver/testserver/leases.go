@@ -0,0 +1,144 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testserver
+
+import (
+	"log"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/globalclockupdater"
+	"github.com/juju/juju/worker/lease"
+	"github.com/juju/juju/worker/raft/raftutil"
+)
+
+var leaseLogger = loggo.GetLogger("juju.apiserver.testserver.lease")
+
+// LeaseManager is a lease manager for API servers started in tests.
+// It's backed by a single-node raft cluster held in memory, so that
+// facades see the same leases and trapdoors they would in a
+// controller, without needing the machine agent's raft workers.
+type LeaseManager struct {
+	*lease.Manager
+
+	raft      *raft.Raft
+	transport *raft.InmemTransport
+	updater   worker.Worker
+}
+
+// NewLeaseManager returns a running LeaseManager that records lease
+// holders in the given controller state. The caller is responsible
+// for stopping it.
+func NewLeaseManager(st *state.State) (_ *LeaseManager, err error) {
+	fsm := raftlease.NewFSM()
+	config := raft.DefaultConfig()
+	config.LocalID = "0"
+	config.HeartbeatTimeout = 100 * time.Millisecond
+	config.ElectionTimeout = config.HeartbeatTimeout
+	config.LeaderLeaseTimeout = config.HeartbeatTimeout
+	config.Logger = log.New(&raftutil.LoggoWriter{leaseLogger, loggo.DEBUG}, "", 0)
+
+	address, transport := raft.NewInmemTransport("")
+	store := raft.NewInmemStore()
+	snapshots := raft.NewInmemSnapshotStore()
+	r, err := raft.NewRaft(config, fsm, store, store, snapshots, transport)
+	if err != nil {
+		transport.Close()
+		return nil, errors.Annotate(err, "starting raft")
+	}
+	m := &LeaseManager{raft: r, transport: transport}
+	defer func() {
+		if err != nil {
+			m.Kill()
+			m.Wait()
+		}
+	}()
+
+	if err := r.BootstrapCluster(raft.Configuration{
+		Servers: []raft.Server{{ID: config.LocalID, Address: address}},
+	}).Error(); err != nil {
+		return nil, errors.Annotate(err, "bootstrapping raft")
+	}
+	select {
+	case <-r.LeaderCh():
+	case <-time.After(10 * time.Second):
+		return nil, errors.New("timed out waiting for raft leadership")
+	}
+
+	leaseStore, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:          fsm,
+		Raft:         r,
+		Clock:        clock.WallClock,
+		ApplyTimeout: 5 * time.Second,
+		Target:       st.LeaseNotifyTarget(leaseLogger),
+		Trapdoor:     state.LeaseTrapdoorFunc(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m.updater, err = globalclockupdater.NewWorker(globalclockupdater.Config{
+		NewUpdater: func() (globalclock.Updater, error) {
+			return raftlease.NewStore(raftlease.StoreConfig{
+				FSM:          fsm,
+				Raft:         r,
+				Clock:        clock.WallClock,
+				ApplyTimeout: 5 * time.Second,
+			})
+		},
+		LocalClock:     clock.WallClock,
+		UpdateInterval: time.Second,
+		BackoffDelay:   10 * time.Millisecond,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m.Manager, err = lease.NewManager(lease.ManagerConfig{
+		Secretary:  state.LeaseSecretary,
+		Store:      leaseStore,
+		Clock:      clock.WallClock,
+		MaxSleep:   time.Minute,
+		EntityUUID: st.ControllerUUID(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (m *LeaseManager) Kill() {
+	if m.Manager != nil {
+		m.Manager.Kill()
+	}
+	if m.updater != nil {
+		m.updater.Kill()
+	}
+}
+
+// Wait is part of the worker.Worker interface. It shuts down the raft
+// cluster once the lease manager has stopped.
+func (m *LeaseManager) Wait() error {
+	var err error
+	if m.Manager != nil {
+		err = m.Manager.Wait()
+	}
+	if m.updater != nil {
+		if updaterErr := m.updater.Wait(); err == nil {
+			err = updaterErr
+		}
+	}
+	if shutdownErr := m.raft.Shutdown().Error(); err == nil {
+		err = shutdownErr
+	}
+	m.transport.Close()
+	return errors.Trace(err)
+}
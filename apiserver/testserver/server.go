@@ -90,13 +90,21 @@ func NewServerWithConfig(c *gc.C, statePool *state.StatePool, cfg apiserver.Serv
 		cfg.Authenticator = authenticator
 	}
 
+	var leaseManager *LeaseManager
+	if cfg.LeaseManager == nil {
+		leaseManager, err = NewLeaseManager(statePool.SystemState())
+		c.Assert(err, jc.ErrorIsNil)
+		cfg.LeaseManager = leaseManager
+	}
+
 	srv, err := apiserver.NewServer(cfg)
 	c.Assert(err, jc.ErrorIsNil)
 	httpServer.StartTLS()
 
 	return &Server{
-		APIServer:  srv,
-		HTTPServer: httpServer,
+		APIServer:    srv,
+		HTTPServer:   httpServer,
+		leaseManager: leaseManager,
 		Info: &api.Info{
 			Addrs:  []string{fmt.Sprintf("localhost:%d", listener.Addr().(*net.TCPAddr).Port)},
 			CACert: coretesting.CACert,
@@ -110,10 +118,20 @@ type Server struct {
 	APIServer  *apiserver.Server
 	HTTPServer *httptest.Server
 	Info       *api.Info
+
+	leaseManager *LeaseManager
 }
 
-// Stop stops both the API and HTTP servers.
+// Stop stops both the API and HTTP servers, and the lease manager if
+// the server started one.
 func (s *Server) Stop() error {
 	s.HTTPServer.Close()
-	return s.APIServer.Stop()
+	err := s.APIServer.Stop()
+	if s.leaseManager != nil {
+		s.leaseManager.Kill()
+		if leaseErr := s.leaseManager.Wait(); err == nil {
+			err = leaseErr
+		}
+	}
+	return err
 }
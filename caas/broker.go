@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
@@ -99,6 +100,9 @@ type Broker interface {
 	// EnsureNamespace ensures this broker's namespace is created.
 	EnsureNamespace() error
 
+	// SetConfig updates the Broker's model configuration.
+	SetConfig(cfg *config.Config) error
+
 	// EnsureOperator creates or updates an operator pod for running
 	// a charm for the specified application.
 	EnsureOperator(appName, agentPath string, config *OperatorConfig) error
@@ -3,7 +3,11 @@
 
 package caas
 
-import "github.com/juju/errors"
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
 
 // FileSet defines a set of files to mount
 // into the container.
@@ -52,11 +56,107 @@ type ContainerSpec struct {
 	ProviderContainer `yaml:"-"`
 }
 
+// PolicyRule defines a rule granting access to the
+// substrate's API, eg a k8s RBAC rule.
+type PolicyRule struct {
+	Verbs           []string `yaml:"verbs"`
+	APIGroups       []string `yaml:"apiGroups,omitempty"`
+	Resources       []string `yaml:"resources,omitempty"`
+	ResourceNames   []string `yaml:"resourceNames,omitempty"`
+	NonResourceURLs []string `yaml:"nonResourceURLs,omitempty"`
+}
+
+// ServiceAccountSpec defines the service account the pods of
+// an application run as, and the access it is granted.
+type ServiceAccountSpec struct {
+	// Global is true if the rules apply across the whole
+	// cluster rather than just the model's namespace.
+	Global                       bool         `yaml:"global,omitempty"`
+	AutomountServiceAccountToken *bool        `yaml:"automountServiceAccountToken,omitempty"`
+	Rules                        []PolicyRule `yaml:"rules,omitempty"`
+}
+
+// Scopes for custom resource definitions.
+const (
+	NamespacedScope = "Namespaced"
+	ClusterScope    = "Cluster"
+)
+
+// CustomResourceDefinition defines a custom resource type which an
+// application adds to the CAAS substrate, eg a k8s CRD.
+type CustomResourceDefinition struct {
+	Kind    string `json:"kind"`
+	Group   string `json:"group"`
+	Version string `json:"version"`
+
+	// Plural is the name used for the resource in the substrate's
+	// API; it defaults to the lower cased kind with an "s" appended.
+	Plural string `json:"plural,omitempty"`
+
+	// Scope is either NamespacedScope (the default) or ClusterScope.
+	Scope string `json:"scope,omitempty"`
+
+	// Validation holds the schema used by the substrate to
+	// validate instances of the custom resource.
+	Validation map[string]interface{} `json:"validation,omitempty"`
+}
+
+// PluralName returns the name used for the resource in
+// the substrate's API.
+func (crd *CustomResourceDefinition) PluralName() string {
+	if crd.Plural != "" {
+		return crd.Plural
+	}
+	return strings.ToLower(crd.Kind) + "s"
+}
+
+// Validate returns an error if the definition is not valid.
+func (crd *CustomResourceDefinition) Validate() error {
+	if crd.Kind == "" {
+		return errors.New("custom resource definition kind is missing")
+	}
+	if crd.Group == "" {
+		return errors.Errorf("group is missing for custom resource definition %q", crd.Kind)
+	}
+	if crd.Version == "" {
+		return errors.Errorf("version is missing for custom resource definition %q", crd.Kind)
+	}
+	switch crd.Scope {
+	case "", NamespacedScope, ClusterScope:
+	default:
+		return errors.NotValidf("scope %q for custom resource definition %q", crd.Scope, crd.Kind)
+	}
+	return nil
+}
+
+// CustomResource defines an instance of a custom resource type
+// declared in the same pod spec.
+type CustomResource struct {
+	Kind string                 `json:"kind"`
+	Name string                 `json:"name"`
+	Spec map[string]interface{} `json:"spec,omitempty"`
+}
+
 // PodSpec defines the data values used to configure
 // a pod on the CAAS substrate.
 type PodSpec struct {
-	Containers          []ContainerSpec `yaml:"-"`
-	OmitServiceFrontend bool            `yaml:"omitServiceFrontend"`
+	Containers          []ContainerSpec     `yaml:"-"`
+	OmitServiceFrontend bool                `yaml:"omitServiceFrontend"`
+	ServiceAccount      *ServiceAccountSpec `yaml:"serviceAccount,omitempty"`
+
+	CustomResourceDefinitions []CustomResourceDefinition `yaml:"-"`
+	CustomResources           []CustomResource           `yaml:"-"`
+}
+
+// CustomResourceDefinition returns the definition of the
+// custom resource type with the given kind.
+func (spec *PodSpec) CustomResourceDefinition(kind string) (*CustomResourceDefinition, error) {
+	for i, crd := range spec.CustomResourceDefinitions {
+		if crd.Kind == kind {
+			return &spec.CustomResourceDefinitions[i], nil
+		}
+	}
+	return nil, errors.NotFoundf("custom resource definition %q", kind)
 }
 
 // Validate returns an error if the spec is not valid.
@@ -66,6 +166,50 @@ func (spec *PodSpec) Validate() error {
 			return errors.Trace(err)
 		}
 	}
+	if spec.ServiceAccount != nil {
+		if err := spec.ServiceAccount.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	kinds := make(map[string]bool)
+	for _, crd := range spec.CustomResourceDefinitions {
+		if err := crd.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if kinds[crd.Kind] {
+			return errors.Errorf("custom resource definition %q is defined more than once", crd.Kind)
+		}
+		kinds[crd.Kind] = true
+	}
+	for _, cr := range spec.CustomResources {
+		if cr.Name == "" {
+			return errors.Errorf("name is missing for custom resource of kind %q", cr.Kind)
+		}
+		if !kinds[cr.Kind] {
+			return errors.Errorf("custom resource %q has undefined kind %q", cr.Name, cr.Kind)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the spec is not valid.
+func (spec *ServiceAccountSpec) Validate() error {
+	for i, rule := range spec.Rules {
+		if len(rule.Verbs) == 0 {
+			return errors.Errorf("rule %d has no verbs", i)
+		}
+		if len(rule.Resources) == 0 && len(rule.NonResourceURLs) == 0 {
+			return errors.Errorf("rule %d needs resources or nonResourceURLs", i)
+		}
+		if len(rule.NonResourceURLs) > 0 {
+			if !spec.Global {
+				return errors.Errorf("rule %d: nonResourceURLs require a global service account", i)
+			}
+			if len(rule.Resources) > 0 {
+				return errors.Errorf("rule %d cannot have both resources and nonResourceURLs", i)
+			}
+		}
+	}
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider/mocks"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
 )
 
@@ -40,10 +42,27 @@ type BaseSuite struct {
 	mockStorage                *mocks.MockStorageV1Interface
 	mockStorageClass           *mocks.MockStorageClassInterface
 	mockIngressInterface       *mocks.MockIngressInterface
+	mockServiceAccounts        *mocks.MockServiceAccountInterface
+	mockRoles                  *mocks.MockRoleInterface
+	mockRoleBindings           *mocks.MockRoleBindingInterface
+	mockClusterRoles           *mocks.MockClusterRoleInterface
+	mockClusterRoleBindings    *mocks.MockClusterRoleBindingInterface
+
+	mockDynamic                   *mocks.MockDynamicInterface
+	mockCustomResourceDefinitions *mocks.MockNamespaceableResourceInterface
+
+	// modelConfigAttrs are merged into the model
+	// config the broker is opened with.
+	modelConfigAttrs testing.Attrs
 }
 
 const testNamespace = "test"
 
+func (s *BaseSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.modelConfigAttrs = nil
+}
+
 func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
 		"username":              "fred",
@@ -61,7 +80,8 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 
 	// Set up the mock k8sclient we pass to our broker under test.
 	s.k8sClient = mocks.NewMockInterface(ctrl)
-	newClient := func(cfg *rest.Config) (kubernetes.Interface, error) {
+	s.mockDynamic = mocks.NewMockDynamicInterface(ctrl)
+	newClient := func(cfg *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
 		c.Assert(cfg.Username, gc.Equals, "fred")
 		c.Assert(cfg.Password, gc.Equals, "secret")
 		c.Assert(cfg.Host, gc.Equals, "some-host")
@@ -70,7 +90,7 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 			KeyData:  []byte("cert-key"),
 			CAData:   []byte(testing.CACert),
 		})
-		return s.k8sClient, nil
+		return s.k8sClient, s.mockDynamic, nil
 	}
 
 	// Plug in the various k8s client modules we need.
@@ -97,6 +117,9 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.mockPersistentVolumeClaims = mocks.NewMockPersistentVolumeClaimInterface(ctrl)
 	mockCoreV1.EXPECT().PersistentVolumeClaims(testNamespace).AnyTimes().Return(s.mockPersistentVolumeClaims)
 
+	s.mockServiceAccounts = mocks.NewMockServiceAccountInterface(ctrl)
+	mockCoreV1.EXPECT().ServiceAccounts(testNamespace).AnyTimes().Return(s.mockServiceAccounts)
+
 	s.mockApps = mocks.NewMockAppsV1Interface(ctrl)
 	s.mockExtensions = mocks.NewMockExtensionsV1beta1Interface(ctrl)
 	s.mockStatefulSets = mocks.NewMockStatefulSetInterface(ctrl)
@@ -113,8 +136,29 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
 	s.mockStorage.EXPECT().StorageClasses().AnyTimes().Return(s.mockStorageClass)
 
-	var err error
-	s.broker, err = provider.NewK8sBroker(cloudSpec, testNamespace, newClient)
+	mockRbac := mocks.NewMockRbacV1Interface(ctrl)
+	s.mockRoles = mocks.NewMockRoleInterface(ctrl)
+	s.mockRoleBindings = mocks.NewMockRoleBindingInterface(ctrl)
+	s.mockClusterRoles = mocks.NewMockClusterRoleInterface(ctrl)
+	s.mockClusterRoleBindings = mocks.NewMockClusterRoleBindingInterface(ctrl)
+	s.k8sClient.EXPECT().RbacV1().AnyTimes().Return(mockRbac)
+	mockRbac.EXPECT().Roles(testNamespace).AnyTimes().Return(s.mockRoles)
+	mockRbac.EXPECT().RoleBindings(testNamespace).AnyTimes().Return(s.mockRoleBindings)
+	mockRbac.EXPECT().ClusterRoles().AnyTimes().Return(s.mockClusterRoles)
+	mockRbac.EXPECT().ClusterRoleBindings().AnyTimes().Return(s.mockClusterRoleBindings)
+
+	s.mockCustomResourceDefinitions = mocks.NewMockNamespaceableResourceInterface(ctrl)
+	s.mockDynamic.EXPECT().Resource(schema.GroupVersionResource{
+		Group:    "apiextensions.k8s.io",
+		Version:  "v1beta1",
+		Resource: "customresourcedefinitions",
+	}).AnyTimes().Return(s.mockCustomResourceDefinitions)
+
+	cfg, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		config.NameKey: testNamespace,
+	}).Merge(s.modelConfigAttrs))
+	c.Assert(err, jc.ErrorIsNil)
+	s.broker, err = provider.NewK8sBroker(cloudSpec, cfg, newClient)
 	c.Assert(err, jc.ErrorIsNil)
 	return ctrl
 }
@@ -0,0 +1,288 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	"github.com/juju/utils/clock"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/juju/juju/caas"
+)
+
+// customResourceDefinitions is the resource used to manage custom
+// resource definitions through the dynamic client.
+var customResourceDefinitions = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1beta1",
+	Resource: "customresourcedefinitions",
+}
+
+var (
+	// customResourceDefinitionDelay is how often a new custom resource
+	// definition is checked while waiting for it to be established.
+	customResourceDefinitionDelay = time.Second
+
+	// customResourceDefinitionTimeout is how long to wait for a new
+	// custom resource definition to be established before giving up.
+	customResourceDefinitionTimeout = time.Minute
+)
+
+// configureCustomResources creates or updates the custom resource
+// definitions and custom resources declared in the pod spec, and
+// removes any the application previously declared which are no
+// longer wanted.
+func (k *kubernetesClient) configureCustomResources(appName string, spec *caas.PodSpec) error {
+	wanted := set.NewStrings()
+	for _, crd := range spec.CustomResourceDefinitions {
+		wanted.Add(customResourceDefinitionName(crd))
+	}
+	// Deleting a definition also deletes all its resources.
+	if err := k.deleteCustomResourceDefinitions(appName, wanted); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, crd := range spec.CustomResourceDefinitions {
+		logger.Debugf("creating/updating custom resource definition %s for %s", crd.Kind, appName)
+		if err := k.ensureCustomResourceDefinition(appName, crd); err != nil {
+			return errors.Annotatef(err, "custom resource definition %q", crd.Kind)
+		}
+
+		var resources []*unstructured.Unstructured
+		for _, cr := range spec.CustomResources {
+			if cr.Kind == crd.Kind {
+				resources = append(resources, k.customResource(appName, crd, cr))
+			}
+		}
+		if err := k.ensureCustomResources(appName, crd, resources); err != nil {
+			return errors.Annotatef(err, "custom resources of kind %q", crd.Kind)
+		}
+	}
+	return nil
+}
+
+func (k *kubernetesClient) ensureCustomResourceDefinition(appName string, crd caas.CustomResourceDefinition) error {
+	scope := crd.Scope
+	if scope == "" {
+		scope = caas.NamespacedScope
+	}
+	spec := map[string]interface{}{
+		"group":   crd.Group,
+		"version": crd.Version,
+		"scope":   scope,
+		"names": map[string]interface{}{
+			"kind":   crd.Kind,
+			"plural": crd.PluralName(),
+		},
+	}
+	if len(crd.Validation) > 0 {
+		spec["validation"] = crd.Validation
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1beta1",
+		"kind":       "CustomResourceDefinition",
+		"spec":       spec,
+	}}
+	obj.SetName(customResourceDefinitionName(crd))
+	// Definitions aren't namespaced, so label them with
+	// the model as well as the application.
+	obj.SetLabels(map[string]string{
+		labelApplication: appName,
+		labelModel:       k.namespace,
+	})
+
+	definitions := k.dynamicClient.Resource(customResourceDefinitions)
+	existing, err := definitions.Get(obj.GetName(), v1.GetOptions{})
+	var result *unstructured.Unstructured
+	if k8serrors.IsNotFound(err) {
+		result, err = definitions.Create(obj, v1.CreateOptions{})
+	} else if err == nil {
+		labels := existing.GetLabels()
+		if labels[labelApplication] != appName || labels[labelModel] != k.namespace {
+			return errors.AlreadyExistsf("custom resource definition %q", obj.GetName())
+		}
+		// Custom resource definitions can't be updated unconditionally.
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		result, err = definitions.Update(obj, v1.UpdateOptions{})
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if customResourceDefinitionEstablished(result) {
+		return nil
+	}
+	return errors.Trace(k.waitCustomResourceDefinitionEstablished(obj.GetName()))
+}
+
+// waitCustomResourceDefinitionEstablished waits for the named custom
+// resource definition to be established, after which the API server
+// will serve resources of the kind it defines.
+func (k *kubernetesClient) waitCustomResourceDefinitionEstablished(name string) error {
+	definitions := k.dynamicClient.Resource(customResourceDefinitions)
+	errNotEstablished := errors.New("not established")
+	err := retry.Call(retry.CallArgs{
+		Clock: clock.WallClock,
+		IsFatalError: func(err error) bool {
+			return errors.Cause(err) != errNotEstablished
+		},
+		Func: func() error {
+			crd, err := definitions.Get(name, v1.GetOptions{})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !customResourceDefinitionEstablished(crd) {
+				return errNotEstablished
+			}
+			return nil
+		},
+		Delay:       customResourceDefinitionDelay,
+		MaxDuration: customResourceDefinitionTimeout,
+	})
+	if retry.IsDurationExceeded(err) {
+		return errors.Timeoutf("waiting for custom resource definition %q to be established", name)
+	}
+	return errors.Trace(err)
+}
+
+// customResourceDefinitionEstablished reports whether the custom
+// resource definition has the Established condition set to True.
+func customResourceDefinitionEstablished(crd *unstructured.Unstructured) bool {
+	if crd == nil {
+		return false
+	}
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == "Established" && condition["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
+
+// customResource returns the object for the custom resource cr,
+// which is an instance of the custom resource definition crd.
+func (k *kubernetesClient) customResource(
+	appName string, crd caas.CustomResourceDefinition, cr caas.CustomResource,
+) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": crd.Group + "/" + crd.Version,
+		"kind":       crd.Kind,
+	}}
+	if cr.Spec != nil {
+		obj.Object["spec"] = cr.Spec
+	}
+	obj.SetName(cr.Name)
+	obj.SetLabels(map[string]string{labelApplication: appName})
+	return obj
+}
+
+// customResources returns the client used to manage
+// instances of the custom resource definition crd.
+func (k *kubernetesClient) customResources(crd caas.CustomResourceDefinition) dynamic.ResourceInterface {
+	resources := k.dynamicClient.Resource(schema.GroupVersionResource{
+		Group:    crd.Group,
+		Version:  crd.Version,
+		Resource: crd.PluralName(),
+	})
+	if crd.Scope == caas.ClusterScope {
+		return resources
+	}
+	return resources.Namespace(k.namespace)
+}
+
+// ensureCustomResources creates or updates the given instances of the
+// custom resource definition crd, and removes any other instances the
+// application previously created.
+func (k *kubernetesClient) ensureCustomResources(
+	appName string, crd caas.CustomResourceDefinition, objs []*unstructured.Unstructured,
+) error {
+	resources := k.customResources(crd)
+	wanted := set.NewStrings()
+	for _, obj := range objs {
+		wanted.Add(obj.GetName())
+	}
+	existing, err := resources.List(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, item := range existing.Items {
+		if wanted.Contains(item.GetName()) {
+			continue
+		}
+		err := resources.Delete(item.GetName(), &v1.DeleteOptions{
+			PropagationPolicy: &defaultPropagationPolicy,
+		})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+
+	for _, obj := range objs {
+		current, err := resources.Get(obj.GetName(), v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			_, err = resources.Create(obj, v1.CreateOptions{})
+		} else if err == nil {
+			// Custom resources can't be updated unconditionally.
+			obj.SetResourceVersion(current.GetResourceVersion())
+			_, err = resources.Update(obj, v1.UpdateOptions{})
+		}
+		if err != nil {
+			return errors.Annotatef(err, "custom resource %q", obj.GetName())
+		}
+	}
+	return nil
+}
+
+// deleteCustomResourceDefinitions removes the custom resource
+// definitions created for the application, except those named in
+// keep. This also removes all the custom resources they define.
+func (k *kubernetesClient) deleteCustomResourceDefinitions(appName string, keep set.Strings) error {
+	selector := applicationSelector(appName) + "," + modelSelector(k.namespace)
+	return errors.Trace(k.deleteCustomResourceDefinitionsMatching(selector, keep))
+}
+
+// deleteModelCustomResourceDefinitions removes all the
+// custom resource definitions created for the model.
+func (k *kubernetesClient) deleteModelCustomResourceDefinitions() error {
+	return errors.Trace(k.deleteCustomResourceDefinitionsMatching(modelSelector(k.namespace), nil))
+}
+
+func (k *kubernetesClient) deleteCustomResourceDefinitionsMatching(selector string, keep set.Strings) error {
+	definitions := k.dynamicClient.Resource(customResourceDefinitions)
+	existing, err := definitions.List(v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, item := range existing.Items {
+		if keep.Contains(item.GetName()) {
+			continue
+		}
+		logger.Debugf("deleting custom resource definition %s", item.GetName())
+		err := definitions.Delete(item.GetName(), &v1.DeleteOptions{
+			PropagationPolicy: &defaultPropagationPolicy,
+		})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func customResourceDefinitionName(crd caas.CustomResourceDefinition) string {
+	return crd.PluralName() + "." + crd.Group
+}
@@ -18,6 +18,9 @@ var (
 	ExtractRegistryURL     = extractRegistryURL
 	CreateDockerConfigJSON = createDockerConfigJSON
 	NewStorageConfig       = newStorageConfig
+
+	CustomResourceDefinitionDelay   = &customResourceDefinitionDelay
+	CustomResourceDefinitionTimeout = &customResourceDefinitionTimeout
 )
 
 func PodSpec(u *unitSpec) core.PodSpec {
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -35,6 +37,7 @@ import (
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/network"
@@ -50,6 +53,7 @@ const (
 	labelStorage     = "juju-storage"
 	labelVersion     = "juju-version"
 	labelApplication = "juju-application"
+	labelModel       = "juju-model"
 
 	operatorStorageClassName = "juju-operator-storage"
 	// TODO(caas) - make this configurable using application config
@@ -63,33 +67,75 @@ var defaultPropagationPolicy = v1.DeletePropagationForeground
 type kubernetesClient struct {
 	kubernetes.Interface
 
+	// dynamicClient is used to manage custom resource
+	// definitions and custom resources.
+	dynamicClient dynamic.Interface
+
 	// namespace is the k8s namespace to use when
 	// creating k8s resources.
 	namespace string
+
+	// lock guards the model config derived fields below.
+	lock sync.Mutex
+
+	// allowClusterRoles is true if charms may be granted
+	// roles across the whole cluster.
+	allowClusterRoles bool
 }
 
 // To regenerate the mocks for the kubernetes Client used by this broker,
 // run "go generate" from the package directory.
 //go:generate mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
 //go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
-//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface
+//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,ServiceAccountInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
+//go:generate mockgen -package mocks -destination mocks/dynamic_mock.go -mock_names Interface=MockDynamicInterface k8s.io/client-go/dynamic Interface,NamespaceableResourceInterface,ResourceInterface
+//go:generate mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,ClusterRoleInterface,ClusterRoleBindingInterface,RoleInterface,RoleBindingInterface
 
-// NewK8sClientFunc defines a function which returns a k8s client based on the supplied config.
-type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, error)
+// NewK8sClientFunc defines a function which returns k8s clients based on the supplied config.
+type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, dynamic.Interface, error)
 
-// NewK8sBroker returns a kubernetes client for the specified k8s cluster.
-func NewK8sBroker(cloudSpec environs.CloudSpec, namespace string, newClient NewK8sClientFunc) (caas.Broker, error) {
-	config, err := newK8sConfig(cloudSpec)
+// NewK8sBroker returns a kubernetes client for the specified k8s cluster,
+// managing resources in the namespace of the given model.
+func NewK8sBroker(cloudSpec environs.CloudSpec, cfg *config.Config, newClient NewK8sClientFunc) (caas.Broker, error) {
+	k8sConfig, err := newK8sConfig(cloudSpec)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	client, err := newClient(config)
+	client, dynamicClient, err := newClient(k8sConfig)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &kubernetesClient{Interface: client, namespace: namespace}, nil
+	return &kubernetesClient{
+		Interface:         client,
+		dynamicClient:     dynamicClient,
+		namespace:         cfg.Name(),
+		allowClusterRoles: allowClusterRoles(cfg),
+	}, nil
+}
+
+// allowClusterRoles returns whether the given model config allows
+// charms to be granted roles across the whole cluster.
+func allowClusterRoles(cfg *config.Config) bool {
+	allowed, _ := cfg.UnknownAttrs()[allowClusterRolesKey].(bool)
+	return allowed
+}
+
+// SetConfig is part of the Broker interface.
+func (k *kubernetesClient) SetConfig(cfg *config.Config) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.allowClusterRoles = allowClusterRoles(cfg)
+	return nil
+}
+
+// clusterRolesAllowed returns whether charms may currently be
+// granted roles across the whole cluster.
+func (k *kubernetesClient) clusterRolesAllowed() bool {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	return k.allowClusterRoles
 }
 
 func newK8sConfig(cloudSpec environs.CloudSpec) (*rest.Config, error) {
@@ -122,6 +168,15 @@ func (*kubernetesClient) Provider() caas.ContainerEnvironProvider {
 
 // Destroy is part of the Broker interface.
 func (k *kubernetesClient) Destroy(context.ProviderCallContext) error {
+	// Cluster roles and custom resource definitions live
+	// outside the namespace, so they need to be removed
+	// separately.
+	if err := k.deleteClusterRoles(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteModelCustomResourceDefinitions(); err != nil {
+		return errors.Trace(err)
+	}
 	return k.deleteNamespace()
 }
 
@@ -500,7 +555,13 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 			return errors.Trace(err)
 		}
 	}
-	return errors.Trace(k.deleteDeployment(appName))
+	if err := k.deleteDeployment(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteServiceAccount(appName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteCustomResourceDefinitions(appName, nil))
 }
 
 // EnsureService creates or updates a service for pods with the given params.
@@ -553,6 +614,13 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteSecret(appName, c.Name) })
 	}
 
+	if err := k.configureServiceAccount(appName, params.PodSpec.ServiceAccount); err != nil {
+		return errors.Annotatef(err, "configuring service account for %s", appName)
+	}
+	if err := k.configureCustomResources(appName, params.PodSpec); err != nil {
+		return errors.Annotatef(err, "configuring custom resources for %s", appName)
+	}
+
 	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
 	// Defensively check to see if a stateful set is already used.
 	useStatefulSet := len(params.Filesystems) > 0
@@ -1045,6 +1113,10 @@ func applicationSelector(appName string) string {
 	return fmt.Sprintf("%v==%v", labelApplication, appName)
 }
 
+func modelSelector(namespace string) string {
+	return fmt.Sprintf("%v==%v", labelModel, namespace)
+}
+
 // WatchUnits returns a watcher which notifies when there
 // are changes to units of the specified application.
 func (k *kubernetesClient) WatchUnits(appName string) (watcher.NotifyWatcher, error) {
@@ -1503,6 +1575,10 @@ func makeUnitSpec(appName string, podSpec *caas.PodSpec) (*unitSpec, error) {
 		}
 	}
 	unitSpec.Pod.ImagePullSecrets = imageSecretNames
+	if podSpec.ServiceAccount != nil {
+		unitSpec.Pod.ServiceAccountName = serviceAccountName(appName)
+		unitSpec.Pod.AutomountServiceAccountToken = podSpec.ServiceAccount.AutomountServiceAccountToken
+	}
 	return &unitSpec, nil
 }
 
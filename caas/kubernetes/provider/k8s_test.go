@@ -4,21 +4,29 @@
 package provider_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/caas/kubernetes/provider/mocks"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 )
@@ -120,6 +128,16 @@ func (s *K8sSuite) TestMakeUnitSpecConfigPairs(c *gc.C) {
 	})
 }
 
+func (s *K8sSuite) TestMakeUnitSpecServiceAccount(c *gc.C) {
+	automount := false
+	podSpec := *basicPodspec
+	podSpec.ServiceAccount = &caas.ServiceAccountSpec{AutomountServiceAccountToken: &automount}
+	spec, err := provider.MakeUnitSpec("app-name", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.PodSpec(spec).ServiceAccountName, gc.Equals, "juju-app-name")
+	c.Assert(provider.PodSpec(spec).AutomountServiceAccountToken, jc.DeepEquals, &automount)
+}
+
 func (s *K8sSuite) TestOperatorPodConfig(c *gc.C) {
 	pod := provider.OperatorPod("gitlab", "/var/lib/juju", "jujusolutions/caas-jujud-operator", "2.99.0")
 	c.Assert(pod.Name, gc.Equals, "juju-operator-gitlab")
@@ -174,6 +192,18 @@ func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
 			Return(&core.PodList{Items: []core.Pod{}}, nil),
 		s.mockDeployments.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
 	)
 
 	err := s.broker.DeleteService("test")
@@ -247,6 +277,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	}
 
 	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
 		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
@@ -335,6 +369,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 	}
 
 	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
 		s.mockPersistentVolumeClaims.EXPECT().Get("juju-database-0", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -425,6 +463,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 	}
 
 	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
 		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
@@ -531,6 +573,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 	}
 
 	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
 		s.mockPersistentVolumeClaims.EXPECT().Get("juju-database-0", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -572,3 +618,441 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+var serviceAccountPodspec = &caas.PodSpec{
+	Containers: basicPodspec.Containers,
+	ServiceAccount: &caas.ServiceAccountSpec{
+		Rules: []caas.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list", "watch"},
+		}},
+	},
+}
+
+func (s *K8sBrokerSuite) deploymentAndServiceCalls(c *gc.C, podSpec *caas.PodSpec) []*gomock.Call {
+	unitSpec, err := provider.MakeUnitSpec("test", podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	numUnits := int32(1)
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-application-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: provider.PodSpec(unitSpec),
+			},
+		},
+	}
+	return []*gomock.Call{
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any()).Times(1).
+			Return(nil, nil),
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccount(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	labels := map[string]string{"juju-application": "test"}
+	serviceAccountArg := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-test", Labels: labels},
+	}
+	rules := []rbacv1.PolicyRule{{
+		APIGroups: []string{""},
+		Resources: []string{"pods"},
+		Verbs:     []string{"get", "list", "watch"},
+	}}
+	roleArg := &rbacv1.Role{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-test", Labels: labels},
+		Rules:      rules,
+	}
+	roleBindingArg := &rbacv1.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-test", Labels: labels},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "juju-test",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      "juju-test",
+			Namespace: "test",
+		}},
+	}
+
+	calls := []*gomock.Call{
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Update(serviceAccountArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Create(serviceAccountArg).Times(1).
+			Return(nil, nil),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Update(roleArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Create(roleArg).Times(1).
+			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Update(roleBindingArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Create(roleBindingArg).Times(1).
+			Return(nil, nil),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
+	}
+	gomock.InOrder(append(calls, s.deploymentAndServiceCalls(c, serviceAccountPodspec)...)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: serviceAccountPodspec,
+	}
+	err := s.broker.EnsureService("test", params, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithClusterRole(c *gc.C) {
+	s.modelConfigAttrs = testing.Attrs{"kubernetes-allow-cluster-roles": true}
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *serviceAccountPodspec
+	podSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		Global: true,
+		Rules:  serviceAccountPodspec.ServiceAccount.Rules,
+	}
+	labels := map[string]string{"juju-application": "test", "juju-model": "test"}
+	clusterRoleArg := &rbacv1.ClusterRole{
+		ObjectMeta: v1.ObjectMeta{Name: "test-juju-test", Labels: labels},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list", "watch"},
+		}},
+	}
+	clusterRoleBindingArg := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: v1.ObjectMeta{Name: "test-juju-test", Labels: labels},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "test-juju-test",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      "juju-test",
+			Namespace: "test",
+		}},
+	}
+
+	calls := []*gomock.Call{
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(&core.ServiceAccount{}, nil),
+		s.mockServiceAccounts.EXPECT().Update(gomock.Any()).Times(1).
+			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockRoles.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockClusterRoles.EXPECT().Update(clusterRoleArg).Times(1).
+			Return(nil, nil),
+		s.mockClusterRoleBindings.EXPECT().Update(clusterRoleBindingArg).Times(1).
+			Return(nil, nil),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
+	}
+	gomock.InOrder(append(calls, s.deploymentAndServiceCalls(c, &podSpec)...)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceClusterRoleNotAllowed(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *serviceAccountPodspec
+	podSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		Global: true,
+		Rules:  serviceAccountPodspec.ServiceAccount.Rules,
+	}
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 1, nil)
+	c.Assert(err, gc.ErrorMatches, `configuring service account for test: cluster-wide roles not allowed, set model config "kubernetes-allow-cluster-roles" to true to enable them`)
+}
+
+func (s *K8sBrokerSuite) TestSetConfigAllowsClusterRoles(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	cfg, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		config.NameKey:                   testNamespace,
+		"kubernetes-allow-cluster-roles": true,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The cluster role is now created rather than refused.
+	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Update(gomock.Any()).Times(1).
+			Return(nil, errors.New("boom")),
+	)
+	podSpec := *serviceAccountPodspec
+	podSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		Global: true,
+		Rules:  serviceAccountPodspec.ServiceAccount.Rules,
+	}
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err = s.broker.EnsureService("test", params, 1, nil)
+	c.Assert(err, gc.ErrorMatches, `configuring service account for test: boom`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceRemovesServiceAccount(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	calls := []*gomock.Call{
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(&core.ServiceAccount{}, nil),
+		s.mockRoleBindings.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockRoles.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
+	}
+	gomock.InOrder(append(calls, s.deploymentAndServiceCalls(c, basicPodspec)...)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	err := s.broker.EnsureService("test", params, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithCustomResources(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.CustomResourceDefinitions = []caas.CustomResourceDefinition{{
+		Kind:    "TFJob",
+		Group:   "kubeflow.org",
+		Version: "v1alpha2",
+	}}
+	podSpec.CustomResources = []caas.CustomResource{{
+		Kind: "TFJob",
+		Name: "dist-mnist",
+		Spec: map[string]interface{}{"replicas": int64(2)},
+	}}
+
+	crdArg := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1beta1",
+		"kind":       "CustomResourceDefinition",
+		"metadata": map[string]interface{}{
+			"name": "tfjobs.kubeflow.org",
+			"labels": map[string]interface{}{
+				"juju-application": "test",
+				"juju-model":       "test",
+			},
+		},
+		"spec": map[string]interface{}{
+			"group":   "kubeflow.org",
+			"version": "v1alpha2",
+			"scope":   "Namespaced",
+			"names": map[string]interface{}{
+				"kind":   "TFJob",
+				"plural": "tfjobs",
+			},
+		},
+	}}
+	crArg := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kubeflow.org/v1alpha2",
+		"kind":       "TFJob",
+		"metadata": map[string]interface{}{
+			"name":   "dist-mnist",
+			"labels": map[string]interface{}{"juju-application": "test"},
+		},
+		"spec": map[string]interface{}{"replicas": int64(2)},
+	}}
+	stale := unstructured.Unstructured{}
+	stale.SetName("old-job")
+
+	mockTFJobs := mocks.NewMockNamespaceableResourceInterface(ctrl)
+	mockNamespacedTFJobs := mocks.NewMockResourceInterface(ctrl)
+	s.mockDynamic.EXPECT().Resource(schema.GroupVersionResource{
+		Group:    "kubeflow.org",
+		Version:  "v1alpha2",
+		Resource: "tfjobs",
+	}).AnyTimes().Return(mockTFJobs)
+	mockTFJobs.EXPECT().Namespace("test").AnyTimes().Return(mockNamespacedTFJobs)
+
+	calls := []*gomock.Call{
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
+		s.mockCustomResourceDefinitions.EXPECT().Get("tfjobs.kubeflow.org", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().Create(crdArg, v1.CreateOptions{}).Times(1).
+			Return(crdArg, nil),
+		s.mockCustomResourceDefinitions.EXPECT().Get("tfjobs.kubeflow.org", v1.GetOptions{}).Times(1).
+			Return(establishedCustomResourceDefinition(crdArg), nil),
+		mockNamespacedTFJobs.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{stale}}, nil),
+		mockNamespacedTFJobs.EXPECT().Delete("old-job", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		mockNamespacedTFJobs.EXPECT().Get("dist-mnist", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		mockNamespacedTFJobs.EXPECT().Create(crArg, v1.CreateOptions{}).Times(1).
+			Return(crArg, nil),
+	}
+	gomock.InOrder(append(calls, s.deploymentAndServiceCalls(c, &podSpec)...)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func establishedCustomResourceDefinition(crd *unstructured.Unstructured) *unstructured.Unstructured {
+	established := crd.DeepCopy()
+	established.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+			map[string]interface{}{"type": "Established", "status": "True"},
+		},
+	}
+	return established
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceCustomResourceDefinitionNotEstablished(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+	s.PatchValue(provider.CustomResourceDefinitionDelay, time.Millisecond)
+	s.PatchValue(provider.CustomResourceDefinitionTimeout, 10*time.Millisecond)
+
+	podSpec := *basicPodspec
+	podSpec.CustomResourceDefinitions = []caas.CustomResourceDefinition{{
+		Kind:    "TFJob",
+		Group:   "kubeflow.org",
+		Version: "v1alpha2",
+	}}
+
+	pending := &unstructured.Unstructured{}
+	pending.SetName("tfjobs.kubeflow.org")
+	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
+		s.mockCustomResourceDefinitions.EXPECT().Get("tfjobs.kubeflow.org", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().Create(gomock.Any(), v1.CreateOptions{}).Times(1).
+			Return(pending, nil),
+		s.mockCustomResourceDefinitions.EXPECT().Get("tfjobs.kubeflow.org", v1.GetOptions{}).MinTimes(1).
+			Return(pending, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 1, nil)
+	c.Assert(err, gc.ErrorMatches, `custom resource definition "TFJob": waiting for custom resource definition "tfjobs.kubeflow.org" to be established timeout`)
+	c.Assert(err, jc.Satisfies, errors.IsTimeout)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceCustomResourceDefinitionConflict(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.CustomResourceDefinitions = []caas.CustomResourceDefinition{{
+		Kind:    "TFJob",
+		Group:   "kubeflow.org",
+		Version: "v1alpha2",
+	}}
+	existing := &unstructured.Unstructured{}
+	existing.SetLabels(map[string]string{"juju-application": "test", "juju-model": "other"})
+
+	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{}, nil),
+		s.mockCustomResourceDefinitions.EXPECT().Get("tfjobs.kubeflow.org", v1.GetOptions{}).Times(1).
+			Return(existing, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 1, nil)
+	c.Assert(err, gc.ErrorMatches, `configuring custom resources for test: custom resource definition "TFJob": custom resource definition "tfjobs.kubeflow.org" already exists`)
+}
+
+func (s *K8sBrokerSuite) TestDeleteServiceRemovesCustomResourceDefinitions(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	crd := unstructured.Unstructured{}
+	crd.SetName("tfjobs.kubeflow.org")
+	gomock.InOrder(
+		s.mockServices.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).
+			Return(&core.PodList{Items: []core.Pod{}}, nil),
+		s.mockDeployments.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockCustomResourceDefinitions.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test,juju-model==test"}).Times(1).
+			Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{crd}}, nil),
+		s.mockCustomResourceDefinitions.EXPECT().Delete("tfjobs.kubeflow.org", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+	)
+
+	err := s.broker.DeleteService("test")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"gopkg.in/yaml.v2"
 	core "k8s.io/api/core/v1"
@@ -25,6 +26,11 @@ type k8sContainers struct {
 	Containers []k8sContainer `json:"containers"`
 }
 
+type k8sCustomResources struct {
+	CustomResourceDefinitions []caas.CustomResourceDefinition `json:"customResourceDefinitions"`
+	CustomResources           []caas.CustomResource           `json:"customResources"`
+}
+
 // K8sContainerSpec is a subset of v1.Container which defines
 // attributes we expose for charms to set.
 type K8sContainerSpec struct {
@@ -59,6 +65,21 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 		return nil, errors.New("require at least one container spec")
 	}
 
+	// Do the custom resources, which need decoding
+	// into JSON compatible types.
+	var customResources k8sCustomResources
+	decoder = k8syaml.NewYAMLOrJSONDecoder(strings.NewReader(in), len(in))
+	if err := decoder.Decode(&customResources); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, crd := range customResources.CustomResourceDefinitions {
+		if err := validateCustomResourceSchema(crd.Validation); err != nil {
+			return nil, errors.Annotatef(err, "custom resource definition %q", crd.Kind)
+		}
+	}
+	spec.CustomResourceDefinitions = customResources.CustomResourceDefinitions
+	spec.CustomResources = customResources.CustomResources
+
 	// Compose the result.
 	spec.Containers = make([]caas.ContainerSpec, len(containers.Containers))
 	for i, c := range containers.Containers {
@@ -82,3 +103,80 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 	}
 	return &spec, nil
 }
+
+// openAPISchemaTypes are the types which may be used in the
+// schema of a custom resource definition.
+var openAPISchemaTypes = set.NewStrings(
+	"object", "array", "string", "integer", "number", "boolean",
+)
+
+// unsupportedSchemaFields are OpenAPI v3 schema fields which
+// k8s does not allow in custom resource validation.
+var unsupportedSchemaFields = []string{
+	"$ref", "$schema", "id", "definitions", "dependencies",
+	"patternProperties", "additionalItems", "default",
+}
+
+// validateCustomResourceSchema checks the validation for a custom
+// resource definition holds a schema k8s will accept.
+func validateCustomResourceSchema(validation map[string]interface{}) error {
+	if len(validation) == 0 {
+		return nil
+	}
+	for k := range validation {
+		if k != "openAPIV3Schema" {
+			return errors.NotValidf("validation key %q", k)
+		}
+	}
+	return errors.Trace(validateSchemaProps("openAPIV3Schema", validation["openAPIV3Schema"]))
+}
+
+func validateSchemaProps(path string, in interface{}) error {
+	props, ok := in.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("%s: expected a schema, got %T", path, in)
+	}
+	for _, field := range unsupportedSchemaFields {
+		if _, ok := props[field]; ok {
+			return errors.NotSupportedf("%s: %q", path, field)
+		}
+	}
+	if t, ok := props["type"]; ok {
+		typeName, _ := t.(string)
+		if !openAPISchemaTypes.Contains(typeName) {
+			return errors.NotValidf("%s: type %v", path, t)
+		}
+	}
+	var properties map[string]interface{}
+	if p, ok := props["properties"]; ok {
+		if properties, ok = p.(map[string]interface{}); !ok {
+			return errors.Errorf("%s.properties: expected a map, got %T", path, p)
+		}
+		for name, prop := range properties {
+			if err := validateSchemaProps(path+".properties."+name, prop); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	if items, ok := props["items"]; ok {
+		if err := validateSchemaProps(path+".items", items); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if r, ok := props["required"]; ok {
+		required, ok := r.([]interface{})
+		if !ok {
+			return errors.Errorf("%s.required: expected a list, got %T", path, r)
+		}
+		for _, name := range required {
+			nameStr, ok := name.(string)
+			if !ok {
+				return errors.NotValidf("%s.required: %v", path, name)
+			}
+			if _, ok := properties[nameStr]; !ok {
+				return errors.Errorf("%s.required: %q is not a defined property", path, nameStr)
+			}
+		}
+	}
+	return nil
+}
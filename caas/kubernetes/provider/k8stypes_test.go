@@ -143,3 +143,72 @@ foo: bar
 			},
 		}}})
 }
+
+func (s *ContainersSuite) TestParseCustomResources(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: tf-operator
+    image: kubeflow/tf-operator
+customResourceDefinitions:
+  - kind: TFJob
+    group: kubeflow.org
+    version: v1alpha2
+    scope: Namespaced
+    validation:
+      openAPIV3Schema:
+        properties:
+          tfReplicaSpecs:
+            properties:
+              Worker:
+                properties:
+                  replicas:
+                    type: integer
+                    minimum: 1
+customResources:
+  - kind: TFJob
+    name: dist-mnist
+    spec:
+      tfReplicaSpecs:
+        Worker:
+          replicas: 2
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.CustomResourceDefinitions, jc.DeepEquals, []caas.CustomResourceDefinition{{
+		Kind:    "TFJob",
+		Group:   "kubeflow.org",
+		Version: "v1alpha2",
+		Scope:   "Namespaced",
+		Validation: map[string]interface{}{
+			"openAPIV3Schema": map[string]interface{}{
+				"properties": map[string]interface{}{
+					"tfReplicaSpecs": map[string]interface{}{
+						"properties": map[string]interface{}{
+							"Worker": map[string]interface{}{
+								"properties": map[string]interface{}{
+									"replicas": map[string]interface{}{
+										"type":    "integer",
+										"minimum": float64(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}})
+	c.Assert(spec.CustomResources, jc.DeepEquals, []caas.CustomResource{{
+		Kind: "TFJob",
+		Name: "dist-mnist",
+		Spec: map[string]interface{}{
+			"tfReplicaSpecs": map[string]interface{}{
+				"Worker": map[string]interface{}{
+					"replicas": float64(2),
+				},
+			},
+		},
+	}})
+}
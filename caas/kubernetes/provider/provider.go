@@ -8,6 +8,9 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/jsonschema"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -18,6 +21,32 @@ import (
 	"github.com/juju/juju/environs/context"
 )
 
+const (
+	// allowClusterRolesKey is the model config key which determines
+	// whether charms may be granted roles across the whole cluster,
+	// rather than just within the model's namespace.
+	allowClusterRolesKey = "kubernetes-allow-cluster-roles"
+)
+
+var modelConfigSchema = environschema.Fields{
+	allowClusterRolesKey: {
+		Description: "Whether charms may request service accounts with cluster-wide roles.",
+		Type:        environschema.Tbool,
+	},
+}
+
+var modelConfigFields = func() schema.Fields {
+	fs, _, err := modelConfigSchema.ValidationSchema()
+	if err != nil {
+		panic(err)
+	}
+	return fs
+}()
+
+var modelConfigDefaults = schema.Defaults{
+	allowClusterRolesKey: false,
+}
+
 type kubernetesEnvironProvider struct {
 	environProviderCredentials
 }
@@ -30,9 +59,16 @@ func (kubernetesEnvironProvider) Version() int {
 	return 0
 }
 
-func newK8sClient(c *rest.Config) (kubernetes.Interface, error) {
+func newK8sClient(c *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
 	client, err := kubernetes.NewForConfig(c)
-	return client, err
+	if err != nil {
+		return nil, nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, dynamicClient, nil
 }
 
 // Open is part of the ContainerEnvironProvider interface.
@@ -41,7 +77,7 @@ func (kubernetesEnvironProvider) Open(args environs.OpenParams) (caas.Broker, er
 	if err := validateCloudSpec(args.Cloud); err != nil {
 		return nil, errors.Annotate(err, "validating cloud spec")
 	}
-	broker, err := NewK8sBroker(args.Cloud, args.Config.Name(), newK8sClient)
+	broker, err := NewK8sBroker(args.Cloud, args.Config, newK8sClient)
 	if err != nil {
 		return nil, err
 	}
@@ -88,11 +124,36 @@ func (p kubernetesEnvironProvider) DetectRegions() ([]cloud.Region, error) {
 	return nil, errors.NotFoundf("regions")
 }
 
+// Schema returns the configuration schema for a kubernetes model.
+func (p kubernetesEnvironProvider) Schema() environschema.Fields {
+	fields, err := config.Schema(modelConfigSchema)
+	if err != nil {
+		panic(err)
+	}
+	return fields
+}
+
+// ConfigSchema returns extra config attributes specific
+// to this provider only.
+func (p kubernetesEnvironProvider) ConfigSchema() schema.Fields {
+	return modelConfigFields
+}
+
+// ConfigDefaults returns the default values for the
+// provider specific config attributes.
+func (p kubernetesEnvironProvider) ConfigDefaults() schema.Defaults {
+	return modelConfigDefaults
+}
+
 func (p kubernetesEnvironProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
 	if err := config.Validate(cfg, old); err != nil {
 		return nil, err
 	}
-	return cfg, nil
+	attrs, err := cfg.ValidateUnknownAttrs(modelConfigFields, modelConfigDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cfg.Apply(attrs)
 }
 
 func validateCloudSpec(spec environs.CloudSpec) error {
@@ -124,8 +124,16 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	validCfg, err := s.provider.Validate(config, nil)
 	c.Check(err, jc.ErrorIsNil)
 
-	validAttrs := validCfg.AllAttrs()
-	c.Assert(config.AllAttrs(), gc.DeepEquals, validAttrs)
+	expected := config.AllAttrs()
+	expected["kubernetes-allow-cluster-roles"] = false
+	c.Assert(validCfg.AllAttrs(), gc.DeepEquals, expected)
+}
+
+func (s *providerSuite) TestValidateAllowClusterRoles(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{"kubernetes-allow-cluster-roles": "true"})
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.AllAttrs()["kubernetes-allow-cluster-roles"], jc.IsTrue)
 }
 
 func (s *providerSuite) TestParsePodSpec(c *gc.C) {
@@ -289,3 +297,145 @@ containers:
 	err = spec.Validate()
 	c.Assert(err, gc.ErrorMatches, `mount path is missing for file set "configuration"`)
 }
+
+func (s *providerSuite) TestParsePodSpecServiceAccount(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+serviceAccount:
+  automountServiceAccountToken: true
+  global: true
+  rules:
+    - apiGroups: [""]
+      resources: ["pods"]
+      verbs: ["get", "watch", "list"]
+    - nonResourceURLs: ["/healthz"]
+      verbs: ["get"]
+`[1:]
+
+	spec, err := s.provider.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	automount := true
+	c.Assert(spec.ServiceAccount, jc.DeepEquals, &caas.ServiceAccountSpec{
+		AutomountServiceAccountToken: &automount,
+		Global:                       true,
+		Rules: []caas.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "watch", "list"},
+		}, {
+			NonResourceURLs: []string{"/healthz"},
+			Verbs:           []string{"get"},
+		}},
+	})
+}
+
+func (s *providerSuite) TestValidateServiceAccountRules(c *gc.C) {
+	for i, test := range []struct {
+		rules  string
+		expect string
+	}{{
+		rules: `
+    - resources: ["pods"]
+`,
+		expect: "rule 0 has no verbs",
+	}, {
+		rules: `
+    - verbs: ["get"]
+`,
+		expect: "rule 0 needs resources or nonResourceURLs",
+	}, {
+		rules: `
+    - nonResourceURLs: ["/healthz"]
+      verbs: ["get"]
+`,
+		expect: "rule 0: nonResourceURLs require a global service account",
+	}} {
+		c.Logf("test %d", i)
+		specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+serviceAccount:
+  rules:`[1:] + test.rules
+
+		_, err := s.provider.ParsePodSpec(specStr)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *providerSuite) TestValidateCustomResources(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		expect string
+	}{{
+		spec: `
+customResourceDefinitions:
+  - group: kubeflow.org
+    version: v1alpha2
+`,
+		expect: "custom resource definition kind is missing",
+	}, {
+		spec: `
+customResourceDefinitions:
+  - kind: TFJob
+    group: kubeflow.org
+    version: v1alpha2
+    scope: Global
+`,
+		expect: `scope "Global" for custom resource definition "TFJob" not valid`,
+	}, {
+		spec: `
+customResourceDefinitions:
+  - kind: TFJob
+    group: kubeflow.org
+    version: v1alpha2
+    validation:
+      openAPIV3Schema:
+        properties:
+          replicas:
+            type: int
+`,
+		expect: `custom resource definition "TFJob": openAPIV3Schema.properties.replicas: type int not valid`,
+	}, {
+		spec: `
+customResourceDefinitions:
+  - kind: TFJob
+    group: kubeflow.org
+    version: v1alpha2
+    validation:
+      openAPIV3Schema:
+        required: ["replicas"]
+`,
+		expect: `custom resource definition "TFJob": openAPIV3Schema.required: "replicas" is not a defined property`,
+	}, {
+		spec: `
+customResourceDefinitions:
+  - kind: TFJob
+    group: kubeflow.org
+    version: v1alpha2
+    validation:
+      openAPIV3Schema:
+        $ref: "#/definitions/job"
+`,
+		expect: `custom resource definition "TFJob": openAPIV3Schema: "\$ref" not supported`,
+	}, {
+		spec: `
+customResources:
+  - kind: TFJob
+    name: dist-mnist
+`,
+		expect: `custom resource "dist-mnist" has undefined kind "TFJob"`,
+	}} {
+		c.Logf("test %d", i)
+		specStr := `
+containers:
+  - name: tf-operator
+    image: kubeflow/tf-operator`[1:] + test.spec
+
+		_, err := s.provider.ParsePodSpec(specStr)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
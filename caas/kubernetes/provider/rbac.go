@@ -0,0 +1,249 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+// configureServiceAccount creates or updates the service account the
+// application's pods run as, along with the role and role binding
+// granting it the access requested in the pod spec. Any access that is
+// no longer requested is removed.
+func (k *kubernetesClient) configureServiceAccount(appName string, spec *caas.ServiceAccountSpec) error {
+	if spec == nil {
+		// Only clean up if a service account was previously requested.
+		_, err := k.CoreV1().ServiceAccounts(k.namespace).Get(serviceAccountName(appName), v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(k.deleteServiceAccount(appName))
+	}
+	if spec.Global && !k.clusterRolesAllowed() {
+		return errors.Errorf("cluster-wide roles not allowed, set model config %q to true to enable them", allowClusterRolesKey)
+	}
+	logger.Debugf("creating/updating service account for %s", appName)
+
+	name := serviceAccountName(appName)
+	labels := map[string]string{labelApplication: appName}
+	serviceAccount := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		AutomountServiceAccountToken: spec.AutomountServiceAccountToken,
+	}
+	if err := k.ensureServiceAccount(serviceAccount); err != nil {
+		return errors.Trace(err)
+	}
+
+	rules := make([]rbac.PolicyRule, len(spec.Rules))
+	for i, rule := range spec.Rules {
+		rules[i] = rbac.PolicyRule{
+			Verbs:           rule.Verbs,
+			APIGroups:       rule.APIGroups,
+			Resources:       rule.Resources,
+			ResourceNames:   rule.ResourceNames,
+			NonResourceURLs: rule.NonResourceURLs,
+		}
+	}
+	subjects := []rbac.Subject{{
+		Kind:      rbac.ServiceAccountKind,
+		Name:      name,
+		Namespace: k.namespace,
+	}}
+
+	// The service account has either a namespaced role or
+	// a cluster role, so remove whichever isn't wanted.
+	if len(rules) == 0 || spec.Global {
+		if err := k.deleteRole(appName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(rules) == 0 || !spec.Global {
+		if err := k.deleteClusterRole(appName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	if spec.Global {
+		clusterName := clusterRoleName(k.namespace, appName)
+		// Label cluster roles with the model so they
+		// can be removed when the model is destroyed.
+		clusterLabels := map[string]string{labelApplication: appName, labelModel: k.namespace}
+		if err := k.ensureClusterRole(&rbac.ClusterRole{
+			ObjectMeta: v1.ObjectMeta{Name: clusterName, Labels: clusterLabels},
+			Rules:      rules,
+		}); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(k.ensureClusterRoleBinding(&rbac.ClusterRoleBinding{
+			ObjectMeta: v1.ObjectMeta{Name: clusterName, Labels: clusterLabels},
+			RoleRef: rbac.RoleRef{
+				APIGroup: rbac.GroupName,
+				Kind:     "ClusterRole",
+				Name:     clusterName,
+			},
+			Subjects: subjects,
+		}))
+	}
+	if err := k.ensureRole(&rbac.Role{
+		ObjectMeta: v1.ObjectMeta{Name: name, Labels: labels},
+		Rules:      rules,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.ensureRoleBinding(&rbac.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{Name: name, Labels: labels},
+		RoleRef: rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+		Subjects: subjects,
+	}))
+}
+
+// deleteServiceAccount removes the application's service account
+// and any roles and role bindings granting it access.
+func (k *kubernetesClient) deleteServiceAccount(appName string) error {
+	if err := k.deleteRole(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteClusterRole(appName); err != nil {
+		return errors.Trace(err)
+	}
+	err := k.CoreV1().ServiceAccounts(k.namespace).Delete(serviceAccountName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureServiceAccount(spec *core.ServiceAccount) error {
+	serviceAccounts := k.CoreV1().ServiceAccounts(k.namespace)
+	// Keep the token secrets k8s has generated for an existing account.
+	existing, err := serviceAccounts.Get(spec.Name, v1.GetOptions{})
+	if err == nil {
+		spec.Secrets = existing.Secrets
+		spec.ObjectMeta.ResourceVersion = existing.ObjectMeta.ResourceVersion
+	}
+	_, err = serviceAccounts.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = serviceAccounts.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureRole(spec *rbac.Role) error {
+	roles := k.RbacV1().Roles(k.namespace)
+	_, err := roles.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureRoleBinding(spec *rbac.RoleBinding) error {
+	bindings := k.RbacV1().RoleBindings(k.namespace)
+	_, err := bindings.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = bindings.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteRole(appName string) error {
+	name := serviceAccountName(appName)
+	err := k.RbacV1().RoleBindings(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	err = k.RbacV1().Roles(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureClusterRole(spec *rbac.ClusterRole) error {
+	roles := k.RbacV1().ClusterRoles()
+	_, err := roles.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureClusterRoleBinding(spec *rbac.ClusterRoleBinding) error {
+	bindings := k.RbacV1().ClusterRoleBindings()
+	_, err := bindings.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = bindings.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteClusterRole(appName string) error {
+	name := clusterRoleName(k.namespace, appName)
+	err := k.RbacV1().ClusterRoleBindings().Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	err = k.RbacV1().ClusterRoles().Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// deleteClusterRoles removes the cluster roles and bindings
+// created for all applications in the model.
+func (k *kubernetesClient) deleteClusterRoles() error {
+	listOptions := v1.ListOptions{LabelSelector: modelSelector(k.namespace)}
+	err := k.RbacV1().ClusterRoleBindings().DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, listOptions)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	err = k.RbacV1().ClusterRoles().DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, listOptions)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func serviceAccountName(appName string) string {
+	return deploymentName(appName)
+}
+
+func clusterRoleName(namespace, appName string) string {
+	// Cluster roles aren't namespaced, so include the
+	// namespace to keep the names unique per model.
+	return namespace + "-" + deploymentName(appName)
+}
@@ -56,6 +56,14 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// EnqueueOperations adds operations that run an action on a number
+	// of units, returning the details of each operation added.
+	EnqueueOperations(params.EnqueueOperationsArgs) (params.OperationResults, error)
+
+	// Operations fetches operations by ID, along with the progress of
+	// each of their actions.
+	Operations(params.OperationQueryArgs) (params.OperationResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
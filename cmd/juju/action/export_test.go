@@ -37,6 +37,10 @@ func (c *RunCommand) UnitTags() []names.UnitTag {
 	return c.unitTags
 }
 
+func (c *RunCommand) ApplicationName() string {
+	return c.applicationName
+}
+
 func (c *RunCommand) ActionName() string {
 	return c.actionName
 }
@@ -53,6 +57,10 @@ func (c *RunCommand) Args() [][]string {
 	return c.args
 }
 
+type ShowOperationCommand struct {
+	*showOperationCommand
+}
+
 type ListCommand struct {
 	*listCommand
 }
@@ -71,6 +79,12 @@ func NewShowOutputCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Sh
 	return modelcmd.Wrap(c), &ShowOutputCommand{c}
 }
 
+func NewShowOperationCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ShowOperationCommand) {
+	c := &showOperationCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &ShowOperationCommand{c}
+}
+
 func NewStatusCommandForTest(store jujuclient.ClientStore) (cmd.Command, *StatusCommand) {
 	c := &statusCommand{}
 	c.SetClientStore(store)
@@ -131,6 +131,8 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	operationArgs      params.EnqueueOperationsArgs
+	operationResults   []params.OperationResult
 	apiErr             error
 }
 
@@ -213,3 +215,12 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) EnqueueOperations(args params.EnqueueOperationsArgs) (params.OperationResults, error) {
+	c.operationArgs = args
+	return params.OperationResults{Results: c.operationResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) Operations(args params.OperationQueryArgs) (params.OperationResults, error) {
+	return params.OperationResults{Results: c.operationResults}, c.apiErr
+}
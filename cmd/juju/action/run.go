@@ -31,13 +31,20 @@ func NewRunCommand() cmd.Command {
 // params
 type runCommand struct {
 	ActionCommandBase
-	unitTags     []names.UnitTag
-	actionName   string
-	paramsYAML   cmd.FileVar
-	parseStrings bool
-	wait         waitFlag
-	out          cmd.Output
-	args         [][]string
+	unitTags        []names.UnitTag
+	applicationName string
+	actionName      string
+	paramsYAML      cmd.FileVar
+	parseStrings    bool
+	wait            waitFlag
+	out             cmd.Output
+	args            [][]string
+
+	maxParallel   int
+	batchSize     int
+	stopOnFailure bool
+	at            string
+	scheduled     time.Time
 }
 
 const runDoc = `
@@ -117,6 +124,24 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+An action may instead be run as an operation, which the controller works
+through unit by unit. An operation is used when an application is given in
+place of units, or when any of --max-parallel, --batch-size,
+--stop-on-failure or --at is given. The operation ID is returned for use
+with 'juju show-operation <ID>'; --wait is not supported for operations.
+
+--max-parallel limits how many units run the action at once, and
+--batch-size splits the units into groups, each of which finishes before
+the next is started. With --stop-on-failure no further units are started
+once the action fails on any unit. --at gives an RFC3339 time at which the
+operation should start.
+
+$ juju run-action mysql backup --max-parallel 2 --stop-on-failure
+operation: "3"
+
+$ juju run-action mysql/0 mysql/1 mysql/2 backup --batch-size 1 --at 2018-10-01T02:00:00Z
+operation: "4"
 `
 
 // SetFlags offers an option for YAML output.
@@ -126,44 +151,64 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "Run the action on at most this many units at once")
+	f.IntVar(&c.batchSize, "batch-size", 0, "Run the action on units in batches of this size")
+	f.BoolVar(&c.stopOnFailure, "stop-on-failure", false, "Stop running the action on further units once it fails on any unit")
+	f.StringVar(&c.at, "at", "", "Start running the action at this time (RFC3339)")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> [<unit> ...] <action name> [key.key.key...=value]",
+		Args:    "(<unit> [<unit> ...] | <application>) <action name> [key.key.key...=value]",
 		Purpose: "Queue an action for execution.",
 		Doc:     runDoc,
 	}
 }
 
-// Init gets the unit tag(s), action name and action arguments.
+// Init gets the unit tag(s) or application, action name and action
+// arguments.
 func (c *runCommand) Init(args []string) error {
-	var unitNames []string
-	for idx, arg := range args {
-		if names.IsValidUnit(arg) {
-			unitNames = args[:idx+1]
-		} else if nameRule.MatchString(arg) {
-			c.actionName = arg
-			break
-		} else {
-			return errors.Errorf("invalid unit or action name %q", arg)
-		}
+	if err := c.initOperation(); err != nil {
+		return errors.Trace(err)
 	}
-	if len(unitNames) == 0 {
-		return errors.New("no unit specified")
-	}
-	if c.actionName == "" {
-		return errors.New("no action specified")
+
+	var targets int
+	if len(args) > 1 && names.IsValidApplication(args[0]) && nameRule.MatchString(args[1]) {
+		c.applicationName = args[0]
+		c.actionName = args[1]
+		targets = 1
+	} else {
+		var unitNames []string
+		for idx, arg := range args {
+			if names.IsValidUnit(arg) {
+				unitNames = args[:idx+1]
+			} else if nameRule.MatchString(arg) {
+				c.actionName = arg
+				break
+			} else {
+				return errors.Errorf("invalid unit or action name %q", arg)
+			}
+		}
+		if len(unitNames) == 0 {
+			return errors.New("no unit specified")
+		}
+		if c.actionName == "" {
+			return errors.New("no action specified")
+		}
+		c.unitTags = make([]names.UnitTag, len(unitNames))
+		for idx, unitName := range unitNames {
+			c.unitTags[idx] = names.NewUnitTag(unitName)
+		}
+		targets = len(unitNames)
 	}
-	c.unitTags = make([]names.UnitTag, len(unitNames))
-	for idx, unitName := range unitNames {
-		c.unitTags[idx] = names.NewUnitTag(unitName)
+	if c.isOperation() && (c.wait.forever || c.wait.d > 0) {
+		return errors.New("--wait is not supported when running an action as an operation")
 	}
 
 	// Parse CLI key-value args if they exist.
 	c.args = make([][]string, 0)
-	for _, arg := range args[len(unitNames)+1:] {
+	for _, arg := range args[targets+1:] {
 		thisArg := strings.SplitN(arg, "=", 2)
 		if len(thisArg) != 2 {
 			return errors.Errorf("argument %q must be of the form key...=value", arg)
@@ -181,6 +226,67 @@ func (c *runCommand) Init(args []string) error {
 	return nil
 }
 
+// initOperation checks the flags that control how an operation runs.
+func (c *runCommand) initOperation() error {
+	if c.maxParallel < 0 {
+		return errors.New("--max-parallel must not be negative")
+	}
+	if c.batchSize < 0 {
+		return errors.New("--batch-size must not be negative")
+	}
+	if c.at != "" {
+		scheduled, err := time.Parse(time.RFC3339, c.at)
+		if err != nil {
+			return errors.Errorf("--at must be an RFC3339 time, got %q", c.at)
+		}
+		c.scheduled = scheduled.UTC()
+	}
+	return nil
+}
+
+// isOperation reports whether the action should be run as an operation
+// rather than enqueued directly on each unit.
+func (c *runCommand) isOperation() bool {
+	return c.applicationName != "" ||
+		c.maxParallel > 0 ||
+		c.batchSize > 0 ||
+		c.stopOnFailure ||
+		!c.scheduled.IsZero()
+}
+
+// runOperation enqueues the action as an operation and reports its ID.
+func (c *runCommand) runOperation(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	arg := params.EnqueueOperationArgs{
+		Name:          c.actionName,
+		Parameters:    actionParams,
+		MaxParallel:   c.maxParallel,
+		BatchSize:     c.batchSize,
+		StopOnFailure: c.stopOnFailure,
+	}
+	if c.applicationName != "" {
+		arg.Application = names.NewApplicationTag(c.applicationName).String()
+	}
+	for _, unitTag := range c.unitTags {
+		arg.Receivers = append(arg.Receivers, unitTag.String())
+	}
+	if !c.scheduled.IsZero() {
+		arg.Scheduled = &c.scheduled
+	}
+	results, err := api.EnqueueOperations(params.EnqueueOperationsArgs{
+		Operations: []params.EnqueueOperationArgs{arg},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	if err := results.Results[0].Error; err != nil {
+		return err
+	}
+	return c.out.Write(ctx, map[string]string{"operation": results.Results[0].Id})
+}
+
 func (c *runCommand) Run(ctx *cmd.Context) error {
 	api, err := c.NewActionAPIClient()
 	if err != nil {
@@ -241,6 +347,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.isOperation() {
+		return c.runOperation(ctx, api, actionParams)
+	}
+
 	actions := make([]params.Action, len(c.unitTags))
 	for i, unitTag := range c.unitTags {
 		actions[i].Receiver = unitTag.String()
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd/cmdtesting"
@@ -432,3 +433,125 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestInitOperation(c *gc.C) {
+	tests := []struct {
+		should            string
+		args              []string
+		expectUnits       []names.UnitTag
+		expectApplication string
+		expectAction      string
+		expectKVArgs      [][]string
+		expectError       string
+	}{{
+		should:            "work with an application",
+		args:              []string{validApplicationId, "valid-action-name", "foo=bar"},
+		expectApplication: validApplicationId,
+		expectAction:      "valid-action-name",
+		expectKVArgs:      [][]string{{"foo", "bar"}},
+	}, {
+		should:       "work with units and operation flags",
+		args:         []string{validUnitId, validUnitId2, "valid-action-name", "--batch-size", "1", "--stop-on-failure"},
+		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId), names.NewUnitTag(validUnitId2)},
+		expectAction: "valid-action-name",
+		expectKVArgs: [][]string{},
+	}, {
+		should:      "fail with negative max parallel",
+		args:        []string{validApplicationId, "valid-action-name", "--max-parallel", "-1"},
+		expectError: "--max-parallel must not be negative",
+	}, {
+		should:      "fail with negative batch size",
+		args:        []string{validApplicationId, "valid-action-name", "--batch-size", "-2"},
+		expectError: "--batch-size must not be negative",
+	}, {
+		should:      "fail with invalid scheduled time",
+		args:        []string{validApplicationId, "valid-action-name", "--at", "tomorrow"},
+		expectError: `--at must be an RFC3339 time, got "tomorrow"`,
+	}, {
+		should:      "fail with --wait",
+		args:        []string{validApplicationId, "valid-action-name", "--wait"},
+		expectError: "--wait is not supported when running an action as an operation",
+	}}
+
+	for i, t := range tests {
+		wrappedCommand, command := action.NewRunCommandForTest(s.store)
+		c.Logf("test %d: should %s:\n$ juju run-action %s\n", i,
+			t.should, strings.Join(t.args, " "))
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := cmdtesting.InitCommand(wrappedCommand, args)
+		if t.expectError == "" {
+			c.Assert(err, jc.ErrorIsNil)
+			c.Check(command.UnitTags(), gc.DeepEquals, t.expectUnits)
+			c.Check(command.ApplicationName(), gc.Equals, t.expectApplication)
+			c.Check(command.ActionName(), gc.Equals, t.expectAction)
+			c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.expectError)
+		}
+	}
+}
+
+func (s *RunSuite) TestRunOperation(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		operationResults: []params.OperationResult{{Id: "3"}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", validApplicationId, "some-action", "out=foo",
+		"--max-parallel", "2", "--batch-size", "4", "--stop-on-failure",
+		"--at", "2018-10-01T02:00:00+02:00",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "operation: \"3\"\n")
+
+	scheduled := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	c.Check(fakeClient.operationArgs, jc.DeepEquals, params.EnqueueOperationsArgs{
+		Operations: []params.EnqueueOperationArgs{{
+			Name:          "some-action",
+			Parameters:    map[string]interface{}{"out": "foo"},
+			Application:   names.NewApplicationTag(validApplicationId).String(),
+			MaxParallel:   2,
+			BatchSize:     4,
+			StopOnFailure: true,
+			Scheduled:     &scheduled,
+		}},
+	})
+	c.Check(fakeClient.EnqueuedActions().Actions, gc.HasLen, 0)
+}
+
+func (s *RunSuite) TestRunOperationUnits(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		operationResults: []params.OperationResult{{Id: "4"}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", validUnitId, validUnitId2, "some-action", "--max-parallel", "1",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fakeClient.operationArgs.Operations, jc.DeepEquals, []params.EnqueueOperationArgs{{
+		Name:        "some-action",
+		Parameters:  map[string]interface{}{},
+		Receivers:   []string{"unit-mysql-0", "unit-mysql-1"},
+		MaxParallel: 1,
+	}})
+}
+
+func (s *RunSuite) TestRunOperationError(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		operationResults: []params.OperationResult{{
+			Error: &params.Error{Message: `action "some-action" not defined on unit "mysql/0"`},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", validApplicationId, "some-action")
+	c.Assert(err, gc.ErrorMatches, `action "some-action" not defined on unit "mysql/0"`)
+}
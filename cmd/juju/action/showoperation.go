@@ -0,0 +1,193 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewShowOperationCommand() cmd.Command {
+	return modelcmd.Wrap(&showOperationCommand{})
+}
+
+// showOperationCommand shows the progress of an operation by ID.
+type showOperationCommand struct {
+	ActionCommandBase
+	out         cmd.Output
+	operationId string
+}
+
+const showOperationDoc = `
+Show the progress of the operation with the given ID. Operations are
+created by 'juju run-action' when an action is run on an application, or
+with any of the --max-parallel, --batch-size, --stop-on-failure or --at
+flags.
+
+The status of the operation is one of "pending", "running", "completed" or
+"failed", and the status of the action on each unit is shown once the
+action has been enqueued there.
+
+Examples:
+
+$ juju show-operation 3
+id: "3"
+action: backup
+application: mysql
+status: running
+max-parallel: 2
+stop-on-failure: true
+timing:
+  enqueued: 2018-10-01 02:00:00 +0000 UTC
+  started: 2018-10-01 02:00:01 +0000 UTC
+units:
+  mysql/0:
+    id: f47ac10b-58cc-4372-a567-0e02b2c3d479
+    status: completed
+  mysql/1:
+    id: 5a8b7c4e-3bcd-4e52-8a3f-6c4e2d5f1a09
+    status: running
+  mysql/2: {}
+
+See also:
+    run-action
+    show-action-output
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *showOperationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Info is part of the cmd.Command interface.
+func (c *showOperationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-operation",
+		Args:    "<operation ID>",
+		Purpose: "Show the progress of an operation by ID.",
+		Doc:     showOperationDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *showOperationCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no operation ID specified")
+	case 1:
+		c.operationId = args[0]
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+// Run is part of the cmd.Command interface.
+func (c *showOperationCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Operations(params.OperationQueryArgs{Ids: []string{c.operationId}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	formatted, err := formatOperationResult(result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// formatOperationResult converts an operation into a map suitable for
+// display, keying the tasks by unit name.
+func formatOperationResult(result params.OperationResult) (map[string]interface{}, error) {
+	response := map[string]interface{}{
+		"id":     result.Id,
+		"action": result.Name,
+		"status": result.Status,
+	}
+	if result.Application != "" {
+		appTag, err := names.ParseApplicationTag(result.Application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		response["application"] = appTag.Id()
+	}
+	if result.Message != "" {
+		response["message"] = result.Message
+	}
+	if len(result.Parameters) != 0 {
+		response["parameters"] = result.Parameters
+	}
+	if result.MaxParallel > 0 {
+		response["max-parallel"] = result.MaxParallel
+	}
+	if result.BatchSize > 0 {
+		response["batch-size"] = result.BatchSize
+	}
+	if result.StopOnFailure {
+		response["stop-on-failure"] = true
+	}
+
+	timing := make(map[string]string)
+	for k, v := range map[string]time.Time{
+		"enqueued":  result.Enqueued,
+		"started":   result.Started,
+		"completed": result.Completed,
+	} {
+		if !v.IsZero() {
+			timing[k] = v.String()
+		}
+	}
+	if result.Scheduled != nil {
+		timing["scheduled"] = result.Scheduled.String()
+	}
+	if len(timing) > 0 {
+		response["timing"] = timing
+	}
+
+	units := make(map[string]interface{}, len(result.Tasks))
+	for _, task := range result.Tasks {
+		unitTag, err := names.ParseUnitTag(task.Receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		formatted := make(map[string]string)
+		if task.Action != "" {
+			actionTag, err := names.ParseActionTag(task.Action)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			formatted["id"] = actionTag.Id()
+		}
+		if task.Status != "" {
+			formatted["status"] = task.Status
+		}
+		if task.Message != "" {
+			formatted["message"] = task.Message
+		}
+		units[unitTag.Id()] = formatted
+	}
+	response["units"] = units
+	return response, nil
+}
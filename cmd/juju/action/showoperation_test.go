@@ -0,0 +1,102 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ShowOperationSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ShowOperationSuite{})
+
+func (s *ShowOperationSuite) TestInit(c *gc.C) {
+	cmd, _ := action.NewShowOperationCommandForTest(s.store)
+	err := cmdtesting.InitCommand(cmd, []string{"-m", "admin"})
+	c.Check(err, gc.ErrorMatches, "no operation ID specified")
+
+	cmd, _ = action.NewShowOperationCommandForTest(s.store)
+	err = cmdtesting.InitCommand(cmd, []string{"-m", "admin", "1", "2"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["2"\]`)
+}
+
+func (s *ShowOperationSuite) TestRun(c *gc.C) {
+	enqueued := time.Date(2018, 10, 1, 2, 0, 0, 0, time.UTC)
+	fakeClient := &fakeAPIClient{
+		operationResults: []params.OperationResult{{
+			Id:            "3",
+			Name:          "backup",
+			Application:   "application-mysql",
+			MaxParallel:   2,
+			StopOnFailure: true,
+			Enqueued:      enqueued,
+			Status:        "failed",
+			Message:       `stopped after action failed on unit "mysql/0"`,
+			Tasks: []params.OperationTask{{
+				Receiver: "unit-mysql-0",
+				Action:   validActionTagString,
+				Status:   "failed",
+				Message:  "disk full",
+			}, {
+				Receiver: "unit-mysql-1",
+			}},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	cmd, _ := action.NewShowOperationCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+action: backup
+application: mysql
+id: "3"
+max-parallel: 2
+message: stopped after action failed on unit "mysql/0"
+status: failed
+stop-on-failure: true
+timing:
+  enqueued: 2018-10-01 02:00:00 +0000 UTC
+units:
+  mysql/0:
+    id: f47ac10b-58cc-4372-a567-0e02b2c3d479
+    message: disk full
+    status: failed
+  mysql/1: {}
+`[1:])
+}
+
+func (s *ShowOperationSuite) TestRunError(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		operationResults: []params.OperationResult{{
+			Error: &params.Error{Message: `operation "42" not found`, Code: params.CodeNotFound},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	cmd, _ := action.NewShowOperationCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "42")
+	c.Assert(err, gc.ErrorMatches, `operation "42" not found`)
+}
+
+func (s *ShowOperationSuite) TestRunAPIError(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{apiErr: errors.New("boom")})
+	defer restore()
+
+	cmd, _ := action.NewShowOperationCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "3")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
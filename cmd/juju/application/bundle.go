@@ -76,6 +76,39 @@ func deployBundle(
 	if err := processBundleOverlay(data, bundleOverlayFile...); err != nil {
 		return nil, err
 	}
+	includeDir := bundleDir
+	if includeDir == "" {
+		includeDir = ctx.Dir
+	}
+	// Process includes in the bundle data.
+	if err := processBundleIncludes(includeDir, data); err != nil {
+		return nil, errors.Annotate(err, "unable to process includes")
+	}
+	if err := verifyBundle(data, bundleDir); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// TODO: move bundle parsing and checking into the handler.
+	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleStorage, bundleDevices)
+	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.resolveCharmsAndEndpoints(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.getChanges(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.handleChanges(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return h.macaroons, nil
+
+}
+
+// verifyBundle checks that the bundle data is valid. If bundleDir is not
+// empty, local charms referenced by the bundle are resolved relative to it.
+func verifyBundle(data *charm.BundleData, bundleDir string) error {
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
 		return err
@@ -90,16 +123,8 @@ func deployBundle(
 	}
 	var verifyError error
 	if bundleDir == "" {
-		// Process includes in the bundle data.
-		if err := processBundleIncludes(ctx.Dir, data); err != nil {
-			return nil, errors.Annotate(err, "unable to process includes")
-		}
 		verifyError = data.Verify(verifyConstraints, verifyStorage, verifyDevices)
 	} else {
-		// Process includes in the bundle data.
-		if err := processBundleIncludes(bundleDir, data); err != nil {
-			return nil, errors.Annotate(err, "unable to process includes")
-		}
 		verifyError = data.VerifyLocal(bundleDir, verifyConstraints, verifyStorage, verifyDevices)
 	}
 	if verifyError != nil {
@@ -108,27 +133,11 @@ func deployBundle(
 			for i, err := range verr.Errors {
 				errs[i] = err.Error()
 			}
-			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+			return errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
 		}
-		return nil, errors.Trace(verifyError)
-	}
-
-	// TODO: move bundle parsing and checking into the handler.
-	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleStorage, bundleDevices)
-	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := h.resolveCharmsAndEndpoints(); err != nil {
-		return nil, errors.Trace(err)
+		return errors.Trace(verifyError)
 	}
-	if err := h.getChanges(); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := h.handleChanges(); err != nil {
-		return nil, errors.Trace(err)
-	}
-	return h.macaroons, nil
-
+	return nil
 }
 
 // bundleHandler provides helpers and the state required to deploy a bundle.
@@ -1348,9 +1357,18 @@ func removeRelations(data [][]string, appName string) [][]string {
 	return result
 }
 
+// modelRepresentationAPI defines the API methods needed to build a
+// representation of the model for comparison with a bundle.
+type modelRepresentationAPI interface {
+	GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error)
+	GetConfig(appNames ...string) ([]map[string]interface{}, error)
+	GetConstraints(appNames ...string) ([]constraints.Value, error)
+	Sequences() (map[string]int, error)
+}
+
 func buildModelRepresentation(
 	status *params.FullStatus,
-	apiRoot DeployAPI,
+	apiRoot modelRepresentationAPI,
 	useExistingMachines bool,
 	bundleMachines map[string]string,
 ) (*bundlechanges.Model, error) {
@@ -0,0 +1,434 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const (
+	// missingFromBundle and missingFromModel are used to report an
+	// entity that is only present on one side of a bundle diff.
+	missingFromBundle = "bundle"
+	missingFromModel  = "model"
+)
+
+// bundleDiff holds the differences between a bundle and a model.
+type bundleDiff struct {
+	Applications map[string]*applicationDiff `yaml:"applications,omitempty"`
+	Machines     map[string]*machineDiff     `yaml:"machines,omitempty"`
+	Relations    *relationsDiff              `yaml:"relations,omitempty"`
+	Offers       map[string]*offerDiff       `yaml:"offers,omitempty"`
+}
+
+// empty returns whether the bundle and model match.
+func (d *bundleDiff) empty() bool {
+	return len(d.Applications) == 0 &&
+		len(d.Machines) == 0 &&
+		d.Relations == nil &&
+		len(d.Offers) == 0
+}
+
+// diffSide holds the bundle and model values of an attribute that
+// differs between the two.
+type diffSide struct {
+	Bundle interface{} `yaml:"bundle"`
+	Model  interface{} `yaml:"model"`
+}
+
+// applicationDiff holds the differences in a single application.
+type applicationDiff struct {
+	Missing     string              `yaml:"missing,omitempty"`
+	Charm       *diffSide           `yaml:"charm,omitempty"`
+	Series      *diffSide           `yaml:"series,omitempty"`
+	NumUnits    *diffSide           `yaml:"num_units,omitempty"`
+	Placement   *diffSide           `yaml:"placement,omitempty"`
+	Expose      *diffSide           `yaml:"expose,omitempty"`
+	Options     map[string]diffSide `yaml:"options,omitempty"`
+	Constraints *diffSide           `yaml:"constraints,omitempty"`
+	Bindings    map[string]diffSide `yaml:"bindings,omitempty"`
+	Annotations map[string]diffSide `yaml:"annotations,omitempty"`
+}
+
+func (d *applicationDiff) empty() bool {
+	return d.Missing == "" &&
+		d.Charm == nil &&
+		d.Series == nil &&
+		d.NumUnits == nil &&
+		d.Placement == nil &&
+		d.Expose == nil &&
+		len(d.Options) == 0 &&
+		d.Constraints == nil &&
+		len(d.Bindings) == 0 &&
+		len(d.Annotations) == 0
+}
+
+// machineDiff holds the differences in a single machine.
+type machineDiff struct {
+	Missing     string              `yaml:"missing,omitempty"`
+	Series      *diffSide           `yaml:"series,omitempty"`
+	Annotations map[string]diffSide `yaml:"annotations,omitempty"`
+}
+
+func (d *machineDiff) empty() bool {
+	return d.Missing == "" && d.Series == nil && len(d.Annotations) == 0
+}
+
+// relationsDiff holds the relations that are only present on one side
+// of the diff.
+type relationsDiff struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty"`
+}
+
+// offerDiff holds the differences in a single offer. Bundles cannot
+// describe offers, so any offer in the model is reported as missing
+// from the bundle.
+type offerDiff struct {
+	Missing     string `yaml:"missing,omitempty"`
+	Application string `yaml:"application,omitempty"`
+}
+
+// diffBundle compares the bundle data with the model, as described by
+// its status and a bundlechanges model built from it, and returns the
+// differences. Bundle machines are mapped to model machines using the
+// model's MachineMap.
+func diffBundle(data *charm.BundleData, status *params.FullStatus, model *bundlechanges.Model) *bundleDiff {
+	d := &bundleDiff{
+		Applications: make(map[string]*applicationDiff),
+		Machines:     make(map[string]*machineDiff),
+		Offers:       make(map[string]*offerDiff),
+	}
+
+	for name, spec := range data.Applications {
+		app, found := model.Applications[name]
+		if !found {
+			d.Applications[name] = &applicationDiff{Missing: missingFromModel}
+			continue
+		}
+		appDiff := diffApplication(data, spec, app, status.Applications[name], model)
+		if !appDiff.empty() {
+			d.Applications[name] = appDiff
+		}
+	}
+	for name := range model.Applications {
+		if _, found := data.Applications[name]; !found {
+			d.Applications[name] = &applicationDiff{Missing: missingFromBundle}
+		}
+	}
+
+	mapped := make(map[string]bool)
+	for id, spec := range data.Machines {
+		if spec == nil {
+			spec = &charm.MachineSpec{}
+		}
+		modelID, ok := model.MachineMap[id]
+		machine, found := model.Machines[modelID]
+		if !ok || !found {
+			d.Machines[id] = &machineDiff{Missing: missingFromModel}
+			continue
+		}
+		mapped[modelID] = true
+		md := &machineDiff{
+			Annotations: diffStringMaps(spec.Annotations, machine.Annotations),
+		}
+		series := spec.Series
+		if series == "" {
+			series = data.Series
+		}
+		if modelSeries := status.Machines[modelID].Series; series != "" && series != modelSeries {
+			md.Series = &diffSide{Bundle: series, Model: modelSeries}
+		}
+		if !md.empty() {
+			d.Machines[id] = md
+		}
+	}
+	for id := range model.Machines {
+		if !mapped[id] {
+			d.Machines[id] = &machineDiff{Missing: missingFromBundle}
+		}
+	}
+
+	d.Relations = diffRelations(data.Relations, model.Relations)
+
+	for name, offer := range status.Offers {
+		d.Offers[name] = &offerDiff{
+			Missing:     missingFromBundle,
+			Application: offer.ApplicationName,
+		}
+	}
+	return d
+}
+
+func diffApplication(
+	data *charm.BundleData,
+	spec *charm.ApplicationSpec,
+	app *bundlechanges.Application,
+	appStatus params.ApplicationStatus,
+	model *bundlechanges.Model,
+) *applicationDiff {
+	d := &applicationDiff{
+		Options:     diffOptions(spec.Options, app.Options),
+		Bindings:    diffBindings(spec.EndpointBindings, appStatus.EndpointBindings),
+		Annotations: diffStringMaps(spec.Annotations, app.Annotations),
+	}
+	if !isLocalCharmPath(spec.Charm) && !charmURLsMatch(spec.Charm, app.Charm) {
+		d.Charm = &diffSide{Bundle: spec.Charm, Model: app.Charm}
+	}
+	series := spec.Series
+	if series == "" {
+		series = data.Series
+	}
+	if series != "" && series != appStatus.Series {
+		d.Series = &diffSide{Bundle: series, Model: appStatus.Series}
+	}
+	if spec.NumUnits != len(app.Units) {
+		d.NumUnits = &diffSide{Bundle: spec.NumUnits, Model: len(app.Units)}
+	}
+	if bundlePlacement, ok := resolveBundlePlacement(spec, model.MachineMap); ok {
+		modelPlacement := modelUnitPlacement(app.Units)
+		if !reflect.DeepEqual(bundlePlacement, modelPlacement) {
+			d.Placement = &diffSide{Bundle: bundlePlacement, Model: modelPlacement}
+		}
+	}
+	if spec.Expose != app.Exposed {
+		d.Expose = &diffSide{Bundle: spec.Expose, Model: app.Exposed}
+	}
+	if !model.ConstraintsEqual(spec.Constraints, app.Constraints) {
+		d.Constraints = &diffSide{Bundle: spec.Constraints, Model: app.Constraints}
+	}
+	return d
+}
+
+// isLocalCharmPath returns whether the bundle charm refers to a charm
+// on the local filesystem. The model has no record of where a local
+// charm came from, so such charms cannot be compared.
+func isLocalCharmPath(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "/")
+}
+
+// charmURLsMatch returns whether the charm specified in a bundle refers
+// to the charm deployed in the model. A bundle charm without a revision
+// matches any revision of the model charm.
+func charmURLsMatch(bundleCharm, modelCharm string) bool {
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		return bundleCharm == modelCharm
+	}
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return false
+	}
+	if bundleURL.Schema != modelURL.Schema ||
+		bundleURL.User != modelURL.User ||
+		bundleURL.Name != modelURL.Name {
+		return false
+	}
+	if bundleURL.Series != "" && bundleURL.Series != modelURL.Series {
+		return false
+	}
+	return bundleURL.Revision < 0 || bundleURL.Revision == modelURL.Revision
+}
+
+// resolveBundlePlacement returns the model machines, in the form
+// "<machine>" or "<container-type>:<machine>", on which the bundle
+// places the application's units. It returns false if the placement
+// cannot be compared with the model, because the bundle does not place
+// every unit on a specific bundle machine.
+func resolveBundlePlacement(spec *charm.ApplicationSpec, machineMap map[string]string) ([]string, bool) {
+	if len(spec.To) == 0 || len(spec.To) != spec.NumUnits {
+		return nil, false
+	}
+	placement := make([]string, len(spec.To))
+	for i, to := range spec.To {
+		containerType := ""
+		if parts := strings.SplitN(to, ":", 2); len(parts) == 2 {
+			containerType, to = parts[0], parts[1]
+		}
+		machine, ok := machineMap[to]
+		if !ok {
+			return nil, false
+		}
+		if containerType != "" {
+			machine = containerType + ":" + machine
+		}
+		placement[i] = machine
+	}
+	sort.Strings(placement)
+	return placement, true
+}
+
+// modelUnitPlacement returns the machines hosting the given units, in
+// the same form as resolveBundlePlacement.
+func modelUnitPlacement(units []bundlechanges.Unit) []string {
+	placement := make([]string, 0, len(units))
+	for _, unit := range units {
+		parts := strings.Split(unit.Machine, "/")
+		if len(parts) >= 3 {
+			placement = append(placement, parts[len(parts)-2]+":"+strings.Join(parts[:len(parts)-2], "/"))
+		} else {
+			placement = append(placement, unit.Machine)
+		}
+	}
+	sort.Strings(placement)
+	return placement
+}
+
+// diffOptions returns the application options that differ between the
+// bundle and the model.
+func diffOptions(bundleOptions, modelOptions map[string]interface{}) map[string]diffSide {
+	result := make(map[string]diffSide)
+	for key, bundleValue := range bundleOptions {
+		modelValue := modelOptions[key]
+		if !reflect.DeepEqual(normaliseOptionValue(bundleValue), normaliseOptionValue(modelValue)) {
+			result[key] = diffSide{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for key, modelValue := range modelOptions {
+		if _, found := bundleOptions[key]; !found {
+			result[key] = diffSide{Bundle: nil, Model: modelValue}
+		}
+	}
+	return result
+}
+
+// normaliseOptionValue converts numeric option values to float64, so
+// that values read from YAML compare equal to values read from the API.
+func normaliseOptionValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+// diffBindings returns the endpoint bindings that differ between the
+// bundle and the model. Endpoints not mentioned in the bundle are
+// expected to be bound to the bundle's default space, if it has one.
+func diffBindings(bundleBindings, modelBindings map[string]string) map[string]diffSide {
+	result := make(map[string]diffSide)
+	expected := func(endpoint string) string {
+		if space, ok := bundleBindings[endpoint]; ok {
+			return space
+		}
+		return bundleBindings[""]
+	}
+	for endpoint, space := range modelBindings {
+		if endpoint == "" {
+			continue
+		}
+		if bundleSpace := expected(endpoint); bundleSpace != space {
+			result[endpoint] = diffSide{Bundle: bundleSpace, Model: space}
+		}
+	}
+	for endpoint, space := range bundleBindings {
+		if _, found := modelBindings[endpoint]; !found && endpoint != "" {
+			result[endpoint] = diffSide{Bundle: space, Model: nil}
+		}
+	}
+	return result
+}
+
+// diffStringMaps returns the keys whose values differ between the
+// bundle and model maps.
+func diffStringMaps(bundleValues, modelValues map[string]string) map[string]diffSide {
+	result := make(map[string]diffSide)
+	for key, bundleValue := range bundleValues {
+		if modelValue, found := modelValues[key]; !found {
+			result[key] = diffSide{Bundle: bundleValue, Model: nil}
+		} else if modelValue != bundleValue {
+			result[key] = diffSide{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for key, modelValue := range modelValues {
+		if _, found := bundleValues[key]; !found {
+			result[key] = diffSide{Bundle: nil, Model: modelValue}
+		}
+	}
+	return result
+}
+
+// diffRelations returns the relations that are only present in one of
+// the bundle and the model, or nil if they have the same relations. A
+// bundle relation that omits an endpoint name matches a model relation
+// using any endpoint of that application.
+func diffRelations(bundleRelations [][]string, modelRelations []bundlechanges.Relation) *relationsDiff {
+	matched := make([]bool, len(modelRelations))
+	var result relationsDiff
+	for _, relation := range bundleRelations {
+		if len(relation) != 2 {
+			continue
+		}
+		found := false
+		for i, modelRelation := range modelRelations {
+			if matched[i] {
+				continue
+			}
+			if relationMatches(relation, modelRelation) {
+				matched[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			result.BundleAdditions = append(result.BundleAdditions, sortedPair(relation[0], relation[1]))
+		}
+	}
+	for i, relation := range modelRelations {
+		if !matched[i] {
+			result.ModelAdditions = append(result.ModelAdditions, sortedPair(
+				relation.App1+":"+relation.Endpoint1,
+				relation.App2+":"+relation.Endpoint2,
+			))
+		}
+	}
+	if len(result.BundleAdditions) == 0 && len(result.ModelAdditions) == 0 {
+		return nil
+	}
+	sortRelations(result.BundleAdditions)
+	sortRelations(result.ModelAdditions)
+	return &result
+}
+
+func relationMatches(bundleRelation []string, modelRelation bundlechanges.Relation) bool {
+	ep1, ep2 := bundleRelation[0], bundleRelation[1]
+	return endpointMatches(ep1, modelRelation.App1, modelRelation.Endpoint1) &&
+		endpointMatches(ep2, modelRelation.App2, modelRelation.Endpoint2) ||
+		endpointMatches(ep1, modelRelation.App2, modelRelation.Endpoint2) &&
+			endpointMatches(ep2, modelRelation.App1, modelRelation.Endpoint1)
+}
+
+func endpointMatches(bundleEndpoint, app, endpoint string) bool {
+	parts := strings.SplitN(bundleEndpoint, ":", 2)
+	if parts[0] != app {
+		return false
+	}
+	return len(parts) == 1 || parts[1] == endpoint
+}
+
+func sortedPair(a, b string) []string {
+	if b < a {
+		a, b = b, a
+	}
+	return []string{a, b}
+}
+
+func sortRelations(relations [][]string) {
+	sort.Slice(relations, func(i, j int) bool {
+		if relations[i][0] != relations[j][0] {
+			return relations[i][0] < relations[j][0]
+		}
+		return relations[i][1] < relations[j][1]
+	})
+}
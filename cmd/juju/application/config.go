@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
@@ -34,6 +37,11 @@ Output includes the name of the charm used to deploy the application and a
 listing of the application-specific configuration settings.
 See ` + "`juju status`" + ` for application names.
 
+Each change to an application's charm configuration is recorded as a new
+revision. The most recent revisions, showing who changed what and when, are
+displayed with --history, and the configuration can be restored to that of
+an earlier revision with --revert.
+
 When only one configuration value is desired, the command will ignore --format
 option and will output the value unformatted. This is provided to support 
 scripts where the output of "juju config <application name> <setting name>" 
@@ -47,6 +55,8 @@ Examples:
     juju config apache2 --file path/to/config.yaml
     juju config mysql dataset-size=80% backup_dir=/vol1/mysql/backups
     juju config apache2 --model mymodel --file /home/ubuntu/mysql.yaml
+    juju config mysql --history
+    juju config mysql --revert 3
 
 See also:
     deploy
@@ -80,6 +90,8 @@ type configCommand struct {
 	action          func(applicationAPI, *cmd.Context) error // get, set, or reset action set in  Init
 	applicationName string
 	configFile      cmd.FileVar
+	history         bool
+	revert          int
 	keys            []string
 	reset           []string // Holds the keys to be reset until parsed.
 	resetKeys       []string // Holds the keys to be reset once parsed.
@@ -100,6 +112,11 @@ type applicationAPI interface {
 
 	SetApplicationConfig(application string, config map[string]string) error
 	UnsetApplicationConfig(application string, options []string) error
+
+	// These methods are on API V9.
+
+	CharmConfigHistory(application string) ([]params.CharmConfigRevision, error)
+	RevertCharmConfig(application string, revision int) error
 }
 
 // Info is part of the cmd.Command interface.
@@ -118,6 +135,8 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.Var(&c.configFile, "file", "path to yaml-formatted application config")
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.history, "history", false, "Show the recent revisions of the charm config")
+	f.IntVar(&c.revert, "revert", 0, "Restore the charm config of the given revision")
 }
 
 // getAPI either uses the fake API set at test time or that is nil, gets a real
@@ -151,6 +170,10 @@ func (c *configCommand) Init(args []string) error {
 	c.applicationName = args[0]
 	args = args[1:]
 
+	if c.history || c.revert != 0 {
+		return c.handleHistory(args)
+	}
+
 	switch len(args) {
 	case 0:
 		return c.handleZeroArgs()
@@ -161,6 +184,26 @@ func (c *configCommand) Init(args []string) error {
 	}
 }
 
+// handleHistory handles the case where --history or --revert is
+// specified.
+func (c *configCommand) handleHistory(args []string) error {
+	if c.history && c.revert != 0 {
+		return errors.New("cannot specify --history and --revert simultaneously")
+	}
+	if len(args) > 0 || len(c.resetKeys) > 0 || c.configFile.Path != "" {
+		return errors.New("cannot combine --history or --revert with other arguments")
+	}
+	if c.history {
+		c.action = c.getHistory
+		return nil
+	}
+	if c.revert < 0 {
+		return errors.Errorf("invalid revision %d", c.revert)
+	}
+	c.action = c.revertConfig
+	return nil
+}
+
 // handleZeroArgs handles the case where there are no positional args.
 func (c *configCommand) handleZeroArgs() error {
 	// If there's a path we're setting args from a file
@@ -379,6 +422,63 @@ func (c *configCommand) getConfig(client applicationAPI, ctx *cmd.Context) error
 	return c.out.Write(ctx, resultsMap)
 }
 
+// configRevision holds a revision of the charm config for output, along
+// with the changes made by it.
+type configRevision struct {
+	Revision int                    `yaml:"revision" json:"revision"`
+	Author   string                 `yaml:"author,omitempty" json:"author,omitempty"`
+	Created  string                 `yaml:"created" json:"created"`
+	Changed  map[string]interface{} `yaml:"changed,omitempty" json:"changed,omitempty"`
+	Unset    []string               `yaml:"unset,omitempty" json:"unset,omitempty"`
+}
+
+// getHistory is the run action when we are showing the config history.
+func (c *configCommand) getHistory(client applicationAPI, ctx *cmd.Context) error {
+	if client.BestAPIVersion() < 9 {
+		return errors.New("config history is not supported by this controller")
+	}
+	revisions, err := client.CharmConfigHistory(c.applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var previous map[string]interface{}
+	result := make([]configRevision, len(revisions))
+	for i, rev := range revisions {
+		result[i] = configRevision{
+			Revision: rev.Revision,
+			Author:   rev.Author,
+			Created:  rev.Created.UTC().Format(time.RFC3339),
+		}
+		for key, value := range rev.Config {
+			if old, ok := previous[key]; ok && reflect.DeepEqual(old, value) {
+				continue
+			}
+			if result[i].Changed == nil {
+				result[i].Changed = make(map[string]interface{})
+			}
+			result[i].Changed[key] = value
+		}
+		for key := range previous {
+			if _, ok := rev.Config[key]; !ok {
+				result[i].Unset = append(result[i].Unset, key)
+			}
+		}
+		sort.Strings(result[i].Unset)
+		previous = rev.Config
+	}
+	return c.out.Write(ctx, result)
+}
+
+// revertConfig is the run action when we are reverting to an earlier
+// revision.
+func (c *configCommand) revertConfig(client applicationAPI, ctx *cmd.Context) error {
+	if client.BestAPIVersion() < 9 {
+		return errors.New("reverting config is not supported by this controller")
+	}
+	err := client.RevertCharmConfig(c.applicationName, c.revert)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
 // validateValues reads the values provided as args and validates that they are
 // valid UTF-8.
 func (c *configCommand) validateValues(ctx *cmd.Context) (map[string]string, error) {
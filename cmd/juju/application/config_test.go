@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
@@ -18,6 +19,7 @@ import (
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -248,6 +250,22 @@ var setCommandInitErrorTests = []struct {
 	about:       "init too many args fails",
 	args:        []string{"application", "key", "another"},
 	expectError: "can only retrieve a single value, or all values",
+}, {
+	about:       "--history and --revert",
+	args:        []string{"application", "--history", "--revert", "2"},
+	expectError: "cannot specify --history and --revert simultaneously",
+}, {
+	about:       "--history with a key",
+	args:        []string{"application", "--history", "key"},
+	expectError: "cannot combine --history or --revert with other arguments",
+}, {
+	about:       "--revert with a value",
+	args:        []string{"application", "--revert", "2", "key=value"},
+	expectError: "cannot combine --history or --revert with other arguments",
+}, {
+	about:       "--revert with an invalid revision",
+	args:        []string{"application", "--revert", "-1"},
+	expectError: "invalid revision -1",
 }}
 
 func (s *configCommandSuite) TestSetCommandInitError(c *gc.C) {
@@ -499,3 +517,63 @@ func setupConfigFile(c *gc.C, dir string) string {
 	c.Assert(err, jc.ErrorIsNil)
 	return path
 }
+
+func (s *configCommandSuite) TestHistory(c *gc.C) {
+	s.fake.version = 9
+	created := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.fake.history = []params.CharmConfigRevision{{
+		Revision: 1,
+		Config:   map[string]interface{}{"title": "Nearly There", "username": "admin001"},
+		Created:  created,
+	}, {
+		Revision: 2,
+		Config:   map[string]interface{}{"title": "Almost There", "username": "admin001", "outlook": "true"},
+		Author:   "fred",
+		Created:  created.Add(time.Hour),
+	}, {
+		Revision: 3,
+		Config:   map[string]interface{}{"title": "Almost There"},
+		Author:   "mary",
+		Created:  created.Add(2 * time.Hour),
+	}}
+	ctx, err := cmdtesting.RunCommand(c, application.NewConfigCommandForTest(s.fake, s.store), "dummy-application", "--history")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- revision: 1
+  created: "2018-10-01T12:00:00Z"
+  changed:
+    title: Nearly There
+    username: admin001
+- revision: 2
+  author: fred
+  created: "2018-10-01T13:00:00Z"
+  changed:
+    outlook: "true"
+    title: Almost There
+- revision: 3
+  author: mary
+  created: "2018-10-01T14:00:00Z"
+  unset:
+  - outlook
+  - username
+`[1:])
+}
+
+func (s *configCommandSuite) TestHistoryNotSupported(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, application.NewConfigCommandForTest(s.fake, s.store), "dummy-application", "--history")
+	c.Assert(err, gc.ErrorMatches, "config history is not supported by this controller")
+}
+
+func (s *configCommandSuite) TestRevert(c *gc.C) {
+	s.fake.version = 9
+	_, err := cmdtesting.RunCommand(c, application.NewConfigCommandForTest(s.fake, s.store), "dummy-application", "--revert", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.reverted, jc.DeepEquals, []int{2})
+}
+
+func (s *configCommandSuite) TestBlockRevert(c *gc.C) {
+	s.fake.version = 9
+	s.fake.err = common.OperationBlockedError("TestBlockRevert")
+	_, err := cmdtesting.RunCommand(c, application.NewConfigCommandForTest(s.fake, s.store), "dummy-application", "--revert", "2")
+	c.Assert(err, gc.ErrorMatches, `(.|\n)*All operations that change model have been disabled(.|\n)*`)
+}
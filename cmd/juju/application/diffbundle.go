@@ -0,0 +1,224 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/annotations"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/constraints"
+)
+
+const diffBundleDoc = `
+Bundle can be a local bundle file or a local bundle directory.
+
+The bundle is compared with the current model and any differences in
+applications, units and their placement, application config, constraints,
+endpoint bindings, annotations, machines, relations and offers are reported.
+Values are shown from both the bundle and the model, and an entity that
+exists on only one side is reported as missing from the other side.
+
+Bundles cannot describe offers, so any offers made from the model are
+reported as missing from the bundle.
+
+The --overlay option may be specified one or more times to apply
+overlay bundles before comparing, as for deploy.
+
+The --map-machines option works as it does for deploy, except that
+"existing" is always assumed: bundle machines are compared with the model
+machines with the same IDs unless a different mapping is given.
+
+Examples:
+    juju diff-bundle ./bundle.yaml
+    juju diff-bundle ./bundle --overlay ./overlay.yaml
+    juju diff-bundle ./bundle.yaml --map-machines 3=4
+
+See also:
+    deploy
+    export-bundle
+`
+
+// DiffBundleAPI defines the API methods used by the diff-bundle command.
+type DiffBundleAPI interface {
+	Close() error
+	Status(patterns []string) (*params.FullStatus, error)
+	GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error)
+	GetConfig(appNames ...string) ([]map[string]interface{}, error)
+	GetConstraints(appNames ...string) ([]constraints.Value, error)
+	Sequences() (map[string]int, error)
+}
+
+// NewDiffBundleCommand returns a command to compare a bundle with the
+// current model.
+func NewDiffBundleCommand() cmd.Command {
+	c := &diffBundleCommand{}
+	c.newAPIRootFn = c.newAPIRoot
+	return modelcmd.Wrap(c)
+}
+
+// diffBundleCommand compares a bundle with the current model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+
+	bundle         string
+	bundleOverlays []string
+	machineMap     string
+	bundleMachines map[string]string
+	out            cmd.Output
+
+	newAPIRootFn func() (DiffBundleAPI, error)
+}
+
+// Info is part of cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or directory>",
+		Purpose: "Compares a bundle with a model and reports any differences.",
+		Doc:     diffBundleDoc,
+	}
+}
+
+// SetFlags is part of cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.bundleOverlays), "overlay", "Bundles to overlay on the primary bundle, applied in order")
+	f.StringVar(&c.machineMap, "map-machines", "", "Indicates how existing machines correspond to bundle machines")
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init is part of cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle specified")
+	}
+	c.bundle = args[0]
+	// UseExisting is assumed for diffing.
+	_, mapping, err := parseMachineMap(c.machineMap)
+	if err != nil {
+		return errors.Annotate(err, "error in --map-machines")
+	}
+	c.bundleMachines = mapping
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	data, err := c.readBundle(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	apiRoot, err := c.newAPIRootFn()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiRoot.Close()
+
+	status, err := apiRoot.Status(nil)
+	if err != nil {
+		return errors.Annotate(err, "getting model status")
+	}
+	model, err := buildModelRepresentation(status, apiRoot, true, c.bundleMachines)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	diff := diffBundle(data, status, model)
+	if diff.empty() {
+		ctx.Infof("No differences between the bundle and the model.")
+		return nil
+	}
+	return c.out.Write(ctx, diff)
+}
+
+// readBundle reads the bundle from a local file or directory, applying
+// any overlays and includes, and verifies it.
+func (c *diffBundleCommand) readBundle(ctx *cmd.Context) (*charm.BundleData, error) {
+	path := ctx.AbsPath(c.bundle)
+	bundleDir := filepath.Dir(path)
+	data, err := charmrepo.ReadBundleFile(path)
+	if err != nil {
+		bundle, _, pathErr := charmrepo.NewBundleAtPath(path)
+		if pathErr != nil {
+			return nil, errors.Annotatef(pathErr, "cannot read bundle %q", c.bundle)
+		}
+		data = bundle.Data()
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			bundleDir = path
+		}
+	}
+	if err := processBundleOverlay(data, c.bundleOverlays...); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := processBundleIncludes(bundleDir, data); err != nil {
+		return nil, errors.Annotate(err, "unable to process includes")
+	}
+	if err := verifyBundle(data, bundleDir); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+func (c *diffBundleCommand) newAPIRoot() (DiffBundleAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &diffBundleAPIAdapter{
+		Connection:  root,
+		client:      root.Client(),
+		application: application.NewClient(root),
+		annotations: annotations.NewClient(root),
+		modelConfig: modelconfig.NewClient(root),
+	}, nil
+}
+
+// diffBundleAPIAdapter implements DiffBundleAPI using the API clients of
+// an API connection.
+type diffBundleAPIAdapter struct {
+	api.Connection
+	client      *api.Client
+	application *application.Client
+	annotations *annotations.Client
+	modelConfig *modelconfig.Client
+}
+
+// Status is part of DiffBundleAPI.
+func (a *diffBundleAPIAdapter) Status(patterns []string) (*params.FullStatus, error) {
+	return a.client.Status(patterns)
+}
+
+// GetAnnotations is part of DiffBundleAPI.
+func (a *diffBundleAPIAdapter) GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error) {
+	return a.annotations.Get(tags)
+}
+
+// GetConfig is part of DiffBundleAPI.
+func (a *diffBundleAPIAdapter) GetConfig(appNames ...string) ([]map[string]interface{}, error) {
+	return a.application.GetConfig(appNames...)
+}
+
+// GetConstraints is part of DiffBundleAPI.
+func (a *diffBundleAPIAdapter) GetConstraints(appNames ...string) ([]constraints.Value, error) {
+	return a.application.GetConstraints(appNames...)
+}
+
+// Sequences is part of DiffBundleAPI.
+func (a *diffBundleAPIAdapter) Sequences() (map[string]int, error) {
+	return a.modelConfig.Sequences()
+}
@@ -0,0 +1,327 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type diffBundleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api *fakeDiffBundleAPI
+	dir string
+}
+
+var _ = gc.Suite(&diffBundleSuite{})
+
+func (s *diffBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.api = &fakeDiffBundleAPI{
+		status: &params.FullStatus{
+			Machines: map[string]params.MachineStatus{
+				"0": {Series: "xenial"},
+				"1": {Series: "xenial"},
+			},
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Charm:  "cs:xenial/mysql-57",
+					Series: "xenial",
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {Machine: "0"},
+					},
+					EndpointBindings: map[string]string{
+						"db":     "",
+						"server": "",
+					},
+				},
+				"wordpress": {
+					Charm:   "cs:xenial/wordpress-47",
+					Series:  "xenial",
+					Exposed: true,
+					Units: map[string]params.UnitStatus{
+						"wordpress/0": {Machine: "1/lxd/0"},
+					},
+				},
+			},
+			Relations: []params.RelationStatus{{
+				Endpoints: []params.EndpointStatus{
+					{ApplicationName: "wordpress", Name: "db"},
+					{ApplicationName: "mysql", Name: "server"},
+				},
+			}},
+		},
+		config: map[string]map[string]interface{}{
+			"mysql": {
+				"max-connections": map[string]interface{}{"value": float64(200), "source": "user"},
+				"tuning-level":    map[string]interface{}{"value": "safest", "source": "default"},
+			},
+		},
+		constraints: map[string]string{
+			"mysql": "mem=4G",
+		},
+	}
+}
+
+func (s *diffBundleSuite) writeBundle(c *gc.C, content string) string {
+	path := filepath.Join(s.dir, "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *diffBundleSuite) runDiffBundle(c *gc.C, args ...string) (string, error) {
+	command := &diffBundleCommand{
+		newAPIRootFn: func() (DiffBundleAPI, error) {
+			return s.api, nil
+		},
+	}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, modelcmd.Wrap(command), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx) + cmdtesting.Stderr(ctx), nil
+}
+
+const matchingBundle = `
+series: xenial
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    to: ["0"]
+    options:
+      max-connections: 200
+    constraints: mem=4096M
+  wordpress:
+    charm: cs:xenial/wordpress-47
+    num_units: 1
+    to: ["lxd:1"]
+    expose: true
+machines:
+  "0": {}
+  "1": {}
+relations:
+- [wordpress:db, mysql]
+`
+
+func (s *diffBundleSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+	_, err = s.runDiffBundle(c, "bundle.yaml", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+	_, err = s.runDiffBundle(c, "bundle.yaml", "--map-machines", "foo")
+	c.Assert(err, gc.ErrorMatches, `error in --map-machines: expected "existing" or "<bundle-id>=<machine-id>", got "foo"`)
+}
+
+func (s *diffBundleSuite) TestNoDifferences(c *gc.C) {
+	path := s.writeBundle(c, matchingBundle)
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "No differences between the bundle and the model.\n")
+	s.api.CheckCallNames(c, "Status", "Sequences", "GetConfig", "GetConstraints", "Close")
+}
+
+func (s *diffBundleSuite) TestDifferences(c *gc.C) {
+	path := s.writeBundle(c, `
+series: xenial
+applications:
+  mysql:
+    charm: cs:mysql-58
+    num_units: 2
+    to: ["0", "2"]
+    options:
+      max-connections: 100
+      tuning-level: fast
+    bindings:
+      db: internal
+  haproxy:
+    charm: cs:haproxy
+    num_units: 1
+machines:
+  "0": {}
+  "2": {}
+relations:
+- [haproxy:reverseproxy, mysql:db]
+`)
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  haproxy:
+    missing: model
+  mysql:
+    charm:
+      bundle: cs:mysql-58
+      model: cs:xenial/mysql-57
+    num_units:
+      bundle: 2
+      model: 1
+    options:
+      max-connections:
+        bundle: 100
+        model: 200
+      tuning-level:
+        bundle: fast
+        model: null
+    constraints:
+      bundle: ""
+      model: mem=4G
+    bindings:
+      db:
+        bundle: internal
+        model: ""
+  wordpress:
+    missing: bundle
+machines:
+  "1":
+    missing: bundle
+  "2":
+    missing: model
+relations:
+  bundle-additions:
+  - - haproxy:reverseproxy
+    - mysql:db
+  model-additions:
+  - - mysql:server
+    - wordpress:db
+`[1:])
+}
+
+func (s *diffBundleSuite) TestMapMachines(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    to: ["5"]
+machines:
+  "5": {}
+`)
+	out, err := s.runDiffBundle(c, path, "--map-machines", "5=1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.Contains, `
+    placement:
+      bundle:
+      - "1"
+      model:
+      - "0"
+`[1:])
+	c.Assert(out, jc.Contains, `
+machines:
+  "0":
+    missing: bundle
+`[1:])
+}
+
+func (s *diffBundleSuite) TestOffers(c *gc.C) {
+	s.api.status.Offers = map[string]params.ApplicationOfferStatus{
+		"db": {OfferName: "db", ApplicationName: "mysql"},
+	}
+	path := s.writeBundle(c, matchingBundle)
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+offers:
+  db:
+    missing: bundle
+    application: mysql
+`[1:])
+}
+
+func (s *diffBundleSuite) TestInvalidBundle(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    constraints: bad
+`)
+	_, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.ErrorMatches, `(?s)the provided bundle has the following errors:.*invalid constraints.*`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *diffBundleSuite) TestStatusError(c *gc.C) {
+	path := s.writeBundle(c, matchingBundle)
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.ErrorMatches, "getting model status: boom")
+}
+
+func (s *diffBundleSuite) TestCharmURLsMatch(c *gc.C) {
+	for i, t := range []struct {
+		bundle, model string
+		match         bool
+	}{
+		{"cs:mysql", "cs:xenial/mysql-57", true},
+		{"mysql", "cs:xenial/mysql-57", true},
+		{"cs:xenial/mysql-57", "cs:xenial/mysql-57", true},
+		{"cs:mysql-58", "cs:xenial/mysql-57", false},
+		{"cs:bionic/mysql", "cs:xenial/mysql-57", false},
+		{"cs:~user/mysql", "cs:xenial/mysql-57", false},
+		{"cs:percona", "cs:xenial/mysql-57", false},
+	} {
+		c.Logf("test %d: %s vs %s", i, t.bundle, t.model)
+		c.Check(charmURLsMatch(t.bundle, t.model), gc.Equals, t.match)
+	}
+}
+
+type fakeDiffBundleAPI struct {
+	jujutesting.Stub
+	status      *params.FullStatus
+	config      map[string]map[string]interface{}
+	constraints map[string]string
+}
+
+func (f *fakeDiffBundleAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.MethodCall(f, "Status", patterns)
+	return f.status, f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error) {
+	return nil, nil
+}
+
+func (f *fakeDiffBundleAPI) GetConfig(appNames ...string) ([]map[string]interface{}, error) {
+	f.MethodCall(f, "GetConfig", appNames)
+	result := make([]map[string]interface{}, len(appNames))
+	for i, name := range appNames {
+		result[i] = f.config[name]
+	}
+	return result, f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) GetConstraints(appNames ...string) ([]constraints.Value, error) {
+	f.MethodCall(f, "GetConstraints", appNames)
+	result := make([]constraints.Value, len(appNames))
+	for i, name := range appNames {
+		result[i] = constraints.MustParse(f.constraints[name])
+	}
+	return result, f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) Sequences() (map[string]int, error) {
+	f.MethodCall(f, "Sequences")
+	return nil, f.NextErr()
+}
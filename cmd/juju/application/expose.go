@@ -6,8 +6,10 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +21,24 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+Access can be limited to particular spaces and CIDRs with the --to-spaces
+and --to-cidrs options. Without --endpoints these settings apply to all of
+the application's endpoints; with it, they are recorded for the listed
+endpoints only, and the settings of other endpoints are left unchanged.
+Naming endpoints without any spaces or CIDRs makes them accessible from
+anywhere.
+
+Units may open ports for particular endpoints with "open-port --endpoints".
+Those ports can be reached from the sources the endpoint is exposed to, or
+from the sources of the wildcard settings (made without --endpoints) if the
+endpoint has no settings of its own. Ports opened for all endpoints can be
+reached from the sources of every exposed endpoint.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/24,192.168.1.0/24
+    juju expose wordpress --endpoints website --to-cidrs 0.0.0.0/0
+    juju expose wordpress --endpoints admin --to-spaces internal
 
 See also: 
     unexpose`[1:]
@@ -34,6 +52,10 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+
+	endpoints []string
+	toSpaces  []string
+	toCIDRs   []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,6 +67,13 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.endpoints), "endpoints", "Comma-delimited list of endpoints to apply the expose settings to")
+	f.Var(cmd.NewAppendStringsValue(&c.toSpaces), "to-spaces", "Comma-delimited list of spaces that may access the exposed ports")
+	f.Var(cmd.NewAppendStringsValue(&c.toCIDRs), "to-cidrs", "Comma-delimited list of CIDRs that may access the exposed ports")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
@@ -56,6 +85,7 @@ func (c *exposeCommand) Init(args []string) error {
 type applicationExposeAPI interface {
 	Close() error
 	Expose(applicationName string) error
+	ExposeEndpoints(applicationName string, exposedEndpoints map[string]params.ExposedEndpoint) error
 	Unexpose(applicationName string) error
 }
 
@@ -75,5 +105,38 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+
+	exposedEndpoints := c.exposedEndpoints()
+	if len(exposedEndpoints) == 0 {
+		return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	}
+	err = client.ExposeEndpoints(c.ApplicationName, exposedEndpoints)
+	if errors.IsNotSupported(err) {
+		return errors.New("exposing specific endpoints, spaces or CIDRs is not supported by this controller")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// exposedEndpoints returns the expose settings requested on the
+// command line, or nil if the application is simply to be exposed.
+func (c *exposeCommand) exposedEndpoints() map[string]params.ExposedEndpoint {
+	if len(c.endpoints)+len(c.toSpaces)+len(c.toCIDRs) == 0 {
+		return nil
+	}
+	settings := params.ExposedEndpoint{
+		ExposeToSpaces: c.toSpaces,
+		ExposeToCIDRs:  c.toCIDRs,
+	}
+	if len(settings.ExposeToSpaces)+len(settings.ExposeToCIDRs) == 0 {
+		settings.ExposeToCIDRs = []string{"0.0.0.0/0"}
+	}
+	endpoints := c.endpoints
+	if len(endpoints) == 0 {
+		endpoints = []string{""}
+	}
+	result := make(map[string]params.ExposedEndpoint, len(endpoints))
+	for _, name := range endpoints {
+		result[name] = settings
+	}
+	return result
 }
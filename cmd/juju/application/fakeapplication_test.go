@@ -22,6 +22,9 @@ type fakeApplicationAPI struct {
 	config      string
 	err         error
 	version     int
+
+	history  []params.CharmConfigRevision
+	reverted []int
 }
 
 func (f *fakeApplicationAPI) Update(args params.ApplicationUpdate) error {
@@ -133,3 +136,24 @@ func (f *fakeApplicationAPI) Unset(application string, options []string) error {
 func (f *fakeApplicationAPI) UnsetApplicationConfig(application string, options []string) error {
 	return f.Unset(application, options)
 }
+
+func (f *fakeApplicationAPI) CharmConfigHistory(application string) ([]params.CharmConfigRevision, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if application != f.name {
+		return nil, errors.NotFoundf("application %q", application)
+	}
+	return f.history, nil
+}
+
+func (f *fakeApplicationAPI) RevertCharmConfig(application string, revision int) error {
+	if f.err != nil {
+		return f.err
+	}
+	if application != f.name {
+		return errors.NotFoundf("application %q", application)
+	}
+	f.reverted = append(f.reverted, revision)
+	return nil
+}
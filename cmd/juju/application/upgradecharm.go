@@ -68,6 +68,8 @@ type CharmUpgradeClient interface {
 	GetCharmURL(string) (*charm.URL, error)
 	Get(string) (*params.ApplicationGetResults, error)
 	SetCharm(application.SetCharmConfig) error
+	HaltCharmRollout(string) error
+	RollbackCharmRollout(string) error
 }
 
 // CharmClient defines a subset of the charms facade, as required
@@ -125,6 +127,22 @@ type upgradeCharmCommand struct {
 	// Storage is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
+
+	// Units holds the names of the units to upgrade. If it is empty,
+	// and MaxUnavailable is zero, all units are upgraded at once.
+	Units []string
+
+	// MaxUnavailable holds the number of units that may be upgrading
+	// at once.
+	MaxUnavailable int
+
+	// Halt stops any more units from being upgraded to the charm
+	// being rolled out to the application.
+	Halt bool
+
+	// Rollback abandons the charm being rolled out to the
+	// application.
+	Rollback bool
 }
 
 const upgradeCharmDoc = `
@@ -198,6 +216,32 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+By default all of the application's units are upgraded at once. The --units
+flag upgrades only the named units, leaving the others running the current
+charm. This allows a new charm to be tried out on a few canary units first:
+
+  juju upgrade-charm foo --units foo/0,foo/1
+
+The --max-unavailable flag upgrades the units progressively instead, with no
+more than the given number of units upgrading at any one time:
+
+  juju upgrade-charm foo --max-unavailable 1
+
+The application's charm only changes once all of its units have been upgraded.
+Running upgrade-charm again with the same charm carries on an upgrade started
+with either flag; without --units or --max-unavailable, it upgrades all of the
+remaining units at once.
+
+The --halt flag stops any more units from being upgraded, and the --rollback
+flag abandons the upgrade, returning any upgraded units to the application's
+charm:
+
+  juju upgrade-charm foo --halt
+  juju upgrade-charm foo --rollback
+
+--halt and --rollback cannot be combined with each other or with any flag that
+selects a charm or the units to upgrade.
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -220,6 +264,10 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.Var(cmd.NewStringsValue(nil, &c.Units), "units", "Upgrade only the specified units (comma separated)")
+	f.IntVar(&c.MaxUnavailable, "max-unavailable", 0, "Upgrade units progressively, at most this many at a time")
+	f.BoolVar(&c.Halt, "halt", false, "Stop upgrading any more units to the charm being rolled out")
+	f.BoolVar(&c.Rollback, "rollback", false, "Abandon the charm being rolled out, returning upgraded units to the current charm")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -243,6 +291,33 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.MaxUnavailable < 0 {
+		return errors.Errorf("--max-unavailable must not be negative")
+	}
+	for _, unit := range c.Units {
+		if !names.IsValidUnit(unit) {
+			return errors.Errorf("invalid unit name %q", unit)
+		}
+		if appName, _ := names.UnitApplication(unit); appName != c.ApplicationName {
+			return errors.Errorf("unit %q does not belong to application %q", unit, c.ApplicationName)
+		}
+	}
+	if c.Halt || c.Rollback {
+		flag := "--halt"
+		if c.Rollback {
+			flag = "--rollback"
+		}
+		switch {
+		case c.Halt && c.Rollback:
+			return errors.Errorf("--halt and --rollback are mutually exclusive")
+		case c.SwitchURL != "" || c.CharmPath != "" || c.Revision != -1 || c.Channel != "":
+			return errors.Errorf("%s cannot be used when selecting a charm", flag)
+		case len(c.Units) > 0 || c.MaxUnavailable > 0:
+			return errors.Errorf("%s cannot be used with --units or --max-unavailable", flag)
+		case c.ForceUnits || c.ForceSeries || c.Config.Path != "" || len(c.Resources) > 0 || len(c.Storage) > 0:
+			return errors.Errorf("%s cannot be used when changing the charm's settings", flag)
+		}
+	}
 	return nil
 }
 
@@ -255,6 +330,16 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiRoot.Close()
 
+	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
+	if c.Halt {
+		err := charmUpgradeClient.HaltCharmRollout(c.ApplicationName)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if c.Rollback {
+		err := charmUpgradeClient.RollbackCharmRollout(c.ApplicationName)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+
 	// If the user has specified config or storage constraints,
 	// make sure the server has facade version 2 at a minimum.
 	if c.Config.Path != "" || len(c.Storage) > 0 {
@@ -271,7 +356,6 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
-	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
 	oldURL, err := charmUpgradeClient.GetCharmURL(c.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -354,6 +438,8 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ForceUnits:         c.ForceUnits,
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
+		Units:              c.Units,
+		MaxUnavailable:     c.MaxUnavailable,
 	}
 	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
 }
@@ -246,6 +246,55 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) TestUnits(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--units", "foo/0,foo/2", "--max-unavailable", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharm")
+	s.charmUpgradeClient.CheckCall(c, 2, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		Units:          []string{"foo/0", "foo/2"},
+		MaxUnavailable: 1,
+	})
+}
+
+func (s *UpgradeCharmSuite) TestInvalidUnits(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--units", "foo")
+	c.Assert(err, gc.ErrorMatches, `invalid unit name "foo"`)
+	_, err = s.runUpgradeCharm(c, "foo", "--units", "bar/0")
+	c.Assert(err, gc.ErrorMatches, `unit "bar/0" does not belong to application "foo"`)
+	_, err = s.runUpgradeCharm(c, "foo", "--max-unavailable", "-1")
+	c.Assert(err, gc.ErrorMatches, "--max-unavailable must not be negative")
+}
+
+func (s *UpgradeCharmSuite) TestHalt(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--halt")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "HaltCharmRollout")
+	s.charmUpgradeClient.CheckCall(c, 0, "HaltCharmRollout", "foo")
+}
+
+func (s *UpgradeCharmSuite) TestRollback(c *gc.C) {
+	s.charmUpgradeClient.SetErrors(errors.NotFoundf("charm rollout"))
+	_, err := s.runUpgradeCharm(c, "foo", "--rollback")
+	c.Assert(err, gc.ErrorMatches, "charm rollout not found")
+	s.charmUpgradeClient.CheckCallNames(c, "RollbackCharmRollout")
+}
+
+func (s *UpgradeCharmSuite) TestHaltAndRollbackInvalidFlags(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--halt", "--rollback")
+	c.Assert(err, gc.ErrorMatches, "--halt and --rollback are mutually exclusive")
+	_, err = s.runUpgradeCharm(c, "foo", "--halt", "--revision", "2")
+	c.Assert(err, gc.ErrorMatches, "--halt cannot be used when selecting a charm")
+	_, err = s.runUpgradeCharm(c, "foo", "--rollback", "--units", "foo/0")
+	c.Assert(err, gc.ErrorMatches, "--rollback cannot be used with --units or --max-unavailable")
+	_, err = s.runUpgradeCharm(c, "foo", "--rollback", "--force-units")
+	c.Assert(err, gc.ErrorMatches, "--rollback cannot be used when changing the charm's settings")
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
@@ -768,6 +817,16 @@ func (m *mockCharmUpgradeClient) SetCharm(cfg application.SetCharmConfig) error
 	return m.NextErr()
 }
 
+func (m *mockCharmUpgradeClient) HaltCharmRollout(applicationName string) error {
+	m.MethodCall(m, "HaltCharmRollout", applicationName)
+	return m.NextErr()
+}
+
+func (m *mockCharmUpgradeClient) RollbackCharmRollout(applicationName string) error {
+	m.MethodCall(m, "RollbackCharmRollout", applicationName)
+	return m.NextErr()
+}
+
 func (m *mockCharmUpgradeClient) Get(applicationName string) (*params.ApplicationGetResults, error) {
 	m.MethodCall(m, "Get", applicationName)
 	return &params.ApplicationGetResults{}, m.NextErr()
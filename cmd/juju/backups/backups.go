@@ -103,6 +103,7 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
 	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
+	fmt.Fprintf(ctx.Stdout, "target:          %q\n", result.Target)
 
 	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
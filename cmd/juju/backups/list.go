@@ -11,10 +11,16 @@ import (
 	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 const listDoc = `
 backups provides the metadata associated with all backups.
+
+Each backup is listed with the target its archive is stored in and the
+checksum of the archive, which can be used to verify a downloaded copy.
+Archives stored in the controller's own database have the target
+"controller". Use --verbose to display all of the metadata.
 `
 
 // NewListCommand returns a command used to list metadata for backups.
@@ -74,19 +80,25 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 		return nil
 	}
 
-	verbose := c.Log != nil && c.Log.Verbose
-	if verbose {
+	if c.Log != nil && c.Log.Verbose {
 		c.dumpMetadata(ctx, &result.List[0])
-	} else {
-		fmt.Fprintln(ctx.Stdout, result.List[0].ID)
-	}
-	for _, resultItem := range result.List[1:] {
-		if verbose {
+		for _, resultItem := range result.List[1:] {
 			fmt.Fprintln(ctx.Stdout)
 			c.dumpMetadata(ctx, &resultItem)
-		} else {
-			fmt.Fprintln(ctx.Stdout, resultItem.ID)
 		}
+		return nil
+	}
+
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{tw}
+	w.Println("ID", "Target", "Checksum")
+	for _, resultItem := range result.List {
+		target := resultItem.Target
+		if target == "" {
+			target = "controller"
+		}
+		w.Println(resultItem.ID, target, resultItem.Checksum)
 	}
+	tw.Flush()
 	return nil
 }
@@ -39,7 +39,23 @@ func (s *listSuite) TestBrief(c *gc.C) {
 	s.setSuccess()
 	ctx, err := cmdtesting.RunCommand(c, s.subcommand)
 	c.Assert(err, jc.ErrorIsNil)
-	out := s.metaresult.ID + "\n"
+	out := `
+ID    Target      Checksum
+spam  controller  
+`[1:]
+	s.checkStd(c, ctx, out, "")
+}
+
+func (s *listSuite) TestBriefTarget(c *gc.C) {
+	s.metaresult.Checksum = "787b8915389d921fa23fb40e16ae81ea979758bf"
+	s.metaresult.Target = "s3://juju-backups/prod"
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand)
+	c.Assert(err, jc.ErrorIsNil)
+	out := `
+ID    Target                  Checksum
+spam  s3://juju-backups/prod  787b8915389d921fa23fb40e16ae81ea979758bf
+`[1:]
 	s.checkStd(c, ctx, out, "")
 }
 
@@ -33,6 +33,7 @@ stored:          0001-01-01 00:00:00 +0000 UTC
 started:         0001-01-01 00:00:00 +0000 UTC
 finished:        0001-01-01 00:00:00 +0000 UTC
 notes:           ""
+target:          ""
 model ID:        ""
 machine ID:      ""
 created on host: ""
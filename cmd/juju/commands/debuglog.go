@@ -6,6 +6,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/loggo"
 	"github.com/juju/loggo/loggocolor"
+	"github.com/juju/utils/clock"
 	"github.com/mattn/go-isatty"
 	"gopkg.in/juju/names.v2"
 
@@ -49,13 +51,27 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-message' and '--exclude-message' options filter by the
+text of the log message. The values are regular expressions which may match
+anywhere within the message, so plain text can also be used.
+
+The '--since' and '--until' options restrict the messages shown to those
+logged within a time window. Each accepts either an absolute time, in
+RFC3339 format or as "YYYY-MM-DD[ HH:MM:SS]" in the local timezone (or UTC
+if '--utc' is set), or a duration such as "90m" or "2h", which is taken
+relative to the current time. Using '--since' implies '--replay'; once the
+'--until' time has passed, no further messages are shown.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --include-message options are logically ORed together.
+* All --exclude-message options are logically ORed together.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --include-message and --exclude-message selections and the time window
+  are logically ANDed to form the complete filter.
 
 Examples:
 
@@ -94,6 +110,19 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all messages about failed hooks logged between 22:00 and 23:30 UTC
+on the 1st of March 2018, and then stop:
+
+    juju debug-log --utc \
+        --since "2018-03-01 22:00:00" \
+        --until "2018-03-01 23:30:00" \
+        --include-message "hook .* failed"
+
+Show the messages logged in the last two hours, except those mentioning
+update-status, and then stop:
+
+    juju debug-log --no-tail --since 2h --exclude-message update-status
+
 See also: 
     status
     ssh`
@@ -111,7 +140,7 @@ func newDebugLogCommand(store jujuclient.ClientStore) cmd.Command {
 }
 
 func newDebugLogCommandTZ(store jujuclient.ClientStore, tz *time.Location) cmd.Command {
-	cmd := &debugLogCommand{tz: tz}
+	cmd := &debugLogCommand{tz: tz, clock: clock.WallClock}
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
@@ -131,8 +160,12 @@ type debugLogCommand struct {
 	notail bool
 	color  bool
 
+	since string
+	until string
+
 	format string
 	tz     *time.Location
+	clock  clock.Clock
 }
 
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -143,6 +176,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeMessage), "include-message", "Only show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeMessage), "exclude-message", "Do not show log messages matching these regular expressions")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -151,6 +186,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
 	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time or duration ago")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time or duration ago")
 
 	f.BoolVar(&c.notail, "no-tail", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.tail, "tail", false, "Wait for new logs")
@@ -177,6 +214,15 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.utc {
 		c.tz = time.UTC
 	}
+	if err := c.processTimes(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkPatterns("--include-message", c.params.IncludeMessage); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkPatterns("--exclude-message", c.params.ExcludeMessage); err != nil {
+		return errors.Trace(err)
+	}
 	if c.date {
 		c.format = "2006-01-02 15:04:05"
 	} else {
@@ -190,6 +236,77 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+func (c *debugLogCommand) processTimes() error {
+	if c.since == "" && c.until == "" {
+		return nil
+	}
+	if c.clock == nil {
+		c.clock = clock.WallClock
+	}
+	if c.tz == nil {
+		c.tz = time.Local
+	}
+	now := c.clock.Now()
+	if c.since != "" {
+		since, err := parseLogTime(c.since, now, c.tz)
+		if err != nil {
+			return errors.Annotate(err, "--since")
+		}
+		c.params.StartTime = since
+		// Without replay, the server only looks at the most
+		// recent lines, which may not reach back far enough.
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := parseLogTime(c.until, now, c.tz)
+		if err != nil {
+			return errors.Annotate(err, "--until")
+		}
+		c.params.EndTime = until
+	}
+	if !c.params.StartTime.IsZero() && !c.params.EndTime.IsZero() &&
+		c.params.EndTime.Before(c.params.StartTime) {
+		return errors.NotValidf("--until time before --since time")
+	}
+	return nil
+}
+
+// logTimeLayouts holds the absolute time formats accepted by --since
+// and --until, in the order they are tried.
+var logTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseLogTime parses value either as a duration before now or as an
+// absolute time. Absolute times without an explicit offset are
+// interpreted in the given location.
+func parseLogTime(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.NotValidf("negative duration %q", value)
+		}
+		return now.Add(-d), nil
+	}
+	for _, layout := range logTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("%q is not a valid time or duration", value)
+}
+
+func checkPatterns(option string, patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return errors.Errorf("%s value %q is not a valid regular expression", option, pattern)
+		}
+	}
+	return nil
+}
+
 func (c *debugLogCommand) processEntities(entities []string) []string {
 	if entities == nil {
 		return nil
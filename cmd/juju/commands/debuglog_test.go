@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/loggo"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -24,6 +25,7 @@ type DebugLogSuite struct {
 var _ = gc.Suite(&DebugLogSuite{})
 
 func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
+	now := time.Date(2018, 3, 2, 10, 0, 0, 0, time.UTC)
 	for i, test := range []struct {
 		args     []string
 		expected common.DebugLogParams
@@ -104,10 +106,55 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--include-message", "hook .* failed", "--include-message", "error"},
+			expected: common.DebugLogParams{
+				IncludeMessage: []string{"hook .* failed", "error"},
+				Backlog:        10,
+			},
+		}, {
+			args: []string{"--exclude-message", "update-status"},
+			expected: common.DebugLogParams{
+				ExcludeMessage: []string{"update-status"},
+				Backlog:        10,
+			},
+		}, {
+			args:     []string{"--include-message", "foo("},
+			errMatch: `--include-message value "foo\(" is not a valid regular expression`,
+		}, {
+			args: []string{"--since", "2h"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: now.Add(-2 * time.Hour),
+			},
+		}, {
+			args:     []string{"--utc", "--since", "2018-03-01 22:00:00", "--until", "2018-03-01"},
+			errMatch: `--until time before --since time not valid`,
+		}, {
+			args: []string{"--utc", "--since", "2018-03-01 22:00:00", "--until", "2018-03-01T23:30:00Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2018, 3, 1, 22, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2018, 3, 1, 23, 30, 0, 0, time.UTC),
+			},
+		}, {
+			args: []string{"--until", "30m"},
+			expected: common.DebugLogParams{
+				Backlog: 10,
+				EndTime: now.Add(-30 * time.Minute),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `--since: "yesterday" is not a valid time or duration`,
+		}, {
+			args:     []string{"--until", "-1h"},
+			errMatch: `--until: negative duration "-1h" not valid`,
 		},
 	} {
 		c.Logf("test %v", i)
-		command := &debugLogCommand{}
+		command := &debugLogCommand{clock: jujutesting.NewClock(now)}
 		command.SetClientStore(jujuclienttesting.MinimalStore())
 		err := cmdtesting.InitCommand(modelcmd.Wrap(command), test.args)
 		if test.errMatch == "" {
@@ -53,6 +53,12 @@ Currently available charm hook tools are:
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
     relation-set             set relation settings
+    secret-add               add a secret
+    secret-get               print a secret value
+    secret-revoke            revoke access to a secret
+    state-delete             delete unit state
+    state-get                print unit state
+    state-set                set unit state
     status-get               print status information
     status-set               set status information
     storage-add              add storage instances
@@ -97,6 +103,12 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"secret-add",
+	"secret-get",
+	"secret-revoke",
+	"state-delete",
+	"state-get",
+	"state-set",
 	"status-get",
 	"status-set",
 	"storage-add",
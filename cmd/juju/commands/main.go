@@ -38,14 +38,17 @@ import (
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/cmd/juju/metricsdebug"
 	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/quota"
 	"github.com/juju/juju/cmd/juju/resource"
 	rcmd "github.com/juju/juju/cmd/juju/romulus/commands"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/juju/subnet"
 	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/cmd/juju/waitfor"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju"
@@ -298,6 +301,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(waitfor.NewWaitForCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand(nil))
@@ -346,6 +350,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(user.NewDisableCommand())
 	r.Register(user.NewLoginCommand())
 	r.Register(user.NewLogoutCommand())
+	r.Register(user.NewEncryptStoreCommand())
 	r.Register(user.NewRemoveCommand())
 	r.Register(user.NewWhoAmICommand())
 
@@ -383,6 +388,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewStatusCommand())
 	r.Register(action.NewRunCommand())
 	r.Register(action.NewShowOutputCommand())
+	r.Register(action.NewShowOperationCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
 
@@ -393,6 +399,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewAddUnitCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
+	r.Register(application.NewDiffBundleCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewApplicationGetConstraintsCommand())
@@ -412,8 +419,20 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
+	r.Register(storage.NewResizeStorageCommandWithAPI())
+	r.Register(storage.NewSnapshotStorageCommandWithAPI())
+	r.Register(storage.NewSnapshotListCommand())
+	r.Register(storage.NewRemoveStorageSnapshotCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
 
+	// Manage model quotas
+	r.Register(quota.NewSetCommand())
+	r.Register(quota.NewShowCommand())
+
+	// Manage secrets
+	r.Register(secrets.NewListCommand())
+	r.Register(secrets.NewShowCommand())
+
 	// Manage spaces
 	r.Register(space.NewAddCommand())
 	r.Register(space.NewListCommand())
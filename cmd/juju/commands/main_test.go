@@ -432,6 +432,7 @@ var commandNames = []string{
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -440,6 +441,7 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"encrypt-client-store",
 	"export-bundle",
 	"expose",
 	"find-offers",
@@ -472,10 +474,12 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"list-wallets",
@@ -509,8 +513,10 @@ var commandNames = []string{
 	"remove-saas",
 	"remove-ssh-key",
 	"remove-storage",
+	"remove-storage-snapshot",
 	"remove-unit",
 	"remove-user",
+	"resize-storage",
 	"resolved",
 	"resolve",
 	"resources",
@@ -522,6 +528,7 @@ var commandNames = []string{
 	"run-action",
 	"scale-application",
 	"scp",
+	"secrets",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
@@ -529,6 +536,7 @@ var commandNames = []string{
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
+	"set-quota",
 	"set-series",
 	"set-wallet",
 	"show-action-output",
@@ -541,18 +549,23 @@ var commandNames = []string{
 	"show-machine",
 	"show-model",
 	"show-offer",
+	"show-operation",
+	"show-quota",
+	"show-secret",
 	"show-status",
 	"show-status-log",
 	"show-storage",
 	"show-user",
 	"show-wallet",
 	"sla",
+	"snapshot-storage",
 	"spaces",
 	"ssh",
 	"ssh-keys",
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
 	"suspend-relation",
 	"switch",
@@ -571,6 +584,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait-for",
 	"wallets",
 	"whoami",
 }
@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
 
@@ -28,10 +31,12 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationDryRun(spec controller.MigrationSpec) ([]string, error)
 }
 
 const migrateDoc = `
@@ -57,6 +62,18 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, no migration is started. Instead, the checks made on
+the source and target controllers before a migration are run, the
+model is exported, and the target controller checks whether it could
+import the model (its charms, agent binaries, cloud, credential, users
+and offers). Every problem that would block the migration is reported,
+and neither controller is changed.
+
+Examples:
+
+    juju migrate mymodel other-controller
+    juju migrate --dry-run mymodel other-controller
+
 See also:
     login
     controllers
@@ -73,6 +90,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Report what would block the migration, without starting it")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -141,6 +164,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.runDryRun(ctx, api, *spec, modelName)
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -149,6 +175,22 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, api migrateAPI, spec controller.MigrationSpec, modelName string) error {
+	blockers, err := api.MigrationDryRun(spec)
+	if err != nil {
+		return err
+	}
+	if len(blockers) == 0 {
+		ctx.Infof("Model %q can be migrated to controller %q", modelName, c.targetController)
+		return nil
+	}
+	fmt.Fprintf(ctx.Stdout, "Migration of model %q to controller %q would be blocked by:\n", modelName, c.targetController)
+	for _, blocker := range blockers {
+		fmt.Fprintf(ctx.Stdout, "  - %s\n", blocker)
+	}
+	return cmd.ErrSilent
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
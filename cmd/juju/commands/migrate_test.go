@@ -219,6 +219,39 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to controller \"target\"\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(s.api.specSeen, gc.IsNil) // No migration started.
+	c.Check(s.api.dryRunSpecSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunBlocked(c *gc.C) {
+	s.api.blockers = []string{
+		"source prechecks failed: unit mysql/0 not idle or executing (error)",
+		`target import validation failed: model owner "bob" not found`,
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Migration of model "model" to controller "target" would be blocked by:
+  - source prechecks failed: unit mysql/0 not idle or executing (error)
+  - target import validation failed: model owner "bob" not found
+`[1:])
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, s.makeCommand(), args...)
 }
@@ -236,7 +269,9 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen       *controller.MigrationSpec
+	dryRunSpecSeen *controller.MigrationSpec
+	blockers       []string
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -244,6 +279,11 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) MigrationDryRun(spec controller.MigrationSpec) ([]string, error) {
+	a.dryRunSpecSeen = &spec
+	return a.blockers, nil
+}
+
 type fakeModelAPI struct {
 	models []base.UserModel
 }
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -16,6 +18,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -32,6 +35,12 @@ are displayed.
 Supplying one key name returns only the value for the key. Supplying key=value
 will set the supplied key to the supplied value, this can be repeated for
 multiple keys. You can also specify a yaml file containing key values.
+
+Each change to a model's configuration is recorded as a new revision. The
+most recent revisions, showing who changed what and when, are displayed with
+--history, and the configuration can be restored to that of an earlier
+revision with --revert. The agent version is never reverted; use
+upgrade-model to change it.
 `
 	modelConfigHelpDocKeys = `
 The following keys are available:
@@ -45,6 +54,8 @@ Examples:
     juju model-config path/to/file.yaml
     juju model-config -m othercontroller:mymodel default-series=yakkety test-mode=false
     juju model-config --reset default-series test-mode
+    juju model-config --history
+    juju model-config --revert 3
 
 See also:
     models
@@ -69,6 +80,8 @@ type configCommand struct {
 	out cmd.Output
 
 	action     func(configCommandAPI, *cmd.Context) error // The action which we want to handle, set in cmd.Init.
+	history    bool
+	revert     int
 	keys       []string
 	reset      []string // Holds the keys to be reset until parsed.
 	resetKeys  []string // Holds the keys to be reset once parsed.
@@ -82,6 +95,8 @@ type configCommandAPI interface {
 	ModelGetWithMetadata() (config.ConfigValues, error)
 	ModelSet(config map[string]interface{}) error
 	ModelUnset(keys ...string) error
+	ModelConfigHistory() ([]params.ModelConfigRevision, error)
+	RevertModelConfig(revision int) error
 }
 
 // Info implements part of the cmd.Command interface.
@@ -117,6 +132,8 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 	})
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.history, "history", false, "Show the recent revisions of the model config")
+	f.IntVar(&c.revert, "revert", 0, "Restore the model config of the given revision")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -129,6 +146,10 @@ func (c *configCommand) Init(args []string) error {
 		return errors.Trace(err)
 	}
 
+	if c.history || c.revert != 0 {
+		return c.handleHistory(args)
+	}
+
 	switch len(args) {
 	case 0:
 		return c.handleZeroArgs()
@@ -139,6 +160,26 @@ func (c *configCommand) Init(args []string) error {
 	}
 }
 
+// handleHistory handles the case where --history or --revert is
+// specified.
+func (c *configCommand) handleHistory(args []string) error {
+	if c.history && c.revert != 0 {
+		return errors.New("cannot specify --history and --revert simultaneously")
+	}
+	if len(args) > 0 || len(c.resetKeys) > 0 {
+		return errors.New("cannot combine --history or --revert with other arguments")
+	}
+	if c.history {
+		c.action = c.getHistory
+		return nil
+	}
+	if c.revert < 0 {
+		return errors.Errorf("invalid revision %d", c.revert)
+	}
+	c.action = c.revertConfig
+	return nil
+}
+
 // handleZeroArgs handles the case where there are no positional args.
 func (c *configCommand) handleZeroArgs() error {
 	// If reset is empty we're getting configuration
@@ -349,6 +390,62 @@ func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) err
 	return c.out.Write(ctx, attrs)
 }
 
+// configRevision holds a revision of the model config for output, along
+// with the changes made by it.
+type configRevision struct {
+	Revision int                    `yaml:"revision" json:"revision"`
+	Author   string                 `yaml:"author,omitempty" json:"author,omitempty"`
+	Created  string                 `yaml:"created" json:"created"`
+	Changed  map[string]interface{} `yaml:"changed,omitempty" json:"changed,omitempty"`
+	Unset    []string               `yaml:"unset,omitempty" json:"unset,omitempty"`
+}
+
+// getHistory writes the recent revisions of the model config, and the
+// changes made by each, to the cmd.Context.
+func (c *configCommand) getHistory(client configCommandAPI, ctx *cmd.Context) error {
+	revisions, err := client.ModelConfigHistory()
+	if errors.IsNotSupported(err) {
+		return errors.New("model config history is not supported by this controller")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	var previous map[string]interface{}
+	result := make([]configRevision, len(revisions))
+	for i, rev := range revisions {
+		result[i] = configRevision{
+			Revision: rev.Revision,
+			Author:   rev.Author,
+			Created:  rev.Created.UTC().Format(time.RFC3339),
+		}
+		for key, value := range rev.Config {
+			if old, ok := previous[key]; ok && reflect.DeepEqual(old, value) {
+				continue
+			}
+			if result[i].Changed == nil {
+				result[i].Changed = make(map[string]interface{})
+			}
+			result[i].Changed[key] = value
+		}
+		for key := range previous {
+			if _, ok := rev.Config[key]; !ok {
+				result[i].Unset = append(result[i].Unset, key)
+			}
+		}
+		sort.Strings(result[i].Unset)
+		previous = rev.Config
+	}
+	return c.out.Write(ctx, result)
+}
+
+// revertConfig restores the model config of an earlier revision.
+func (c *configCommand) revertConfig(client configCommandAPI, ctx *cmd.Context) error {
+	err := client.RevertModelConfig(c.revert)
+	if errors.IsNotSupported(err) {
+		return errors.New("reverting model config is not supported by this controller")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
 // verifyKnownKeys is a helper to validate the keys we are operating with
 // against the set of known attributes from the model.
 func (c *configCommand) verifyKnownKeys(client configCommandAPI, keys []string) error {
@@ -381,6 +478,9 @@ func (c *configCommand) isModelAttribute(attr string) bool {
 
 // formatConfigTabular writes a tabular summary of config information.
 func formatConfigTabular(writer io.Writer, value interface{}) error {
+	if revisions, ok := value.([]configRevision); ok {
+		return formatHistoryTabular(writer, revisions)
+	}
 	configValues, ok := value.(config.ConfigValues)
 	if !ok {
 		return errors.Errorf("expected value of type %T, got %T", configValues, value)
@@ -413,6 +513,46 @@ func formatConfigTabular(writer io.Writer, value interface{}) error {
 	return nil
 }
 
+// formatHistoryTabular writes a tabular summary of the model config
+// history, with a line for each setting changed by a revision.
+func formatHistoryTabular(writer io.Writer, revisions []configRevision) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Revision", "Author", "Created", "Attribute", "Value")
+
+	for _, rev := range revisions {
+		var names []string
+		for name := range rev.Changed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		lines := make([][]interface{}, 0, len(names)+len(rev.Unset))
+		for _, name := range names {
+			out := &bytes.Buffer{}
+			if err := cmd.FormatYaml(out, rev.Changed[name]); err != nil {
+				return errors.Annotatef(err, "formatting value for %q", name)
+			}
+			lines = append(lines, []interface{}{name, strings.TrimSuffix(out.String(), "\n")})
+		}
+		for _, name := range rev.Unset {
+			lines = append(lines, []interface{}{name, "<unset>"})
+		}
+		if len(lines) == 0 {
+			lines = append(lines, []interface{}{"", ""})
+		}
+		for i, line := range lines {
+			if i == 0 {
+				w.Println(append([]interface{}{rev.Revision, rev.Author, rev.Created}, line...)...)
+			} else {
+				w.Println(append([]interface{}{"", "", ""}, line...)...)
+			}
+		}
+	}
+
+	tw.Flush()
+	return nil
+}
+
 // modelConfigDetails gets ModelDetails when a model is not available
 // to use.
 func (c *configCommand) modelConfigDetails() (map[string]interface{}, error) {
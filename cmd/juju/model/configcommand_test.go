@@ -5,13 +5,16 @@ package model_test
 import (
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/testing"
 )
@@ -64,6 +67,26 @@ func (s *ConfigCommandSuite) TestInit(c *gc.C) {
 			desc:       "get multiple fails",
 			args:       []string{"one", "two"},
 			errorMatch: "can only retrieve a single value, or all values",
+		}, {
+			desc:   "history succeeds",
+			args:   []string{"--history"},
+			nilErr: true,
+		}, {
+			desc:   "revert succeeds",
+			args:   []string{"--revert", "2"},
+			nilErr: true,
+		}, {
+			desc:       "history and revert together fail",
+			args:       []string{"--history", "--revert", "2"},
+			errorMatch: "cannot specify --history and --revert simultaneously",
+		}, {
+			desc:       "history with other args fails",
+			args:       []string{"--history", "special=foo"},
+			errorMatch: "cannot combine --history or --revert with other arguments",
+		}, {
+			desc:       "revert needs a valid revision",
+			args:       []string{"--revert", "-1"},
+			errorMatch: "invalid revision -1",
 		}, {
 			// test variations
 			desc:   "test reset interspersed",
@@ -295,3 +318,72 @@ func (s *ConfigCommandSuite) TestResetBlockedError(c *gc.C) {
 	_, err := s.run(c, "--reset", "special")
 	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
 }
+
+func (s *ConfigCommandSuite) setHistory() {
+	created := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.fake.history = []params.ModelConfigRevision{{
+		Revision: 1,
+		Config:   map[string]interface{}{"special": "special value", "running": true},
+		Created:  created,
+	}, {
+		Revision: 2,
+		Config:   map[string]interface{}{"special": "extra"},
+		Author:   "bob",
+		Created:  created.Add(time.Hour),
+	}}
+}
+
+func (s *ConfigCommandSuite) TestHistoryYAML(c *gc.C) {
+	s.setHistory()
+	context, err := s.run(c, "--history", "--format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"- revision: 1\n" +
+		"  created: \"2019-01-02T03:04:05Z\"\n" +
+		"  changed:\n" +
+		"    running: true\n" +
+		"    special: special value\n" +
+		"- revision: 2\n" +
+		"  author: bob\n" +
+		"  created: \"2019-01-02T04:04:05Z\"\n" +
+		"  changed:\n" +
+		"    special: extra\n" +
+		"  unset:\n" +
+		"  - running\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestHistoryTabular(c *gc.C) {
+	s.setHistory()
+	context, err := s.run(c, "--history")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"Revision  Author  Created               Attribute  Value\n" +
+		"1                 2019-01-02T03:04:05Z  running    true\n" +
+		"                                        special    special value\n" +
+		"2         bob     2019-01-02T04:04:05Z  special    extra\n" +
+		"                                        running    <unset>\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestRevert(c *gc.C) {
+	_, err := s.run(c, "--revert", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.reverted, gc.Equals, 2)
+}
+
+func (s *ConfigCommandSuite) TestRevertBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "--revert", "2")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
+}
+
+func (s *ConfigCommandSuite) TestRevertNotSupported(c *gc.C) {
+	s.fake.err = errors.NotSupportedf("RevertModelConfig on v2 facade")
+	_, err := s.run(c, "--revert", "2")
+	c.Assert(err, gc.ErrorMatches, "reverting model config is not supported by this controller")
+}
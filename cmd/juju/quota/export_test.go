@@ -0,0 +1,29 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+func NewShowCommandForTest(api QuotaAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &showCommand{}
+	c.newAPIFunc = func() (QuotaAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewSetCommandForTest(api QuotaAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &setCommand{}
+	c.newAPIFunc = func() (QuotaAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
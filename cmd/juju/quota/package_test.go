@@ -0,0 +1,71 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota_test
+
+import (
+	"testing"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func TestAll(t *testing.T) {
+	gc.TestingT(t)
+}
+
+type baseQuotaSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+	api   *mockQuotaAPI
+}
+
+func (s *baseQuotaSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.api = &mockQuotaAPI{
+		quota: quota.Quota{quota.Machines: 10, quota.Memory: 64 * 1024},
+		usage: quota.Usage{quota.Machines: 3, quota.Units: 4, quota.Memory: 6 * 1024},
+	}
+}
+
+type mockQuotaAPI struct {
+	jujutesting.Stub
+	quota quota.Quota
+	usage quota.Usage
+}
+
+func (m *mockQuotaAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockQuotaAPI) Quota() (quota.Quota, quota.Usage, error) {
+	m.MethodCall(m, "Quota")
+	if err := m.NextErr(); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return m.quota, m.usage, nil
+}
+
+func (m *mockQuotaAPI) SetQuota(q quota.Quota) error {
+	m.MethodCall(m, "SetQuota", q)
+	return m.NextErr()
+}
@@ -0,0 +1,33 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package quota provides the commands used to inspect and change the
+// resource quota of a model.
+package quota
+
+import (
+	apiquota "github.com/juju/juju/api/quota"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/quota"
+)
+
+// QuotaAPI defines the API methods used by the quota commands.
+type QuotaAPI interface {
+	Close() error
+	Quota() (quota.Quota, quota.Usage, error)
+	SetQuota(quota.Quota) error
+}
+
+// quotaCommandBase is the base for the quota commands.
+type quotaCommandBase struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (QuotaAPI, error)
+}
+
+func (c *quotaCommandBase) newQuotaAPI() (QuotaAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return apiquota.NewClient(root), nil
+}
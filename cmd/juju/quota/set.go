@@ -0,0 +1,103 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/quota"
+)
+
+const setCommandDoc = `
+Set limits on the resources a model may use.
+
+Each limit is given as <resource>=<value>; a limit with no value is
+removed, and limits that are not mentioned are left unchanged. Memory
+and storage are in MiB unless suffixed with M, G, T or P.
+
+Adding machines, units or storage to the model fails if it would take
+the model over its quota. Resources already in use are not affected
+when a quota is lowered.
+
+Cores and memory are counted from each machine's hardware once it is
+provisioned, and from its constraints until then. While cores or
+memory are limited, machines must be added with cores and mem
+constraints, and the limit cannot be set if the cores or memory of an
+existing machine are not known.
+
+Only controller administrators may change a model's quota.
+
+The resources that may be limited are:
+    machines    the number of top level machines
+    containers  the number of containers
+    units       the number of principal units
+    cores       the total CPU cores of the top level machines
+    memory      the total memory of the top level machines
+    storage     the total size of the volumes and filesystems
+
+Examples:
+    juju set-quota machines=10 cores=40 memory=64G
+    juju set-quota -m mymodel storage=2T units=
+    juju set-quota containers=0
+
+See also:
+    show-quota
+`
+
+// NewSetCommand returns a command that sets the quota of a model.
+func NewSetCommand() cmd.Command {
+	c := &setCommand{}
+	c.newAPIFunc = c.newQuotaAPI
+	return modelcmd.Wrap(c)
+}
+
+// setCommand sets the quota of a model.
+type setCommand struct {
+	quotaCommandBase
+	limits []string
+}
+
+// Info implements cmd.Command.
+func (c *setCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-quota",
+		Args:    "<resource>=[<value>] ...",
+		Purpose: "Sets the resource quota of a model.",
+		Doc:     setCommandDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *setCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no quota limits specified")
+	}
+	// Check the limits are well formed before connecting.
+	if _, err := (quota.Quota{}).Update(args...); err != nil {
+		return errors.Trace(err)
+	}
+	c.limits = args
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *setCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	current, _, err := api.Quota()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	updated, err := current.Update(c.limits...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.SetQuota(updated))
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/quota"
+	corequota "github.com/juju/juju/core/quota"
+)
+
+type SetSuite struct {
+	baseQuotaSuite
+}
+
+var _ = gc.Suite(&SetSuite{})
+
+func (s *SetSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no quota limits specified",
+	}, {
+		args: []string{"machines"},
+		err:  `malformed quota "machines"`,
+	}, {
+		args: []string{"gpus=1"},
+		err:  `unknown quota resource "gpus"`,
+	}, {
+		args: []string{"memory=lots"},
+		err:  `bad "memory" quota: must be a non-negative float with optional M/G/T/P suffix`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, quota.NewSetCommandForTest(s.api, s.store), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *SetSuite) TestSet(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, quota.NewSetCommandForTest(s.api, s.store), "units=20", "machines=", "storage=1T")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Quota", nil},
+		{"SetQuota", []interface{}{corequota.Quota{
+			corequota.Units:   20,
+			corequota.Memory:  64 * 1024,
+			corequota.Storage: 1024 * 1024,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetSuite) TestSetError(c *gc.C) {
+	s.api.SetErrors(nil, errors.New("permission denied"))
+	_, err := cmdtesting.RunCommand(c, quota.NewSetCommandForTest(s.api, s.store), "units=20")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
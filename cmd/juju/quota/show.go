@@ -0,0 +1,126 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/quota"
+)
+
+const showCommandDoc = `
+Show the resource quota of a model, and how much of each resource the
+model is using.
+
+Resources without a limit may be used freely. In yaml and json
+output, memory and storage are given in MiB. See "juju help set-quota"
+for a description of each resource.
+
+Examples:
+    juju show-quota
+    juju show-quota -m mymodel --format yaml
+
+See also:
+    set-quota
+`
+
+// NewShowCommand returns a command that shows the quota of a model.
+func NewShowCommand() cmd.Command {
+	c := &showCommand{}
+	c.newAPIFunc = c.newQuotaAPI
+	return modelcmd.Wrap(c)
+}
+
+// showCommand shows the quota of a model.
+type showCommand struct {
+	quotaCommandBase
+	out cmd.Output
+}
+
+// Info implements cmd.Command.
+func (c *showCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-quota",
+		Purpose: "Shows the resource quota of a model.",
+		Doc:     showCommandDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *showCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.quotaCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *showCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *showCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	limits, usage, err := api.Quota()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatQuota(limits, usage))
+}
+
+// resourceDetails is the serialization of the quota and usage of a
+// resource.
+type resourceDetails struct {
+	Used  uint64  `yaml:"used" json:"used"`
+	Limit *uint64 `yaml:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// formatQuota returns the quota and usage of each resource, keyed on
+// resource name.
+func formatQuota(limits quota.Quota, usage quota.Usage) map[string]resourceDetails {
+	result := make(map[string]resourceDetails, len(quota.Resources))
+	for _, resource := range quota.Resources {
+		details := resourceDetails{Used: usage[resource]}
+		if limit, ok := limits[resource]; ok {
+			details.Limit = &limit
+		}
+		result[resource] = details
+	}
+	return result
+}
+
+func formatTabular(writer io.Writer, value interface{}) error {
+	resources, ok := value.(map[string]resourceDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", resources, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Resource\tUsed\tLimit")
+	for _, resource := range quota.Resources {
+		details := resources[resource]
+		limit := "-"
+		if details.Limit != nil {
+			limit = quota.FormatValue(resource, *details.Limit)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n",
+			resource, quota.FormatValue(resource, details.Used), limit,
+		)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/quota"
+)
+
+type ShowSuite struct {
+	baseQuotaSuite
+}
+
+var _ = gc.Suite(&ShowSuite{})
+
+func (s *ShowSuite) TestInitErrors(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, quota.NewShowCommandForTest(s.api, s.store), "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ShowSuite) TestShowTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, quota.NewShowCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Resource    Used  Limit
+machines    3     10
+containers  0     -
+units       4     -
+cores       0     -
+memory      6G    64G
+storage     0M    -
+`[1:])
+	s.api.CheckCallNames(c, "Quota", "Close")
+}
+
+func (s *ShowSuite) TestShowYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, quota.NewShowCommandForTest(s.api, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+containers:
+  used: 0
+cores:
+  used: 0
+machines:
+  used: 3
+  limit: 10
+memory:
+  used: 6144
+  limit: 65536
+storage:
+  used: 0
+units:
+  used: 4
+`[1:])
+}
+
+func (s *ShowSuite) TestShowError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, quota.NewShowCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,29 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+func NewListCommandForTest(api SecretsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listCommand{}
+	c.newAPIFunc = func() (SecretsAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewShowCommandForTest(api SecretsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &showCommand{}
+	c.newAPIFunc = func() (SecretsAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
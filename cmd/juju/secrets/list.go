@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const listCommandDoc = `
+List the secrets stored by the charms in the model.
+
+Secret values are never displayed by this command; use show-secret
+with the --reveal option to see the value of a secret.
+
+Examples:
+    juju secrets
+    juju secrets --format yaml
+`
+
+// NewListCommand returns a command that lists the secrets in a model.
+func NewListCommand() cmd.Command {
+	c := &listCommand{}
+	c.newAPIFunc = c.newSecretsAPI
+	return modelcmd.Wrap(c)
+}
+
+// listCommand lists the secrets in a model.
+type listCommand struct {
+	secretsCommandBase
+	out     cmd.Output
+	isoTime bool
+}
+
+// Info implements cmd.Command.
+func (c *listCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secrets",
+		Purpose: "Lists the secrets in a model.",
+		Doc:     listCommandDoc,
+		Aliases: []string{"list-secrets"},
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.secretsCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	result, err := api.ListSecrets(false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No secrets to display.")
+		return nil
+	}
+	return c.out.Write(ctx, formatSecrets(result))
+}
+
+func (c *listCommand) formatTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.(map[string]secretDisplayDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	ids := make([]string, 0, len(secrets))
+	for id := range secrets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "ID\tRevision\tConsumers\tLast updated")
+	for _, id := range ids {
+		s := secrets[id]
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n",
+			id, s.Revision, strings.Join(s.Consumers, ", "),
+			common.FormatTime(&s.Updated, c.isoTime),
+		)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apisecrets "github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/cmd/juju/secrets"
+	coresecrets "github.com/juju/juju/core/secrets"
+)
+
+type ListSuite struct {
+	baseSecretsSuite
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.baseSecretsSuite.SetUpTest(c)
+	created := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.api.secrets = []apisecrets.SecretDetails{{
+		Metadata: coresecrets.Metadata{
+			ID:         "secret:mysql/password",
+			Owner:      "mysql",
+			Name:       "password",
+			Revision:   2,
+			CreateTime: created,
+			UpdateTime: created.Add(time.Hour),
+			Consumers: []coresecrets.Consumer{{
+				Application: "wordpress",
+				RelationKey: "wordpress:db mysql:server",
+			}},
+		},
+	}, {
+		Metadata: coresecrets.Metadata{
+			ID:          "secret:mysql/apikey",
+			Owner:       "mysql",
+			Name:        "apikey",
+			Description: "backup service key",
+			Revision:    1,
+			CreateTime:  created,
+			UpdateTime:  created,
+		},
+	}}
+}
+
+func (s *ListSuite) TestInitErrors(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(s.api, s.store), "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ListSuite) TestListTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(s.api, s.store), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ID                     Revision  Consumers                              Last updated
+secret:mysql/apikey    1                                                2018-01-02 03:04:05Z
+secret:mysql/password  2         wordpress (wordpress:db mysql:server)  2018-01-02 04:04:05Z
+`[1:])
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"ListSecrets", []interface{}{false, []string(nil)}},
+		{"Close", nil},
+	})
+}
+
+func (s *ListSuite) TestListYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(s.api, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+secret:mysql/apikey:
+  owner: mysql
+  name: apikey
+  description: backup service key
+  revision: 1
+  created: 2018-01-02T03:04:05Z
+  updated: 2018-01-02T03:04:05Z
+secret:mysql/password:
+  owner: mysql
+  name: password
+  revision: 2
+  consumers:
+  - wordpress (wordpress:db mysql:server)
+  created: 2018-01-02T03:04:05Z
+  updated: 2018-01-02T04:04:05Z
+`[1:])
+}
+
+func (s *ListSuite) TestListEmpty(c *gc.C) {
+	s.api.secrets = nil
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No secrets to display.\n")
+}
+
+func (s *ListSuite) TestListError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"testing"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	apisecrets "github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func TestAll(t *testing.T) {
+	gc.TestingT(t)
+}
+
+type baseSecretsSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+	api   *mockSecretsAPI
+}
+
+func (s *baseSecretsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.api = &mockSecretsAPI{}
+}
+
+type mockSecretsAPI struct {
+	jujutesting.Stub
+	secrets []apisecrets.SecretDetails
+}
+
+func (m *mockSecretsAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockSecretsAPI) ListSecrets(showSecrets bool, ids ...string) ([]apisecrets.SecretDetails, error) {
+	m.MethodCall(m, "ListSecrets", showSecrets, ids)
+	if err := m.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.secrets, nil
+}
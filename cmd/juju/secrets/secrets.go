@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the commands used to inspect the secrets
+// that charms have stored in a model.
+package secrets
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	apisecrets "github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// SecretsAPI defines the API methods used by the secrets commands.
+type SecretsAPI interface {
+	Close() error
+	ListSecrets(showSecrets bool, ids ...string) ([]apisecrets.SecretDetails, error)
+}
+
+// secretsCommandBase is the base for the secrets commands.
+type secretsCommandBase struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (SecretsAPI, error)
+}
+
+func (c *secretsCommandBase) newSecretsAPI() (SecretsAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return apisecrets.NewClient(root), nil
+}
+
+// secretDisplayDetails is the serialization of a secret's details.
+type secretDisplayDetails struct {
+	Owner       string            `yaml:"owner" json:"owner"`
+	Name        string            `yaml:"name" json:"name"`
+	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Revision    int               `yaml:"revision" json:"revision"`
+	Consumers   []string          `yaml:"consumers,omitempty" json:"consumers,omitempty"`
+	Created     time.Time         `yaml:"created" json:"created"`
+	Updated     time.Time         `yaml:"updated" json:"updated"`
+	Value       map[string]string `yaml:"value,omitempty" json:"value,omitempty"`
+	Error       string            `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// formatSecrets returns the details of the given secrets, keyed on
+// secret ID.
+func formatSecrets(all []apisecrets.SecretDetails) map[string]secretDisplayDetails {
+	result := make(map[string]secretDisplayDetails, len(all))
+	for _, d := range all {
+		details := secretDisplayDetails{
+			Owner:       d.Metadata.Owner,
+			Name:        d.Metadata.Name,
+			Description: d.Metadata.Description,
+			Revision:    d.Metadata.Revision,
+			Created:     d.Metadata.CreateTime,
+			Updated:     d.Metadata.UpdateTime,
+			Value:       d.Value,
+		}
+		for _, consumer := range d.Metadata.Consumers {
+			details.Consumers = append(details.Consumers,
+				fmt.Sprintf("%s (%s)", consumer.Application, consumer.RelationKey))
+		}
+		sort.Strings(details.Consumers)
+		if d.Error != nil {
+			details.Error = d.Error.Error()
+		}
+		result[d.Metadata.ID] = details
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/secrets"
+)
+
+const showCommandDoc = `
+Show the details of a secret.
+
+The secret's value is only displayed if --reveal is specified, which
+requires admin access to the model.
+
+Examples:
+    juju show-secret secret:mysql/password
+    juju show-secret secret:mysql/password --reveal
+`
+
+// NewShowCommand returns a command that shows the details of a secret.
+func NewShowCommand() cmd.Command {
+	c := &showCommand{}
+	c.newAPIFunc = c.newSecretsAPI
+	return modelcmd.Wrap(c)
+}
+
+// showCommand shows the details of a secret.
+type showCommand struct {
+	secretsCommandBase
+	out    cmd.Output
+	id     string
+	reveal bool
+}
+
+// Info implements cmd.Command.
+func (c *showCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-secret",
+		Args:    "<ID>",
+		Purpose: "Shows the details of a secret.",
+		Doc:     showCommandDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *showCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.secretsCommandBase.SetFlags(f)
+	f.BoolVar(&c.reveal, "reveal", false, "Include the secret value in the output")
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *showCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret ID specified")
+	}
+	c.id = args[0]
+	if _, _, err := secrets.ParseID(c.id); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *showCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	result, err := api.ListSecrets(c.reveal, c.id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result) == 0 {
+		return errors.NotFoundf("secret %q", c.id)
+	}
+	return c.out.Write(ctx, formatSecrets(result))
+}
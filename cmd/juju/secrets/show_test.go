@@ -0,0 +1,105 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apisecrets "github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/cmd/juju/secrets"
+	coresecrets "github.com/juju/juju/core/secrets"
+)
+
+type ShowSuite struct {
+	baseSecretsSuite
+}
+
+var _ = gc.Suite(&ShowSuite{})
+
+func (s *ShowSuite) SetUpTest(c *gc.C) {
+	s.baseSecretsSuite.SetUpTest(c)
+	created := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.api.secrets = []apisecrets.SecretDetails{{
+		Metadata: coresecrets.Metadata{
+			ID:         "secret:mysql/password",
+			Owner:      "mysql",
+			Name:       "password",
+			Revision:   2,
+			CreateTime: created,
+			UpdateTime: created,
+		},
+	}}
+}
+
+func (s *ShowSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret ID specified",
+	}, {
+		args: []string{"password"},
+		err:  `secret ID "password" not valid`,
+	}, {
+		args: []string{"secret:mysql/password", "foo"},
+		err:  `unrecognized args: \["foo"\]`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, secrets.NewShowCommandForTest(s.api, s.store), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ShowSuite) TestShow(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewShowCommandForTest(s.api, s.store), "secret:mysql/password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+secret:mysql/password:
+  owner: mysql
+  name: password
+  revision: 2
+  created: 2018-01-02T03:04:05Z
+  updated: 2018-01-02T03:04:05Z
+`[1:])
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"ListSecrets", []interface{}{false, []string{"secret:mysql/password"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ShowSuite) TestShowReveal(c *gc.C) {
+	s.api.secrets[0].Value = coresecrets.Value{"pass": "s3cret"}
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewShowCommandForTest(s.api, s.store), "secret:mysql/password", "--reveal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+secret:mysql/password:
+  owner: mysql
+  name: password
+  revision: 2
+  created: 2018-01-02T03:04:05Z
+  updated: 2018-01-02T03:04:05Z
+  value:
+    pass: s3cret
+`[1:])
+	s.api.CheckCall(c, 0, "ListSecrets", true, []string{"secret:mysql/password"})
+}
+
+func (s *ShowSuite) TestShowValueError(c *gc.C) {
+	s.api.secrets[0].Error = errors.New("permission denied")
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewShowCommandForTest(s.api, s.store), "secret:mysql/password", "--reveal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "error: permission denied\n")
+}
+
+func (s *ShowSuite) TestShowError(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf(`secret "secret:mysql/password"`))
+	_, err := cmdtesting.RunCommand(c, secrets.NewShowCommandForTest(s.api, s.store), "secret:mysql/password")
+	c.Assert(err, gc.ErrorMatches, `secret "secret:mysql/password" not found`)
+}
@@ -33,16 +33,24 @@ type errorStatus struct {
 }
 
 type modelStatus struct {
-	Name             string             `json:"name" yaml:"name"`
-	Type             string             `json:"type" yaml:"type"`
-	Controller       string             `json:"controller" yaml:"controller"`
-	Cloud            string             `json:"cloud" yaml:"cloud"`
-	CloudRegion      string             `json:"region,omitempty" yaml:"region,omitempty"`
-	Version          string             `json:"version" yaml:"version"`
-	AvailableVersion string             `json:"upgrade-available,omitempty" yaml:"upgrade-available,omitempty"`
-	Status           statusInfoContents `json:"model-status,omitempty" yaml:"model-status,omitempty"`
-	MeterStatus      *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
-	SLA              string             `json:"sla,omitempty" yaml:"sla,omitempty"`
+	Name             string                 `json:"name" yaml:"name"`
+	Type             string                 `json:"type" yaml:"type"`
+	Controller       string                 `json:"controller" yaml:"controller"`
+	Cloud            string                 `json:"cloud" yaml:"cloud"`
+	CloudRegion      string                 `json:"region,omitempty" yaml:"region,omitempty"`
+	Version          string                 `json:"version" yaml:"version"`
+	AvailableVersion string                 `json:"upgrade-available,omitempty" yaml:"upgrade-available,omitempty"`
+	Status           statusInfoContents     `json:"model-status,omitempty" yaml:"model-status,omitempty"`
+	MeterStatus      *meterStatus           `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
+	SLA              string                 `json:"sla,omitempty" yaml:"sla,omitempty"`
+	Quota            map[string]quotaStatus `json:"quota,omitempty" yaml:"quota,omitempty"`
+}
+
+// quotaStatus holds the limit on a resource in a model's quota, and
+// how much of it is in use. Memory and storage are measured in MiB.
+type quotaStatus struct {
+	Used  uint64 `json:"used" yaml:"used"`
+	Limit uint64 `json:"limit" yaml:"limit"`
 }
 
 type controllerStatus struct {
@@ -93,25 +101,43 @@ func (s machineStatus) MarshalYAML() (interface{}, error) {
 }
 
 type applicationStatus struct {
-	Err              error                 `json:"-" yaml:",omitempty"`
-	Charm            string                `json:"charm" yaml:"charm"`
-	Series           string                `json:"series"`
-	OS               string                `json:"os"`
-	CharmOrigin      string                `json:"charm-origin" yaml:"charm-origin"`
-	CharmName        string                `json:"charm-name" yaml:"charm-name"`
-	CharmRev         int                   `json:"charm-rev" yaml:"charm-rev"`
-	CharmVersion     string                `json:"charm-version,omitempty" yaml:"charm-version,omitempty"`
-	CanUpgradeTo     string                `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
-	ProviderId       string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
-	Address          string                `json:"address,omitempty" yaml:"address,omitempty"`
-	Exposed          bool                  `json:"exposed" yaml:"exposed"`
-	Life             string                `json:"life,omitempty" yaml:"life,omitempty"`
-	StatusInfo       statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
-	Relations        map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
-	SubordinateTo    []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
-	Units            map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
-	Version          string                `json:"version,omitempty" yaml:"version,omitempty"`
-	EndpointBindings map[string]string     `json:"endpoint-bindings,omitempty" yaml:"endpoint-bindings,omitempty"`
+	Err              error                      `json:"-" yaml:",omitempty"`
+	Charm            string                     `json:"charm" yaml:"charm"`
+	Series           string                     `json:"series"`
+	OS               string                     `json:"os"`
+	CharmOrigin      string                     `json:"charm-origin" yaml:"charm-origin"`
+	CharmName        string                     `json:"charm-name" yaml:"charm-name"`
+	CharmRev         int                        `json:"charm-rev" yaml:"charm-rev"`
+	CharmVersion     string                     `json:"charm-version,omitempty" yaml:"charm-version,omitempty"`
+	CanUpgradeTo     string                     `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
+	ProviderId       string                     `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
+	Address          string                     `json:"address,omitempty" yaml:"address,omitempty"`
+	Exposed          bool                       `json:"exposed" yaml:"exposed"`
+	ExposedEndpoints map[string]exposedEndpoint `json:"exposed-endpoints,omitempty" yaml:"exposed-endpoints,omitempty"`
+	CharmRollout     *charmRollout              `json:"charm-rollout,omitempty" yaml:"charm-rollout,omitempty"`
+	Life             string                     `json:"life,omitempty" yaml:"life,omitempty"`
+	StatusInfo       statusInfoContents         `json:"application-status,omitempty" yaml:"application-status"`
+	Relations        map[string][]string        `json:"relations,omitempty" yaml:"relations,omitempty"`
+	SubordinateTo    []string                   `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
+	Units            map[string]unitStatus      `json:"units,omitempty" yaml:"units,omitempty"`
+	Version          string                     `json:"version,omitempty" yaml:"version,omitempty"`
+	EndpointBindings map[string]string          `json:"endpoint-bindings,omitempty" yaml:"endpoint-bindings,omitempty"`
+}
+
+// exposedEndpoint holds who may access the ports of an endpoint of an
+// exposed application.
+type exposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty" yaml:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty" yaml:"expose-to-cidrs,omitempty"`
+}
+
+// charmRollout holds details of a charm being rolled out to an
+// application's units.
+type charmRollout struct {
+	Charm          string `json:"charm" yaml:"charm"`
+	CharmRev       int    `json:"charm-rev" yaml:"charm-rev"`
+	MaxUnavailable int    `json:"max-unavailable,omitempty" yaml:"max-unavailable,omitempty"`
+	Halted         bool   `json:"halted,omitempty" yaml:"halted,omitempty"`
 }
 
 type applicationStatusNoMarshal applicationStatus
@@ -200,6 +226,8 @@ type unitStatus struct {
 
 	Leader        bool                  `json:"leader,omitempty" yaml:"leader,omitempty"`
 	Charm         string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
+	RolloutCharm  string                `json:"charm,omitempty" yaml:"charm,omitempty"`
+	UpgradingTo   string                `json:"upgrading-to,omitempty" yaml:"upgrading-to,omitempty"`
 	Machine       string                `json:"machine,omitempty" yaml:"machine,omitempty"`
 	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
 	PublicAddress string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
@@ -74,6 +74,15 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 		Offers:             make(map[string]offerStatus),
 		Relations:          make([]relationStatus, len(sf.relations)),
 	}
+	if q := sf.status.Model.Quota; q != nil {
+		out.Model.Quota = make(map[string]quotaStatus, len(q.Limits))
+		for resource, limit := range q.Limits {
+			out.Model.Quota[resource] = quotaStatus{
+				Used:  q.Usage[resource],
+				Limit: limit,
+			}
+		}
+	}
 	if sf.status.Model.MeterStatus.Color != "" {
 		out.Model.MeterStatus = &meterStatus{
 			Color:   sf.status.Model.MeterStatus.Color,
@@ -220,12 +229,34 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		Version:          application.WorkloadVersion,
 		EndpointBindings: application.EndpointBindings,
 	}
+	if len(application.ExposedEndpoints) > 0 {
+		out.ExposedEndpoints = make(map[string]exposedEndpoint, len(application.ExposedEndpoints))
+		for name, ep := range application.ExposedEndpoints {
+			out.ExposedEndpoints[name] = exposedEndpoint{
+				ExposeToSpaces: ep.ExposeToSpaces,
+				ExposeToCIDRs:  ep.ExposeToCIDRs,
+			}
+		}
+	}
+	var rolloutCharm string
+	if rollout := application.CharmRollout; rollout != nil {
+		rolloutCharm = rollout.Charm
+		out.CharmRollout = &charmRollout{
+			Charm:          rollout.Charm,
+			MaxUnavailable: rollout.MaxUnavailable,
+			Halted:         rollout.Halted,
+		}
+		if curl, err := charm.ParseURL(rollout.Charm); err == nil {
+			out.CharmRollout.CharmRev = curl.Revision
+		}
+	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
 			unit:            m,
 			unitName:        k,
 			applicationName: name,
 			meterStatuses:   application.MeterStatuses,
+			rolloutCharm:    rolloutCharm,
 		})
 	}
 
@@ -335,6 +366,7 @@ type unitFormatInfo struct {
 	unitName        string
 	applicationName string
 	meterStatuses   map[string]params.MeterStatus
+	rolloutCharm    string
 }
 
 func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
@@ -350,9 +382,16 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		Address:            info.unit.Address,
 		PublicAddress:      info.unit.PublicAddress,
 		Charm:              info.unit.Charm,
+		UpgradingTo:        info.unit.TargetCharm,
 		Subordinates:       make(map[string]unitStatus),
 		Leader:             info.unit.Leader,
 	}
+	// A unit running the charm being rolled out to its application
+	// isn't upgrading from that charm.
+	if info.rolloutCharm != "" && out.Charm == info.rolloutCharm {
+		out.Charm = ""
+		out.RolloutCharm = info.rolloutCharm
+	}
 
 	if ms, ok := info.meterStatuses[info.unitName]; ok {
 		out.MeterStatus = &meterStatus{
@@ -19,6 +19,7 @@ import (
 	cmdcrossmodel "github.com/juju/juju/cmd/juju/crossmodel"
 	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/status"
@@ -61,6 +62,10 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		header = append(header, "SLA")
 		values = append(values, fs.Model.SLA)
 	}
+	if len(fs.Model.Quota) > 0 {
+		header = append(header, "Quota")
+		values = append(values, formatQuota(fs.Model.Quota))
+	}
 	if cs := fs.Controller; cs != nil && cs.Timestamp != "" {
 		header = append(header, "Timestamp")
 		values = append(values, cs.Timestamp)
@@ -142,10 +147,16 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 		if len(version) > maxVersionWidth {
 			version = version[:truncatedWidth] + ellipsis
 		}
-		// Notes may well contain other things later.
-		notes := ""
+		var notes []string
 		if app.Exposed {
-			notes = "exposed"
+			notes = append(notes, "exposed")
+		}
+		if rollout := app.CharmRollout; rollout != nil {
+			if rollout.Halted {
+				notes = append(notes, fmt.Sprintf("upgrade to rev %d halted", rollout.CharmRev))
+			} else {
+				notes = append(notes, fmt.Sprintf("upgrading to rev %d", rollout.CharmRev))
+			}
 		}
 		w.Print(appName, version)
 		w.PrintStatus(app.StatusInfo.Current)
@@ -171,7 +182,7 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 			w.Print(charmVersion)
 		}
 
-		w.Println(notes)
+		w.Println(strings.Join(notes, ", "))
 		for un, u := range app.Units {
 			units[un] = u
 			if u.MeterStatus != nil {
@@ -368,6 +379,22 @@ func getModelMessage(model modelStatus) string {
 	}
 }
 
+// formatQuota returns a summary of the model's quota usage, in the
+// form "machines=3/10 memory=6G/64G".
+func formatQuota(q map[string]quotaStatus) string {
+	var parts []string
+	for _, resource := range quota.Resources {
+		if qs, ok := q[resource]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%s/%s",
+				resource,
+				quota.FormatValue(resource, qs.Used),
+				quota.FormatValue(resource, qs.Limit),
+			))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
 func printMachines(tw *ansiterm.TabWriter, standAlone bool, machines map[string]machineStatus) {
 	w := startSection(tw, standAlone, "Machine", "State", "DNS", "Inst id", "Series", "AZ", "Message")
 	for _, name := range naturalsort.Sort(stringKeysFromMap(machines)) {
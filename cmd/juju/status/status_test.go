@@ -4762,6 +4762,44 @@ foo/1  maintenance  executing                                  (backup database)
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatCharmRollout(c *gc.C) {
+	fullStatus := &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"foo": {
+				Charm: "cs:quantal/foo-1",
+				CharmRollout: &params.CharmRolloutStatus{
+					Charm:  "cs:quantal/foo-2",
+					Halted: true,
+				},
+				Units: map[string]params.UnitStatus{
+					"foo/0": {Charm: "cs:quantal/foo-2"},
+					"foo/1": {TargetCharm: "cs:quantal/foo-2"},
+					"foo/2": {},
+				},
+			},
+		},
+	}
+	app := NewStatusFormatter(fullStatus, false).formatApplication("foo", fullStatus.Applications["foo"])
+	c.Assert(app.CharmRollout, jc.DeepEquals, &charmRollout{
+		Charm:    "cs:quantal/foo-2",
+		CharmRev: 2,
+		Halted:   true,
+	})
+	c.Check(app.Units["foo/0"].Charm, gc.Equals, "")
+	c.Check(app.Units["foo/0"].RolloutCharm, gc.Equals, "cs:quantal/foo-2")
+	c.Check(app.Units["foo/1"].UpgradingTo, gc.Equals, "cs:quantal/foo-2")
+	c.Check(app.Units["foo/2"].Charm, gc.Equals, "")
+	c.Check(app.Units["foo/2"].RolloutCharm, gc.Equals, "")
+	c.Check(app.Units["foo/2"].UpgradingTo, gc.Equals, "")
+
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, formattedStatus{
+		Applications: map[string]applicationStatus{"foo": app},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), jc.Contains, "upgrade to rev 2 halted\n")
+}
+
 func (s *StatusSuite) TestFormatTabularHookActionName(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
@@ -4924,6 +4962,33 @@ foo/1   up            things are looking up
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatQuota(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+			Quota: &params.ModelQuota{
+				Limits: map[string]uint64{"machines": 10, "memory": 65536},
+				Usage:  map[string]uint64{"machines": 3, "memory": 6144, "units": 4},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(formatted.Model.Quota, jc.DeepEquals, map[string]quotaStatus{
+		"machines": {Used: 3, Limit: 10},
+		"memory":   {Used: 6144, Limit: 65536},
+	})
+
+	out := &bytes.Buffer{}
+	err = FormatTabular(out, false, formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version  Quota
+                   dummy                  machines=3/10 memory=6G/64G
+`[1:])
+}
+
 //
 // Filtering Feature
 //
@@ -5177,6 +5242,28 @@ func (s *StatusSuite) TestFilterToExposedApplication(c *gc.C) {
 	c.Assert(string(stdout), gc.Equals, expected[1:])
 }
 
+func (s *StatusSuite) TestStatusExposedEndpoints(c *gc.C) {
+	ctx := s.FilteringTestSetup(c)
+	defer s.resetContext(c, ctx)
+
+	app, err := ctx.st.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, stdout, stderr := runStatus(c, "--format", "yaml", "mysql")
+	c.Assert(stderr, gc.IsNil)
+	c.Assert(string(stdout), jc.Contains, `
+    exposed: true
+    exposed-endpoints:
+      server:
+        expose-to-cidrs:
+        - 10.0.0.0/24
+`)
+}
+
 // Scenario: User filters to non-exposed applications
 func (s *StatusSuite) TestFilterToNotExposedApplication(c *gc.C) {
 	ctx := s.FilteringTestSetup(c)
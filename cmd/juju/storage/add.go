@@ -38,7 +38,7 @@ A storage directive consists of a storage name as per charm specification
 and storage constraints, e.g. pool, count, size.
 
 The acceptable format for storage constraints is a comma separated
-sequence of: POOL, COUNT, and SIZE, where
+sequence of: POOL, COUNT, SIZE and SNAPSHOT, where
 
     POOL identifies the storage pool. POOL can be a string
     starting with a letter, followed by zero or more digits
@@ -53,6 +53,11 @@ sequence of: POOL, COUNT, and SIZE, where
     the set (M, G, T, P, E, Z, Y), which are all treated as
     powers of 1024.
 
+    SNAPSHOT is "snapshot:" followed by the ID of a volume snapshot,
+    as shown by juju storage-snapshots. The storage instances are
+    created from the snapshot, in the snapshot's pool, and are at
+    least as large as the volume the snapshot was taken of.
+
 Storage constraints can be optionally omitted.
 Model default values will be used for all omitted constraint values.
 There is no need to comma-separate omitted constraints. 
@@ -73,6 +78,12 @@ Examples:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+
+    # Add 1 storage instance for "data" storage to unit u/0,
+    # restored from the volume snapshot with ID 0/2:
+
+      juju add-storage u/0 data=snapshot:0/2
 `
 	addCommandAgs = `<unit name> <charm storage name>[=<storage constraints>]`
 )
@@ -190,6 +201,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 				cons.Pool,
 				&cons.Size,
 				&cons.Count,
+				cons.Snapshot,
 			},
 		})
 	}
@@ -4,12 +4,16 @@
 package storage
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/storage"
 )
 
 // NewAttachStorageCommandWithAPI returns a command
@@ -35,8 +39,17 @@ const (
 Attach existing storage to a unit. Specify a unit
 and one or more storage IDs to attach to it.
 
+Instead of a storage ID, "snapshot:" followed by the ID of a
+volume snapshot, as shown by juju storage-snapshots, may be
+specified. New storage is created from the snapshot, for the
+same charm store as the storage the snapshot was taken of,
+and attached to the unit.
+
 Examples:
     juju attach-storage postgresql/1 pgdata/0
+
+    # Attach storage restored from the volume snapshot with ID 0/2:
+    juju attach-storage postgresql/1 snapshot:0/2
 `
 
 	attachStorageCommandArgs = `<unit> <storage> [<storage> ...]`
@@ -49,15 +62,30 @@ type attachStorageCommand struct {
 	newEntityAttacherCloser NewEntityAttacherCloserFunc
 	unitId                  string
 	storageIds              []string
+	snapshotIds             []string
 }
 
+// snapshotPrefix is the prefix of an argument that identifies a
+// volume snapshot from which to create the storage to attach.
+const snapshotPrefix = "snapshot:"
+
 // Init implements Command.Init.
 func (c *attachStorageCommand) Init(args []string) error {
 	if len(args) < 2 {
 		return errors.New("attach-storage requires a unit ID and at least one storage ID")
 	}
 	c.unitId = args[0]
-	c.storageIds = args[1:]
+	for _, arg := range args[1:] {
+		if !strings.HasPrefix(arg, snapshotPrefix) {
+			c.storageIds = append(c.storageIds, arg)
+			continue
+		}
+		id := arg[len(snapshotPrefix):]
+		if !storage.IsValidSnapshotId(id) {
+			return errors.NotValidf("snapshot ID %q", id)
+		}
+		c.snapshotIds = append(c.snapshotIds, id)
+	}
 	return nil
 }
 
@@ -79,24 +107,36 @@ func (c *attachStorageCommand) Run(ctx *cmd.Context) error {
 	}
 	defer attacher.Close()
 
-	results, err := attacher.Attach(c.unitId, c.storageIds)
-	if err != nil {
-		if params.IsCodeUnauthorized(err) {
-			common.PermissionsMessage(ctx.Stderr, "attach storage")
+	var anyFailed bool
+	if len(c.storageIds) > 0 {
+		results, err := attacher.Attach(c.unitId, c.storageIds)
+		if err != nil {
+			if params.IsCodeUnauthorized(err) {
+				common.PermissionsMessage(ctx.Stderr, "attach storage")
+			}
+			return errors.Trace(err)
 		}
-		return errors.Trace(err)
-	}
-	for i, result := range results {
-		if result.Error == nil {
-			ctx.Infof("attaching %s to %s", c.storageIds[i], c.unitId)
+		for i, result := range results {
+			if result.Error == nil {
+				ctx.Infof("attaching %s to %s", c.storageIds[i], c.unitId)
+			}
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				ctx.Infof("failed to attach %s to %s: %s", c.storageIds[i], c.unitId, result.Error)
+				anyFailed = true
+			}
 		}
 	}
-	var anyFailed bool
-	for i, result := range results {
-		if result.Error != nil {
-			ctx.Infof("failed to attach %s to %s: %s", c.storageIds[i], c.unitId, result.Error)
-			anyFailed = true
+	if len(c.snapshotIds) > 0 {
+		failed, err := c.attachSnapshots(ctx, attacher)
+		if err != nil {
+			if params.IsCodeUnauthorized(err) {
+				common.PermissionsMessage(ctx.Stderr, "attach storage")
+			}
+			return errors.Trace(err)
 		}
+		anyFailed = anyFailed || failed
 	}
 	if anyFailed {
 		return cmd.ErrSilent
@@ -104,6 +144,87 @@ func (c *attachStorageCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// attachSnapshots creates storage from each of the command's volume
+// snapshots, and attaches it to the unit. The storage is created for
+// the charm store of the storage instance the snapshot was taken of.
+// attachSnapshots reports whether any of the snapshots failed to be
+// restored and attached.
+func (c *attachStorageCommand) attachSnapshots(ctx *cmd.Context, attacher EntityAttacher) (anyFailed bool, _ error) {
+	if !names.IsValidUnit(c.unitId) {
+		return false, errors.NotValidf("unit ID %q", c.unitId)
+	}
+	snapshots, err := attacher.ListSnapshots(nil)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	snapshotsById := make(map[string]params.VolumeSnapshotDetails)
+	for _, snapshot := range snapshots {
+		snapshotsById[snapshot.Id] = snapshot
+	}
+
+	count := uint64(1)
+	var args []params.StorageAddParams
+	var argSnapshotIds []string
+	for _, id := range c.snapshotIds {
+		snapshot, ok := snapshotsById[id]
+		if !ok {
+			ctx.Infof("failed to attach snapshot %s to %s: snapshot not found", id, c.unitId)
+			anyFailed = true
+			continue
+		}
+		if snapshot.StorageTag == "" {
+			ctx.Infof("failed to attach snapshot %s to %s: snapshot not taken of storage", id, c.unitId)
+			anyFailed = true
+			continue
+		}
+		storageTag, err := names.ParseStorageTag(snapshot.StorageTag)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		storageName, err := names.StorageName(storageTag.Id())
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		args = append(args, params.StorageAddParams{
+			UnitTag:     names.NewUnitTag(c.unitId).String(),
+			StorageName: storageName,
+			Constraints: params.StorageConstraints{
+				Count:    &count,
+				Snapshot: id,
+			},
+		})
+		argSnapshotIds = append(argSnapshotIds, id)
+	}
+	if len(args) == 0 {
+		return anyFailed, nil
+	}
+
+	results, err := attacher.AddToUnit(args)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for i, result := range results {
+		id := argSnapshotIds[i]
+		if result.Error != nil {
+			ctx.Infof("failed to attach snapshot %s to %s: %s", id, c.unitId, result.Error)
+			anyFailed = true
+			continue
+		}
+		if result.Result == nil {
+			ctx.Infof("attaching storage restored from snapshot %s to %s", id, c.unitId)
+			continue
+		}
+		for _, tagString := range result.Result.StorageTags {
+			tag, err := names.ParseStorageTag(tagString)
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			ctx.Infof("attaching %s restored from snapshot %s to %s", tag.Id(), id, c.unitId)
+		}
+	}
+	return anyFailed, nil
+}
+
 // NewEntityAttacherCloser is the type of a function that returns an
 // EntityAttacherCloser.
 type NewEntityAttacherCloserFunc func() (EntityAttacherCloser, error)
@@ -115,7 +236,10 @@ type EntityAttacherCloser interface {
 }
 
 // EntityAttacher defines an interface for attaching storage with the
-// specified IDs to a unit.
+// specified IDs to a unit, and for attaching storage created from
+// volume snapshots to a unit.
 type EntityAttacher interface {
 	Attach(string, []string) ([]params.ErrorResult, error)
+	ListSnapshots([]string) ([]params.VolumeSnapshotDetails, error)
+	AddToUnit([]params.StorageAddParams) ([]params.AddStorageResult, error)
 }
@@ -64,9 +64,56 @@ You may ask an administrator to grant you access with "juju grant".
 `)
 }
 
+func (s *AttachStorageSuite) TestAttachSnapshot(c *gc.C) {
+	fake := fakeEntityAttacher{
+		results: []params.ErrorResult{{}},
+		snapshots: []params.VolumeSnapshotDetails{
+			{Id: "0/2", StorageTag: "storage-pgdata-0"},
+			{Id: "3"},
+		},
+		addResults: []params.AddStorageResult{{
+			Result: &params.AddStorageDetails{StorageTags: []string{"storage-pgdata-4"}},
+		}},
+	}
+	attachCmd := storage.NewAttachStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, attachCmd, "foo/0", "bar/1", "snapshot:0/2", "snapshot:3", "snapshot:4")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	fake.CheckCallNames(c, "NewEntityAttacherCloser", "Attach", "ListSnapshots", "AddToUnit", "Close")
+	fake.CheckCall(c, 1, "Attach", "foo/0", []string{"bar/1"})
+	count := uint64(1)
+	fake.CheckCall(c, 3, "AddToUnit", []params.StorageAddParams{{
+		UnitTag:     "unit-foo-0",
+		StorageName: "pgdata",
+		Constraints: params.StorageConstraints{Count: &count, Snapshot: "0/2"},
+	}})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+attaching bar/1 to foo/0
+failed to attach snapshot 3 to foo/0: snapshot not taken of storage
+failed to attach snapshot 4 to foo/0: snapshot not found
+attaching pgdata/4 restored from snapshot 0/2 to foo/0
+`[1:])
+}
+
+func (s *AttachStorageSuite) TestAttachSnapshotError(c *gc.C) {
+	fake := fakeEntityAttacher{
+		snapshots: []params.VolumeSnapshotDetails{
+			{Id: "0/2", StorageTag: "storage-pgdata-0"},
+		},
+		addResults: []params.AddStorageResult{{
+			Error: &params.Error{Message: "foo"},
+		}},
+	}
+	attachCmd := storage.NewAttachStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, attachCmd, "foo/0", "snapshot:0/2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	fake.CheckCallNames(c, "NewEntityAttacherCloser", "ListSnapshots", "AddToUnit", "Close")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "failed to attach snapshot 0/2 to foo/0: foo\n")
+}
+
 func (s *AttachStorageSuite) TestAttachInitErrors(c *gc.C) {
 	s.testAttachInitError(c, []string{}, "attach-storage requires a unit ID and at least one storage ID")
 	s.testAttachInitError(c, []string{"unit/0"}, "attach-storage requires a unit ID and at least one storage ID")
+	s.testAttachInitError(c, []string{"unit/0", "snapshot:x"}, `snapshot ID "x" not valid`)
 }
 
 func (s *AttachStorageSuite) testAttachInitError(c *gc.C, args []string, expect string) {
@@ -77,7 +124,9 @@ func (s *AttachStorageSuite) testAttachInitError(c *gc.C, args []string, expect
 
 type fakeEntityAttacher struct {
 	testing.Stub
-	results []params.ErrorResult
+	results    []params.ErrorResult
+	snapshots  []params.VolumeSnapshotDetails
+	addResults []params.AddStorageResult
 }
 
 func (f *fakeEntityAttacher) new() (storage.EntityAttacherCloser, error) {
@@ -94,3 +143,13 @@ func (f *fakeEntityAttacher) Attach(unitId string, storageIds []string) ([]param
 	f.MethodCall(f, "Attach", unitId, storageIds)
 	return f.results, f.NextErr()
 }
+
+func (f *fakeEntityAttacher) ListSnapshots(storageIds []string) ([]params.VolumeSnapshotDetails, error) {
+	f.MethodCall(f, "ListSnapshots", storageIds)
+	return f.snapshots, f.NextErr()
+}
+
+func (f *fakeEntityAttacher) AddToUnit(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
+	f.MethodCall(f, "AddToUnit", storages)
+	return f.addResults, f.NextErr()
+}
@@ -75,3 +75,32 @@ func NewDetachStorageCommandForTest(new NewEntityDetacherCloserFunc, store jujuc
 	cmd.newEntityDetacherCloser = new
 	return modelcmd.Wrap(cmd)
 }
+
+func NewResizeStorageCommandForTest(new NewStorageResizerCloserFunc, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.SetClientStore(store)
+	cmd.newStorageResizerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSnapshotStorageCommandForTest(new NewStorageSnapshotterCloserFunc, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotStorageCommand{}
+	cmd.SetClientStore(store)
+	cmd.newStorageSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRemoveStorageSnapshotCommandForTest(new NewSnapshotRemoverCloserFunc, store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeStorageSnapshotCommand{}
+	cmd.SetClientStore(store)
+	cmd.newSnapshotRemoverCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSnapshotListCommandForTest(api SnapshotListAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotListCommand{newAPIFunc: func() (SnapshotListAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,123 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeStorageCommandWithAPI returns a command
+// used to grow storage instances.
+func NewResizeStorageCommandWithAPI() cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newStorageResizerCloser = func() (StorageResizerCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResizeStorageCommand returns a command
+// used to grow storage instances.
+func NewResizeStorageCommand(new NewStorageResizerCloserFunc) cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newStorageResizerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	resizeStorageCommandDoc = `
+Grows a storage instance to the specified size, while it remains attached
+to its unit. The size is given with an optional unit suffix: M, G, T or P
+(the default is M). Storage may not be shrunk.
+
+The volume backing the storage is grown by the storage provider, followed
+by any filesystem on it. Once the new size is visible on the machine, the
+unit's storage-resized hook is run.
+
+Examples:
+    juju resize-storage pgdata/0 200G
+`
+
+	resizeStorageCommandArgs = `<storage> <size>`
+)
+
+// resizeStorageCommand grows a storage instance.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newStorageResizerCloser NewStorageResizerCloserFunc
+	storageId               string
+	size                    uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	if size == 0 {
+		return errors.New("size must be greater than zero")
+	}
+	c.storageId = args[0]
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows a storage instance.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    resizeStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	resizer, err := c.newStorageResizerCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resizer.Close()
+
+	if err := resizer.Resize(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return err
+	}
+	ctx.Infof("resizing %s to %dMiB", c.storageId, c.size)
+	return nil
+}
+
+// NewStorageResizerCloserFunc is the type of a function that returns a
+// StorageResizerCloser.
+type NewStorageResizerCloserFunc func() (StorageResizerCloser, error)
+
+// StorageResizerCloser extends StorageResizer with a Closer method.
+type StorageResizerCloser interface {
+	StorageResizer
+	Close() error
+}
+
+// StorageResizer defines an interface for growing the storage instance
+// with the specified ID.
+type StorageResizer interface {
+	Resize(storageId string, size uint64) error
+}
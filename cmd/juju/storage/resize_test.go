@@ -0,0 +1,87 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ResizeStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ResizeStorageSuite{})
+
+func (s *ResizeStorageSuite) TestResize(c *gc.C) {
+	var fake fakeStorageResizer
+	cmd := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "2G")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageResizerCloser", "Resize", "Close")
+	fake.CheckCall(c, 1, "Resize", "pgdata/0", uint64(2048))
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resizing pgdata/0 to 2048MiB\n")
+}
+
+func (s *ResizeStorageSuite) TestResizeError(c *gc.C) {
+	var fake fakeStorageResizer
+	fake.SetErrors(nil, &params.Error{Message: "new size 512MiB is not larger than current size 1024MiB"})
+	cmd := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "512")
+	c.Assert(err, gc.ErrorMatches, "new size 512MiB is not larger than current size 1024MiB")
+	fake.CheckCallNames(c, "NewStorageResizerCloser", "Resize", "Close")
+}
+
+func (s *ResizeStorageSuite) TestResizeUnauthorizedError(c *gc.C) {
+	var fake fakeStorageResizer
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "2G")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to resize storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *ResizeStorageSuite) TestResizeInitErrors(c *gc.C) {
+	s.testResizeInitError(c, []string{}, "resize-storage requires a storage ID and a size")
+	s.testResizeInitError(c, []string{"pgdata/0"}, "resize-storage requires a storage ID and a size")
+	s.testResizeInitError(c, []string{"pgdata/0", "2G", "extra"}, "resize-storage requires a storage ID and a size")
+	s.testResizeInitError(c, []string{"pgdata", "2G"}, `storage ID "pgdata" not valid`)
+	s.testResizeInitError(c, []string{"pgdata/0", "lots"}, `cannot parse size: .*`)
+	s.testResizeInitError(c, []string{"pgdata/0", "0"}, "size must be greater than zero")
+}
+
+func (s *ResizeStorageSuite) testResizeInitError(c *gc.C, args []string, expect string) {
+	cmd := storage.NewResizeStorageCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeStorageResizer struct {
+	testing.Stub
+}
+
+func (f *fakeStorageResizer) new() (storage.StorageResizerCloser, error) {
+	f.MethodCall(f, "NewStorageResizerCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageResizer) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageResizer) Resize(storageId string, size uint64) error {
+	f.MethodCall(f, "Resize", storageId, size)
+	return f.NextErr()
+}
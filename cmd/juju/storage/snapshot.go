@@ -0,0 +1,129 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSnapshotStorageCommandWithAPI returns a command
+// used to take snapshots of storage instances.
+func NewSnapshotStorageCommandWithAPI() cmd.Command {
+	cmd := &snapshotStorageCommand{}
+	cmd.newStorageSnapshotterCloser = func() (StorageSnapshotterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSnapshotStorageCommand returns a command
+// used to take snapshots of storage instances.
+func NewSnapshotStorageCommand(new NewStorageSnapshotterCloserFunc) cmd.Command {
+	cmd := &snapshotStorageCommand{}
+	cmd.newStorageSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	snapshotStorageCommandDoc = `
+Takes a snapshot of the volume backing each of the specified block
+storage instances. The snapshots are taken by the storage provider,
+and are listed by "juju storage-snapshots".
+
+New storage may be created from a snapshot by specifying the storage
+constraint "snapshot:<id>" to deploy or add-storage. The new storage
+must be in the same storage pool as the snapshot and, for machine-scoped
+pools such as "loop", on the same machine.
+
+Examples:
+    juju snapshot-storage pgdata/0
+    juju deploy postgresql --storage pgdata=snapshot:0/1
+`
+
+	snapshotStorageCommandArgs = `<storage> [<storage> ...]`
+)
+
+// snapshotStorageCommand takes snapshots of storage instances.
+type snapshotStorageCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newStorageSnapshotterCloser NewStorageSnapshotterCloserFunc
+	storageIds                  []string
+}
+
+// Init implements Command.Init.
+func (c *snapshotStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("snapshot-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "snapshot-storage",
+		Purpose: "Takes snapshots of storage instances.",
+		Doc:     snapshotStorageCommandDoc,
+		Args:    snapshotStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *snapshotStorageCommand) Run(ctx *cmd.Context) error {
+	snapshotter, err := c.newStorageSnapshotterCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer snapshotter.Close()
+
+	results, err := snapshotter.CreateSnapshots(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "snapshot storage")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("snapshotting %s as %s", c.storageIds[i], result.Result)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewStorageSnapshotterCloserFunc is the type of a function that returns
+// a StorageSnapshotterCloser.
+type NewStorageSnapshotterCloserFunc func() (StorageSnapshotterCloser, error)
+
+// StorageSnapshotterCloser extends StorageSnapshotter with a Closer method.
+type StorageSnapshotterCloser interface {
+	StorageSnapshotter
+	Close() error
+}
+
+// StorageSnapshotter defines an interface for taking snapshots of the
+// storage instances with the specified IDs.
+type StorageSnapshotter interface {
+	CreateSnapshots(storageIds []string) ([]params.StringResult, error)
+}
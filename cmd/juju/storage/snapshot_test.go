@@ -0,0 +1,149 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type SnapshotStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SnapshotStorageSuite{})
+
+func (s *SnapshotStorageSuite) TestSnapshot(c *gc.C) {
+	fake := fakeStorageSnapshotter{results: []params.StringResult{
+		{Result: "0/1"},
+		{Result: "2"},
+	}}
+	cmd := storage.NewSnapshotStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "pgdata/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "CreateSnapshots", "Close")
+	fake.CheckCall(c, 1, "CreateSnapshots", []string{"pgdata/0", "pgdata/1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+snapshotting pgdata/0 as 0/1
+snapshotting pgdata/1 as 2
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotError(c *gc.C) {
+	fake := fakeStorageSnapshotter{results: []params.StringResult{
+		{Result: "0/1"},
+		{Error: &params.Error{Message: "snapshotting filesystem storage not supported"}},
+	}}
+	snapshotCmd := storage.NewSnapshotStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, snapshotCmd, "pgdata/0", "logs/0")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+snapshotting pgdata/0 as 0/1
+failed to snapshot logs/0: snapshotting filesystem storage not supported
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotUnauthorizedError(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewSnapshotStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to snapshot storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotInitErrors(c *gc.C) {
+	s.testSnapshotInitError(c, []string{}, "snapshot-storage requires at least one storage ID")
+	s.testSnapshotInitError(c, []string{"pgdata"}, `storage ID "pgdata" not valid`)
+}
+
+func (s *SnapshotStorageSuite) testSnapshotInitError(c *gc.C, args []string, expect string) {
+	cmd := storage.NewSnapshotStorageCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeStorageSnapshotter struct {
+	testing.Stub
+	results []params.StringResult
+}
+
+func (f *fakeStorageSnapshotter) new() (storage.StorageSnapshotterCloser, error) {
+	f.MethodCall(f, "NewStorageSnapshotterCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) CreateSnapshots(storageIds []string) ([]params.StringResult, error) {
+	f.MethodCall(f, "CreateSnapshots", storageIds)
+	return f.results, f.NextErr()
+}
+
+type RemoveStorageSnapshotSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RemoveStorageSnapshotSuite{})
+
+func (s *RemoveStorageSnapshotSuite) TestRemove(c *gc.C) {
+	fake := fakeSnapshotRemover{results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "volume snapshot 2 not found", Code: params.CodeNotFound}},
+	}}
+	removeCmd := storage.NewRemoveStorageSnapshotCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, removeCmd, "0/1", "2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	fake.CheckCallNames(c, "NewSnapshotRemoverCloser", "RemoveSnapshots", "Close")
+	fake.CheckCall(c, 1, "RemoveSnapshots", []string{"0/1", "2"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing snapshot 0/1
+failed to remove snapshot 2: volume snapshot 2 not found
+`[1:])
+}
+
+func (s *RemoveStorageSnapshotSuite) TestRemoveInitErrors(c *gc.C) {
+	s.testRemoveInitError(c, []string{}, "remove-storage-snapshot requires at least one snapshot ID")
+	s.testRemoveInitError(c, []string{"pgdata/0"}, `snapshot ID "pgdata/0" not valid`)
+}
+
+func (s *RemoveStorageSnapshotSuite) testRemoveInitError(c *gc.C, args []string, expect string) {
+	cmd := storage.NewRemoveStorageSnapshotCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeSnapshotRemover struct {
+	testing.Stub
+	results []params.ErrorResult
+}
+
+func (f *fakeSnapshotRemover) new() (storage.SnapshotRemoverCloser, error) {
+	f.MethodCall(f, "NewSnapshotRemoverCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeSnapshotRemover) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeSnapshotRemover) RemoveSnapshots(snapshotIds []string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "RemoveSnapshots", snapshotIds)
+	return f.results, f.NextErr()
+}
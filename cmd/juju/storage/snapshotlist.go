@@ -0,0 +1,186 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// SnapshotInfo defines the serialization behaviour of the volume
+// snapshot information.
+type SnapshotInfo struct {
+	Storage            string    `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Volume             string    `yaml:"volume" json:"volume"`
+	Pool               string    `yaml:"pool" json:"pool"`
+	Size               uint64    `yaml:"size" json:"size"`
+	ProviderSnapshotId string    `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+	Life               string    `yaml:"life,omitempty" json:"life,omitempty"`
+	Created            time.Time `yaml:"created" json:"created"`
+}
+
+func formatSnapshotInfo(all []params.VolumeSnapshotDetails) (map[string]SnapshotInfo, error) {
+	output := make(map[string]SnapshotInfo)
+	for _, one := range all {
+		volumeTag, err := names.ParseVolumeTag(one.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := SnapshotInfo{
+			Volume:             volumeTag.Id(),
+			Pool:               one.Pool,
+			Size:               one.Size,
+			ProviderSnapshotId: one.SnapshotId,
+			Life:               string(one.Life),
+			Created:            one.Created,
+		}
+		if one.StorageTag != "" {
+			storageTag, err := names.ParseStorageTag(one.StorageTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info.Storage = storageTag.Id()
+		}
+		output[one.Id] = info
+	}
+	return output, nil
+}
+
+const snapshotListCommandDoc = `
+Lists the volume snapshots in the model. If storage IDs are specified,
+only the snapshots taken of those storage instances are listed.
+
+A snapshot without a provider ID has not yet been taken by the storage
+provider.
+
+Examples:
+    juju storage-snapshots
+    juju storage-snapshots pgdata/0
+`
+
+// NewSnapshotListCommand returns a command that lists volume snapshots
+// in a model.
+func NewSnapshotListCommand() cmd.Command {
+	cmd := &snapshotListCommand{}
+	cmd.newAPIFunc = func() (SnapshotListAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// snapshotListCommand lists volume snapshots.
+type snapshotListCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (SnapshotListAPI, error)
+	storageIds []string
+	out        cmd.Output
+}
+
+// Init implements Command.Init.
+func (c *snapshotListCommand) Init(args []string) error {
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotListCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "storage-snapshots",
+		Args:    "[<storage> ...]",
+		Purpose: "Lists volume snapshots.",
+		Doc:     snapshotListCommandDoc,
+		Aliases: []string{"list-storage-snapshots"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *snapshotListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotListCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	result, err := api.ListSnapshots(c.storageIds)
+	if err != nil {
+		return err
+	}
+	if len(result) == 0 {
+		ctx.Infof("No storage snapshots to display.")
+		return nil
+	}
+	output, err := formatSnapshotInfo(result)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, output)
+}
+
+// SnapshotListAPI defines the API methods that the storage-snapshots
+// command uses.
+type SnapshotListAPI interface {
+	Close() error
+	ListSnapshots(storageIds []string) ([]params.VolumeSnapshotDetails, error)
+}
+
+// formatSnapshotListTabular returns a tabular summary of volume snapshots
+// or errors out if parameter is not a map of SnapshotInfo.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("Snapshot", "Storage", "Volume", "Pool", "Size", "Provider Id", "Life", "Created")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	naturalsort.Sort(ids)
+	for _, id := range ids {
+		snapshot := snapshots[id]
+		created := snapshot.Created
+		print(
+			id, snapshot.Storage, snapshot.Volume, snapshot.Pool,
+			humanize.IBytes(snapshot.Size*humanize.MiByte),
+			snapshot.ProviderSnapshotId, snapshot.Life,
+			common.FormatTime(&created, false),
+		)
+	}
+	return tw.Flush()
+}
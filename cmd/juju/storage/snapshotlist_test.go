@@ -0,0 +1,118 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type snapshotListSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockSnapshotListAPI
+}
+
+var _ = gc.Suite(&snapshotListSuite{})
+
+func (s *snapshotListSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	created := time.Date(2019, 3, 4, 12, 6, 7, 0, time.UTC)
+	s.mockAPI = &mockSnapshotListAPI{
+		snapshots: []params.VolumeSnapshotDetails{{
+			Id:        "2",
+			VolumeTag: "volume-3",
+			Pool:      "ebs",
+			Size:      2048,
+			Life:      params.Alive,
+			Created:   created,
+		}, {
+			Id:         "0/1",
+			StorageTag: "storage-pgdata-0",
+			VolumeTag:  "volume-0-0",
+			Pool:       "loop",
+			Size:       1024,
+			SnapshotId: "snapshot-0-1",
+			Life:       params.Alive,
+			Created:    created,
+		}},
+	}
+}
+
+func (s *snapshotListSuite) runSnapshotList(c *gc.C, args ...string) (string, error) {
+	command := storage.NewSnapshotListCommandForTest(s.mockAPI, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *snapshotListSuite) TestSnapshotListYAML(c *gc.C) {
+	out, err := s.runSnapshotList(c, "--format", "yaml", "pgdata/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.storageIds, jc.DeepEquals, []string{"pgdata/0"})
+	c.Assert(out, gc.Equals, `
+0/1:
+  storage: pgdata/0
+  volume: 0/0
+  pool: loop
+  size: 1024
+  provider-id: snapshot-0-1
+  life: alive
+  created: 2019-03-04T12:06:07Z
+"2":
+  volume: "3"
+  pool: ebs
+  size: 2048
+  life: alive
+  created: 2019-03-04T12:06:07Z
+`[1:])
+}
+
+func (s *snapshotListSuite) TestSnapshotListTabular(c *gc.C) {
+	out, err := s.runSnapshotList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Matches, `
+Snapshot  Storage   Volume  Pool  Size    Provider Id   Life   Created
+0/1       pgdata/0  0/0     loop  1.0GiB  snapshot-0-1  alive  04 Mar 2019 .*
+2                   3       ebs   2.0GiB                alive  04 Mar 2019 .*
+
+`[1:])
+}
+
+func (s *snapshotListSuite) TestSnapshotListEmpty(c *gc.C) {
+	s.mockAPI.snapshots = nil
+	command := storage.NewSnapshotListCommandForTest(s.mockAPI, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No storage snapshots to display.\n")
+}
+
+func (s *snapshotListSuite) TestSnapshotListInvalidStorage(c *gc.C) {
+	_, err := s.runSnapshotList(c, "pgdata")
+	c.Assert(err, gc.ErrorMatches, `storage ID "pgdata" not valid`)
+}
+
+type mockSnapshotListAPI struct {
+	snapshots  []params.VolumeSnapshotDetails
+	storageIds []string
+}
+
+func (s *mockSnapshotListAPI) Close() error {
+	return nil
+}
+
+func (s *mockSnapshotListAPI) ListSnapshots(storageIds []string) ([]params.VolumeSnapshotDetails, error) {
+	s.storageIds = storageIds
+	return s.snapshots, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/storage"
+)
+
+// NewRemoveStorageSnapshotCommandWithAPI returns a command
+// used to remove volume snapshots.
+func NewRemoveStorageSnapshotCommandWithAPI() cmd.Command {
+	cmd := &removeStorageSnapshotCommand{}
+	cmd.newSnapshotRemoverCloser = func() (SnapshotRemoverCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRemoveStorageSnapshotCommand returns a command
+// used to remove volume snapshots.
+func NewRemoveStorageSnapshotCommand(new NewSnapshotRemoverCloserFunc) cmd.Command {
+	cmd := &removeStorageSnapshotCommand{}
+	cmd.newSnapshotRemoverCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	removeStorageSnapshotCommandDoc = `
+Removes volume snapshots from the model. The snapshots are destroyed
+by the storage provider. Storage already created from a snapshot is
+not affected.
+
+Examples:
+    juju remove-storage-snapshot 0/1 2
+`
+
+	removeStorageSnapshotCommandArgs = `<snapshot> [<snapshot> ...]`
+)
+
+// removeStorageSnapshotCommand removes volume snapshots.
+type removeStorageSnapshotCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newSnapshotRemoverCloser NewSnapshotRemoverCloserFunc
+	snapshotIds              []string
+}
+
+// Init implements Command.Init.
+func (c *removeStorageSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("remove-storage-snapshot requires at least one snapshot ID")
+	}
+	for _, id := range args {
+		if !storage.IsValidSnapshotId(id) {
+			return errors.NotValidf("snapshot ID %q", id)
+		}
+	}
+	c.snapshotIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *removeStorageSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage-snapshot",
+		Purpose: "Removes volume snapshots from the model.",
+		Doc:     removeStorageSnapshotCommandDoc,
+		Args:    removeStorageSnapshotCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *removeStorageSnapshotCommand) Run(ctx *cmd.Context) error {
+	remover, err := c.newSnapshotRemoverCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer remover.Close()
+
+	results, err := remover.RemoveSnapshots(c.snapshotIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "remove storage snapshots")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to remove snapshot %s: %s", c.snapshotIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("removing snapshot %s", c.snapshotIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewSnapshotRemoverCloserFunc is the type of a function that returns
+// a SnapshotRemoverCloser.
+type NewSnapshotRemoverCloserFunc func() (SnapshotRemoverCloser, error)
+
+// SnapshotRemoverCloser extends SnapshotRemover with a Closer method.
+type SnapshotRemoverCloser interface {
+	SnapshotRemover
+	Close() error
+}
+
+// SnapshotRemover defines an interface for removing the volume
+// snapshots with the specified IDs.
+type SnapshotRemover interface {
+	RemoveSnapshots(snapshotIds []string) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/jujuclient"
+)
+
+const encryptStoreDoc = `
+Encrypts the cloud credentials and controller account details held
+in the local client store, so they are no longer kept in plain text.
+
+By default the encryption key is derived from a passphrase, which is
+prompted for. Alternatively, the --helper option names a command whose
+output the key is derived from, such as a password manager lookup; the
+helper is run whenever the store needs to be unlocked.
+
+When the store is locked, commands needing credentials or account
+details fail until it is unlocked with "juju login", which caches the
+key for the rest of the session. The passphrase may instead be set in
+the JUJU_STORE_PASSPHRASE environment variable.
+
+Examples:
+
+    juju encrypt-client-store
+    juju encrypt-client-store --helper "pass show juju"
+
+See also:
+    login
+`
+
+// Functions defined as variables so they can be overridden in tests.
+var encryptStore = jujuclient.EncryptFileStore
+
+// NewEncryptStoreCommand returns a new cmd.Command to handle
+// "juju encrypt-client-store".
+func NewEncryptStoreCommand() cmd.Command {
+	return &encryptStoreCommand{}
+}
+
+// encryptStoreCommand encrypts the local client store.
+type encryptStoreCommand struct {
+	cmd.CommandBase
+	helper string
+}
+
+// Info implements Command.Info.
+func (c *encryptStoreCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "encrypt-client-store",
+		Purpose: "Encrypts the credentials and accounts in the local client store.",
+		Doc:     encryptStoreDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *encryptStoreCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.helper, "helper", "", "Command whose output the encryption key is derived from")
+}
+
+// Run implements Command.Run.
+func (c *encryptStoreCommand) Run(ctx *cmd.Context) error {
+	var passphrase string
+	if c.helper == "" {
+		var err error
+		if passphrase, err = readAndConfirmPassphrase(ctx); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := encryptStore(passphrase, c.helper); err != nil {
+		return errors.Annotate(err, "cannot encrypt client store")
+	}
+	if passphrase != "" {
+		// Leave the store unlocked for the rest of the session.
+		if err := unlockStore(passphrase); err != nil {
+			logger.Warningf("%v", err)
+		}
+	}
+	ctx.Infof("Client store encrypted.")
+	return nil
+}
+
+func readAndConfirmPassphrase(ctx *cmd.Context) (string, error) {
+	fmt.Fprint(ctx.Stderr, "passphrase: ")
+	passphrase, err := readPassword(ctx.Stdin)
+	fmt.Fprint(ctx.Stderr, "\n")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if passphrase == "" {
+		return "", errors.Errorf("you must enter a passphrase")
+	}
+
+	fmt.Fprint(ctx.Stderr, "type passphrase again: ")
+	verify, err := readPassword(ctx.Stdin)
+	fmt.Fprint(ctx.Stderr, "\n")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if passphrase != verify {
+		return "", errors.New("passphrases do not match")
+	}
+	return passphrase, nil
+}
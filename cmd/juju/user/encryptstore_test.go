@@ -0,0 +1,85 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/testing"
+)
+
+type EncryptStoreCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	secret   string
+	helper   string
+	unlocked string
+}
+
+var _ = gc.Suite(&EncryptStoreCommandSuite{})
+
+func (s *EncryptStoreCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.secret, s.helper, s.unlocked = "", "", ""
+	s.PatchValue(user.EncryptStore, func(secret, helper string) error {
+		s.secret, s.helper = secret, helper
+		return nil
+	})
+	s.PatchValue(user.UnlockStore, func(passphrase string) error {
+		s.unlocked = passphrase
+		return nil
+	})
+}
+
+func runEncryptStore(c *gc.C, stdin string, args ...string) (stderr string, errCode int) {
+	var stdoutBuf, stderrBuf bytes.Buffer
+	ctxt := &cmd.Context{
+		Dir:    c.MkDir(),
+		Stdin:  strings.NewReader(stdin),
+		Stdout: &stdoutBuf,
+		Stderr: &stderrBuf,
+	}
+	exitCode := cmd.Main(user.NewEncryptStoreCommand(), ctxt, args)
+	return stderrBuf.String(), exitCode
+}
+
+func (s *EncryptStoreCommandSuite) TestEncryptWithPassphrase(c *gc.C) {
+	stderr, code := runEncryptStore(c, "sekrit\nsekrit\n")
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(stderr, gc.Equals, "passphrase: \ntype passphrase again: \nClient store encrypted.\n")
+	c.Assert(s.secret, gc.Equals, "sekrit")
+	c.Assert(s.helper, gc.Equals, "")
+	c.Assert(s.unlocked, gc.Equals, "sekrit")
+}
+
+func (s *EncryptStoreCommandSuite) TestEncryptWithHelper(c *gc.C) {
+	stderr, code := runEncryptStore(c, "", "--helper", "pass show juju")
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(stderr, gc.Equals, "Client store encrypted.\n")
+	c.Assert(s.secret, gc.Equals, "")
+	c.Assert(s.helper, gc.Equals, "pass show juju")
+	c.Assert(s.unlocked, gc.Equals, "")
+}
+
+func (s *EncryptStoreCommandSuite) TestPassphrasesDoNotMatch(c *gc.C) {
+	stderr, code := runEncryptStore(c, "sekrit\nsecret\n")
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(stderr, gc.Equals, "passphrase: \ntype passphrase again: \nERROR passphrases do not match\n")
+	c.Assert(s.secret, gc.Equals, "")
+}
+
+func (s *EncryptStoreCommandSuite) TestAlreadyEncrypted(c *gc.C) {
+	s.PatchValue(user.EncryptStore, func(secret, helper string) error {
+		return errors.AlreadyExistsf("client store encryption")
+	})
+	stderr, code := runEncryptStore(c, "", "--helper", "pass show juju")
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(stderr, jc.Contains, "ERROR cannot encrypt client store: client store encryption already exists\n")
+}
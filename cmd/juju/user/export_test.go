@@ -18,6 +18,10 @@ var (
 	ListModels       = &listModels
 	NewAPIConnection = &newAPIConnection
 	LoginClientStore = &loginClientStore
+	StoreLocked      = &storeLocked
+	UnlockStore      = &unlockStore
+	LockStore        = &lockStore
+	EncryptStore     = &encryptStore
 )
 
 const NoModelsMessage = noModelsMessage
@@ -44,6 +44,11 @@ except verify that fact.
 If the -u flag is provided, the juju login command will attempt to log
 into the controller as that user.
 
+If the client store has been encrypted with "juju encrypt-client-store"
+and is locked, the juju login command first prompts for the passphrase
+to unlock it. The key is cached until the user's session ends or the
+store is locked again with the --lock flag.
+
 After login, a token ("macaroon") will become active. It has an expiration
 time of 24 hours. Upon expiration, no further Juju commands can be issued
 and the user will be prompted to log in again.
@@ -61,8 +66,10 @@ Examples:
     juju login somepubliccontroller
     juju login jimm.jujucharms.com
     juju login -u bob
+    juju login --lock
 
 See also:
+    encrypt-client-store
     disable-user
     enable-user
     logout
@@ -80,6 +87,10 @@ var (
 	// loginClientStore is used as the client store. When it is nil,
 	// the default client store will be used.
 	loginClientStore jujuclient.ClientStore
+
+	storeLocked = jujuclient.FileStoreLocked
+	unlockStore = jujuclient.UnlockFileStore
+	lockStore   = jujuclient.LockFileStore
 )
 
 // NewLoginCommand returns a new cmd.Command to handle "juju login".
@@ -101,6 +112,10 @@ type loginCommand struct {
 	// is executed.
 	controllerName string
 
+	// lock holds whether to lock the encrypted
+	// client store rather than logging in.
+	lock bool
+
 	// onRunError is executed if non-nil if there is an error at the end
 	// of the Run method.
 	onRunError func()
@@ -122,6 +137,7 @@ func (c *loginCommand) SetFlags(fset *gnuflag.FlagSet) {
 	fset.StringVar(&c.controllerName, "controller", "", "")
 	fset.StringVar(&c.username, "u", "", "log in as this local user")
 	fset.StringVar(&c.username, "user", "", "")
+	fset.BoolVar(&c.lock, "lock", false, "Lock the encrypted client store instead of logging in")
 }
 
 // Init implements Command.Init.
@@ -131,6 +147,9 @@ func (c *loginCommand) Init(args []string) error {
 		return errors.Trace(err)
 	}
 	c.domain = domain
+	if c.lock && (c.domain != "" || c.username != "") {
+		return errors.New("cannot log in when locking the client store")
+	}
 	return nil
 }
 
@@ -144,6 +163,13 @@ func (c *loginCommand) Run(ctx *cmd.Context) error {
 }
 
 func (c *loginCommand) run(ctx *cmd.Context) error {
+	if c.lock {
+		return errors.Annotate(lockStore(), "cannot lock client store")
+	}
+	if err := c.unlockStore(ctx); err != nil {
+		return errors.Trace(err)
+	}
+
 	store := c.ClientStore()
 	switch {
 	case c.controllerName == "" && c.domain == "":
@@ -252,6 +278,22 @@ use "juju unregister %s" to remove the existing controller.`[1:], c.domain, c.co
 	return c.maybeSetCurrentModel(ctx, store, c.controllerName, accountDetails.User, models)
 }
 
+// unlockStore prompts for the passphrase to unlock
+// the client store, if it is encrypted and locked.
+func (c *loginCommand) unlockStore(ctx *cmd.Context) error {
+	locked, err := storeLocked()
+	if err != nil || !locked {
+		return errors.Trace(err)
+	}
+	fmt.Fprint(ctx.Stderr, "passphrase to unlock client store: ")
+	passphrase, err := readPassword(ctx.Stdin)
+	fmt.Fprint(ctx.Stderr, "\n")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(unlockStore(passphrase), "cannot unlock client store")
+}
+
 func (c *loginCommand) existingControllerLogin(ctx *cmd.Context, store jujuclient.ClientStore, controllerName string, currentAccountDetails *jujuclient.AccountDetails) (api.Connection, *jujuclient.AccountDetails, error) {
 	dial := func(accountDetails *jujuclient.AccountDetails) (api.Connection, error) {
 		args, err := c.NewAPIConnectionParams(store, controllerName, "", accountDetails)
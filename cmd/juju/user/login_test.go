@@ -67,6 +67,9 @@ func (s *LoginCommandSuite) TestInitError(c *gc.C) {
 	}, {
 		args:   []string{"foobar", "extra"},
 		stderr: `ERROR unrecognized args: \["extra"\]\n`,
+	}, {
+		args:   []string{"--lock", "foobar"},
+		stderr: `ERROR cannot log in when locking the client store\n`,
 	}} {
 		c.Logf("test %d", i)
 		stdout, stderr, code := runLogin(c, "", test.args...)
@@ -91,6 +94,52 @@ func (s *LoginCommandSuite) TestLogin(c *gc.C) {
 	})
 }
 
+func (s *LoginCommandSuite) TestLoginUnlocksStore(c *gc.C) {
+	s.PatchValue(user.StoreLocked, func() (bool, error) {
+		return true, nil
+	})
+	var passphrase string
+	s.PatchValue(user.UnlockStore, func(p string) error {
+		passphrase = p
+		return nil
+	})
+	stdout, stderr, code := runLogin(c, "sekrit\n")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, "passphrase to unlock client store: \n")
+	c.Assert(passphrase, gc.Equals, "sekrit")
+	s.assertStorePassword(c, "current-user", "old-password", "superuser")
+}
+
+func (s *LoginCommandSuite) TestLoginUnlockStoreFails(c *gc.C) {
+	s.PatchValue(user.StoreLocked, func() (bool, error) {
+		return true, nil
+	})
+	s.PatchValue(user.UnlockStore, func(string) error {
+		return errors.New("incorrect passphrase")
+	})
+	_, stderr, code := runLogin(c, "guess\n")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stderr, gc.Equals, "passphrase to unlock client store: \nERROR cannot unlock client store: incorrect passphrase\n")
+}
+
+func (s *LoginCommandSuite) TestLock(c *gc.C) {
+	locked := false
+	s.PatchValue(user.LockStore, func() error {
+		locked = true
+		return nil
+	})
+	s.PatchValue(user.NewAPIConnection, func(juju.NewAPIConnectionParams) (api.Connection, error) {
+		c.Fatalf("unexpected login")
+		return nil, nil
+	})
+	stdout, stderr, code := runLogin(c, "", "--lock")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, "")
+	c.Assert(locked, jc.IsTrue)
+}
+
 func (s *LoginCommandSuite) TestLoginNewUser(c *gc.C) {
 	err := s.store.RemoveAccount("testing")
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,13 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
+)
+
+func NewWaitForCommandForTest(api WaitForAPI, clock clock.Clock) cmd.Command {
+	return &waitForCommand{api: api, clock: clock}
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+)
+
+// fieldGetters maps the field names that may be used in a query to
+// functions that extract the field's value from an entity.
+type fieldGetters map[string]func(entity interface{}) string
+
+// names returns the sorted names of the fields.
+func (f fieldGetters) names() []string {
+	result := make([]string, 0, len(f))
+	for name := range f {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// values returns the values of all of the fields for the given
+// entity, keyed on field name.
+func (f fieldGetters) values(entity interface{}) map[string]string {
+	result := make(map[string]string, len(f))
+	for name, get := range f {
+		result[name] = get(entity)
+	}
+	return result
+}
+
+// comparison is a single "<field>==<value>" or "<field>!=<value>"
+// term of a query.
+type comparison struct {
+	field  string
+	value  string
+	negate bool
+}
+
+// query is a parsed query expression. It is satisfied by an entity if
+// all of the comparisons in any one of its alternatives hold.
+type query struct {
+	alternatives [][]comparison
+	fields       fieldGetters
+}
+
+// parseQuery parses a query expression of the form
+//
+//	<field> == <value> && <field> != <value> || ...
+//
+// where "&&" binds more tightly than "||". Values may be bare words or
+// double-quoted strings. An empty expression matches every entity.
+func parseQuery(expr string, fields fieldGetters) (*query, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	q := &query{fields: fields}
+	if len(tokens) == 0 {
+		return q, nil
+	}
+	var terms []comparison
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return nil, errors.Errorf("incomplete comparison at end of query %q", expr)
+		}
+		field, op, value := tokens[0], tokens[1], tokens[2]
+		tokens = tokens[3:]
+		if field.kind != tokenWord {
+			return nil, errors.Errorf("expected field name, got %q", field.text)
+		}
+		if _, ok := fields[field.text]; !ok {
+			return nil, errors.Errorf("unknown field %q, expected one of %s",
+				field.text, strings.Join(fields.names(), ", "))
+		}
+		if op.kind != tokenEquals && op.kind != tokenNotEquals {
+			return nil, errors.Errorf("expected == or != after %q, got %q", field.text, op.text)
+		}
+		if value.kind != tokenWord && value.kind != tokenString {
+			return nil, errors.Errorf("expected value after %q, got %q", field.text+op.text, value.text)
+		}
+		terms = append(terms, comparison{
+			field:  field.text,
+			value:  value.text,
+			negate: op.kind == tokenNotEquals,
+		})
+		if len(tokens) == 0 {
+			break
+		}
+		switch tokens[0].kind {
+		case tokenAnd:
+		case tokenOr:
+			q.alternatives = append(q.alternatives, terms)
+			terms = nil
+		default:
+			return nil, errors.Errorf("expected && or ||, got %q", tokens[0].text)
+		}
+		tokens = tokens[1:]
+		if len(tokens) == 0 {
+			return nil, errors.Errorf("incomplete comparison at end of query %q", expr)
+		}
+	}
+	q.alternatives = append(q.alternatives, terms)
+	return q, nil
+}
+
+// match returns whether the entity satisfies the query.
+func (q *query) match(entity interface{}) bool {
+	if len(q.alternatives) == 0 {
+		return true
+	}
+	for _, terms := range q.alternatives {
+		if q.matchAll(terms, entity) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *query) matchAll(terms []comparison, entity interface{}) bool {
+	for _, term := range terms {
+		equal := q.fields[term.field](entity) == term.value
+		if equal == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenEquals
+	tokenNotEquals
+	tokenAnd
+	tokenOr
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+var operators = []token{
+	{tokenEquals, "=="},
+	{tokenNotEquals, "!="},
+	{tokenAnd, "&&"},
+	{tokenOr, "||"},
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	rest := expr
+outer:
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			return tokens, nil
+		}
+		for _, op := range operators {
+			if strings.HasPrefix(rest, op.text) {
+				tokens = append(tokens, op)
+				rest = rest[len(op.text):]
+				continue outer
+			}
+		}
+		if rest[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, errors.Errorf("unterminated string in query %q", expr)
+			}
+			value, err := strconv.Unquote(quoted)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			tokens = append(tokens, token{tokenString, value})
+			rest = rest[len(quoted):]
+			continue
+		}
+		end := strings.IndexFunc(rest, func(r rune) bool {
+			return unicode.IsSpace(r) || strings.ContainsRune(`"=!&|`, r)
+		})
+		if end == 0 {
+			return nil, errors.Errorf("unexpected %q in query %q", rest[:1], expr)
+		}
+		if end == -1 {
+			end = len(rest)
+		}
+		tokens = append(tokens, token{tokenWord, rest[:end]})
+		rest = rest[end:]
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type querySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&querySuite{})
+
+var testFields = fieldGetters{
+	"colour": func(e interface{}) string { return e.(map[string]string)["colour"] },
+	"shape":  func(e interface{}) string { return e.(map[string]string)["shape"] },
+}
+
+func (s *querySuite) TestMatch(c *gc.C) {
+	entity := map[string]string{"colour": "dark red", "shape": "square"}
+	for i, test := range []struct {
+		expr  string
+		match bool
+	}{
+		{"", true},
+		{"shape==square", true},
+		{"shape == circle", false},
+		{"shape!=circle", true},
+		{`colour=="dark red"`, true},
+		{`colour=="dark red" && shape==circle`, false},
+		{`shape==circle || colour=="dark red"`, true},
+		{`shape==circle || colour==blue && shape==square`, false},
+		{`shape==square && colour!=blue || shape==circle`, true},
+	} {
+		c.Logf("test %d: %s", i, test.expr)
+		q, err := parseQuery(test.expr, testFields)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(q.match(entity), gc.Equals, test.match)
+	}
+}
+
+func (s *querySuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		expr string
+		err  string
+	}{
+		{"size==big", `unknown field "size", expected one of colour, shape`},
+		{"shape", `incomplete comparison at end of query "shape"`},
+		{"shape==square &&", `incomplete comparison at end of query "shape==square &&"`},
+		{"shape square circle", `expected == or != after "shape", got "square"`},
+		{"==shape square", `expected field name, got "=="`},
+		{"shape==&&", `expected value after "shape==", got "&&"`},
+		{"shape==square colour==red", `expected && or \|\|, got "colour"`},
+		{`colour=="red`, `unterminated string in query "colour==\\"red"`},
+		{"shape=square", `unexpected "=" in query "shape=square"`},
+	} {
+		c.Logf("test %d: %s", i, test.expr)
+		_, err := parseQuery(test.expr, testFields)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+const waitForDoc = `
+Block until the entities of the given kind in the current model satisfy a
+query expression. The kind is one of "model", "application", "machine" or
+"unit"; an optional name, which may contain shell-style wildcards, restricts
+the entities considered.
+
+The command waits until at least one matching entity exists and every
+matching entity satisfies the query. It then prints the changes that
+satisfied the query and exits with status 0. If the query is not satisfied
+before the timeout expires, the command exits with a non-zero status.
+
+A query is made up of comparisons of the form <field>==<value> or
+<field>!=<value>, joined with && and ||; && binds more tightly than ||.
+Values containing spaces or operator characters must be double-quoted.
+An empty query is satisfied as soon as a matching entity exists.
+
+The fields available for each kind are:
+
+    model:       %s
+    application: %s
+    machine:     %s
+    unit:        %s
+
+Examples:
+
+    juju wait-for application mysql --query 'status==active'
+
+    juju wait-for unit 'mysql/*' --query 'workload-status==active && agent-status==idle'
+
+    juju wait-for machine 0 --query 'agent-status==started' --timeout 30m
+
+    juju wait-for model --query 'life==dead || status==destroying'
+
+See also:
+    status
+    show-status-log
+`
+
+const defaultTimeout = 10 * time.Minute
+
+// NewWaitForCommand returns a command that blocks until entities in
+// the model satisfy a query.
+func NewWaitForCommand() cmd.Command {
+	return modelcmd.Wrap(&waitForCommand{clock: clock.WallClock})
+}
+
+// WaitForAPI is the API surface for the wait-for command.
+type WaitForAPI interface {
+	WatchAll() (AllWatcher, error)
+	Close() error
+}
+
+// AllWatcher is the part of the all watcher used by the wait-for
+// command.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+	out     cmd.Output
+	api     WaitForAPI
+	clock   clock.Clock
+	kind    string
+	name    string
+	expr    string
+	query   *query
+	timeout time.Duration
+}
+
+func (c *waitForCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait-for",
+		Args:    "<kind> [<name>]",
+		Purpose: "Wait for model entities to satisfy a query.",
+		Doc: fmt.Sprintf(waitForDoc,
+			strings.Join(kindFields["model"].names(), ", "),
+			strings.Join(kindFields["application"].names(), ", "),
+			strings.Join(kindFields["machine"].names(), ", "),
+			strings.Join(kindFields["unit"].names(), ", "),
+		),
+	}
+}
+
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.expr, "query", "", "Query expression the entities must satisfy")
+	f.DurationVar(&c.timeout, "timeout", defaultTimeout, "How long to wait before giving up")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *waitForCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no entity kind specified")
+	}
+	c.kind, args = args[0], args[1:]
+	fields, ok := kindFields[c.kind]
+	if !ok {
+		return errors.Errorf("entity kind %q not valid, expected one of %s",
+			c.kind, strings.Join(sortedKinds(), ", "))
+	}
+	if len(args) > 0 {
+		c.name, args = args[0], args[1:]
+		if _, err := path.Match(c.name, ""); err != nil {
+			return errors.NotValidf("name pattern %q", c.name)
+		}
+	}
+	if err := cmd.CheckEmpty(args); err != nil {
+		return err
+	}
+	if c.timeout <= 0 {
+		return errors.NotValidf("timeout %v", c.timeout)
+	}
+	q, err := parseQuery(c.expr, fields)
+	if err != nil {
+		return errors.Annotate(err, "invalid query")
+	}
+	c.query = q
+	return nil
+}
+
+func (c *waitForCommand) getAPI() (WaitForAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &clientShim{client}, nil
+}
+
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer watcher.Stop()
+
+	type result struct {
+		deltas []multiwatcher.Delta
+		err    error
+	}
+	results := make(chan result)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			select {
+			case results <- result{deltas, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	fields := kindFields[c.kind]
+	entities := make(map[string]multiwatcher.EntityInfo)
+	timeout := c.clock.After(c.timeout)
+	for {
+		select {
+		case <-timeout:
+			return errors.Errorf("timed out after %v waiting for %s", c.timeout, c.description())
+		case r := <-results:
+			if r.err != nil {
+				return errors.Annotate(r.err, "watching model")
+			}
+			changed := c.apply(entities, r.deltas)
+			if len(changed) == 0 || !c.satisfied(entities) {
+				continue
+			}
+			output := make(map[string]map[string]string)
+			for _, entity := range changed {
+				if entity == nil {
+					continue
+				}
+				output[entityName(entity)] = fields.values(entity)
+			}
+			return c.out.Write(ctx, map[string]map[string]map[string]string{
+				c.kind: output,
+			})
+		}
+	}
+}
+
+// apply updates entities with the deltas for the entities the command
+// is waiting on, and returns the entities that were changed, keyed on
+// their ids. Removed entities are recorded with a nil value.
+func (c *waitForCommand) apply(
+	entities map[string]multiwatcher.EntityInfo,
+	deltas []multiwatcher.Delta,
+) map[string]multiwatcher.EntityInfo {
+	changed := make(map[string]multiwatcher.EntityInfo)
+	for _, delta := range deltas {
+		id := delta.Entity.EntityId()
+		if id.Kind != c.kind || !c.matchName(delta.Entity) {
+			continue
+		}
+		if delta.Removed {
+			delete(entities, id.Id)
+			changed[id.Id] = nil
+			continue
+		}
+		entities[id.Id] = delta.Entity
+		changed[id.Id] = delta.Entity
+	}
+	return changed
+}
+
+// satisfied returns whether there is at least one entity and all of
+// the entities match the query.
+func (c *waitForCommand) satisfied(entities map[string]multiwatcher.EntityInfo) bool {
+	if len(entities) == 0 {
+		return false
+	}
+	for _, entity := range entities {
+		if !c.query.match(entity) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *waitForCommand) matchName(entity multiwatcher.EntityInfo) bool {
+	if c.name == "" {
+		return true
+	}
+	matched, _ := path.Match(c.name, entityName(entity))
+	return matched
+}
+
+func (c *waitForCommand) description() string {
+	var parts []string
+	if c.name != "" {
+		parts = append(parts, fmt.Sprintf("%s %q", c.kind, c.name))
+	} else {
+		parts = append(parts, c.kind)
+	}
+	if c.expr != "" {
+		parts = append(parts, fmt.Sprintf("to satisfy %q", c.expr))
+	}
+	return strings.Join(parts, " ")
+}
+
+// entityName returns the name against which the name pattern given
+// on the command line is matched.
+func entityName(entity multiwatcher.EntityInfo) string {
+	switch entity := entity.(type) {
+	case *multiwatcher.ModelInfo:
+		return entity.Name
+	case *multiwatcher.ApplicationInfo:
+		return entity.Name
+	case *multiwatcher.MachineInfo:
+		return entity.Id
+	case *multiwatcher.UnitInfo:
+		return entity.Name
+	}
+	return entity.EntityId().Id
+}
+
+// kindFields holds the fields that may be queried for each kind of
+// entity.
+var kindFields = map[string]fieldGetters{
+	"model": {
+		"name":    func(e interface{}) string { return e.(*multiwatcher.ModelInfo).Name },
+		"life":    func(e interface{}) string { return string(e.(*multiwatcher.ModelInfo).Life) },
+		"owner":   func(e interface{}) string { return e.(*multiwatcher.ModelInfo).Owner },
+		"status":  func(e interface{}) string { return string(e.(*multiwatcher.ModelInfo).Status.Current) },
+		"message": func(e interface{}) string { return e.(*multiwatcher.ModelInfo).Status.Message },
+	},
+	"application": {
+		"name":             func(e interface{}) string { return e.(*multiwatcher.ApplicationInfo).Name },
+		"life":             func(e interface{}) string { return string(e.(*multiwatcher.ApplicationInfo).Life) },
+		"exposed":          func(e interface{}) string { return strconv.FormatBool(e.(*multiwatcher.ApplicationInfo).Exposed) },
+		"charm-url":        func(e interface{}) string { return e.(*multiwatcher.ApplicationInfo).CharmURL },
+		"subordinate":      func(e interface{}) string { return strconv.FormatBool(e.(*multiwatcher.ApplicationInfo).Subordinate) },
+		"status":           func(e interface{}) string { return string(e.(*multiwatcher.ApplicationInfo).Status.Current) },
+		"message":          func(e interface{}) string { return e.(*multiwatcher.ApplicationInfo).Status.Message },
+		"workload-version": func(e interface{}) string { return e.(*multiwatcher.ApplicationInfo).WorkloadVersion },
+		"min-units":        func(e interface{}) string { return strconv.Itoa(e.(*multiwatcher.ApplicationInfo).MinUnits) },
+	},
+	"machine": {
+		"id":               func(e interface{}) string { return e.(*multiwatcher.MachineInfo).Id },
+		"life":             func(e interface{}) string { return string(e.(*multiwatcher.MachineInfo).Life) },
+		"series":           func(e interface{}) string { return e.(*multiwatcher.MachineInfo).Series },
+		"instance-id":      func(e interface{}) string { return e.(*multiwatcher.MachineInfo).InstanceId },
+		"agent-status":     func(e interface{}) string { return string(e.(*multiwatcher.MachineInfo).AgentStatus.Current) },
+		"agent-message":    func(e interface{}) string { return e.(*multiwatcher.MachineInfo).AgentStatus.Message },
+		"instance-status":  func(e interface{}) string { return string(e.(*multiwatcher.MachineInfo).InstanceStatus.Current) },
+		"instance-message": func(e interface{}) string { return e.(*multiwatcher.MachineInfo).InstanceStatus.Message },
+	},
+	"unit": {
+		"name":             func(e interface{}) string { return e.(*multiwatcher.UnitInfo).Name },
+		"application":      func(e interface{}) string { return e.(*multiwatcher.UnitInfo).Application },
+		"series":           func(e interface{}) string { return e.(*multiwatcher.UnitInfo).Series },
+		"charm-url":        func(e interface{}) string { return e.(*multiwatcher.UnitInfo).CharmURL },
+		"machine":          func(e interface{}) string { return e.(*multiwatcher.UnitInfo).MachineId },
+		"public-address":   func(e interface{}) string { return e.(*multiwatcher.UnitInfo).PublicAddress },
+		"private-address":  func(e interface{}) string { return e.(*multiwatcher.UnitInfo).PrivateAddress },
+		"subordinate":      func(e interface{}) string { return strconv.FormatBool(e.(*multiwatcher.UnitInfo).Subordinate) },
+		"workload-status":  func(e interface{}) string { return string(e.(*multiwatcher.UnitInfo).WorkloadStatus.Current) },
+		"workload-message": func(e interface{}) string { return e.(*multiwatcher.UnitInfo).WorkloadStatus.Message },
+		"agent-status":     func(e interface{}) string { return string(e.(*multiwatcher.UnitInfo).AgentStatus.Current) },
+		"agent-message":    func(e interface{}) string { return e.(*multiwatcher.UnitInfo).AgentStatus.Message },
+	},
+}
+
+// clientShim adapts *api.Client to the WaitForAPI interface.
+type clientShim struct {
+	*api.Client
+}
+
+func (c *clientShim) WatchAll() (AllWatcher, error) {
+	watcher, err := c.Client.WatchAll()
+	if err != nil {
+		return nil, err
+	}
+	return watcher, nil
+}
+
+// sortedKinds returns the entity kinds supported by the command.
+func sortedKinds() []string {
+	kinds := make([]string, 0, len(kindFields))
+	for kind := range kindFields {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
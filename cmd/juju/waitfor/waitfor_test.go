@@ -0,0 +1,225 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WaitForSuite struct {
+	testing.IsolationSuite
+	api   *fakeWaitForAPI
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&WaitForSuite{})
+
+func (s *WaitForSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &fakeWaitForAPI{
+		watcher: &fakeAllWatcher{
+			deltas:  make(chan []multiwatcher.Delta, 10),
+			stopped: make(chan struct{}),
+		},
+	}
+	s.clock = testing.NewClock(time.Now())
+}
+
+func (s *WaitForSuite) newCommand() cmd.Command {
+	return waitfor.NewWaitForCommandForTest(s.api, s.clock)
+}
+
+func (s *WaitForSuite) send(deltas ...multiwatcher.Delta) {
+	s.api.watcher.deltas <- deltas
+}
+
+func unit(name string, workload, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{
+		Entity: &multiwatcher.UnitInfo{
+			ModelUUID:      coretesting.ModelTag.Id(),
+			Name:           name,
+			Application:    "mysql",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: workload},
+			AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+		},
+	}
+}
+
+func (s *WaitForSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no entity kind specified",
+	}, {
+		args: []string{"widget"},
+		err:  `entity kind "widget" not valid, expected one of application, machine, model, unit`,
+	}, {
+		args: []string{"unit", "[mysql"},
+		err:  `name pattern "\[mysql" not valid`,
+	}, {
+		args: []string{"unit", "mysql/0", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"unit", "--timeout", "0s"},
+		err:  "timeout 0s not valid",
+	}, {
+		args: []string{"unit", "--query", "colour==red"},
+		err:  `invalid query: unknown field "colour", expected one of .*`,
+	}, {
+		args: []string{"unit", "--query", "agent-status=="},
+		err:  `invalid query: incomplete comparison at end of query "agent-status=="`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(s.newCommand(), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WaitForSuite) TestAlreadySatisfied(c *gc.C) {
+	s.send(
+		unit("mysql/0", status.Active, status.Idle),
+		multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}},
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(),
+		"unit", "mysql/*", "--query", "workload-status==active && agent-status==idle",
+		"--format", "json",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `{"unit":{"mysql/0":{`)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `"workload-status":"active"`)
+	c.Check(s.api.watcher.isStopped(), jc.IsTrue)
+	c.Check(s.api.closed, jc.IsTrue)
+}
+
+func (s *WaitForSuite) TestWaitsForAllMatching(c *gc.C) {
+	s.send(
+		unit("mysql/0", status.Active, status.Idle),
+		unit("mysql/1", status.Waiting, status.Executing),
+		unit("wordpress/0", status.Blocked, status.Idle),
+	)
+	s.send(unit("mysql/1", status.Active, status.Executing))
+	s.send(unit("mysql/1", status.Active, status.Idle))
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(),
+		"unit", "mysql/*", "--query", "workload-status==active && agent-status==idle",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Matches, `(?s)unit:
+  mysql/1:
+.*  agent-status: idle
+.*  workload-status: active
+.*`)
+	c.Check(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "mysql/0")
+	c.Check(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "wordpress/0")
+}
+
+func (s *WaitForSuite) TestRemovedEntityNoLongerConsidered(c *gc.C) {
+	s.send(
+		unit("mysql/0", status.Active, status.Idle),
+		unit("mysql/1", status.Error, status.Idle),
+	)
+	removed := unit("mysql/1", status.Error, status.Idle)
+	removed.Removed = true
+	s.send(removed)
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "unit", "--query", "workload-status!=error")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WaitForSuite) TestAlternatives(c *gc.C) {
+	s.send(multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{
+		Name:   "mysql",
+		Status: multiwatcher.StatusInfo{Current: status.Blocked},
+	}})
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(),
+		"application", "mysql", "--query", "status==active || status==blocked",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "status: blocked")
+}
+
+func (s *WaitForSuite) TestTimeout(c *gc.C) {
+	s.send(unit("mysql/0", status.Waiting, status.Executing))
+	errc := make(chan error, 1)
+	go func() {
+		_, err := cmdtesting.RunCommand(c, s.newCommand(),
+			"unit", "mysql/0", "--query", "workload-status==active", "--timeout", "5m",
+		)
+		errc <- err
+	}()
+	err := s.clock.WaitAdvance(5*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-errc:
+		c.Assert(err, gc.ErrorMatches,
+			`timed out after 5m0s waiting for unit "mysql/0" to satisfy "workload-status==active"`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+	c.Check(s.api.watcher.isStopped(), jc.IsTrue)
+}
+
+func (s *WaitForSuite) TestWatchError(c *gc.C) {
+	s.api.watcher.err = errors.New("boom")
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "model")
+	c.Assert(err, gc.ErrorMatches, "watching model: boom")
+}
+
+type fakeWaitForAPI struct {
+	watcher *fakeAllWatcher
+	closed  bool
+}
+
+func (f *fakeWaitForAPI) WatchAll() (waitfor.AllWatcher, error) {
+	return f.watcher, nil
+}
+
+func (f *fakeWaitForAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+type fakeAllWatcher struct {
+	deltas  chan []multiwatcher.Delta
+	stopped chan struct{}
+	err     error
+}
+
+func (f *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	select {
+	case deltas := <-f.deltas:
+		return deltas, nil
+	case <-f.stopped:
+		return nil, errors.New("watcher was stopped")
+	}
+}
+
+func (f *fakeAllWatcher) Stop() error {
+	close(f.stopped)
+	return nil
+}
+
+func (f *fakeAllWatcher) isStopped() bool {
+	select {
+	case <-f.stopped:
+		return true
+	default:
+		return false
+	}
+}
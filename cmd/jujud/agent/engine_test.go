@@ -44,6 +44,7 @@ var (
 		"migration-inactive-flag", // secondary dependency: will be inactive because depends on model-upgrader
 		"migration-master",        // secondary dependency: will be inactive because depends on model-upgrader
 		"model-upgrader",
+		"operation-runner",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"remote-relations",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"state-cleaner",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"status-history-pruner", // tertiary dependency: will be inactive because migration workers will be inactive
@@ -64,6 +65,7 @@ var (
 		"migration-inactive-flag",
 		"migration-master",
 		"application-scaler",
+		"operation-runner",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
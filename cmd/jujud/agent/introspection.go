@@ -4,12 +4,20 @@
 package agent
 
 import (
+	"io"
+	"net"
+	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	names "gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
@@ -115,3 +123,92 @@ func (h *statePoolIntrospectionReporter) IntrospectionReport() string {
 	}
 	return h.pool.IntrospectionReport()
 }
+
+// uniterMetricsPrefix is the prefix of the names of the metrics that
+// unit agents report about the hooks and actions they run.
+const uniterMetricsPrefix = "juju_uniter_"
+
+// unitAgentMetricsTimeout is how long to wait for a unit agent to
+// report its metrics.
+const unitAgentMetricsTimeout = 5 * time.Second
+
+// unitAgentsGatherer is a prometheus.Gatherer that collects the uniter
+// metrics from the introspection sockets of the unit agents deployed
+// alongside a machine agent. Unit agents run in their own processes,
+// so this is how their metrics come to be seen in the machine agent's.
+type unitAgentsGatherer struct {
+	dataDir       string
+	newSocketName func(names.Tag) string
+}
+
+// Gather is part of the prometheus.Gatherer interface.
+func (g unitAgentsGatherer) Gather() ([]*dto.MetricFamily, error) {
+	dirs, err := filepath.Glob(filepath.Join(agent.BaseDir(g.dataDir), names.UnitTagKind+"-*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byName := make(map[string]*dto.MetricFamily)
+	for _, dir := range dirs {
+		tag, err := names.ParseUnitTag(filepath.Base(dir))
+		if err != nil {
+			continue
+		}
+		families, err := gatherUnitAgentMetrics(g.newSocketName(tag))
+		if err != nil {
+			// The unit agent may be stopped, or not yet started;
+			// its metrics are left out until it's serving them.
+			logger.Debugf("cannot gather metrics from %s: %v", tag, err)
+			continue
+		}
+		for _, mf := range families {
+			if !strings.HasPrefix(mf.GetName(), uniterMetricsPrefix) {
+				continue
+			}
+			if existing, ok := byName[mf.GetName()]; ok {
+				existing.Metric = append(existing.Metric, mf.Metric...)
+			} else {
+				byName[mf.GetName()] = mf
+			}
+		}
+	}
+	result := make([]*dto.MetricFamily, 0, len(byName))
+	for _, mf := range byName {
+		result = append(result, mf)
+	}
+	return result, nil
+}
+
+// gatherUnitAgentMetrics returns the metrics served by the
+// introspection worker listening on the named abstract domain socket.
+func gatherUnitAgentMetrics(socketName string) ([]*dto.MetricFamily, error) {
+	client := &http.Client{
+		Transport: &http.Transport{
+			Dial: func(proto, addr string) (net.Conn, error) {
+				return net.Dial("unix", "@"+socketName)
+			},
+			DisableKeepAlives: true,
+		},
+		Timeout: unitAgentMetricsTimeout,
+	}
+	resp, err := client.Get("http://unix.socket/metrics/")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	var result []*dto.MetricFamily
+	decoder := expfmt.NewDecoder(resp.Body, expfmt.ResponseFormat(resp.Header))
+	for {
+		var mf dto.MetricFamily
+		if err := decoder.Decode(&mf); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotate(err, "decoding metrics")
+		}
+		result = append(result, &mf)
+	}
+	return result, nil
+}
@@ -4,6 +4,10 @@
 package agent
 
 import (
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
 	"runtime"
 	"time"
 
@@ -12,6 +16,8 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -112,6 +118,51 @@ func (s *introspectionSuite) TestDefaultIntrospectionSocketName(c *gc.C) {
 	c.Assert(name, gc.Equals, "jujud-machine-42")
 }
 
+func (s *introspectionSuite) TestUnitAgentsGatherer(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("abstract domain sockets not supported on non-linux")
+	}
+	dataDir := c.MkDir()
+	for _, tag := range []string{"unit-mysql-0", "unit-wordpress-0", "machine-0"} {
+		err := os.MkdirAll(filepath.Join(agent.BaseDir(dataDir), tag), 0755)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Only mysql/0 is serving its metrics; wordpress/0 is skipped.
+	registry := prometheus.NewRegistry()
+	err := registry.Register(prometheus.NewGoCollector())
+	c.Assert(err, jc.ErrorIsNil)
+	hookRuns := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "juju_uniter_hook_runs_total",
+		Help:        "Total number of hooks run",
+		ConstLabels: prometheus.Labels{"unit": "mysql/0"},
+	})
+	hookRuns.Add(3)
+	err = registry.Register(hookRuns)
+	c.Assert(err, jc.ErrorIsNil)
+
+	socketName := "jujud-test-" + names.NewUnitTag("mysql/0").String()
+	listener, err := net.Listen("unix", "@"+socketName)
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+	mux := http.NewServeMux()
+	mux.Handle("/metrics/", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	go http.Serve(listener, mux)
+
+	gatherer := unitAgentsGatherer{
+		dataDir: dataDir,
+		newSocketName: func(tag names.Tag) string {
+			return "jujud-test-" + tag.String()
+		},
+	}
+	families, err := gatherer.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(families, gc.HasLen, 1)
+	c.Check(families[0].GetName(), gc.Equals, "juju_uniter_hook_runs_total")
+	c.Assert(families[0].GetMetric(), gc.HasLen, 1)
+	c.Check(families[0].GetMetric()[0].GetCounter().GetValue(), gc.Equals, 3.0)
+}
+
 type dummyAgent struct {
 	agent.Agent
 }
@@ -588,6 +588,15 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			}
 			return nil, err
 		}
+		// The unit agents' metrics are included alongside the machine
+		// agent's own, so that they can be found in one place.
+		prometheusGatherer := prometheus.Gatherers{
+			a.prometheusRegistry,
+			unitAgentsGatherer{
+				dataDir:       a.CurrentConfig().DataDir(),
+				newSocketName: a.newIntrospectionSocketName,
+			},
+		}
 		if err := startIntrospection(introspectionConfig{
 			Agent:              a,
 			Engine:             engine,
@@ -595,7 +604,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			PubSubReporter:     pubsubReporter,
 			MachineLock:        a.machineLock,
 			NewSocketName:      a.newIntrospectionSocketName,
-			PrometheusGatherer: a.prometheusRegistry,
+			PrometheusGatherer: prometheusGatherer,
 			PresenceRecorder:   presenceRecorder,
 			WorkerFunc:         introspection.NewWorker,
 		}); err != nil {
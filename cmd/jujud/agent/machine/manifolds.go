@@ -29,7 +29,7 @@ import (
 	"github.com/juju/juju/container/lxd"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/presence"
-	"github.com/juju/juju/feature"
+	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/state"
 	proxyconfig "github.com/juju/juju/utils/proxy"
 	jworker "github.com/juju/juju/worker"
@@ -41,6 +41,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/common"
@@ -50,13 +51,13 @@ import (
 	"github.com/juju/juju/worker/diskmanager"
 	"github.com/juju/juju/worker/externalcontrollerupdater"
 	"github.com/juju/juju/worker/fanconfigurer"
-	"github.com/juju/juju/worker/featureflag"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/globalclockupdater"
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/httpserver"
 	"github.com/juju/juju/worker/identityfilewriter"
+	leasemanager "github.com/juju/juju/worker/lease/manifold"
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/machineactions"
@@ -71,6 +72,7 @@ import (
 	"github.com/juju/juju/worker/raft"
 	"github.com/juju/juju/worker/raft/raftbackstop"
 	"github.com/juju/juju/worker/raft/raftclusterer"
+	"github.com/juju/juju/worker/raft/raftforwarder"
 	"github.com/juju/juju/worker/raft/raftflag"
 	"github.com/juju/juju/worker/raft/rafttransport"
 	"github.com/juju/juju/worker/reboot"
@@ -95,6 +97,10 @@ const (
 	// globalClockUpdaterBackoffDelay is the amount of time to
 	// delay when a concurrent global clock update is detected.
 	globalClockUpdaterBackoffDelay = 10 * time.Second
+
+	// raftForwarderApplyTimeout is how long the raft forwarder will
+	// wait for a forwarded lease command to be enqueued.
+	raftForwarderApplyTimeout = 5 * time.Second
 )
 
 // ManifoldsConfig allows specialisation of the result of Manifolds.
@@ -271,6 +277,11 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 	machineTag := agentConfig.Tag().(names.MachineTag)
 	controllerTag := agentConfig.Controller()
 
+	// The raft lease FSM is shared between the raft worker, which
+	// applies replicated commands to it, and the lease manager and
+	// clock updater, which read lease state from it directly.
+	leaseFSM := raftlease.NewFSM()
+
 	return dependency.Manifolds{
 		// The agent manifold references the enclosing agent, and is the
 		// foundation stone on which most other manifolds ultimately depend.
@@ -680,6 +691,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName:  agentName,
+				ClockName:  clockName,
+				StateName:  stateName,
+				NewBackups: backupscheduler.NewBackups,
+				NewWorker:  backupscheduler.NewWorkerShim,
+			},
+		))),
+
 		httpServerName: httpserver.Manifold(httpserver.ManifoldConfig{
 			AgentName:             agentName,
 			CertWatcherName:       certificateWatcherName,
@@ -700,6 +721,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			UpgradeGateName:                   upgradeStepsGateName,
 			RestoreStatusName:                 restoreWatcherName,
 			AuditConfigUpdaterName:            auditConfigUpdaterName,
+			LeaseManagerName:                  leaseManagerName,
 			PrometheusRegisterer:              config.PrometheusRegisterer,
 			RegisterIntrospectionHTTPHandlers: config.RegisterIntrospectionHTTPHandlers,
 			Hub:       config.CentralHub,
@@ -740,17 +762,9 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker: auditconfigupdater.New,
 		})),
 
-		raftEnabledName: ifController(featureflag.Manifold(featureflag.ManifoldConfig{
-			StateName: stateName,
-			FlagName:  feature.DisableRaft,
-			Invert:    true,
-			Logger:    loggo.GetLogger("juju.worker.raft.raftenabled"),
-			NewWorker: featureflag.NewWorker,
-		})),
-
 		// All the other raft workers hang off the raft transport, so
-		// it's the only one that needs to be gated by the enabled flag.
-		raftTransportName: ifRaftEnabled(ifFullyUpgraded(rafttransport.Manifold(rafttransport.ManifoldConfig{
+		// it's the only one that needs to wait for the upgrade.
+		raftTransportName: ifFullyUpgraded(rafttransport.Manifold(rafttransport.ManifoldConfig{
 			ClockName:         clockName,
 			AgentName:         agentName,
 			AuthenticatorName: httpServerName,
@@ -759,13 +773,13 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			DialConn:          rafttransport.DialConn,
 			NewWorker:         rafttransport.NewWorker,
 			Path:              "/raft",
-		}))),
+		})),
 
 		raftName: raft.Manifold(raft.ManifoldConfig{
 			ClockName:     clockName,
 			AgentName:     agentName,
 			TransportName: raftTransportName,
-			FSM:           &raft.SimpleFSM{},
+			FSM:           leaseFSM,
 			Logger:        loggo.GetLogger("juju.worker.raft"),
 			NewWorker:     raft.NewWorker,
 		}),
@@ -784,6 +798,45 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:      raftclusterer.NewWorker,
 		})),
 
+		// The lease manager runs on every controller so the API
+		// server can use it; commands that can't be applied locally
+		// are forwarded over the hub to the raft leader.
+		leaseManagerName: leasemanager.Manifold(leasemanager.ManifoldConfig{
+			AgentName:      agentName,
+			ClockName:      clockName,
+			RaftName:       raftName,
+			CentralHubName: centralHubName,
+			StateName:      stateName,
+			FSM:            leaseFSM,
+			Secretary:      state.LeaseSecretary,
+			Logger:         loggo.GetLogger("juju.worker.lease.raft"),
+			NewStore:       leasemanager.NewStore,
+			NewWorker:      leasemanager.NewManagerWorker,
+			NewTarget:      leasemanager.NewTarget,
+		}),
+
+		// The raft forwarder and raft clock updater can only run on
+		// the raft leader, since only the leader can apply commands
+		// to the lease FSM.
+		raftForwarderName: ifRaftLeader(raftforwarder.Manifold(raftforwarder.ManifoldConfig{
+			RaftName:       raftName,
+			CentralHubName: centralHubName,
+			StateName:      stateName,
+			ApplyTimeout:   raftForwarderApplyTimeout,
+			Logger:         loggo.GetLogger("juju.worker.raft.raftforwarder"),
+			NewWorker:      raftforwarder.NewWorker,
+			NewTarget:      leasemanager.NewTarget,
+		})),
+
+		raftClockUpdaterName: ifRaftLeader(globalclockupdater.RaftManifold(globalclockupdater.RaftManifoldConfig{
+			ClockName:      clockName,
+			RaftName:       raftName,
+			FSM:            leaseFSM,
+			NewWorker:      globalclockupdater.NewWorker,
+			UpdateInterval: globalClockUpdaterUpdateInterval,
+			BackoffDelay:   globalClockUpdaterBackoffDelay,
+		})),
+
 		raftBackstopName: raftbackstop.Manifold(raftbackstop.ManifoldConfig{
 			RaftName:       raftName,
 			CentralHubName: centralHubName,
@@ -841,12 +894,6 @@ var ifRaftLeader = engine.Housing{
 	},
 }.Decorate
 
-var ifRaftEnabled = engine.Housing{
-	Flags: []string{
-		raftEnabledName,
-	},
-}.Decorate
-
 var ifCredentialValid = engine.Housing{
 	Flags: []string{
 		validCredentialFlagName,
@@ -901,6 +948,7 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	logPrunerName                 = "log-pruner"
 	txnPrunerName                 = "transaction-pruner"
+	backupSchedulerName           = "backup-scheduler"
 	certificateWatcherName        = "certificate-watcher"
 	modelWorkerManagerName        = "model-worker-manager"
 	peergrouperName               = "peer-grouper"
@@ -915,8 +963,11 @@ const (
 	raftName          = "raft"
 	raftClustererName = "raft-clusterer"
 	raftFlagName      = "raft-leader-flag"
-	raftEnabledName   = "raft-enabled-flag"
 	raftBackstopName  = "raft-backstop"
+	raftForwarderName = "raft-forwarder"
+
+	leaseManagerName     = "lease-manager"
+	raftClockUpdaterName = "raft-clock-updater"
 
 	validCredentialFlagName = "valid-credential-flag"
 )
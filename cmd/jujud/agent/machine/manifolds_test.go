@@ -50,6 +50,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-config-watcher",
 		"api-server",
 		"audit-config-updater",
+		"backup-scheduler",
 		"central-hub",
 		"certificate-updater",
 		"certificate-watcher",
@@ -62,6 +63,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"http-server",
 		"is-controller-flag",
 		"is-primary-controller-flag",
+		"lease-manager",
 		"log-pruner",
 		"log-sender",
 		"logging-config-updater",
@@ -78,8 +80,9 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"pubsub-forwarder",
 		"raft",
 		"raft-backstop",
+		"raft-clock-updater",
 		"raft-clusterer",
-		"raft-enabled-flag",
+		"raft-forwarder",
 		"raft-leader-flag",
 		"raft-transport",
 		"reboot-executor",
@@ -152,10 +155,12 @@ func (*ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"upgrader",
 		"raft",
 		"raft-backstop",
+		"raft-clock-updater",
 		"raft-clusterer",
-		"raft-enabled-flag",
+		"raft-forwarder",
 		"raft-leader-flag",
 		"raft-transport",
+		"lease-manager",
 		"valid-credential-flag",
 	)
 	manifolds := machine.Manifolds(machine.ManifoldsConfig{
@@ -178,9 +183,9 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 		"certificate-watcher",
 		"audit-config-updater",
 		"is-primary-controller-flag",
-		"raft-enabled-flag",
 	)
 	primaryControllerWorkers := set.NewStrings(
+		"backup-scheduler",
 		"external-controller-updater",
 		"log-pruner",
 		"transaction-pruner",
@@ -286,13 +291,20 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 	"api-server": {
 		"agent",
 		"audit-config-updater",
+		"central-hub",
 		"certificate-watcher",
 		"clock",
 		"http-server",
 		"is-controller-flag",
+		"lease-manager",
+		"raft",
+		"raft-transport",
 		"restore-watcher",
 		"state",
 		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
 		"upgrade-steps-gate"},
 
 	"audit-config-updater": {
@@ -301,6 +313,21 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state",
 		"state-config-watcher"},
 
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
 	"central-hub": {"agent", "state-config-watcher"},
 
 	"certificate-updater": {
@@ -394,6 +421,23 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state",
 		"state-config-watcher"},
 
+	"lease-manager": {
+		"agent",
+		"central-hub",
+		"certificate-watcher",
+		"clock",
+		"http-server",
+		"is-controller-flag",
+		"raft",
+		"raft-transport",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"log-pruner": {
 		"agent",
 		"api-caller",
@@ -531,7 +575,6 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"clock",
 		"http-server",
 		"is-controller-flag",
-		"raft-enabled-flag",
 		"raft-transport",
 		"state",
 		"state-config-watcher",
@@ -549,7 +592,6 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"http-server",
 		"is-controller-flag",
 		"raft",
-		"raft-enabled-flag",
 		"raft-transport",
 		"state",
 		"state-config-watcher",
@@ -559,7 +601,7 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
-	"raft-clusterer": {
+	"raft-clock-updater": {
 		"agent",
 		"central-hub",
 		"certificate-watcher",
@@ -567,7 +609,6 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"http-server",
 		"is-controller-flag",
 		"raft",
-		"raft-enabled-flag",
 		"raft-leader-flag",
 		"raft-transport",
 		"state",
@@ -578,11 +619,41 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
-	"raft-enabled-flag": {
+	"raft-forwarder": {
 		"agent",
+		"central-hub",
+		"certificate-watcher",
+		"clock",
+		"http-server",
 		"is-controller-flag",
+		"raft",
+		"raft-leader-flag",
+		"raft-transport",
 		"state",
-		"state-config-watcher"},
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
+	"raft-clusterer": {
+		"agent",
+		"central-hub",
+		"certificate-watcher",
+		"clock",
+		"http-server",
+		"is-controller-flag",
+		"raft",
+		"raft-leader-flag",
+		"raft-transport",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
 
 	"raft-leader-flag": {
 		"agent",
@@ -592,7 +663,6 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"http-server",
 		"is-controller-flag",
 		"raft",
-		"raft-enabled-flag",
 		"raft-transport",
 		"state",
 		"state-config-watcher",
@@ -609,7 +679,6 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"clock",
 		"http-server",
 		"is-controller-flag",
-		"raft-enabled-flag",
 		"state",
 		"state-config-watcher",
 		"upgrade-check-flag",
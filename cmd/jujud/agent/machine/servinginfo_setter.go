@@ -79,6 +79,12 @@ func ServingInfoSetterManifold(config ServingInfoSetterConfig) dependency.Manifo
 					// apiState.
 					info.Cert = existing.Cert
 					info.PrivateKey = existing.PrivateKey
+					// The secrets key is only held in agent
+					// config, so don't lose it if the API
+					// server we asked doesn't have one.
+					if info.SecretsKey == "" {
+						info.SecretsKey = existing.SecretsKey
+					}
 				}
 				config.SetStateServingInfo(info)
 				return nil
@@ -168,6 +168,20 @@ func (s *ServingInfoSetterSuite) TestJobManageEnvironNotOverwriteCert(c *gc.C) {
 	c.Assert(a.conf.ssi.PrivateKey, gc.Equals, existingKey)
 }
 
+func (s *ServingInfoSetterSuite) TestJobManageEnvironKeepsSecretsKey(c *gc.C) {
+	a := &mockAgent{}
+	a.conf.SetStateServingInfo(params.StateServingInfo{
+		Cert:       "cert",
+		PrivateKey: "key",
+		SecretsKey: "c2VjcmV0cy1rZXk=",
+	})
+
+	s.startManifold(c, a, 1234)
+
+	c.Assert(a.conf.ssiSet, jc.IsTrue)
+	c.Assert(a.conf.ssi.SecretsKey, gc.Equals, "c2VjcmV0cy1rZXk=")
+}
+
 func (s *ServingInfoSetterSuite) TestJobHostUnits(c *gc.C) {
 	// State serving info should not be set for JobHostUnits.
 	s.checkNotController(c, multiwatcher.JobHostUnits)
@@ -50,6 +50,7 @@ import (
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/modelupgrader"
+	"github.com/juju/juju/worker/operationrunner"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
@@ -267,12 +268,13 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     actionpruner.NewFacade,
 			PruneInterval: config.ActionPrunerInterval,
 		})),
+		operationRunnerName: ifNotMigrating(operationrunner.Manifold(operationrunner.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
-			Sinks: []logforwarder.LogSinkSpec{{
-				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
-			}},
+			Sinks:         sinks.Registry(agentConfig.LogDir(), modelTag.Id()),
 		})),
 		// The model upgrader runs on all controller agents, and
 		// unlocks the gate when the model is up-to-date. The
@@ -589,6 +591,7 @@ const (
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
+	operationRunnerName      = "operation-runner"
 
 	caasFirewallerName          = "caas-firewaller"
 	caasOperatorProvisionerName = "caas-operator-provisioner"
@@ -55,6 +55,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"model-upgrader",
 		"not-alive-flag",
 		"not-dead-flag",
+		"operation-runner",
 		"remote-relations",
 		"state-cleaner",
 		"status-history-pruner",
@@ -97,6 +98,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"model-upgrader",
 		"not-alive-flag",
 		"not-dead-flag",
+		"operation-runner",
 		"remote-relations",
 		"state-cleaner",
 		"status-history-pruner",
@@ -317,6 +319,17 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 	"not-dead-flag": {"agent", "api-caller"},
 
+	"operation-runner": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"remote-relations": {
 		"agent",
 		"api-caller",
@@ -534,6 +547,17 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 
 	"not-dead-flag": {"agent", "api-caller"},
 
+	"operation-runner": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"remote-relations": {
 		"agent",
 		"api-caller",
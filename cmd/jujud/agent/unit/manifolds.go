@@ -310,6 +310,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			CharmDirName:          charmDirName,
 			HookRetryStrategyName: hookRetryStrategyName,
 			TranslateResolverErr:  uniter.TranslateFortressErrors,
+			PrometheusRegisterer:  config.PrometheusRegisterer,
 		})),
 
 		// TODO (mattyw) should be added to machine agent.
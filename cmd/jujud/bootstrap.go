@@ -220,12 +220,19 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	// Generate the key used to encrypt secret values. It is only
+	// ever held in the controller agents' configuration.
+	secretsKey, err := state.NewControllerSecretsKey()
+	if err != nil {
+		return errors.Annotate(err, "failed to generate secrets key")
+	}
 	info, ok := agentConfig.StateServingInfo()
 	if !ok {
 		return fmt.Errorf("bootstrap machine config has no state serving info")
 	}
 	info.SharedSecret = sharedSecret
 	info.SystemIdentity = privateKey
+	info.SecretsKey = secretsKey
 	err = c.ChangeConfig(func(agentConfig agent.ConfigSetter) error {
 		agentConfig.SetStateServingInfo(info)
 		mmprof, err := mongo.NewMemoryProfile(args.ControllerConfig.MongoMemoryProfile())
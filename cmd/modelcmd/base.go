@@ -23,9 +23,11 @@ import (
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju"
+	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -411,6 +413,7 @@ func newAPIConnectionParams(
 	}
 	dialOpts := api.DefaultDialOpts()
 	dialOpts.BakeryClient = bakery
+	dialOpts.TraceContext = traceContext()
 
 	if accountDetails != nil {
 		bakery.WebPageVisitor = httpbakery.NewMultiVisitor(
@@ -429,6 +432,13 @@ func newAPIConnectionParams(
 	}, nil
 }
 
+// traceContext returns the trace that API requests made by this
+// process are part of, if tracing has been requested by setting
+// $JUJU_TRACE. All connections made by the process share the trace.
+func traceContext() trace.SpanContext {
+	return trace.ProcessSpanContext(os.Getenv(osenv.JujuTraceEnvKey))
+}
+
 // NewGetBootstrapConfigParamsFunc returns a function that, given a controller name,
 // returns the params needed to bootstrap a fresh copy of that controller in the given client store.
 func NewGetBootstrapConfigParamsFunc(
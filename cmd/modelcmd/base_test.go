@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
@@ -80,6 +81,43 @@ func (s *BaseCommandSuite) TestUnknownModelNotCurrent(c *gc.C) {
 	s.assertUnknownModel(c, "admin/goodmodel", "admin/goodmodel")
 }
 
+func (s *BaseCommandSuite) dialOpts(c *gc.C) api.DialOpts {
+	var dialOpts api.DialOpts
+	apiOpen := func(_ *api.Info, opts api.DialOpts) (api.Connection, error) {
+		dialOpts = opts
+		return nil, errors.New("boom")
+	}
+	baseCmd := new(modelcmd.ModelCommandBase)
+	baseCmd.SetClientStore(s.store)
+	baseCmd.SetAPIOpen(apiOpen)
+	modelcmd.InitContexts(&cmd.Context{Stderr: ioutil.Discard}, baseCmd)
+	modelcmd.SetRunStarted(baseCmd)
+	baseCmd.SetModelName("foo:admin/goodmodel", false)
+	_, err := baseCmd.NewAPIRoot()
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "boom")
+	return dialOpts
+}
+
+func (s *BaseCommandSuite) TestNoTraceContext(c *gc.C) {
+	dialOpts := s.dialOpts(c)
+	c.Assert(dialOpts.TraceContext, gc.Equals, trace.SpanContext{})
+}
+
+func (s *BaseCommandSuite) TestTraceContextFromEnvironment(c *gc.C) {
+	s.PatchEnvironment("JUJU_TRACE", "0123456789abcdef0123456789abcdef")
+	dialOpts := s.dialOpts(c)
+	c.Assert(dialOpts.TraceContext.TraceID, gc.Equals, "0123456789abcdef0123456789abcdef")
+	c.Assert(dialOpts.TraceContext.IsValid(), jc.IsTrue)
+}
+
+func (s *BaseCommandSuite) TestTraceContextGenerated(c *gc.C) {
+	s.PatchEnvironment("JUJU_TRACE", "1")
+	dialOpts := s.dialOpts(c)
+	c.Assert(dialOpts.TraceContext.IsValid(), jc.IsTrue)
+	// The same trace is used for all connections.
+	c.Assert(s.dialOpts(c).TraceContext, gc.Equals, dialOpts.TraceContext)
+}
+
 type NewGetBootstrapConfigParamsFuncSuite struct {
 	testing.IsolationSuite
 }
@@ -20,7 +20,9 @@ import (
 	"gopkg.in/macaroon-bakery.v2-unstable/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/backups"
 	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 const (
@@ -57,6 +59,33 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogSyslogHost is the host-port of a remote syslog server
+	// (RFC 5424 over TLS) to which audit log records are forwarded
+	// in addition to being written to the local audit log file.
+	AuditLogSyslogHost = "audit-log-syslog-host"
+
+	// AuditLogSyslogCACert is the CA certificate (x.509, PEM-encoded)
+	// used to validate the audit log syslog server's certificate.
+	AuditLogSyslogCACert = "audit-log-syslog-ca-cert"
+
+	// AuditLogSyslogClientCert is the client certificate (x.509,
+	// PEM-encoded) used when connecting to the audit log syslog server.
+	AuditLogSyslogClientCert = "audit-log-syslog-client-cert"
+
+	// AuditLogSyslogClientKey is the client private key (PEM-encoded)
+	// used when connecting to the audit log syslog server.
+	AuditLogSyslogClientKey = "audit-log-syslog-client-key"
+
+	// AuditLogWebhookURL is an http or https URL to which audit log
+	// records are POSTed as JSON.
+	AuditLogWebhookURL = "audit-log-webhook-url"
+
+	// AuditLogForwardBufferSize is the number of audit log records
+	// that will be held for each forwarding target while it is
+	// unavailable. Records are dropped once the buffer is full, so
+	// that an unavailable target never blocks API calls.
+	AuditLogForwardBufferSize = "audit-log-forward-buffer-size"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -118,6 +147,90 @@ const (
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
+	// BackupSchedule is the cron-like schedule on which the controller
+	// takes backups of itself, eg "0 2 * * *" or "@every 12h". Scheduled
+	// backups are disabled if it is empty.
+	BackupSchedule = "backup-schedule"
+
+	// BackupRetentionCount is the number of scheduled backups to keep;
+	// older ones are removed after each scheduled backup. Zero means
+	// keep them all.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupRetentionAge is the maximum age of scheduled backups, eg
+	// "720h"; older ones are removed after each scheduled backup. Empty
+	// means keep them regardless of age.
+	BackupRetentionAge = "backup-retention-age"
+
+	// BackupTarget is where scheduled backup archives are written, eg
+	// "file:/var/backups/juju" or "s3://bucket/prefix". If it is empty
+	// archives are kept in the controller's own database.
+	BackupTarget = "backup-target"
+
+	// BackupS3Endpoint is the URL of the S3-compatible object store
+	// used by s3 backup targets.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region of the bucket used by s3 backup
+	// targets.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3AccessKey is the access key used by s3 backup targets.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used by s3 backup targets.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
+	// TracingExporter is where the API server sends the spans it
+	// records for traced requests: "file" writes them to traces.log
+	// in the controller's log directory, and "otlp" sends them to the
+	// OpenTelemetry collector at TracingOTLPURL. Tracing is disabled
+	// if it is empty. Each API server reads it when it starts.
+	TracingExporter = "tracing-exporter"
+
+	// TracingOTLPURL is the http or https URL of the OpenTelemetry
+	// collector, or Jaeger instance, that spans are sent to by the
+	// "otlp" tracing exporter, eg "http://jaeger:4318/v1/traces".
+	TracingOTLPURL = "tracing-otlp-url"
+
+	// TracingExporterFile is the TracingExporter value that writes
+	// spans to a file.
+	TracingExporterFile = "file"
+
+	// TracingExporterOTLP is the TracingExporter value that sends
+	// spans to an OpenTelemetry collector.
+	TracingExporterOTLP = "otlp"
+
+	// APIUserRequestRate is the number of API requests per second
+	// that each user may make to an API server once their burst
+	// allowance is spent. Each API server applies the limit
+	// separately, and reads it when it starts. Zero disables the
+	// limit.
+	APIUserRequestRate = "api-user-request-rate"
+
+	// APIUserRequestBurst is the number of API requests that each
+	// user may make in a burst above APIUserRequestRate.
+	APIUserRequestBurst = "api-user-request-burst"
+
+	// APIUserMaxConnections is the number of connections that each
+	// user may have open to an API server at once. Zero disables the
+	// limit.
+	APIUserMaxConnections = "api-user-max-connections"
+
+	// APIAgentRequestRate is the number of API requests per second
+	// that each machine or unit agent may make to an API server once
+	// its burst allowance is spent. Zero disables the limit.
+	APIAgentRequestRate = "api-agent-request-rate"
+
+	// APIAgentRequestBurst is the number of API requests that each
+	// agent may make in a burst above APIAgentRequestRate.
+	APIAgentRequestBurst = "api-agent-request-burst"
+
+	// APIAgentMaxConnections is the number of connections that each
+	// machine or unit agent may have open to an API server at once.
+	// Zero disables the limit.
+	APIAgentMaxConnections = "api-agent-max-connections"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -136,6 +249,34 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultAuditLogForwardBufferSize is the default number of
+	// records buffered for each audit log forwarding target.
+	DefaultAuditLogForwardBufferSize = 1000
+
+	// DefaultAPIUserRequestRate is the default for the
+	// APIUserRequestRate setting (no limit).
+	DefaultAPIUserRequestRate = 0
+
+	// DefaultAPIUserRequestBurst is the default for the
+	// APIUserRequestBurst setting.
+	DefaultAPIUserRequestBurst = 1000
+
+	// DefaultAPIUserMaxConnections is the default for the
+	// APIUserMaxConnections setting (no limit).
+	DefaultAPIUserMaxConnections = 0
+
+	// DefaultAPIAgentRequestRate is the default for the
+	// APIAgentRequestRate setting (no limit).
+	DefaultAPIAgentRequestRate = 0
+
+	// DefaultAPIAgentRequestBurst is the default for the
+	// APIAgentRequestBurst setting.
+	DefaultAPIAgentRequestBurst = 500
+
+	// DefaultAPIAgentMaxConnections is the default for the
+	// APIAgentMaxConnections setting (no limit).
+	DefaultAPIAgentMaxConnections = 0
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -197,6 +338,22 @@ var (
 		MaxLogsSize,
 		MaxLogsAge,
 		MaxTxnLogSize,
+		BackupSchedule,
+		BackupRetentionCount,
+		BackupRetentionAge,
+		BackupTarget,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+		TracingExporter,
+		TracingOTLPURL,
+		APIUserRequestRate,
+		APIUserRequestBurst,
+		APIUserMaxConnections,
+		APIAgentRequestRate,
+		APIAgentRequestBurst,
+		APIAgentMaxConnections,
 		JujuHASpace,
 		JujuManagementSpace,
 		AuditingEnabled,
@@ -204,6 +361,12 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogSyslogHost,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogWebhookURL,
+		AuditLogForwardBufferSize,
 		CAASOperatorImagePath,
 		Features,
 		MeteringURL,
@@ -216,10 +379,41 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		AuditLogSyslogHost,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogWebhookURL,
+		AuditLogForwardBufferSize,
+		BackupSchedule,
+		BackupRetentionCount,
+		BackupRetentionAge,
+		BackupTarget,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 		JujuHASpace,
 		JujuManagementSpace,
 		CAASOperatorImagePath,
 		Features,
+		APIUserRequestRate,
+		APIUserRequestBurst,
+		APIUserMaxConnections,
+		APIAgentRequestRate,
+		APIAgentRequestBurst,
+		APIAgentMaxConnections,
+		TracingExporter,
+		TracingOTLPURL,
+	)
+
+	// secretAttributes contains the controller config attributes that
+	// hold credentials. They're only used by controller workers, which
+	// read the config from state, so they aren't returned by the API.
+	secretAttributes = set.NewStrings(
+		AuditLogSyslogClientKey,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -247,6 +441,18 @@ func ControllerOnlyAttribute(attr string) bool {
 // Config is a string-keyed map of controller configuration attributes.
 type Config map[string]interface{}
 
+// WithoutSecrets returns a copy of the config without the attributes
+// that hold credentials, for returning to API clients and agents.
+func (c Config) WithoutSecrets() Config {
+	result := make(Config, len(c))
+	for name, value := range c {
+		if !secretAttributes.Contains(name) {
+			result[name] = value
+		}
+	}
+	return result
+}
+
 // Validate validates the controller configuration.
 func (c Config) Validate() error {
 	return Validate(c)
@@ -367,6 +573,39 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogSyslogConfig returns the configuration for forwarding audit
+// log records to a remote syslog server. The returned config is only
+// enabled if a syslog host has been specified.
+func (c Config) AuditLogSyslogConfig() syslog.RawConfig {
+	host := c.asString(AuditLogSyslogHost)
+	return syslog.RawConfig{
+		Enabled:    host != "",
+		Host:       host,
+		CACert:     c.asString(AuditLogSyslogCACert),
+		ClientCert: c.asString(AuditLogSyslogClientCert),
+		ClientKey:  c.asString(AuditLogSyslogClientKey),
+	}
+}
+
+// AuditLogWebhookURL returns the URL to which audit log records
+// should be POSTed, or "" if there is none.
+func (c Config) AuditLogWebhookURL() string {
+	return c.asString(AuditLogWebhookURL)
+}
+
+// AuditLogForwardBufferSize returns the number of audit log records
+// to buffer for each forwarding target.
+func (c Config) AuditLogForwardBufferSize() int {
+	if value, ok := c[AuditLogForwardBufferSize]; ok {
+		// Values obtained over the API are encoded as float64.
+		if floatValue, ok := value.(float64); ok {
+			return int(floatValue)
+		}
+		return value.(int)
+	}
+	return DefaultAuditLogForwardBufferSize
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -485,6 +724,111 @@ func (c Config) MaxTxnLogSizeMB() int {
 	return int(val)
 }
 
+// BackupSchedule returns the schedule on which the controller takes
+// backups of itself, or "" if scheduled backups are disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupRetentionCount returns the number of scheduled backups to
+// keep. Zero means keep them all.
+func (c Config) BackupRetentionCount() int {
+	// Values obtained over the API are encoded as float64.
+	if value, ok := c[BackupRetentionCount].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[BackupRetentionCount].(int)
+	return value
+}
+
+// BackupRetentionAge returns the maximum age of scheduled backups.
+// Zero means keep them regardless of age.
+func (c Config) BackupRetentionAge() time.Duration {
+	// Value has already been validated.
+	age, _ := time.ParseDuration(c.asString(BackupRetentionAge))
+	return age
+}
+
+// BackupTarget returns where scheduled backup archives are written,
+// or "" if they are kept in the controller's database.
+func (c Config) BackupTarget() string {
+	return c.asString(BackupTarget)
+}
+
+// BackupS3Config returns the details used to connect to the object
+// store for s3 backup targets.
+func (c Config) BackupS3Config() backups.S3Config {
+	return backups.S3Config{
+		Endpoint:  c.asString(BackupS3Endpoint),
+		Region:    c.asString(BackupS3Region),
+		AccessKey: c.asString(BackupS3AccessKey),
+		SecretKey: c.asString(BackupS3SecretKey),
+	}
+}
+
+// TracingExporter returns where spans recorded for traced API
+// requests are sent, or "" if tracing is disabled.
+func (c Config) TracingExporter() string {
+	return c.asString(TracingExporter)
+}
+
+// TracingOTLPURL returns the URL that the "otlp" tracing exporter
+// sends spans to.
+func (c Config) TracingOTLPURL() string {
+	return c.asString(TracingOTLPURL)
+}
+
+// APIUserRequestRate returns the number of API requests per second
+// that each user may make, or zero if requests are not limited.
+func (c Config) APIUserRequestRate() int {
+	return c.intOrDefault(APIUserRequestRate, DefaultAPIUserRequestRate)
+}
+
+// APIUserRequestBurst returns the number of API requests that each
+// user may make in a burst above their request rate.
+func (c Config) APIUserRequestBurst() int {
+	return c.intOrDefault(APIUserRequestBurst, DefaultAPIUserRequestBurst)
+}
+
+// APIUserMaxConnections returns the number of API connections that
+// each user may have open at once, or zero if there is no limit.
+func (c Config) APIUserMaxConnections() int {
+	return c.intOrDefault(APIUserMaxConnections, DefaultAPIUserMaxConnections)
+}
+
+// APIAgentRequestRate returns the number of API requests per second
+// that each machine or unit agent may make, or zero if requests are
+// not limited.
+func (c Config) APIAgentRequestRate() int {
+	return c.intOrDefault(APIAgentRequestRate, DefaultAPIAgentRequestRate)
+}
+
+// APIAgentRequestBurst returns the number of API requests that each
+// agent may make in a burst above its request rate.
+func (c Config) APIAgentRequestBurst() int {
+	return c.intOrDefault(APIAgentRequestBurst, DefaultAPIAgentRequestBurst)
+}
+
+// APIAgentMaxConnections returns the number of API connections that
+// each machine or unit agent may have open at once, or zero if there
+// is no limit.
+func (c Config) APIAgentMaxConnections() int {
+	return c.intOrDefault(APIAgentMaxConnections, DefaultAPIAgentMaxConnections)
+}
+
+// intOrDefault returns the integer value of the given key, or
+// defaultValue if it is not set.
+func (c Config) intOrDefault(key string, defaultValue int) int {
+	// Values obtained over the API are encoded as float64.
+	switch value := c[key].(type) {
+	case float64:
+		return int(value)
+	case int:
+		return value
+	}
+	return defaultValue
+}
+
 // JujuHASpace is the network space within which the MongoDB replica-set
 // should communicate.
 func (c Config) JujuHASpace() string {
@@ -571,6 +915,44 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := backups.ParseSchedule(v); err != nil {
+			return errors.Annotate(err, "invalid backup schedule in configuration")
+		}
+	}
+
+	if v, ok := c[BackupRetentionCount].(int); ok {
+		if v < 0 {
+			return errors.Errorf("invalid backup retention count: should be a number of backups (or 0 to keep all), got %d", v)
+		}
+	}
+
+	if v, ok := c[BackupRetentionAge].(string); ok && v != "" {
+		age, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid backup retention age in configuration")
+		}
+		if age < 0 {
+			return errors.Errorf("invalid backup retention age: should not be negative, got %v", age)
+		}
+	}
+
+	if v, ok := c[BackupTarget].(string); ok && v != "" {
+		if _, err := backups.ParseTargetSpec(v); err != nil {
+			return errors.Annotate(err, "invalid backup target in configuration")
+		}
+	}
+
+	if v, ok := c[BackupS3Endpoint].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid backup s3 endpoint")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid backup s3 endpoint: expected http or https scheme, got %q", v)
+		}
+	}
+
 	if err := c.validateSpaceConfig(JujuHASpace, "juju HA"); err != nil {
 		return errors.Trace(err)
 	}
@@ -619,6 +1001,70 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.AuditLogSyslogConfig().Validate(); err != nil {
+		return errors.Annotate(err, "invalid audit log syslog config")
+	}
+
+	if v, ok := c[AuditLogWebhookURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid audit log webhook URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid audit log webhook URL: expected http or https scheme, got %q", v)
+		}
+	}
+
+	if v, ok := c[AuditLogForwardBufferSize].(int); ok {
+		if v <= 0 {
+			return errors.Errorf("invalid audit log forward buffer size: should be a positive number of records, got %d", v)
+		}
+	}
+
+	switch exporter := c.TracingExporter(); exporter {
+	case "", TracingExporterFile:
+	case TracingExporterOTLP:
+		v := c.TracingOTLPURL()
+		if v == "" {
+			return errors.Errorf("%s must be set when using the %q tracing exporter", TracingOTLPURL, exporter)
+		}
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid tracing OTLP URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid tracing OTLP URL: expected http or https scheme, got %q", v)
+		}
+	default:
+		return errors.Errorf("invalid tracing exporter %q: expected %q or %q", exporter, TracingExporterFile, TracingExporterOTLP)
+	}
+
+	for _, key := range []string{
+		APIUserRequestRate,
+		APIUserRequestBurst,
+		APIUserMaxConnections,
+		APIAgentRequestRate,
+		APIAgentRequestBurst,
+		APIAgentMaxConnections,
+	} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.Errorf("invalid %s: should be a positive number (or 0 for no limit), got %d", key, v)
+		}
+	}
+	for _, key := range []string{APIUserRequestRate, APIAgentRequestRate} {
+		// The rate is turned into the interval between requests,
+		// which must be at least a nanosecond.
+		if v, ok := c[key].(int); ok && v > int(time.Second) {
+			return errors.Errorf("invalid %s: should be at most %d, got %d", key, int(time.Second), v)
+		}
+	}
+	if c.APIUserRequestRate() > 0 && c.APIUserRequestBurst() <= 0 {
+		return errors.Errorf("%s must be positive when %s is set", APIUserRequestBurst, APIUserRequestRate)
+	}
+	if c.APIAgentRequestRate() > 0 && c.APIAgentRequestBurst() <= 0 {
+		return errors.Errorf("%s must be positive when %s is set", APIAgentRequestBurst, APIAgentRequestRate)
+	}
+
 	return nil
 }
 
@@ -674,51 +1120,95 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	AuditLogCaptureArgs:     schema.Bool(),
-	AuditLogMaxSize:         schema.String(),
-	AuditLogMaxBackups:      schema.ForceInt(),
-	AuditLogExcludeMethods:  schema.List(schema.String()),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
-	MaxLogsAge:              schema.String(),
-	MaxLogsSize:             schema.String(),
-	MaxTxnLogSize:           schema.String(),
-	JujuHASpace:             schema.String(),
-	JujuManagementSpace:     schema.String(),
-	CAASOperatorImagePath:   schema.String(),
-	Features:                schema.List(schema.String()),
-	CharmStoreURL:           schema.String(),
-	MeteringURL:             schema.String(),
+	AuditingEnabled:           schema.Bool(),
+	AuditLogCaptureArgs:       schema.Bool(),
+	AuditLogMaxSize:           schema.String(),
+	AuditLogMaxBackups:        schema.ForceInt(),
+	AuditLogExcludeMethods:    schema.List(schema.String()),
+	AuditLogSyslogHost:        schema.String(),
+	AuditLogSyslogCACert:      schema.String(),
+	AuditLogSyslogClientCert:  schema.String(),
+	AuditLogSyslogClientKey:   schema.String(),
+	AuditLogWebhookURL:        schema.String(),
+	AuditLogForwardBufferSize: schema.ForceInt(),
+	APIPort:                   schema.ForceInt(),
+	StatePort:                 schema.ForceInt(),
+	IdentityURL:               schema.String(),
+	IdentityPublicKey:         schema.String(),
+	SetNUMAControlPolicyKey:   schema.Bool(),
+	AutocertURLKey:            schema.String(),
+	AutocertDNSNameKey:        schema.String(),
+	AllowModelAccessKey:       schema.Bool(),
+	MongoMemoryProfile:        schema.String(),
+	MaxLogsAge:                schema.String(),
+	MaxLogsSize:               schema.String(),
+	MaxTxnLogSize:             schema.String(),
+	BackupSchedule:            schema.String(),
+	BackupRetentionCount:      schema.ForceInt(),
+	BackupRetentionAge:        schema.String(),
+	BackupTarget:              schema.String(),
+	BackupS3Endpoint:          schema.String(),
+	BackupS3Region:            schema.String(),
+	BackupS3AccessKey:         schema.String(),
+	BackupS3SecretKey:         schema.String(),
+	TracingExporter:           schema.String(),
+	TracingOTLPURL:            schema.String(),
+	APIUserRequestRate:        schema.ForceInt(),
+	APIUserRequestBurst:       schema.ForceInt(),
+	APIUserMaxConnections:     schema.ForceInt(),
+	APIAgentRequestRate:       schema.ForceInt(),
+	APIAgentRequestBurst:      schema.ForceInt(),
+	APIAgentMaxConnections:    schema.ForceInt(),
+	JujuHASpace:               schema.String(),
+	JujuManagementSpace:       schema.String(),
+	CAASOperatorImagePath:     schema.String(),
+	Features:                  schema.List(schema.String()),
+	CharmStoreURL:             schema.String(),
+	MeteringURL:               schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	AuditLogCaptureArgs:     DefaultAuditLogCaptureArgs,
-	AuditLogMaxSize:         fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
-	AuditLogMaxBackups:      DefaultAuditLogMaxBackups,
-	AuditLogExcludeMethods:  DefaultAuditLogExcludeMethods,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      schema.Omit,
-	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
-	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
-	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
-	JujuHASpace:             schema.Omit,
-	JujuManagementSpace:     schema.Omit,
-	CAASOperatorImagePath:   schema.Omit,
-	Features:                schema.Omit,
-	CharmStoreURL:           csclient.ServerURL,
-	MeteringURL:             romulus.DefaultAPIRoot,
+	APIPort:                   DefaultAPIPort,
+	AuditingEnabled:           DefaultAuditingEnabled,
+	AuditLogCaptureArgs:       DefaultAuditLogCaptureArgs,
+	AuditLogMaxSize:           fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
+	AuditLogMaxBackups:        DefaultAuditLogMaxBackups,
+	AuditLogExcludeMethods:    DefaultAuditLogExcludeMethods,
+	AuditLogSyslogHost:        schema.Omit,
+	AuditLogSyslogCACert:      schema.Omit,
+	AuditLogSyslogClientCert:  schema.Omit,
+	AuditLogSyslogClientKey:   schema.Omit,
+	AuditLogWebhookURL:        schema.Omit,
+	AuditLogForwardBufferSize: DefaultAuditLogForwardBufferSize,
+	StatePort:                 DefaultStatePort,
+	IdentityURL:               schema.Omit,
+	IdentityPublicKey:         schema.Omit,
+	SetNUMAControlPolicyKey:   DefaultNUMAControlPolicy,
+	AutocertURLKey:            schema.Omit,
+	AutocertDNSNameKey:        schema.Omit,
+	AllowModelAccessKey:       schema.Omit,
+	MongoMemoryProfile:        schema.Omit,
+	MaxLogsAge:                fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
+	MaxLogsSize:               fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
+	MaxTxnLogSize:             fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	BackupSchedule:            schema.Omit,
+	BackupRetentionCount:      schema.Omit,
+	BackupRetentionAge:        schema.Omit,
+	BackupTarget:              schema.Omit,
+	BackupS3Endpoint:          schema.Omit,
+	BackupS3Region:            schema.Omit,
+	BackupS3AccessKey:         schema.Omit,
+	BackupS3SecretKey:         schema.Omit,
+	TracingExporter:           schema.Omit,
+	TracingOTLPURL:            schema.Omit,
+	APIUserRequestRate:        schema.Omit,
+	APIUserRequestBurst:       schema.Omit,
+	APIUserMaxConnections:     schema.Omit,
+	APIAgentRequestRate:       schema.Omit,
+	APIAgentRequestBurst:      schema.Omit,
+	APIAgentMaxConnections:    schema.Omit,
+	JujuHASpace:               schema.Omit,
+	JujuManagementSpace:       schema.Omit,
+	CAASOperatorImagePath:     schema.Omit,
+	Features:                  schema.Omit,
+	CharmStoreURL:             csclient.ServerURL,
+	MeteringURL:               romulus.DefaultAPIRoot,
 })
@@ -18,6 +18,8 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/backups"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -188,6 +190,112 @@ var validateTests = []struct {
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "ReadOnlyMethods", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "Sharon Jones" at position 3`,
+}, {
+	about: "audit log syslog host without TLS config",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.AuditLogSyslogHost: "syslog.example.com:6514",
+	},
+	expectError: `invalid audit log syslog config: validating TLS config: parsing client key pair: .*`,
+}, {
+	about: "invalid audit log webhook URL scheme",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.AuditLogWebhookURL: "ftp://audit.example.com/",
+	},
+	expectError: `invalid audit log webhook URL: expected http or https scheme, got "ftp://audit.example.com/"`,
+}, {
+	about: "invalid audit log forward buffer size",
+	config: controller.Config{
+		controller.CACertKey:                 testing.CACert,
+		controller.AuditLogForwardBufferSize: 0,
+	},
+	expectError: `invalid audit log forward buffer size: should be a positive number of records, got 0`,
+}, {
+	about: "invalid tracing exporter",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.TracingExporter: "zipkin",
+	},
+	expectError: `invalid tracing exporter "zipkin": expected "file" or "otlp"`,
+}, {
+	about: "missing tracing OTLP URL",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.TracingExporter: "otlp",
+	},
+	expectError: `tracing-otlp-url must be set when using the "otlp" tracing exporter`,
+}, {
+	about: "invalid tracing OTLP URL scheme",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.TracingExporter: "otlp",
+		controller.TracingOTLPURL:  "grpc://jaeger:4317",
+	},
+	expectError: `invalid tracing OTLP URL: expected http or https scheme, got "grpc://jaeger:4317"`,
+}, {
+	about: "negative API user request rate",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.APIUserRequestRate: -1,
+	},
+	expectError: `invalid api-user-request-rate: should be a positive number \(or 0 for no limit\), got -1`,
+}, {
+	about: "negative API agent max connections",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.APIAgentMaxConnections: -5,
+	},
+	expectError: `invalid api-agent-max-connections: should be a positive number \(or 0 for no limit\), got -5`,
+}, {
+	about: "API user request rate too high",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.APIUserRequestRate: 2000000000,
+	},
+	expectError: `invalid api-user-request-rate: should be at most 1000000000, got 2000000000`,
+}, {
+	about: "zero API agent request burst",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.APIAgentRequestBurst: 0,
+	},
+	expectError: `api-agent-request-burst must be positive when api-agent-request-rate is set`,
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupSchedule: "0 25 * * *",
+	},
+	expectError: `invalid backup schedule in configuration: backup schedule "0 25 \* \* \*": range "25" \(must be within 0-23\) not valid`,
+}, {
+	about: "negative backup retention count",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.BackupRetentionCount: -1,
+	},
+	expectError: `invalid backup retention count: should be a number of backups \(or 0 to keep all\), got -1`,
+}, {
+	about: "invalid backup retention age",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.BackupRetentionAge: "a week",
+	},
+	expectError: `invalid backup retention age in configuration: time: invalid duration .*`,
+}, {
+	about: "invalid backup target",
+	config: controller.Config{
+		controller.CACertKey:    testing.CACert,
+		controller.BackupTarget: "file:backups",
+	},
+	expectError: `invalid backup target in configuration: backup target "file:backups": directory must be absolute not valid`,
+}, {
+	about: "invalid backup s3 endpoint scheme",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.BackupS3Endpoint: "ftp://s3.example.com",
+	},
+	expectError: `invalid backup s3 endpoint: expected http or https scheme, got "ftp://s3.example.com"`,
 }, {
 	about: "invalid CAAS operator docker image path",
 	config: controller.Config{
@@ -320,6 +428,124 @@ func (s *ConfigSuite) TestAuditLogDefaults(c *gc.C) {
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals,
 		set.NewStrings(controller.DefaultAuditLogExcludeMethods...))
+	c.Assert(cfg.AuditLogSyslogConfig().Enabled, gc.Equals, false)
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "")
+	c.Assert(cfg.AuditLogForwardBufferSize(), gc.Equals, 1000)
+}
+
+func (s *ConfigSuite) TestAuditLogForwardingValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"audit-log-syslog-host":         "syslog.example.com:6514",
+			"audit-log-syslog-ca-cert":      testing.CACert,
+			"audit-log-syslog-client-cert":  testing.ServerCert,
+			"audit-log-syslog-client-key":   testing.ServerKey,
+			"audit-log-webhook-url":         "https://audit.example.com/juju",
+			"audit-log-forward-buffer-size": 50.0,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSyslogConfig(), jc.DeepEquals, syslog.RawConfig{
+		Enabled:    true,
+		Host:       "syslog.example.com:6514",
+		CACert:     testing.CACert,
+		ClientCert: testing.ServerCert,
+		ClientKey:  testing.ServerKey,
+	})
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "https://audit.example.com/juju")
+	c.Assert(cfg.AuditLogForwardBufferSize(), gc.Equals, 50)
+}
+
+func (s *ConfigSuite) TestTracingValues(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingExporter(), gc.Equals, "")
+	c.Assert(cfg.TracingOTLPURL(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"tracing-exporter": "otlp",
+			"tracing-otlp-url": "http://jaeger:4318/v1/traces",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingExporter(), gc.Equals, "otlp")
+	c.Assert(cfg.TracingOTLPURL(), gc.Equals, "http://jaeger:4318/v1/traces")
+}
+
+func (s *ConfigSuite) TestAPILimitDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIUserRequestRate(), gc.Equals, controller.DefaultAPIUserRequestRate)
+	c.Assert(cfg.APIUserRequestBurst(), gc.Equals, controller.DefaultAPIUserRequestBurst)
+	c.Assert(cfg.APIUserMaxConnections(), gc.Equals, controller.DefaultAPIUserMaxConnections)
+	c.Assert(cfg.APIAgentRequestRate(), gc.Equals, controller.DefaultAPIAgentRequestRate)
+	c.Assert(cfg.APIAgentRequestBurst(), gc.Equals, controller.DefaultAPIAgentRequestBurst)
+	c.Assert(cfg.APIAgentMaxConnections(), gc.Equals, controller.DefaultAPIAgentMaxConnections)
+}
+
+func (s *ConfigSuite) TestAPILimitValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"api-user-request-rate":     0,
+			"api-user-request-burst":    0,
+			"api-user-max-connections":  10,
+			"api-agent-request-rate":    5.0,
+			"api-agent-request-burst":   20.0,
+			"api-agent-max-connections": 2,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIUserRequestRate(), gc.Equals, 0)
+	c.Assert(cfg.APIUserRequestBurst(), gc.Equals, 0)
+	c.Assert(cfg.APIUserMaxConnections(), gc.Equals, 10)
+	c.Assert(cfg.APIAgentRequestRate(), gc.Equals, 5)
+	c.Assert(cfg.APIAgentRequestBurst(), gc.Equals, 20)
+	c.Assert(cfg.APIAgentMaxConnections(), gc.Equals, 2)
+}
+
+func (s *ConfigSuite) TestBackupDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "")
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 0)
+	c.Assert(cfg.BackupRetentionAge(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.BackupTarget(), gc.Equals, "")
+	c.Assert(cfg.BackupS3Config(), gc.Equals, backups.S3Config{})
+}
+
+func (s *ConfigSuite) TestBackupValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":        "@daily",
+			"backup-retention-count": 7.0,
+			"backup-retention-age":   "720h",
+			"backup-target":          "s3://juju-backups/prod",
+			"backup-s3-endpoint":     "https://s3.example.com",
+			"backup-s3-region":       "us-east-1",
+			"backup-s3-access-key":   "access",
+			"backup-s3-secret-key":   "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "@daily")
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 7)
+	c.Assert(cfg.BackupRetentionAge(), gc.Equals, 720*time.Hour)
+	c.Assert(cfg.BackupTarget(), gc.Equals, "s3://juju-backups/prod")
+	c.Assert(cfg.BackupS3Config(), gc.Equals, backups.S3Config{
+		Endpoint:  "https://s3.example.com",
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
 }
 
 func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
@@ -483,3 +709,36 @@ func (s *ConfigSuite) TestMeteringURLSettingValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MeteringURL(), gc.Equals, mURL)
 }
+
+func (s *ConfigSuite) TestWithoutSecrets(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.BackupTarget:      "s3://bucket/backups",
+			controller.BackupS3AccessKey: "access",
+			controller.BackupS3SecretKey: "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	stripped := cfg.WithoutSecrets()
+	c.Assert(stripped.BackupTarget(), gc.Equals, "s3://bucket/backups")
+	_, ok := stripped[controller.BackupS3AccessKey]
+	c.Assert(ok, jc.IsFalse)
+	_, ok = stripped[controller.BackupS3SecretKey]
+	c.Assert(ok, jc.IsFalse)
+	// The original config is left alone.
+	c.Assert(cfg.BackupS3Config().SecretKey, gc.Equals, "secret")
+}
+
+func (s *ConfigSuite) TestWithoutSecretsAuditLogSyslogClientKey(c *gc.C) {
+	cfg := controller.Config{
+		controller.AuditLogSyslogHost:       "syslog.example.com:6514",
+		controller.AuditLogSyslogClientCert: "cert",
+		controller.AuditLogSyslogClientKey:  "key",
+	}
+	c.Assert(cfg.WithoutSecrets(), jc.DeepEquals, controller.Config{
+		controller.AuditLogSyslogHost:       "syslog.example.com:6514",
+		controller.AuditLogSyslogClientCert: "cert",
+	})
+}
@@ -6,6 +6,8 @@ package auditlog
 import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 // Config holds parameters to control audit logging.
@@ -29,6 +31,19 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
+	// Syslog holds the details of a remote syslog server that records
+	// should be forwarded to. Records are only forwarded if it is
+	// enabled.
+	Syslog syslog.RawConfig
+
+	// WebhookURL is an HTTP endpoint that records should be POSTed
+	// to, if not empty.
+	WebhookURL string
+
+	// ForwardBufferSize is the number of records held for each
+	// forwarding target while it is unavailable.
+	ForwardBufferSize int
+
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
 }
@@ -0,0 +1,20 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+func NewSyslogSinkForTest(
+	cfg syslog.RawConfig,
+	origin logfwd.Origin,
+	clock clock.Clock,
+	open func(syslog.RawConfig) (SyslogClient, error),
+) Sink {
+	return newSyslogSink(cfg, origin, clock, open)
+}
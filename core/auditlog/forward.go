@@ -0,0 +1,219 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+// Sink is a remote destination that audit log records can be
+// forwarded to.
+type Sink interface {
+	// Send delivers the record to the sink, returning an error if
+	// it couldn't be delivered.
+	Send(Record) error
+
+	// Close releases any resources held by the sink.
+	Close() error
+}
+
+// ForwarderConfig holds the parameters needed to create a forwarding
+// AuditLog.
+type ForwarderConfig struct {
+	// Name identifies the sink in log messages.
+	Name string
+
+	// Sink is where records are sent.
+	Sink Sink
+
+	// BufferSize is the number of records that will be held while
+	// the sink is unavailable. Once the buffer is full any further
+	// records are dropped.
+	BufferSize int
+
+	// RetryDelay is how long to wait before retrying a record that
+	// couldn't be sent.
+	RetryDelay time.Duration
+
+	// Clock is used for retry delays.
+	Clock clock.Clock
+}
+
+// Validate checks that the forwarder config is usable.
+func (cfg ForwarderConfig) Validate() error {
+	if cfg.Name == "" {
+		return errors.NotValidf("empty Name")
+	}
+	if cfg.Sink == nil {
+		return errors.NotValidf("nil Sink")
+	}
+	if cfg.BufferSize <= 0 {
+		return errors.NotValidf("non-positive BufferSize")
+	}
+	if cfg.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if cfg.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// NewForwarder returns an AuditLog that sends records to a sink in
+// the background. Adding a record never blocks: records are buffered
+// while the sink is unavailable and dropped once the buffer is full,
+// so that a slow or broken sink can't hold up API requests.
+func NewForwarder(cfg ForwarderConfig) (AuditLog, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	f := &forwarder{
+		config:  cfg,
+		records: make(chan Record, cfg.BufferSize),
+		stop:    make(chan struct{}),
+	}
+	go f.loop()
+	return f, nil
+}
+
+type forwarder struct {
+	config    ForwarderConfig
+	records   chan Record
+	stop      chan struct{}
+	closeOnce sync.Once
+
+	// dropped is the number of records discarded since the sink
+	// was last available, accessed atomically.
+	dropped int64
+}
+
+// AddConversation implements AuditLog.
+func (f *forwarder) AddConversation(c Conversation) error {
+	f.enqueue(Record{Conversation: &c})
+	return nil
+}
+
+// AddRequest implements AuditLog.
+func (f *forwarder) AddRequest(r Request) error {
+	f.enqueue(Record{Request: &r})
+	return nil
+}
+
+// AddResponse implements AuditLog.
+func (f *forwarder) AddResponse(r ResponseErrors) error {
+	f.enqueue(Record{Errors: &r})
+	return nil
+}
+
+// Close implements AuditLog. Any records that haven't been sent yet
+// are discarded.
+func (f *forwarder) Close() error {
+	f.closeOnce.Do(func() { close(f.stop) })
+	return nil
+}
+
+func (f *forwarder) enqueue(r Record) {
+	select {
+	case <-f.stop:
+		return
+	default:
+	}
+	select {
+	case f.records <- r:
+	default:
+		if atomic.AddInt64(&f.dropped, 1) == 1 {
+			logger.Warningf("audit log buffer for %s is full, dropping records", f.config.Name)
+		}
+	}
+}
+
+func (f *forwarder) loop() {
+	defer func() {
+		if err := f.config.Sink.Close(); err != nil {
+			logger.Warningf("closing audit log sink %s: %v", f.config.Name, err)
+		}
+	}()
+	for {
+		select {
+		case <-f.stop:
+			return
+		case r := <-f.records:
+			if !f.send(r) {
+				return
+			}
+		}
+	}
+}
+
+// send delivers the record to the sink, retrying until it succeeds.
+// It returns false if the forwarder was closed first.
+func (f *forwarder) send(r Record) bool {
+	failing := false
+	for {
+		err := f.config.Sink.Send(r)
+		if err == nil {
+			break
+		}
+		if !failing {
+			logger.Errorf("cannot forward audit log records to %s (will retry): %v", f.config.Name, err)
+			failing = true
+		}
+		select {
+		case <-f.stop:
+			return false
+		case <-f.config.Clock.After(f.config.RetryDelay):
+		}
+	}
+	if failing {
+		logger.Infof("resumed forwarding audit log records to %s", f.config.Name)
+	}
+	if dropped := atomic.SwapInt64(&f.dropped, 0); dropped > 0 {
+		logger.Warningf("dropped %d audit log records for %s", dropped, f.config.Name)
+	}
+	return true
+}
+
+// NewTeeLog returns an AuditLog that writes each record to all of the
+// logs passed in. An error from one log doesn't prevent the record
+// being written to the others.
+func NewTeeLog(logs ...AuditLog) AuditLog {
+	return teeLog(logs)
+}
+
+type teeLog []AuditLog
+
+// AddConversation implements AuditLog.
+func (t teeLog) AddConversation(c Conversation) error {
+	return t.each(func(log AuditLog) error { return log.AddConversation(c) })
+}
+
+// AddRequest implements AuditLog.
+func (t teeLog) AddRequest(r Request) error {
+	return t.each(func(log AuditLog) error { return log.AddRequest(r) })
+}
+
+// AddResponse implements AuditLog.
+func (t teeLog) AddResponse(r ResponseErrors) error {
+	return t.each(func(log AuditLog) error { return log.AddResponse(r) })
+}
+
+// Close implements AuditLog.
+func (t teeLog) Close() error {
+	return t.each(func(log AuditLog) error { return log.Close() })
+}
+
+func (t teeLog) each(f func(AuditLog) error) error {
+	var result error
+	for _, log := range t {
+		if err := f(log); err != nil && result == nil {
+			result = errors.Trace(err)
+		}
+	}
+	return result
+}
@@ -0,0 +1,221 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ForwarderSuite struct {
+	testing.IsolationSuite
+	clock *testing.Clock
+	sink  *fakeSink
+}
+
+var _ = gc.Suite(&ForwarderSuite{})
+
+func (s *ForwarderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.sink = &fakeSink{
+		sent:   make(chan auditlog.Record),
+		closed: make(chan struct{}),
+		abort:  make(chan struct{}),
+	}
+}
+
+func (s *ForwarderSuite) TearDownTest(c *gc.C) {
+	close(s.sink.abort)
+	s.IsolationSuite.TearDownTest(c)
+}
+
+func (s *ForwarderSuite) newForwarder(c *gc.C, bufferSize int) auditlog.AuditLog {
+	f, err := auditlog.NewForwarder(auditlog.ForwarderConfig{
+		Name:       "fake",
+		Sink:       s.sink,
+		BufferSize: bufferSize,
+		RetryDelay: time.Second,
+		Clock:      s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return f
+}
+
+func (s *ForwarderSuite) nextRecord(c *gc.C) auditlog.Record {
+	select {
+	case r := <-s.sink.sent:
+		return r
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for record")
+	}
+	panic("unreachable")
+}
+
+func (s *ForwarderSuite) TestValidate(c *gc.C) {
+	_, err := auditlog.NewForwarder(auditlog.ForwarderConfig{
+		Name:       "fake",
+		Sink:       s.sink,
+		RetryDelay: time.Second,
+		Clock:      s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive BufferSize not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ForwarderSuite) TestForwardsRecords(c *gc.C) {
+	f := s.newForwarder(c, 10)
+	defer f.Close()
+
+	err := f.AddConversation(auditlog.Conversation{ConversationID: "abc"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = f.AddRequest(auditlog.Request{ConversationID: "abc", RequestID: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = f.AddResponse(auditlog.ResponseErrors{ConversationID: "abc", RequestID: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.nextRecord(c), jc.DeepEquals, auditlog.Record{
+		Conversation: &auditlog.Conversation{ConversationID: "abc"},
+	})
+	c.Assert(s.nextRecord(c), jc.DeepEquals, auditlog.Record{
+		Request: &auditlog.Request{ConversationID: "abc", RequestID: 1},
+	})
+	c.Assert(s.nextRecord(c), jc.DeepEquals, auditlog.Record{
+		Errors: &auditlog.ResponseErrors{ConversationID: "abc", RequestID: 1},
+	})
+}
+
+func (s *ForwarderSuite) TestRetriesAfterFailure(c *gc.C) {
+	s.sink.SetErrors(errors.New("sink unavailable"))
+	f := s.newForwarder(c, 10)
+	defer f.Close()
+
+	err := f.AddConversation(auditlog.Conversation{ConversationID: "abc"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextRecord(c).Conversation.ConversationID, gc.Equals, "abc")
+	s.sink.CheckCallNames(c, "Send", "Send")
+}
+
+func (s *ForwarderSuite) TestDropsRecordsWhenBufferFull(c *gc.C) {
+	f := s.newForwarder(c, 1)
+	defer f.Close()
+
+	// Nothing is reading from the sink, so at most one record can be
+	// in flight and one more buffered; the rest are dropped rather
+	// than blocking.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10; i++ {
+			f.AddRequest(auditlog.Request{RequestID: uint64(i)})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("adding records blocked")
+	}
+
+	c.Assert(s.nextRecord(c).Request.RequestID, gc.Equals, uint64(0))
+	var received int
+	for {
+		select {
+		case <-s.sink.sent:
+			received++
+			continue
+		case <-time.After(coretesting.ShortWait):
+		}
+		break
+	}
+	c.Assert(received, jc.LessThan, 2)
+}
+
+func (s *ForwarderSuite) TestCloseClosesSink(c *gc.C) {
+	f := s.newForwarder(c, 10)
+	err := f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-s.sink.closed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("sink not closed")
+	}
+
+	// Records added after closing are discarded.
+	err = f.AddConversation(auditlog.Conversation{ConversationID: "abc"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ForwarderSuite) TestTeeLog(c *gc.C) {
+	first := &fakeLog{err: errors.New("disk full")}
+	second := &fakeLog{}
+	tee := auditlog.NewTeeLog(first, second)
+
+	err := tee.AddConversation(auditlog.Conversation{ConversationID: "abc"})
+	c.Assert(err, gc.ErrorMatches, "disk full")
+	err = tee.AddRequest(auditlog.Request{RequestID: 1})
+	c.Assert(err, gc.ErrorMatches, "disk full")
+
+	first.CheckCallNames(c, "AddConversation", "AddRequest")
+	second.CheckCallNames(c, "AddConversation", "AddRequest")
+}
+
+type fakeSink struct {
+	testing.Stub
+	sent   chan auditlog.Record
+	closed chan struct{}
+	abort  chan struct{}
+}
+
+func (s *fakeSink) Send(r auditlog.Record) error {
+	s.MethodCall(s, "Send", r)
+	if err := s.NextErr(); err != nil {
+		return err
+	}
+	select {
+	case s.sent <- r:
+	case <-s.abort:
+	}
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.MethodCall(s, "Close")
+	close(s.closed)
+	return s.NextErr()
+}
+
+type fakeLog struct {
+	testing.Stub
+	err error
+}
+
+func (l *fakeLog) AddConversation(c auditlog.Conversation) error {
+	l.MethodCall(l, "AddConversation", c)
+	return l.err
+}
+
+func (l *fakeLog) AddRequest(r auditlog.Request) error {
+	l.MethodCall(l, "AddRequest", r)
+	return l.err
+}
+
+func (l *fakeLog) AddResponse(r auditlog.ResponseErrors) error {
+	l.MethodCall(l, "AddResponse", r)
+	return l.err
+}
+
+func (l *fakeLog) Close() error {
+	l.MethodCall(l, "Close")
+	return l.err
+}
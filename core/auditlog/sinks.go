@@ -0,0 +1,152 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// webhookTimeout is how long a webhook sink waits for a response
+// before treating the request as failed.
+const webhookTimeout = 30 * time.Second
+
+// SyslogClient is the part of a syslog.Client needed to forward
+// records.
+type SyslogClient interface {
+	Send([]logfwd.Record) error
+	Close() error
+}
+
+// NewSyslogSink returns a Sink that sends records as JSON messages to
+// the remote syslog server described by cfg. The connection is made
+// when the first record is sent, and remade after any failure.
+func NewSyslogSink(cfg syslog.RawConfig, origin logfwd.Origin, clock clock.Clock) Sink {
+	return newSyslogSink(cfg, origin, clock, openSyslogClient)
+}
+
+func newSyslogSink(
+	cfg syslog.RawConfig,
+	origin logfwd.Origin,
+	clock clock.Clock,
+	open func(syslog.RawConfig) (SyslogClient, error),
+) Sink {
+	return &syslogSink{
+		config: cfg,
+		origin: origin,
+		clock:  clock,
+		open:   open,
+	}
+}
+
+func openSyslogClient(cfg syslog.RawConfig) (SyslogClient, error) {
+	client, err := syslog.Open(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
+type syslogSink struct {
+	config syslog.RawConfig
+	origin logfwd.Origin
+	clock  clock.Clock
+	open   func(syslog.RawConfig) (SyslogClient, error)
+
+	client SyslogClient
+	nextID int64
+}
+
+// Send implements Sink.
+func (s *syslogSink) Send(r Record) error {
+	message, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.client == nil {
+		client, err := s.open(s.config)
+		if err != nil {
+			return errors.Annotatef(err, "connecting to syslog host %q", s.config.Host)
+		}
+		s.client = client
+	}
+	s.nextID++
+	err = s.client.Send([]logfwd.Record{{
+		ID:        s.nextID,
+		Origin:    s.origin,
+		Timestamp: s.clock.Now(),
+		Level:     loggo.INFO,
+		Location:  logfwd.SourceLocation{Module: "juju.audit"},
+		Message:   string(message),
+	}})
+	if err != nil {
+		// Reconnect next time in case the connection is broken.
+		s.closeClient()
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *syslogSink) Close() error {
+	return errors.Trace(s.closeClient())
+}
+
+func (s *syslogSink) closeClient() error {
+	if s.client == nil {
+		return nil
+	}
+	err := s.client.Close()
+	s.client = nil
+	return err
+}
+
+// NewWebhookSink returns a Sink that POSTs each record as JSON to the
+// given URL.
+func NewWebhookSink(url string) Sink {
+	return &webhookSink{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// Send implements Sink.
+func (s *webhookSink) Send(r Record) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("POST %s: %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *webhookSink) Close() error {
+	return nil
+}
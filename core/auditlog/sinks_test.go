@@ -0,0 +1,139 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+type SinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinksSuite{})
+
+func (s *SinksSuite) TestWebhookSink(c *gc.C) {
+	var received []auditlog.Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		var r auditlog.Record
+		c.Check(json.Unmarshal(body, &r), jc.ErrorIsNil)
+		received = append(received, r)
+	}))
+	defer server.Close()
+
+	sink := auditlog.NewWebhookSink(server.URL)
+	defer sink.Close()
+	err := sink.Send(auditlog.Record{
+		Request: &auditlog.Request{ConversationID: "abc", Facade: "Application", Method: "Deploy"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, jc.DeepEquals, []auditlog.Record{{
+		Request: &auditlog.Request{ConversationID: "abc", Facade: "Application", Method: "Deploy"},
+	}})
+}
+
+func (s *SinksSuite) TestWebhookSinkErrorStatus(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink := auditlog.NewWebhookSink(server.URL)
+	err := sink.Send(auditlog.Record{Conversation: &auditlog.Conversation{}})
+	c.Assert(err, gc.ErrorMatches, "POST .*: 503 Service Unavailable")
+}
+
+func (s *SinksSuite) TestSyslogSink(c *gc.C) {
+	now := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	clock := testing.NewClock(now)
+	origin := logfwd.Origin{ControllerUUID: "controller-uuid", ModelUUID: "model-uuid"}
+	cfg := syslog.RawConfig{Enabled: true, Host: "syslog.example.com"}
+	client := &fakeSyslogClient{}
+	var opened []syslog.RawConfig
+	open := func(cfg syslog.RawConfig) (auditlog.SyslogClient, error) {
+		opened = append(opened, cfg)
+		return client, nil
+	}
+	sink := auditlog.NewSyslogSinkForTest(cfg, origin, clock, open)
+
+	record := auditlog.Record{Conversation: &auditlog.Conversation{Who: "fred", What: "juju status"}}
+	err := sink.Send(record)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opened, jc.DeepEquals, []syslog.RawConfig{cfg})
+
+	message, err := json.Marshal(record)
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCalls(c, []testing.StubCall{{"Send", []interface{}{[]logfwd.Record{{
+		ID:        1,
+		Origin:    origin,
+		Timestamp: now,
+		Level:     loggo.INFO,
+		Location:  logfwd.SourceLocation{Module: "juju.audit"},
+		Message:   string(message),
+	}}}}})
+
+	err = sink.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCallNames(c, "Send", "Close")
+}
+
+func (s *SinksSuite) TestSyslogSinkReconnectsAfterFailure(c *gc.C) {
+	client := &fakeSyslogClient{}
+	client.SetErrors(errors.New("connection reset"))
+	var opens int
+	open := func(syslog.RawConfig) (auditlog.SyslogClient, error) {
+		opens++
+		return client, nil
+	}
+	sink := auditlog.NewSyslogSinkForTest(syslog.RawConfig{}, logfwd.Origin{}, testing.NewClock(time.Time{}), open)
+
+	err := sink.Send(auditlog.Record{Conversation: &auditlog.Conversation{}})
+	c.Assert(err, gc.ErrorMatches, "connection reset")
+	err = sink.Send(auditlog.Record{Conversation: &auditlog.Conversation{}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opens, gc.Equals, 2)
+	client.CheckCallNames(c, "Send", "Close", "Send")
+}
+
+func (s *SinksSuite) TestSyslogSinkConnectError(c *gc.C) {
+	open := func(syslog.RawConfig) (auditlog.SyslogClient, error) {
+		return nil, errors.New("no route to host")
+	}
+	cfg := syslog.RawConfig{Host: "syslog.example.com"}
+	sink := auditlog.NewSyslogSinkForTest(cfg, logfwd.Origin{}, testing.NewClock(time.Time{}), open)
+	err := sink.Send(auditlog.Record{Conversation: &auditlog.Conversation{}})
+	c.Assert(err, gc.ErrorMatches, `connecting to syslog host "syslog.example.com": no route to host`)
+}
+
+type fakeSyslogClient struct {
+	testing.Stub
+}
+
+func (f *fakeSyslogClient) Send(records []logfwd.Record) error {
+	f.MethodCall(f, "Send", records)
+	return f.NextErr()
+}
+
+func (f *fakeSyslogClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -0,0 +1,183 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backups holds the types used to describe when controller
+// backups are taken and where their archives are written.
+package backups
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule reports when a scheduled backup should next be taken.
+type Schedule interface {
+	// Next returns the first activation time strictly after t.
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses a backup schedule specification. The supported
+// forms are a standard five field cron expression (minute, hour, day of
+// month, month, day of week), evaluated in UTC; one of the descriptors
+// @yearly, @annually, @monthly, @weekly, @daily, @midnight or @hourly;
+// and "@every <duration>" for a fixed interval.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, errors.NotValidf("empty backup schedule")
+	}
+	if strings.HasPrefix(spec, "@every") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every")))
+		if err != nil {
+			return nil, errors.NotValidf("backup schedule %q", spec)
+		}
+		if d < time.Minute {
+			return nil, errors.NotValidf("backup schedule %q with interval under a minute", spec)
+		}
+		return everySchedule(d), nil
+	}
+	if expanded, ok := descriptors[spec]; ok {
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("backup schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+	var sched cronSchedule
+	var err error
+	for i, f := range []struct {
+		dest     *uint64
+		min, max uint
+	}{
+		{&sched.minute, 0, 59},
+		{&sched.hour, 0, 23},
+		{&sched.dom, 1, 31},
+		{&sched.month, 1, 12},
+		{&sched.dow, 0, 7},
+	} {
+		if *f.dest, err = parseField(fields[i], f.min, f.max); err != nil {
+			return nil, errors.Annotatef(err, "backup schedule %q", spec)
+		}
+	}
+	// Both 0 and 7 mean Sunday.
+	if sched.dow&(1<<7) != 0 {
+		sched.dow |= 1
+	}
+	sched.domStar = fields[2] == "*"
+	sched.dowStar = fields[4] == "*"
+	if sched.Next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil, errors.NotValidf("backup schedule %q that never fires", spec)
+	}
+	return &sched, nil
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseField parses a comma separated list of cron ranges into a bit
+// set of the values they match.
+func parseField(field string, min, max uint) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := uint(1)
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.ParseUint(part[i+1:], 10, 8)
+			if err != nil || n == 0 {
+				return 0, errors.NotValidf("step in %q", part)
+			}
+			step = uint(n)
+			part = part[:i]
+		}
+		lo, hi := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			n, err := strconv.ParseUint(bounds[0], 10, 8)
+			if err != nil {
+				return 0, errors.NotValidf("value %q", part)
+			}
+			lo, hi = uint(n), uint(n)
+			if len(bounds) == 2 {
+				if n, err = strconv.ParseUint(bounds[1], 10, 8); err != nil {
+					return 0, errors.NotValidf("value %q", part)
+				}
+				hi = uint(n)
+			} else if step > 1 {
+				// "n/step" means from n to the end of the range.
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, errors.NotValidf("range %q (must be within %d-%d)", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// everySchedule activates at a fixed interval.
+type everySchedule time.Duration
+
+// Next is part of the Schedule interface.
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(s)).Truncate(time.Second)
+}
+
+// cronSchedule holds a parsed cron expression as bit sets of the
+// matching values for each field.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether the day fields were
+	// unrestricted; when both are restricted a day matches if either
+	// of them does.
+	domStar, dowStar bool
+}
+
+// Next is part of the Schedule interface.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	// Every valid expression matches at least once within 5 years
+	// (e.g. 29 February); give up on anything that doesn't.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
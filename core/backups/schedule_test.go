@@ -0,0 +1,129 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/backups"
+)
+
+type ScheduleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+// 2019-03-14 is a Thursday.
+var scheduleStart = time.Date(2019, 3, 14, 10, 30, 15, 0, time.UTC)
+
+func (s *ScheduleSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		expect time.Time
+	}{{
+		spec:   "* * * * *",
+		expect: time.Date(2019, 3, 14, 10, 31, 0, 0, time.UTC),
+	}, {
+		spec:   "30 2 * * *",
+		expect: time.Date(2019, 3, 15, 2, 30, 0, 0, time.UTC),
+	}, {
+		spec:   "*/15 * * * *",
+		expect: time.Date(2019, 3, 14, 10, 45, 0, 0, time.UTC),
+	}, {
+		spec:   "0 9-17/4 * * *",
+		expect: time.Date(2019, 3, 14, 13, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 * * 0",
+		expect: time.Date(2019, 3, 17, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 * * 7",
+		expect: time.Date(2019, 3, 17, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 1,20 * *",
+		expect: time.Date(2019, 3, 20, 0, 0, 0, 0, time.UTC),
+	}, {
+		// Day of month and day of week are OR'd when both are set.
+		spec:   "0 0 20 * 5",
+		expect: time.Date(2019, 3, 15, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 29 2 *",
+		expect: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@daily",
+		expect: time.Date(2019, 3, 15, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@hourly",
+		expect: time.Date(2019, 3, 14, 11, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@monthly",
+		expect: time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@yearly",
+		expect: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@every 6h",
+		expect: time.Date(2019, 3, 14, 16, 30, 15, 0, time.UTC),
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		sched, err := backups.ParseSchedule(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(sched.Next(scheduleStart), gc.Equals, test.expect)
+	}
+}
+
+func (s *ScheduleSuite) TestNextIsStrictlyAfter(c *gc.C) {
+	sched, err := backups.ParseSchedule("0 * * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	t := time.Date(2019, 3, 14, 10, 0, 0, 0, time.UTC)
+	c.Assert(sched.Next(t), gc.Equals, t.Add(time.Hour))
+}
+
+func (s *ScheduleSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  "empty backup schedule not valid",
+	}, {
+		spec: "* * * *",
+		err:  `backup schedule "\* \* \* \*": expected 5 fields, got 4 not valid`,
+	}, {
+		spec: "60 * * * *",
+		err:  `backup schedule "60 \* \* \* \*": range "60" \(must be within 0-59\) not valid`,
+	}, {
+		spec: "* * 0 * *",
+		err:  `backup schedule "\* \* 0 \* \*": range "0" \(must be within 1-31\) not valid`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `backup schedule "\*/0 \* \* \* \*": step in "\*/0" not valid`,
+	}, {
+		spec: "5-1 * * * *",
+		err:  `backup schedule "5-1 \* \* \* \*": range "5-1" \(must be within 0-59\) not valid`,
+	}, {
+		spec: "a * * * *",
+		err:  `backup schedule "a \* \* \* \*": value "a" not valid`,
+	}, {
+		spec: "0 0 31 2 *",
+		err:  `backup schedule "0 0 31 2 \*" that never fires not valid`,
+	}, {
+		spec: "@every soon",
+		err:  `backup schedule "@every soon" not valid`,
+	}, {
+		spec: "@every 10s",
+		err:  `backup schedule "@every 10s" with interval under a minute not valid`,
+	}, {
+		spec: "@fortnightly",
+		err:  `backup schedule "@fortnightly": expected 5 fields, got 1 not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := backups.ParseSchedule(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
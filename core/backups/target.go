@@ -0,0 +1,104 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// TargetKind identifies the kind of storage a backup archive is
+// written to.
+type TargetKind string
+
+const (
+	// FileTarget writes archives to a directory on the controller
+	// machine's filesystem.
+	FileTarget TargetKind = "file"
+
+	// S3Target writes archives to a bucket in an S3-compatible object
+	// store.
+	S3Target TargetKind = "s3"
+)
+
+// TargetSpec describes where backup archives are written. Specs are
+// written as "file:/absolute/dir" or "s3://bucket/optional/prefix".
+type TargetSpec struct {
+	Kind TargetKind
+
+	// Path is the directory archives are written to, for file
+	// targets.
+	Path string
+
+	// Bucket and Prefix locate archives in an object store, for s3
+	// targets.
+	Bucket string
+	Prefix string
+}
+
+// ParseTargetSpec parses a backup target specification.
+func ParseTargetSpec(spec string) (TargetSpec, error) {
+	switch {
+	case strings.HasPrefix(spec, "file:"):
+		dir := strings.TrimPrefix(spec, "file:")
+		if !filepath.IsAbs(dir) {
+			return TargetSpec{}, errors.NotValidf("backup target %q: directory must be absolute", spec)
+		}
+		return TargetSpec{Kind: FileTarget, Path: filepath.Clean(dir)}, nil
+	case strings.HasPrefix(spec, "s3://"):
+		rest := strings.TrimPrefix(spec, "s3://")
+		parts := strings.SplitN(rest, "/", 2)
+		if parts[0] == "" {
+			return TargetSpec{}, errors.NotValidf("backup target %q: missing bucket", spec)
+		}
+		target := TargetSpec{Kind: S3Target, Bucket: parts[0]}
+		if len(parts) == 2 {
+			target.Prefix = strings.Trim(parts[1], "/")
+		}
+		return target, nil
+	}
+	return TargetSpec{}, errors.NotValidf("backup target %q (expected file:<dir> or s3://<bucket>[/<prefix>])", spec)
+}
+
+// String returns the specification the target was parsed from, in
+// canonical form.
+func (t TargetSpec) String() string {
+	switch t.Kind {
+	case FileTarget:
+		return "file:" + t.Path
+	case S3Target:
+		if t.Prefix == "" {
+			return "s3://" + t.Bucket
+		}
+		return "s3://" + t.Bucket + "/" + t.Prefix
+	}
+	return fmt.Sprintf("%s:", t.Kind)
+}
+
+// Key returns the object key under which an archive with the given
+// name is stored in an s3 target.
+func (t TargetSpec) Key(name string) string {
+	if t.Prefix == "" {
+		return name
+	}
+	return path.Join(t.Prefix, name)
+}
+
+// S3Config holds the details needed to connect to an S3-compatible
+// object store.
+type S3Config struct {
+	// Endpoint is the URL of the object store. It must be set for
+	// stores other than AWS.
+	Endpoint string
+
+	// Region is the region the bucket lives in.
+	Region string
+
+	AccessKey string
+	SecretKey string
+}
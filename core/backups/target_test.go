@@ -0,0 +1,71 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/backups"
+)
+
+type TargetSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TargetSuite{})
+
+func (s *TargetSuite) TestParseTargetSpec(c *gc.C) {
+	for i, test := range []struct {
+		spec      string
+		expect    backups.TargetSpec
+		canonical string
+	}{{
+		spec:      "file:/var/backups/juju/",
+		expect:    backups.TargetSpec{Kind: backups.FileTarget, Path: "/var/backups/juju"},
+		canonical: "file:/var/backups/juju",
+	}, {
+		spec:      "s3://juju-backups",
+		expect:    backups.TargetSpec{Kind: backups.S3Target, Bucket: "juju-backups"},
+		canonical: "s3://juju-backups",
+	}, {
+		spec:      "s3://juju-backups/prod/controller/",
+		expect:    backups.TargetSpec{Kind: backups.S3Target, Bucket: "juju-backups", Prefix: "prod/controller"},
+		canonical: "s3://juju-backups/prod/controller",
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		target, err := backups.ParseTargetSpec(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(target, jc.DeepEquals, test.expect)
+		c.Check(target.String(), gc.Equals, test.canonical)
+	}
+}
+
+func (s *TargetSuite) TestParseTargetSpecErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "file:relative/dir",
+		err:  `backup target "file:relative/dir": directory must be absolute not valid`,
+	}, {
+		spec: "s3://",
+		err:  `backup target "s3://": missing bucket not valid`,
+	}, {
+		spec: "ftp://example.com",
+		err:  `backup target "ftp://example.com" \(expected file:<dir> or s3://<bucket>\[/<prefix>\]\) not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := backups.ParseTargetSpec(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *TargetSuite) TestKey(c *gc.C) {
+	target := backups.TargetSpec{Kind: backups.S3Target, Bucket: "b"}
+	c.Check(target.Key("x.tar.gz"), gc.Equals, "x.tar.gz")
+	target.Prefix = "prod"
+	c.Check(target.Key("x.tar.gz"), gc.Equals, "prod/x.tar.gz")
+}
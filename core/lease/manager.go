@@ -0,0 +1,27 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+// Manager describes methods for acquiring objects that manipulate and
+// query leases in a specific namespace and model.
+type Manager interface {
+	// Claimer returns a Claimer for leases in the given namespace
+	// and model.
+	Claimer(namespace string, modelUUID string) (Claimer, error)
+
+	// Checker returns a Checker for leases in the given namespace
+	// and model.
+	Checker(namespace string, modelUUID string) (Checker, error)
+
+	// Reader returns a Reader for leases in the given namespace
+	// and model.
+	Reader(namespace string, modelUUID string) (Reader, error)
+}
+
+// Reader describes retrieval of the current lease holders.
+type Reader interface {
+	// Leases returns the holder of each current lease, keyed by
+	// lease name.
+	Leases() map[string]string
+}
@@ -0,0 +1,223 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package quota defines the limits that can be placed on the resources
+// used by a model, so that no single model can exhaust the capacity of
+// the cloud account it is deployed to.
+package quota
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// The resources that may be limited by a quota.
+const (
+	// Machines is the number of top level machines in a model.
+	Machines = "machines"
+
+	// Containers is the number of containers in a model.
+	Containers = "containers"
+
+	// Units is the number of principal units in a model.
+	Units = "units"
+
+	// Cores is the total number of CPU cores of the model's
+	// top level machines.
+	Cores = "cores"
+
+	// Memory is the total memory, in MiB, of the model's top
+	// level machines.
+	Memory = "memory"
+
+	// Storage is the total size, in MiB, of the volumes and
+	// filesystems in a model.
+	Storage = "storage"
+)
+
+// Resources holds the names of the resources that may be limited,
+// in the order they are displayed.
+var Resources = []string{Machines, Containers, Units, Cores, Memory, Storage}
+
+// IsValidResource reports whether name is the name of a resource
+// that may be limited.
+func IsValidResource(name string) bool {
+	for _, r := range Resources {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isSize reports whether the resource is measured in MiB.
+func isSize(resource string) bool {
+	return resource == Memory || resource == Storage
+}
+
+// Quota holds the limits on the resources a model may use, keyed on
+// resource name. Resources that have no limit may be used freely.
+type Quota map[string]uint64
+
+// Validate returns an error if the quota limits an unknown resource.
+func (q Quota) Validate() error {
+	for resource := range q {
+		if !IsValidResource(resource) {
+			return errors.NotValidf("quota resource %q", resource)
+		}
+	}
+	return nil
+}
+
+// String returns the quota in the form accepted by Update.
+func (q Quota) String() string {
+	var strs []string
+	for _, resource := range Resources {
+		if limit, ok := q[resource]; ok {
+			strs = append(strs, resource+"="+FormatValue(resource, limit))
+		}
+	}
+	return strings.Join(strs, " ")
+}
+
+// Update returns a copy of the quota with the changes in args applied.
+// Each arg holds space separated name=value pairs; an empty value
+// removes the limit on the named resource. Memory and storage sizes
+// are in MiB unless suffixed with M, G, T or P.
+func (q Quota) Update(args ...string) (Quota, error) {
+	result := make(Quota)
+	for resource, limit := range q {
+		result[resource] = limit
+	}
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		for _, raw := range strings.Fields(arg) {
+			eq := strings.Index(raw, "=")
+			if eq <= 0 {
+				return nil, errors.Errorf("malformed quota %q", raw)
+			}
+			resource, value := raw[:eq], raw[eq+1:]
+			if !IsValidResource(resource) {
+				return nil, errors.Errorf("unknown quota resource %q", resource)
+			}
+			if seen[resource] {
+				return nil, errors.Errorf("bad %q quota: already set", resource)
+			}
+			seen[resource] = true
+			if value == "" {
+				delete(result, resource)
+				continue
+			}
+			limit, err := parseValue(resource, value)
+			if err != nil {
+				return nil, errors.Annotatef(err, "bad %q quota", resource)
+			}
+			result[resource] = limit
+		}
+	}
+	return result, nil
+}
+
+var sizeSuffixes = map[string]float64{
+	"M": 1,
+	"G": 1024,
+	"T": 1024 * 1024,
+	"P": 1024 * 1024 * 1024,
+}
+
+func parseValue(resource, value string) (uint64, error) {
+	if !isSize(resource) {
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, errors.New("must be a non-negative integer")
+		}
+		return n, nil
+	}
+	mult := 1.0
+	if m, ok := sizeSuffixes[value[len(value)-1:]]; ok {
+		value = value[:len(value)-1]
+		mult = m
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, errors.New("must be a non-negative float with optional M/G/T/P suffix")
+	}
+	return uint64(math.Ceil(n * mult)), nil
+}
+
+// FormatValue returns the given amount of the resource as a string,
+// with sizes given in the largest whole unit.
+func FormatValue(resource string, value uint64) string {
+	if !isSize(resource) {
+		return strconv.FormatUint(value, 10)
+	}
+	suffix := "M"
+	for _, s := range []string{"G", "T", "P"} {
+		mult := uint64(sizeSuffixes[s])
+		if value == 0 || value%mult != 0 {
+			break
+		}
+		suffix = s
+	}
+	return strconv.FormatUint(value/uint64(sizeSuffixes[suffix]), 10) + suffix
+}
+
+// Usage holds the amount of each resource used by a model, or
+// requested by an operation, keyed on resource name.
+type Usage map[string]uint64
+
+// Check returns an error satisfying IsExceeded if adding the requested
+// resources to those already in use would exceed the quota.
+func (q Quota) Check(used, requested Usage) error {
+	var resources []string
+	for resource := range requested {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	for _, resource := range resources {
+		limit, ok := q[resource]
+		if !ok || requested[resource] == 0 {
+			continue
+		}
+		if used[resource]+requested[resource] > limit {
+			return &ExceededError{
+				Resource:  resource,
+				Limit:     limit,
+				Used:      used[resource],
+				Requested: requested[resource],
+			}
+		}
+	}
+	return nil
+}
+
+// ExceededError is returned when an operation would cause a model to
+// use more of a resource than its quota allows.
+type ExceededError struct {
+	Resource  string
+	Limit     uint64
+	Used      uint64
+	Requested uint64
+}
+
+// Error is part of the error interface.
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf(
+		"model quota exceeded: %s of %s %s already in use, cannot add %s more",
+		FormatValue(e.Resource, e.Used),
+		FormatValue(e.Resource, e.Limit),
+		e.Resource,
+		FormatValue(e.Resource, e.Requested),
+	)
+}
+
+// IsExceeded reports whether err was caused by a quota being exceeded.
+func IsExceeded(err error) bool {
+	_, ok := errors.Cause(err).(*ExceededError)
+	return ok
+}
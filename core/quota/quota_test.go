@@ -0,0 +1,105 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/quota"
+)
+
+type QuotaSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&QuotaSuite{})
+
+func (s *QuotaSuite) TestValidate(c *gc.C) {
+	c.Assert(quota.Quota{"machines": 1, "storage": 1024}.Validate(), jc.ErrorIsNil)
+	err := quota.Quota{"gpus": 1}.Validate()
+	c.Assert(err, gc.ErrorMatches, `quota resource "gpus" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *QuotaSuite) TestString(c *gc.C) {
+	q := quota.Quota{
+		quota.Storage:  2 * 1024 * 1024,
+		quota.Memory:   1536,
+		quota.Cores:    0,
+		quota.Machines: 10,
+	}
+	c.Assert(q.String(), gc.Equals, "machines=10 cores=0 memory=1536M storage=2T")
+	c.Assert(quota.Quota{}.String(), gc.Equals, "")
+}
+
+func (s *QuotaSuite) TestUpdate(c *gc.C) {
+	q := quota.Quota{quota.Machines: 10, quota.Units: 20}
+	updated, err := q.Update("units=50 memory=64G", "machines=", "storage=1.5T")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated, jc.DeepEquals, quota.Quota{
+		quota.Units:   50,
+		quota.Memory:  64 * 1024,
+		quota.Storage: 1536 * 1024,
+	})
+	// The original is unchanged.
+	c.Assert(q, jc.DeepEquals, quota.Quota{quota.Machines: 10, quota.Units: 20})
+}
+
+func (s *QuotaSuite) TestUpdateErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		expect string
+	}{{
+		args:   []string{"machines"},
+		expect: `malformed quota "machines"`,
+	}, {
+		args:   []string{"=10"},
+		expect: `malformed quota "=10"`,
+	}, {
+		args:   []string{"gpus=1"},
+		expect: `unknown quota resource "gpus"`,
+	}, {
+		args:   []string{"units=1", "units=2"},
+		expect: `bad "units" quota: already set`,
+	}, {
+		args:   []string{"cores=-1"},
+		expect: `bad "cores" quota: must be a non-negative integer`,
+	}, {
+		args:   []string{"memory=lots"},
+		expect: `bad "memory" quota: must be a non-negative float with optional M/G/T/P suffix`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := quota.Quota{}.Update(test.args...)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *QuotaSuite) TestFormatValue(c *gc.C) {
+	c.Assert(quota.FormatValue(quota.Units, 2048), gc.Equals, "2048")
+	c.Assert(quota.FormatValue(quota.Memory, 0), gc.Equals, "0M")
+	c.Assert(quota.FormatValue(quota.Memory, 512), gc.Equals, "512M")
+	c.Assert(quota.FormatValue(quota.Memory, 2048), gc.Equals, "2G")
+	c.Assert(quota.FormatValue(quota.Storage, 3*1024*1024), gc.Equals, "3T")
+}
+
+func (s *QuotaSuite) TestCheck(c *gc.C) {
+	q := quota.Quota{quota.Machines: 10, quota.Memory: 64 * 1024}
+	used := quota.Usage{quota.Machines: 9, quota.Memory: 60 * 1024, quota.Units: 100}
+
+	err := q.Check(used, quota.Usage{quota.Machines: 1, quota.Units: 100})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = q.Check(used, quota.Usage{quota.Machines: 2})
+	c.Assert(err, gc.ErrorMatches, "model quota exceeded: 9 of 10 machines already in use, cannot add 2 more")
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+
+	err = q.Check(used, quota.Usage{quota.Memory: 8 * 1024})
+	c.Assert(err, gc.ErrorMatches, "model quota exceeded: 60G of 64G memory already in use, cannot add 8G more")
+	c.Assert(errors.Annotate(err, "cannot add machine"), jc.Satisfies, quota.IsExceeded)
+
+	c.Assert(errors.New("boom"), gc.Not(jc.Satisfies), quota.IsExceeded)
+}
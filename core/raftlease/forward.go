@@ -0,0 +1,117 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/lease"
+)
+
+const (
+	// RequestTopic is the pubsub topic on which lease commands that
+	// can't be applied locally are forwarded to the raft leader.
+	RequestTopic = "lease.request"
+
+	// responseTopicPrefix prefixes the unique topic on which the
+	// leader replies to a forwarded command.
+	responseTopicPrefix = "lease.response."
+)
+
+// ForwardRequest is published on RequestTopic by a controller that
+// isn't the raft leader, asking the leader to apply the command.
+type ForwardRequest struct {
+	Command       string `yaml:"command"`
+	ResponseTopic string `yaml:"response-topic"`
+}
+
+// ForwardResponse is published by the raft leader on the request's
+// ResponseTopic once the forwarded command has been applied.
+type ForwardResponse struct {
+	Error *ResponseError `yaml:"error,omitempty"`
+}
+
+// ResponseError is a serialisable form of the error returned by
+// applying a forwarded command.
+type ResponseError struct {
+	Message string `yaml:"message"`
+	Code    string `yaml:"code"`
+}
+
+const (
+	errorCodeInvalid = "invalid"
+	errorCodeTimeout = "timeout"
+)
+
+// AsResponseError returns the serialisable form of err, or nil if
+// err is nil.
+func AsResponseError(err error) *ResponseError {
+	if err == nil {
+		return nil
+	}
+	result := &ResponseError{Message: err.Error()}
+	switch errors.Cause(err) {
+	case lease.ErrInvalid:
+		result.Code = errorCodeInvalid
+	case lease.ErrTimeout:
+		result.Code = errorCodeTimeout
+	}
+	return result
+}
+
+// AsError turns the response error back into an error, restoring
+// the lease errors that callers compare against.
+func (e *ResponseError) AsError() error {
+	if e == nil {
+		return nil
+	}
+	switch e.Code {
+	case errorCodeInvalid:
+		return lease.ErrInvalid
+	case errorCodeTimeout:
+		return errors.Wrap(errors.New(e.Message), lease.ErrTimeout)
+	}
+	return errors.New(e.Message)
+}
+
+// ApplyCommand applies the marshalled command to raft, converting
+// the raft errors that indicate the command should be retried into
+// lease.ErrTimeout and returning the FSM's response error. If target
+// is not nil, it's told about any change to the lease's holder.
+func ApplyCommand(r RaftApplier, data []byte, timeout time.Duration, target NotifyTarget) error {
+	return convertRaftError(applyCommand(r, data, timeout, target))
+}
+
+// applyCommand applies the marshalled command to raft, returning any
+// raft error unconverted.
+func applyCommand(r RaftApplier, data []byte, timeout time.Duration, target NotifyTarget) error {
+	future := r.Apply(data, timeout)
+	if err := future.Error(); err != nil {
+		return err
+	}
+	response, ok := future.Response().(FSMResponse)
+	if !ok {
+		return errors.Errorf("expected FSMResponse, got %T: %#v", future.Response(), future.Response())
+	}
+	if target != nil {
+		// The holder is recorded before the command's caller is
+		// told it succeeded, so a new holder's trapdoor assertions
+		// can succeed as soon as it knows it holds the lease.
+		response.Notify(target)
+	}
+	return response.Error()
+}
+
+func convertRaftError(err error) error {
+	switch err {
+	case nil:
+		return nil
+	case raft.ErrNotLeader, raft.ErrLeadershipLost, raft.ErrEnqueueTimeout:
+		return errors.Wrap(err, lease.ErrTimeout)
+	}
+	return err
+}
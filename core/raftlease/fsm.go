@@ -0,0 +1,526 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/lease"
+)
+
+const (
+	// CommandVersion is the current version of the command format.
+	// If this changes then we need to be sure that reading and
+	// applying commands for previous versions still works.
+	CommandVersion = 1
+
+	// SnapshotVersion is the current version of the snapshot
+	// format. If this changes then Restore needs to handle
+	// snapshots written in earlier formats.
+	SnapshotVersion = 1
+
+	// OperationClaim denotes claiming a new lease.
+	OperationClaim = "claim"
+
+	// OperationExtend denotes extending an already-held lease.
+	OperationExtend = "extend"
+
+	// OperationExpire denotes expiring a lease.
+	OperationExpire = "expire"
+
+	// OperationPin pins a lease, preventing it from expiring
+	// until it is unpinned.
+	OperationPin = "pin"
+
+	// OperationUnpin unpins a lease, allowing it to expire
+	// normally.
+	OperationUnpin = "unpin"
+
+	// OperationSetTime denotes updating the recorded global clock
+	// time.
+	OperationSetTime = "setTime"
+)
+
+// NewFSM returns a new FSM to store lease information.
+func NewFSM() *FSM {
+	return &FSM{
+		entries: make(map[lease.Key]*entry),
+	}
+}
+
+// FSM stores the state of leases in the system, and implements
+// raft.FSM so that it can be replicated across the raft cluster.
+type FSM struct {
+	mu         sync.Mutex
+	globalTime time.Time
+	entries    map[lease.Key]*entry
+}
+
+// entry holds the details of a lease.
+type entry struct {
+	// holder identifies the current holder of the lease.
+	holder string
+
+	// start is the global time at which the lease started.
+	start time.Time
+
+	// duration is the duration for which the lease is valid,
+	// from the start time.
+	duration time.Duration
+
+	// pinned records the entities that have pinned the lease.
+	// A pinned lease cannot be expired.
+	pinned map[string]bool
+}
+
+func (e *entry) expiry() time.Time {
+	return e.start.Add(e.duration)
+}
+
+func (e *entry) isPinned() bool {
+	return len(e.pinned) > 0
+}
+
+func (f *FSM) claim(key lease.Key, holder string, duration time.Duration) error {
+	if _, found := f.entries[key]; found {
+		return lease.ErrInvalid
+	}
+	f.entries[key] = &entry{
+		holder:   holder,
+		start:    f.globalTime,
+		duration: duration,
+	}
+	return nil
+}
+
+func (f *FSM) extend(key lease.Key, holder string, duration time.Duration) error {
+	entry, found := f.entries[key]
+	if !found || entry.holder != holder {
+		return lease.ErrInvalid
+	}
+	expiry := f.globalTime.Add(duration)
+	if !expiry.After(entry.expiry()) {
+		// No extension needed - the lease already expires after the
+		// new time.
+		return nil
+	}
+	// entry is a pointer back into the f.entries map, so this update
+	// isn't lost.
+	entry.start = f.globalTime
+	entry.duration = duration
+	return nil
+}
+
+func (f *FSM) expire(key lease.Key) error {
+	entry, found := f.entries[key]
+	if !found {
+		return lease.ErrInvalid
+	}
+	if entry.isPinned() {
+		return lease.ErrInvalid
+	}
+	if entry.expiry().After(f.globalTime) {
+		return lease.ErrInvalid
+	}
+	delete(f.entries, key)
+	return nil
+}
+
+func (f *FSM) pin(key lease.Key, entity string) error {
+	entry, found := f.entries[key]
+	if !found {
+		return errors.NotFoundf("lease %q", key.Lease)
+	}
+	if entry.pinned == nil {
+		entry.pinned = make(map[string]bool)
+	}
+	entry.pinned[entity] = true
+	return nil
+}
+
+func (f *FSM) unpin(key lease.Key, entity string) error {
+	entry, found := f.entries[key]
+	if !found {
+		return errors.NotFoundf("lease %q", key.Lease)
+	}
+	delete(entry.pinned, entity)
+	return nil
+}
+
+func (f *FSM) setTime(oldTime, newTime time.Time) error {
+	if !f.globalTime.Equal(oldTime) {
+		return errors.Annotatef(
+			lease.ErrInvalid, "old time %s doesn't match current global time %s",
+			oldTime, f.globalTime,
+		)
+	}
+	if newTime.Before(oldTime) {
+		return errors.NotValidf("new time %s before old time %s", newTime, oldTime)
+	}
+	f.globalTime = newTime
+	return nil
+}
+
+// GlobalTime returns the FSM's internal time.
+func (f *FSM) GlobalTime() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.globalTime
+}
+
+// Leases gets information about all of the leases in the system,
+// with expiry times expressed relative to the supplied local time.
+// Each lease's trapdoor is made by the supplied function.
+func (f *FSM) Leases(localTime time.Time, trapdoor TrapdoorFunc) map[lease.Key]lease.Info {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make(map[lease.Key]lease.Info, len(f.entries))
+	for key, entry := range f.entries {
+		remaining := entry.expiry().Sub(f.globalTime)
+		results[key] = lease.Info{
+			Holder:   entry.holder,
+			Expiry:   localTime.Add(remaining),
+			Trapdoor: trapdoor(key, entry.holder),
+		}
+	}
+	return results
+}
+
+// Pinned returns, for each pinned lease, the entities that have
+// pinned it.
+func (f *FSM) Pinned() map[lease.Key][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make(map[lease.Key][]string)
+	for key, entry := range f.entries {
+		if !entry.isPinned() {
+			continue
+		}
+		entities := make([]string, 0, len(entry.pinned))
+		for entity := range entry.pinned {
+			entities = append(entities, entity)
+		}
+		results[key] = entities
+	}
+	return results
+}
+
+// Apply is part of raft.FSM.
+func (f *FSM) Apply(log *raft.Log) interface{} {
+	var command Command
+	if err := yaml.Unmarshal(log.Data, &command); err != nil {
+		return &response{err: errors.Annotate(err, "unmarshalling command")}
+	}
+	if err := command.Validate(); err != nil {
+		return &response{err: errors.Trace(err)}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := command.LeaseKey()
+	result := &response{key: key, index: log.Index}
+	var err error
+	switch command.Operation {
+	case OperationClaim:
+		err = f.claim(key, command.Holder, command.Duration)
+		if err == nil {
+			result.claimed = command.Holder
+		}
+	case OperationExtend:
+		err = f.extend(key, command.Holder, command.Duration)
+	case OperationExpire:
+		err = f.expire(key)
+		result.expired = err == nil
+	case OperationPin:
+		err = f.pin(key, command.PinEntity)
+	case OperationUnpin:
+		err = f.unpin(key, command.PinEntity)
+	case OperationSetTime:
+		err = f.setTime(command.OldTime, command.NewTime)
+	default:
+		err = errors.NotValidf("operation %q", command.Operation)
+	}
+	result.err = err
+	return result
+}
+
+// Snapshot is part of raft.FSM.
+func (f *FSM) Snapshot() (raft.FSMSnapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entries := make(map[SnapshotKey]SnapshotEntry, len(f.entries))
+	for key, entry := range f.entries {
+		var pinned []string
+		for entity := range entry.pinned {
+			pinned = append(pinned, entity)
+		}
+		entries[SnapshotKey{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+		}] = SnapshotEntry{
+			Holder:   entry.holder,
+			Start:    entry.start,
+			Duration: entry.duration,
+			Pinned:   pinned,
+		}
+	}
+	return &Snapshot{
+		Version:    SnapshotVersion,
+		Entries:    entries,
+		GlobalTime: f.globalTime,
+	}, nil
+}
+
+// Restore is part of raft.FSM.
+func (f *FSM) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var snapshot Snapshot
+	if err := yaml.Unmarshal(data, &snapshot); err != nil {
+		return errors.Trace(err)
+	}
+	if snapshot.Version != SnapshotVersion {
+		return errors.NotValidf("snapshot version %d", snapshot.Version)
+	}
+	if snapshot.Entries == nil {
+		return errors.NotValidf("nil entries")
+	}
+
+	newEntries := make(map[lease.Key]*entry, len(snapshot.Entries))
+	for key, ssEntry := range snapshot.Entries {
+		var pinned map[string]bool
+		if len(ssEntry.Pinned) > 0 {
+			pinned = make(map[string]bool, len(ssEntry.Pinned))
+			for _, entity := range ssEntry.Pinned {
+				pinned[entity] = true
+			}
+		}
+		newEntries[lease.Key{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+		}] = &entry{
+			holder:   ssEntry.Holder,
+			start:    ssEntry.Start,
+			duration: ssEntry.Duration,
+			pinned:   pinned,
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.globalTime = snapshot.GlobalTime
+	f.entries = newEntries
+	return nil
+}
+
+// Snapshot defines the format of the FSM snapshot.
+type Snapshot struct {
+	Version    int                           `yaml:"version"`
+	Entries    map[SnapshotKey]SnapshotEntry `yaml:"entries"`
+	GlobalTime time.Time                     `yaml:"global-time"`
+}
+
+// Persist is part of raft.FSMSnapshot.
+func (s *Snapshot) Persist(sink raft.SnapshotSink) (err error) {
+	defer func() {
+		if err != nil {
+			sink.Cancel()
+		}
+	}()
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := sink.Write(data); err != nil {
+		return errors.Trace(err)
+	}
+	return sink.Close()
+}
+
+// Release is part of raft.FSMSnapshot.
+func (s *Snapshot) Release() {}
+
+// SnapshotKey defines the format of a lease key in a snapshot.
+type SnapshotKey struct {
+	Namespace string `yaml:"namespace"`
+	ModelUUID string `yaml:"model-uuid"`
+	Lease     string `yaml:"lease"`
+}
+
+// SnapshotEntry defines the format of a lease entry in a snapshot.
+type SnapshotEntry struct {
+	Holder   string        `yaml:"holder"`
+	Start    time.Time     `yaml:"start"`
+	Duration time.Duration `yaml:"duration"`
+	Pinned   []string      `yaml:"pinned,omitempty"`
+}
+
+// Command captures the details of an operation to be run on the FSM.
+type Command struct {
+	// Version of the command format, in case it changes and we need
+	// to handle multiple formats.
+	Version int `yaml:"version"`
+
+	// Operation is one of claim, extend, expire, pin, unpin or
+	// setTime.
+	Operation string `yaml:"operation"`
+
+	// Namespace is the kind of lease.
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// ModelUUID identifies the model the lease belongs to.
+	ModelUUID string `yaml:"model-uuid,omitempty"`
+
+	// Lease is the name of the lease the command affects.
+	Lease string `yaml:"lease,omitempty"`
+
+	// Holder is the name of the party claiming or extending the
+	// lease.
+	Holder string `yaml:"holder,omitempty"`
+
+	// Duration is how long the lease should last.
+	Duration time.Duration `yaml:"duration,omitempty"`
+
+	// PinEntity identifies the entity pinning or unpinning the
+	// lease.
+	PinEntity string `yaml:"pin-entity,omitempty"`
+
+	// OldTime is the previous time for time updates (to avoid
+	// applying stale ones).
+	OldTime time.Time `yaml:"old-time,omitempty"`
+
+	// NewTime is the time to store as the global time.
+	NewTime time.Time `yaml:"new-time,omitempty"`
+}
+
+// LeaseKey returns the lease.Key the command refers to.
+func (c *Command) LeaseKey() lease.Key {
+	return lease.Key{
+		Namespace: c.Namespace,
+		ModelUUID: c.ModelUUID,
+		Lease:     c.Lease,
+	}
+}
+
+// Validate checks that the command describes a valid state change.
+func (c *Command) Validate() error {
+	if c.Version != CommandVersion {
+		return errors.NotValidf("version %d", c.Version)
+	}
+	switch c.Operation {
+	case OperationClaim, OperationExtend:
+		if err := c.validateLeaseKey(); err != nil {
+			return errors.Trace(err)
+		}
+		if err := lease.ValidateString(c.Holder); err != nil {
+			return errors.NewNotValid(err, "invalid holder")
+		}
+		if c.Duration <= 0 {
+			return errors.NotValidf("%s with duration %s", c.Operation, c.Duration)
+		}
+	case OperationExpire:
+		if err := c.validateLeaseKey(); err != nil {
+			return errors.Trace(err)
+		}
+	case OperationPin, OperationUnpin:
+		if err := c.validateLeaseKey(); err != nil {
+			return errors.Trace(err)
+		}
+		if c.PinEntity == "" {
+			return errors.NotValidf("%s with empty pin entity", c.Operation)
+		}
+	case OperationSetTime:
+		if c.NewTime.IsZero() {
+			return errors.NotValidf("setTime with zero new time")
+		}
+	default:
+		return errors.NotValidf("operation %q", c.Operation)
+	}
+	return nil
+}
+
+func (c *Command) validateLeaseKey() error {
+	if err := lease.ValidateString(c.Namespace); err != nil {
+		return errors.NewNotValid(err, "invalid namespace")
+	}
+	if c.ModelUUID == "" {
+		return errors.NotValidf("%s with empty model UUID", c.Operation)
+	}
+	if err := lease.ValidateString(c.Lease); err != nil {
+		return errors.NewNotValid(err, "invalid lease")
+	}
+	return nil
+}
+
+// Marshal converts this command to a byte slice.
+func (c *Command) Marshal() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// UnmarshalCommand converts a marshalled command []byte into a
+// command.
+func UnmarshalCommand(data []byte) (*Command, error) {
+	var result Command
+	err := yaml.Unmarshal(data, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
+
+// FSMResponse defines what will be available on the return value
+// from FSM apply calls.
+type FSMResponse interface {
+	// Error is a lease error (rather than anything to do with the
+	// raft machinery).
+	Error() error
+
+	// Notify tells the target about any change to the lease's
+	// holder made by applying the command.
+	Notify(NotifyTarget)
+}
+
+type response struct {
+	err   error
+	key   lease.Key
+	index uint64
+
+	// claimed is the new holder of the lease, if the command
+	// claimed it.
+	claimed string
+
+	// expired records whether the command expired the lease.
+	expired bool
+}
+
+// Error is part of FSMResponse.
+func (r *response) Error() error {
+	return r.err
+}
+
+// Notify is part of FSMResponse.
+func (r *response) Notify(target NotifyTarget) {
+	if r.err != nil {
+		return
+	}
+	switch {
+	case r.claimed != "":
+		target.Claimed(r.key, r.claimed, r.index)
+	case r.expired:
+		target.Expired(r.key, r.index)
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+var zero time.Time
+
+type fsmSuite struct {
+	testing.IsolationSuite
+
+	fsm *raftlease.FSM
+}
+
+var _ = gc.Suite(&fsmSuite{})
+
+func (s *fsmSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.fsm = raftlease.NewFSM()
+}
+
+func (s *fsmSuite) apply(c *gc.C, command raftlease.Command) error {
+	return s.applyResponse(c, command, 0).Error()
+}
+
+func (s *fsmSuite) applyResponse(c *gc.C, command raftlease.Command, index uint64) raftlease.FSMResponse {
+	command.Version = raftlease.CommandVersion
+	data, err := command.Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	result := s.fsm.Apply(&raft.Log{Index: index, Data: data})
+	response, ok := result.(raftlease.FSMResponse)
+	c.Assert(ok, gc.Equals, true)
+	return response
+}
+
+func (s *fsmSuite) claim(c *gc.C, name, holder string, duration time.Duration) error {
+	return s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     name,
+		Holder:    holder,
+		Duration:  duration,
+	})
+}
+
+func (s *fsmSuite) setTime(c *gc.C, oldTime, newTime time.Time) error {
+	return s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationSetTime,
+		OldTime:   oldTime,
+		NewTime:   newTime,
+	})
+}
+
+func key(name string) lease.Key {
+	return lease.Key{Namespace: "ns", ModelUUID: "model", Lease: name}
+}
+
+func (s *fsmSuite) TestClaim(c *gc.C) {
+	c.Assert(s.claim(c, "arm", "penfold", time.Minute), jc.ErrorIsNil)
+
+	localTime := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	c.Assert(s.fsm.Leases(localTime, noTrapdoor), gc.HasLen, 1)
+	info := s.fsm.Leases(localTime, noTrapdoor)[key("arm")]
+	c.Assert(info.Holder, gc.Equals, "penfold")
+	c.Assert(info.Expiry, gc.Equals, localTime.Add(time.Minute))
+
+	// Can't claim a lease that's already held.
+	err := s.claim(c, "arm", "dangermouse", time.Minute)
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
+
+func (s *fsmSuite) TestExtend(c *gc.C) {
+	c.Assert(s.claim(c, "arm", "penfold", time.Minute), jc.ErrorIsNil)
+	extend := raftlease.Command{
+		Operation: raftlease.OperationExtend,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "arm",
+		Holder:    "penfold",
+		Duration:  2 * time.Minute,
+	}
+	c.Assert(s.apply(c, extend), jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(zero, noTrapdoor)[key("arm")].Expiry, gc.Equals, zero.Add(2*time.Minute))
+
+	// Shorter extensions don't shrink the lease.
+	extend.Duration = time.Second
+	c.Assert(s.apply(c, extend), jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(zero, noTrapdoor)[key("arm")].Expiry, gc.Equals, zero.Add(2*time.Minute))
+
+	// Only the holder can extend.
+	extend.Holder = "baron"
+	c.Assert(s.apply(c, extend), gc.Equals, lease.ErrInvalid)
+}
+
+func (s *fsmSuite) TestExpire(c *gc.C) {
+	c.Assert(s.claim(c, "arm", "penfold", time.Minute), jc.ErrorIsNil)
+	expire := raftlease.Command{
+		Operation: raftlease.OperationExpire,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "arm",
+	}
+	// Not expired yet.
+	c.Assert(s.apply(c, expire), gc.Equals, lease.ErrInvalid)
+
+	c.Assert(s.setTime(c, zero, zero.Add(time.Minute)), jc.ErrorIsNil)
+	c.Assert(s.apply(c, expire), jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(zero, noTrapdoor), gc.HasLen, 0)
+
+	// Expiring a missing lease is invalid.
+	c.Assert(s.apply(c, expire), gc.Equals, lease.ErrInvalid)
+}
+
+func (s *fsmSuite) TestPinnedLeaseCannotExpire(c *gc.C) {
+	c.Assert(s.claim(c, "arm", "penfold", time.Minute), jc.ErrorIsNil)
+	pin := raftlease.Command{
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "arm",
+		PinEntity: "machine-0",
+	}
+	c.Assert(s.apply(c, pin), jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), jc.DeepEquals, map[lease.Key][]string{
+		key("arm"): {"machine-0"},
+	})
+
+	c.Assert(s.setTime(c, zero, zero.Add(time.Hour)), jc.ErrorIsNil)
+	expire := raftlease.Command{
+		Operation: raftlease.OperationExpire,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "arm",
+	}
+	c.Assert(s.apply(c, expire), gc.Equals, lease.ErrInvalid)
+
+	pin.Operation = raftlease.OperationUnpin
+	c.Assert(s.apply(c, pin), jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), gc.HasLen, 0)
+	c.Assert(s.apply(c, expire), jc.ErrorIsNil)
+}
+
+func (s *fsmSuite) TestPinMissingLease(c *gc.C) {
+	err := s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "arm",
+		PinEntity: "machine-0",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *fsmSuite) TestSetTime(c *gc.C) {
+	t1 := zero.Add(time.Second)
+	c.Assert(s.setTime(c, zero, t1), jc.ErrorIsNil)
+	c.Assert(s.fsm.GlobalTime(), gc.Equals, t1)
+
+	// A stale old time is rejected.
+	err := s.setTime(c, zero, t1.Add(time.Second))
+	c.Assert(errors.Cause(err), gc.Equals, lease.ErrInvalid)
+	c.Assert(s.fsm.GlobalTime(), gc.Equals, t1)
+}
+
+func (s *fsmSuite) TestLeasesRelativeToGlobalTime(c *gc.C) {
+	c.Assert(s.claim(c, "arm", "penfold", time.Minute), jc.ErrorIsNil)
+	c.Assert(s.setTime(c, zero, zero.Add(20*time.Second)), jc.ErrorIsNil)
+
+	localTime := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	info := s.fsm.Leases(localTime, noTrapdoor)[key("arm")]
+	c.Assert(info.Expiry, gc.Equals, localTime.Add(40*time.Second))
+}
+
+func (s *fsmSuite) TestInvalidCommand(c *gc.C) {
+	err := s.apply(c, raftlease.Command{
+		Operation: "frobnicate",
+	})
+	c.Assert(err, gc.ErrorMatches, `operation "frobnicate" not valid`)
+
+	err = s.claim(c, "arm", "penfold", 0)
+	c.Assert(err, gc.ErrorMatches, `claim with duration 0s not valid`)
+
+	err = s.claim(c, "arm", "", time.Minute)
+	c.Assert(err, gc.ErrorMatches, `invalid holder: string is empty`)
+}
+
+func (s *fsmSuite) TestSnapshotRestore(c *gc.C) {
+	c.Assert(s.claim(c, "arm", "penfold", time.Minute), jc.ErrorIsNil)
+	c.Assert(s.claim(c, "leg", "dangermouse", 2*time.Minute), jc.ErrorIsNil)
+	c.Assert(s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "leg",
+		PinEntity: "machine-0",
+	}), jc.ErrorIsNil)
+	c.Assert(s.setTime(c, zero, zero.Add(10*time.Second)), jc.ErrorIsNil)
+
+	snapshot, err := s.fsm.Snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	sink := &fakeSnapshotSink{}
+	c.Assert(snapshot.Persist(sink), jc.ErrorIsNil)
+	c.Assert(sink.cancelled, gc.Equals, false)
+
+	restored := raftlease.NewFSM()
+	err = restored.Restore(ioutil.NopCloser(&sink.Buffer))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored.GlobalTime(), gc.Equals, s.fsm.GlobalTime())
+	c.Assert(restored.Pinned(), jc.DeepEquals, s.fsm.Pinned())
+
+	localTime := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	expected := s.fsm.Leases(localTime, noTrapdoor)
+	actual := restored.Leases(localTime, noTrapdoor)
+	c.Assert(actual, gc.HasLen, len(expected))
+	for k, info := range expected {
+		c.Check(actual[k].Holder, gc.Equals, info.Holder)
+		c.Check(actual[k].Expiry, gc.Equals, info.Expiry)
+	}
+}
+
+func (s *fsmSuite) TestRestoreBadVersion(c *gc.C) {
+	data := []byte("version: 99\nentries: {}\n")
+	err := s.fsm.Restore(ioutil.NopCloser(bytes.NewReader(data)))
+	c.Assert(err, gc.ErrorMatches, `snapshot version 99 not valid`)
+}
+
+type fakeSnapshotSink struct {
+	bytes.Buffer
+	cancelled bool
+}
+
+func (s *fakeSnapshotSink) ID() string {
+	return "fake"
+}
+
+func (s *fakeSnapshotSink) Cancel() error {
+	s.cancelled = true
+	return nil
+}
+
+func (s *fakeSnapshotSink) Close() error {
+	return nil
+}
+
+func (s *fsmSuite) TestLeasesTrapdoor(c *gc.C) {
+	c.Assert(s.claim(c, "arm", "penfold", time.Minute), jc.ErrorIsNil)
+	var calls []string
+	trapdoor := func(key lease.Key, holder string) lease.Trapdoor {
+		return func(out interface{}) error {
+			calls = append(calls, key.Lease+" "+holder)
+			return nil
+		}
+	}
+	info := s.fsm.Leases(zero, trapdoor)[key("arm")]
+	c.Assert(info.Trapdoor(nil), jc.ErrorIsNil)
+	c.Assert(calls, jc.DeepEquals, []string{"arm penfold"})
+}
+
+func (s *fsmSuite) TestNotify(c *gc.C) {
+	var target fakeTarget
+	s.applyResponse(c, raftlease.Command{
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "arm",
+		Holder:    "penfold",
+		Duration:  time.Minute,
+	}, 1).Notify(&target)
+
+	// Failed commands don't change the holder.
+	s.applyResponse(c, raftlease.Command{
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "arm",
+		Holder:    "dangermouse",
+		Duration:  time.Minute,
+	}, 2).Notify(&target)
+
+	// Nor does extending the lease.
+	s.applyResponse(c, raftlease.Command{
+		Operation: raftlease.OperationExtend,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "arm",
+		Holder:    "penfold",
+		Duration:  2 * time.Minute,
+	}, 3).Notify(&target)
+
+	c.Assert(s.setTime(c, zero, zero.Add(2*time.Minute)), jc.ErrorIsNil)
+	s.applyResponse(c, raftlease.Command{
+		Operation: raftlease.OperationExpire,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "arm",
+	}, 5).Notify(&target)
+
+	target.CheckCalls(c, []testing.StubCall{
+		{"Claimed", []interface{}{key("arm"), "penfold", uint64(1)}},
+		{"Expired", []interface{}{key("arm"), uint64(5)}},
+	})
+}
+
+func noTrapdoor(lease.Key, string) lease.Trapdoor {
+	return nil
+}
+
+type fakeTarget struct {
+	testing.Stub
+}
+
+func (t *fakeTarget) Claimed(key lease.Key, holder string, index uint64) {
+	t.AddCall("Claimed", key, holder, index)
+}
+
+func (t *fakeTarget) Expired(key lease.Key, index uint64) {
+	t.AddCall("Expired", key, index)
+}
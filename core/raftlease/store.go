@@ -0,0 +1,317 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+)
+
+// RaftApplier is the subset of *raft.Raft used by the Store to
+// replicate lease operations.
+type RaftApplier interface {
+	// Apply applies the command to the replicated FSM, blocking
+	// for at most the given timeout while the command is enqueued.
+	Apply(cmd []byte, timeout time.Duration) raft.ApplyFuture
+}
+
+// NotifyTarget is told when the holder of a lease changes, so that
+// the holder can be recorded where transactions can assert on it.
+// Notifications are sent by the controller that applied the command
+// to raft, and may arrive late; each carries the index of the raft
+// log entry that made the change, so that a late notification can't
+// undo a later change.
+type NotifyTarget interface {
+	// Claimed is called when the holder claims the lease.
+	Claimed(key lease.Key, holder string, index uint64)
+
+	// Expired is called when the lease expires.
+	Expired(key lease.Key, index uint64)
+}
+
+// TrapdoorFunc returns the trapdoor for the lease with the given key
+// and holder.
+type TrapdoorFunc func(key lease.Key, holder string) lease.Trapdoor
+
+// lockedTrapdoorFunc returns trapdoors that don't give access to the
+// lease substrate, so that callers can't accidentally gate changes
+// on a lease without asserting that it's still held.
+func lockedTrapdoorFunc(lease.Key, string) lease.Trapdoor {
+	return lease.LockedTrapdoor
+}
+
+// StoreConfig holds resources and settings needed to run the Store.
+type StoreConfig struct {
+	// FSM is the local copy of the replicated lease state. Lease
+	// information is read directly from it.
+	FSM *FSM
+
+	// Raft is used to apply commands to the replicated FSM. Commands
+	// can only be applied on the raft leader; on any other node they
+	// are forwarded to the leader over Hub if it is set, or fail
+	// with lease.ErrTimeout, which callers treat as retryable. Raft
+	// may be nil if Hub is set, in which case every command is
+	// forwarded.
+	Raft RaftApplier
+
+	// Hub, if set, is used to forward commands to the raft leader
+	// when this node isn't the leader.
+	Hub Hub
+
+	// ForwardTimeout is the maximum time to wait for the leader to
+	// respond to a forwarded command. It must be set if Hub is.
+	ForwardTimeout time.Duration
+
+	// Clock is the writer-local clock, used to convert global lease
+	// expiry times to local ones.
+	Clock clock.Clock
+
+	// ApplyTimeout is the maximum time to wait for a command to be
+	// enqueued for replication.
+	ApplyTimeout time.Duration
+
+	// Target, if set, is told about changes to lease holders made
+	// by commands this store applies to raft.
+	Target NotifyTarget
+
+	// Trapdoor, if set, makes the trapdoors for the leases. The
+	// trapdoors let transactions assert that a lease is still held,
+	// which must be checked against the records kept by Target. If
+	// it isn't set, the trapdoors can't be used.
+	Trapdoor TrapdoorFunc
+}
+
+// Hub defines the methods of the central hub used to forward commands
+// to the raft leader.
+type Hub interface {
+	Publish(topic string, data interface{}) (<-chan struct{}, error)
+	Subscribe(topic string, handler interface{}) (func(), error)
+}
+
+// Validate returns an error if the configuration is invalid.
+func (config StoreConfig) Validate() error {
+	if config.FSM == nil {
+		return errors.NotValidf("nil FSM")
+	}
+	if config.Raft == nil && config.Hub == nil {
+		return errors.NotValidf("nil Raft without Hub")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.ApplyTimeout <= 0 {
+		return errors.NotValidf("non-positive ApplyTimeout")
+	}
+	if config.Hub != nil && config.ForwardTimeout <= 0 {
+		return errors.NotValidf("non-positive ForwardTimeout")
+	}
+	if config.Trapdoor != nil && config.Target == nil {
+		return errors.NotValidf("Trapdoor without Target")
+	}
+	return nil
+}
+
+// NewStore returns a new Store using the supplied config, or an error
+// if the config is invalid.
+func NewStore(config StoreConfig) (*Store, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Store{
+		config:   config,
+		prevTime: config.FSM.GlobalTime(),
+	}, nil
+}
+
+// Store implements lease.Store by applying commands to a raft FSM,
+// and globalclock.Updater by replicating the global time through the
+// same FSM. As with other lease.Store implementations, ErrInvalid is
+// returned unwrapped so that callers can compare against it directly.
+type Store struct {
+	config StoreConfig
+
+	mu       sync.Mutex
+	prevTime time.Time
+}
+
+var (
+	_ lease.Store         = (*Store)(nil)
+	_ globalclock.Updater = (*Store)(nil)
+)
+
+// ClaimLease is part of lease.Store.
+func (s *Store) ClaimLease(key lease.Key, req lease.Request) error {
+	if err := req.Validate(); err != nil {
+		return errors.Annotate(err, "invalid request")
+	}
+	return s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: OperationClaim,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+		Holder:    req.Holder,
+		Duration:  req.Duration,
+	})
+}
+
+// ExtendLease is part of lease.Store.
+func (s *Store) ExtendLease(key lease.Key, req lease.Request) error {
+	if err := req.Validate(); err != nil {
+		return errors.Annotate(err, "invalid request")
+	}
+	return s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: OperationExtend,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+		Holder:    req.Holder,
+		Duration:  req.Duration,
+	})
+}
+
+// ExpireLease is part of lease.Store.
+func (s *Store) ExpireLease(key lease.Key) error {
+	return s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: OperationExpire,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+	})
+}
+
+// PinLease prevents the lease from being expired until all of the
+// entities pinning it have unpinned it.
+func (s *Store) PinLease(key lease.Key, entity string) error {
+	return errors.Trace(s.pinOp(OperationPin, key, entity))
+}
+
+// UnpinLease removes the given entity's pin from the lease.
+func (s *Store) UnpinLease(key lease.Key, entity string) error {
+	return errors.Trace(s.pinOp(OperationUnpin, key, entity))
+}
+
+func (s *Store) pinOp(operation string, key lease.Key, entity string) error {
+	return s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: operation,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+		PinEntity: entity,
+	})
+}
+
+// Leases is part of lease.Store.
+func (s *Store) Leases() map[lease.Key]lease.Info {
+	trapdoor := s.config.Trapdoor
+	if trapdoor == nil {
+		trapdoor = lockedTrapdoorFunc
+	}
+	return s.config.FSM.Leases(s.config.Clock.Now(), trapdoor)
+}
+
+// Pinned returns the entities pinning each pinned lease.
+func (s *Store) Pinned() map[lease.Key][]string {
+	return s.config.FSM.Pinned()
+}
+
+// Refresh is part of lease.Store. The FSM is updated as raft log
+// entries are applied, so there is nothing to do here.
+func (s *Store) Refresh() error {
+	return nil
+}
+
+// Advance is part of globalclock.Updater.
+func (s *Store) Advance(duration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	newTime := s.prevTime.Add(duration)
+	err := s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: OperationSetTime,
+		OldTime:   s.prevTime,
+		NewTime:   newTime,
+	})
+	if errors.Cause(err) == lease.ErrInvalid {
+		// Someone else updated the global time concurrently;
+		// pick up the latest value so the next attempt can
+		// succeed.
+		s.prevTime = s.config.FSM.GlobalTime()
+		return globalclock.ErrConcurrentUpdate
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	s.prevTime = newTime
+	return nil
+}
+
+func (s *Store) runOnLeader(command *Command) error {
+	if err := command.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	data, err := command.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.config.Raft == nil {
+		return s.forward(data)
+	}
+	err = applyCommand(s.config.Raft, data, s.config.ApplyTimeout, s.config.Target)
+	if err == raft.ErrNotLeader && s.config.Hub != nil {
+		return s.forward(data)
+	}
+	return convertRaftError(err)
+}
+
+// forward publishes the command for the raft leader to apply, and
+// waits for its response.
+func (s *Store) forward(data []byte) error {
+	id, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	responseTopic := responseTopicPrefix + id.String()
+	responses := make(chan ForwardResponse, 1)
+	unsubscribe, err := s.config.Hub.Subscribe(
+		responseTopic,
+		func(_ string, response ForwardResponse, err error) {
+			if err != nil {
+				response = ForwardResponse{Error: AsResponseError(err)}
+			}
+			select {
+			case responses <- response:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		return errors.Annotate(err, "subscribing to lease responses")
+	}
+	defer unsubscribe()
+
+	_, err = s.config.Hub.Publish(RequestTopic, ForwardRequest{
+		Command:       string(data),
+		ResponseTopic: responseTopic,
+	})
+	if err != nil {
+		return errors.Annotate(err, "forwarding lease command")
+	}
+	select {
+	case response := <-responses:
+		return response.Error.AsError()
+	case <-s.config.Clock.After(s.config.ForwardTimeout):
+		return errors.Wrap(errors.New("no response from raft leader"), lease.ErrTimeout)
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease_test
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/pubsub/centralhub"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type storeSuite struct {
+	testing.IsolationSuite
+
+	fsm   *raftlease.FSM
+	raft  *fakeRaft
+	clock *testing.Clock
+	store *raftlease.Store
+}
+
+var _ = gc.Suite(&storeSuite{})
+
+func (s *storeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.fsm = raftlease.NewFSM()
+	s.raft = &fakeRaft{fsm: s.fsm}
+	s.clock = testing.NewClock(time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC))
+	var err error
+	s.store, err = raftlease.NewStore(raftlease.StoreConfig{
+		FSM:          s.fsm,
+		Raft:         s.raft,
+		Clock:        s.clock,
+		ApplyTimeout: time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storeSuite) TestValidateConfig(c *gc.C) {
+	_, err := raftlease.NewStore(raftlease.StoreConfig{
+		Raft:         s.raft,
+		Clock:        s.clock,
+		ApplyTimeout: time.Second,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil FSM not valid")
+	_, err = raftlease.NewStore(raftlease.StoreConfig{
+		FSM:   s.fsm,
+		Raft:  s.raft,
+		Clock: s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive ApplyTimeout not valid")
+	_, err = raftlease.NewStore(raftlease.StoreConfig{
+		FSM:          s.fsm,
+		Raft:         s.raft,
+		Clock:        s.clock,
+		ApplyTimeout: time.Second,
+		Hub:          centralhub.New(names.NewMachineTag("0")),
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive ForwardTimeout not valid")
+	_, err = raftlease.NewStore(raftlease.StoreConfig{
+		FSM:          s.fsm,
+		Raft:         s.raft,
+		Clock:        s.clock,
+		ApplyTimeout: time.Second,
+		Trapdoor:     noTrapdoor,
+	})
+	c.Assert(err, gc.ErrorMatches, "Trapdoor without Target not valid")
+	_, err = raftlease.NewStore(raftlease.StoreConfig{
+		FSM:          s.fsm,
+		Clock:        s.clock,
+		ApplyTimeout: time.Second,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Raft without Hub not valid")
+}
+
+func (s *storeSuite) TestClaimExtendExpire(c *gc.C) {
+	err := s.store.ClaimLease(key("arm"), lease.Request{"penfold", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	info := s.store.Leases()[key("arm")]
+	c.Assert(info.Holder, gc.Equals, "penfold")
+	c.Assert(info.Expiry, gc.Equals, s.clock.Now().Add(time.Minute))
+
+	err = s.store.ClaimLease(key("arm"), lease.Request{"baron", time.Minute})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+
+	err = s.store.ExtendLease(key("arm"), lease.Request{"penfold", 2 * time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.Leases()[key("arm")].Expiry, gc.Equals, s.clock.Now().Add(2*time.Minute))
+
+	err = s.store.ExpireLease(key("arm"))
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+
+	c.Assert(s.store.Advance(2*time.Minute), jc.ErrorIsNil)
+	c.Assert(s.store.ExpireLease(key("arm")), jc.ErrorIsNil)
+	c.Assert(s.store.Leases(), gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestNotifyTarget(c *gc.C) {
+	var target fakeTarget
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:          s.fsm,
+		Raft:         s.raft,
+		Clock:        s.clock,
+		ApplyTimeout: time.Second,
+		Target:       &target,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = store.ClaimLease(key("arm"), lease.Request{"penfold", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	err = store.ClaimLease(key("arm"), lease.Request{"baron", time.Minute})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+	c.Assert(store.Advance(time.Minute), jc.ErrorIsNil)
+	c.Assert(store.ExpireLease(key("arm")), jc.ErrorIsNil)
+
+	target.CheckCalls(c, []testing.StubCall{
+		{"Claimed", []interface{}{key("arm"), "penfold", uint64(1)}},
+		{"Expired", []interface{}{key("arm"), uint64(4)}},
+	})
+}
+
+func (s *storeSuite) TestTrapdoor(c *gc.C) {
+	err := s.store.ClaimLease(key("arm"), lease.Request{"penfold", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Without a Trapdoor the lease substrate can't be reached.
+	var ops []interface{}
+	info := s.store.Leases()[key("arm")]
+	c.Assert(info.Trapdoor(&ops), gc.ErrorMatches, "lease substrate not accessible")
+
+	var trapdoorKey lease.Key
+	var trapdoorHolder string
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:          s.fsm,
+		Raft:         s.raft,
+		Clock:        s.clock,
+		ApplyTimeout: time.Second,
+		Target:       &fakeTarget{},
+		Trapdoor: func(key lease.Key, holder string) lease.Trapdoor {
+			trapdoorKey, trapdoorHolder = key, holder
+			return lease.LockedTrapdoor
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	store.Leases()
+	c.Assert(trapdoorKey, gc.Equals, key("arm"))
+	c.Assert(trapdoorHolder, gc.Equals, "penfold")
+}
+
+func (s *storeSuite) TestClaimInvalidRequest(c *gc.C) {
+	err := s.store.ClaimLease(key("arm"), lease.Request{"penfold", 0})
+	c.Assert(err, gc.ErrorMatches, "invalid request: invalid duration")
+	c.Assert(s.raft.applied, gc.Equals, 0)
+}
+
+func (s *storeSuite) TestPinUnpin(c *gc.C) {
+	err := s.store.ClaimLease(key("arm"), lease.Request{"penfold", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.PinLease(key("arm"), "machine-0"), jc.ErrorIsNil)
+	c.Assert(s.store.Pinned(), jc.DeepEquals, map[lease.Key][]string{
+		key("arm"): {"machine-0"},
+	})
+	c.Assert(s.store.UnpinLease(key("arm"), "machine-0"), jc.ErrorIsNil)
+	c.Assert(s.store.Pinned(), gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestAdvanceConcurrentUpdate(c *gc.C) {
+	other, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:          s.fsm,
+		Raft:         s.raft,
+		Clock:        s.clock,
+		ApplyTimeout: time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other.Advance(time.Second), jc.ErrorIsNil)
+
+	err = s.store.Advance(time.Second)
+	c.Assert(err, gc.Equals, globalclock.ErrConcurrentUpdate)
+
+	// The store has refreshed its view of the time, so
+	// the next attempt succeeds.
+	c.Assert(s.store.Advance(time.Second), jc.ErrorIsNil)
+	c.Assert(s.fsm.GlobalTime(), gc.Equals, time.Time{}.Add(2*time.Second))
+}
+
+func (s *storeSuite) TestNotLeaderIsTimeout(c *gc.C) {
+	s.raft.err = raft.ErrNotLeader
+	err := s.store.ClaimLease(key("arm"), lease.Request{"penfold", time.Minute})
+	c.Assert(errors.Cause(err), gc.Equals, lease.ErrTimeout)
+}
+
+func (s *storeSuite) TestNotLeaderForwardsToLeader(c *gc.C) {
+	var target fakeTarget
+	hub := centralhub.New(names.NewMachineTag("0"))
+	leader := &fakeRaft{fsm: s.fsm}
+	unsubscribe, err := hub.Subscribe(raftlease.RequestTopic,
+		func(_ string, req raftlease.ForwardRequest, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			err = raftlease.ApplyCommand(leader, []byte(req.Command), time.Second, &target)
+			_, err = hub.Publish(req.ResponseTopic, raftlease.ForwardResponse{
+				Error: raftlease.AsResponseError(err),
+			})
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	s.raft.err = raft.ErrNotLeader
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:            s.fsm,
+		Raft:           s.raft,
+		Clock:          clock.WallClock,
+		ApplyTimeout:   time.Second,
+		Hub:            hub,
+		ForwardTimeout: coretesting.LongWait,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = store.ClaimLease(key("arm"), lease.Request{"penfold", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leader.applied, gc.Equals, 1)
+	c.Assert(s.fsm.Leases(s.clock.Now(), noTrapdoor)[key("arm")].Holder, gc.Equals, "penfold")
+	target.CheckCalls(c, []testing.StubCall{
+		{"Claimed", []interface{}{key("arm"), "penfold", uint64(1)}},
+	})
+
+	err = store.ClaimLease(key("arm"), lease.Request{"baron", time.Minute})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
+
+func (s *storeSuite) TestNoRaftForwardsToLeader(c *gc.C) {
+	hub := centralhub.New(names.NewMachineTag("0"))
+	leader := &fakeRaft{fsm: s.fsm}
+	unsubscribe, err := hub.Subscribe(raftlease.RequestTopic,
+		func(_ string, req raftlease.ForwardRequest, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			err = raftlease.ApplyCommand(leader, []byte(req.Command), time.Second, nil)
+			_, err = hub.Publish(req.ResponseTopic, raftlease.ForwardResponse{
+				Error: raftlease.AsResponseError(err),
+			})
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:            s.fsm,
+		Clock:          clock.WallClock,
+		ApplyTimeout:   time.Second,
+		Hub:            hub,
+		ForwardTimeout: coretesting.LongWait,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = store.ClaimLease(key("arm"), lease.Request{"penfold", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leader.applied, gc.Equals, 1)
+	c.Assert(s.raft.applied, gc.Equals, 0)
+	c.Assert(s.fsm.Leases(s.clock.Now(), noTrapdoor)[key("arm")].Holder, gc.Equals, "penfold")
+}
+
+func (s *storeSuite) TestForwardTimeout(c *gc.C) {
+	s.raft.err = raft.ErrNotLeader
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:            s.fsm,
+		Raft:           s.raft,
+		Clock:          clock.WallClock,
+		ApplyTimeout:   time.Second,
+		Hub:            centralhub.New(names.NewMachineTag("0")),
+		ForwardTimeout: time.Millisecond,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = store.ClaimLease(key("arm"), lease.Request{"penfold", time.Minute})
+	c.Assert(errors.Cause(err), gc.Equals, lease.ErrTimeout)
+}
+
+func (s *storeSuite) TestOtherRaftError(c *gc.C) {
+	s.raft.err = errors.New("boom")
+	err := s.store.ClaimLease(key("arm"), lease.Request{"penfold", time.Minute})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+// fakeRaft applies commands directly to an FSM, as a single-node
+// raft cluster would.
+type fakeRaft struct {
+	fsm     *raftlease.FSM
+	err     error
+	applied int
+}
+
+func (r *fakeRaft) Apply(cmd []byte, timeout time.Duration) raft.ApplyFuture {
+	if r.err != nil {
+		return &fakeApplyFuture{err: r.err}
+	}
+	r.applied++
+	response := r.fsm.Apply(&raft.Log{
+		Index: uint64(r.applied),
+		Data:  cmd,
+	})
+	return &fakeApplyFuture{
+		index:    uint64(r.applied),
+		response: response,
+	}
+}
+
+type fakeApplyFuture struct {
+	index    uint64
+	response interface{}
+	err      error
+}
+
+func (f *fakeApplyFuture) Error() error {
+	return f.err
+}
+
+func (f *fakeApplyFuture) Index() uint64 {
+	return f.index
+}
+
+func (f *fakeApplyFuture) Response() interface{} {
+	return f.response
+}
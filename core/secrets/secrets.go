@@ -0,0 +1,119 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets defines the secrets that charms use to share
+// sensitive values, such as passwords, without exposing them in
+// application config or relation data.
+package secrets
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// IDPrefix is the prefix of all secret IDs.
+const IDPrefix = "secret:"
+
+var validName = regexp.MustCompile(`^[a-z0-9]+(?:[-.][a-z0-9]+)*$`)
+
+// IsValidName returns whether name is a valid secret name.
+func IsValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// NewID returns the ID of the named secret owned by the given
+// application.
+func NewID(owner, name string) string {
+	return IDPrefix + owner + "/" + name
+}
+
+// ParseID returns the owning application and name of the secret with
+// the given ID.
+func ParseID(id string) (owner, name string, _ error) {
+	if !strings.HasPrefix(id, IDPrefix) {
+		return "", "", errors.NotValidf("secret ID %q", id)
+	}
+	parts := strings.Split(strings.TrimPrefix(id, IDPrefix), "/")
+	if len(parts) != 2 || parts[0] == "" || !IsValidName(parts[1]) {
+		return "", "", errors.NotValidf("secret ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Value holds the key/value pairs that make up a revision of a secret.
+type Value map[string]string
+
+// Consumer records an application that has been granted access to a
+// secret by its owner over a relation.
+type Consumer struct {
+	// Application is the name of the consuming application.
+	Application string
+
+	// RelationKey is the key of the relation over which access
+	// was granted.
+	RelationKey string
+}
+
+// Metadata holds everything about a secret except its value.
+type Metadata struct {
+	// ID uniquely identifies the secret within its model.
+	ID string
+
+	// Owner is the name of the application that created the secret.
+	Owner string
+
+	// Name is the name given to the secret by its owner.
+	Name string
+
+	// Description is an optional description of the secret.
+	Description string
+
+	// Revision is the latest revision of the secret's value. The first
+	// revision is 1.
+	Revision int
+
+	// Consumers holds the applications that have been granted access
+	// to the secret.
+	Consumers []Consumer
+
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+// CanRead returns whether the named application may read the value of
+// the secret.
+func (m *Metadata) CanRead(application string) bool {
+	if application == m.Owner {
+		return true
+	}
+	for _, consumer := range m.Consumers {
+		if consumer.Application == application {
+			return true
+		}
+	}
+	return false
+}
+
+// Backend stores the values of secrets. Values are stored per revision,
+// so that consumers may continue to read a revision after it has been
+// superseded.
+type Backend interface {
+	// SaveValue stores the value of the given revision of a secret,
+	// replacing any value already stored for that revision.
+	SaveValue(id string, revision int, value Value) error
+
+	// Value returns the value of the given revision of a secret. An
+	// error satisfying errors.IsNotFound is returned if there is no
+	// such value.
+	Value(id string, revision int) (Value, error)
+
+	// DeleteValue removes the stored value of the given revision of
+	// a secret. It is not an error if there is no such value.
+	DeleteValue(id string, revision int) error
+
+	// DeleteValues removes all stored revisions of a secret.
+	DeleteValues(id string) error
+}
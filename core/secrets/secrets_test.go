@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) TestIsValidName(c *gc.C) {
+	for _, name := range []string{"password", "db-password", "tls.key", "a1"} {
+		c.Check(secrets.IsValidName(name), jc.IsTrue, gc.Commentf("%q", name))
+	}
+	for _, name := range []string{"", "Password", "-foo", "foo-", "foo..bar", "foo/bar", "foo bar"} {
+		c.Check(secrets.IsValidName(name), jc.IsFalse, gc.Commentf("%q", name))
+	}
+}
+
+func (s *secretsSuite) TestID(c *gc.C) {
+	id := secrets.NewID("mysql", "password")
+	c.Assert(id, gc.Equals, "secret:mysql/password")
+	owner, name, err := secrets.ParseID(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, "mysql")
+	c.Assert(name, gc.Equals, "password")
+}
+
+func (s *secretsSuite) TestParseIDInvalid(c *gc.C) {
+	for _, id := range []string{"", "mysql/password", "secret:mysql", "secret:/password", "secret:mysql/Password", "secret:a/b/c"} {
+		_, _, err := secrets.ParseID(id)
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("%q", id))
+	}
+}
+
+func (s *secretsSuite) TestCanRead(c *gc.C) {
+	md := secrets.Metadata{
+		Owner: "mysql",
+		Consumers: []secrets.Consumer{{
+			Application: "wordpress",
+			RelationKey: "wordpress:db mysql:server",
+		}},
+	}
+	c.Check(md.CanRead("mysql"), jc.IsTrue)
+	c.Check(md.CanRead("wordpress"), jc.IsTrue)
+	c.Check(md.CanRead("mediawiki"), jc.IsFalse)
+}
@@ -0,0 +1,209 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// otlpTimeout is how long an OTLP exporter waits for a
+// response before treating the export as failed.
+const otlpTimeout = 30 * time.Second
+
+// NewFileExporter returns an Exporter that appends spans to the file
+// at the given path, one JSON object per line, for offline analysis.
+func NewFileExporter(path string) (Exporter, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &fileExporter{w: f}, nil
+}
+
+type fileExporter struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// Export implements Exporter.
+func (e *fileExporter) Export(spans []SpanData) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, span := range spans {
+		if err := encoder.Encode(span); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err := e.w.Write(buf.Bytes())
+	return errors.Trace(err)
+}
+
+// Close implements Exporter.
+func (e *fileExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return errors.Trace(e.w.Close())
+}
+
+// NewOTLPExporter returns an Exporter that POSTs spans to url using
+// the OpenTelemetry protocol's JSON encoding over HTTP, as accepted
+// by OpenTelemetry collectors and Jaeger, typically at a URL ending
+// in "/v1/traces". The spans are reported as coming from the named
+// service.
+func NewOTLPExporter(url, serviceName string) Exporter {
+	return &otlpExporter{
+		url:         url,
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: otlpTimeout},
+	}
+}
+
+type otlpExporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+}
+
+// Export implements Exporter.
+func (e *otlpExporter) Export(spans []SpanData) error {
+	data, err := json.Marshal(newOTLPRequest(e.serviceName, spans))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("%s returned %s", e.url, resp.Status)
+	}
+	return nil
+}
+
+// Close implements Exporter.
+func (e *otlpExporter) Close() error {
+	return nil
+}
+
+// The following types describe the OTLP JSON encoding of an
+// ExportTraceServiceRequest.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+	otlpStatusCodeError  = 2
+)
+
+func newOTLPRequest(serviceName string, spans []SpanData) otlpRequest {
+	otlpSpans := make([]otlpSpan, len(spans))
+	for i, span := range spans {
+		otlpSpans[i] = newOTLPSpan(span)
+	}
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpAttribute{newOTLPAttribute("service.name", serviceName)},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/juju/juju"},
+				Spans: otlpSpans,
+			}},
+		}},
+	}
+}
+
+func newOTLPSpan(span SpanData) otlpSpan {
+	result := otlpSpan{
+		TraceID:           span.TraceID,
+		SpanID:            span.SpanID,
+		ParentSpanID:      span.ParentSpanID,
+		Name:              span.Name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+	}
+	if span.Kind == SpanKindServer {
+		result.Kind = otlpSpanKindServer
+	}
+	if span.Error != "" {
+		result.Status = otlpStatus{Code: otlpStatusCodeError, Message: span.Error}
+	}
+	keys := make([]string, 0, len(span.Attributes))
+	for key := range span.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		result.Attributes = append(result.Attributes, newOTLPAttribute(key, span.Attributes[key]))
+	}
+	return result
+}
+
+func newOTLPAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAnyValue{StringValue: value}}
+}
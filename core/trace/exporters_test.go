@@ -0,0 +1,132 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/trace"
+)
+
+type ExportersSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ExportersSuite{})
+
+var testSpans = []trace.SpanData{{
+	TraceID:      "0123456789abcdef0123456789abcdef",
+	SpanID:       "1111111111111111",
+	ParentSpanID: "0123456789abcdef",
+	Name:         "Application.Deploy",
+	Kind:         trace.SpanKindServer,
+	Start:        time.Unix(10, 0).UTC(),
+	End:          time.Unix(12, 500).UTC(),
+	Attributes:   map[string]string{"rpc.version": "9", "model": "deadbeef"},
+	Error:        "boom",
+}, {
+	TraceID:      "0123456789abcdef0123456789abcdef",
+	SpanID:       "2222222222222222",
+	ParentSpanID: "1111111111111111",
+	Name:         "state.AddApplication",
+	Kind:         trace.SpanKindInternal,
+	Start:        time.Unix(11, 0).UTC(),
+	End:          time.Unix(12, 0).UTC(),
+}}
+
+func (s *ExportersSuite) TestFileExporter(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "traces.log")
+	exporter, err := trace.NewFileExporter(path)
+	c.Assert(err, jc.ErrorIsNil)
+	err = exporter.Export(testSpans[:1])
+	c.Assert(err, jc.ErrorIsNil)
+	err = exporter.Export(testSpans[1:])
+	c.Assert(err, jc.ErrorIsNil)
+	err = exporter.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, ``+
+		`{"trace-id":"0123456789abcdef0123456789abcdef","span-id":"1111111111111111",`+
+		`"parent-span-id":"0123456789abcdef","name":"Application.Deploy","kind":"server",`+
+		`"start":"1970-01-01T00:00:10Z","end":"1970-01-01T00:00:12.0000005Z",`+
+		`"attributes":{"model":"deadbeef","rpc.version":"9"},"error":"boom"}`+"\n"+
+		`{"trace-id":"0123456789abcdef0123456789abcdef","span-id":"2222222222222222",`+
+		`"parent-span-id":"1111111111111111","name":"state.AddApplication","kind":"internal",`+
+		`"start":"1970-01-01T00:00:11Z","end":"1970-01-01T00:00:12Z"}`+"\n",
+	)
+}
+
+func (s *ExportersSuite) TestOTLPExporter(c *gc.C) {
+	var request map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/v1/traces")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		c.Check(json.NewDecoder(req.Body).Decode(&request), jc.ErrorIsNil)
+	}))
+	defer server.Close()
+
+	exporter := trace.NewOTLPExporter(server.URL+"/v1/traces", "juju-controller")
+	err := exporter.Export(testSpans)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var expected map[string]interface{}
+	err = json.Unmarshal([]byte(`{
+	"resourceSpans": [{
+		"resource": {
+			"attributes": [{"key": "service.name", "value": {"stringValue": "juju-controller"}}]
+		},
+		"scopeSpans": [{
+			"scope": {"name": "github.com/juju/juju"},
+			"spans": [{
+				"traceId": "0123456789abcdef0123456789abcdef",
+				"spanId": "1111111111111111",
+				"parentSpanId": "0123456789abcdef",
+				"name": "Application.Deploy",
+				"kind": 2,
+				"startTimeUnixNano": "10000000000",
+				"endTimeUnixNano": "12000000500",
+				"attributes": [
+					{"key": "model", "value": {"stringValue": "deadbeef"}},
+					{"key": "rpc.version", "value": {"stringValue": "9"}}
+				],
+				"status": {"code": 2, "message": "boom"}
+			}, {
+				"traceId": "0123456789abcdef0123456789abcdef",
+				"spanId": "2222222222222222",
+				"parentSpanId": "1111111111111111",
+				"name": "state.AddApplication",
+				"kind": 1,
+				"startTimeUnixNano": "11000000000",
+				"endTimeUnixNano": "12000000000",
+				"status": {}
+			}]
+		}]
+	}]
+}`), &expected)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(request, jc.DeepEquals, expected)
+}
+
+func (s *ExportersSuite) TestOTLPExporterError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	exporter := trace.NewOTLPExporter(server.URL, "juju-controller")
+	err := exporter.Export(testSpans)
+	c.Assert(err, gc.ErrorMatches, `.* returned 503 Service Unavailable`)
+}
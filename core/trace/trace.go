@@ -0,0 +1,260 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package trace provides distributed request tracing. A client starts
+// a trace by generating a trace ID, which is propagated with each API
+// request it makes; the API server records a span for every request
+// that is part of a trace, and the code serving the request can record
+// child spans for the operations it performs. Finished spans are sent
+// to an Exporter.
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+var logger = loggo.GetLogger("juju.core.trace")
+
+var (
+	validTraceID = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	validSpanID  = regexp.MustCompile(`^[0-9a-f]{16}$`)
+)
+
+// SpanContext identifies a span within a trace. It is what is
+// propagated between processes to link their spans together.
+type SpanContext struct {
+	// TraceID identifies the trace, as 32 lower case hex digits.
+	TraceID string
+
+	// SpanID identifies the span within the trace,
+	// as 16 lower case hex digits.
+	SpanID string
+}
+
+// IsValid reports whether the span context holds
+// well-formed trace and span IDs.
+func (sc SpanContext) IsValid() bool {
+	return validTraceID.MatchString(sc.TraceID) && validSpanID.MatchString(sc.SpanID)
+}
+
+// IsValidTraceID reports whether id is a well-formed trace ID.
+func IsValidTraceID(id string) bool {
+	return validTraceID.MatchString(id)
+}
+
+var (
+	processTraceOnce sync.Once
+	processTrace     SpanContext
+)
+
+// ProcessSpanContext returns the span context that API requests made
+// by this process are part of, given the value of $JUJU_TRACE. If the
+// value is empty the span context is not valid and requests are not
+// traced; if it holds a trace ID the requests are made part of that
+// trace; otherwise a new trace is started, which all subsequent calls
+// share.
+func ProcessSpanContext(value string) SpanContext {
+	if value == "" {
+		return SpanContext{}
+	}
+	processTraceOnce.Do(func() {
+		processTrace = NewSpanContext()
+		if !IsValidTraceID(value) {
+			logger.Infof("tracing API requests with trace ID %s", processTrace.TraceID)
+		}
+	})
+	sc := processTrace
+	if IsValidTraceID(value) {
+		sc.TraceID = value
+	}
+	return sc
+}
+
+// NewSpanContext returns a span context that starts a new trace.
+func NewSpanContext() SpanContext {
+	return SpanContext{
+		TraceID: randomID(16),
+		SpanID:  randomID(8),
+	}
+}
+
+func randomID(n int) string {
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, buf); err != nil {
+		panic(errors.Annotate(err, "generating trace id"))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// SpanKind describes the relationship of a span to the
+// other spans in its trace.
+type SpanKind string
+
+const (
+	// SpanKindInternal is the kind of spans recording
+	// operations within a process.
+	SpanKindInternal SpanKind = "internal"
+
+	// SpanKindServer is the kind of spans recording
+	// the handling of a request from a remote client.
+	SpanKindServer SpanKind = "server"
+)
+
+// SpanData holds the details of a finished span.
+type SpanData struct {
+	TraceID      string            `json:"trace-id"`
+	SpanID       string            `json:"span-id"`
+	ParentSpanID string            `json:"parent-span-id,omitempty"`
+	Name         string            `json:"name"`
+	Kind         SpanKind          `json:"kind"`
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+type contextKey int
+
+const (
+	spanContextKey contextKey = iota
+	tracerKey
+)
+
+// ContextWithSpanContext returns a context holding sc, so that spans
+// started from the context become its children.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey, sc)
+}
+
+// SpanContextFromContext returns the span context held by ctx. The
+// returned span context is not valid if ctx is not part of a trace.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	sc, _ := ctx.Value(spanContextKey).(SpanContext)
+	return sc
+}
+
+// ContextWithTracer returns a context holding the tracer used
+// to record spans started from the context.
+func ContextWithTracer(ctx context.Context, tracer *Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey, tracer)
+}
+
+// TracerFromContext returns the tracer held by ctx, or nil.
+func TracerFromContext(ctx context.Context) *Tracer {
+	tracer, _ := ctx.Value(tracerKey).(*Tracer)
+	return tracer
+}
+
+// StartSpan starts an internal span with the given name, as a child of
+// the span held by ctx. It returns a context holding the new span, to
+// pass to the operations it covers. Spans are only recorded as part of
+// an existing trace; if ctx holds no span or no tracer, a nil span is
+// returned, which can be used but records nothing.
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	return startSpan(ctx, name, SpanKindInternal)
+}
+
+// StartSpanAt is like StartSpan but records the span as having started
+// at the given time, for operations which are only observed once they
+// have finished.
+func StartSpanAt(ctx context.Context, name string, start time.Time) (context.Context, *Span) {
+	ctx, span := startSpan(ctx, name, SpanKindInternal)
+	if span != nil {
+		span.data.Start = start
+	}
+	return ctx, span
+}
+
+// StartServerSpan is like StartSpan but starts a span recording the
+// handling of a request from a remote client.
+func StartServerSpan(ctx context.Context, name string) (context.Context, *Span) {
+	return startSpan(ctx, name, SpanKindServer)
+}
+
+func startSpan(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+	tracer := TracerFromContext(ctx)
+	if tracer == nil || !parent.IsValid() {
+		return ctx, nil
+	}
+	span := &Span{
+		tracer: tracer,
+		data: SpanData{
+			TraceID:      parent.TraceID,
+			SpanID:       randomID(8),
+			ParentSpanID: parent.SpanID,
+			Name:         name,
+			Kind:         kind,
+			Start:        tracer.config.Clock.Now(),
+		},
+	}
+	return ContextWithSpanContext(ctx, span.SpanContext()), span
+}
+
+// Span records an operation within a trace. All methods may be
+// called on a nil Span, and do nothing.
+type Span struct {
+	tracer *Tracer
+
+	mu    sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+// SpanContext returns the context identifying the span.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return SpanContext{TraceID: s.data.TraceID, SpanID: s.data.SpanID}
+}
+
+// SetAttribute records a key/value attribute of the operation.
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data.Attributes == nil {
+		s.data.Attributes = make(map[string]string)
+	}
+	s.data.Attributes[key] = value
+}
+
+// SetError records that the operation failed with err.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Error = err.Error()
+}
+
+// End finishes the span and sends it to be exported. Calls
+// after the first have no effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = s.tracer.config.Clock.Now()
+	data := s.data
+	s.mu.Unlock()
+	s.tracer.record(data)
+}
@@ -0,0 +1,206 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/trace"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SpanSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	exporter *fakeExporter
+	tracer   *trace.Tracer
+}
+
+var _ = gc.Suite(&SpanSuite{})
+
+func (s *SpanSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	s.exporter = newFakeExporter()
+	tracer, err := trace.NewTracer(trace.Config{
+		Exporter:      s.exporter,
+		Clock:         s.clock,
+		BufferSize:    10,
+		BatchSize:     1,
+		FlushInterval: time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.tracer = tracer
+	s.AddCleanup(func(*gc.C) { s.tracer.Close() })
+}
+
+func (s *SpanSuite) TestNewSpanContext(c *gc.C) {
+	sc := trace.NewSpanContext()
+	c.Assert(sc.IsValid(), jc.IsTrue)
+	c.Assert(trace.IsValidTraceID(sc.TraceID), jc.IsTrue)
+	c.Assert(sc, gc.Not(gc.Equals), trace.NewSpanContext())
+}
+
+func (s *SpanSuite) TestSpanContextValidity(c *gc.C) {
+	c.Assert(trace.SpanContext{}.IsValid(), jc.IsFalse)
+	c.Assert(trace.SpanContext{
+		TraceID: "0123456789abcdef0123456789abcdef",
+		SpanID:  "0123456789abcdef",
+	}.IsValid(), jc.IsTrue)
+	c.Assert(trace.SpanContext{
+		TraceID: "0123456789ABCDEF0123456789ABCDEF",
+		SpanID:  "0123456789abcdef",
+	}.IsValid(), jc.IsFalse)
+	c.Assert(trace.SpanContext{
+		TraceID: "0123456789abcdef0123456789abcdef",
+		SpanID:  "0123",
+	}.IsValid(), jc.IsFalse)
+}
+
+func (s *SpanSuite) TestStartSpanNotTraced(c *gc.C) {
+	// Without a trace, nothing is recorded.
+	ctx := trace.ContextWithTracer(context.Background(), s.tracer)
+	spanCtx, span := trace.StartSpan(ctx, "operation")
+	c.Assert(span, gc.IsNil)
+	c.Assert(spanCtx, gc.Equals, ctx)
+
+	// Spans can be used regardless.
+	span.SetAttribute("key", "value")
+	span.SetError(errors.New("boom"))
+	span.End()
+	c.Assert(span.SpanContext(), gc.Equals, trace.SpanContext{})
+}
+
+func (s *SpanSuite) TestStartSpanNoTracer(c *gc.C) {
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext())
+	_, span := trace.StartSpan(ctx, "operation")
+	c.Assert(span, gc.IsNil)
+}
+
+func (s *SpanSuite) TestSpansRecorded(c *gc.C) {
+	parent := trace.NewSpanContext()
+	ctx := trace.ContextWithTracer(context.Background(), s.tracer)
+	ctx = trace.ContextWithSpanContext(ctx, parent)
+
+	ctx, server := trace.StartServerSpan(ctx, "Application.Deploy")
+	c.Assert(trace.SpanContextFromContext(ctx), gc.Equals, server.SpanContext())
+	_, child := trace.StartSpan(ctx, "state.AddApplication")
+	child.SetAttribute("application", "mysql")
+	s.clock.Advance(time.Second)
+	child.End()
+	server.SetError(errors.New("boom"))
+	server.End()
+	// Ending again does nothing.
+	server.End()
+
+	start := s.clock.Now().Add(-time.Second)
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []trace.SpanData{{
+		TraceID:      parent.TraceID,
+		SpanID:       child.SpanContext().SpanID,
+		ParentSpanID: server.SpanContext().SpanID,
+		Name:         "state.AddApplication",
+		Kind:         trace.SpanKindInternal,
+		Start:        start,
+		End:          s.clock.Now(),
+		Attributes:   map[string]string{"application": "mysql"},
+	}})
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []trace.SpanData{{
+		TraceID:      parent.TraceID,
+		SpanID:       server.SpanContext().SpanID,
+		ParentSpanID: parent.SpanID,
+		Name:         "Application.Deploy",
+		Kind:         trace.SpanKindServer,
+		Start:        start,
+		End:          s.clock.Now(),
+		Error:        "boom",
+	}})
+	s.assertNoBatch(c)
+}
+
+func (s *SpanSuite) TestStartSpanAt(c *gc.C) {
+	parent := trace.NewSpanContext()
+	ctx := trace.ContextWithTracer(context.Background(), s.tracer)
+	ctx = trace.ContextWithSpanContext(ctx, parent)
+
+	start := s.clock.Now().Add(-time.Minute)
+	_, span := trace.StartSpanAt(ctx, "state.txn", start)
+	span.End()
+
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []trace.SpanData{{
+		TraceID:      parent.TraceID,
+		SpanID:       span.SpanContext().SpanID,
+		ParentSpanID: parent.SpanID,
+		Name:         "state.txn",
+		Kind:         trace.SpanKindInternal,
+		Start:        start,
+		End:          s.clock.Now(),
+	}})
+}
+
+func (s *SpanSuite) TestProcessSpanContext(c *gc.C) {
+	c.Assert(trace.ProcessSpanContext(""), gc.Equals, trace.SpanContext{})
+
+	generated := trace.ProcessSpanContext("1")
+	c.Assert(generated.IsValid(), jc.IsTrue)
+	// The same trace is used for all calls.
+	c.Assert(trace.ProcessSpanContext("1"), gc.Equals, generated)
+
+	sc := trace.ProcessSpanContext("0123456789abcdef0123456789abcdef")
+	c.Assert(sc.IsValid(), jc.IsTrue)
+	c.Assert(sc.TraceID, gc.Equals, "0123456789abcdef0123456789abcdef")
+}
+
+func (s *SpanSuite) nextBatch(c *gc.C) []trace.SpanData {
+	select {
+	case batch := <-s.exporter.exported:
+		return batch
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for spans")
+	}
+	panic("unreachable")
+}
+
+func (s *SpanSuite) assertNoBatch(c *gc.C) {
+	select {
+	case batch := <-s.exporter.exported:
+		c.Fatalf("unexpected spans %v", batch)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeExporter struct {
+	testing.Stub
+	exported chan []trace.SpanData
+	closed   chan struct{}
+}
+
+func newFakeExporter() *fakeExporter {
+	return &fakeExporter{
+		exported: make(chan []trace.SpanData, 10),
+		closed:   make(chan struct{}),
+	}
+}
+
+func (e *fakeExporter) Export(spans []trace.SpanData) error {
+	e.MethodCall(e, "Export", spans)
+	if err := e.NextErr(); err != nil {
+		return err
+	}
+	batch := make([]trace.SpanData, len(spans))
+	copy(batch, spans)
+	e.exported <- batch
+	return nil
+}
+
+func (e *fakeExporter) Close() error {
+	e.MethodCall(e, "Close")
+	close(e.closed)
+	return e.NextErr()
+}
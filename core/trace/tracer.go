@@ -0,0 +1,177 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+// Exporter is a destination that finished spans are sent to.
+type Exporter interface {
+	// Export delivers a batch of spans, returning an error
+	// if they couldn't be delivered.
+	Export([]SpanData) error
+
+	// Close releases any resources held by the exporter.
+	Close() error
+}
+
+// Config holds the parameters needed to create a Tracer.
+type Config struct {
+	// Exporter is where finished spans are sent.
+	Exporter Exporter
+
+	// Clock is used to time spans and batches.
+	Clock clock.Clock
+
+	// BufferSize is the number of finished spans that will be held
+	// waiting to be exported. Once the buffer is full any further
+	// spans are dropped.
+	BufferSize int
+
+	// BatchSize is the largest number of spans exported at once.
+	BatchSize int
+
+	// FlushInterval is the longest a finished span will
+	// wait before being exported.
+	FlushInterval time.Duration
+}
+
+// Validate checks that the tracer config is usable.
+func (cfg Config) Validate() error {
+	if cfg.Exporter == nil {
+		return errors.NotValidf("nil Exporter")
+	}
+	if cfg.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if cfg.BufferSize <= 0 {
+		return errors.NotValidf("non-positive BufferSize")
+	}
+	if cfg.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if cfg.FlushInterval <= 0 {
+		return errors.NotValidf("non-positive FlushInterval")
+	}
+	return nil
+}
+
+// Tracer records finished spans, exporting them in batches in the
+// background. Recording a span never blocks: if the exporter can't
+// keep up, spans are dropped so that tracing can't hold up the
+// operations being traced. Failed exports are not retried.
+type Tracer struct {
+	config    Config
+	spans     chan SpanData
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// dropped is the number of spans discarded since the
+	// last successful export, accessed atomically.
+	dropped int64
+}
+
+// NewTracer returns a new Tracer with the given configuration.
+// The tracer must be closed when it is no longer needed.
+func NewTracer(cfg Config) (*Tracer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	t := &Tracer{
+		config: cfg,
+		spans:  make(chan SpanData, cfg.BufferSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go t.loop()
+	return t, nil
+}
+
+// Close exports any spans that have been recorded,
+// and closes the exporter.
+func (t *Tracer) Close() error {
+	t.closeOnce.Do(func() { close(t.stop) })
+	<-t.done
+	return nil
+}
+
+func (t *Tracer) record(span SpanData) {
+	select {
+	case <-t.stop:
+		return
+	default:
+	}
+	select {
+	case t.spans <- span:
+	default:
+		if atomic.AddInt64(&t.dropped, 1) == 1 {
+			logger.Warningf("trace span buffer is full, dropping spans")
+		}
+	}
+}
+
+func (t *Tracer) loop() {
+	defer close(t.done)
+	defer func() {
+		if err := t.config.Exporter.Close(); err != nil {
+			logger.Warningf("closing trace exporter: %v", err)
+		}
+	}()
+	var (
+		batch []SpanData
+		flush <-chan time.Time
+	)
+	for {
+		select {
+		case <-t.stop:
+			// Export whatever has already been recorded.
+		drain:
+			for {
+				select {
+				case span := <-t.spans:
+					batch = append(batch, span)
+				default:
+					break drain
+				}
+			}
+			for len(batch) > 0 {
+				n := len(batch)
+				if n > t.config.BatchSize {
+					n = t.config.BatchSize
+				}
+				t.export(batch[:n])
+				batch = batch[n:]
+			}
+			return
+		case span := <-t.spans:
+			batch = append(batch, span)
+			if len(batch) >= t.config.BatchSize {
+				t.export(batch)
+				batch, flush = nil, nil
+			} else if flush == nil {
+				flush = t.config.Clock.After(t.config.FlushInterval)
+			}
+		case <-flush:
+			t.export(batch)
+			batch, flush = nil, nil
+		}
+	}
+}
+
+func (t *Tracer) export(batch []SpanData) {
+	if err := t.config.Exporter.Export(batch); err != nil {
+		logger.Errorf("cannot export %d trace spans: %v", len(batch), err)
+		return
+	}
+	if dropped := atomic.SwapInt64(&t.dropped, 0); dropped > 0 {
+		logger.Warningf("dropped %d trace spans", dropped)
+	}
+}
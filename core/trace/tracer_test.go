@@ -0,0 +1,123 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/trace"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type TracerSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	exporter *fakeExporter
+}
+
+var _ = gc.Suite(&TracerSuite{})
+
+func (s *TracerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.exporter = newFakeExporter()
+}
+
+func (s *TracerSuite) newTracer(c *gc.C) *trace.Tracer {
+	tracer, err := trace.NewTracer(trace.Config{
+		Exporter:      s.exporter,
+		Clock:         s.clock,
+		BufferSize:    10,
+		BatchSize:     3,
+		FlushInterval: time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return tracer
+}
+
+func (s *TracerSuite) recordSpans(tracer *trace.Tracer, names ...string) {
+	ctx := trace.ContextWithTracer(context.Background(), tracer)
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext())
+	for _, name := range names {
+		_, span := trace.StartSpan(ctx, name)
+		span.End()
+	}
+}
+
+func (s *TracerSuite) nextBatch(c *gc.C) []string {
+	select {
+	case batch := <-s.exporter.exported:
+		names := make([]string, len(batch))
+		for i, span := range batch {
+			names[i] = span.Name
+		}
+		return names
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for spans")
+	}
+	panic("unreachable")
+}
+
+func (s *TracerSuite) TestValidate(c *gc.C) {
+	_, err := trace.NewTracer(trace.Config{
+		Exporter:      s.exporter,
+		Clock:         s.clock,
+		BatchSize:     3,
+		FlushInterval: time.Second,
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive BufferSize not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *TracerSuite) TestExportsFullBatches(c *gc.C) {
+	tracer := s.newTracer(c)
+	defer tracer.Close()
+
+	s.recordSpans(tracer, "a", "b", "c", "d")
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []string{"a", "b", "c"})
+}
+
+func (s *TracerSuite) TestExportsAfterFlushInterval(c *gc.C) {
+	tracer := s.newTracer(c)
+	defer tracer.Close()
+
+	s.recordSpans(tracer, "a", "b")
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []string{"a", "b"})
+}
+
+func (s *TracerSuite) TestCloseExportsRecordedSpans(c *gc.C) {
+	tracer := s.newTracer(c)
+	s.recordSpans(tracer, "a", "b", "c", "d")
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []string{"a", "b", "c"})
+
+	err := tracer.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []string{"d"})
+	select {
+	case <-s.exporter.closed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("exporter not closed")
+	}
+
+	// Spans ended after the tracer is closed are discarded.
+	s.recordSpans(tracer, "e")
+	s.exporter.CheckCallNames(c, "Export", "Export", "Close")
+}
+
+func (s *TracerSuite) TestExportFailureDropsBatch(c *gc.C) {
+	s.exporter.SetErrors(errors.New("collector unavailable"))
+	tracer := s.newTracer(c)
+	defer tracer.Close()
+
+	s.recordSpans(tracer, "a", "b", "c", "d", "e", "f")
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []string{"d", "e", "f"})
+}
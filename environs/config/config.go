@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
+	"github.com/juju/juju/logfwd/sinkconfig"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 )
@@ -188,6 +189,10 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogForwardSinks is a YAML map describing the additional sinks to
+	// which the model's logs are forwarded, keyed by sink name.
+	LogForwardSinks = "logforward-sinks"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -219,6 +224,15 @@ const (
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
+	// HookTimeout is the maximum time a charm hook may run before it
+	// is killed and the unit is put into an error state, eg "30m".
+	// An empty value means hooks are never timed out.
+	HookTimeout = "hook-timeout"
+
+	// HookWarningThreshold is how long a charm hook may run before the
+	// unit agent reports that it is taking a long time, eg "10m".
+	HookWarningThreshold = "hook-warning-threshold"
+
 	// EgressSubnets are the source addresses from which traffic from this model
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
@@ -399,6 +413,9 @@ const (
 	// DefaultUpdateStatusHookInterval is the default value for UpdateStatusHookInterval
 	DefaultUpdateStatusHookInterval = "5m"
 
+	// DefaultHookWarningThreshold is the default value for HookWarningThreshold.
+	DefaultHookWarningThreshold = "10m"
+
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
@@ -595,6 +612,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[LogForwardSinks].(string); ok && v != "" {
+		if _, err := sinkconfig.Parse(v); err != nil {
+			return errors.Annotate(err, "invalid logforward-sinks")
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -641,6 +664,22 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[HookTimeout].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid hook timeout in model configuration")
+		} else if d < 0 {
+			return errors.Errorf("hook timeout %v cannot be negative", d)
+		}
+	}
+
+	if v, ok := cfg.defined[HookWarningThreshold].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid hook warning threshold in model configuration")
+		} else if d <= 0 {
+			return errors.Errorf("hook warning threshold %v must be positive", d)
+		}
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -1068,6 +1107,23 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdSinks returns the config for each of the sinks to which the
+// model's logs are forwarded. This includes the sink configured by the
+// syslog-* keys if log forwarding is enabled.
+func (c *Config) LogFwdSinks() []sinkconfig.Config {
+	var sinks []sinkconfig.Config
+	if lfCfg, ok := c.LogFwdSyslog(); ok && lfCfg.Enabled {
+		sinks = append(sinks, sinkconfig.Config{
+			Name:   sinkconfig.SyslogSinkName,
+			Type:   sinkconfig.TypeSyslog,
+			Syslog: *lfCfg,
+		})
+	}
+	// The value has already been validated.
+	configured, _ := sinkconfig.Parse(c.asString(LogForwardSinks))
+	return append(sinks, configured...)
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1332,6 +1388,26 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// HookTimeout is the maximum time a charm hook may run before it is
+// killed. Zero means hooks are never timed out.
+func (c *Config) HookTimeout() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(HookTimeout))
+	return val
+}
+
+// HookWarningThreshold is how long a charm hook may run before the
+// unit agent reports that it is taking a long time.
+func (c *Config) HookWarningThreshold() time.Duration {
+	raw := c.asString(HookWarningThreshold)
+	if raw == "" {
+		raw = DefaultHookWarningThreshold
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1442,6 +1518,7 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogForwardSinks:        schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1494,6 +1571,8 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsAge:          schema.Omit,
 	MaxActionResultsSize:         schema.Omit,
 	UpdateStatusHookInterval:     schema.Omit,
+	HookTimeout:                  schema.Omit,
+	HookWarningThreshold:         schema.Omit,
 	EgressSubnets:                schema.Omit,
 	FanConfig:                    schema.Omit,
 	CloudInitUserDataKey:         schema.Omit,
@@ -1898,6 +1977,11 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogForwardSinks: {
+		Description: `Additional log forwarding sinks (in yaml format), keyed by name. Each has a type of "syslog", "http" or "file", and may filter records by level, entities and modules.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1978,6 +2062,16 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	HookTimeout: {
+		Description: "The maximum time a charm hook may run before it is killed and the unit is put into an error state, in human-readable time format (default none)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	HookWarningThreshold: {
+		Description: "How long a charm hook may run before the unit reports it as long-running, in human-readable time format (default 10m)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	EgressSubnets: {
 		Description: "Source address(es) for traffic originating from this model",
 		Type:        environschema.Tstring,
@@ -23,6 +23,8 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -571,6 +573,40 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"backup-dir": "/foo/bar",
 		}),
+	}, {
+		about:       "Invalid hook-timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "forever",
+		}),
+		err: `invalid hook timeout in model configuration: time: invalid duration "?forever"?`,
+	}, {
+		about:       "Negative hook-timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "-5m",
+		}),
+		err: `hook timeout -5m0s cannot be negative`,
+	}, {
+		about:       "Zero hook-warning-threshold",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-warning-threshold": "0s",
+		}),
+		err: `hook warning threshold 0s must be positive`,
+	}, {
+		about:       "Valid logforward-sinks",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-sinks": "archive:\n  type: file\n",
+		}),
+	}, {
+		about:       "Invalid logforward-sinks",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-sinks": "archive:\n  type: file\n  level: LOUD\n",
+		}),
+		err: `invalid logforward-sinks: log forwarding sink "archive": level "LOUD" not valid`,
 	},
 }
 
@@ -1318,6 +1354,65 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestHookTimeoutConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookTimeout(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.HookWarningThreshold(), gc.Equals, 10*time.Minute)
+}
+
+func (s *ConfigSuite) TestHookTimeoutConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"hook-timeout":           "1h",
+		"hook-warning-threshold": "15m",
+	})
+	c.Assert(cfg.HookTimeout(), gc.Equals, time.Hour)
+	c.Assert(cfg.HookWarningThreshold(), gc.Equals, 15*time.Minute)
+}
+
+func (s *ConfigSuite) TestLogFwdSinks(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled": true,
+		"syslog-host":        "localhost:1234",
+		"syslog-ca-cert":     testing.CACert,
+		"syslog-client-cert": testing.ServerCert,
+		"syslog-client-key":  testing.ServerKey,
+		"logforward-sinks": `
+es:
+  type: http
+  format: elasticsearch
+  url: https://es.example.com:9200/juju/_bulk
+  level: ERROR
+`,
+	})
+	c.Assert(cfg.LogFwdSinks(), jc.DeepEquals, []sinkconfig.Config{{
+		Name: "juju-log-forward",
+		Type: sinkconfig.TypeSyslog,
+		Syslog: syslog.RawConfig{
+			Enabled:    true,
+			Host:       "localhost:1234",
+			CACert:     testing.CACert,
+			ClientCert: testing.ServerCert,
+			ClientKey:  testing.ServerKey,
+		},
+	}, {
+		Name:   "es",
+		Type:   sinkconfig.TypeHTTP,
+		Filter: sinkconfig.Filter{Level: loggo.ERROR},
+		HTTP: sinkconfig.HTTPConfig{
+			URL:    "https://es.example.com:9200/juju/_bulk",
+			Format: sinkconfig.FormatElasticsearch,
+		},
+	}})
+}
+
+func (s *ConfigSuite) TestLogFwdSinksSyslogDisabled(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled": false,
+		"syslog-host":        "localhost:1234",
+	})
+	c.Assert(cfg.LogFwdSinks(), gc.HasLen, 0)
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
@@ -43,11 +43,5 @@ const StrictMigration = "strict-migration"
 // This value is only checked using the controller config "features" attrubite.
 const OldPresence = "old-presence"
 
-// DisableRaft will prevent the raft workers from running. At the
-// moment the raft cluster isn't managing leadership, so we want the
-// ability to stop the workers from running if they cause any issues
-// (or just unwanted noise).
-const DisableRaft = "disable-raft"
-
 // UpgradeSeries is a development feature flag.
 const UpgradeSeries = "upgrade-series"
@@ -110,6 +110,12 @@ func (s *cmdJujuSuite) TestApplicationUnset(c *gc.C) {
 func (s *cmdJujuSuite) TestApplicationGetIAASModel(c *gc.C) {
 	expected := `application: dummy-application
 application-config:
+  hook-timeout:
+    default: ""
+    description: Maximum time a charm hook may run before it is killed
+    source: default
+    type: string
+    value: ""
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -153,6 +159,12 @@ settings:
 func (s *cmdJujuSuite) TestApplicationGetCAASModel(c *gc.C) {
 	expected := `application: gitlab-application
 application-config:
+  hook-timeout:
+    default: ""
+    description: Maximum time a charm hook may run before it is killed
+    source: default
+    type: string
+    value: ""
   juju-application-path:
     default: /
     description: the relative http path used to access an application
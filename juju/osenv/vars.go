@@ -27,6 +27,15 @@ const (
 	// of the command creation and initialisation process.
 	JujuStartupLoggingConfigEnvKey = "JUJU_STARTUP_LOGGING_CONFIG"
 
+	// JujuStorePassphraseEnvKey if set is the passphrase used to
+	// unlock an encrypted client store.
+	JujuStorePassphraseEnvKey = "JUJU_STORE_PASSPHRASE"
+
+	// JujuTraceEnvKey if set causes API requests made by the client
+	// to be traced. If it holds a trace ID, the requests are made
+	// part of that trace, otherwise a new trace is started.
+	JujuTraceEnvKey = "JUJU_TRACE"
+
 	// Registry key containing juju related information
 	JujuRegistryKey = `HKLM:\SOFTWARE\juju-core`
 
@@ -46,6 +55,10 @@ const (
 	// XDGDataHome is a path where data for the running user
 	// should be stored according to the xdg standard.
 	XDGDataHome = "XDG_DATA_HOME"
+
+	// XDGRuntimeDir is a path where runtime files for the running
+	// user should be stored according to the xdg standard.
+	XDGRuntimeDir = "XDG_RUNTIME_DIR"
 )
 
 // FeatureFlags returns a map that can be merged with os.Environ.
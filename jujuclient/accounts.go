@@ -36,13 +36,17 @@ func ReadAccountsFile(file string) (map[string]AccountDetails, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := migrateLocalAccountUsers(accounts); err != nil {
-		return nil, err
+	if migrateLocalAccountUsers(accounts) {
+		if err := WriteAccountsFile(accounts); err != nil {
+			return nil, err
+		}
 	}
 	return accounts, nil
 }
 
-func migrateLocalAccountUsers(accounts map[string]AccountDetails) error {
+// migrateLocalAccountUsers strips the legacy "@local" suffix from
+// account user names, reporting whether any accounts were changed.
+func migrateLocalAccountUsers(accounts map[string]AccountDetails) bool {
 	changes := false
 	for user, account := range accounts {
 		if !strings.HasSuffix(account.User, "@local") {
@@ -54,10 +58,7 @@ func migrateLocalAccountUsers(accounts map[string]AccountDetails) error {
 		accounts[user] = updated
 		changes = true
 	}
-	if changes {
-		return WriteAccountsFile(accounts)
-	}
-	return nil
+	return changes
 }
 
 // WriteAccountsFile marshals to YAML details of the given accounts
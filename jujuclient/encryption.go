@@ -0,0 +1,553 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/persistent-cookiejar"
+	"github.com/juju/utils"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/pbkdf2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+const (
+	// PassphraseKeySource indicates that the client store key
+	// is derived from a passphrase entered by the user.
+	PassphraseKeySource = "passphrase"
+
+	// HelperKeySource indicates that the client store key is
+	// derived from the output of an external helper command.
+	HelperKeySource = "helper"
+
+	keyLength     = 32
+	nonceLength   = 24
+	saltLength    = 16
+	kdfIterations = 100000
+
+	// checkPlaintext is encrypted with the store key so that
+	// a key can be verified without decrypting any secrets.
+	checkPlaintext = "juju client store"
+)
+
+// ErrStoreLocked is returned when reading or writing the encrypted
+// parts of the client store without the key being available.
+var ErrStoreLocked = errors.New(`client store is locked, run "juju login" to unlock it`)
+
+// IsStoreLocked reports whether err was caused by the
+// client store being locked.
+func IsStoreLocked(err error) bool {
+	return errors.Cause(err) == ErrStoreLocked
+}
+
+// EncryptionConfig holds the details needed to derive
+// the key for an encrypted client store.
+type EncryptionConfig struct {
+	// KeySource is either PassphraseKeySource or HelperKeySource.
+	KeySource string `yaml:"key-source"`
+
+	// Helper is the command run to obtain the secret the key is
+	// derived from, when KeySource is HelperKeySource.
+	Helper string `yaml:"helper,omitempty"`
+
+	// Salt is the base64 encoded salt used when deriving the key.
+	Salt string `yaml:"salt"`
+
+	// Check is a known value, encrypted with the key.
+	Check string `yaml:"check"`
+}
+
+// JujuEncryptionPath is the location where the client store
+// encryption config is expected to be found.
+func JujuEncryptionPath() string {
+	return osenv.JujuXDGDataHomePath("encryption.yaml")
+}
+
+// JujuStoreKeyPath is the location where the key for an unlocked
+// client store is cached. The key is kept in $XDG_RUNTIME_DIR, which
+// is private to the user and cleared when they log out; if that isn't
+// set the key cannot be cached.
+func JujuStoreKeyPath() string {
+	runtimeDir := os.Getenv(osenv.XDGRuntimeDir)
+	if runtimeDir == "" {
+		return ""
+	}
+	// Include a hash of the data directory so that
+	// different stores don't share a key.
+	h := sha256.New()
+	h.Write([]byte(osenv.JujuXDGDataHomeDir()))
+	return filepath.Join(runtimeDir, fmt.Sprintf("juju-store-%x.key", h.Sum(nil)[:8]))
+}
+
+// ReadEncryptionFile loads the client store encryption config
+// from the given file. If the file is not found, it is not an
+// error and nil is returned.
+func ReadEncryptionFile(file string) (*EncryptionConfig, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var cfg EncryptionConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal encryption config")
+	}
+	return &cfg, nil
+}
+
+// WriteEncryptionFile marshals to YAML the given encryption
+// config and writes it to the encryption file.
+func WriteEncryptionFile(cfg *EncryptionConfig) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal encryption config")
+	}
+	return utils.AtomicWriteFile(JujuEncryptionPath(), data, os.FileMode(0600))
+}
+
+// encryptedFile is the format of an encrypted client store file.
+type encryptedFile struct {
+	Encrypted string `yaml:"encrypted"`
+}
+
+// storeKey is the key used to encrypt the client store.
+type storeKey [keyLength]byte
+
+func deriveKey(secret string, cfg *EncryptionConfig) (*storeKey, error) {
+	salt, err := base64.StdEncoding.DecodeString(cfg.Salt)
+	if err != nil {
+		return nil, errors.Annotate(err, "decoding salt")
+	}
+	var key storeKey
+	copy(key[:], pbkdf2.Key([]byte(secret), salt, kdfIterations, keyLength, sha256.New))
+	return &key, nil
+}
+
+// verifyKey checks that key is the key the store was encrypted with.
+func verifyKey(key *storeKey, cfg *EncryptionConfig) error {
+	check, err := key.decrypt(cfg.Check)
+	if err != nil || string(check) != checkPlaintext {
+		return errors.New("incorrect passphrase")
+	}
+	return nil
+}
+
+func (key *storeKey) encrypt(plaintext []byte) (string, error) {
+	var nonce [nonceLength]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return "", errors.Annotate(err, "generating nonce")
+	}
+	sealed := secretbox.Seal(nonce[:], plaintext, &nonce, (*[keyLength]byte)(key))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (key *storeKey) decrypt(ciphertext string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(sealed) < nonceLength {
+		return nil, errors.New("encrypted data too short")
+	}
+	var nonce [nonceLength]byte
+	copy(nonce[:], sealed)
+	plaintext, ok := secretbox.Open(nil, sealed[nonceLength:], &nonce, (*[keyLength]byte)(key))
+	if !ok {
+		return nil, errors.New("cannot decrypt data")
+	}
+	return plaintext, nil
+}
+
+// runKeyHelper runs the given helper command, returning
+// the secret it writes to stdout.
+func runKeyHelper(command string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("/bin/sh", "-c", command)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Annotatef(err, "running key helper: %s", strings.TrimSpace(stderr.String()))
+	}
+	secret := strings.TrimRight(stdout.String(), "\r\n")
+	if secret == "" {
+		return "", errors.New("key helper returned an empty secret")
+	}
+	return secret, nil
+}
+
+func readCachedKey() *storeKey {
+	path := JujuStoreKeyPath()
+	if path == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warningf("cannot read cached client store key: %v", err)
+		}
+		return nil
+	}
+	decoded, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(decoded) != keyLength {
+		logger.Warningf("ignoring invalid cached client store key in %s", path)
+		return nil
+	}
+	var key storeKey
+	copy(key[:], decoded)
+	return &key
+}
+
+func writeCachedKey(key *storeKey) error {
+	path := JujuStoreKeyPath()
+	if path == "" {
+		return errors.Errorf(
+			"cannot cache client store key: %s not set, set %s instead",
+			osenv.XDGRuntimeDir, osenv.JujuStorePassphraseEnvKey,
+		)
+	}
+	return utils.AtomicWriteFile(path, []byte(hex.EncodeToString(key[:])), os.FileMode(0600))
+}
+
+// encryptionConfig returns the store's encryption config,
+// or nil if the store is not encrypted.
+func (s *store) encryptionConfig() (*EncryptionConfig, error) {
+	cfg, err := ReadEncryptionFile(JujuEncryptionPath())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read encryption config")
+	}
+	return cfg, nil
+}
+
+// encryptionKey returns the key for the encrypted store. The key is
+// looked for in the passphrase environment variable, the cached key
+// file and finally the key helper if one is configured.
+func (s *store) encryptionKey(cfg *EncryptionConfig) (*storeKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.key != nil {
+		return s.key, nil
+	}
+
+	var key *storeKey
+	if passphrase := os.Getenv(osenv.JujuStorePassphraseEnvKey); passphrase != "" {
+		derived, err := deriveKey(passphrase, cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := verifyKey(derived, cfg); err != nil {
+			return nil, errors.Annotatef(err, "using %s", osenv.JujuStorePassphraseEnvKey)
+		}
+		key = derived
+	} else if cached := readCachedKey(); cached != nil && verifyKey(cached, cfg) == nil {
+		key = cached
+	} else if cfg.KeySource == HelperKeySource {
+		secret, err := runKeyHelper(cfg.Helper)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		derived, err := deriveKey(secret, cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := verifyKey(derived, cfg); err != nil {
+			return nil, errors.Annotate(err, "using key helper")
+		}
+		key = derived
+	}
+	if key == nil {
+		return nil, ErrStoreLocked
+	}
+	s.key = key
+	return key, nil
+}
+
+// readStoreFile reads the given client store file, decrypting
+// it if the store is encrypted. If the file is not found, it is
+// not an error and nil is returned.
+func (s *store) readStoreFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	cfg, err := s.encryptionConfig()
+	if err != nil || cfg == nil {
+		return data, errors.Trace(err)
+	}
+	var envelope encryptedFile
+	if err := yaml.Unmarshal(data, &envelope); err != nil || envelope.Encrypted == "" {
+		// The file was written before the store was encrypted;
+		// it will be encrypted the next time it is written.
+		return data, nil
+	}
+	key, err := s.encryptionKey(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	plaintext, err := key.decrypt(envelope.Encrypted)
+	if err != nil {
+		return nil, errors.Annotatef(err, "decrypting %s", path)
+	}
+	return plaintext, nil
+}
+
+// writeStoreFile writes data to the given client store
+// file, encrypting it if the store is encrypted.
+func (s *store) writeStoreFile(path string, data []byte) error {
+	cfg, err := s.encryptionConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg != nil {
+		key, err := s.encryptionKey(cfg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ciphertext, err := key.encrypt(data)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if data, err = yaml.Marshal(encryptedFile{ciphertext}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return utils.AtomicWriteFile(path, data, os.FileMode(0600))
+}
+
+// EncryptFileStore encrypts the accounts, credentials and controller
+// cookie jars in the file-based client store. If helper is empty the key is derived
+// from secret, otherwise it is derived from the output of the
+// helper command and secret is ignored.
+func EncryptFileStore(secret, helper string) error {
+	s := &store{lockName: generateStoreLockName()}
+	releaser, err := s.acquireLock()
+	if err != nil {
+		return errors.Annotate(err, "cannot acquire lock file to encrypt client store")
+	}
+	defer releaser.Release()
+
+	if cfg, err := s.encryptionConfig(); err != nil {
+		return errors.Trace(err)
+	} else if cfg != nil {
+		return errors.AlreadyExistsf("client store encryption")
+	}
+
+	// Read everything before the store is encrypted.
+	accounts, err := s.readAccounts()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	credentials, err := s.readCredentials()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cookies, err := readCookieFiles()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	cfg := &EncryptionConfig{KeySource: PassphraseKeySource}
+	if helper != "" {
+		cfg.KeySource = HelperKeySource
+		cfg.Helper = helper
+		if secret, err = runKeyHelper(helper); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if secret == "" {
+		return errors.NotValidf("empty passphrase")
+	}
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return errors.Annotate(err, "generating salt")
+	}
+	cfg.Salt = base64.StdEncoding.EncodeToString(salt)
+	key, err := deriveKey(secret, cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.Check, err = key.encrypt([]byte(checkPlaintext)); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Unencrypted files are still readable once the config is written,
+	// so write it first and then rewrite the files encrypted.
+	if err := WriteEncryptionFile(cfg); err != nil {
+		return errors.Trace(err)
+	}
+	s.key = key
+	if accounts != nil {
+		if err := s.writeAccounts(accounts); err != nil {
+			return errors.Annotate(err, "encrypting accounts")
+		}
+	}
+	if credentials != nil {
+		if err := s.writeCredentials(credentials); err != nil {
+			return errors.Annotate(err, "encrypting credentials")
+		}
+	}
+	for path, data := range cookies {
+		if err := s.writeStoreFile(path, data); err != nil {
+			return errors.Annotatef(err, "encrypting cookies in %s", path)
+		}
+	}
+	return nil
+}
+
+// readCookieFiles returns the contents of all the controller
+// cookie jar files, keyed by path.
+func readCookieFiles() (map[string][]byte, error) {
+	paths, err := filepath.Glob(JujuCookiePath("*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cookies := make(map[string][]byte)
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cookies[path] = data
+	}
+	return cookies, nil
+}
+
+// cookieEntry holds the fields we need from the entries
+// written by persistent-cookiejar when it saves a jar.
+type cookieEntry struct {
+	Name          string
+	Value         string
+	Domain        string
+	Path          string
+	Secure        bool
+	HttpOnly      bool
+	HostOnly      bool
+	Expires       time.Time
+	CanonicalHost string
+}
+
+// readCookies loads the cookies in the given encrypted cookie
+// jar file into jar. Expired cookies are dropped by the jar.
+func (s *store) readCookies(jar *cookiejar.Jar, path string) error {
+	data, err := s.readStoreFile(path)
+	if err != nil || len(data) == 0 {
+		return errors.Trace(err)
+	}
+	var entries []cookieEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return errors.Annotatef(err, "cannot parse cookies in %s", path)
+	}
+	for _, e := range entries {
+		host := e.CanonicalHost
+		if host == "" {
+			host = e.Domain
+		}
+		u := &url.URL{Scheme: "http", Host: host, Path: e.Path}
+		if e.Secure {
+			u.Scheme = "https"
+		}
+		cookie := &http.Cookie{
+			Name:     e.Name,
+			Value:    e.Value,
+			Path:     e.Path,
+			Secure:   e.Secure,
+			HttpOnly: e.HttpOnly,
+			Expires:  e.Expires,
+		}
+		if !e.HostOnly {
+			cookie.Domain = e.Domain
+		}
+		jar.SetCookies(u, []*http.Cookie{cookie})
+	}
+	return nil
+}
+
+// writeCookies saves the persistent cookies in jar to the
+// given cookie jar file, encrypting it.
+func (s *store) writeCookies(jar *cookiejar.Jar, path string) error {
+	data, err := jar.MarshalJSON()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	releaser, err := s.acquireLock()
+	if err != nil {
+		return errors.Annotate(err, "cannot acquire lock file to write cookies")
+	}
+	defer releaser.Release()
+	return errors.Trace(s.writeStoreFile(path, data))
+}
+
+// FileStoreLocked reports whether the file-based client store
+// is encrypted and its key is not available.
+func FileStoreLocked() (bool, error) {
+	s := &store{lockName: generateStoreLockName()}
+	cfg, err := s.encryptionConfig()
+	if err != nil || cfg == nil {
+		return false, errors.Trace(err)
+	}
+	if _, err := s.encryptionKey(cfg); IsStoreLocked(err) {
+		return true, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return false, nil
+}
+
+// UnlockFileStore verifies the passphrase for the encrypted
+// file-based client store, and caches the key derived from it
+// so that later commands can read the store.
+func UnlockFileStore(passphrase string) error {
+	s := &store{lockName: generateStoreLockName()}
+	cfg, err := s.encryptionConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg == nil {
+		return errors.NotFoundf("client store encryption")
+	}
+	key, err := deriveKey(passphrase, cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := verifyKey(key, cfg); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(writeCachedKey(key))
+}
+
+// LockFileStore removes the cached key for the encrypted
+// file-based client store, if there is one.
+func LockFileStore() error {
+	path := JujuStoreKeyPath()
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
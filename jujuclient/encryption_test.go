@@ -0,0 +1,197 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type EncryptionSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&EncryptionSuite{})
+
+func (s *EncryptionSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.PatchEnvironment(osenv.XDGRuntimeDir, c.MkDir())
+	writeTestAccountsFile(c)
+	writeTestCredentialsFile(c)
+}
+
+func (s *EncryptionSuite) assertEncrypted(c *gc.C) {
+	data, err := ioutil.ReadFile(jujuclient.JujuAccountsPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Matches, "encrypted: .*\n")
+	data, err = ioutil.ReadFile(jujuclient.JujuCredentialsPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Matches, "encrypted: .*\n")
+}
+
+func (s *EncryptionSuite) assertReadable(c *gc.C) {
+	store := jujuclient.NewFileClientStore()
+	details, err := store.AccountDetails("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*details, jc.DeepEquals, ctrlAdminAccountDetails)
+	credentials, err := store.AllCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credentials, jc.DeepEquals, parseCredentials(c))
+}
+
+func (s *EncryptionSuite) TestEncryptWithPassphrase(c *gc.C) {
+	err := jujuclient.EncryptFileStore("sekrit", "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEncrypted(c)
+
+	cfg, err := jujuclient.ReadEncryptionFile(jujuclient.JujuEncryptionPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.KeySource, gc.Equals, jujuclient.PassphraseKeySource)
+
+	s.PatchEnvironment(osenv.JujuStorePassphraseEnvKey, "sekrit")
+	s.assertReadable(c)
+}
+
+func (s *EncryptionSuite) TestEncryptWithHelper(c *gc.C) {
+	err := jujuclient.EncryptFileStore("", "echo sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEncrypted(c)
+	s.assertReadable(c)
+}
+
+func (s *EncryptionSuite) TestEncryptTwice(c *gc.C) {
+	err := jujuclient.EncryptFileStore("sekrit", "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuclient.EncryptFileStore("sekrit", "")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *EncryptionSuite) TestLocked(c *gc.C) {
+	err := jujuclient.EncryptFileStore("sekrit", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	locked, err := jujuclient.FileStoreLocked()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsTrue)
+
+	store := jujuclient.NewFileClientStore()
+	_, err = store.AccountDetails("ctrl")
+	c.Assert(err, jc.Satisfies, jujuclient.IsStoreLocked)
+	_, err = store.AllCredentials()
+	c.Assert(err, jc.Satisfies, jujuclient.IsStoreLocked)
+
+	// Details which aren't secret can still be read.
+	_, err = store.AllControllers()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *EncryptionSuite) TestWrongPassphrase(c *gc.C) {
+	err := jujuclient.EncryptFileStore("sekrit", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.PatchEnvironment(osenv.JujuStorePassphraseEnvKey, "guess")
+	_, err = jujuclient.NewFileClientStore().AccountDetails("ctrl")
+	c.Assert(err, gc.ErrorMatches, "using JUJU_STORE_PASSPHRASE: incorrect passphrase")
+
+	err = jujuclient.UnlockFileStore("guess")
+	c.Assert(err, gc.ErrorMatches, "incorrect passphrase")
+}
+
+func (s *EncryptionSuite) TestUnlockAndLock(c *gc.C) {
+	err := jujuclient.EncryptFileStore("sekrit", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuclient.UnlockFileStore("sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	locked, err := jujuclient.FileStoreLocked()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+	s.assertReadable(c)
+
+	// Writes are encrypted with the cached key too.
+	err = jujuclient.NewFileClientStore().RemoveAccount("kontroll")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEncrypted(c)
+
+	err = jujuclient.LockFileStore()
+	c.Assert(err, jc.ErrorIsNil)
+	locked, err = jujuclient.FileStoreLocked()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsTrue)
+}
+
+func (s *EncryptionSuite) TestUnlockWithoutRuntimeDir(c *gc.C) {
+	err := jujuclient.EncryptFileStore("sekrit", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.PatchEnvironment(osenv.XDGRuntimeDir, "")
+	err = jujuclient.UnlockFileStore("sekrit")
+	c.Assert(err, gc.ErrorMatches, "cannot cache client store key: XDG_RUNTIME_DIR not set, set JUJU_STORE_PASSPHRASE instead")
+}
+
+func (s *EncryptionSuite) TestNotEncrypted(c *gc.C) {
+	locked, err := jujuclient.FileStoreLocked()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+	err = jujuclient.UnlockFileStore("sekrit")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.assertReadable(c)
+}
+
+func (s *EncryptionSuite) TestEncryptCookies(c *gc.C) {
+	u, err := url.Parse("https://controller.example.com/api")
+	c.Assert(err, jc.ErrorIsNil)
+	jar, err := jujuclient.NewFileClientStore().CookieJar("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	jar.SetCookies(u, []*http.Cookie{{
+		Name:    "macaroon-auth",
+		Value:   "sekrit-macaroon",
+		Expires: time.Now().Add(time.Hour),
+	}})
+	err = jar.Save()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuclient.EncryptFileStore("sekrit", "")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(jujuclient.JujuCookiePath("ctrl"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Matches, "encrypted: .*\n")
+	c.Assert(string(data), gc.Not(jc.Contains), "sekrit-macaroon")
+
+	_, err = jujuclient.NewFileClientStore().CookieJar("ctrl")
+	c.Assert(err, jc.Satisfies, jujuclient.IsStoreLocked)
+
+	s.PatchEnvironment(osenv.JujuStorePassphraseEnvKey, "sekrit")
+	jar, err = jujuclient.NewFileClientStore().CookieJar("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	cookies := jar.Cookies(u)
+	c.Assert(cookies, gc.HasLen, 1)
+	c.Assert(cookies[0].Value, gc.Equals, "sekrit-macaroon")
+
+	// Saving the jar keeps it encrypted.
+	jar.SetCookies(u, []*http.Cookie{{
+		Name:    "other",
+		Value:   "sekrit-other",
+		Expires: time.Now().Add(time.Hour),
+	}})
+	err = jar.Save()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err = ioutil.ReadFile(jujuclient.JujuCookiePath("ctrl"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Matches, "encrypted: .*\n")
+
+	jar, err = jujuclient.NewFileClientStore().CookieJar("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(jar.Cookies(u), gc.HasLen, 2)
+}
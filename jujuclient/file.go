@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/mutex"
 	"github.com/juju/persistent-cookiejar"
 	"github.com/juju/utils/clock"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/model"
@@ -36,7 +38,9 @@ var (
 )
 
 // NewFileClientStore returns a new filesystem-based client store
-// that manages files in $XDG_DATA_HOME/juju.
+// that manages files in $XDG_DATA_HOME/juju. If the store has been
+// encrypted with EncryptFileStore, accounts and credentials are
+// decrypted and encrypted as they are read and written.
 func NewFileClientStore() ClientStore {
 	return &store{
 		lockName: generateStoreLockName(),
@@ -53,6 +57,11 @@ func NewFileCredentialStore() CredentialStore {
 
 type store struct {
 	lockName string
+
+	// mu guards key, the key for an encrypted store
+	// once it has been found.
+	mu  sync.Mutex
+	key *storeKey
 }
 
 // generateStoreLockName uses part of the hash of the controller path as the
@@ -299,14 +308,14 @@ func (s *store) RemoveController(name string) error {
 	}
 
 	// Remove accounts for the controller.
-	controllerAccounts, err := ReadAccountsFile(JujuAccountsPath())
+	controllerAccounts, err := s.readAccounts()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	for _, name := range names {
 		if _, ok := controllerAccounts[name]; ok {
 			delete(controllerAccounts, name)
-			if err := WriteAccountsFile(controllerAccounts); err != nil {
+			if err := s.writeAccounts(controllerAccounts); err != nil {
 				return errors.Trace(err)
 			}
 		}
@@ -645,7 +654,7 @@ func (s *store) UpdateAccount(controllerName string, details AccountDetails) err
 	}
 	defer releaser.Release()
 
-	accounts, err := ReadAccountsFile(JujuAccountsPath())
+	accounts, err := s.readAccounts()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -662,7 +671,7 @@ func (s *store) UpdateAccount(controllerName string, details AccountDetails) err
 	}
 
 	accounts[controllerName] = details
-	return errors.Trace(WriteAccountsFile(accounts))
+	return errors.Trace(s.writeAccounts(accounts))
 }
 
 // AccountByName implements AccountGetter.
@@ -679,7 +688,7 @@ func (s *store) AccountDetails(controllerName string) (*AccountDetails, error) {
 	}
 	defer releaser.Release()
 
-	accounts, err := ReadAccountsFile(JujuAccountsPath())
+	accounts, err := s.readAccounts()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -704,7 +713,7 @@ func (s *store) RemoveAccount(controllerName string) error {
 	}
 	defer releaser.Release()
 
-	accounts, err := ReadAccountsFile(JujuAccountsPath())
+	accounts, err := s.readAccounts()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -713,7 +722,7 @@ func (s *store) RemoveAccount(controllerName string) error {
 	}
 
 	delete(accounts, controllerName)
-	return errors.Trace(WriteAccountsFile(accounts))
+	return errors.Trace(s.writeAccounts(accounts))
 }
 
 // UpdateCredential implements CredentialUpdater.
@@ -726,7 +735,7 @@ func (s *store) UpdateCredential(cloudName string, details cloud.CloudCredential
 	}
 	defer releaser.Release()
 
-	all, err := ReadCredentialsFile(JujuCredentialsPath())
+	all, err := s.readCredentials()
 	if err != nil {
 		return errors.Annotate(err, "cannot get credentials")
 	}
@@ -754,7 +763,7 @@ func (s *store) UpdateCredential(cloudName string, details cloud.CloudCredential
 		delete(all, cloudName)
 	}
 
-	return WriteCredentialsFile(all)
+	return s.writeCredentials(all)
 }
 
 // CredentialForCloud implements CredentialGetter.
@@ -772,13 +781,62 @@ func (s *store) CredentialForCloud(cloudName string) (*cloud.CloudCredential, er
 
 // AllCredentials implements CredentialGetter.
 func (s *store) AllCredentials() (map[string]cloud.CloudCredential, error) {
-	cloudCredentials, err := ReadCredentialsFile(JujuCredentialsPath())
+	cloudCredentials, err := s.readCredentials()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return cloudCredentials, nil
 }
 
+// readAccounts loads all accounts, which may be encrypted.
+func (s *store) readAccounts() (map[string]AccountDetails, error) {
+	data, err := s.readStoreFile(JujuAccountsPath())
+	if err != nil || data == nil {
+		return nil, errors.Trace(err)
+	}
+	accounts, err := ParseAccounts(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if migrateLocalAccountUsers(accounts) {
+		if err := s.writeAccounts(accounts); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return accounts, nil
+}
+
+// writeAccounts writes all accounts, encrypting them if necessary.
+func (s *store) writeAccounts(controllerAccounts map[string]AccountDetails) error {
+	data, err := yaml.Marshal(accountsCollection{controllerAccounts})
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal accounts")
+	}
+	return errors.Trace(s.writeStoreFile(JujuAccountsPath(), data))
+}
+
+// readCredentials loads all credentials, which may be encrypted.
+func (s *store) readCredentials() (map[string]cloud.CloudCredential, error) {
+	data, err := s.readStoreFile(JujuCredentialsPath())
+	if err != nil || data == nil {
+		return nil, errors.Trace(err)
+	}
+	credentials, err := cloud.ParseCredentials(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return credentials, nil
+}
+
+// writeCredentials writes all credentials, encrypting them if necessary.
+func (s *store) writeCredentials(credentials map[string]cloud.CloudCredential) error {
+	data, err := yaml.Marshal(credentialsCollection{credentials})
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal yaml credentials")
+	}
+	return errors.Trace(s.writeStoreFile(JujuCredentialsPath(), data))
+}
+
 // UpdateBootstrapConfig implements BootstrapConfigUpdater.
 func (s *store) UpdateBootstrapConfig(controllerName string, cfg BootstrapConfig) error {
 	if err := ValidateControllerName(controllerName); err != nil {
@@ -826,22 +884,40 @@ func (s *store) CookieJar(controllerName string) (CookieJar, error) {
 	if err := ValidateControllerName(controllerName); err != nil {
 		return nil, errors.Trace(err)
 	}
+	cfg, err := s.encryptionConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	path := JujuCookiePath(controllerName)
 	jar, err := cookiejar.New(&cookiejar.Options{
 		Filename: path,
+		// The cookies of an encrypted store are
+		// read and written by the store itself.
+		NoPersist: cfg != nil,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &cookieJar{
+	cj := &cookieJar{
 		path: path,
 		Jar:  jar,
-	}, nil
+	}
+	if cfg != nil {
+		if err := s.readCookies(jar, path); err != nil {
+			return nil, errors.Trace(err)
+		}
+		cj.store = s
+	}
+	return cj, nil
 }
 
 type cookieJar struct {
 	path string
 	*cookiejar.Jar
+
+	// store is set when the cookie jar
+	// is encrypted by the client store.
+	store *store
 }
 
 func (jar *cookieJar) Save() error {
@@ -849,6 +925,9 @@ func (jar *cookieJar) Save() error {
 	if err := os.MkdirAll(filepath.Dir(jar.path), 0700); err != nil {
 		return errors.Annotatef(err, "cannot make cookies directory")
 	}
+	if jar.store != nil {
+		return errors.Trace(jar.store.writeCookies(jar.Jar, jar.path))
+	}
 	return jar.Jar.Save()
 }
 
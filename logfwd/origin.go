@@ -188,6 +188,26 @@ func (o Origin) Validate() error {
 	return nil
 }
 
+// EntityTag returns the tag of the entity that generated the record,
+// e.g. "unit-mysql-0", or "" if the origin type is unknown.
+func (o Origin) EntityTag() string {
+	switch o.Type {
+	case OriginTypeUser:
+		if names.IsValidUser(o.Name) {
+			return names.NewUserTag(o.Name).String()
+		}
+	case OriginTypeMachine:
+		if names.IsValidMachine(o.Name) {
+			return names.NewMachineTag(o.Name).String()
+		}
+	case OriginTypeUnit:
+		if names.IsValidUnit(o.Name) {
+			return names.NewUnitTag(o.Name).String()
+		}
+	}
+	return ""
+}
+
 // Software describes a running application.
 type Software struct {
 	// PrivateEnterpriseNumber is the IANA-registered "SMI Network
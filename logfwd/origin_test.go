@@ -78,6 +78,22 @@ func (s *OriginSuite) TestOriginForJuju(c *gc.C) {
 	})
 }
 
+func (s *OriginSuite) TestEntityTag(c *gc.C) {
+	for _, test := range []struct {
+		oType logfwd.OriginType
+		name  string
+		tag   string
+	}{
+		{logfwd.OriginTypeMachine, "99", "machine-99"},
+		{logfwd.OriginTypeUnit, "svc-a/0", "unit-svc-a-0"},
+		{logfwd.OriginTypeUser, "bob", "user-bob"},
+		{logfwd.OriginTypeUnknown, "", ""},
+	} {
+		origin := logfwd.Origin{Type: test.oType, Name: test.name}
+		c.Check(origin.EntityTag(), gc.Equals, test.tag)
+	}
+}
+
 func (s *OriginSuite) TestValidateValid(c *gc.C) {
 	origin := validOrigin
 
@@ -0,0 +1,292 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The sinkconfig package holds the configuration for each of the
+// sinks to which a model's log records may be forwarded.
+package sinkconfig
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/cert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// Type identifies the kind of a log forwarding sink.
+type Type string
+
+// These are the recognized sink types.
+const (
+	// TypeSyslog sinks send records to a remote syslog
+	// (RFC 5424) host over TLS.
+	TypeSyslog Type = "syslog"
+
+	// TypeHTTP sinks POST batches of records as JSON to a
+	// remote log store.
+	TypeHTTP Type = "http"
+
+	// TypeFile sinks append records to a file in the model's
+	// log forwarding directory on the controller.
+	TypeFile Type = "file"
+)
+
+// HTTPFormat identifies how an HTTP sink encodes a batch of records.
+type HTTPFormat string
+
+// These are the recognized HTTP sink formats.
+const (
+	// FormatElasticsearch encodes records for the Elasticsearch
+	// bulk API. The sink's URL should name the index, e.g.
+	// "https://es.example.com:9200/juju/_bulk".
+	FormatElasticsearch HTTPFormat = "elasticsearch"
+
+	// FormatLoki encodes records for the Loki push API, e.g.
+	// "https://loki.example.com/loki/api/v1/push".
+	FormatLoki HTTPFormat = "loki"
+)
+
+// SyslogSinkName is the name of the sink configured by the syslog-*
+// model config keys. Sinks in the structured config may not use it.
+const SyslogSinkName = "juju-log-forward"
+
+var validName = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// Config holds the configuration for a single log forwarding sink.
+// Only the fields for the sink's type are used.
+type Config struct {
+	// Name identifies the sink. It is also used to track the last
+	// record forwarded to the sink, so each sink's progress through
+	// the log is independent of the others.
+	Name string
+
+	// Type is the kind of sink.
+	Type Type
+
+	// Filter determines which records are forwarded to the sink.
+	Filter Filter
+
+	// Syslog holds the configuration for a syslog sink.
+	Syslog syslog.RawConfig
+
+	// HTTP holds the configuration for an HTTP sink.
+	HTTP HTTPConfig
+
+	// File holds the configuration for a file sink.
+	File FileConfig
+}
+
+// HTTPConfig holds the configuration for an HTTP sink.
+type HTTPConfig struct {
+	// URL is the http or https URL to which batches of records are
+	// POSTed. Basic auth credentials may be included in the URL.
+	URL string
+
+	// Format determines how each batch of records is encoded.
+	Format HTTPFormat
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) used to
+	// validate the server certificate. If it is empty, the system
+	// roots are used.
+	CACert string
+}
+
+// FileConfig holds the configuration for a file sink.
+type FileConfig struct {
+	// Name is the name of the file, without its ".log" extension,
+	// to which records are appended. The file is created in the
+	// "forward/<model-uuid>" directory under the controller's log
+	// directory. It is always the name of the sink.
+	Name string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg Config) Validate() error {
+	if !validName.MatchString(cfg.Name) {
+		return errors.NotValidf("sink name %q", cfg.Name)
+	}
+	if err := cfg.Filter.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	switch cfg.Type {
+	case TypeSyslog:
+		if !cfg.Syslog.Enabled {
+			return errors.NotValidf("disabled syslog config")
+		}
+		return errors.Trace(cfg.Syslog.Validate())
+	case TypeHTTP:
+		return errors.Trace(cfg.HTTP.Validate())
+	case TypeFile:
+		return errors.Trace(cfg.File.Validate())
+	}
+	return errors.NotValidf("sink type %q", cfg.Type)
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg HTTPConfig) Validate() error {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.Annotate(err, "parsing URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL %q without http or https scheme", cfg.URL)
+	}
+	switch cfg.Format {
+	case FormatElasticsearch, FormatLoki:
+	default:
+		return errors.NotValidf("format %q", cfg.Format)
+	}
+	if cfg.CACert != "" {
+		if _, err := cert.ParseCert(cfg.CACert); err != nil {
+			return errors.Annotate(err, "parsing CA certificate")
+		}
+	}
+	return nil
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg FileConfig) Validate() error {
+	// The name is used as a file name, so it must not be able
+	// to escape the model's log forwarding directory.
+	if cfg.Name == "" || cfg.Name == "." || strings.Contains(cfg.Name, "..") || strings.ContainsAny(cfg.Name, `/\`) {
+		return errors.NotValidf("file name %q", cfg.Name)
+	}
+	return nil
+}
+
+// rawConfig is the YAML representation of a single sink's config.
+type rawConfig struct {
+	Type       Type       `yaml:"type"`
+	Level      string     `yaml:"level,omitempty"`
+	Entities   []string   `yaml:"entities,omitempty"`
+	Modules    []string   `yaml:"modules,omitempty"`
+	Host       string     `yaml:"host,omitempty"`
+	URL        string     `yaml:"url,omitempty"`
+	Format     HTTPFormat `yaml:"format,omitempty"`
+	CACert     string     `yaml:"ca-cert,omitempty"`
+	ClientCert string     `yaml:"client-cert,omitempty"`
+	ClientKey  string     `yaml:"client-key,omitempty"`
+}
+
+// Parse parses the YAML value of the logforward-sinks model config
+// key, which maps each sink name to that sink's attributes:
+//
+//	es:
+//	  type: http
+//	  format: elasticsearch
+//	  url: https://es.example.com:9200/juju/_bulk
+//	  level: WARNING
+//	archive:
+//	  type: file
+//	  entities: [unit-mysql-*]
+//
+// A file sink writes to <log-dir>/forward/<model-uuid>/<name>.log
+// on the controller, so the archive sink above writes to
+// /var/log/juju/forward/<model-uuid>/archive.log.
+//
+// The sinks are validated and returned sorted by name.
+func Parse(value string) ([]Config, error) {
+	var raw map[string]rawConfig
+	if err := yaml.UnmarshalStrict([]byte(value), &raw); err != nil {
+		return nil, errors.Annotate(err, "parsing log forwarding sinks")
+	}
+	sinks := make([]Config, 0, len(raw))
+	for name, attrs := range raw {
+		if name == SyslogSinkName {
+			return nil, errors.Errorf("log forwarding sink name %q is reserved", name)
+		}
+		cfg, err := attrs.config(name)
+		if err != nil {
+			return nil, errors.Annotatef(err, "log forwarding sink %q", name)
+		}
+		if err := cfg.Validate(); err != nil {
+			return nil, errors.Annotatef(err, "log forwarding sink %q", name)
+		}
+		sinks = append(sinks, cfg)
+	}
+	sort.Slice(sinks, func(i, j int) bool {
+		return sinks[i].Name < sinks[j].Name
+	})
+	return sinks, nil
+}
+
+func (raw rawConfig) config(name string) (Config, error) {
+	cfg := Config{
+		Name: name,
+		Type: raw.Type,
+		Filter: Filter{
+			Entities: raw.Entities,
+			Modules:  raw.Modules,
+		},
+	}
+	if raw.Level != "" {
+		level, ok := loggo.ParseLevel(raw.Level)
+		if !ok {
+			return Config{}, errors.NotValidf("level %q", raw.Level)
+		}
+		cfg.Filter.Level = level
+	}
+
+	// Reject attributes that don't apply to the sink's type, since
+	// they are most likely a mistake.
+	unexpected := func(attr string, value string) error {
+		if value != "" {
+			return errors.Errorf("%q not valid for %s sinks", attr, raw.Type)
+		}
+		return nil
+	}
+	var err error
+	switch raw.Type {
+	case TypeSyslog:
+		cfg.Syslog = syslog.RawConfig{
+			Enabled:    true,
+			Host:       raw.Host,
+			CACert:     raw.CACert,
+			ClientCert: raw.ClientCert,
+			ClientKey:  raw.ClientKey,
+		}
+		err = firstError(
+			unexpected("url", raw.URL),
+			unexpected("format", string(raw.Format)),
+		)
+	case TypeHTTP:
+		cfg.HTTP = HTTPConfig{
+			URL:    raw.URL,
+			Format: raw.Format,
+			CACert: raw.CACert,
+		}
+		err = firstError(
+			unexpected("host", raw.Host),
+			unexpected("client-cert", raw.ClientCert),
+			unexpected("client-key", raw.ClientKey),
+		)
+	case TypeFile:
+		cfg.File = FileConfig{
+			Name: name,
+		}
+		err = firstError(
+			unexpected("host", raw.Host),
+			unexpected("url", raw.URL),
+			unexpected("format", string(raw.Format)),
+			unexpected("ca-cert", raw.CACert),
+			unexpected("client-cert", raw.ClientCert),
+			unexpected("client-key", raw.ClientKey),
+		)
+	}
+	return cfg, errors.Trace(err)
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
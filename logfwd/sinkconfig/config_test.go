@@ -0,0 +1,149 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinkconfig_test
+
+import (
+	"strings"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestParse(c *gc.C) {
+	sinks, err := sinkconfig.Parse(`
+loki:
+  type: http
+  format: loki
+  url: https://loki.example.com/loki/api/v1/push
+  level: warning
+  entities: [unit-mysql-*, machine-0]
+  modules: [juju.worker.uniter]
+archive:
+  type: file
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sinks, jc.DeepEquals, []sinkconfig.Config{{
+		Name: "archive",
+		Type: sinkconfig.TypeFile,
+		File: sinkconfig.FileConfig{
+			Name: "archive",
+		},
+	}, {
+		Name: "loki",
+		Type: sinkconfig.TypeHTTP,
+		Filter: sinkconfig.Filter{
+			Level:    loggo.WARNING,
+			Entities: []string{"unit-mysql-*", "machine-0"},
+			Modules:  []string{"juju.worker.uniter"},
+		},
+		HTTP: sinkconfig.HTTPConfig{
+			URL:    "https://loki.example.com/loki/api/v1/push",
+			Format: sinkconfig.FormatLoki,
+		},
+	}})
+}
+
+func (s *ConfigSuite) TestParseSyslog(c *gc.C) {
+	sinks, err := sinkconfig.Parse(`
+remote:
+  type: syslog
+  host: syslog.example.com:6514
+  ca-cert: |
+` + indent(coretesting.CACert) + `
+  client-cert: |
+` + indent(coretesting.ServerCert) + `
+  client-key: |
+` + indent(coretesting.ServerKey))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sinks, gc.HasLen, 1)
+	c.Check(sinks[0].Syslog, jc.DeepEquals, syslog.RawConfig{
+		Enabled:    true,
+		Host:       "syslog.example.com:6514",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	})
+}
+
+func (s *ConfigSuite) TestParseEmpty(c *gc.C) {
+	sinks, err := sinkconfig.Parse("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sinks, gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "foo: [",
+		err:   "parsing log forwarding sinks: .*",
+	}, {
+		value: "a:\n  type: file\n  colour: blue",
+		err:   "parsing log forwarding sinks: (.|\n)*field colour not found(.|\n)*",
+	}, {
+		value: "a:\n  type: carrier-pigeon",
+		err:   `log forwarding sink "a": sink type "carrier-pigeon" not valid`,
+	}, {
+		value: "Bad_Name:\n  type: file",
+		err:   `log forwarding sink "Bad_Name": sink name "Bad_Name" not valid`,
+	}, {
+		value: "juju-log-forward:\n  type: file",
+		err:   `log forwarding sink name "juju-log-forward" is reserved`,
+	}, {
+		value: "a:\n  type: file\n  path: /tmp/a",
+		err:   "parsing log forwarding sinks: (.|\n)*field path not found(.|\n)*",
+	}, {
+		value: "a:\n  type: file\n  url: http://example.com",
+		err:   `log forwarding sink "a": "url" not valid for file sinks`,
+	}, {
+		value: "a:\n  type: file\n  level: LOUD",
+		err:   `log forwarding sink "a": level "LOUD" not valid`,
+	}, {
+		value: "a:\n  type: file\n  entities: ['unit-[']",
+		err:   `log forwarding sink "a": entity pattern "unit-\[" not valid`,
+	}, {
+		value: "a:\n  type: http\n  url: ftp://example.com\n  format: loki",
+		err:   `log forwarding sink "a": URL "ftp://example.com" without http or https scheme not valid`,
+	}, {
+		value: "a:\n  type: http\n  url: http://example.com\n  format: splunk",
+		err:   `log forwarding sink "a": format "splunk" not valid`,
+	}, {
+		value: "a:\n  type: http\n  url: http://example.com\n  format: loki\n  ca-cert: foo",
+		err:   `log forwarding sink "a": parsing CA certificate: .*`,
+	}, {
+		value: "a:\n  type: syslog\n  host: example.com",
+		err:   `log forwarding sink "a": validating TLS config: .*`,
+	}} {
+		c.Logf("test %d: %q", i, test.value)
+		_, err := sinkconfig.Parse(test.value)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestFileConfigValidate(c *gc.C) {
+	for i, name := range []string{"", ".", "..", "a..b", "a/b", "/a", `a\b`} {
+		c.Logf("test %d: %q", i, name)
+		err := sinkconfig.FileConfig{Name: name}.Validate()
+		c.Check(err, gc.ErrorMatches, `file name ".*" not valid`)
+	}
+	err := sinkconfig.FileConfig{Name: "archive"}.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func indent(s string) string {
+	return "    " + strings.Replace(strings.TrimSuffix(s, "\n"), "\n", "\n    ", -1)
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinkconfig
+
+import (
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// Filter determines which log records are forwarded to a sink. The
+// zero Filter matches every record.
+type Filter struct {
+	// Level is the minimum level of the records that match.
+	Level loggo.Level
+
+	// Entities holds the tags of the entities whose records match,
+	// e.g. "unit-mysql-0". Each may contain "*" wildcards, e.g.
+	// "unit-mysql-*". If empty, records from all entities match.
+	Entities []string
+
+	// Modules holds the modules whose records match. A record also
+	// matches if it is from a submodule of one of these. If empty,
+	// records from all modules match.
+	Modules []string
+}
+
+// Validate ensures that the filter is currently valid.
+func (f Filter) Validate() error {
+	for _, pattern := range f.Entities {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.NotValidf("entity pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// Match reports whether the record should be forwarded.
+func (f Filter) Match(rec logfwd.Record) bool {
+	if rec.Level < f.Level {
+		return false
+	}
+	if len(f.Entities) > 0 && !f.matchEntity(rec.Origin.EntityTag()) {
+		return false
+	}
+	if len(f.Modules) > 0 && !f.matchModule(rec.Location.Module) {
+		return false
+	}
+	return true
+}
+
+func (f Filter) matchEntity(tag string) bool {
+	for _, pattern := range f.Entities {
+		// Entity tags never contain "/", so a shell pattern's "*"
+		// matches any part of the tag.
+		if ok, _ := path.Match(pattern, tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Filter) matchModule(module string) bool {
+	for _, m := range f.Modules {
+		if module == m || strings.HasPrefix(module, m+".") {
+			return true
+		}
+	}
+	return false
+}
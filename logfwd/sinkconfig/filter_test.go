@@ -0,0 +1,70 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinkconfig_test
+
+import (
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
+)
+
+type FilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FilterSuite{})
+
+func (s *FilterSuite) TestMatch(c *gc.C) {
+	rec := logfwd.Record{
+		Origin: logfwd.Origin{
+			Type: logfwd.OriginTypeUnit,
+			Name: "mysql/0",
+		},
+		Level: loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module: "juju.worker.uniter.operation",
+		},
+	}
+	for i, test := range []struct {
+		filter sinkconfig.Filter
+		match  bool
+	}{{
+		filter: sinkconfig.Filter{},
+		match:  true,
+	}, {
+		filter: sinkconfig.Filter{Level: loggo.INFO},
+		match:  true,
+	}, {
+		filter: sinkconfig.Filter{Level: loggo.WARNING},
+		match:  false,
+	}, {
+		filter: sinkconfig.Filter{Entities: []string{"machine-0", "unit-mysql-*"}},
+		match:  true,
+	}, {
+		filter: sinkconfig.Filter{Entities: []string{"unit-mysql-1"}},
+		match:  false,
+	}, {
+		filter: sinkconfig.Filter{Modules: []string{"juju.worker.uniter"}},
+		match:  true,
+	}, {
+		filter: sinkconfig.Filter{Modules: []string{"juju.worker.uniter.operation"}},
+		match:  true,
+	}, {
+		filter: sinkconfig.Filter{Modules: []string{"juju.worker.uni"}},
+		match:  false,
+	}, {
+		filter: sinkconfig.Filter{
+			Level:    loggo.DEBUG,
+			Entities: []string{"unit-*"},
+			Modules:  []string{"juju.apiserver"},
+		},
+		match: false,
+	}} {
+		c.Logf("test %d: %+v", i, test.filter)
+		c.Check(test.filter.Match(rec), gc.Equals, test.match)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/juju/description"
 	"github.com/juju/errors"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6"
 
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
@@ -24,19 +26,28 @@ import (
 
 var logger = loggo.GetLogger("juju.migration")
 
+// When we import a new model, we need to give the leaders some time to
+// settle. We don't want to have leader switches just because we migrated an
+// model, so this time needs to be long enough to make sure we cover
+// the time taken to migration a reasonable sized model. We don't yet
+// know how long this is going to be, but we need something.
+var initialLeaderClaimTime = time.Minute
+
 // StateExporter describes interface on state required to export a
 // model.
 type StateExporter interface {
-	// Export generates an abstract representation of a model.
-	Export() (description.Model, error)
+	// Export generates an abstract representation of a model, with
+	// the given application leaders.
+	Export(leaders map[string]string) (description.Model, error)
 }
 
 // ExportModel creates a description.Model representation of the
 // active model for StateExporter (typically a *state.State), and
 // returns the serialized version. It provides the symmetric
-// functionality to ImportModel.
-func ExportModel(st StateExporter) ([]byte, error) {
-	model, err := st.Export()
+// functionality to ImportModel. The leaders map holds the name of
+// the leader unit for each application.
+func ExportModel(st StateExporter, leaders map[string]string) ([]byte, error) {
+	model, err := st.Export(leaders)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -47,10 +58,15 @@ func ExportModel(st StateExporter) ([]byte, error) {
 	return bytes, nil
 }
 
+// ClaimerFunc returns a leadership claimer for the model with the
+// given UUID.
+type ClaimerFunc func(modelUUID string) (leadership.Claimer, error)
+
 // ImportModel deserializes a model description from the bytes, transforms
 // the model config based on information from the controller model, and then
-// imports that as a new database model.
-func ImportModel(st *state.State, bytes []byte) (*state.Model, *state.State, error) {
+// imports that as a new database model. The application leaders in the
+// description are then claimed using the claimer for the new model.
+func ImportModel(st *state.State, getClaimer ClaimerFunc, bytes []byte) (*state.Model, *state.State, error) {
 	model, err := description.Deserialize(bytes)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -60,6 +76,25 @@ func ImportModel(st *state.State, bytes []byte) (*state.Model, *state.State, err
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
+
+	claimer, err := getClaimer(dbModel.UUID())
+	if err != nil {
+		dbState.Close()
+		return nil, nil, errors.Annotate(err, "getting leadership claimer")
+	}
+	for _, application := range model.Applications() {
+		if application.Leader() == "" {
+			continue
+		}
+		if err := claimer.ClaimLeadership(
+			application.Name(),
+			application.Leader(),
+			initialLeaderClaimTime,
+		); err != nil {
+			dbState.Close()
+			return nil, nil, errors.Annotatef(err, "claiming leadership for %q", application.Name())
+		}
+	}
 	return dbModel, dbState, nil
 }
 
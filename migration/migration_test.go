@@ -9,9 +9,11 @@ import (
 	"io"
 	"io/ioutil"
 	"net/url"
+	"time"
 
 	"github.com/juju/description"
 	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/version"
@@ -19,6 +21,7 @@ import (
 	"gopkg.in/juju/charm.v6"
 
 	"github.com/juju/juju/component/all"
+	"github.com/juju/juju/core/leadership"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/provider/dummy"
@@ -56,14 +59,14 @@ func (s *ImportSuite) SetUpTest(c *gc.C) {
 
 func (s *ImportSuite) TestBadBytes(c *gc.C) {
 	bytes := []byte("not a model")
-	model, st, err := migration.ImportModel(s.State, bytes)
+	model, st, err := migration.ImportModel(s.State, s.getClaimer, bytes)
 	c.Check(st, gc.IsNil)
 	c.Check(model, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
 }
 
 func (s *ImportSuite) TestImportModel(c *gc.C) {
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Update the config values in the exported model for different values for
@@ -78,7 +81,7 @@ func (s *ImportSuite) TestImportModel(c *gc.C) {
 	bytes, err := description.Serialize(model)
 	c.Check(err, jc.ErrorIsNil)
 
-	dbModel, dbState, err := migration.ImportModel(s.State, bytes)
+	dbModel, dbState, err := migration.ImportModel(s.State, s.getClaimer, bytes)
 	c.Assert(err, jc.ErrorIsNil)
 	defer dbState.Close()
 
@@ -88,6 +91,62 @@ func (s *ImportSuite) TestImportModel(c *gc.C) {
 	c.Assert(dbConfig.Name(), gc.Equals, "new-model")
 }
 
+func (s *ImportSuite) TestImportModelClaimsLeaders(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	model, err := s.State.Export(map[string]string{
+		unit.ApplicationName(): unit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := utils.MustNewUUID().String()
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": uuid,
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	claimer := &fakeClaimer{}
+	getClaimer := func(modelUUID string) (leadership.Claimer, error) {
+		claimer.AddCall("GetClaimer", modelUUID)
+		return claimer, nil
+	}
+	_, dbState, err := migration.ImportModel(s.State, getClaimer, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	defer dbState.Close()
+
+	claimer.CheckCalls(c, []jujutesting.StubCall{
+		{"GetClaimer", []interface{}{uuid}},
+		{"ClaimLeadership", []interface{}{unit.ApplicationName(), unit.Name(), time.Minute}},
+	})
+}
+
+func (s *ImportSuite) TestImportModelClaimError(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	model, err := s.State.Export(map[string]string{
+		unit.ApplicationName(): unit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": utils.MustNewUUID().String(),
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	claimer := &fakeClaimer{}
+	claimer.SetErrors(errors.New("boom"))
+	getClaimer := func(string) (leadership.Claimer, error) {
+		return claimer, nil
+	}
+	_, _, err = migration.ImportModel(s.State, getClaimer, bytes)
+	c.Assert(err, gc.ErrorMatches, `claiming leadership for "`+unit.ApplicationName()+`": boom`)
+}
+
+func (s *ImportSuite) getClaimer(string) (leadership.Claimer, error) {
+	return &fakeClaimer{}, nil
+}
+
 func (s *ImportSuite) TestUploadBinariesConfigValidate(c *gc.C) {
 	type T migration.UploadBinariesConfig // alias for brevity
 
@@ -292,10 +351,24 @@ type ExportSuite struct {
 var _ = gc.Suite(&ExportSuite{})
 
 func (s *ExportSuite) TestExportModel(c *gc.C) {
-	bytes, err := migration.ExportModel(s.State)
+	bytes, err := migration.ExportModel(s.State, map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 	// The bytes must be a valid model.
 	modelDesc, err := description.Deserialize(bytes)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(modelDesc.Validate(), jc.ErrorIsNil)
 }
+
+type fakeClaimer struct {
+	jujutesting.Stub
+}
+
+func (c *fakeClaimer) ClaimLeadership(application, unit string, duration time.Duration) error {
+	c.AddCall("ClaimLeadership", application, unit, duration)
+	return c.NextErr()
+}
+
+func (c *fakeClaimer) BlockUntilLeadershipReleased(application string, cancel <-chan struct{}) error {
+	c.AddCall("BlockUntilLeadershipReleased", application, cancel)
+	return c.NextErr()
+}
@@ -6,6 +6,7 @@ package migration
 import (
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
@@ -168,3 +169,40 @@ func (s *precheckRelationShim) Unit(pu PrecheckUnit) (PrecheckRelationUnit, erro
 	}
 	return ru, nil
 }
+
+// ImportValidationShim wraps the target controller's *state.State to
+// implement ImportValidationBackend.
+func ImportValidationShim(controllerState *state.State) ImportValidationBackend {
+	return &importValidationShim{
+		precheckShim: &precheckShim{
+			State:           controllerState,
+			controllerState: controllerState,
+		},
+	}
+}
+
+// importValidationShim is untested, but is simple enough to be
+// verified by inspection.
+type importValidationShim struct {
+	*precheckShim
+}
+
+// ControllerCloudName implements ImportValidationBackend.
+func (s *importValidationShim) ControllerCloudName() (string, error) {
+	info, err := s.State.ControllerInfo()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return info.CloudName, nil
+}
+
+// UserExists implements ImportValidationBackend.
+func (s *importValidationShim) UserExists(tag names.UserTag) (bool, error) {
+	_, err := s.State.User(tag)
+	if _, deleted := errors.Cause(err).(state.DeletedUserError); deleted || errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// ImportValidationBackend defines the interface to query the target
+// controller's state when checking whether a model could be imported.
+type ImportValidationBackend interface {
+	AgentVersion() (version.Number, error)
+	ControllerCloudName() (string, error)
+	ModelExists(uuid string) (bool, error)
+	Cloud(name string) (cloud.Cloud, error)
+	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
+	UserExists(tag names.UserTag) (bool, error)
+}
+
+// ValidateImport checks whether the model could be imported into the
+// target controller, without changing anything. Rather than stopping
+// at the first problem found it returns a description of every problem
+// that would block the import; an error is only returned if the
+// checks themselves could not be run.
+func ValidateImport(backend ImportValidationBackend, model description.Model) ([]string, error) {
+	v := importValidator{backend: backend, model: model}
+	checks := []func() error{
+		v.checkModel,
+		v.checkCloud,
+		v.checkCredential,
+		v.checkUsers,
+		v.checkCharms,
+		v.checkTools,
+		v.checkOffers,
+	}
+	for _, check := range checks {
+		if err := check(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return v.blockers, nil
+}
+
+type importValidator struct {
+	backend  ImportValidationBackend
+	model    description.Model
+	blockers []string
+}
+
+func (v *importValidator) block(format string, args ...interface{}) {
+	v.blockers = append(v.blockers, fmt.Sprintf(format, args...))
+}
+
+func (v *importValidator) modelType() state.ModelType {
+	// Models exported with a blank type are IAAS, as on import.
+	if v.model.Type() == "" {
+		return state.ModelTypeIAAS
+	}
+	modelType, _ := state.ParseModelType(v.model.Type())
+	return modelType
+}
+
+func (v *importValidator) checkModel() error {
+	uuid := v.model.Tag().Id()
+	if exists, err := v.backend.ModelExists(uuid); err != nil {
+		return errors.Annotate(err, "checking for existing model")
+	} else if exists {
+		v.block("model with same UUID already exists (%s)", uuid)
+	}
+	if v.model.Type() != "" {
+		if _, err := state.ParseModelType(v.model.Type()); err != nil {
+			v.block("model type %q not supported", v.model.Type())
+		}
+	}
+	if _, err := config.New(config.NoDefaults, v.model.Config()); err != nil {
+		v.block("model config not valid: %v", err)
+	}
+	return nil
+}
+
+func (v *importValidator) checkCloud() error {
+	cloudName := v.model.Cloud()
+	modelCloud, err := v.backend.Cloud(cloudName)
+	if errors.IsNotFound(err) {
+		v.block("cloud %q not found", cloudName)
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving cloud %q", cloudName)
+	}
+	if v.modelType() != state.ModelTypeIAAS {
+		return nil
+	}
+	controllerCloud, err := v.backend.ControllerCloudName()
+	if err != nil {
+		return errors.Annotate(err, "retrieving controller cloud")
+	}
+	if controllerCloud != cloudName {
+		v.block("controller cloud %s does not match model cloud %s", controllerCloud, cloudName)
+	}
+	if region := v.model.CloudRegion(); region != "" {
+		if _, err := cloud.RegionByName(modelCloud.Regions, region); err != nil {
+			v.block("cloud %q has no region %q", cloudName, region)
+		}
+	} else if len(modelCloud.Regions) > 0 {
+		v.block("model has no region but cloud %q requires one", cloudName)
+	}
+	return nil
+}
+
+func (v *importValidator) checkCredential() error {
+	creds := v.model.CloudCredential()
+	if creds == nil {
+		return nil
+	}
+	credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+	if !names.IsValidCloudCredential(credID) {
+		v.block("model credentials id not valid: %q", credID)
+		return nil
+	}
+	existing, err := v.backend.CloudCredential(names.NewCloudCredentialTag(credID))
+	if errors.IsNotFound(err) {
+		// The credential will be created on import.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving credential %q", credID)
+	}
+	if existing.AuthType != creds.AuthType() {
+		v.block("credential %q auth type mismatch: %q != %q", credID, existing.AuthType, creds.AuthType())
+	}
+	if !reflect.DeepEqual(existing.Attributes, creds.Attributes()) {
+		v.block("credential %q attributes differ from those on the target controller", credID)
+	}
+	if existing.Revoked {
+		v.block("credential %q is revoked", credID)
+	}
+	return nil
+}
+
+func (v *importValidator) checkUsers() error {
+	owner := v.model.Owner()
+	if err := v.checkUser(owner, "model owner"); err != nil {
+		return errors.Trace(err)
+	}
+	for _, user := range v.model.Users() {
+		if user.Name() == owner {
+			continue
+		}
+		if err := v.checkUser(user.Name(), "user"); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (v *importValidator) checkUser(tag names.UserTag, role string) error {
+	if !tag.IsLocal() {
+		// External users aren't stored in the controller.
+		return nil
+	}
+	exists, err := v.backend.UserExists(tag)
+	if err != nil {
+		return errors.Annotatef(err, "retrieving user %q", tag.Id())
+	}
+	if !exists {
+		v.block("%s %q not found", role, tag.Id())
+	}
+	return nil
+}
+
+func (v *importValidator) checkCharms() error {
+	for _, app := range v.model.Applications() {
+		if _, err := charm.ParseURL(app.CharmURL()); err != nil {
+			v.block("application %s charm URL %q not valid: %v", app.Name(), app.CharmURL(), err)
+		}
+	}
+	return nil
+}
+
+func (v *importValidator) checkTools() error {
+	controllerVersion, err := v.backend.AgentVersion()
+	if err != nil {
+		return errors.Annotate(err, "retrieving controller version")
+	}
+	check := func(entity string, tools description.AgentTools) {
+		if tools == nil {
+			// CAAS units don't have agent binaries of their own.
+			return
+		}
+		if agentVersion := tools.Version().Number; agentVersion.Compare(controllerVersion) > 0 {
+			v.block("%s agent binaries are newer than target controller (%s > %s)",
+				entity, agentVersion, controllerVersion)
+		}
+	}
+	var checkMachine func(description.Machine)
+	checkMachine = func(machine description.Machine) {
+		check("machine "+machine.Id(), machine.Tools())
+		for _, container := range machine.Containers() {
+			checkMachine(container)
+		}
+	}
+	for _, machine := range v.model.Machines() {
+		checkMachine(machine)
+	}
+	for _, app := range v.model.Applications() {
+		for _, unit := range app.Units() {
+			check("unit "+unit.Name(), unit.Tools())
+		}
+	}
+	return nil
+}
+
+func (v *importValidator) checkOffers() error {
+	// Cross-model relations are currently limited to models on the
+	// same controller, so neither side of one can be migrated.
+	for _, app := range v.model.RemoteApplications() {
+		if app.IsConsumerProxy() {
+			v.block("offer is consumed by remote application %s", app.Name())
+		} else {
+			v.block("remote application %s consumes offer %s", app.Name(), app.URL())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type ValidateImportSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ValidateImportSuite{})
+
+func (*ValidateImportSuite) TestSuccess(c *gc.C) {
+	blockers, err := migration.ValidateImport(newFakeImportBackend(), newImportModel())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, gc.HasLen, 0)
+}
+
+func (*ValidateImportSuite) TestReportsEveryBlocker(c *gc.C) {
+	backend := newFakeImportBackend()
+	backend.modelExists = true
+	backend.users = nil
+	backend.credential.Revoked = true
+	backend.agentVersion = version.MustParse("2.5.0")
+
+	model := newImportModel()
+	model.AddRemoteApplication(description.RemoteApplicationArgs{
+		Tag:         names.NewApplicationTag("mysql"),
+		URL:         "other/model.mysql",
+		SourceModel: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+	})
+
+	blockers, err := migration.ValidateImport(backend, model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, jc.DeepEquals, []string{
+		"model with same UUID already exists (" + testing.ModelTag.Id() + ")",
+		`credential "dummy/owner/cred" is revoked`,
+		`model owner "owner" not found`,
+		`user "bob" not found`,
+		"machine 0 agent binaries are newer than target controller (2.6.1 > 2.5.0)",
+		"unit wordpress/0 agent binaries are newer than target controller (2.6.1 > 2.5.0)",
+		"remote application mysql consumes offer other/model.mysql",
+	})
+}
+
+func (*ValidateImportSuite) TestCloudNotFound(c *gc.C) {
+	backend := newFakeImportBackend()
+	backend.cloud.Name = "elsewhere"
+
+	blockers, err := migration.ValidateImport(backend, newImportModel())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, jc.DeepEquals, []string{`cloud "dummy" not found`})
+}
+
+func (*ValidateImportSuite) TestCloudMismatch(c *gc.C) {
+	backend := newFakeImportBackend()
+	backend.controllerCloud = "elsewhere"
+	backend.cloud.Regions = []cloud.Region{{Name: "north"}}
+
+	blockers, err := migration.ValidateImport(backend, newImportModel())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, jc.DeepEquals, []string{
+		"controller cloud elsewhere does not match model cloud dummy",
+		`cloud "dummy" has no region "dummy-region"`,
+	})
+}
+
+func (*ValidateImportSuite) TestCredentialMismatch(c *gc.C) {
+	backend := newFakeImportBackend()
+	backend.credential = state.Credential{}
+	backend.credential.AuthType = "oauth2"
+	backend.credential.Attributes = map[string]string{"token": "sekrit"}
+
+	blockers, err := migration.ValidateImport(backend, newImportModel())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, jc.DeepEquals, []string{
+		`credential "dummy/owner/cred" auth type mismatch: "oauth2" != "userpass"`,
+		`credential "dummy/owner/cred" attributes differ from those on the target controller`,
+	})
+}
+
+func (*ValidateImportSuite) TestCredentialCreatedOnImport(c *gc.C) {
+	backend := newFakeImportBackend()
+	backend.credentialErr = errors.NotFoundf("credential")
+
+	blockers, err := migration.ValidateImport(backend, newImportModel())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, gc.HasLen, 0)
+}
+
+func (*ValidateImportSuite) TestInvalidCharmURL(c *gc.C) {
+	model := newImportModel()
+	model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mediawiki"),
+		CharmURL: "cs:::mediawiki",
+	})
+
+	blockers, err := migration.ValidateImport(newFakeImportBackend(), model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, gc.HasLen, 1)
+	c.Assert(blockers[0], gc.Matches, `application mediawiki charm URL "cs:::mediawiki" not valid: .*`)
+}
+
+func (*ValidateImportSuite) TestBackendError(c *gc.C) {
+	backend := newFakeImportBackend()
+	backend.credentialErr = errors.New("boom")
+
+	_, err := migration.ValidateImport(backend, newImportModel())
+	c.Assert(err, gc.ErrorMatches, `retrieving credential "dummy/owner/cred": boom`)
+}
+
+func newImportModel() description.Model {
+	owner := names.NewUserTag("owner")
+	model := description.NewModel(description.ModelArgs{
+		Type:        "iaas",
+		Owner:       owner,
+		Config:      testing.FakeConfig().Merge(testing.Attrs{"uuid": testing.ModelTag.Id()}),
+		Cloud:       "dummy",
+		CloudRegion: "dummy-region",
+	})
+	model.SetCloudCredential(description.CloudCredentialArgs{
+		Owner:      owner,
+		Cloud:      names.NewCloudTag("dummy"),
+		Name:       "cred",
+		AuthType:   "userpass",
+		Attributes: map[string]string{"username": "bob"},
+	})
+	model.AddUser(description.UserArgs{
+		Name:      owner,
+		CreatedBy: owner,
+		Access:    "admin",
+	})
+	model.AddUser(description.UserArgs{
+		Name:      names.NewUserTag("bob"),
+		CreatedBy: owner,
+		Access:    "read",
+	})
+	model.AddUser(description.UserArgs{
+		Name:      names.NewUserTag("alice@external"),
+		CreatedBy: owner,
+		Access:    "read",
+	})
+
+	tools := description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.6.1-bionic-amd64"),
+	}
+	machine := model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+	machine.SetTools(tools)
+	app := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("wordpress"),
+		CharmURL: "cs:wordpress-1",
+	})
+	unit := app.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/0"),
+		Machine: machine.Tag(),
+	})
+	unit.SetTools(tools)
+	return model
+}
+
+func newFakeImportBackend() *fakeImportBackend {
+	credential := state.Credential{}
+	credential.AuthType = "userpass"
+	credential.Attributes = map[string]string{"username": "bob"}
+	return &fakeImportBackend{
+		agentVersion:    version.MustParse("2.6.1"),
+		controllerCloud: "dummy",
+		cloud: cloud.Cloud{
+			Name:    "dummy",
+			Regions: []cloud.Region{{Name: "dummy-region"}},
+		},
+		credential: credential,
+		users:      []string{"owner", "bob"},
+	}
+}
+
+type fakeImportBackend struct {
+	agentVersion    version.Number
+	controllerCloud string
+	modelExists     bool
+	cloud           cloud.Cloud
+	credential      state.Credential
+	credentialErr   error
+	users           []string
+}
+
+func (b *fakeImportBackend) AgentVersion() (version.Number, error) {
+	return b.agentVersion, nil
+}
+
+func (b *fakeImportBackend) ControllerCloudName() (string, error) {
+	return b.controllerCloud, nil
+}
+
+func (b *fakeImportBackend) ModelExists(uuid string) (bool, error) {
+	return b.modelExists, nil
+}
+
+func (b *fakeImportBackend) Cloud(name string) (cloud.Cloud, error) {
+	if name != b.cloud.Name {
+		return cloud.Cloud{}, errors.NotFoundf("cloud %q", name)
+	}
+	return b.cloud, nil
+}
+
+func (b *fakeImportBackend) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
+	return b.credential, b.credentialErr
+}
+
+func (b *fakeImportBackend) UserExists(tag names.UserTag) (bool, error) {
+	for _, name := range b.users {
+		if name == tag.Name() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
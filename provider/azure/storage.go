@@ -161,6 +161,8 @@ type azureVolumeSource struct {
 	maybeStorageClient  internalazurestorage.Client
 }
 
+var _ storage.VolumeResizer = (*azureVolumeSource)(nil)
+
 // CreateVolumes is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) (_ []storage.CreateVolumesResult, err error) {
 	results := make([]storage.CreateVolumesResult, len(params))
@@ -497,6 +499,72 @@ func foreachVolume(volumeIds []string, f func(string) error) []error {
 	return results
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+// Only managed disks may be resized.
+func (v *azureVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	if v.maybeStorageClient != nil {
+		for i := range results {
+			results[i].Error = errors.NotSupportedf("resizing unmanaged disks")
+		}
+		return results, nil
+	}
+	diskClient := disk.DisksClient{v.env.disk}
+	var wg sync.WaitGroup
+	for i, p := range params {
+		wg.Add(1)
+		go func(i int, p storage.VolumeResizeParams) {
+			defer wg.Done()
+			size, err := v.resizeManagedDiskVolume(ctx, diskClient, p)
+			if err != nil {
+				results[i].Error = err
+				return
+			}
+			results[i].Size = size
+		}(i, p)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+func (v *azureVolumeSource) resizeManagedDiskVolume(
+	ctx context.ProviderCallContext,
+	diskClient disk.DisksClient,
+	p storage.VolumeResizeParams,
+) (uint64, error) {
+	sizeInGib := mibToGib(p.Size)
+	if sizeInGib > volumeSizeMaxGiB {
+		return 0, errors.Errorf(
+			"%d GiB exceeds the maximum of %d GiB",
+			sizeInGib,
+			volumeSizeMaxGiB,
+		)
+	}
+	diskModel, err := diskClient.Get(v.env.resourceGroup, p.VolumeId)
+	if err != nil {
+		if isNotFoundResponse(diskModel.Response) {
+			err = errors.NotFoundf("disk %s", p.VolumeId)
+		}
+		return 0, errorutils.HandleCredentialError(errors.Annotatef(err, "getting disk %q", p.VolumeId), ctx)
+	}
+	if diskModel.Properties == nil {
+		diskModel.Properties = &disk.Properties{}
+	}
+	currentSize := uint64(to.Int32(diskModel.DiskSizeGB))
+	if currentSize >= sizeInGib {
+		// Disks cannot be shrunk, and there is nothing
+		// to do if the disk is already large enough.
+		return gibToMib(currentSize), nil
+	}
+	diskModel.DiskSizeGB = to.Int32Ptr(int32(sizeInGib))
+	resultCh, errCh := diskClient.CreateOrUpdate(v.env.resourceGroup, p.VolumeId, diskModel, nil)
+	result, err := <-resultCh, <-errCh
+	if err != nil {
+		return 0, errorutils.HandleCredentialError(errors.Annotatef(err, "resizing disk %q", p.VolumeId), ctx)
+	}
+	return gibToMib(uint64(to.Int32(result.DiskSizeGB))), nil
+}
+
 // ReleaseVolumes is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
 	// Releasing volumes is not supported, see azureStorageProvider.Releasable.
@@ -730,6 +730,77 @@ func (s *storageSuite) TestDestroyVolumesLegacy(c *gc.C) {
 	blob1.CheckCallNames(c, "DeleteIfExists")
 }
 
+func (s *storageSuite) TestResizeVolumes(c *gc.C) {
+	volumeSource := s.volumeSource(c, false)
+	getSender := azuretesting.NewSenderWithValue(&disk.Model{
+		Name: to.StringPtr("volume-0"),
+		Properties: &disk.Properties{
+			DiskSizeGB: to.Int32Ptr(10),
+		},
+	})
+	getSender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
+	updateSender := azuretesting.NewSenderWithValue(&disk.Model{
+		Name: to.StringPtr("volume-0"),
+		Properties: &disk.Properties{
+			DiskSizeGB: to.Int32Ptr(20),
+		},
+	})
+	updateSender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
+	s.sender = azuretesting.Senders{getSender, updateSender}
+	s.requests = nil
+
+	results, err := volumeSource.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     20 * 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 20 * 1024}})
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[0].Method, gc.Equals, "GET")
+	c.Assert(s.requests[1].Method, gc.Equals, "PUT")
+	assertRequestBody(c, s.requests[1], &disk.Model{
+		Name: to.StringPtr("volume-0"),
+		Properties: &disk.Properties{
+			DiskSizeGB: to.Int32Ptr(20),
+		},
+	})
+}
+
+func (s *storageSuite) TestResizeVolumesAlreadyLargeEnough(c *gc.C) {
+	volumeSource := s.volumeSource(c, false)
+	getSender := azuretesting.NewSenderWithValue(&disk.Model{
+		Properties: &disk.Properties{
+			DiskSizeGB: to.Int32Ptr(32),
+		},
+	})
+	getSender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
+	s.sender = azuretesting.Senders{getSender}
+	s.requests = nil
+
+	results, err := volumeSource.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     20 * 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 32 * 1024}})
+	c.Assert(s.requests, gc.HasLen, 1)
+}
+
+func (s *storageSuite) TestResizeVolumesLegacy(c *gc.C) {
+	volumeSource := s.volumeSource(c, true)
+	results, err := volumeSource.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     20 * 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *storageSuite) TestAttachVolumes(c *gc.C) {
 	s.testAttachVolumes(c, false)
 }
@@ -52,6 +52,7 @@ import (
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/observer/fakeobserver"
 	"github.com/juju/juju/apiserver/stateauthenticator"
+	"github.com/juju/juju/apiserver/testserver"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
@@ -255,6 +256,7 @@ type environState struct {
 	mux            *apiserverhttp.Mux
 	httpServer     *httptest.Server
 	apiServer      *apiserver.Server
+	leaseManager   *testserver.LeaseManager
 	apiState       *state.State
 	apiStatePool   *state.StatePool
 	hub            *pubsub.StructuredHub
@@ -353,9 +355,11 @@ func (state *environState) destroyLocked() {
 		return
 	}
 	apiServer := state.apiServer
+	leaseManager := state.leaseManager
 	apiStatePool := state.apiStatePool
 	apiState := state.apiState
 	state.apiServer = nil
+	state.leaseManager = nil
 	state.apiStatePool = nil
 	state.apiState = nil
 	state.bootstrapped = false
@@ -374,6 +378,13 @@ func (state *environState) destroyLocked() {
 		}
 	}
 
+	if leaseManager != nil {
+		leaseManager.Kill()
+		if err := leaseManager.Wait(); err != nil && mongoAlive() {
+			panic(err)
+		}
+	}
+
 	if apiStatePool != nil {
 		if err := apiStatePool.Close(); err != nil && mongoAlive() {
 			panic(err)
@@ -867,6 +878,12 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.Provi
 			estate.httpServer.StartTLS()
 			estate.presence = &fakePresence{make(map[string]presence.Status)}
 			estate.hub = centralhub.New(machineTag)
+			estate.leaseManager, err = testserver.NewLeaseManager(st)
+			if err != nil {
+				statePool.Close()
+				st.Close()
+				return err
+			}
 			estate.apiServer, err = apiserver.NewServer(apiserver.ServerConfig{
 				StatePool:       statePool,
 				Authenticator:   stateAuthenticator,
@@ -887,6 +904,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.Provi
 				RestoreStatus: func() state.RestoreStatus {
 					return state.RestoreNotActive
 				},
+				LeaseManager: estate.leaseManager,
 			})
 			if err != nil {
 				statePool.Close()
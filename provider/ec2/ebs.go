@@ -4,7 +4,11 @@
 package ec2
 
 import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +17,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
+	"gopkg.in/amz.v3/aws"
 	"gopkg.in/amz.v3/ec2"
 	"gopkg.in/juju/names.v2"
 
@@ -286,6 +291,7 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeResizer = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -987,6 +993,95 @@ func (v *ebsVolumeSource) ImportVolume(ctx context.ProviderCallContext, volumeId
 	}, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *ebsVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeOneVolume(ctx, p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) resizeOneVolume(ctx context.ProviderCallContext, p storage.VolumeResizeParams) (uint64, error) {
+	vol, err := describeVolume(v.env.ec2, ctx, p.VolumeId)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	sizeGiB := mibToGib(p.Size)
+	if uint64(vol.Size) >= sizeGiB {
+		// The volume is already at least as large as
+		// requested; EBS volumes cannot be shrunk.
+		return gibToMib(uint64(vol.Size)), nil
+	}
+	if err := modifyVolume(v.env.ec2, p.VolumeId, sizeGiB); err != nil {
+		return 0, errors.Annotatef(maybeConvertCredentialError(err, ctx), "cannot resize volume %q", p.VolumeId)
+	}
+	return gibToMib(sizeGiB), nil
+}
+
+// modifyVolumeVersion is the EC2 API version used to make ModifyVolume
+// requests. The client library predates ModifyVolume, which was added
+// in this version, so the request is made directly.
+const modifyVolumeVersion = "2016-11-15"
+
+// ec2HTTPClient is the HTTP client used to make requests that the EC2
+// client library doesn't support. It's the client the library itself
+// sends requests with.
+var ec2HTTPClient = http.DefaultClient
+
+// modifyVolume requests that the EBS volume with the given id be grown
+// to the given size in GiB. The volume may be used at its new size
+// once the modification is "optimizing"; the partition and filesystem
+// are grown on the machine.
+func modifyVolume(client *ec2.EC2, volumeId string, sizeGiB uint64) error {
+	endpoint, err := url.Parse(client.Region.EC2Endpoint)
+	if err != nil {
+		return errors.Annotate(err, "parsing EC2 endpoint")
+	}
+	endpoint.RawQuery = url.Values{
+		"Action":   {"ModifyVolume"},
+		"Version":  {modifyVolumeVersion},
+		"VolumeId": {volumeId},
+		"Size":     {strconv.FormatUint(sizeGiB, 10)},
+	}.Encode()
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The V4 signer requires the request date, which the client
+	// library sets for its own requests.
+	req.Header.Set("x-amz-date", time.Now().In(time.UTC).Format(aws.ISO8601BasicFormat))
+	if err := client.Sign(req, client.Auth); err != nil {
+		return errors.Annotate(err, "signing request")
+	}
+	resp, err := ec2HTTPClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	var errs struct {
+		RequestId string      `xml:"RequestID"`
+		Errors    []ec2.Error `xml:"Errors>Error"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&errs); err != nil || len(errs.Errors) == 0 {
+		return errors.Errorf("ModifyVolume failed: %s", resp.Status)
+	}
+	ec2err := &errs.Errors[0]
+	ec2err.StatusCode = resp.StatusCode
+	if ec2err.RequestId == "" {
+		ec2err.RequestId = errs.RequestId
+	}
+	return ec2err
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -1223,6 +1225,93 @@ func makeDescribeVolumesResponseModifier(modify func(*awsec2.VolumesResp) error)
 	}
 }
 
+func (s *ebsSuite) TestResizeVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeResizer))
+	s.assertCreateVolumes(c, vs, "")
+
+	var modified []url.Values
+	var headers []http.Header
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		query := resp.Request.URL.Query()
+		if query.Get("Action") != "ModifyVolume" {
+			return nil
+		}
+		modified = append(modified, query)
+		headers = append(headers, resp.Request.Header)
+		resp.StatusCode = http.StatusOK
+		resp.Body = ioutil.NopCloser(strings.NewReader("<ModifyVolumeResponse/>"))
+		return nil
+	}
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     15000,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     10240,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{
+		{Size: 15360},
+		// vol-1 is already larger than requested.
+		{Size: 20480},
+	})
+	c.Assert(modified, gc.HasLen, 1)
+	c.Assert(modified[0].Get("VolumeId"), gc.Equals, "vol-0")
+	c.Assert(modified[0].Get("Size"), gc.Equals, "15")
+	c.Assert(modified[0].Get("Version"), gc.Equals, "2016-11-15")
+	c.Assert(headers[0].Get("X-Amz-Date"), gc.Not(gc.Equals), "")
+	c.Assert(headers[0].Get("Authorization"), gc.Matches, "AWS4-HMAC-SHA256 .*")
+}
+
+func (s *ebsSuite) TestResizeVolumesError(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		if resp.Request.URL.Query().Get("Action") != "ModifyVolume" {
+			return nil
+		}
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code:    "IncorrectModificationState",
+			Message: "vol-0 is already being modified",
+		}}})
+	}
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     20480,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `cannot resize volume "vol-0": vol-0 is already being modified.*`)
+	c.Assert(errors.Cause(results[0].Error), gc.FitsTypeOf, &awsec2.Error{})
+}
+
+func (s *ebsSuite) TestResizeVolumesCredentialError(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		if resp.Request.URL.Query().Get("Action") != "ModifyVolume" {
+			return nil
+		}
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code: "Blocked",
+		}}})
+	}
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     20480,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.Satisfies, common.IsCredentialNotValid)
+}
+
 func replaceResponseBody(resp *http.Response, value interface{}) error {
 	var buf bytes.Buffer
 	if err := xml.NewEncoder(&buf).Encode(value); err != nil {
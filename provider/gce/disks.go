@@ -76,6 +76,8 @@ type volumeSource struct {
 	modelUUID string
 }
 
+var _ storage.VolumeResizer = (*volumeSource)(nil)
+
 func (g *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
 	environConfig := g.env.Config()
 	source := &volumeSource{
@@ -420,6 +422,41 @@ func (v *volumeSource) describeOneVolume(ctx context.ProviderCallContext, volNam
 	return desc, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *volumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeOneVolume(ctx, p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeOneVolume(ctx context.ProviderCallContext, p storage.VolumeResizeParams) (uint64, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return 0, errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+	}
+	disk, err := v.gce.Disk(zone, p.VolumeId)
+	if err != nil {
+		return 0, google.HandleCredentialError(errors.Annotatef(err, "cannot get volume %q", p.VolumeId), ctx)
+	}
+	sizeGB := mibToGib(p.Size)
+	if disk.Size >= sizeGB*1024 {
+		// The disk is already at least as large as requested;
+		// GCE rejects requests to shrink a disk.
+		return disk.Size, nil
+	}
+	if err := v.gce.ResizeDisk(zone, p.VolumeId, sizeGB); err != nil {
+		return 0, google.HandleCredentialError(errors.Annotatef(err, "cannot resize volume %q", p.VolumeId), ctx)
+	}
+	return sizeGB * 1024, nil
+}
+
 // TODO(perrito666) These rules are yet to be defined.
 func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	return nil
@@ -357,6 +357,55 @@ func (s *volumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	c.Assert(call[0].ID, gc.Equals, volName)
 }
 
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	res, err := s.source.(storage.VolumeResizer).ResizeVolumes(s.CallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: volName,
+		Size:     3000,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 3072}})
+
+	resizeCalled, call := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsTrue)
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].ID, gc.Equals, volName)
+	c.Assert(call[0].SizeGB, gc.Equals, uint64(3))
+}
+
+func (s *volumeSourceSuite) TestResizeVolumesAlreadyLargeEnough(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	res, err := s.source.(storage.VolumeResizer).ResizeVolumes(s.CallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: volName,
+		Size:     1000,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 1024}})
+
+	resizeCalled, _ := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsFalse)
+}
+
+func (s *volumeSourceSuite) TestResizeVolumesInvalidCredentialError(c *gc.C) {
+	s.FakeConn.Err = gce.InvalidCredentialError
+	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	res, err := s.source.(storage.VolumeResizer).ResizeVolumes(s.CallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: volName,
+		Size:     3000,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, gc.NotNil)
+	c.Assert(s.InvalidatedCredentials, jc.IsTrue)
+}
+
 func (s *volumeSourceSuite) TestAttachVolumes(c *gc.C) {
 	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
 	attachments := []storage.VolumeAttachmentParams{*s.attachmentParams}
@@ -59,6 +59,9 @@ type gceConnection interface {
 	// SetDiskLabels sets the labels on a disk, ensuring that the disk's
 	// label fingerprint matches the one supplied.
 	SetDiskLabels(zone, id, labelFingerprint string, labels map[string]string) error
+	// ResizeDisk grows the disk identified by <name> in <zone> to
+	// <sizeGB> GiB.
+	ResizeDisk(zone, name string, sizeGB uint64) error
 	// AttachDisk will attach the volume identified by <volumeName> into the instance
 	// <instanceId> and return an AttachedDisk representing it or error.
 	AttachDisk(zone, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error)
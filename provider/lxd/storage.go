@@ -140,7 +140,9 @@ func (e *lxdStorageProvider) ValidateConfig(cfg *storage.Config) error {
 
 // Supports is part of the Provider interface.
 func (e *lxdStorageProvider) Supports(k storage.StorageKind) bool {
-	return k == storage.StorageKindFilesystem
+	// Custom storage volumes may be attached to several
+	// containers at once, so filesystems may be shared.
+	return k == storage.StorageKindFilesystem || k == storage.StorageKindSharedFilesystem
 }
 
 // Scope is part of the Provider interface.
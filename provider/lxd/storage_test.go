@@ -89,6 +89,7 @@ func (s *storageSuite) TestFilesystemSource(c *gc.C) {
 func (s *storageSuite) TestSupports(c *gc.C) {
 	c.Assert(s.provider.Supports(storage.StorageKindBlock), jc.IsFalse)
 	c.Assert(s.provider.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(s.provider.Supports(storage.StorageKindSharedFilesystem), jc.IsTrue)
 }
 
 func (s *storageSuite) TestDynamic(c *gc.C) {
@@ -4,9 +4,15 @@
 package openstack
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -128,9 +134,24 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 		}
 	}
 
+	actionCl := &cinderActionClient{
+		endpoint:   env.volumeURL,
+		tenantId:   client.TenantId(),
+		token:      client.Token,
+		httpClient: http.DefaultClient,
+	}
+	if len(cloudSpec.CACertificates) > 0 {
+		actionCl.httpClient = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig(cloudSpec.CACertificates),
+			},
+		}
+	}
+
 	return &openstackStorageAdapter{
 		cinderCl,
 		novaClient{env.novaUnlocked},
+		actionCl,
 	}, nil
 }
 
@@ -212,6 +233,7 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeResizer = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -339,6 +361,48 @@ func (s *cinderVolumeSource) DescribeVolumes(ctx context.ProviderCallContext, vo
 	return results, nil
 }
 
+// ResizeVolumes implements storage.VolumeResizer.
+func (s *cinderVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		size, err := resizeVolume(s.storageAdapter, arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.VolumeId)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func resizeVolume(storageAdapter OpenstackStorage, arg storage.VolumeResizeParams) (uint64, error) {
+	volume, err := storageAdapter.GetVolume(arg.VolumeId)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	// Cinder sizes are in GiB.
+	newSize := int((arg.Size + 1023) / 1024)
+	if volume.Size >= newSize {
+		// Volumes cannot be shrunk, and there is nothing
+		// to do if the volume is already large enough.
+		return uint64(volume.Size * 1024), nil
+	}
+	if err := storageAdapter.ExtendVolume(arg.VolumeId, newSize); err != nil {
+		return 0, errors.Trace(err)
+	}
+	volume, err = waitVolume(storageAdapter, arg.VolumeId, func(v *cinder.Volume) (bool, error) {
+		switch v.Status {
+		case volumeStatusError, "error_extending":
+			return false, errors.Errorf("volume is in %q state", v.Status)
+		}
+		return v.Size >= newSize, nil
+	})
+	if err != nil {
+		return 0, errors.Annotate(err, "waiting for volume to be extended")
+	}
+	return uint64(volume.Size * 1024), nil
+}
+
 // DestroyVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) DestroyVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
 	return foreachVolume(s.storageAdapter, volumeIds, destroyVolume), nil
@@ -603,6 +667,7 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	ExtendVolume(volumeId string, newSize int) error
 }
 
 type endpointResolver interface {
@@ -633,6 +698,7 @@ func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, err
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+	*cinderActionClient
 }
 
 type cinderClient struct {
@@ -703,3 +769,58 @@ func (ga *openstackStorageAdapter) DetachVolume(serverId, attachmentId string) e
 	}
 	return nil
 }
+
+// cinderActionClient performs volume actions that the goose cinder
+// client does not support.
+type cinderActionClient struct {
+	endpoint   *url.URL
+	tenantId   string
+	token      func() string
+	httpClient *http.Client
+}
+
+// ExtendVolume is part of the OpenstackStorage interface. It grows
+// the volume to newSize GiB.
+//
+// Cinder only extends volumes that are attached to a server if the
+// cloud supports version 3.42 or later of the block storage API;
+// older clouds will fail the request for in-use volumes.
+func (c *cinderActionClient) ExtendVolume(volumeId string, newSize int) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"os-extend": map[string]int{"new_size": newSize},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The request is sent to the same path that the goose
+	// client uses for the volume, with the endpoint's scheme
+	// and host.
+	actionURL := url.URL{
+		Scheme: c.endpoint.Scheme,
+		Host:   c.endpoint.Host,
+		Path:   fmt.Sprintf("/v2/%s/volumes/%s/action", c.tenantId, volumeId),
+	}
+	req, err := http.NewRequest("POST", actionURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Auth-Token", c.token())
+	req.Header.Set("OpenStack-API-Version", "volume 3.42")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "extending volume %q", volumeId)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusAccepted, http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return errors.NotFoundf("volume %q", volumeId)
+	}
+	message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return errors.Errorf(
+		"extending volume %q: %s: %s",
+		volumeId, resp.Status, strings.TrimSpace(string(message)),
+	)
+}
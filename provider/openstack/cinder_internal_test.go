@@ -4,6 +4,13 @@
 package openstack
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -46,6 +53,44 @@ func (s *cinderInternalSuite) TestStorageProviderTypesNotSupported(c *gc.C) {
 	c.Assert(types, gc.HasLen, 0)
 }
 
+func (s *cinderInternalSuite) TestExtendVolume(c *gc.C) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		requests = append(requests, req)
+		bodies = append(bodies, string(body))
+		if strings.Contains(req.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL + "/v2")
+	c.Assert(err, jc.ErrorIsNil)
+	client := &cinderActionClient{
+		endpoint:   endpoint,
+		tenantId:   "tenant-id",
+		token:      func() string { return "token" },
+		httpClient: http.DefaultClient,
+	}
+	err = client.ExtendVolume("vol-id", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.HasLen, 1)
+	c.Assert(requests[0].Method, gc.Equals, "POST")
+	c.Assert(requests[0].URL.Path, gc.Equals, "/v2/tenant-id/volumes/vol-id/action")
+	c.Assert(requests[0].Header.Get("X-Auth-Token"), gc.Equals, "token")
+	c.Assert(bodies[0], jc.JSONEquals, map[string]interface{}{
+		"os-extend": map[string]interface{}{"new_size": 3},
+	})
+
+	err = client.ExtendVolume("missing", 3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 type testAuthClient struct {
 	client.AuthenticatingClient
 	regionEndpoints map[string]identity.ServiceURLs
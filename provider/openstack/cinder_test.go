@@ -329,6 +329,73 @@ func (s *cinderVolumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	}})
 }
 
+func (s *cinderVolumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	size := 1
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   size,
+				Status: "in-use",
+			}, nil
+		},
+		extendVolume: func(volumeId string, newSize int) error {
+			size = newSize
+			return nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("123"),
+		VolumeId: mockVolId,
+		Size:     2500,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 3072}})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"ExtendVolume", []interface{}{mockVolId, 3}},
+		{"GetVolume", []interface{}{mockVolId}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesAlreadyLargeEnough(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volumeId, Size: 4}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("123"),
+		VolumeId: mockVolId,
+		Size:     2500,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 4096}})
+	mockAdapter.CheckCallNames(c, "GetVolume")
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesExtendFails(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volumeId, Size: 1}, nil
+		},
+		extendVolume: func(volumeId string, newSize int) error {
+			return errors.New("400 Bad Request")
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("123"),
+		VolumeId: mockVolId,
+		Size:     2500,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "resizing volume 0: 400 Bad Request")
+}
+
 func (s *cinderVolumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	mockAdapter := &mockAdapter{}
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
@@ -623,6 +690,7 @@ type mockAdapter struct {
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	extendVolume          func(string, int) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -692,6 +760,14 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) ExtendVolume(volumeId string, newSize int) error {
+	ma.MethodCall(ma, "ExtendVolume", volumeId, newSize)
+	if ma.extendVolume != nil {
+		return ma.extendVolume(volumeId, newSize)
+	}
+	return nil
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
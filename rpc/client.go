@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/trace"
 )
 
 var ErrShutdown = errors.New("connection is shut down")
@@ -61,11 +63,15 @@ func (conn *Conn) send(call *Call) {
 	conn.clientPending[reqId] = call
 	conn.mutex.Unlock()
 
-	// Encode and send the request.
+	// Encode and send the request, along with the trace
+	// that the connection is part of, if any.
+	sc := trace.SpanContextFromContext(conn.context)
 	hdr := &Header{
 		RequestId: reqId,
 		Request:   call.Request,
 		Version:   1,
+		TraceID:   sc.TraceID,
+		SpanID:    sc.SpanID,
 	}
 	params := call.Params
 	if params == nil {
@@ -72,6 +72,8 @@ type inMsgV1 struct {
 	Error     string          `json:"error"`
 	ErrorCode string          `json:"error-code"`
 	Response  json.RawMessage `json:"response"`
+	TraceID   string          `json:"trace-id"`
+	SpanID    string          `json:"span-id"`
 }
 
 // outMsg holds an outgoing message.
@@ -97,6 +99,8 @@ type outMsgV1 struct {
 	Error     string      `json:"error,omitempty"`
 	ErrorCode string      `json:"error-code,omitempty"`
 	Response  interface{} `json:"response,omitempty"`
+	TraceID   string      `json:"trace-id,omitempty"`
+	SpanID    string      `json:"span-id,omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -140,6 +144,8 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
 	hdr.Version = version
+	hdr.TraceID = c.msg.TraceID
+	hdr.SpanID = c.msg.SpanID
 	return nil
 }
 
@@ -272,6 +278,8 @@ func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 		Request:   hdr.Request.Action,
 		Error:     hdr.Error,
 		ErrorCode: hdr.ErrorCode,
+		TraceID:   hdr.TraceID,
+		SpanID:    hdr.SpanID,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
@@ -115,6 +115,19 @@ func (*suite) TestRead(c *gc.C) {
 			Version: 1,
 		},
 		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"request-id": 5, "type": "foo", "request": "frob", "params": {"X": "param"}, "trace-id": "0123456789abcdef0123456789abcdef", "span-id": "0123456789abcdef"}`,
+		expectHdr: rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			Version: 1,
+			TraceID: "0123456789abcdef0123456789abcdef",
+			SpanID:  "0123456789abcdef",
+		},
+		expectBody: &value{X: "param"},
 	}} {
 		c.Logf("test %d", i)
 		codec := jsoncodec.New(&testConn{
@@ -236,6 +249,19 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "param"},
 		expect: `{"request-id": 4, "type": "foo", "version": 2, "request": "frob", "params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			Version: 1,
+			TraceID: "0123456789abcdef0123456789abcdef",
+			SpanID:  "0123456789abcdef",
+		},
+		body:   &value{X: "param"},
+		expect: `{"request-id": 5, "type": "foo", "request": "frob", "params": {"X": "param"}, "trace-id": "0123456789abcdef0123456789abcdef", "span-id": "0123456789abcdef"}`,
 	}} {
 		c.Logf("test %d", i)
 		var conn testConn
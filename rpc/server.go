@@ -8,12 +8,14 @@ import (
 	"io"
 	"reflect"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/rpc/rpcreflect"
 )
 
@@ -67,6 +69,14 @@ type Header struct {
 
 	// Version defines the wire format of the request and response structure.
 	Version int
+
+	// TraceID holds the ID of the trace the request is part of,
+	// if any.
+	TraceID string
+
+	// SpanID holds the ID of the client span the request was
+	// made from, if any.
+	SpanID string
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -159,6 +169,10 @@ type Conn struct {
 	// terminate prematurely.  It is set before dead is closed.
 	inputLoopError error
 
+	// traceContext holds the trace of the most recent request
+	// the client made as part of a trace.
+	traceContext trace.SpanContext
+
 	recorderFactory RecorderFactory
 }
 
@@ -411,7 +425,28 @@ func (conn *Conn) getRecorder() Recorder {
 	return conn.recorderFactory()
 }
 
+// setTraceContext records the trace that the request is part of, if any.
+func (conn *Conn) setTraceContext(hdr *Header) {
+	sc := trace.SpanContext{TraceID: hdr.TraceID, SpanID: hdr.SpanID}
+	if !sc.IsValid() {
+		return
+	}
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.traceContext = sc
+}
+
+// TraceContext returns the trace that the client has made its requests
+// part of, as given by the most recent traced request it has made. It
+// is not valid if the client has not made any traced requests.
+func (conn *Conn) TraceContext() trace.SpanContext {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	return conn.traceContext
+}
+
 func (conn *Conn) handleRequest(hdr *Header) error {
+	conn.setTraceContext(hdr)
 	recorder := conn.getRecorder()
 	req, err := conn.bindRequest(hdr)
 	if err != nil {
@@ -557,7 +592,21 @@ func (conn *Conn) runRequest(
 	ctx, cancel := context.WithCancel(conn.context)
 	defer cancel()
 
+	// Record the request as part of the client's trace, if it
+	// has one.
+	ctx = trace.ContextWithSpanContext(ctx, trace.SpanContext{
+		TraceID: req.hdr.TraceID,
+		SpanID:  req.hdr.SpanID,
+	})
+	ctx, span := trace.StartServerSpan(ctx, req.hdr.Request.Type+"."+req.hdr.Request.Action)
+	span.SetAttribute("rpc.version", strconv.Itoa(req.hdr.Request.Version))
+	if req.hdr.Request.Id != "" {
+		span.SetAttribute("rpc.id", req.hdr.Request.Id)
+	}
+	defer span.End()
+
 	rv, err := req.Call(ctx, req.hdr.Request.Id, arg)
+	span.SetError(err)
 	if err != nil {
 		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), recorder)
 	} else {
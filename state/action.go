@@ -380,11 +380,40 @@ func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(m.st, receiver, actionName, payload)
+	doc, ops, err := m.enqueueActionOps(receiver, actionName, payload)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if notDead, err := isNotDead(m.st, receiverCollectionName, receiverId); err != nil {
+			return nil, err
+		} else if !notDead {
+			return nil, ErrDead
+		} else if attempt != 0 {
+			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
+		}
+		return ops, nil
+	}
+	if err = m.st.db().Run(buildTxn); err == nil {
+		return newAction(m.st, doc), nil
+	}
+	return nil, err
+}
+
+// enqueueActionOps returns the document for a new action on the
+// receiver, and the transaction operations that add it.
+func (m *Model) enqueueActionOps(receiver names.Tag, actionName string, payload map[string]interface{}) (actionDoc, []txn.Op, error) {
+	receiverCollectionName, receiverId, err := m.st.tagToCollectionAndId(receiver)
+	if err != nil {
+		return actionDoc{}, nil, errors.Trace(err)
+	}
+
+	doc, ndoc, err := newActionDoc(m.st, receiver, actionName, payload)
+	if err != nil {
+		return actionDoc{}, nil, errors.Trace(err)
+	}
+
 	ops := []txn.Op{{
 		C:      receiverCollectionName,
 		Id:     receiverId,
@@ -400,21 +429,7 @@ func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map
 		Assert: txn.DocMissing,
 		Insert: ndoc,
 	}}
-
-	buildTxn := func(attempt int) ([]txn.Op, error) {
-		if notDead, err := isNotDead(m.st, receiverCollectionName, receiverId); err != nil {
-			return nil, err
-		} else if !notDead {
-			return nil, ErrDead
-		} else if attempt != 0 {
-			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
-		}
-		return ops, nil
-	}
-	if err = m.st.db().Run(buildTxn); err == nil {
-		return newAction(m.st, doc), nil
-	}
-	return nil, err
+	return doc, ops, nil
 }
 
 // matchingActions finds actions that match ActionReceiver.
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
@@ -113,21 +114,19 @@ type HostFilesystemParams struct {
 // of the given type inside another new machine. The two given templates
 // specify the form of the child and parent respectively.
 func (st *State) AddMachineInsideNewMachine(template, parentTemplate MachineTemplate, containerType instance.ContainerType) (*Machine, error) {
-	mdoc, ops, err := st.addMachineInsideNewMachineOps(template, parentTemplate, containerType)
-	if err != nil {
-		return nil, errors.Annotate(err, "cannot add a new machine")
-	}
-	return st.addMachine(mdoc, ops)
+	m, err := st.addMachine(func() (*machineDoc, []txn.Op, error) {
+		return st.addMachineInsideNewMachineOps(template, parentTemplate, containerType)
+	})
+	return m, errors.Annotate(err, "cannot add a new machine")
 }
 
 // AddMachineInsideMachine adds a machine inside a container of the
 // given type on the existing machine with id=parentId.
 func (st *State) AddMachineInsideMachine(template MachineTemplate, parentId string, containerType instance.ContainerType) (*Machine, error) {
-	mdoc, ops, err := st.addMachineInsideMachineOps(template, parentId, containerType)
-	if err != nil {
-		return nil, errors.Annotate(err, "cannot add a new machine")
-	}
-	return st.addMachine(mdoc, ops)
+	m, err := st.addMachine(func() (*machineDoc, []txn.Op, error) {
+		return st.addMachineInsideMachineOps(template, parentId, containerType)
+	})
+	return m, errors.Annotate(err, "cannot add a new machine")
 }
 
 // AddMachine adds a machine with the given series and jobs.
@@ -158,42 +157,67 @@ func (st *State) AddOneMachine(template MachineTemplate) (*Machine, error) {
 func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add a new machine")
 	var ms []*Machine
-	var ops []txn.Op
-	var mdocs []*machineDoc
-	for _, template := range templates {
-		mdoc, addOps, err := st.addMachineOps(template)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		mdocs = append(mdocs, mdoc)
-		ms = append(ms, newMachine(st, mdoc))
-		ops = append(ops, addOps...)
-	}
-	ssOps, err := st.maintainControllersOps(mdocs, nil)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	ops = append(ops, ssOps...)
-	ops = append(ops, assertModelActiveOp(st.ModelUUID()))
-	if err := st.db().RunTransaction(ops); err != nil {
-		if errors.Cause(err) == txn.ErrAborted {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
 		}
+		ms = nil
+		var ops []txn.Op
+		var mdocs []*machineDoc
+		requested := make(quota.Usage)
+		for _, template := range templates {
+			mdoc, addOps, usage, err := st.newMachineOps(template)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			mdocs = append(mdocs, mdoc)
+			ms = append(ms, newMachine(st, mdoc))
+			ops = append(ops, addOps...)
+			addQuotaUsage(requested, usage)
+		}
+		// The machines are charged to the quota together, as a
+		// transaction may only update it once.
+		chargeOps, err := quotaOps(st, requested)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, chargeOps...)
+		ssOps, err := st.maintainControllersOps(mdocs, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, ssOps...)
+		ops = append(ops, assertModelActiveOp(st.ModelUUID()))
+		return ops, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return ms, nil
 }
 
-func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op) (*Machine, error) {
-	ops = append([]txn.Op{assertModelActiveOp(st.ModelUUID())}, ops...)
-	if err := st.db().RunTransaction(ops); err != nil {
-		if errors.Cause(err) == txn.ErrAborted {
+// addMachine runs a transaction to add the machine whose document and
+// operations are returned by buildOps, retrying if the transaction
+// aborts.
+func (st *State) addMachine(buildOps func() (*machineDoc, []txn.Op, error)) (*Machine, error) {
+	var mdoc *machineDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
 		}
+		var ops []txn.Op
+		var err error
+		mdoc, ops, err = buildOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append([]txn.Op{assertModelActiveOp(st.ModelUUID())}, ops...), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return newMachine(st, mdoc), nil
@@ -257,17 +281,36 @@ func (st *State) effectiveMachineTemplate(p MachineTemplate, allowController boo
 }
 
 // addMachineOps returns operations to add a new top level machine
-// based on the given template. It also returns the machine document
-// that will be inserted.
+// based on the given template, and to charge it to the model's quota.
+// It also returns the machine document that will be inserted.
 func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op, error) {
-	template, err := st.effectiveMachineTemplate(template, st.IsController())
+	mdoc, ops, usage, err := st.newMachineOps(template)
 	if err != nil {
 		return nil, nil, err
 	}
+	chargeOps, err := quotaOps(st, usage)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return mdoc, append(ops, chargeOps...), nil
+}
+
+// newMachineOps returns operations to add a new top level machine
+// based on the given template, without charging it to the model's
+// quota. It also returns the machine document that will be inserted,
+// and the resources the machine will use.
+func (st *State) newMachineOps(template MachineTemplate) (*machineDoc, []txn.Op, quota.Usage, error) {
+	template, err := st.effectiveMachineTemplate(template, st.IsController())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkMachineTemplateSized(st, template); err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
 	if template.InstanceId == "" {
 		volumeAttachments, err := st.machineTemplateVolumeAttachmentParams(template)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		if err := st.precheckInstance(
 			template.Series,
@@ -275,17 +318,17 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 			template.Placement,
 			volumeAttachments,
 		); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 	seq, err := sequence(st, "machine")
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	mdoc := st.machineDocForTemplate(template, strconv.Itoa(seq))
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
-		return nil, nil, errors.Trace(err)
+		return nil, nil, nil, errors.Trace(err)
 	}
 	prereqOps = append(prereqOps, assertModelActiveOp(st.ModelUUID()))
 	prereqOps = append(prereqOps, insertNewContainerRefOp(st, mdoc.Id))
@@ -310,7 +353,8 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 		})
 	}
 
-	return mdoc, append(prereqOps, machineOp), nil
+	usage := machineTemplateQuotaUsage(template, false)
+	return mdoc, append(prereqOps, machineOp), usage, nil
 }
 
 // supportsContainerType reports whether the machine supports the given
@@ -345,6 +389,10 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if containerType == "" {
 		return nil, nil, errors.New("no container type specified")
 	}
+	chargeOps, err := quotaOps(st, machineTemplateQuotaUsage(template, true))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 
 	// If a parent machine is specified, make sure it exists
 	// and can support the requested container type.
@@ -372,6 +420,7 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 		// Create a containers reference document for the container itself.
 		insertNewContainerRefOp(st, mdoc.Id),
 	)
+	prereqOps = append(prereqOps, chargeOps...)
 	return mdoc, append(prereqOps, machineOp), nil
 }
 
@@ -428,6 +477,15 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := checkMachineTemplateSized(st, parentTemplate); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	requested := machineTemplateQuotaUsage(parentTemplate, false)
+	addQuotaUsage(requested, machineTemplateQuotaUsage(template, true))
+	chargeOps, err := quotaOps(st, requested)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc := st.machineDocForTemplate(template, newId)
 	mdoc.ContainerType = string(containerType)
 	parentPrereqOps, parentOp, err := st.insertNewMachineOps(parentDoc, parentTemplate)
@@ -445,6 +503,7 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 		// Create a containers reference document for the container itself.
 		insertNewContainerRefOp(st, parentDoc.Id, mdoc.Id),
 	)
+	prereqOps = append(prereqOps, chargeOps...)
 	return mdoc, append(prereqOps, parentOp, machineOp), nil
 }
 
@@ -649,6 +708,19 @@ func (sb *storageBackend) hostStorageOps(
 		}
 	}
 	for tag, filesystemAttachment := range args.filesystemAttachments {
+		if names.IsValidMachine(hostId) {
+			// A shared filesystem may already be attached to the
+			// machine for another unit, in which case the unit
+			// uses the existing attachment.
+			existingOps, err := sb.existingFilesystemAttachmentOps(names.NewMachineTag(hostId), tag)
+			if err != nil {
+				return nil, nil, nil, errors.Trace(err)
+			}
+			if len(existingOps) > 0 {
+				filesystemOps = append(filesystemOps, existingOps...)
+				continue
+			}
+		}
 		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
 			tag, names.StorageTag{}, filesystemAttachment, attachOnly,
 		})
@@ -672,9 +744,9 @@ func (sb *storageBackend) hostStorageOps(
 	}
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
+	ops = append(ops, filesystemOps...)
 	if len(fsAttachments) > 0 {
 		attachmentOps := createMachineFilesystemAttachmentsOps(hostId, fsAttachments)
-		ops = append(ops, filesystemOps...)
 		ops = append(ops, attachmentOps...)
 	}
 	if len(volumeAttachments) > 0 {
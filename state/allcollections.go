@@ -229,6 +229,10 @@ func allCollections() collectionSchema {
 		// changes from being accepted.
 		blocksC: {},
 
+		// This collection holds the resource quota of the model,
+		// which limits the machines, units and storage it may use.
+		quotasC: {},
+
 		// This collection is used for internal bookkeeping; certain complex
 		// or tedious state changes are deferred by recording a cleanup doc
 		// for later handling.
@@ -249,6 +253,13 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection records the holder of each lease in the raft
+		// lease store, so that transactions can assert that a lease is
+		// still held. It's kept up to date by the raft lease store.
+		leaseHoldersC: {
+			global: true,
+		},
+
 		// -----
 
 		// These collections hold information associated with applications.
@@ -277,6 +288,27 @@ func allCollections() collectionSchema {
 		// meterStatusC is the collection used to store meter status information.
 		meterStatusC: {},
 
+		// unitStatesC holds the persistent key/value state that charms
+		// store for their units using the state-* hook tools.
+		unitStatesC: {},
+
+		// secretMetadataC holds the metadata and access grants of the
+		// secrets that charms create.
+		secretMetadataC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}},
+		},
+
+		// secretValuesC holds the encrypted values of secrets stored
+		// by the controller secrets backend, along with the key used
+		// to encrypt them.
+		secretValuesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "secret-id"},
+			}},
+		},
+
 		// These collections hold reference counts which are used
 		// by the nsRefcounts struct.
 		refcountsC: {}, // Per model.
@@ -361,6 +393,13 @@ func allCollections() collectionSchema {
 			}},
 		},
 		volumeAttachmentsC: {},
+		volumeSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "storageid"},
+			}, {
+				Key: []string{"model-uuid", "hostid"},
+			}},
+		},
 
 		// -----
 
@@ -387,6 +426,14 @@ func allCollections() collectionSchema {
 		},
 		actionNotificationsC: {},
 
+		// operationsC holds actions that are run across a number of
+		// units in batches by the operation runner.
+		operationsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "status"},
+			}},
+		},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -420,6 +467,15 @@ func allCollections() collectionSchema {
 		// unit relation settings, model config, etc etc etc.
 		settingsC: {},
 
+		// settingsHistoryC holds recent revisions of application
+		// charm config and model config, so that changes can be
+		// reviewed and reverted.
+		settingsHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "globalkey", "revision"},
+			}},
+		},
+
 		constraintsC:        {},
 		storageConstraintsC: {},
 		deviceConstraintsC:  {},
@@ -535,6 +591,7 @@ const (
 	guimetadataC               = "guimetadata"
 	guisettingsC               = "guisettings"
 	instanceDataC              = "instanceData"
+	leaseHoldersC              = "leaseholders"
 	leasesC                    = "leases"
 	machinesC                  = "machines"
 	machineRemovalsC           = "machineremovals"
@@ -552,18 +609,23 @@ const (
 	modelsC                    = "models"
 	modelEntityRefsC           = "modelEntityRefs"
 	openedPortsC               = "openedPorts"
+	operationsC                = "operations"
 	payloadsC                  = "payloads"
 	permissionsC               = "permissions"
 	podSpecsC                  = "podSpecs"
 	providerIDsC               = "providerIDs"
+	quotasC                    = "quotas"
 	rebootC                    = "reboot"
 	relationScopesC            = "relationscopes"
 	relationsC                 = "relations"
 	restoreInfoC               = "restoreInfo"
+	secretMetadataC            = "secretMetadata"
+	secretValuesC              = "secretValues"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
 	endpointBindingsC          = "endpointbindings"
 	settingsC                  = "settings"
+	settingsHistoryC           = "settingsHistory"
 	refcountsC                 = "refcounts"
 	sshHostKeysC               = "sshhostkeys"
 	spacesC                    = "spaces"
@@ -581,11 +643,13 @@ const (
 	txnLogC                    = "txns.log"
 	txnsC                      = "txns"
 	unitsC                     = "units"
+	unitStatesC                = "unitstates"
 	upgradeInfoC               = "upgradeInfo"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
 	usersC                     = "users"
 	volumeAttachmentsC         = "volumeattachments"
+	volumeSnapshotsC           = "volumesnapshots"
 	volumesC                   = "volumes"
 	// "resources" (see resource/persistence/mongo.go)
 
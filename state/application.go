@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -29,6 +30,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state/presence"
 	"github.com/juju/juju/status"
@@ -44,25 +46,27 @@ type Application struct {
 // applicationDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string       `bson:"_id"`
-	Name                 string       `bson:"name"`
-	ModelUUID            string       `bson:"model-uuid"`
-	Series               string       `bson:"series"`
-	Subordinate          bool         `bson:"subordinate"`
-	CharmURL             *charm.URL   `bson:"charmurl"`
-	Channel              string       `bson:"cs-channel"`
-	CharmModifiedVersion int          `bson:"charmmodifiedversion"`
-	ForceCharm           bool         `bson:"forcecharm"`
-	Life                 Life         `bson:"life"`
-	UnitCount            int          `bson:"unitcount"`
-	RelationCount        int          `bson:"relationcount"`
-	Exposed              bool         `bson:"exposed"`
-	MinUnits             int          `bson:"minunits"`
-	DesiredScale         int          `bson:"scale"`
-	Tools                *tools.Tools `bson:",omitempty"`
-	TxnRevno             int64        `bson:"txn-revno"`
-	MetricCredentials    []byte       `bson:"metric-credentials"`
-	PasswordHash         string       `bson:"passwordhash"`
+	DocID                string                     `bson:"_id"`
+	Name                 string                     `bson:"name"`
+	ModelUUID            string                     `bson:"model-uuid"`
+	Series               string                     `bson:"series"`
+	Subordinate          bool                       `bson:"subordinate"`
+	CharmURL             *charm.URL                 `bson:"charmurl"`
+	Channel              string                     `bson:"cs-channel"`
+	CharmModifiedVersion int                        `bson:"charmmodifiedversion"`
+	ForceCharm           bool                       `bson:"forcecharm"`
+	CharmRollout         *charmRolloutDoc           `bson:"charm-rollout,omitempty"`
+	Life                 Life                       `bson:"life"`
+	UnitCount            int                        `bson:"unitcount"`
+	RelationCount        int                        `bson:"relationcount"`
+	Exposed              bool                       `bson:"exposed"`
+	ExposedEndpoints     map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
+	MinUnits             int                        `bson:"minunits"`
+	DesiredScale         int                        `bson:"scale"`
+	Tools                *tools.Tools               `bson:",omitempty"`
+	TxnRevno             int64                      `bson:"txn-revno"`
+	MetricCredentials    []byte                     `bson:"metric-credentials"`
+	PasswordHash         string                     `bson:"passwordhash"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -389,6 +393,13 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	}
 	ops = append(ops, removeOfferOps...)
 
+	// Schedule removal of any secrets owned by the application.
+	secretsOps, err := applicationSecretsCleanupOps(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretsOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -403,6 +414,27 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	// By the time we get to here, all units and charm refs have been removed,
 	// so it's safe to do this additional cleanup.
 	ops = append(ops, finalAppCharmRemoveOps(name, curl)...)
+	if rollout := a.doc.CharmRollout; rollout != nil {
+		// Likewise for the charm being rolled out.
+		rolloutOps, err := appCharmDecRefOps(a.st, name, rollout.CharmURL, false)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, rolloutOps...)
+		ops = append(ops, finalAppCharmRemoveOps(name, rollout.CharmURL)...)
+	}
+
+	// Remove the application's shared storage instances. The units,
+	// and so their storage attachments, have been removed already.
+	sb, err := NewStorageBackend(a.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageInstanceOps, err := removeStorageInstancesOps(sb, a.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, storageInstanceOps...)
 
 	ops = append(ops, a.removeCloudServiceOps()...)
 	globalKey := a.globalKey()
@@ -416,6 +448,11 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeModelApplicationRefOp(a.st, name),
 		removePodSpecOp(a.ApplicationTag()),
 	)
+	historyOps, err := removeSettingsHistoryOps(a.st, globalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, historyOps...)
 	return ops, nil
 }
 
@@ -432,23 +469,137 @@ func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the application.
+// ClearExposed removes the exposed flag from the application, along
+// with any per-endpoint expose settings.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
 	return a.setExposed(false)
 }
 
 func (a *Application) setExposed(exposed bool) (err error) {
+	update := bson.D{{"$set", bson.D{{"exposed", exposed}}}}
+	if !exposed {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"exposed-endpoints", 1}}})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, applicationNotAliveErr))
 	}
 	a.doc.Exposed = exposed
+	if !exposed {
+		a.doc.ExposedEndpoints = nil
+	}
+	return nil
+}
+
+// WildcardEndpoint is the key used in an application's exposed endpoints
+// for settings that apply to all of the application's endpoints that
+// don't have settings of their own.
+const WildcardEndpoint = ""
+
+// ExposedEndpoint describes who may access the ports opened by an
+// exposed application's units on a particular endpoint.
+type ExposedEndpoint struct {
+	// ExposeToSpaces holds the names of the spaces whose subnets
+	// may access the ports.
+	ExposeToSpaces []string `bson:"to-spaces,omitempty"`
+
+	// ExposeToCIDRs holds the CIDRs that may access the ports.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
+// AllowsAccessFromAnywhere reports whether the settings do not
+// restrict access to any spaces or CIDRs.
+func (e ExposedEndpoint) AllowsAccessFromAnywhere() bool {
+	return len(e.ExposeToSpaces) == 0 && len(e.ExposeToCIDRs) == 0
+}
+
+// ExposedEndpoints returns the per-endpoint expose settings of the
+// application. Settings for the WildcardEndpoint apply to all endpoints
+// without settings of their own. If the application is exposed but has
+// no expose settings, its ports are accessible from anywhere.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint, len(a.doc.ExposedEndpoints))
+	for name, settings := range a.doc.ExposedEndpoints {
+		result[name] = settings
+	}
+	return result
+}
+
+// MergeExposeSettings marks the application as exposed and records the
+// given expose settings, replacing any existing settings for the same
+// endpoints. Settings for other endpoints are left unchanged.
+func (a *Application) MergeExposeSettings(exposedEndpoints map[string]ExposedEndpoint) error {
+	if err := a.validateExposedEndpoints(exposedEndpoints); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", a)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		merged := a.ExposedEndpoints()
+		if merged == nil {
+			merged = make(map[string]ExposedEndpoint)
+		}
+		for name, settings := range exposedEndpoints {
+			merged[name] = settings
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", a.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"exposed", true},
+				{"exposed-endpoints", merged},
+			}}},
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", a)
+	}
+	return a.Refresh()
+}
+
+// validateExposedEndpoints checks that the given expose settings refer
+// to endpoints of the application's charm and to known spaces, and that
+// all the CIDRs are valid.
+func (a *Application) validateExposedEndpoints(exposedEndpoints map[string]ExposedEndpoint) error {
+	eps, err := a.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	known := set.NewStrings(WildcardEndpoint)
+	for _, ep := range eps {
+		known.Add(ep.Name)
+	}
+	for name, settings := range exposedEndpoints {
+		if !known.Contains(name) {
+			return errors.NotValidf("endpoint %q", name)
+		}
+		for _, spaceName := range settings.ExposeToSpaces {
+			if _, err := a.st.Space(spaceName); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, cidr := range settings.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+	}
 	return nil
 }
 
@@ -638,6 +789,12 @@ func (a *Application) checkStorageUpgrade(newMeta, oldMeta *charm.Meta, units []
 	for name, newStorageMeta := range newMeta.Storage {
 		oldStorageMeta, ok := oldMeta.Storage[name]
 		if !ok {
+			if newStorageMeta.Shared && newStorageMeta.CountMin > 0 {
+				// Shared storage is only created along with
+				// the application, so it cannot be required
+				// by an upgrade.
+				return nil, errors.Errorf("required shared storage %q added", name)
+			}
 			continue
 		}
 		if newStorageMeta.Type != oldStorageMeta.Type {
@@ -688,7 +845,9 @@ func (a *Application) checkStorageUpgrade(newMeta, oldMeta *charm.Meta, units []
 }
 
 // changeCharmOps returns the operations necessary to set a application's
-// charm URL to a new value.
+// charm URL to a new value. They do not change the application's charm
+// modified version; callers that need the units to run the upgrade-charm
+// hook must add incCharmModifiedVersionOps as well.
 func (a *Application) changeCharmOps(
 	ch *Charm,
 	channel string,
@@ -697,36 +856,17 @@ func (a *Application) changeCharmOps(
 	resourceIDs map[string]string,
 	updatedStorageConstraints map[string]StorageConstraints,
 ) ([]txn.Op, error) {
-	// Build the new application config from what can be used of the old one.
-	var newSettings charm.Settings
-	oldKey, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
-	if err == nil {
-		// Filter the old settings through to get the new settings.
-		newSettings = ch.Config().FilterSettings(oldKey.Map())
-		for k, v := range updatedSettings {
-			newSettings[k] = v
-		}
-	} else if errors.IsNotFound(err) {
-		// No old settings, start with the updated settings.
-		newSettings = updatedSettings
-	} else {
-		return nil, errors.Trace(err)
+	// Any charm being rolled out to the units must be the one the
+	// application is changing to; changing the charm completes the
+	// rollout.
+	rollout := a.doc.CharmRollout
+	if rollout != nil && *rollout.CharmURL != *ch.URL() {
+		return nil, errors.Errorf("charm %q is being rolled out to the units", rollout.CharmURL)
 	}
 
-	// Create or replace application settings.
-	var settingsOp txn.Op
-	newSettingsKey := applicationCharmConfigKey(a.doc.Name, ch.URL())
-	if _, err := readSettings(a.st.db(), settingsC, newSettingsKey); errors.IsNotFound(err) {
-		// No settings for this key yet, create it.
-		settingsOp = createSettingsOp(settingsC, newSettingsKey, newSettings)
-	} else if err != nil {
+	oldKey, settingsOp, err := a.newCharmSettingsOps(ch, updatedSettings)
+	if err != nil {
 		return nil, errors.Trace(err)
-	} else {
-		// Settings exist, just replace them with the new ones.
-		settingsOp, _, err = replaceSettingsOp(a.st.db(), settingsC, newSettingsKey, newSettings)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
 	}
 
 	// Make sure no units are added or removed while the upgrade
@@ -745,6 +885,10 @@ func (a *Application) changeCharmOps(
 			Id:     u.doc.DocID,
 			Assert: txn.DocExists,
 		}
+		if rollout != nil {
+			// All units follow the application's charm again.
+			unitOps[i].Update = bson.D{{"$unset", bson.D{{"target-charmurl", nil}}}}
+		}
 	}
 	unitOps = append(unitOps, txn.Op{
 		C:      applicationsC,
@@ -758,10 +902,14 @@ func (a *Application) changeCharmOps(
 	}
 
 	// Add or create a reference to the new charm, settings,
-	// and storage constraints docs.
-	incOps, err := appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
-	if err != nil {
-		return nil, errors.Trace(err)
+	// and storage constraints docs. A rollout already holds a
+	// reference, which passes to the application.
+	var incOps []txn.Op
+	if rollout == nil {
+		incOps, err = appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	var decOps []txn.Op
 	// Drop the references to the old settings, storage constraints,
@@ -781,26 +929,30 @@ func (a *Application) changeCharmOps(
 	}
 	ops = append(ops, unitOps...)
 	ops = append(ops, incOps...)
+	charmUpdate := bson.D{{"$set", bson.D{
+		{"charmurl", ch.URL()},
+		{"cs-channel", channel},
+		{"forcecharm", forceUnits},
+	}}}
+	if rollout != nil {
+		charmUpdate = append(charmUpdate, bson.DocElem{
+			"$unset", bson.D{{"charm-rollout", nil}},
+		})
+	}
 	ops = append(ops, []txn.Op{
 		// Create or replace new settings.
 		settingsOp,
 		// Update the charm URL and force flag (if relevant).
 		{
-			C:  applicationsC,
-			Id: a.doc.DocID,
-			Update: bson.D{{"$set", bson.D{
-				{"charmurl", ch.URL()},
-				{"cs-channel", channel},
-				{"forcecharm", forceUnits},
-			}}},
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Update: charmUpdate,
 		},
 	}...)
 	ops = append(ops, storageConstraintsOps...)
 	ops = append(ops, checkStorageOps...)
 	ops = append(ops, upgradeStorageOps...)
 
-	ops = append(ops, incCharmModifiedVersionOps(a.doc.DocID)...)
-
 	// Add any extra peer relations that need creation.
 	newPeers := a.extraPeerRelations(ch.Meta())
 	peerOps, err := a.st.addPeerRelationsOps(a.doc.Name, newPeers)
@@ -858,6 +1010,49 @@ func (a *Application) changeCharmOps(
 	return append(ops, decOps...), nil
 }
 
+// newCharmSettingsOps returns the application's current charm settings,
+// if any, and an operation to create or replace the settings for the
+// new charm. The new settings are those of the current settings that
+// the new charm understands, updated with the given settings.
+func (a *Application) newCharmSettingsOps(ch *Charm, updatedSettings charm.Settings) (*Settings, txn.Op, error) {
+	fail := func(err error) (*Settings, txn.Op, error) {
+		return nil, txn.Op{}, errors.Trace(err)
+	}
+
+	// Build the new application config from what can be used of the old one.
+	var newSettings charm.Settings
+	oldKey, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
+	if err == nil {
+		// Filter the old settings through to get the new settings.
+		newSettings = ch.Config().FilterSettings(oldKey.Map())
+		for k, v := range updatedSettings {
+			newSettings[k] = v
+		}
+	} else if errors.IsNotFound(err) {
+		// No old settings, start with the updated settings.
+		newSettings = updatedSettings
+	} else {
+		return fail(err)
+	}
+
+	// Create or replace application settings.
+	var settingsOp txn.Op
+	newSettingsKey := applicationCharmConfigKey(a.doc.Name, ch.URL())
+	if _, err := readSettings(a.st.db(), settingsC, newSettingsKey); errors.IsNotFound(err) {
+		// No settings for this key yet, create it.
+		settingsOp = createSettingsOp(settingsC, newSettingsKey, newSettings)
+	} else if err != nil {
+		return fail(err)
+	} else {
+		// Settings exist, just replace them with the new ones.
+		settingsOp, _, err = replaceSettingsOp(a.st.db(), settingsC, newSettingsKey, newSettings)
+		if err != nil {
+			return fail(err)
+		}
+	}
+	return oldKey, settingsOp, nil
+}
+
 func (a *Application) newCharmStorageOps(
 	ch *Charm,
 	units []*Unit,
@@ -948,6 +1143,11 @@ func (a *Application) upgradeStorageOps(
 	// many instances as are specified in the storage constraints.
 	var ops []txn.Op
 	for name, cons := range allStorageCons {
+		if meta.Storage[name].Shared {
+			// Shared storage is owned by the application,
+			// and is never created for individual units.
+			continue
+		}
 		for _, u := range units {
 			countMin := meta.Storage[name].CountMin
 			if _, ok := oldMeta.Storage[name]; !ok {
@@ -1020,9 +1220,26 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// Units holds the names of units to upgrade to the new charm
+	// straight away. If Units or MaxUnavailable is set, the charm is
+	// rolled out to the units, and the application's charm is only
+	// changed once all of its units are running the new charm.
+	Units []string
+
+	// MaxUnavailable, if positive, rolls the new charm out to the rest
+	// of the application's units, upgrading no more than this many of
+	// them at once.
+	MaxUnavailable int
 }
 
 // SetCharm changes the charm for the application.
+//
+// If units or a maximum number of unavailable units are configured,
+// the charm is rolled out to the units instead, and the application's
+// charm is changed once all of them are running it. Setting the same
+// charm again carries on with the rollout as newly configured; setting
+// it without any units or maximum completes the rollout straight away.
 func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	defer errors.DeferredAnnotatef(
 		&err, "cannot upgrade application %q to charm %q", a, cfg.Charm,
@@ -1085,6 +1302,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	}
 
 	var newCharmModifiedVersion int
+	var rollingOut bool
 	channel := string(cfg.Channel)
 	acopy := &Application{a.st, a.doc}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1118,6 +1336,12 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			}),
 		}}
 
+		rollout := a.doc.CharmRollout
+		if rollout != nil && *rollout.CharmURL != *cfg.Charm.URL() {
+			return nil, errors.Errorf("charm %q is being rolled out to the units; roll it back first", rollout.CharmURL)
+		}
+		rollingOut = false
+
 		if a.doc.CharmURL.String() == cfg.Charm.URL().String() {
 			// Charm URL already set; just update the force flag and channel.
 			ops = append(ops, txn.Op{
@@ -1128,19 +1352,33 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 					{"forcecharm", cfg.ForceUnits},
 				}}},
 			})
+		} else if len(cfg.Units) > 0 || cfg.MaxUnavailable > 0 {
+			chng, err := a.charmRolloutOps(cfg, updatedSettings)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, chng...)
+			rollingOut = true
 		} else {
+			settings, storageConstraints := updatedSettings, cfg.StorageConstraints
+			if rollout != nil {
+				// Changing the charm completes the rollout, so apply
+				// the updates given when it started as well.
+				settings, storageConstraints = rollout.completionConfig(settings, storageConstraints)
+			}
 			chng, err := a.changeCharmOps(
 				cfg.Charm,
 				channel,
-				updatedSettings,
+				settings,
 				cfg.ForceUnits,
 				cfg.ResourceIDs,
-				cfg.StorageConstraints,
+				storageConstraints,
 			)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
 			ops = append(ops, chng...)
+			ops = append(ops, incCharmModifiedVersionOps(a.doc.DocID)...)
 			newCharmModifiedVersion++
 		}
 
@@ -1149,10 +1387,16 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	if err := a.st.db().Run(buildTxn); err != nil {
 		return err
 	}
+	if rollingOut {
+		// Start upgrading units, or change the application's charm
+		// if they're all running it already.
+		return errors.Trace(a.advanceCharmRollout())
+	}
 	a.doc.CharmURL = cfg.Charm.URL()
 	a.doc.Channel = channel
 	a.doc.ForceCharm = cfg.ForceUnits
 	a.doc.CharmModifiedVersion = newCharmModifiedVersion
+	a.doc.CharmRollout = nil
 	return nil
 }
 
@@ -1320,50 +1564,78 @@ func (a *Application) newUnitName() (string, error) {
 }
 
 // addUnitOps returns a unique name for a new unit, and a list of txn operations
-// necessary to create that unit. The principalName param must be non-empty if
-// and only if s is a subordinate application. Only one subordinate of a given
-// application will be assigned to a given principal. The asserts param can be used
-// to include additional assertions for the application document.  This method
-// assumes that the application already exists in the db.
+// necessary to create that unit and charge it to the model's quota. The
+// principalName param must be non-empty if and only if s is a subordinate
+// application. Only one subordinate of a given application will be assigned
+// to a given principal. The asserts param can be used to include additional
+// assertions for the application document.  This method assumes that the
+// application already exists in the db.
 func (a *Application) addUnitOps(
 	principalName string,
 	args AddUnitParams,
 	asserts bson.D,
 ) (string, []txn.Op, error) {
+	name, ops, usage, err := a.newUnitOps(principalName, args, asserts)
+	if err != nil {
+		return name, ops, err
+	}
+	chargeOps, err := quotaOps(a.st, usage)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return name, append(ops, chargeOps...), nil
+}
+
+// newUnitOps is like addUnitOps, but does not charge the unit to the
+// model's quota; it returns the resources the unit will use instead.
+func (a *Application) newUnitOps(
+	principalName string,
+	args AddUnitParams,
+	asserts bson.D,
+) (string, []txn.Op, quota.Usage, error) {
 	var cons constraints.Value
 	if !a.doc.Subordinate {
 		scons, err := a.Constraints()
 		if errors.IsNotFound(err) {
-			return "", nil, errors.NotFoundf("application %q", a.Name())
+			return "", nil, nil, errors.NotFoundf("application %q", a.Name())
 		}
 		if err != nil {
-			return "", nil, err
+			return "", nil, nil, err
 		}
 		cons, err = a.st.resolveConstraints(scons)
 		if err != nil {
-			return "", nil, err
+			return "", nil, nil, err
 		}
 	}
 	storageCons, err := a.StorageConstraints()
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, err
+	}
+	sharedStorage, err := a.sharedStorageInstances()
+	if err != nil {
+		return "", nil, nil, err
 	}
 	names, ops, err := a.addUnitOpsWithCons(applicationAddUnitOpsArgs{
 		cons:          cons,
 		principalName: principalName,
 		storageCons:   storageCons,
 		attachStorage: args.AttachStorage,
+		sharedStorage: sharedStorage,
 		providerId:    args.ProviderId,
 		address:       args.Address,
 		ports:         args.Ports,
 	})
 	if err != nil {
-		return names, ops, err
+		return names, ops, nil, err
+	}
+	ch, _, err := a.Charm()
+	if err != nil {
+		return "", nil, nil, errors.Trace(err)
 	}
 	// we verify the application is alive
 	asserts = append(isAliveDoc, asserts...)
 	ops = append(ops, a.incUnitCountOp(asserts))
-	return names, ops, err
+	return names, ops, unitsQuotaUsage(1, ch.Meta(), storageCons), nil
 }
 
 type applicationAddUnitOpsArgs struct {
@@ -1372,6 +1644,10 @@ type applicationAddUnitOpsArgs struct {
 	storageCons   map[string]StorageConstraints
 	attachStorage []names.StorageTag
 
+	// sharedStorage holds the application's shared storage
+	// instances, which are attached to every unit.
+	sharedStorage []*storageInstance
+
 	// These optional attributes are relevant to CAAS models.
 	providerId *string
 	address    *string
@@ -1591,6 +1867,25 @@ func (a *Application) addUnitStorageOps(
 		numStorageAttachments++
 		storageTags[si.StorageName()] = append(storageTags[si.StorageName()], storageTag)
 	}
+	for _, si := range args.sharedStorage {
+		// Shared storage remains owned by the application,
+		// so the unit's storage refcounts are unaffected.
+		ops, err := sb.attachStorageOps(
+			si,
+			unitTag,
+			a.doc.Series,
+			charm,
+			machineAssignable,
+		)
+		if err != nil {
+			return nil, -1, errors.Annotatef(
+				err, "attaching shared %s",
+				names.ReadableString(si.StorageTag()),
+			)
+		}
+		storageOps = append(storageOps, ops...)
+		numStorageAttachments++
+	}
 	for name, tags := range storageTags {
 		count := len(tags)
 		charmStorage := charm.Meta().Storage[name]
@@ -1606,6 +1901,64 @@ func (a *Application) addUnitStorageOps(
 	return storageOps, numStorageAttachments, nil
 }
 
+// sharedStorageInstances returns the application's live shared
+// storage instances.
+func (a *Application) sharedStorageInstances() ([]*storageInstance, error) {
+	sb, err := NewStorageBackend(a.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	all, err := sb.storageInstances(bson.D{{"owner", a.Tag().String()}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var alive []*storageInstance
+	for _, si := range all {
+		if si.Life() == Alive {
+			alive = append(alive, si)
+		}
+	}
+	return alive, nil
+}
+
+// addSharedStorageOps returns txn.Ops to create the shared storage instances
+// of a new application, along with the instances so that they can be attached
+// to units added in the same transaction.
+func (a *Application) addSharedStorageOps(
+	charmMeta *charm.Meta,
+	storageCons map[string]StorageConstraints,
+) ([]txn.Op, []*storageInstance, error) {
+	sb, err := NewStorageBackend(a.st)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	ops, storageTags, _, err := createStorageOps(
+		sb, a.ApplicationTag(), charmMeta, storageCons, a.doc.Series, nil,
+	)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var instances []*storageInstance
+	for name, tags := range storageTags {
+		incRefOp, err := increfEntityStorageOp(a.st, a.Tag(), name, len(tags))
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		ops = append(ops, incRefOp)
+		for _, tag := range tags {
+			// The instances do not exist in state until the
+			// transaction is run, so we describe them here.
+			instances = append(instances, &storageInstance{sb, storageInstanceDoc{
+				Id:          tag.Id(),
+				Kind:        StorageKindFilesystem,
+				Owner:       a.Tag().String(),
+				StorageName: name,
+			}})
+		}
+	}
+	return ops, instances, nil
+}
+
 // applicationOffersRefCountKey returns a key for refcounting offers
 // for the specified application. Each time an offer is created, the
 // refcount is incremented, and the opposite happens on removal.
@@ -1678,19 +2031,23 @@ type AddUnitParams struct {
 // AddUnit adds a new principal unit to the application.
 func (a *Application) AddUnit(args AddUnitParams) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to application %q", a)
-	name, ops, err := a.addUnitOps("", args, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
-		if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
-			return nil, err
-		} else if !alive {
-			return nil, applicationNotAliveErr
+	var name string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			// The unit may have been charged to the quota
+			// concurrently with others.
+			if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+				return nil, err
+			} else if !alive {
+				return nil, applicationNotAliveErr
+			}
 		}
-		return nil, errors.New("inconsistent state")
-	} else if err != nil {
+		var ops []txn.Op
+		var err error
+		name, ops, err = a.addUnitOps("", args, nil)
+		return ops, err
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
 		return nil, err
 	}
 	return a.st.Unit(name)
@@ -1728,6 +2085,7 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, u.globalKey()),
 		removeConstraintsOp(u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
+		removeUnitStateOp(a.st, u.globalKey()),
 		newCleanupOp(cleanupRemovedUnit, u.doc.Name),
 	}
 	ops = append(ops, portsOps...)
@@ -1883,31 +2241,105 @@ func (a *Application) CharmConfig() (charm.Settings, error) {
 // UpdateCharmConfig changes a application's charm config settings. Values set
 // to nil will be deleted; unknown and invalid values will return an error.
 func (a *Application) UpdateCharmConfig(changes charm.Settings) error {
-	charm, _, err := a.Charm()
+	return a.UpdateCharmConfigBy("", changes)
+}
+
+// UpdateCharmConfigBy is like UpdateCharmConfig, but records the change
+// in the application's config history as made by the named user.
+func (a *Application) UpdateCharmConfigBy(author string, changes charm.Settings) error {
+	ch, _, err := a.Charm()
 	if err != nil {
 		return err
 	}
-	changes, err = charm.Config().ValidateSettings(changes)
+	changes, err = ch.Config().ValidateSettings(changes)
 	if err != nil {
 		return err
 	}
+	return a.updateCharmConfig(func(map[string]interface{}) (charm.Settings, error) {
+		return changes, nil
+	}, author)
+}
+
+// CharmConfigHistory returns the recorded revisions of the application's
+// charm config, oldest first. Only the most recent revisions are kept,
+// and the history is not carried over when the model is migrated.
+func (a *Application) CharmConfigHistory() ([]SettingsRevision, error) {
+	return settingsHistory(a.st, a.globalKey())
+}
+
+// RevertCharmConfig restores the application's charm config to the values
+// it had at the given revision. Settings that are not defined by the
+// current charm are ignored. The change is recorded as a new revision,
+// made by the named user.
+func (a *Application) RevertCharmConfig(revision int, author string) error {
+	rev, err := settingsRevision(a.st, a.globalKey(), revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := a.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	options := ch.Config().Options
+	err = a.updateCharmConfig(func(current map[string]interface{}) (charm.Settings, error) {
+		changes := make(charm.Settings)
+		for name := range current {
+			if _, ok := rev.Settings[name]; !ok {
+				changes[name] = nil
+			}
+		}
+		for name, value := range rev.Settings {
+			if _, ok := options[name]; ok {
+				changes[name] = value
+			}
+		}
+		return ch.Config().ValidateSettings(changes)
+	}, author)
+	return errors.Annotatef(err, "reverting to revision %d", revision)
+}
+
+// updateCharmConfig applies the changes returned by getChanges to the
+// application's charm config, and records the result in the application's
+// config history, in a single transaction.
+func (a *Application) updateCharmConfig(
+	getChanges func(current map[string]interface{}) (charm.Settings, error),
+	author string,
+) error {
 	// TODO(fwereade) state.Settings is itself really problematic in just
 	// about every use case. This needs to be resolved some time; but at
 	// least the settings docs are keyed by charm url as well as application
 	// name, so the actual impact of a race is non-threatening.
-	node, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
-	if err != nil {
-		return err
-	}
-	for name, value := range changes {
-		if value == nil {
-			node.Delete(name)
-		} else {
-			node.Set(name, value)
+	buildTxn := func(int) ([]txn.Op, error) {
+		node, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
+		if err != nil {
+			return nil, err
 		}
+		old := node.Map()
+		changes, err := getChanges(old)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range changes {
+			if value == nil {
+				node.Delete(name)
+			} else {
+				node.Set(name, value)
+			}
+		}
+		_, ops := node.settingsUpdateOps()
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		// The history records the complete settings, so they must
+		// not have changed since they were read.
+		ops[0].Assert = bson.D{{"version", node.version}}
+		historyOps, err := addSettingsRevisionOps(a.st, a.globalKey(), old, node.Map(), author)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, historyOps...), nil
 	}
-	_, err = node.Write()
-	return err
+	return a.st.db().Run(buildTxn)
 }
 
 // ApplicationConfig returns the configuration for the application itself.
@@ -2417,8 +2849,33 @@ func (op *UpdateUnitsOperation) allOps() []ModelOperation {
 func (op *UpdateUnitsOperation) Build(attempt int) ([]txn.Op, error) {
 	var ops []txn.Op
 
-	all := op.allOps()
-	for _, op := range all {
+	// The added units are charged to the quota together, as a
+	// transaction may only update it once.
+	requested := make(quota.Usage)
+	for _, add := range op.Adds {
+		addOps, usage, err := add.build()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, addOps...)
+		addQuotaUsage(requested, usage)
+	}
+	if len(op.Adds) > 0 {
+		chargeOps, err := quotaOps(op.Adds[0].application.st, requested)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, chargeOps...)
+	}
+
+	var others []ModelOperation
+	for _, mop := range op.Updates {
+		others = append(others, mop)
+	}
+	for _, mop := range op.Deletes {
+		others = append(others, mop)
+	}
+	for _, op := range others {
 		switch nextOps, err := op.Build(attempt); err {
 		case jujutxn.ErrNoOperations:
 			continue
@@ -2463,10 +2920,24 @@ type AddUnitOperation struct {
 
 // Build is part of the ModelOperation interface.
 func (op *AddUnitOperation) Build(attempt int) ([]txn.Op, error) {
+	ops, usage, err := op.build()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	chargeOps, err := quotaOps(op.application.st, usage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, chargeOps...), nil
+}
+
+// build returns the operations that add the unit, without charging
+// it to the model's quota, and the resources the unit will use.
+func (op *AddUnitOperation) build() ([]txn.Op, quota.Usage, error) {
 	if alive, err := isAlive(op.application.st, applicationsC, op.application.doc.DocID); err != nil {
-		return nil, err
+		return nil, nil, err
 	} else if !alive {
-		return nil, applicationNotAliveErr
+		return nil, nil, applicationNotAliveErr
 	}
 
 	var ops []txn.Op
@@ -2476,14 +2947,14 @@ func (op *AddUnitOperation) Build(attempt int) ([]txn.Op, error) {
 		Address:    op.props.Address,
 		Ports:      op.props.Ports,
 	}
-	name, addOps, err := op.application.addUnitOps("", addUnitArgs, nil)
+	name, addOps, usage, err := op.application.newUnitOps("", addUnitArgs, nil)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	op.unitName = name
 	ops = append(ops, addOps...)
 
-	return ops, nil
+	return ops, usage, nil
 }
 
 // Done is part of the ModelOperation interface.
@@ -961,6 +961,95 @@ func (s *ApplicationSuite) TestUpdateCharmConfig(c *gc.C) {
 	}
 }
 
+func (s *ApplicationSuite) TestCharmConfigHistory(c *gc.C) {
+	sch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy-application", sch)
+	history, err := app.CharmConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+
+	err = app.UpdateCharmConfigBy("fred", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateCharmConfigBy("mary", charm.Settings{"title": nil, "outlook": "good"})
+	c.Assert(err, jc.ErrorIsNil)
+	// A change that leaves the settings as they are isn't recorded.
+	err = app.UpdateCharmConfigBy("mary", charm.Settings{"outlook": "good"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err = app.CharmConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Check(history[0].Revision, gc.Equals, 1)
+	c.Check(history[0].Settings, gc.HasLen, 0)
+	c.Check(history[0].Author, gc.Equals, "")
+	c.Check(history[1].Revision, gc.Equals, 2)
+	c.Check(history[1].Settings, jc.DeepEquals, map[string]interface{}{"title": "foo"})
+	c.Check(history[1].Author, gc.Equals, "fred")
+	c.Check(history[2].Revision, gc.Equals, 3)
+	c.Check(history[2].Settings, jc.DeepEquals, map[string]interface{}{"outlook": "good"})
+	c.Check(history[2].Author, gc.Equals, "mary")
+}
+
+func (s *ApplicationSuite) TestCharmConfigHistoryBounded(c *gc.C) {
+	sch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy-application", sch)
+	for i := 0; i < 25; i++ {
+		err := app.UpdateCharmConfig(charm.Settings{"title": fmt.Sprintf("title-%d", i)})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	history, err := app.CharmConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 20)
+	c.Assert(history[0].Revision, gc.Equals, 7)
+	c.Assert(history[19].Revision, gc.Equals, 26)
+	c.Assert(history[19].Settings, jc.DeepEquals, map[string]interface{}{"title": "title-24"})
+}
+
+func (s *ApplicationSuite) TestRevertCharmConfig(c *gc.C) {
+	sch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy-application", sch)
+	err := app.UpdateCharmConfigBy("fred", charm.Settings{"title": "foo", "username": "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateCharmConfigBy("fred", charm.Settings{"title": "bar", "username": nil, "outlook": "good"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.RevertCharmConfig(2, "mary")
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := app.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, s.combinedSettings(sch, charm.Settings{
+		"title":    "foo",
+		"username": "bob",
+	}))
+
+	// The revert is itself recorded as a new revision.
+	history, err := app.CharmConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 4)
+	c.Assert(history[3].Author, gc.Equals, "mary")
+	c.Assert(history[3].Settings, jc.DeepEquals, history[1].Settings)
+}
+
+func (s *ApplicationSuite) TestRevertCharmConfigUnknownRevision(c *gc.C) {
+	err := s.mysql.RevertCharmConfig(3, "fred")
+	c.Assert(err, gc.ErrorMatches, "settings revision 3 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationSuite) TestDestroyRemovesCharmConfigHistory(c *gc.C) {
+	sch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy-application", sch)
+	err := app.UpdateCharmConfigBy("fred", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app = s.AddTestingApplication(c, "dummy-application", sch)
+	history, err := app.CharmConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
 func (s *ApplicationSuite) TestUpdateApplicationSeries(c *gc.C) {
 	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
 	app := state.AddTestingApplicationForSeries(c, s.State, "precise", "multi-series", ch)
@@ -1815,6 +1904,60 @@ func (s *ApplicationSuite) TestApplicationExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestMergeExposeSettings(c *gc.C) {
+	_, err := s.State.AddSpace("admin", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server-admin": {ExposeToSpaces: []string{"admin"}, ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := map[string]state.ExposedEndpoint{
+		"server":       {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+		"server-admin": {ExposeToSpaces: []string{"admin"}, ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	}
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, expected)
+
+	// Unexposing the application discards its expose settings.
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsFalse)
+	c.Assert(app.ExposedEndpoints(), gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	for i, test := range []struct {
+		settings map[string]state.ExposedEndpoint
+		err      string
+	}{{
+		settings: map[string]state.ExposedEndpoint{"bogus": {}},
+		err:      `cannot expose application "mysql": endpoint "bogus" not valid`,
+	}, {
+		settings: map[string]state.ExposedEndpoint{"": {ExposeToSpaces: []string{"missing"}}},
+		err:      `cannot expose application "mysql": space "missing" not found`,
+	}, {
+		settings: map[string]state.ExposedEndpoint{"server": {ExposeToCIDRs: []string{"10.0.0.0"}}},
+		err:      `cannot expose application "mysql": CIDR "10.0.0.0" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.mysql.MergeExposeSettings(test.settings)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
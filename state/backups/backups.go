@@ -110,6 +110,14 @@ type Backups interface {
 
 type backups struct {
 	storage filestorage.FileStorage
+
+	// target, if set, is where new archives are written instead of
+	// the file storage; their metadata remains in the file storage.
+	target Target
+
+	// openTarget, if set, opens the targets recorded in existing
+	// backups' metadata.
+	openTarget TargetOpener
 }
 
 // NewBackups creates a new Backups value using the FileStorage provided.
@@ -120,6 +128,19 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 	return &b
 }
 
+// NewBackupsWithTargets creates a new Backups value that keeps backup
+// metadata in the FileStorage provided. Archives for new backups are
+// written to target, or to the FileStorage if target is nil. Archives
+// for existing backups are found using openTarget.
+func NewBackupsWithTargets(stor filestorage.FileStorage, target Target, openTarget TargetOpener) Backups {
+	b := backups{
+		storage:    stor,
+		target:     target,
+		openTarget: openTarget,
+	}
+	return &b
+}
+
 // Create creates and stores a new juju backup archive (based on arguments)
 // and updates the provided metadata.  A filename to download the backup is provided.
 func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error) {
@@ -161,7 +182,12 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	}
 
 	// Store the archive if asked by user
-	if keepCopy {
+	if keepCopy && b.target != nil {
+		err = storeArchiveInTarget(b.storage, b.target, meta, result.archiveFile)
+		if err != nil {
+			return "", errors.Annotatef(err, "while storing backup archive in %s", b.target.Spec())
+		}
+	} else if keepCopy {
 		err = storeArchive(b.storage, meta, result.archiveFile)
 		if err != nil {
 			return "", errors.Annotate(err, "while storing backup archive")
@@ -171,6 +197,45 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	return result.filename, nil
 }
 
+// storeArchiveInTarget records the backup metadata in storage and
+// writes the archive to target under the backup's ID. The metadata is
+// removed again if the archive cannot be written.
+func storeArchiveInTarget(stor filestorage.FileStorage, target Target, meta *Metadata, file io.Reader) error {
+	meta.Target = target.Spec()
+	id, err := stor.Add(meta, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	meta.SetID(id)
+	if err := target.Put(ArchiveName(id), file, meta.Size()); err != nil {
+		if err2 := stor.Remove(id); err2 != nil {
+			logger.Errorf("removing metadata for unstored backup %q: %v", id, err2)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// targetMetadata returns the metadata for the backup with the given
+// ID if its archive was written to a target, or nil otherwise.
+func (b *backups) targetMetadata(id string) (*Metadata, error) {
+	if b.openTarget == nil {
+		return nil, nil
+	}
+	rawmeta, err := b.storage.Metadata(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, ok := rawmeta.(*Metadata)
+	if !ok {
+		return nil, errors.New("did not get a backups.Metadata value from storage")
+	}
+	if meta.Target == "" {
+		return nil, nil
+	}
+	return meta, nil
+}
+
 // Add stores the backup archive and returns its new ID.
 func (b *backups) Add(archive io.Reader, meta *Metadata) (string, error) {
 	// Store the archive.
@@ -183,12 +248,27 @@ func (b *backups) Add(archive io.Reader, meta *Metadata) (string, error) {
 }
 
 // Get retrieves the associated metadata and archive file from model storage.
-// There are two cases, the archive file can be in the juju database or
-// a file on the machine.
+// There are three cases, the archive file can be in the juju database,
+// in a backup target or a file on the machine.
 func (b *backups) Get(id string) (*Metadata, io.ReadCloser, error) {
 	if strings.Contains(id, TempFilename) {
 		return b.getArchiveFromFilename(id)
 	}
+	meta, err := b.targetMetadata(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if meta != nil {
+		target, err := b.openTarget(meta.Target)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		archiveFile, err := target.Get(ArchiveName(id))
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		return meta, archiveFile, nil
+	}
 	rawmeta, archiveFile, err := b.storage.Get(id)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -251,7 +331,21 @@ func (b *backups) List() ([]*Metadata, error) {
 	return result, nil
 }
 
-// Remove deletes the backup from storage.
+// Remove deletes the backup from storage, along with its archive if
+// that was written to a target.
 func (b *backups) Remove(id string) error {
+	meta, err := b.targetMetadata(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if meta != nil {
+		target, err := b.openTarget(meta.Target)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := target.Remove(ArchiveName(id)); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return errors.Trace(b.storage.Remove(id))
 }
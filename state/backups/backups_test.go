@@ -6,6 +6,7 @@ package backups_test
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
@@ -243,3 +244,138 @@ func (s *backupsSuite) TestGetFileName(c *gc.C) {
 	_, err = ioutil.ReadDir(backupDir)
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("open %s: no such file or directory", backupDir))
 }
+
+type fakeTarget struct {
+	archives map[string]string
+	err      error
+}
+
+func (t *fakeTarget) Spec() string {
+	return "file:/fake"
+}
+
+func (t *fakeTarget) Put(name string, archive io.Reader, size int64) error {
+	if t.err != nil {
+		return t.err
+	}
+	data, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return err
+	}
+	t.archives[name] = string(data)
+	return nil
+}
+
+func (t *fakeTarget) Get(name string) (io.ReadCloser, error) {
+	data, ok := t.archives[name]
+	if !ok {
+		return nil, errors.NotFoundf("archive %q", name)
+	}
+	return ioutil.NopCloser(bytes.NewBufferString(data)), nil
+}
+
+func (t *fakeTarget) Remove(name string) error {
+	delete(t.archives, name)
+	return nil
+}
+
+func (s *backupsSuite) newTargetBackups(c *gc.C) (backups.Backups, *fakeTarget) {
+	target := &fakeTarget{archives: make(map[string]string)}
+	api := backups.NewBackupsWithTargets(s.Storage, target, func(spec string) (backups.Target, error) {
+		c.Check(spec, gc.Equals, "file:/fake")
+		return target, nil
+	})
+	return api, target
+}
+
+func (s *backupsSuite) createWithTarget(c *gc.C, api backups.Backups) (*backups.Metadata, error) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 20, "<checksum>", backups.TempFilename)
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(string, *backups.Paths, string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(*backups.DBInfo) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+	s.Storage.ID = "spam"
+
+	paths := backups.Paths{BackupDir: c.MkDir(), DataDir: c.MkDir()}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt}
+	meta := backupstesting.NewMetadataStarted()
+	_, err := api.Create(meta, &paths, &dbInfo, true, true)
+	return meta, err
+}
+
+func (s *backupsSuite) TestCreateWithTarget(c *gc.C) {
+	api, target := s.newTargetBackups(c)
+	meta, err := s.createWithTarget(c, api)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the metadata goes into the file storage.
+	s.Storage.CheckCalled(c, "", meta, nil, "Add")
+	c.Check(meta.ID(), gc.Equals, "spam")
+	c.Check(meta.Target, gc.Equals, "file:/fake")
+	c.Check(meta.Stored(), gc.IsNil)
+	c.Check(target.archives, jc.DeepEquals, map[string]string{
+		"spam.tar.gz": "<compressed tarball>",
+	})
+}
+
+func (s *backupsSuite) TestCreateWithTargetPutFails(c *gc.C) {
+	api, target := s.newTargetBackups(c)
+	target.err = errors.New("bucket full")
+	_, err := s.createWithTarget(c, api)
+	c.Assert(err, gc.ErrorMatches, "while storing backup archive in file:/fake: bucket full")
+
+	// The metadata for the missing archive is removed again.
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"Add", "Remove"})
+	c.Check(s.Storage.IDArg, gc.Equals, "spam")
+}
+
+func (s *backupsSuite) TestGetFromTarget(c *gc.C) {
+	api, target := s.newTargetBackups(c)
+	target.archives["spam.tar.gz"] = "<compressed tarball>"
+	s.setStored("spam")
+	s.Storage.Meta.(*backups.Metadata).Target = "file:/fake"
+
+	meta, archive, err := api.Get("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+
+	c.Check(meta.ID(), gc.Equals, "spam")
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<compressed tarball>")
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"Metadata"})
+}
+
+func (s *backupsSuite) TestGetWithTargetsFromStorage(c *gc.C) {
+	api, _ := s.newTargetBackups(c)
+	s.setStored("spam")
+	s.Storage.File = ioutil.NopCloser(bytes.NewBufferString("<stored tarball>"))
+
+	_, archive, err := api.Get("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<stored tarball>")
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"Metadata", "Get"})
+}
+
+func (s *backupsSuite) TestRemoveFromTarget(c *gc.C) {
+	api, target := s.newTargetBackups(c)
+	target.archives["spam.tar.gz"] = "<compressed tarball>"
+	s.setStored("spam")
+	s.Storage.Meta.(*backups.Metadata).Target = "file:/fake"
+
+	err := api.Remove("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(target.archives, gc.HasLen, 0)
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"Metadata", "Remove"})
+	c.Check(s.Storage.IDArg, gc.Equals, "spam")
+}
@@ -68,6 +68,15 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Target is the backup target the archive was written to. It is
+	// empty if the archive is held in the controller's own storage.
+	Target string
+
+	// Scheduled records whether the backup was taken by the
+	// controller's backup schedule. Retention policies only apply
+	// to scheduled backups.
+	Scheduled bool
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -47,6 +47,9 @@ type storageMetaDoc struct {
 	Started  int64  `bson:"started,minsize"`
 	Finished int64  `bson:"finished,minsize"`
 	Notes    string `bson:"notes,omitempty"`
+	Target   string `bson:"target,omitempty"`
+
+	Scheduled bool `bson:"scheduled,omitempty"`
 
 	// origin
 
@@ -124,6 +127,8 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Target = doc.Target
+	meta.Scheduled = doc.Scheduled
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +180,8 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Target = meta.Target
+	doc.Scheduled = meta.Scheduled
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
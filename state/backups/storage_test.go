@@ -66,6 +66,8 @@ func (s *storageSuite) checkMeta(c *gc.C, meta, expected *backups.Metadata, id s
 		c.Check(meta.ID(), gc.Equals, id)
 	}
 	c.Check(meta.Notes, gc.Equals, expected.Notes)
+	c.Check(meta.Target, gc.Equals, expected.Target)
+	c.Check(meta.Scheduled, gc.Equals, expected.Scheduled)
 	c.Check(meta.Started.Unix(), gc.Equals, expected.Started.Unix())
 	c.Check(meta.Checksum(), gc.Equals, expected.Checksum())
 	c.Check(meta.ChecksumFormat(), gc.Equals, expected.ChecksumFormat())
@@ -118,6 +120,30 @@ func (s *storageSuite) TestAddBackupMetadataSuccess(c *gc.C) {
 	s.checkMeta(c, meta, original, id)
 }
 
+func (s *storageSuite) TestAddBackupMetadataTarget(c *gc.C) {
+	original := s.metadata(c)
+	original.Target = "file:/var/backups/juju"
+	id, err := backups.AddBackupMetadata(s.State, original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := backups.GetBackupMetadata(s.State, id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkMeta(c, meta, original, id)
+}
+
+func (s *storageSuite) TestAddBackupMetadataScheduled(c *gc.C) {
+	original := s.metadata(c)
+	original.Scheduled = true
+	id, err := backups.AddBackupMetadata(s.State, original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := backups.GetBackupMetadata(s.State, id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkMeta(c, meta, original, id)
+}
+
 func (s *storageSuite) TestAddBackupMetadataGeneratedID(c *gc.C) {
 	original := s.metadata(c)
 	original.SetID("spam")
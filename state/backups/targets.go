@@ -0,0 +1,197 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+
+	corebackups "github.com/juju/juju/core/backups"
+)
+
+// ScheduledBackupNotes is the note attached to backups taken by the
+// controller's backup schedule.
+const ScheduledBackupNotes = "scheduled backup"
+
+// ArchiveName returns the name under which the archive for the backup
+// with the given ID is written to a target.
+func ArchiveName(id string) string {
+	return id + ".tar.gz"
+}
+
+// Target is somewhere outside the controller's database that backup
+// archives can be written to.
+type Target interface {
+	// Spec returns the specification of the target, as accepted
+	// by ParseTargetSpec in core/backups.
+	Spec() string
+
+	// Put writes the archive with the given name and size.
+	Put(name string, archive io.Reader, size int64) error
+
+	// Get returns the contents of the named archive.
+	Get(name string) (io.ReadCloser, error)
+
+	// Remove deletes the named archive. It is not an error if
+	// the archive does not exist.
+	Remove(name string) error
+}
+
+// TargetOpener returns the Target for a specification.
+type TargetOpener func(spec string) (Target, error)
+
+// NewTargetOpener returns a TargetOpener that uses the given details
+// to connect to s3 targets.
+func NewTargetOpener(s3Config corebackups.S3Config) TargetOpener {
+	return func(spec string) (Target, error) {
+		targetSpec, err := corebackups.ParseTargetSpec(spec)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch targetSpec.Kind {
+		case corebackups.FileTarget:
+			return &dirTarget{spec: targetSpec}, nil
+		case corebackups.S3Target:
+			return newS3Target(targetSpec, s3Config)
+		}
+		return nil, errors.NotSupportedf("backup target %q", spec)
+	}
+}
+
+// NewControllerTargetOpener returns a TargetOpener that connects to s3
+// targets with the details in the controller's current configuration.
+func NewControllerTargetOpener(db DB) TargetOpener {
+	return func(spec string) (Target, error) {
+		controllerConfig, err := db.ControllerConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return NewTargetOpener(controllerConfig.BackupS3Config())(spec)
+	}
+}
+
+// dirTarget writes archives to a local directory.
+type dirTarget struct {
+	spec corebackups.TargetSpec
+}
+
+// Spec is part of the Target interface.
+func (t *dirTarget) Spec() string {
+	return t.spec.String()
+}
+
+// Put is part of the Target interface.
+func (t *dirTarget) Put(name string, archive io.Reader, size int64) error {
+	if err := os.MkdirAll(t.spec.Path, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	// Write to a temporary file first so a partially written archive
+	// is never mistaken for a complete one.
+	f, err := ioutil.TempFile(t.spec.Path, "."+name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(f.Name())
+	n, err := io.Copy(f, archive)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Annotatef(err, "writing %q", name)
+	}
+	if n != size {
+		return errors.Errorf("writing %q: wrote %d bytes, expected %d", name, n, size)
+	}
+	return errors.Trace(os.Rename(f.Name(), filepath.Join(t.spec.Path, name)))
+}
+
+// Get is part of the Target interface.
+func (t *dirTarget) Get(name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(t.spec.Path, name))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup archive %q in %s", name, t.Spec())
+	}
+	return f, errors.Trace(err)
+}
+
+// Remove is part of the Target interface.
+func (t *dirTarget) Remove(name string) error {
+	err := os.Remove(filepath.Join(t.spec.Path, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// s3Target writes archives to a bucket in an S3-compatible object
+// store. The bucket must already exist.
+type s3Target struct {
+	spec   corebackups.TargetSpec
+	bucket *s3.Bucket
+}
+
+func newS3Target(spec corebackups.TargetSpec, config corebackups.S3Config) (*s3Target, error) {
+	region := aws.Region{
+		Name:       config.Region,
+		S3Endpoint: config.Endpoint,
+	}
+	if region.S3Endpoint == "" {
+		known, ok := aws.Regions[config.Region]
+		if !ok {
+			return nil, errors.NotValidf("backup s3 region %q without an endpoint", config.Region)
+		}
+		region = known
+	}
+	auth := aws.Auth{
+		AccessKey: config.AccessKey,
+		SecretKey: config.SecretKey,
+	}
+	bucket, err := s3.New(auth, region).Bucket(spec.Bucket)
+	if err != nil {
+		return nil, errors.Annotatef(err, "opening bucket %q", spec.Bucket)
+	}
+	return &s3Target{spec: spec, bucket: bucket}, nil
+}
+
+// Spec is part of the Target interface.
+func (t *s3Target) Spec() string {
+	return t.spec.String()
+}
+
+// Put is part of the Target interface.
+func (t *s3Target) Put(name string, archive io.Reader, size int64) error {
+	err := t.bucket.PutReader(t.spec.Key(name), archive, size, "application/x-tar-gz", s3.Private)
+	return errors.Annotatef(err, "writing %q to %s", name, t.Spec())
+}
+
+// Get is part of the Target interface.
+func (t *s3Target) Get(name string) (io.ReadCloser, error) {
+	r, err := t.bucket.GetReader(t.spec.Key(name))
+	if isS3NotFound(err) {
+		return nil, errors.NotFoundf("backup archive %q in %s", name, t.Spec())
+	}
+	return r, errors.Annotatef(err, "reading %q from %s", name, t.Spec())
+}
+
+// Remove is part of the Target interface.
+func (t *s3Target) Remove(name string) error {
+	err := t.bucket.Del(t.spec.Key(name))
+	if isS3NotFound(err) {
+		return nil
+	}
+	return errors.Annotatef(err, "removing %q from %s", name, t.Spec())
+}
+
+func isS3NotFound(err error) bool {
+	if s3err, ok := err.(*s3.Error); ok {
+		return s3err.StatusCode == 404
+	}
+	return false
+}
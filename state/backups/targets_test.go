@@ -0,0 +1,123 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+	"gopkg.in/amz.v3/s3/s3test"
+	gc "gopkg.in/check.v1"
+
+	corebackups "github.com/juju/juju/core/backups"
+	"github.com/juju/juju/state/backups"
+)
+
+type targetsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&targetsSuite{})
+
+func (s *targetsSuite) TestOpenInvalidSpec(c *gc.C) {
+	_, err := backups.NewTargetOpener(corebackups.S3Config{})("ftp://example.com")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *targetsSuite) TestDirTarget(c *gc.C) {
+	dir := filepath.Join(c.MkDir(), "backups")
+	target, err := backups.NewTargetOpener(corebackups.S3Config{})("file:" + dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.Spec(), gc.Equals, "file:"+dir)
+
+	err = target.Put("spam.tar.gz", bytes.NewBufferString("archive"), 7)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := os.Stat(filepath.Join(dir, "spam.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	r, err := target.Get("spam.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "archive")
+
+	err = target.Remove("spam.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = target.Get("spam.tar.gz")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a missing archive is not an error.
+	err = target.Remove("spam.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *targetsSuite) TestDirTargetShortWrite(c *gc.C) {
+	dir := c.MkDir()
+	target, err := backups.NewTargetOpener(corebackups.S3Config{})("file:" + dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = target.Put("spam.tar.gz", bytes.NewBufferString("arch"), 7)
+	c.Assert(err, gc.ErrorMatches, `writing "spam.tar.gz": wrote 4 bytes, expected 7`)
+
+	// Nothing is left behind.
+	entries, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *targetsSuite) TestS3Target(c *gc.C) {
+	srv, err := s3test.NewServer(&s3test.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer srv.Quit()
+
+	config := corebackups.S3Config{
+		Endpoint:  srv.URL(),
+		Region:    "faux-region-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+	region := aws.Region{Name: config.Region, S3Endpoint: config.Endpoint}
+	bucket, err := s3.New(aws.Auth{}, region).Bucket("juju-backups")
+	c.Assert(err, jc.ErrorIsNil)
+	err = bucket.PutBucket(s3.Private)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target, err := backups.NewTargetOpener(config)("s3://juju-backups/prod")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.Spec(), gc.Equals, "s3://juju-backups/prod")
+
+	err = target.Put("spam.tar.gz", bytes.NewBufferString("archive"), 7)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := bucket.Get("prod/spam.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "archive")
+
+	r, err := target.Get("spam.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err = ioutil.ReadAll(r)
+	r.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "archive")
+
+	err = target.Remove("spam.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = target.Get("spam.tar.gz")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *targetsSuite) TestS3TargetUnknownRegion(c *gc.C) {
+	_, err := backups.NewTargetOpener(corebackups.S3Config{Region: "nowhere"})("s3://juju-backups")
+	c.Assert(err, gc.ErrorMatches, `backup s3 region "nowhere" without an endpoint not valid`)
+}
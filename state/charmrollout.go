@@ -0,0 +1,496 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// charmRolloutDoc records the progress of rolling a new charm out to
+// an application's units. Units chosen to run the new charm have
+// their target charm URL set; the application's own charm URL is only
+// changed once every unit is running the new charm.
+//
+// The rollout holds a reference to the new charm, and to its settings
+// and storage constraints, which passes to the application when the
+// rollout completes.
+type charmRolloutDoc struct {
+	CharmURL   *charm.URL `bson:"charmurl"`
+	Channel    string     `bson:"cs-channel"`
+	ForceUnits bool       `bson:"forcecharm"`
+
+	// MaxUnavailable is the number of units that may be upgrading
+	// at once. If it is zero, only the units the user chose are
+	// upgraded.
+	MaxUnavailable int `bson:"max-unavailable"`
+
+	// Halted records that no more units should be chosen to upgrade.
+	Halted bool `bson:"halted"`
+
+	// ConfigSettings and StorageConstraints hold the updates given
+	// when the rollout started, which are applied to the application
+	// when the rollout completes.
+	ConfigSettings     settingsMap                   `bson:"config-settings,omitempty"`
+	StorageConstraints map[string]StorageConstraints `bson:"storage-constraints,omitempty"`
+}
+
+// completionConfig returns the charm settings and storage constraints
+// to apply when the rollout completes, with the given updates applied
+// on top of those given when the rollout started.
+func (r *charmRolloutDoc) completionConfig(
+	updatedSettings charm.Settings,
+	updatedStorageConstraints map[string]StorageConstraints,
+) (charm.Settings, map[string]StorageConstraints) {
+	settings := make(charm.Settings)
+	for k, v := range r.ConfigSettings {
+		settings[k] = v
+	}
+	for k, v := range updatedSettings {
+		settings[k] = v
+	}
+	storageConstraints := make(map[string]StorageConstraints)
+	for name, cons := range r.StorageConstraints {
+		storageConstraints[name] = cons
+	}
+	for name, cons := range updatedStorageConstraints {
+		storageConstraints[name] = cons
+	}
+	return settings, storageConstraints
+}
+
+// CharmRollout describes a new charm being rolled out to an
+// application's units.
+type CharmRollout struct {
+	// CharmURL is the URL of the charm being rolled out.
+	CharmURL *charm.URL
+
+	// ForceUnits records whether units should upgrade to the charm
+	// even if they are in an error state.
+	ForceUnits bool
+
+	// MaxUnavailable is the number of units that may be upgrading
+	// to the charm at once. If it is zero, only units chosen
+	// explicitly are upgraded.
+	MaxUnavailable int
+
+	// Halted records whether the rollout has been stopped from
+	// upgrading any more units.
+	Halted bool
+}
+
+// CharmRollout returns the charm being rolled out to the application's
+// units, and whether there is one.
+func (a *Application) CharmRollout() (CharmRollout, bool) {
+	rollout := a.doc.CharmRollout
+	if rollout == nil {
+		return CharmRollout{}, false
+	}
+	return CharmRollout{
+		CharmURL:       rollout.CharmURL,
+		ForceUnits:     rollout.ForceUnits,
+		MaxUnavailable: rollout.MaxUnavailable,
+		Halted:         rollout.Halted,
+	}, true
+}
+
+// charmRolloutOps returns the operations needed to start rolling the
+// configured charm out to the application's units, or to carry on
+// rolling it out if it already is.
+func (a *Application) charmRolloutOps(cfg SetCharmConfig, updatedSettings charm.Settings) ([]txn.Op, error) {
+	units, err := a.namedUnits(cfg.Units)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var ops []txn.Op
+	rollout := a.doc.CharmRollout
+	if rollout == nil {
+		startOps, err := a.startCharmRolloutOps(
+			cfg.Charm,
+			updatedSettings,
+			cfg.ResourceIDs,
+			cfg.StorageConstraints,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"charm-rollout", bson.D{{"$exists", false}}}},
+		})
+		ops = append(ops, startOps...)
+		rollout = &charmRolloutDoc{
+			CharmURL:           cfg.Charm.URL(),
+			ConfigSettings:     settingsMap(updatedSettings),
+			StorageConstraints: cfg.StorageConstraints,
+		}
+	} else {
+		if len(updatedSettings) > 0 || len(cfg.StorageConstraints) > 0 || len(cfg.ResourceIDs) > 0 {
+			return nil, errors.New("cannot change config, storage constraints or resources while the charm is being rolled out")
+		}
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"charm-rollout.charmurl", rollout.CharmURL}},
+		})
+	}
+
+	// Each change to the rollout says how it should carry on, and
+	// resumes it if it was halted.
+	newRollout := *rollout
+	newRollout.Channel = string(cfg.Channel)
+	newRollout.ForceUnits = cfg.ForceUnits
+	newRollout.MaxUnavailable = cfg.MaxUnavailable
+	newRollout.Halted = false
+	ops = append(ops, txn.Op{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Update: bson.D{{"$set", bson.D{{"charm-rollout", newRollout}}}},
+	})
+	for _, u := range units {
+		if u.doc.CharmURL != nil && *u.doc.CharmURL == *rollout.CharmURL {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+			Update: bson.D{{"$set", bson.D{{"target-charmurl", rollout.CharmURL}}}},
+		})
+	}
+	return ops, nil
+}
+
+// startCharmRolloutOps returns the operations needed to prepare for
+// units of the application to run the new charm: creating its settings
+// and storage constraints, and taking a reference to them. These are
+// checked in the same way as when the application's charm is changed,
+// so that an incompatible charm is rejected before any unit upgrades.
+//
+// Storage added by the new charm is only created for the units when
+// the rollout completes.
+func (a *Application) startCharmRolloutOps(
+	ch *Charm,
+	updatedSettings charm.Settings,
+	resourceIDs map[string]string,
+	updatedStorageConstraints map[string]StorageConstraints,
+) ([]txn.Op, error) {
+	_, settingsOp, err := a.newCharmSettingsOps(ch, updatedSettings)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	checkStorageOps, _, storageConstraintsOps, err := a.newCharmStorageOps(ch, units, updatedStorageConstraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relOps, err := a.checkRelationsOps(ch, relations)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	incOps, err := appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ops := []txn.Op{{
+		C:  applicationsC,
+		Id: a.doc.DocID,
+		Assert: bson.D{
+			{"unitcount", len(units)},
+			{"relationcount", len(relations)},
+		},
+	}, settingsOp}
+	ops = append(ops, incOps...)
+	ops = append(ops, storageConstraintsOps...)
+	ops = append(ops, checkStorageOps...)
+	ops = append(ops, relOps...)
+	if len(resourceIDs) > 0 {
+		resOps, err := a.resolveResourceOps(resourceIDs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, resOps...)
+	}
+	return ops, nil
+}
+
+// namedUnits returns the application's units with the given names.
+func (a *Application) namedUnits(unitNames []string) ([]*Unit, error) {
+	units := make([]*Unit, len(unitNames))
+	for i, name := range unitNames {
+		if !names.IsValidUnit(name) {
+			return nil, errors.NotValidf("unit name %q", name)
+		}
+		if unitAppName(name) != a.doc.Name {
+			return nil, errors.Errorf("unit %q does not belong to application %q", name, a.doc.Name)
+		}
+		u, err := a.st.Unit(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units[i] = u
+	}
+	return units, nil
+}
+
+// advanceCharmRollout moves the application's charm rollout on. If all
+// of the units are running the new charm, the application's charm is
+// changed to it. Otherwise, more units are chosen to upgrade, in unit
+// number order, so long as no more than the rollout's MaxUnavailable
+// are upgrading at once.
+func (a *Application) advanceCharmRollout() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := a.Refresh(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		rollout := a.doc.CharmRollout
+		if rollout == nil || rollout.Halted {
+			return nil, jujutxn.ErrNoOperations
+		}
+		units, err := a.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var upgrading int
+		var waiting []*Unit
+		for _, u := range units {
+			switch {
+			case u.doc.CharmURL != nil && *u.doc.CharmURL == *rollout.CharmURL:
+				// Already running the new charm.
+			case u.doc.TargetCharmURL != nil:
+				upgrading++
+			default:
+				waiting = append(waiting, u)
+			}
+		}
+		if upgrading == 0 && len(waiting) == 0 {
+			return a.completeCharmRolloutOps(units)
+		}
+
+		available := rollout.MaxUnavailable - upgrading
+		if available <= 0 || len(waiting) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		sort.Slice(waiting, func(i, j int) bool {
+			return waiting[i].UnitTag().Number() < waiting[j].UnitTag().Number()
+		})
+		if len(waiting) > available {
+			waiting = waiting[:available]
+		}
+		ops := []txn.Op{{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: bson.D{
+				{"charm-rollout.charmurl", rollout.CharmURL},
+				{"charm-rollout.max-unavailable", rollout.MaxUnavailable},
+				{"charm-rollout.halted", false},
+			},
+		}}
+		for _, u := range waiting {
+			ops = append(ops, txn.Op{
+				C:      unitsC,
+				Id:     u.doc.DocID,
+				Assert: bson.D{{"target-charmurl", bson.D{{"$exists", false}}}},
+				Update: bson.D{{"$set", bson.D{{"target-charmurl", rollout.CharmURL}}}},
+			})
+		}
+		return ops, nil
+	}
+	return errors.Annotatef(a.st.db().Run(buildTxn), "cannot advance charm rollout for application %q", a)
+}
+
+// completeCharmRolloutOps returns the operations needed to change the
+// application's charm to the one being rolled out, which the given
+// units must all be running. The charm modified version is left as it
+// is: every unit has already upgraded to the charm, and changing the
+// version would make them run the upgrade-charm hook again.
+func (a *Application) completeCharmRolloutOps(units []*Unit) ([]txn.Op, error) {
+	rollout := a.doc.CharmRollout
+	ch, err := a.st.Charm(rollout.CharmURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:  applicationsC,
+		Id: a.doc.DocID,
+		Assert: append(notDeadDoc, bson.DocElem{
+			"charmmodifiedversion", a.doc.CharmModifiedVersion,
+		}),
+	}}
+	for _, u := range units {
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: bson.D{{"charmurl", rollout.CharmURL}},
+		})
+	}
+	changeOps, err := a.changeCharmOps(
+		ch,
+		rollout.Channel,
+		charm.Settings(rollout.ConfigSettings),
+		rollout.ForceUnits,
+		nil,
+		rollout.StorageConstraints,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, changeOps...), nil
+}
+
+// advanceApplicationCharmRollout moves on the charm rollout, if any,
+// of the named application. It is called when one of the application's
+// units is removed, which may free a place for another unit to upgrade
+// or leave every remaining unit running the new charm.
+func advanceApplicationCharmRollout(st *State, appName string) error {
+	app, err := st.Application(appName)
+	if errors.IsNotFound(err) {
+		// The application was removed along with its last unit.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := app.CharmRollout(); !ok {
+		return nil
+	}
+	return errors.Trace(app.advanceCharmRollout())
+}
+
+// retryableAdvanceCharmRollout moves on the charm rollout, if any, of
+// the named application after a change to one of its units has been
+// committed. Failing to advance the rollout doesn't undo that change,
+// so rather than being returned, the error is logged and a cleanup is
+// scheduled to try again.
+func retryableAdvanceCharmRollout(st *State, appName string) {
+	err := advanceApplicationCharmRollout(st, appName)
+	if err == nil {
+		return
+	}
+	logger.Errorf("cannot advance charm rollout for application %q, will retry: %v", appName, err)
+	ops := []txn.Op{newCleanupOp(cleanupCharmRollout, appName)}
+	if err := st.db().RunTransaction(ops); err != nil {
+		logger.Errorf("cannot schedule charm rollout retry for application %q: %v", appName, err)
+	}
+}
+
+// HaltCharmRollout stops the application's charm rollout from choosing
+// any more units to upgrade. Units that are already upgrading carry on.
+// The rollout is resumed by setting the same charm again, or abandoned
+// with RollbackCharmRollout.
+func (a *Application) HaltCharmRollout() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot halt charm rollout for application %q", a)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		rollout := a.doc.CharmRollout
+		if rollout == nil {
+			return nil, errors.NotFoundf("charm rollout")
+		}
+		if rollout.Halted {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"charm-rollout.charmurl", rollout.CharmURL}},
+			Update: bson.D{{"$set", bson.D{{"charm-rollout.halted", true}}}},
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return err
+	}
+	a.doc.CharmRollout.Halted = true
+	return nil
+}
+
+// RollbackCharmRollout abandons the application's charm rollout. All
+// units follow the application's charm again, so any that upgraded to
+// the new charm go back to it. Resources activated when the rollout
+// started are left as they are.
+func (a *Application) RollbackCharmRollout() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot roll back charm rollout for application %q", a)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		rollout := a.doc.CharmRollout
+		if rollout == nil {
+			return nil, errors.NotFoundf("charm rollout")
+		}
+		units, err := a.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: bson.D{
+				{"charm-rollout.charmurl", rollout.CharmURL},
+				{"unitcount", len(units)},
+			},
+			Update: bson.D{{"$unset", bson.D{{"charm-rollout", nil}}}},
+		}}
+		for _, u := range units {
+			ops = append(ops, txn.Op{
+				C:      unitsC,
+				Id:     u.doc.DocID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$unset", bson.D{{"target-charmurl", nil}}}},
+			})
+		}
+		// Units that upgraded hold their own references to the new
+		// charm until they go back.
+		decOps, err := appCharmDecRefOps(a.st, a.doc.Name, rollout.CharmURL, true)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, decOps...), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return err
+	}
+	a.doc.CharmRollout = nil
+	return nil
+}
+
+// TargetCharmURL returns the URL of the charm the unit should be
+// running, and whether it should upgrade to that charm even if it is
+// in an error state. This is the application's charm, unless the unit
+// has been chosen to run a charm being rolled out to the application.
+func (u *Unit) TargetCharmURL() (*charm.URL, bool, error) {
+	app, err := u.Application()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if u.doc.TargetCharmURL != nil {
+		var force bool
+		if rollout := app.doc.CharmRollout; rollout != nil {
+			force = rollout.ForceUnits
+		}
+		return u.doc.TargetCharmURL, force, nil
+	}
+	curl, force := app.CharmURL()
+	return curl, force, nil
+}
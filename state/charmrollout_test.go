@@ -0,0 +1,369 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmRolloutSuite struct {
+	ConnSuite
+	oldCh *state.Charm
+	newCh *state.Charm
+	app   *state.Application
+	units []*state.Unit
+}
+
+var _ = gc.Suite(&CharmRolloutSuite{})
+
+func (s *CharmRolloutSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.oldCh = s.AddConfigCharm(c, "wordpress", stringConfig, 1)
+	s.newCh = s.AddConfigCharm(c, "wordpress", stringConfig, 2)
+	s.app = s.AddTestingApplication(c, "wordpress", s.oldCh)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		u, err := s.app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = u.SetCharmURL(s.oldCh.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, u)
+	}
+}
+
+func (s *CharmRolloutSuite) assertTargets(c *gc.C, expected ...*state.Charm) {
+	c.Assert(s.units, gc.HasLen, len(expected))
+	for i, u := range s.units {
+		err := u.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		curl, _, err := u.TargetCharmURL()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(curl, gc.DeepEquals, expected[i].URL(), gc.Commentf("unit %s", u))
+	}
+}
+
+func (s *CharmRolloutSuite) assertAppCharm(c *gc.C, ch *state.Charm) {
+	err := s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := s.app.CharmURL()
+	c.Assert(curl, gc.DeepEquals, ch.URL())
+}
+
+func (s *CharmRolloutSuite) upgrade(c *gc.C, i int, ch *state.Charm) {
+	err := s.units[i].SetCharmURL(ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRolloutSuite) removeUnit(c *gc.C, i int) {
+	u := s.units[i]
+	err := u.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	s.units = append(s.units[:i], s.units[i+1:]...)
+}
+
+func (s *CharmRolloutSuite) TestCanary(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm:      s.newCh,
+		ForceUnits: true,
+		Units:      []string{"wordpress/1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAppCharm(c, s.oldCh)
+	rollout, ok := s.app.CharmRollout()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(rollout, jc.DeepEquals, state.CharmRollout{
+		CharmURL:   s.newCh.URL(),
+		ForceUnits: true,
+	})
+	s.assertTargets(c, s.oldCh, s.newCh, s.oldCh)
+	_, force, err := s.units[1].TargetCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(force, jc.IsTrue)
+	_, force, err = s.units[0].TargetCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(force, jc.IsFalse)
+
+	// The rollout holds a reference to the new charm's settings,
+	// as does the canary once it has upgraded.
+	assertSettingsRef(c, s.State, "wordpress", s.newCh, 1)
+	s.upgrade(c, 1, s.newCh)
+	assertSettingsRef(c, s.State, "wordpress", s.newCh, 2)
+
+	// No more units are upgraded until the user says so.
+	s.assertAppCharm(c, s.oldCh)
+	s.assertTargets(c, s.oldCh, s.newCh, s.oldCh)
+}
+
+func (s *CharmRolloutSuite) TestRolling(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCh,
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertTargets(c, s.newCh, s.oldCh, s.oldCh)
+
+	s.upgrade(c, 0, s.newCh)
+	s.assertTargets(c, s.newCh, s.newCh, s.oldCh)
+	s.assertAppCharm(c, s.oldCh)
+
+	s.upgrade(c, 1, s.newCh)
+	s.assertTargets(c, s.newCh, s.newCh, s.newCh)
+	s.assertAppCharm(c, s.oldCh)
+
+	// Once the last unit upgrades, the application's charm changes,
+	// and the old charm is no longer referenced.
+	s.upgrade(c, 2, s.newCh)
+	s.assertAppCharm(c, s.newCh)
+	_, ok := s.app.CharmRollout()
+	c.Assert(ok, jc.IsFalse)
+	assertSettingsRef(c, s.State, "wordpress", s.newCh, 4)
+	assertNoSettingsRef(c, s.State, "wordpress", s.oldCh)
+}
+
+func (s *CharmRolloutSuite) TestCompletingRolloutKeepsCharmModifiedVersion(c *gc.C) {
+	version := s.app.CharmModifiedVersion()
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCh,
+		MaxUnavailable: 3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for i := range s.units {
+		s.upgrade(c, i, s.newCh)
+	}
+	s.assertAppCharm(c, s.newCh)
+
+	// The units have all upgraded already, so they mustn't be told
+	// to run the upgrade-charm hook again.
+	c.Assert(s.app.CharmModifiedVersion(), gc.Equals, version)
+}
+
+func (s *CharmRolloutSuite) TestRemoveUnitAdvancesRollout(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCh,
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertTargets(c, s.newCh, s.oldCh, s.oldCh)
+
+	// Removing the upgrading unit lets the next one upgrade.
+	s.removeUnit(c, 0)
+	s.assertTargets(c, s.newCh, s.oldCh)
+	s.upgrade(c, 0, s.newCh)
+	s.assertTargets(c, s.newCh, s.newCh)
+
+	// Removing the last unit still to upgrade completes the rollout.
+	s.removeUnit(c, 1)
+	s.assertAppCharm(c, s.newCh)
+	_, ok := s.app.CharmRollout()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *CharmRolloutSuite) setCharmPendingUpload(c *gc.C, ch *state.Charm, pending bool) {
+	err := state.RunTransaction(s.State, []txn.Op{{
+		C:      "charms",
+		Id:     state.DocID(s.State, ch.URL().String()),
+		Update: bson.D{{"$set", bson.D{{"pendingupload", pending}}}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRolloutSuite) TestAdvanceFailureIsRetried(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCh,
+		MaxUnavailable: 3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.upgrade(c, 0, s.newCh)
+	s.upgrade(c, 1, s.newCh)
+
+	// The rollout can't complete while the new charm can't be read,
+	// but the unit's upgrade is still recorded.
+	s.setCharmPendingUpload(c, s.newCh, true)
+	s.upgrade(c, 2, s.newCh)
+	s.assertAppCharm(c, s.oldCh)
+	_, ok := s.app.CharmRollout()
+	c.Assert(ok, jc.IsTrue)
+
+	// A cleanup completes the rollout once it can.
+	s.setCharmPendingUpload(c, s.newCh, false)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAppCharm(c, s.newCh)
+	_, ok = s.app.CharmRollout()
+	c.Assert(ok, jc.IsFalse)
+	state.AssertNoCleanups(c, s.State)
+}
+
+func (s *CharmRolloutSuite) TestRollingAfterCanary(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm: s.newCh,
+		Units: []string{"wordpress/2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.upgrade(c, 2, s.newCh)
+
+	err = s.app.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCh,
+		MaxUnavailable: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertTargets(c, s.newCh, s.newCh, s.newCh)
+
+	s.upgrade(c, 0, s.newCh)
+	s.upgrade(c, 1, s.newCh)
+	s.assertAppCharm(c, s.newCh)
+}
+
+func (s *CharmRolloutSuite) TestHaltAndResume(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCh,
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.HaltCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, ok := s.app.CharmRollout()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(rollout.Halted, jc.IsTrue)
+
+	// The unit that was already upgrading carries on, but no
+	// others follow.
+	s.upgrade(c, 0, s.newCh)
+	s.assertTargets(c, s.newCh, s.oldCh, s.oldCh)
+
+	err = s.app.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCh,
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, ok = s.app.CharmRollout()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(rollout.Halted, jc.IsFalse)
+	s.assertTargets(c, s.newCh, s.newCh, s.oldCh)
+}
+
+func (s *CharmRolloutSuite) TestRollback(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm: s.newCh,
+		Units: []string{"wordpress/0", "wordpress/1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.upgrade(c, 0, s.newCh)
+
+	err = s.app.RollbackCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.app.CharmRollout()
+	c.Assert(ok, jc.IsFalse)
+	s.assertAppCharm(c, s.oldCh)
+	s.assertTargets(c, s.oldCh, s.oldCh, s.oldCh)
+
+	// The upgraded unit holds the only reference to the new charm's
+	// settings until it goes back.
+	assertSettingsRef(c, s.State, "wordpress", s.newCh, 1)
+	s.upgrade(c, 0, s.oldCh)
+	assertNoSettingsRef(c, s.State, "wordpress", s.newCh)
+
+	err = s.app.RollbackCharmRollout()
+	c.Assert(err, gc.ErrorMatches, `cannot roll back charm rollout for application "wordpress": charm rollout not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmRolloutSuite) TestSetCharmCompletesRollout(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCh,
+		ConfigSettings: charm.Settings{"key": "rolled"},
+		Units:          []string{"wordpress/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.upgrade(c, 0, s.newCh)
+
+	// The canary runs with the new settings, while the application
+	// keeps its old ones until the rollout completes.
+	settings, err := s.units[0].ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["key"], gc.Equals, "rolled")
+	settings, err = s.app.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["key"], gc.Equals, "My Key")
+
+	err = s.app.SetCharm(state.SetCharmConfig{Charm: s.newCh})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAppCharm(c, s.newCh)
+	_, ok := s.app.CharmRollout()
+	c.Assert(ok, jc.IsFalse)
+	s.assertTargets(c, s.newCh, s.newCh, s.newCh)
+	settings, err = s.app.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["key"], gc.Equals, "rolled")
+
+	// The rollout's reference passed to the application.
+	assertSettingsRef(c, s.State, "wordpress", s.newCh, 2)
+}
+
+func (s *CharmRolloutSuite) TestSetOtherCharmDuringRollout(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm: s.newCh,
+		Units: []string{"wordpress/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	otherCh := s.AddConfigCharm(c, "wordpress", stringConfig, 3)
+	err = s.app.SetCharm(state.SetCharmConfig{Charm: otherCh})
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(
+		`cannot upgrade application "wordpress" to charm %q: charm %q is being rolled out to the units; roll it back first`,
+		otherCh.URL(), s.newCh.URL(),
+	))
+}
+
+func (s *CharmRolloutSuite) TestRolloutUnitOfOtherApplication(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm: s.newCh,
+		Units: []string{"mysql/0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*unit "mysql/0" does not belong to application "wordpress"`)
+	_, ok := s.app.CharmRollout()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *CharmRolloutSuite) TestRolloutWithoutUnits(c *gc.C) {
+	app := s.AddTestingApplication(c, "empty", s.oldCh)
+	err := app.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCh,
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl, gc.DeepEquals, s.newCh.URL())
+	_, ok := app.CharmRollout()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *CharmRolloutSuite) TestRemoveApplicationDuringRollout(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm: s.newCh,
+		Units: []string{"wordpress/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, u := range s.units {
+		err := u.EnsureDead()
+		c.Assert(err, jc.ErrorIsNil)
+		err = u.Remove()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoSettingsRef(c, s.State, "wordpress", s.oldCh)
+	assertNoSettingsRef(c, s.State, "wordpress", s.newCh)
+}
@@ -40,6 +40,8 @@ const (
 
 	cleanupResourceBlob         cleanupKind = "resourceBlob"
 	cleanupStorageForDyingModel cleanupKind = "modelStorage"
+	cleanupApplicationSecrets   cleanupKind = "applicationSecrets"
+	cleanupCharmRollout         cleanupKind = "charmRollout"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -154,6 +156,10 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupResourceBlob(doc.Prefix)
 		case cleanupStorageForDyingModel:
 			err = st.cleanupStorageForDyingModel(args)
+		case cleanupApplicationSecrets:
+			err = st.cleanupApplicationSecrets(doc.Prefix)
+		case cleanupCharmRollout:
+			err = advanceApplicationCharmRollout(st, doc.Prefix)
 		default:
 			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
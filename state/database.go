@@ -4,9 +4,12 @@
 package state
 
 import (
+	"context"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jujutxn "github.com/juju/txn"
@@ -17,6 +20,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/mongo"
 )
@@ -243,6 +247,10 @@ type database struct {
 
 	// clock is used to time how long transactions take to run
 	clock clock.Clock
+
+	// traceContext, if non-nil, holds the trace that transactions
+	// run through the database are recorded in.
+	traceContext context.Context
 }
 
 // RunTransactionObserverFunc is the type of a function to be called
@@ -252,15 +260,35 @@ type RunTransactionObserverFunc func(dbName, modelUUID string, ops []txn.Op, err
 func (db *database) copySession(modelUUID string) (*database, SessionCloser) {
 	session := db.raw.Session.Copy()
 	return &database{
-		raw:        db.raw.With(session),
-		schema:     db.schema,
-		modelUUID:  modelUUID,
-		runner:     db.runner,
-		ownSession: true,
-		clock:      db.clock,
+		raw:          db.raw.With(session),
+		schema:       db.schema,
+		modelUUID:    modelUUID,
+		runner:       db.runner,
+		ownSession:   true,
+		clock:        db.clock,
+		traceContext: db.traceContext,
 	}, session.Close
 }
 
+// traceTransaction records the transaction as a span in
+// the database's trace, if it has one.
+func (db *database) traceTransaction(t jujutxn.ObservedTransaction) {
+	if db.traceContext == nil {
+		return
+	}
+	_, span := trace.StartSpanAt(db.traceContext, "state.txn", db.clock.Now().Add(-t.Duration))
+	collections := set.NewStrings()
+	for _, op := range t.Ops {
+		collections.Add(op.C)
+	}
+	span.SetAttribute("database", db.raw.Name)
+	span.SetAttribute("model-uuid", db.modelUUID)
+	span.SetAttribute("collections", strings.Join(collections.SortedValues(), ","))
+	span.SetAttribute("ops", strconv.Itoa(len(t.Ops)))
+	span.SetError(t.Error)
+	span.End()
+}
+
 // Copy is part of the Database interface.
 func (db *database) Copy() (Database, SessionCloser) {
 	return db.copySession(db.modelUUID)
@@ -336,11 +364,13 @@ func (db *database) TransactionRunner() (runner jujutxn.Runner, closer SessionCl
 		observer := func(t jujutxn.ObservedTransaction) {
 			txnLogger.Tracef("ran transaction in %.3fs %# v\nerr: %v",
 				t.Duration.Seconds(), pretty.Formatter(t.Ops), t.Error)
+			db.traceTransaction(t)
 		}
 		if db.runTransactionObserver != nil {
 			observer = func(t jujutxn.ObservedTransaction) {
 				txnLogger.Tracef("ran transaction in %.3fs %# v\nerr: %v",
 					t.Duration.Seconds(), pretty.Formatter(t.Ops), t.Error)
+				db.traceTransaction(t)
 				db.runTransactionObserver(
 					db.raw.Name, db.modelUUID,
 					t.Ops, t.Error,
@@ -350,7 +380,7 @@ func (db *database) TransactionRunner() (runner jujutxn.Runner, closer SessionCl
 		params := jujutxn.RunnerParams{
 			Database:               raw,
 			RunTransactionObserver: observer,
-			Clock: db.clock,
+			Clock:                  db.clock,
 		}
 		runner = jujutxn.NewRunner(params)
 	}
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/instance"
 )
 
@@ -206,6 +207,7 @@ func (st *State) enableHAIntentionOps(
 		return result, constraints.Value{}
 	}
 	mdocs := make([]*machineDoc, intent.newCount)
+	requested := make(quota.Usage)
 	for i := range mdocs {
 		placement, cons := getPlacementConstraints()
 		template := MachineTemplate{
@@ -217,15 +219,21 @@ func (st *State) enableHAIntentionOps(
 			Constraints: cons,
 			Placement:   placement,
 		}
-		mdoc, addOps, err := st.addMachineOps(template)
+		mdoc, addOps, usage, err := st.newMachineOps(template)
 		if err != nil {
 			return nil, ControllersChanges{}, err
 		}
 		mdocs[i] = mdoc
 		ops = append(ops, addOps...)
+		addQuotaUsage(requested, usage)
 		change.Added = append(change.Added, mdoc.Id)
 
 	}
+	chargeOps, err := quotaOps(st, requested)
+	if err != nil {
+		return nil, ControllersChanges{}, errors.Trace(err)
+	}
+	ops = append(ops, chargeOps...)
 	for _, m := range intent.maintain {
 		tag, err := names.ParseTag(m.Tag().String())
 		if err != nil {
@@ -25,7 +25,6 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
-	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/network"
@@ -597,14 +596,6 @@ func AssertEndpointBindingsNotFoundForApplication(c *gc.C, app *Application) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
-func LeadershipLeases(st *State) (map[lease.Key]lease.Info, error) {
-	store, err := st.getLeadershipLeaseStore()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	return store.Leases(), nil
-}
-
 func StorageAttachmentCount(instance StorageInstance) int {
 	internal, ok := instance.(*storageInstance)
 	if !ok {
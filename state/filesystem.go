@@ -67,6 +67,16 @@ type Filesystem interface {
 	// Releasing reports whether or not the filesystem is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// Shared reports whether or not the filesystem may be attached
+	// to multiple hosts concurrently.
+	Shared() bool
+
+	// HostManaged reports whether or not the filesystem is provisioned
+	// by the storage provisioners of the hosts it is attached to, rather
+	// than by the model storage provisioner. This is true of shared
+	// filesystems from machine-scoped storage providers.
+	HostManaged() bool
 }
 
 // FilesystemAttachment describes an attachment of a filesystem to a machine.
@@ -121,6 +131,13 @@ type filesystemDoc struct {
 	// the filesystem as being non-detachable, and to determine
 	// which filesystems must be removed along with said machine.
 	HostId string `bson:"hostid,omitempty"`
+
+	// Shared records whether the filesystem may be attached to
+	// multiple hosts concurrently. HostManaged records whether
+	// the hosts it is attached to provision it; see the
+	// Filesystem interface.
+	Shared      bool `bson:"shared,omitempty"`
+	HostManaged bool `bson:"hostmanaged,omitempty"`
 }
 
 // filesystemAttachmentDoc records information about a filesystem attachment.
@@ -155,6 +172,11 @@ type FilesystemParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// Snapshot is the ID of the volume snapshot that the filesystem's
+	// backing volume is to be created from, if any. Only filesystems
+	// backed by volumes may be created from a snapshot.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 // FilesystemInfo describes information about a filesystem.
@@ -563,6 +585,16 @@ func (f *filesystem) Detachable() bool {
 	return f.doc.HostId == ""
 }
 
+// Shared is required to implement Filesystem.
+func (f *filesystem) Shared() bool {
+	return f.doc.Shared
+}
+
+// HostManaged is required to implement Filesystem.
+func (f *filesystem) HostManaged() bool {
+	return f.doc.HostManaged
+}
+
 func (f *filesystem) pool() string {
 	if f.doc.Info != nil {
 		return f.doc.Info.Pool
@@ -694,9 +726,10 @@ func (sb *storageBackend) RemoveFilesystemAttachment(host names.Tag, filesystem
 
 func removeFilesystemAttachmentOps(sb *storageBackend, host names.Tag, f *filesystem) ([]txn.Op, error) {
 	var ops []txn.Op
-	if f.doc.VolumeId != "" && f.doc.Life == Dying && f.doc.AttachmentCount == 1 {
-		// Volume-backed filesystems are removed immediately, instead
-		// of transitioning to Dead.
+	removeWithLastAttachment := f.doc.VolumeId != "" || f.doc.HostManaged
+	if removeWithLastAttachment && f.doc.Life == Dying && f.doc.AttachmentCount == 1 {
+		// Volume-backed and host-managed filesystems are removed
+		// immediately, instead of transitioning to Dead.
 		assert := bson.D{
 			{"life", Dying},
 			{"attachmentcount", 1},
@@ -777,6 +810,12 @@ func destroyFilesystemOps(sb *storageBackend, f *filesystem, release bool, extra
 			// filesystem contents anyway.
 			return removeFilesystemOps(sb, f, release, assert)
 		}
+		if f.doc.HostManaged {
+			// Host-managed filesystems are only provisioned by
+			// the hosts they are attached to, so there is no
+			// provisioner left to destroy it.
+			return removeFilesystemOps(sb, f, release, assert)
+		}
 		// The filesystem is not volume-backed, so leave it to the
 		// storage provisioner to destroy it.
 		setFields = append(setFields, bson.DocElem{"life", Dead})
@@ -1068,10 +1107,11 @@ func (sb *storageBackend) addFilesystemOps(params FilesystemParams, hostId strin
 			params.filesystemId = filesystemTag.String()
 		}
 		volumeParams := VolumeParams{
-			params.storage,
-			params.volumeInfo,
-			params.Pool,
-			params.Size,
+			storage:    params.storage,
+			volumeInfo: params.volumeInfo,
+			Pool:       params.Pool,
+			Size:       params.Size,
+			Snapshot:   params.Snapshot,
 		}
 		volumeOps, volumeTag, err = sb.addVolumeOps(volumeParams, hostId)
 		if err != nil {
@@ -1079,6 +1119,10 @@ func (sb *storageBackend) addFilesystemOps(params FilesystemParams, hostId strin
 		}
 		volumeId = volumeTag.Id()
 		ops = append(ops, volumeOps...)
+	} else if params.Snapshot != "" {
+		return nil, names.FilesystemTag{}, names.VolumeTag{}, errors.NotSupportedf(
+			"creating filesystem in pool %q from a volume snapshot", params.Pool,
+		)
 	}
 
 	statusDoc := statusDoc{
@@ -1112,6 +1156,46 @@ func (sb *storageBackend) addFilesystemOps(params FilesystemParams, hostId strin
 	return ops, filesystemTag, volumeTag, nil
 }
 
+// addSharedFilesystemOps returns txn.Ops to create a new shared filesystem
+// with the specified parameters. Shared filesystems are model-scoped and are
+// created without attachments; units of the owning application attach them
+// as they are assigned to hosts.
+func (sb *storageBackend) addSharedFilesystemOps(params FilesystemParams) ([]txn.Op, names.FilesystemTag, error) {
+	// There is no default pool for shared filesystems, so the
+	// pool must have been specified in the storage constraints.
+	if err := validateStoragePool(sb, params.Pool, storage.StorageKindSharedFilesystem, nil); err != nil {
+		return nil, names.FilesystemTag{}, errors.Annotate(err, "validating filesystem params")
+	}
+	if params.Size == 0 {
+		return nil, names.FilesystemTag{}, errors.New("validating filesystem params: invalid size 0")
+	}
+	_, provider, err := poolStorageProvider(sb, params.Pool)
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Trace(err)
+	}
+
+	filesystemId, err := newFilesystemId(sb.mb, "")
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	doc := filesystemDoc{
+		FilesystemId: filesystemId,
+		StorageId:    params.storage.Id(),
+		Params:       &params,
+		Shared:       true,
+		// Machine-scoped providers can only provision storage
+		// from the machine itself, so each host that the
+		// filesystem is attached to is responsible for it.
+		HostManaged: provider.Scope() == storage.ScopeMachine,
+	}
+	statusDoc := statusDoc{
+		Status:  status.Pending,
+		Updated: sb.mb.clock().Now().UnixNano(),
+	}
+	ops := sb.newFilesystemOps(doc, statusDoc)
+	return ops, names.NewFilesystemTag(filesystemId), nil
+}
+
 func (sb *storageBackend) newFilesystemOps(doc filesystemDoc, status statusDoc) []txn.Op {
 	return []txn.Op{
 		createStatusOp(sb.mb, filesystemGlobalKey(doc.FilesystemId), status),
@@ -1194,6 +1278,31 @@ func createMachineFilesystemAttachmentsOps(hostId string, attachments []filesyst
 	return ops
 }
 
+// existingFilesystemAttachmentOps returns txn.Ops asserting that the
+// filesystem remains attached to the host, if it is attached already.
+// If it is not, no ops are returned. An error is returned if the
+// existing attachment is being removed.
+func (sb *storageBackend) existingFilesystemAttachmentOps(host names.Tag, filesystem names.FilesystemTag) ([]txn.Op, error) {
+	att, err := sb.FilesystemAttachment(host, filesystem)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if att.Life() != Alive {
+		return nil, errors.Errorf(
+			"%s is detaching from %s",
+			names.ReadableString(filesystem),
+			names.ReadableString(host),
+		)
+	}
+	return []txn.Op{{
+		C:      filesystemAttachmentsC,
+		Id:     filesystemAttachmentId(host.Id(), filesystem.Id()),
+		Assert: isAliveDoc,
+	}}, nil
+}
+
 // SetFilesystemInfo sets the FilesystemInfo for the specified filesystem.
 func (sb *storageBackend) SetFilesystemInfo(tag names.FilesystemTag, info FilesystemInfo) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot set info for filesystem %q", tag.Id())
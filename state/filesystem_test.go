@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
@@ -360,6 +361,33 @@ func (s *FilesystemIAASModelSuite) TestWatchFilesystemAttachment(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *FilesystemIAASModelSuite) TestWatchFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+
+	w := s.storageBackend.WatchFilesystem(filesystemTag)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.st, w)
+	wc.AssertOneChange()
+
+	err = s.storageBackend.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Size:         123,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.storageBackend.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "rootfs",
+		Size:         456,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *FilesystemStateSuite) TestFilesystemInfo(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
 	hostTag := s.maybeAssignUnit(c, u)
@@ -1564,3 +1592,160 @@ func (s *FilesystemStateSuite) assertDestroyFilesystem(c *gc.C, tag names.Filesy
 	filesystem := s.filesystem(c, tag)
 	c.Assert(filesystem.Life(), gc.Equals, life)
 }
+
+func (s *FilesystemIAASModelSuite) addSharedFilesystemApplication(c *gc.C, pool string) (*state.Application, state.Filesystem) {
+	ch := s.createStorageCharm(c, "shared-filesystem", charm.Storage{
+		Name:     "shared",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 0,
+		CountMax: 1,
+	})
+	app := s.AddTestingApplicationWithStorage(c, "shared-filesystem", ch, map[string]state.StorageConstraints{
+		"shared": makeStorageCons(pool, 1024, 1),
+	})
+	storageInstances, err := s.storageBackend.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstances, gc.HasLen, 1)
+	owner, ok := storageInstances[0].Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, app.Tag())
+	return app, s.storageInstanceFilesystem(c, storageInstances[0].StorageTag())
+}
+
+func (s *FilesystemIAASModelSuite) TestAddApplicationSharedFilesystem(c *gc.C) {
+	_, filesystem := s.addSharedFilesystemApplication(c, "modelscoped")
+	c.Assert(filesystem.Shared(), jc.IsTrue)
+	c.Assert(filesystem.HostManaged(), jc.IsFalse)
+	c.Assert(filesystem.Tag(), gc.Equals, names.NewFilesystemTag("0"))
+	params, ok := filesystem.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params, jc.DeepEquals, state.FilesystemParams{Pool: "modelscoped", Size: 1024})
+}
+
+func (s *FilesystemIAASModelSuite) TestAddApplicationSharedFilesystemHostManaged(c *gc.C) {
+	// Shared filesystems from machine-scoped providers are model-scoped
+	// in state, but are managed by each machine they are attached to.
+	_, filesystem := s.addSharedFilesystemApplication(c, "machinescoped")
+	c.Assert(filesystem.Shared(), jc.IsTrue)
+	c.Assert(filesystem.HostManaged(), jc.IsTrue)
+	c.Assert(filesystem.Tag(), gc.Equals, names.NewFilesystemTag("0"))
+}
+
+func (s *FilesystemIAASModelSuite) TestSharedFilesystemChargedOnce(c *gc.C) {
+	err := s.Model.SetQuota(quota.Quota{quota.Storage: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	app, _ := s.addSharedFilesystemApplication(c, "modelscoped")
+
+	// The shared filesystem is charged to the quota along with the
+	// application, and not again for each unit it is attached to.
+	for i := 0; i < 2; i++ {
+		_, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage[quota.Storage], gc.Equals, uint64(1024))
+}
+
+func (s *FilesystemIAASModelSuite) TestSharedFilesystemExceedsQuota(c *gc.C) {
+	err := s.Model.SetQuota(quota.Quota{quota.Storage: 512})
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.createStorageCharm(c, "shared-filesystem", charm.Storage{
+		Name:     "shared",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 0,
+		CountMax: 1,
+	})
+	_, err = s.st.AddApplication(state.AddApplicationArgs{
+		Name: "shared-filesystem", Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"shared": makeStorageCons("modelscoped", 1024, 1),
+		},
+	})
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *FilesystemIAASModelSuite) TestSharedFilesystemAttachedToEachMachine(c *gc.C) {
+	app, filesystem := s.addSharedFilesystemApplication(c, "modelscoped")
+	storageTag, err := filesystem.Storage()
+	c.Assert(err, jc.ErrorIsNil)
+	var machineTags []names.MachineTag
+	for i := 0; i < 2; i++ {
+		u, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = u.AssignToNewMachine()
+		c.Assert(err, jc.ErrorIsNil)
+		machineId, err := u.AssignedMachineId()
+		c.Assert(err, jc.ErrorIsNil)
+		machineTags = append(machineTags, names.NewMachineTag(machineId))
+
+		storageAttachments, err := s.storageBackend.UnitStorageAttachments(u.UnitTag())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(storageAttachments, gc.HasLen, 1)
+		c.Assert(storageAttachments[0].StorageInstance(), gc.Equals, storageTag)
+	}
+	attachments, err := s.storageBackend.FilesystemAttachments(filesystem.FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 2)
+	hosts := make([]names.Tag, len(attachments))
+	for i, a := range attachments {
+		hosts[i] = a.Host()
+	}
+	c.Assert(hosts, jc.SameContents, []names.Tag{machineTags[0], machineTags[1]})
+	for _, m := range machineTags {
+		assertMachineStorageRefs(c, s.storageBackend, m)
+	}
+}
+
+func (s *FilesystemIAASModelSuite) TestSharedFilesystemUnitsOnSameMachine(c *gc.C) {
+	app, filesystem := s.addSharedFilesystemApplication(c, "modelscoped")
+	machine, err := s.st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	units := make([]*state.Unit, 2)
+	for i := range units {
+		units[i], err = app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = units[i].AssignToMachine(machine)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// The filesystem is attached to the machine once,
+	// however many of the units are assigned to it.
+	attachments, err := s.storageBackend.FilesystemAttachments(filesystem.FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].Host(), gc.Equals, machine.MachineTag())
+	assertMachineStorageRefs(c, s.storageBackend, machine.MachineTag())
+
+	// Detaching the storage from one of the units leaves the
+	// filesystem attached for the other.
+	storageTag, err := filesystem.Storage()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.DetachStorage(storageTag, units[0].UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment, err := s.storageBackend.FilesystemAttachment(machine.MachineTag(), filesystem.FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+
+	// Detaching it from the last unit on the machine detaches
+	// the filesystem from the machine.
+	err = s.storageBackend.DetachStorage(storageTag, units[1].UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment, err = s.storageBackend.FilesystemAttachment(machine.MachineTag(), filesystem.FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+}
+
+func (s *FilesystemIAASModelSuite) TestRemoveSharedStorageRemovesHostManagedFilesystem(c *gc.C) {
+	_, filesystem := s.addSharedFilesystemApplication(c, "machinescoped")
+	storageTag, err := filesystem.Storage()
+	c.Assert(err, jc.ErrorIsNil)
+	removeStorageInstance(c, s.storageBackend, storageTag)
+
+	// Host-managed filesystems have no model-level provisioner to
+	// remove them, so they are removed when nothing is attached.
+	_, err = s.storageBackend.Filesystem(filesystem.FilesystemTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
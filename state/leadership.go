@@ -46,6 +46,39 @@ func (st *State) LeadershipChecker() leadership.Checker {
 	}
 }
 
+// NewLeadershipClaimer returns a leadership.Claimer for units and
+// applications in the given model, backed by the supplied lease
+// manager rather than the state's own.
+func NewLeadershipClaimer(manager lease.Manager, modelUUID string) leadership.Claimer {
+	return leadershipClaimer{
+		lazyLeaseClaimer{func() (lease.Claimer, error) {
+			return manager.Claimer(applicationLeadershipNamespace, modelUUID)
+		}},
+	}
+}
+
+// NewLeadershipChecker returns a leadership.Checker for units and
+// applications in the given model, backed by the supplied lease
+// manager rather than the state's own.
+func NewLeadershipChecker(manager lease.Manager, modelUUID string) leadership.Checker {
+	return leadershipChecker{
+		lazyLeaseChecker{func() (lease.Checker, error) {
+			return manager.Checker(applicationLeadershipNamespace, modelUUID)
+		}},
+	}
+}
+
+// ApplicationLeadersFromManager returns a map of application name to
+// the name of the unit that is the leader of that application, read
+// from the supplied lease manager.
+func ApplicationLeadersFromManager(manager lease.Manager, modelUUID string) (map[string]string, error) {
+	reader, err := manager.Reader(applicationLeadershipNamespace, modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return reader.Leases(), nil
+}
+
 // buildTxnWithLeadership returns a transaction source that combines the supplied source
 // with checks and asserts on the supplied token.
 func buildTxnWithLeadership(buildTxn jujutxn.TransactionSource, token leadership.Token) jujutxn.TransactionSource {
@@ -0,0 +1,131 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+// leaseHolderDoc records the holder of a lease in the raft lease
+// store.
+type leaseHolderDoc struct {
+	DocID     string `bson:"_id"`
+	Namespace string `bson:"namespace"`
+	ModelUUID string `bson:"model-uuid"`
+	Lease     string `bson:"lease"`
+
+	// Holder is empty once the lease has expired.
+	Holder string `bson:"holder"`
+
+	// Index is the index of the raft log entry that last changed
+	// the holder.
+	Index uint64 `bson:"index"`
+}
+
+func leaseHolderDocId(key lease.Key) string {
+	return fmt.Sprintf("%s:%s#%s#", key.ModelUUID, key.Namespace, key.Lease)
+}
+
+// LeaseNotifyTarget returns a raftlease.NotifyTarget that records
+// the holder of each lease in the leaseholders collection. Failures
+// are logged, since the lease change has already been applied.
+func (st *State) LeaseNotifyTarget(logger loggo.Logger) raftlease.NotifyTarget {
+	return &leaseNotifyTarget{st: st, logger: logger}
+}
+
+type leaseNotifyTarget struct {
+	st     *State
+	logger loggo.Logger
+}
+
+// Claimed is part of raftlease.NotifyTarget.
+func (t *leaseNotifyTarget) Claimed(key lease.Key, holder string, index uint64) {
+	if err := t.setHolder(key, holder, index); err != nil {
+		t.logger.Errorf("cannot record %q holding lease %q: %v", holder, key.Lease, err)
+	}
+}
+
+// Expired is part of raftlease.NotifyTarget.
+func (t *leaseNotifyTarget) Expired(key lease.Key, index uint64) {
+	if err := t.setHolder(key, "", index); err != nil {
+		t.logger.Errorf("cannot record expiry of lease %q: %v", key.Lease, err)
+	}
+}
+
+// setHolder records the lease's holder, unless a later raft log
+// entry has already changed it. The document is kept when the lease
+// expires, so that a late claim notification can't bring back the
+// old holder.
+func (t *leaseNotifyTarget) setHolder(key lease.Key, holder string, index uint64) error {
+	coll, closer := t.st.db().GetCollection(leaseHoldersC)
+	defer closer()
+
+	docID := leaseHolderDocId(key)
+	buildTxn := func(int) ([]txn.Op, error) {
+		var doc leaseHolderDoc
+		err := coll.FindId(docID).One(&doc)
+		if err == mgo.ErrNotFound {
+			return []txn.Op{{
+				C:      leaseHoldersC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &leaseHolderDoc{
+					DocID:     docID,
+					Namespace: key.Namespace,
+					ModelUUID: key.ModelUUID,
+					Lease:     key.Lease,
+					Holder:    holder,
+					Index:     index,
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Index >= index {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      leaseHoldersC,
+			Id:     docID,
+			Assert: bson.D{{"index", doc.Index}},
+			Update: bson.D{{"$set", bson.D{
+				{"holder", holder},
+				{"index", index},
+			}}},
+		}}, nil
+	}
+	return errors.Trace(t.st.db().Run(buildTxn))
+}
+
+// LeaseTrapdoorFunc returns a raftlease.TrapdoorFunc whose trapdoors
+// replace a supplied *[]txn.Op with one that asserts that the holder
+// still holds the lease, according to the records kept by the
+// LeaseNotifyTarget.
+func LeaseTrapdoorFunc() raftlease.TrapdoorFunc {
+	return func(key lease.Key, holder string) lease.Trapdoor {
+		op := txn.Op{
+			C:      leaseHoldersC,
+			Id:     leaseHolderDocId(key),
+			Assert: bson.D{{"holder", holder}},
+		}
+		return func(out interface{}) error {
+			outPtr, ok := out.(*[]txn.Op)
+			if !ok {
+				return errors.NotValidf("expected *[]txn.Op; %T", out)
+			}
+			*outPtr = []txn.Op{op}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/lease"
+)
+
+type leaseHoldersSuite struct {
+	internalStateSuite
+}
+
+var _ = gc.Suite(&leaseHoldersSuite{})
+
+func (s *leaseHoldersSuite) assertHolder(c *gc.C, key lease.Key, holder string) {
+	coll, closer := s.state.db().GetCollection(leaseHoldersC)
+	defer closer()
+	var doc leaseHolderDoc
+	err := coll.FindId(leaseHolderDocId(key)).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc.Holder, gc.Equals, holder)
+}
+
+func (s *leaseHoldersSuite) TestNotifyTarget(c *gc.C) {
+	key := lease.Key{
+		Namespace: applicationLeadershipNamespace,
+		ModelUUID: s.state.ModelUUID(),
+		Lease:     "mysql",
+	}
+	target := s.state.LeaseNotifyTarget(loggo.GetLogger("test"))
+
+	target.Claimed(key, "mysql/0", 1)
+	s.assertHolder(c, key, "mysql/0")
+	target.Expired(key, 3)
+	s.assertHolder(c, key, "")
+
+	// A late notification can't undo a later change.
+	target.Claimed(key, "mysql/0", 2)
+	s.assertHolder(c, key, "")
+
+	target.Claimed(key, "mysql/1", 4)
+	s.assertHolder(c, key, "mysql/1")
+}
+
+func (s *leaseHoldersSuite) TestTrapdoor(c *gc.C) {
+	key := lease.Key{
+		Namespace: applicationLeadershipNamespace,
+		ModelUUID: s.state.ModelUUID(),
+		Lease:     "mysql",
+	}
+	target := s.state.LeaseNotifyTarget(loggo.GetLogger("test"))
+	target.Claimed(key, "mysql/0", 1)
+
+	trapdoor := LeaseTrapdoorFunc()
+	var ops []txn.Op
+	err := trapdoor(key, "mysql/0")(&ops)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.state.db().RunTransaction(ops)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once the lease has expired the old holder's
+	// assertion fails.
+	target.Expired(key, 2)
+	err = s.state.db().RunTransaction(ops)
+	c.Assert(err, gc.Equals, txn.ErrAborted)
+
+	err = trapdoor(key, "mysql/0")("bad")
+	c.Assert(err, gc.ErrorMatches, `expected \*\[\]txn.Op; string not valid`)
+}
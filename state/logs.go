@@ -312,8 +312,12 @@ type LogRecord struct {
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
 type LogTailerParams struct {
-	StartID       int64
-	StartTime     time.Time
+	StartID   int64
+	StartTime time.Time
+	// EndTime, if non-zero, is the time after which no records are
+	// returned. Once EndTime has passed the tailer stops rather than
+	// waiting for new records.
+	EndTime       time.Time
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -321,7 +325,12 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+	// IncludeMessage and ExcludeMessage hold regular expressions
+	// matched against the log message. As with the entity and module
+	// filters, the patterns in each list are ORed together.
+	IncludeMessage []string
+	ExcludeMessage []string
+	Oplog          *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -422,6 +431,10 @@ func (t *logTailer) loop() error {
 	if t.params.NoTail {
 		return nil
 	}
+	if !t.params.EndTime.IsZero() && !t.params.EndTime.After(time.Now()) {
+		// No new records can fall within the requested window.
+		return nil
+	}
 
 	return t.tailOplog()
 }
@@ -567,10 +580,16 @@ func (t *logTailer) tailOplog() error {
 	// a good value, or end the method.
 	deserialisationFailures := 0
 	skipCount := 0
+	var endTimer <-chan time.Time
+	if !t.params.EndTime.IsZero() {
+		endTimer = time.After(t.params.EndTime.Sub(time.Now()))
+	}
 	for {
 		select {
 		case <-t.tomb.Dying():
 			return tomb.ErrDying
+		case <-endTimer:
+			return nil
 		case oplogDoc, ok := <-oplogTailer.Out():
 			if !ok {
 				return errors.Annotate(oplogTailer.Err(), "oplog tailer died")
@@ -618,8 +637,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeRange := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeRange["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeRange["$lte"] = params.EndTime.UnixNano()
+	}
+	if len(timeRange) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeRange})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -640,6 +666,14 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if len(params.IncludeMessage) > 0 {
+		sel = append(sel,
+			bson.DocElem{"x", bson.RegEx{Pattern: makeMessagePattern(params.IncludeMessage)}})
+	}
+	if len(params.ExcludeMessage) > 0 {
+		sel = append(sel,
+			bson.DocElem{"x", bson.M{"$not": bson.RegEx{Pattern: makeMessagePattern(params.ExcludeMessage)}}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -666,6 +700,12 @@ func makeModulePattern(modules []string) string {
 	return `^(` + strings.Join(patterns, "|") + `)(\..+)?$`
 }
 
+func makeMessagePattern(patterns []string) string {
+	// The patterns are unanchored, so plain text matches anywhere
+	// within the message.
+	return `(` + strings.Join(patterns, "|") + `)`
+}
+
 func newRecentIdTracker(maxLen int) *recentIdTracker {
 	return &recentIdTracker{
 		ids: deque.NewWithMaxLen(maxLen),
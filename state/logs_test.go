@@ -781,6 +781,70 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeMessage(c *gc.C) {
+	hook := logTemplate{Message: `hook "install" failed: exit status 1`}
+	other := logTemplate{Message: "starting"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, hook)
+		s.writeLogs(c, s.otherUUID, 1, other)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: []string{"hook .* failed", "no match"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, hook)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestExcludeMessage(c *gc.C) {
+	noisy := logTemplate{Message: "skipping update-status"}
+	wanted := logTemplate{Message: "ran hook"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, noisy)
+		s.writeLogs(c, s.otherUUID, 1, wanted)
+		s.writeLogs(c, s.otherUUID, 1, noisy)
+	}
+	params := state.LogTailerParams{
+		ExcludeMessage: []string{"update-status"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, wanted)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestEndTimeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT.Add(-5*time.Second), threshT, 5, want)
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(time.Second), threshT.Add(5*time.Second), 5,
+		logTemplate{Message: "dont want"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		EndTime: threshT,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// The end time has passed, so the tailer stops itself rather
+	// than tailing the oplog.
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/featureflag"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/feature"
@@ -40,17 +41,20 @@ type ExportConfig struct {
 }
 
 // ExportPartial the current model for the State optionally skipping
-// aspects as defined by the ExportConfig.
+// aspects as defined by the ExportConfig. Application leaders are not
+// included.
 func (st *State) ExportPartial(cfg ExportConfig) (description.Model, error) {
-	return st.exportImpl(cfg)
+	return st.exportImpl(cfg, map[string]string{})
 }
 
-// Export the current model for the State.
-func (st *State) Export() (description.Model, error) {
-	return st.exportImpl(ExportConfig{})
+// Export the current model for the State. The leaders map holds the
+// name of the leader unit for each application, as read from the
+// controller's lease manager.
+func (st *State) Export(leaders map[string]string) (description.Model, error) {
+	return st.exportImpl(ExportConfig{}, leaders)
 }
 
-func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
+func (st *State) exportImpl(cfg ExportConfig, leaders map[string]string) (description.Model, error) {
 	dbModel, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -59,6 +63,7 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 		st:      st,
 		cfg:     cfg,
 		dbModel: dbModel,
+		leaders: leaders,
 		logger:  loggo.GetLogger("juju.state.export-model"),
 	}
 	if err := export.readAllStatuses(); err != nil {
@@ -166,6 +171,26 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 		return nil, errors.Trace(err)
 	}
 
+	if err := export.secrets(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := export.operations(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := export.quota(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := export.volumeSnapshots(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := export.settingsHistory(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if err := export.cloudimagemetadata(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -202,6 +227,7 @@ type exporter struct {
 	st      *State
 	dbModel *Model
 	model   description.Model
+	leaders map[string]string
 	logger  loggo.Logger
 
 	annotations             map[string]annotatorDoc
@@ -459,7 +485,11 @@ func (e *exporter) newMachine(exParent description.Machine, machine *Machine, in
 		Size:    tools.Size,
 	})
 
-	for _, args := range e.openedPortsArgsForMachine(machine.Id(), portsData) {
+	openedPorts, err := e.openedPortsArgsForMachine(machine.Id(), portsData)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, args := range openedPorts {
 		exMachine.AddOpenedPorts(args)
 	}
 
@@ -474,13 +504,19 @@ func (e *exporter) newMachine(exParent description.Machine, machine *Machine, in
 	return exMachine, nil
 }
 
-func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []portsDoc) []description.OpenedPortsArgs {
+func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []portsDoc) ([]description.OpenedPortsArgs, error) {
 	var result []description.OpenedPortsArgs
 	for _, doc := range portsData {
 		// Don't bother including a subnet if there are no ports open on it.
 		if doc.MachineID == machineId && len(doc.Ports) > 0 {
 			args := description.OpenedPortsArgs{SubnetID: doc.SubnetID}
 			for _, p := range doc.Ports {
+				if p.Endpoint != "" {
+					// The migration format has no endpoint for a port
+					// range, so the ports would be opened for every
+					// endpoint in the target model.
+					return nil, errors.NotSupportedf("migrating unit %q with ports opened for endpoint %q", p.UnitName, p.Endpoint)
+				}
 				args.OpenedPorts = append(args.OpenedPorts, description.PortRangeArgs{
 					UnitName: p.UnitName,
 					FromPort: p.FromPort,
@@ -491,7 +527,7 @@ func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []ports
 			result = append(result, args)
 		}
 	}
-	return result
+	return result, nil
 }
 
 func (e *exporter) newAddressArgsSlice(a []address) []description.AddressArgs {
@@ -561,11 +597,6 @@ func (e *exporter) applications() error {
 		return errors.Trace(err)
 	}
 
-	leaders, err := e.st.ApplicationLeaders()
-	if err != nil {
-		return errors.Trace(err)
-	}
-
 	payloads, err := e.readAllPayloads()
 	if err != nil {
 		return errors.Trace(err)
@@ -583,6 +614,10 @@ func (e *exporter) applications() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	unitStates, err := e.readAllUnitStates()
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	resourcesSt, err := e.st.Resources()
 	if err != nil {
@@ -591,7 +626,7 @@ func (e *exporter) applications() error {
 
 	for _, application := range applications {
 		applicationUnits := e.units[application.Name()]
-		leader := leaders[application.Name()]
+		leader := e.leaders[application.Name()]
 		resources, err := resourcesSt.ListResources(application.Name())
 		if err != nil {
 			return errors.Trace(err)
@@ -600,6 +635,7 @@ func (e *exporter) applications() error {
 			application:      application,
 			units:            applicationUnits,
 			meterStatus:      meterStatus,
+			unitStates:       unitStates,
 			podSpecs:         podSpecs,
 			cloudServices:    cloudServices,
 			cloudContainers:  cloudContainers,
@@ -661,6 +697,7 @@ type addApplicationContext struct {
 	application      *Application
 	units            []*Unit
 	meterStatus      map[string]*meterStatusDoc
+	unitStates       map[string]map[string]string
 	leader           string
 	payloads         map[string][]payload.FullPayloadInfo
 	resources        resource.ApplicationResources
@@ -697,6 +734,17 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	}
 	delete(e.modelSettings, leadershipKey)
 
+	if len(application.doc.ExposedEndpoints) > 0 {
+		// Exporting the application as simply exposed would open its
+		// ports to everyone in the target model.
+		return errors.NotSupportedf("migrating application %q with per-endpoint expose settings", appName)
+	}
+	if application.doc.CharmRollout != nil {
+		// The units' target charms aren't exported, so the rollout
+		// must be completed or rolled back first.
+		return errors.NotSupportedf("migrating application %q during a charm rollout", appName)
+	}
+
 	args := description.ApplicationArgs{
 		Tag:                  application.ApplicationTag(),
 		Type:                 e.model.Type(),
@@ -775,6 +823,11 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 		if cloudContainer, found := ctx.cloudContainers[unit.globalKey()]; found {
 			args.CloudContainer = e.cloudContainer(cloudContainer)
 		}
+		if len(ctx.unitStates[unit.globalKey()]) > 0 {
+			// The model description has no place for unit state yet,
+			// so exporting the unit would silently lose it.
+			return errors.NotSupportedf("migrating unit %q with charm state", unit.Name())
+		}
 		exUnit := exApplication.AddUnit(args)
 
 		e.setUnitResources(exUnit, ctx.resources.UnitResources)
@@ -1203,6 +1256,110 @@ func (e *exporter) actions() error {
 	return nil
 }
 
+// secrets refuses to export a model that has secrets. The values are
+// encrypted with a key that only this controller holds, and the model
+// description has nowhere to carry them.
+func (e *exporter) secrets() error {
+	secrets, closer := e.st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	count, err := secrets.Find(nil).Count()
+	if err != nil {
+		return errors.Annotate(err, "cannot count secrets")
+	}
+	if count > 0 {
+		return errors.NotSupportedf("migrating model with %d secrets", count)
+	}
+	return nil
+}
+
+// operations refuses to export a model while any operation is still
+// pending or running, since the target controller wouldn't know to
+// carry on with it. Finished operations are only a record of what was
+// done, like the actions they ran, and are left behind.
+func (e *exporter) operations() error {
+	operations, closer := e.st.db().GetCollection(operationsC)
+	defer closer()
+
+	var doc operationDoc
+	err := operations.Find(bson.D{{"status", bson.D{{"$in", []OperationStatus{
+		OperationPending, OperationRunning,
+	}}}}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot get operations")
+	}
+	return errors.NotSupportedf("migrating model with %s operation %q", doc.Status, doc.Id)
+}
+
+// quota refuses to export a model with resource limits, rather than
+// letting it arrive on the target controller unlimited.
+func (e *exporter) quota() error {
+	q, err := readQuota(e.st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(q) > 0 {
+		return errors.NotSupportedf("migrating model with a quota")
+	}
+	return nil
+}
+
+// settingsHistory warns that the history of charm config and model
+// config changes is left behind. The model description has nowhere to
+// keep the earlier revisions, so the migrated model starts with a
+// fresh history. Refusing to export would rule out migrating nearly
+// every model, as any config change is recorded.
+func (e *exporter) settingsHistory() error {
+	history, closer := e.st.db().GetCollection(settingsHistoryC)
+	defer closer()
+
+	count, err := history.Find(nil).Count()
+	if err != nil {
+		return errors.Annotate(err, "cannot count settings history")
+	}
+	if count > 0 {
+		e.logger.Warningf("not exporting %d settings history revisions; the migrated model starts with a fresh history", count)
+	}
+	return nil
+}
+
+// volumeSnapshots refuses to export a model with volume snapshots, or
+// with storage that is to be created from one. The snapshots aren't
+// migrated, so storage constraints and unprovisioned volumes naming
+// them couldn't be honoured by the target controller.
+func (e *exporter) volumeSnapshots() error {
+	snapshots, closer := e.st.db().GetCollection(volumeSnapshotsC)
+	defer closer()
+	count, err := snapshots.Find(nil).Count()
+	if err != nil {
+		return errors.Annotate(err, "cannot count volume snapshots")
+	}
+	if count > 0 {
+		return errors.NotSupportedf("migrating model with volume snapshots")
+	}
+
+	for _, doc := range e.modelStorageConstraints {
+		for name, cons := range doc.Constraints {
+			if cons.Snapshot != "" {
+				return errors.NotSupportedf("migrating model with storage %q restored from volume snapshot %q", name, cons.Snapshot)
+			}
+		}
+	}
+
+	volumes, closer := e.st.db().GetCollection(volumesC)
+	defer closer()
+	var doc volumeDoc
+	err = volumes.Find(bson.D{{"params.snapshot", bson.D{{"$exists", true}}}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot get volumes")
+	}
+	return errors.NotSupportedf("migrating model with volume %q to be restored from volume snapshot %q", doc.Name, doc.Params.Snapshot)
+}
+
 func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 	relationScopes, closer := e.st.db().GetCollection(relationScopesC)
 	defer closer()
@@ -1335,6 +1492,23 @@ func (e *exporter) readAllCloudContainers() (map[string]*cloudContainerDoc, erro
 	return result, nil
 }
 
+func (e *exporter) readAllUnitStates() (map[string]map[string]string, error) {
+	unitStates, closer := e.st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	var docs []unitStateDoc
+	err := unitStates.Find(nil).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get all unit state docs")
+	}
+	e.logger.Debugf("found %d unit state docs", len(docs))
+	result := make(map[string]map[string]string)
+	for _, doc := range docs {
+		result[e.st.localID(doc.DocID)] = unescapeUnitState(doc.State)
+	}
+	return result, nil
+}
+
 func (e *exporter) cloudContainer(doc *cloudContainerDoc) *description.CloudContainerArgs {
 	result := &description.CloudContainerArgs{
 		ProviderId: doc.ProviderId,
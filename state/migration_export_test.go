@@ -22,6 +22,7 @@ import (
 
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/network"
@@ -81,9 +82,7 @@ func (s *MigrationBaseSuite) primeStatusHistory(c *gc.C, entity statusSetter, st
 	}, 0, "")
 }
 
-func (s *MigrationBaseSuite) makeApplicationWithLeader(c *gc.C, applicationname string, count int, leader int) {
-	c.Assert(leader < count, jc.IsTrue)
-	units := make([]*state.Unit, count)
+func (s *MigrationBaseSuite) makeApplicationWithUnits(c *gc.C, applicationname string, count int) {
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Name: applicationname,
 		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
@@ -91,15 +90,10 @@ func (s *MigrationBaseSuite) makeApplicationWithLeader(c *gc.C, applicationname
 		}),
 	})
 	for i := 0; i < count; i++ {
-		units[i] = s.Factory.MakeUnit(c, &factory.UnitParams{
+		s.Factory.MakeUnit(c, &factory.UnitParams{
 			Application: application,
 		})
 	}
-	err := s.State.LeadershipClaimer().ClaimLeadership(
-		application.Name(),
-		units[leader].Name(),
-		time.Minute)
-	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *MigrationBaseSuite) makeUnitWithStorage(c *gc.C) (*state.Application, *state.Unit, names.StorageTag) {
@@ -168,7 +162,7 @@ func (s *MigrationExportSuite) TestModelInfo(c *gc.C) {
 	err = s.Model.SetEnvironVersion(environVersion)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(model.Type(), gc.Equals, string(s.Model.Type()))
@@ -218,7 +212,7 @@ func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	err = state.UpdateModelUserLastConnection(s.State, bob, lastConnection)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	users := model.Users()
@@ -247,7 +241,7 @@ func (s *MigrationExportSuite) TestSLAs(c *gc.C) {
 	err := s.State.SetSLA("essential", "bob", []byte("creds"))
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	sla := model.SLA()
@@ -260,7 +254,7 @@ func (s *MigrationExportSuite) TestMeterStatus(c *gc.C) {
 	err := s.State.SetModelMeterStatus("RED", "red info message")
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	sla := model.MeterStatus()
@@ -288,7 +282,7 @@ func (s *MigrationExportSuite) assertMachinesMigrated(c *gc.C, cons constraints.
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, machine1, status.Started, addedHistoryCount)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	machines := model.Machines()
@@ -343,7 +337,7 @@ func (s *MigrationExportSuite) TestMachineDevices(c *gc.C) {
 	err := machine.SetMachineBlockDevices(sda, sdb)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 	machines := model.Machines()
 	c.Assert(machines, gc.HasLen, 1)
@@ -426,7 +420,7 @@ func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, st *state.Stat
 		c.Assert(err, jc.ErrorIsNil)
 	}
 
-	model, err := st.Export()
+	model, err := st.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	applications := model.Applications()
@@ -488,7 +482,7 @@ func (s *MigrationExportSuite) TestMultipleApplications(c *gc.C) {
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "second"})
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "third"})
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	applications := model.Applications()
@@ -539,7 +533,7 @@ func (s *MigrationExportSuite) assertMigrateUnits(c *gc.C, st *state.State) {
 		c.Assert(err, jc.ErrorIsNil)
 	}
 
-	model, err := st.Export()
+	model, err := st.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	applications := model.Applications()
@@ -603,10 +597,13 @@ func (s *MigrationExportSuite) assertMigrateUnits(c *gc.C, st *state.State) {
 }
 
 func (s *MigrationExportSuite) TestApplicationLeadership(c *gc.C) {
-	s.makeApplicationWithLeader(c, "mysql", 2, 1)
-	s.makeApplicationWithLeader(c, "wordpress", 4, 2)
+	s.makeApplicationWithUnits(c, "mysql", 2)
+	s.makeApplicationWithUnits(c, "wordpress", 4)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{
+		"mysql":     "mysql/1",
+		"wordpress": "wordpress/2",
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	leaders := make(map[string]string)
@@ -624,7 +621,7 @@ func (s *MigrationExportSuite) TestUnitsOpenPorts(c *gc.C) {
 	err := unit.OpenPorts("tcp", 1234, 2345)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	machines := model.Machines()
@@ -640,6 +637,44 @@ func (s *MigrationExportSuite) TestUnitsOpenPorts(c *gc.C) {
 	c.Assert(opened[0].UnitName(), gc.Equals, unit.Name())
 }
 
+func (s *MigrationExportSuite) TestUnitsOpenEndpointPorts(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err := unit.OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export(map[string]string{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `.*migrating unit "wordpress/0" with ports opened for endpoint "url" not supported`)
+}
+
+func (s *MigrationExportSuite) TestApplicationExposedEndpoints(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	err := app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"":    {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"url": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export(map[string]string{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `.*migrating application "wordpress" with per-endpoint expose settings not supported`)
+}
+
+func (s *MigrationExportSuite) TestUnitsState(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.SetState(map[string]string{"foo.bar": "baz"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export(map[string]string{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `migrating unit "`+unit.Name()+`" with charm state not supported`)
+}
+
 func (s *MigrationExportSuite) TestEndpointBindings(c *gc.C) {
 	s.Factory.MakeSpace(c, &factory.SpaceParams{
 		Name: "one", ProviderID: network.Id("provider"), IsPublic: true})
@@ -647,7 +682,7 @@ func (s *MigrationExportSuite) TestEndpointBindings(c *gc.C) {
 		c, s.State, "wordpress", state.AddTestingCharm(c, s.State, "wordpress"),
 		map[string]string{"db": "one"})
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	apps := model.Applications()
@@ -688,7 +723,7 @@ func (s *MigrationExportSuite) TestRelations(c *gc.C) {
 	err = ru.EnterScope(mysqlSettings)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	rels := model.Relations()
@@ -779,7 +814,7 @@ func (s *MigrationExportSuite) TestSubordinateRelations(c *gc.C) {
 		setTools(unit)
 	}
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	rels := model.Relations()
@@ -790,7 +825,7 @@ func (s *MigrationExportSuite) TestSpaces(c *gc.C) {
 	s.Factory.MakeSpace(c, &factory.SpaceParams{
 		Name: "one", ProviderID: network.Id("provider"), IsPublic: true})
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	spaces := model.Spaces()
@@ -806,7 +841,7 @@ func (s *MigrationExportSuite) TestMultipleSpaces(c *gc.C) {
 	s.Factory.MakeSpace(c, &factory.SpaceParams{Name: "two"})
 	s.Factory.MakeSpace(c, &factory.SpaceParams{Name: "three"})
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(model.Spaces(), gc.HasLen, 3)
 }
@@ -822,7 +857,7 @@ func (s *MigrationExportSuite) TestLinkLayerDevices(c *gc.C) {
 	err := machine.SetLinkLayerDevices(deviceArgs)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	devices := model.LinkLayerDevices()
@@ -867,7 +902,7 @@ func (s *MigrationExportSuite) TestSubnets(c *gc.C) {
 	_, err = s.State.AddSpace("bam", "", nil, true)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	subnets := model.Subnets()
@@ -907,7 +942,7 @@ func (s *MigrationExportSuite) TestIPAddresses(c *gc.C) {
 	err = machine.SetDevicesAddresses(args)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	addresses := model.IPAddresses()
@@ -964,7 +999,7 @@ func (s *MigrationExportSuite) TestSSHHostKeys(c *gc.C) {
 	err := s.State.SetSSHHostKeys(machine.MachineTag(), []string{"bam", "mam"})
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	keys := model.SSHHostKeys()
@@ -1008,7 +1043,7 @@ func (s *MigrationExportSuite) TestCloudImageMetadata(c *gc.C) {
 	err := s.State.CloudImageMetadataStorage.SaveMetadata(metadata)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	images := model.CloudImageMetadata()
@@ -1056,6 +1091,68 @@ func (s *MigrationExportSuite) TestCloudImageMetadataSkipped(c *gc.C) {
 	c.Assert(images, gc.HasLen, 0)
 }
 
+func (s *MigrationExportSuite) TestSecrets(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	controllerKey, err := state.NewControllerSecretsKey()
+	c.Assert(err, jc.ErrorIsNil)
+	secrets := state.NewSecrets(s.State, state.NewControllerSecretsBackend(s.State, controllerKey))
+	_, err = secrets.CreateSecret(state.CreateSecretParams{
+		Owner: app.Name(),
+		Name:  "password",
+		Value: map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export(map[string]string{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `migrating model with 1 secrets not supported`)
+}
+
+func (s *MigrationExportSuite) TestOperations(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"}),
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = m.AddOperation(state.OperationArgs{
+		Application: app.Name(),
+		ActionName:  "snapshot",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export(map[string]string{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `migrating model with pending operation "0" not supported`)
+}
+
+func (s *MigrationExportSuite) TestQuota(c *gc.C) {
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetQuota(quota.Quota{quota.Units: 10})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export(map[string]string{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `migrating model with a quota not supported`)
+}
+
+func (s *MigrationExportSuite) TestSettingsHistory(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	err := app.UpdateCharmConfig(charm.Settings{"blog-title": "history"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The history is left behind, but doesn't stop the export.
+	model, err := s.State.Export(map[string]string{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Applications(), gc.HasLen, 1)
+	c.Assert(model.Applications()[0].CharmConfig(), jc.DeepEquals, map[string]interface{}{
+		"blog-title": "history",
+	})
+}
+
 func (s *MigrationExportSuite) TestActions(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
@@ -1067,7 +1164,7 @@ func (s *MigrationExportSuite) TestActions(c *gc.C) {
 	_, err = m.EnqueueAction(machine.MachineTag(), "foo", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 	actions := model.Actions()
 	c.Assert(actions, gc.HasLen, 1)
@@ -1136,7 +1233,7 @@ func (s *MigrationExportSuite) TestVolumes(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	volumes := model.Volumes()
@@ -1212,7 +1309,7 @@ func (s *MigrationExportSuite) TestFilesystems(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	filesystems := model.Filesystems()
@@ -1257,7 +1354,7 @@ func (s *MigrationExportSuite) TestFilesystems(c *gc.C) {
 func (s *MigrationExportSuite) TestStorage(c *gc.C) {
 	_, u, storageTag := s.makeUnitWithStorage(c)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	apps := model.Applications()
@@ -1298,7 +1395,7 @@ func (s *MigrationExportSuite) TestStoragePools(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	pools := model.StoragePools()
@@ -1327,7 +1424,7 @@ func (s *MigrationExportSuite) TestPayloads(c *gc.C) {
 	err = up.Track(original)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	applications := model.Applications()
@@ -1392,7 +1489,7 @@ func (s *MigrationExportSuite) TestResources(c *gc.C) {
 	err = st.SetCharmStoreResources(app.Name(), []charmresource.Resource{res3}, time.Now())
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	applications := model.Applications()
@@ -1530,7 +1627,7 @@ func (s *MigrationExportSuite) TestRemoteApplications(c *gc.C) {
 	_, err = s.State.AddRelation(eps...)
 	c.Assert(err, jc.ErrorIsNil)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(model.RemoteApplications(), gc.HasLen, 1)
@@ -1595,7 +1692,7 @@ func checkSubnetMatches(c *gc.C, actual description.Subnet, original state.Remot
 }
 
 func (s *MigrationExportSuite) TestModelStatus(c *gc.C) {
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(model.Status().Value(), gc.Equals, "available")
@@ -1607,7 +1704,7 @@ func (s *MigrationExportSuite) TestTooManyStatusHistories(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, nil)
 	s.primeStatusHistory(c, machine, status.Started, 21)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(model.Machines(), gc.HasLen, 1)
@@ -1648,7 +1745,7 @@ func (s *MigrationExportSuite) TestRelationWithNoStatus(c *gc.C) {
 
 	state.RemoveRelationStatus(c, rel)
 
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	rels := model.Relations()
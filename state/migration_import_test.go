@@ -54,7 +54,7 @@ func (s *MigrationImportSuite) checkStatusHistory(c *gc.C, exported, imported st
 }
 
 func (s *MigrationImportSuite) TestExisting(c *gc.C) {
-	out, err := s.State.Export()
+	out, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	_, _, err = s.State.Import(out)
@@ -62,7 +62,7 @@ func (s *MigrationImportSuite) TestExisting(c *gc.C) {
 }
 
 func (s *MigrationImportSuite) importModel(c *gc.C, st *state.State, transform ...func(map[string]interface{})) (*state.Model, *state.State) {
-	out, err := st.Export()
+	out, err := st.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	if len(transform) > 0 {
@@ -119,7 +119,7 @@ func (s *MigrationImportSuite) TestNewModel(c *gc.C) {
 	err = s.Model.SetAnnotations(original, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 
-	out, err := s.State.Export()
+	out, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	uuid := utils.MustNewUUID().String()
@@ -583,24 +583,6 @@ func (s *MigrationImportSuite) TestCAASApplications(c *gc.C) {
 	c.Assert(cloudService.Addresses(), jc.DeepEquals, []network.Address{addr})
 }
 
-func (s *MigrationImportSuite) TestApplicationLeaders(c *gc.C) {
-	s.makeApplicationWithLeader(c, "mysql", 2, 1)
-	s.makeApplicationWithLeader(c, "wordpress", 4, 2)
-
-	_, newSt := s.importModel(c, s.State)
-
-	leaders := make(map[string]string)
-	leases, err := state.LeadershipLeases(newSt)
-	c.Assert(err, jc.ErrorIsNil)
-	for key, value := range leases {
-		leaders[key.Lease] = value.Holder
-	}
-	c.Assert(leaders, jc.DeepEquals, map[string]string{
-		"mysql":     "mysql/1",
-		"wordpress": "wordpress/2",
-	})
-}
-
 func (s *MigrationImportSuite) TestCharmRevSequencesNotImported(c *gc.C) {
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
@@ -614,7 +596,7 @@ func (s *MigrationImportSuite) TestCharmRevSequencesNotImported(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(nextVal, gc.Equals, 3)
 
-	out, err := s.State.Export()
+	out, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(len(out.Applications()), gc.Equals, 1)
@@ -676,7 +658,7 @@ func (s *MigrationImportSuite) TestApplicationsSubordinatesAfter(c *gc.C) {
 		c.Assert(err, jc.ErrorIsNil)
 	}
 
-	out, err := s.State.Export()
+	out, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	apps := out.Applications()
@@ -1629,7 +1611,7 @@ func (s *MigrationImportSuite) TestRemoteApplications(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	out, err := s.State.Export()
+	out, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	uuid := utils.MustNewUUID().String()
@@ -1780,7 +1762,7 @@ func (s *MigrationImportSuite) TestOneSubordinateTwoGuvnors(c *gc.C) {
 }
 
 func (s *MigrationImportSuite) TestImportingModelWithBlankType(c *gc.C) {
-	model, err := s.State.Export()
+	model, err := s.State.Export(map[string]string{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	newConfig := model.Config()
@@ -190,6 +190,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// we include the name of the leader unit. On import, a new lease
 		// is created for the leader unit.
 		leasesC,
+
+		// Settings history is not migrated; export logs a warning
+		// and the migrated model starts with a fresh history.
+		settingsHistoryC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -204,6 +208,29 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		relationNetworksC,
 		firewallRulesC,
 		dockerResourcesC,
+
+		// Unit state needs to be added to the model description
+		// before it can be exported and imported.
+		unitStatesC,
+
+		// Secrets need to be added to the model description, and
+		// their values re-encrypted for the target controller,
+		// before they can be exported and imported.
+		secretMetadataC,
+		secretValuesC,
+
+		// The model description has no operations yet. Export
+		// refuses models with unfinished operations, and finished
+		// ones are left behind with the source model.
+		operationsC,
+
+		// Export refuses models with a quota until the model
+		// description can hold the limits.
+		quotasC,
+
+		// The model description can't hold volume snapshots,
+		// so export refuses models that have any.
+		volumeSnapshotsC,
 	)
 
 	modelCollections := set.NewStrings()
@@ -374,6 +401,11 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"RelationCount",
 		// TODO(caas)
 		"DesiredScale",
+		// ExposedEndpoints can't be represented in the migration
+		// format yet, so export fails for applications that have them.
+		"ExposedEndpoints",
+		// Likewise, export fails during a charm rollout.
+		"CharmRollout",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -404,6 +436,9 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		// Series and CharmURL also come from the application.
 		"Series",
 		"CharmURL",
+		// Export fails during a charm rollout, the only time a
+		// unit has a target charm.
+		"TargetCharmURL",
 		"TxnRevno",
 	)
 	migrated := set.NewStrings(
@@ -276,6 +276,17 @@ type ValidateConfigFunc func(updateAttrs map[string]interface{}, removeAttrs []s
 // configuration of the model with the provided updateAttrs and
 // removeAttrs.
 func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...ValidateConfigFunc) error {
+	return m.UpdateModelConfigBy("", updateAttrs, removeAttrs, additionalValidation...)
+}
+
+// UpdateModelConfigBy is like UpdateModelConfig, but records the change
+// in the model's config history as made by the named user.
+func (m *Model) UpdateModelConfigBy(
+	author string,
+	updateAttrs map[string]interface{},
+	removeAttrs []string,
+	additionalValidation ...ValidateConfigFunc,
+) error {
 	if len(updateAttrs)+len(removeAttrs) == 0 {
 		return nil
 	}
@@ -342,11 +353,56 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 	// Some values require marshalling before storage.
 	validAttrs = config.CoerceForStorage(validAttrs)
 
+	oldSettings := modelSettings.Map()
 	modelSettings.Update(validAttrs)
 	_, ops := modelSettings.settingsUpdateOps()
+	if len(ops) > 0 {
+		historyOps, err := addSettingsRevisionOps(st, modelGlobalKey, oldSettings, modelSettings.Map(), author)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, historyOps...)
+	}
 	return modelSettings.write(ops)
 }
 
+// ModelConfigHistory returns the recent revisions of the model's own
+// config settings, oldest first. The history is not carried over when
+// the model is migrated.
+func (m *Model) ModelConfigHistory() ([]SettingsRevision, error) {
+	return settingsHistory(m.State(), modelGlobalKey)
+}
+
+// RevertModelConfig restores the model's config to the values it had at
+// the given revision. The agent version is never reverted; that can only
+// be changed by upgrading the model. The change is recorded as a new
+// revision, made by the named user.
+func (m *Model) RevertModelConfig(revision int, author string, additionalValidation ...ValidateConfigFunc) error {
+	st := m.State()
+	rev, err := settingsRevision(st, modelGlobalKey, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := readSettings(st.db(), settingsC, modelGlobalKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	updateAttrs := make(map[string]interface{})
+	for key, value := range rev.Settings {
+		if key != config.AgentVersionKey {
+			updateAttrs[key] = value
+		}
+	}
+	var removeAttrs []string
+	for key := range current.Map() {
+		if _, ok := rev.Settings[key]; !ok && key != config.AgentVersionKey {
+			removeAttrs = append(removeAttrs, key)
+		}
+	}
+	err = m.UpdateModelConfigBy(author, updateAttrs, removeAttrs, additionalValidation...)
+	return errors.Annotatef(err, "reverting to revision %d", revision)
+}
+
 type modelConfigSourceFunc func() (attrValues, error)
 
 type modelConfigSource struct {
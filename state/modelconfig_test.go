@@ -259,6 +259,81 @@ func (s *ModelConfigSuite) TestUpdateModelConfigPreferredOverRemove(c *gc.C) {
 	c.Assert(ok, jc.IsFalse)
 }
 
+func (s *ModelConfigSuite) TestModelConfigHistory(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"arbitrary-key": "shazam!"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.UpdateModelConfig(nil, []string{"arbitrary-key"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.Model.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	_, ok := history[0].Settings["arbitrary-key"]
+	c.Check(ok, jc.IsFalse)
+	c.Check(history[1].Settings["arbitrary-key"], gc.Equals, "shazam!")
+	_, ok = history[2].Settings["arbitrary-key"]
+	c.Check(ok, jc.IsFalse)
+	for i, rev := range history {
+		c.Check(rev.Revision, gc.Equals, i+1)
+	}
+}
+
+func (s *ModelConfigSuite) TestModelConfigHistoryAuthor(c *gc.C) {
+	err := s.Model.UpdateModelConfigBy("bob", map[string]interface{}{"arbitrary-key": "shazam!"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.Model.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Author, gc.Equals, "")
+	c.Check(history[1].Author, gc.Equals, "bob")
+}
+
+func (s *ModelConfigSuite) TestRevertModelConfig(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"arbitrary-key": "shazam!"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"another-key": "kaboom"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.RevertModelConfig(2, "bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	attrs := cfg.AllAttrs()
+	c.Check(attrs["arbitrary-key"], gc.Equals, "shazam!")
+	_, ok := attrs["another-key"]
+	c.Check(ok, jc.IsFalse)
+
+	history, err := s.Model.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 4)
+	c.Check(history[3].Author, gc.Equals, "bob")
+}
+
+func (s *ModelConfigSuite) TestRevertModelConfigKeepsAgentVersion(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"arbitrary-key": "shazam!"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"agent-version": "2.99.0"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.RevertModelConfig(1, "bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	agentVersion, ok := cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(agentVersion.String(), gc.Equals, "2.99.0")
+	_, ok = cfg.AllAttrs()["arbitrary-key"]
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *ModelConfigSuite) TestRevertModelConfigNotFound(c *gc.C) {
+	err := s.Model.RevertModelConfig(42, "bob")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 type ModelConfigSourceSuite struct {
 	ConnSuite
 }
@@ -0,0 +1,644 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+var operationLogger = loggo.GetLogger("juju.state.operation")
+
+// OperationStatus represents the state of an operation.
+type OperationStatus string
+
+const (
+	// OperationPending is the status of an operation that hasn't
+	// started yet, either because it was only just added or because
+	// it is scheduled to start later.
+	OperationPending OperationStatus = "pending"
+
+	// OperationRunning indicates that actions are being run on the
+	// operation's units.
+	OperationRunning OperationStatus = "running"
+
+	// OperationCompleted indicates that the action has completed
+	// successfully on all of the operation's units.
+	OperationCompleted OperationStatus = "completed"
+
+	// OperationFailed indicates that the operation finished, but the
+	// action failed or couldn't be run on at least one unit.
+	OperationFailed OperationStatus = "failed"
+)
+
+// operationTaskDoc records the progress of an operation's action on
+// a single unit.
+type operationTaskDoc struct {
+	// Unit is the name of the unit the action is run on.
+	Unit string `bson:"unit"`
+
+	// ActionId is the id of the action once it has been enqueued.
+	ActionId string `bson:"action-id,omitempty"`
+
+	// Status is the last known status of the action, or empty if
+	// the action hasn't been enqueued yet.
+	Status ActionStatus `bson:"status,omitempty"`
+
+	// Message holds the reason the action couldn't be enqueued, if
+	// any.
+	Message string `bson:"message,omitempty"`
+}
+
+func (t operationTaskDoc) finished() bool {
+	switch t.Status {
+	case ActionCompleted, ActionFailed, ActionCancelled:
+		return true
+	}
+	return false
+}
+
+func (t operationTaskDoc) failed() bool {
+	return t.Status == ActionFailed || t.Status == ActionCancelled
+}
+
+// operationDoc holds an action that is run on a set of units in
+// batches by the operation runner worker.
+type operationDoc struct {
+	DocId         string                 `bson:"_id"`
+	ModelUUID     string                 `bson:"model-uuid"`
+	Id            string                 `bson:"id"`
+	Application   string                 `bson:"application,omitempty"`
+	ActionName    string                 `bson:"action-name"`
+	Parameters    map[string]interface{} `bson:"parameters"`
+	MaxParallel   int                    `bson:"max-parallel"`
+	BatchSize     int                    `bson:"batch-size"`
+	StopOnFailure bool                   `bson:"stop-on-failure"`
+	Scheduled     time.Time              `bson:"scheduled"`
+	Enqueued      time.Time              `bson:"enqueued"`
+	Started       time.Time              `bson:"started"`
+	Completed     time.Time              `bson:"completed"`
+	Status        OperationStatus        `bson:"status"`
+	Message       string                 `bson:"message"`
+	Tasks         []operationTaskDoc     `bson:"tasks"`
+	TxnRevno      int64                  `bson:"txn-revno"`
+}
+
+// OperationTask describes the progress of an operation's action on
+// one unit.
+type OperationTask struct {
+	// Unit is the name of the unit.
+	Unit string
+
+	// ActionId is the id of the action enqueued on the unit, or
+	// empty if it hasn't been enqueued yet.
+	ActionId string
+
+	// Status is the last known status of the action, or empty if
+	// it hasn't been enqueued yet.
+	Status ActionStatus
+
+	// Message explains why the action couldn't be enqueued, if it
+	// couldn't.
+	Message string
+}
+
+// Operation represents an action that is run across a number of units,
+// with limits on how many units run the action at once.
+type Operation struct {
+	st  *State
+	doc operationDoc
+}
+
+// Id returns the operation's id.
+func (op *Operation) Id() string {
+	return op.doc.Id
+}
+
+// Application returns the name of the application whose units the
+// operation runs on, or "" if it was given an explicit list of units.
+func (op *Operation) Application() string {
+	return op.doc.Application
+}
+
+// ActionName returns the name of the action being run.
+func (op *Operation) ActionName() string {
+	return op.doc.ActionName
+}
+
+// Parameters returns the parameters passed to each action.
+func (op *Operation) Parameters() map[string]interface{} {
+	return op.doc.Parameters
+}
+
+// MaxParallel returns the maximum number of units that may run the
+// action at the same time, or 0 if there is no limit.
+func (op *Operation) MaxParallel() int {
+	return op.doc.MaxParallel
+}
+
+// BatchSize returns the number of units in each batch, or 0 if all
+// units are in a single batch. A batch is only started once all of
+// the actions in the previous batch have finished.
+func (op *Operation) BatchSize() int {
+	return op.doc.BatchSize
+}
+
+// StopOnFailure returns whether the operation stops enqueuing actions
+// once an action has failed.
+func (op *Operation) StopOnFailure() bool {
+	return op.doc.StopOnFailure
+}
+
+// Scheduled returns the time the operation is scheduled to start, or
+// the zero time if it starts as soon as possible.
+func (op *Operation) Scheduled() time.Time {
+	return op.doc.Scheduled
+}
+
+// Enqueued returns the time the operation was added.
+func (op *Operation) Enqueued() time.Time {
+	return op.doc.Enqueued
+}
+
+// Started returns the time the first action was enqueued.
+func (op *Operation) Started() time.Time {
+	return op.doc.Started
+}
+
+// Completed returns the time the operation finished.
+func (op *Operation) Completed() time.Time {
+	return op.doc.Completed
+}
+
+// Status returns the operation's status.
+func (op *Operation) Status() OperationStatus {
+	return op.doc.Status
+}
+
+// Message returns any explanation of the operation's status.
+func (op *Operation) Message() string {
+	return op.doc.Message
+}
+
+// Tasks returns the progress of the operation on each of its units,
+// in the order the units are run.
+func (op *Operation) Tasks() []OperationTask {
+	tasks := make([]OperationTask, len(op.doc.Tasks))
+	for i, t := range op.doc.Tasks {
+		tasks[i] = OperationTask{
+			Unit:     t.Unit,
+			ActionId: t.ActionId,
+			Status:   t.Status,
+			Message:  t.Message,
+		}
+	}
+	return tasks
+}
+
+// OperationArgs holds the parameters for adding an operation.
+type OperationArgs struct {
+	// ActionName is the name of the action to run.
+	ActionName string
+
+	// Parameters holds the action's parameters.
+	Parameters map[string]interface{}
+
+	// Application is the name of an application whose units the
+	// action is run on. Exactly one of Application and Units must
+	// be specified.
+	Application string
+
+	// Units holds the names of the units to run the action on, in
+	// order.
+	Units []string
+
+	// MaxParallel is the maximum number of units that may run the
+	// action at the same time; 0 means no limit.
+	MaxParallel int
+
+	// BatchSize is the number of units in each batch; 0 means all
+	// of the units are in one batch.
+	BatchSize int
+
+	// StopOnFailure stops any further actions being enqueued once
+	// an action has failed.
+	StopOnFailure bool
+
+	// Scheduled is the time at which the operation should start;
+	// the zero time means as soon as possible.
+	Scheduled time.Time
+}
+
+// Validate checks that the operation arguments are sensible.
+func (args OperationArgs) Validate() error {
+	if args.ActionName == "" {
+		return errors.NotValidf("empty action name")
+	}
+	if args.Application == "" && len(args.Units) == 0 {
+		return errors.NotValidf("operation without application or units")
+	}
+	if args.Application != "" && len(args.Units) > 0 {
+		return errors.NotValidf("operation with both application and units")
+	}
+	if args.MaxParallel < 0 {
+		return errors.NotValidf("negative max parallel %d", args.MaxParallel)
+	}
+	if args.BatchSize < 0 {
+		return errors.NotValidf("negative batch size %d", args.BatchSize)
+	}
+	return nil
+}
+
+// AddOperation adds an operation that runs an action on a set of units.
+// The action and its parameters are validated against each unit's
+// charm, but no actions are enqueued until the operation runner
+// advances the operation.
+func (m *Model) AddOperation(args OperationArgs) (*Operation, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := m.operationUnits(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tasks := make([]operationTaskDoc, len(units))
+	for i, u := range units {
+		if _, err := u.validateAction(args.ActionName, args.Parameters); err != nil {
+			return nil, errors.Trace(err)
+		}
+		tasks[i] = operationTaskDoc{Unit: u.Name()}
+	}
+
+	seq, err := sequence(m.st, "operation")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	var scheduled time.Time
+	if !args.Scheduled.IsZero() {
+		scheduled = args.Scheduled.UTC()
+	}
+	doc := operationDoc{
+		DocId:         m.st.docID(id),
+		ModelUUID:     m.st.ModelUUID(),
+		Id:            id,
+		Application:   args.Application,
+		ActionName:    args.ActionName,
+		Parameters:    args.Parameters,
+		MaxParallel:   args.MaxParallel,
+		BatchSize:     args.BatchSize,
+		StopOnFailure: args.StopOnFailure,
+		Scheduled:     scheduled,
+		Enqueued:      m.st.nowToTheSecond(),
+		Status:        OperationPending,
+		Tasks:         tasks,
+	}
+	ops := []txn.Op{{
+		C:      operationsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotate(err, "cannot add operation")
+	}
+	return &Operation{st: m.st, doc: doc}, nil
+}
+
+// operationUnits returns the units an operation should run on, in the
+// order they should be run.
+func (m *Model) operationUnits(args OperationArgs) ([]*Unit, error) {
+	if args.Application == "" {
+		units := make([]*Unit, len(args.Units))
+		for i, name := range args.Units {
+			u, err := m.st.Unit(name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			units[i] = u
+		}
+		return units, nil
+	}
+	app, err := m.st.Application(args.Application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return nil, errors.Errorf("application %q has no units", args.Application)
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].UnitTag().Number() < units[j].UnitTag().Number()
+	})
+	return units, nil
+}
+
+// Operation returns the operation with the given id.
+func (m *Model) Operation(id string) (*Operation, error) {
+	operations, closer := m.st.db().GetCollection(operationsC)
+	defer closer()
+
+	var doc operationDoc
+	err := operations.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("operation %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get operation %q", id)
+	}
+	return &Operation{st: m.st, doc: doc}, nil
+}
+
+// AllOperations returns all of the operations in the model.
+func (m *Model) AllOperations() ([]*Operation, error) {
+	return m.findOperations(nil)
+}
+
+func (m *Model) findOperations(query bson.D) ([]*Operation, error) {
+	operations, closer := m.st.db().GetCollection(operationsC)
+	defer closer()
+
+	var docs []operationDoc
+	if err := operations.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get operations")
+	}
+	result := make([]*Operation, len(docs))
+	for i, doc := range docs {
+		result[i] = &Operation{st: m.st, doc: doc}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, _ := strconv.Atoi(result[i].doc.Id)
+		b, _ := strconv.Atoi(result[j].doc.Id)
+		return a < b
+	})
+	return result, nil
+}
+
+// OperationProgress summarises the operations that still need to be
+// advanced.
+type OperationProgress struct {
+	// Running is the number of operations with actions in progress.
+	Running int
+
+	// NextScheduled is the earliest time at which a pending operation
+	// is due to start, or the zero time if none are scheduled.
+	NextScheduled time.Time
+}
+
+// AdvanceOperations updates the progress of all unfinished operations,
+// enqueueing as many further actions for each one as its batch size
+// and parallelism limit allow. It's called by the operation runner
+// whenever an operation changes and periodically while operations are
+// running.
+func (m *Model) AdvanceOperations() (OperationProgress, error) {
+	var progress OperationProgress
+	active, err := m.findOperations(bson.D{{"status", bson.D{{"$in", []OperationStatus{
+		OperationPending, OperationRunning,
+	}}}}})
+	if err != nil {
+		return progress, errors.Trace(err)
+	}
+	now := m.st.nowToTheSecond()
+	for _, op := range active {
+		if op.doc.Status == OperationPending && op.doc.Scheduled.After(now) {
+			if progress.NextScheduled.IsZero() || op.doc.Scheduled.Before(progress.NextScheduled) {
+				progress.NextScheduled = op.doc.Scheduled
+			}
+			continue
+		}
+		if err := op.advance(now); err != nil {
+			return progress, errors.Annotatef(err, "advancing operation %q", op.Id())
+		}
+		if op.doc.Status == OperationRunning {
+			progress.Running++
+		}
+	}
+	return progress, nil
+}
+
+// advance refreshes the status of the operation's actions, enqueues
+// any actions that can now be run, and saves the result if anything
+// changed. The new actions are added in the same transaction that
+// records them against the operation, so they're only enqueued once
+// even if two controllers advance the operation at the same time.
+func (op *Operation) advance(now time.Time) error {
+	model, err := op.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var doc operationDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := op.refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		var ops []txn.Op
+		var err error
+		doc, ops, err = op.advanceOps(model, now)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := op.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	op.doc = doc
+	return nil
+}
+
+// advanceOps works out the operation's new progress, returning the
+// updated document and the transaction operations that record it and
+// enqueue any new actions. No operations are returned if nothing has
+// changed.
+func (op *Operation) advanceOps(model *Model, now time.Time) (operationDoc, []txn.Op, error) {
+	var ops []txn.Op
+	doc := op.doc
+	doc.Tasks = make([]operationTaskDoc, len(op.doc.Tasks))
+	copy(doc.Tasks, op.doc.Tasks)
+
+	// Refresh the status of any actions in progress.
+	inFlight := 0
+	for i, t := range doc.Tasks {
+		if t.ActionId == "" || t.finished() {
+			continue
+		}
+		action, err := model.Action(t.ActionId)
+		if errors.IsNotFound(err) {
+			doc.Tasks[i].Status = ActionFailed
+			doc.Tasks[i].Message = "action not found"
+			continue
+		} else if err != nil {
+			return operationDoc{}, nil, errors.Trace(err)
+		}
+		doc.Tasks[i].Status = action.Status()
+		if !doc.Tasks[i].finished() {
+			inFlight++
+		}
+	}
+
+	firstFailure := -1
+	firstUnfinished := -1
+	for i, t := range doc.Tasks {
+		if t.failed() && firstFailure == -1 {
+			firstFailure = i
+		}
+		if !t.finished() && firstUnfinished == -1 {
+			firstUnfinished = i
+		}
+	}
+
+	stopping := doc.StopOnFailure && firstFailure != -1
+	if !stopping && firstUnfinished != -1 {
+		limit := len(doc.Tasks)
+		if doc.BatchSize > 0 {
+			limit = (firstUnfinished/doc.BatchSize + 1) * doc.BatchSize
+			if limit > len(doc.Tasks) {
+				limit = len(doc.Tasks)
+			}
+		}
+		for i := firstUnfinished; i < limit; i++ {
+			if doc.MaxParallel > 0 && inFlight >= doc.MaxParallel {
+				break
+			}
+			t := &doc.Tasks[i]
+			if t.ActionId != "" || t.finished() {
+				continue
+			}
+			if doc.Started.IsZero() {
+				doc.Started = now
+				doc.Status = OperationRunning
+			}
+			action, actionOps, err := op.enqueueOps(t.Unit)
+			if err != nil {
+				operationLogger.Warningf("operation %s: cannot enqueue action %q on unit %q: %v", doc.Id, doc.ActionName, t.Unit, err)
+				t.Status = ActionFailed
+				t.Message = err.Error()
+				if doc.StopOnFailure {
+					stopping = true
+					break
+				}
+				continue
+			}
+			t.ActionId = op.st.localID(action.DocId)
+			t.Status = action.Status
+			ops = append(ops, actionOps...)
+			inFlight++
+		}
+	}
+
+	if inFlight == 0 {
+		remaining := 0
+		failed := ""
+		for _, t := range doc.Tasks {
+			if !t.finished() {
+				remaining++
+			} else if t.failed() && failed == "" {
+				failed = t.Unit
+			}
+		}
+		switch {
+		case stopping:
+			doc.Status = OperationFailed
+			doc.Message = fmt.Sprintf("stopped after action failed on unit %q", failed)
+			doc.Completed = now
+		case remaining == 0 && failed != "":
+			doc.Status = OperationFailed
+			doc.Message = fmt.Sprintf("action failed on unit %q", failed)
+			doc.Completed = now
+		case remaining == 0:
+			doc.Status = OperationCompleted
+			doc.Completed = now
+		}
+	}
+
+	if operationUnchanged(op.doc, doc) {
+		return doc, nil, nil
+	}
+	ops = append(ops, txn.Op{
+		C:      operationsC,
+		Id:     doc.DocId,
+		Assert: bson.D{{"txn-revno", op.doc.TxnRevno}},
+		Update: bson.D{{"$set", bson.D{
+			{"status", doc.Status},
+			{"message", doc.Message},
+			{"started", doc.Started},
+			{"completed", doc.Completed},
+			{"tasks", doc.Tasks},
+		}}},
+	})
+	return doc, ops, nil
+}
+
+// enqueueOps returns the document for a new action running the
+// operation's action on the named unit, and the transaction operations
+// that add it.
+func (op *Operation) enqueueOps(unitName string) (actionDoc, []txn.Op, error) {
+	unit, err := op.st.Unit(unitName)
+	if err != nil {
+		return actionDoc{}, nil, errors.Trace(err)
+	}
+	if unit.Life() == Dead {
+		return actionDoc{}, nil, ErrDead
+	}
+	// Default values are inserted into the parameters, so give each
+	// unit its own copy.
+	parameters := make(map[string]interface{}, len(op.doc.Parameters))
+	for k, v := range op.doc.Parameters {
+		parameters[k] = v
+	}
+	return unit.addActionOps(op.doc.ActionName, parameters)
+}
+
+// refresh reloads the operation's document from the database.
+func (op *Operation) refresh() error {
+	operations, closer := op.st.db().GetCollection(operationsC)
+	defer closer()
+
+	var doc operationDoc
+	err := operations.FindId(op.doc.DocId).One(&doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("operation %q", op.doc.Id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot get operation %q", op.doc.Id)
+	}
+	op.doc = doc
+	return nil
+}
+
+func operationUnchanged(old, new operationDoc) bool {
+	if old.Status != new.Status ||
+		old.Message != new.Message ||
+		!old.Started.Equal(new.Started) ||
+		!old.Completed.Equal(new.Completed) {
+		return false
+	}
+	for i := range old.Tasks {
+		if old.Tasks[i] != new.Tasks[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// WatchOperations returns a NotifyWatcher that fires when operations
+// are added or changed.
+func (st *State) WatchOperations() NotifyWatcher {
+	return newNotifyCollWatcher(st, operationsC, isLocalID(st))
+}
@@ -0,0 +1,276 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type OperationSuite struct {
+	ConnSuite
+	application *state.Application
+	units       []*state.Unit
+	model       *state.Model
+}
+
+var _ = gc.Suite(&OperationSuite{})
+
+func (s *OperationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", ch)
+	curl, _ := s.application.CharmURL()
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(curl)
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *OperationSuite) addOperation(c *gc.C, args state.OperationArgs) *state.Operation {
+	if args.ActionName == "" {
+		args.ActionName = "snapshot"
+	}
+	if len(args.Units) == 0 {
+		args.Application = "dummy"
+	}
+	op, err := s.model.AddOperation(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return op
+}
+
+func (s *OperationSuite) advance(c *gc.C, id string) (*state.Operation, state.OperationProgress) {
+	progress, err := s.model.AdvanceOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	op, err := s.model.Operation(id)
+	c.Assert(err, jc.ErrorIsNil)
+	return op, progress
+}
+
+func (s *OperationSuite) finishAction(c *gc.C, op *state.Operation, unit string, status state.ActionStatus) {
+	for _, t := range op.Tasks() {
+		if t.Unit != unit {
+			continue
+		}
+		c.Assert(t.ActionId, gc.Not(gc.Equals), "")
+		action, err := s.model.Action(t.ActionId)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = action.Finish(state.ActionResults{Status: status})
+		c.Assert(err, jc.ErrorIsNil)
+		return
+	}
+	c.Fatalf("no task for unit %q", unit)
+}
+
+func taskStatuses(op *state.Operation) []state.ActionStatus {
+	var result []state.ActionStatus
+	for _, t := range op.Tasks() {
+		result = append(result, t.Status)
+	}
+	return result
+}
+
+func (s *OperationSuite) TestAddOperationForApplication(c *gc.C) {
+	op := s.addOperation(c, state.OperationArgs{
+		Parameters:    map[string]interface{}{"outfile": "foo.tar.gz"},
+		MaxParallel:   2,
+		BatchSize:     1,
+		StopOnFailure: true,
+	})
+	c.Assert(op.Id(), gc.Equals, "0")
+	c.Assert(op.Application(), gc.Equals, "dummy")
+	c.Assert(op.ActionName(), gc.Equals, "snapshot")
+	c.Assert(op.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.tar.gz"})
+	c.Assert(op.MaxParallel(), gc.Equals, 2)
+	c.Assert(op.BatchSize(), gc.Equals, 1)
+	c.Assert(op.StopOnFailure(), jc.IsTrue)
+	c.Assert(op.Status(), gc.Equals, state.OperationPending)
+	c.Assert(op.Tasks(), jc.DeepEquals, []state.OperationTask{
+		{Unit: "dummy/0"}, {Unit: "dummy/1"}, {Unit: "dummy/2"},
+	})
+
+	got, err := s.model.Operation(op.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.Tasks(), jc.DeepEquals, op.Tasks())
+
+	all, err := s.model.AllOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *OperationSuite) TestAddOperationValidates(c *gc.C) {
+	_, err := s.model.AddOperation(state.OperationArgs{
+		ActionName:  "snapshot",
+		Application: "dummy",
+		Units:       []string{"dummy/0"},
+	})
+	c.Assert(err, gc.ErrorMatches, "operation with both application and units not valid")
+
+	_, err = s.model.AddOperation(state.OperationArgs{
+		ActionName:  "snapshot",
+		Application: "dummy",
+		MaxParallel: -1,
+	})
+	c.Assert(err, gc.ErrorMatches, "negative max parallel -1 not valid")
+
+	_, err = s.model.AddOperation(state.OperationArgs{
+		ActionName: "nope",
+		Units:      []string{"dummy/1"},
+	})
+	c.Assert(err, gc.ErrorMatches, `action "nope" not defined on unit "dummy/1"`)
+
+	_, err = s.model.AddOperation(state.OperationArgs{
+		ActionName:  "snapshot",
+		Application: "missing",
+	})
+	c.Assert(err, gc.ErrorMatches, `application "missing" not found`)
+}
+
+func (s *OperationSuite) TestOperationNotFound(c *gc.C) {
+	_, err := s.model.Operation("42")
+	c.Assert(err, gc.ErrorMatches, `operation "42" not found`)
+}
+
+func (s *OperationSuite) TestAdvanceInBatches(c *gc.C) {
+	op := s.addOperation(c, state.OperationArgs{BatchSize: 2})
+
+	op, progress := s.advance(c, op.Id())
+	c.Assert(progress.Running, gc.Equals, 1)
+	c.Assert(op.Status(), gc.Equals, state.OperationRunning)
+	c.Assert(op.Started().IsZero(), jc.IsFalse)
+	c.Assert(taskStatuses(op), jc.DeepEquals, []state.ActionStatus{
+		state.ActionPending, state.ActionPending, "",
+	})
+
+	// The second batch doesn't start until the first has finished.
+	s.finishAction(c, op, "dummy/0", state.ActionCompleted)
+	op, _ = s.advance(c, op.Id())
+	c.Assert(taskStatuses(op), jc.DeepEquals, []state.ActionStatus{
+		state.ActionCompleted, state.ActionPending, "",
+	})
+
+	s.finishAction(c, op, "dummy/1", state.ActionCompleted)
+	op, _ = s.advance(c, op.Id())
+	c.Assert(taskStatuses(op), jc.DeepEquals, []state.ActionStatus{
+		state.ActionCompleted, state.ActionCompleted, state.ActionPending,
+	})
+
+	s.finishAction(c, op, "dummy/2", state.ActionCompleted)
+	op, progress = s.advance(c, op.Id())
+	c.Assert(progress.Running, gc.Equals, 0)
+	c.Assert(op.Status(), gc.Equals, state.OperationCompleted)
+	c.Assert(op.Completed().IsZero(), jc.IsFalse)
+}
+
+func (s *OperationSuite) TestAdvanceConcurrently(c *gc.C) {
+	op := s.addOperation(c, state.OperationArgs{})
+
+	// Another controller advances the operation first; the actions
+	// it enqueues must not be enqueued again.
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.model.AdvanceOperations()
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	op, _ = s.advance(c, op.Id())
+	c.Assert(taskStatuses(op), jc.DeepEquals, []state.ActionStatus{
+		state.ActionPending, state.ActionPending, state.ActionPending,
+	})
+	actions, err := s.model.AllActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 3)
+}
+
+func (s *OperationSuite) TestAdvanceMaxParallel(c *gc.C) {
+	op := s.addOperation(c, state.OperationArgs{MaxParallel: 2})
+
+	op, _ = s.advance(c, op.Id())
+	c.Assert(taskStatuses(op), jc.DeepEquals, []state.ActionStatus{
+		state.ActionPending, state.ActionPending, "",
+	})
+
+	// As soon as any action finishes another one is started.
+	s.finishAction(c, op, "dummy/1", state.ActionCompleted)
+	op, _ = s.advance(c, op.Id())
+	c.Assert(taskStatuses(op), jc.DeepEquals, []state.ActionStatus{
+		state.ActionPending, state.ActionCompleted, state.ActionPending,
+	})
+}
+
+func (s *OperationSuite) TestAdvanceContinuesAfterFailure(c *gc.C) {
+	op := s.addOperation(c, state.OperationArgs{MaxParallel: 1})
+
+	op, _ = s.advance(c, op.Id())
+	s.finishAction(c, op, "dummy/0", state.ActionFailed)
+	op, _ = s.advance(c, op.Id())
+	s.finishAction(c, op, "dummy/1", state.ActionCompleted)
+	op, _ = s.advance(c, op.Id())
+	s.finishAction(c, op, "dummy/2", state.ActionCompleted)
+	op, _ = s.advance(c, op.Id())
+
+	c.Assert(op.Status(), gc.Equals, state.OperationFailed)
+	c.Assert(op.Message(), gc.Equals, `action failed on unit "dummy/0"`)
+}
+
+func (s *OperationSuite) TestAdvanceStopOnFailure(c *gc.C) {
+	op := s.addOperation(c, state.OperationArgs{MaxParallel: 1, StopOnFailure: true})
+
+	op, _ = s.advance(c, op.Id())
+	s.finishAction(c, op, "dummy/0", state.ActionFailed)
+	op, progress := s.advance(c, op.Id())
+
+	c.Assert(progress.Running, gc.Equals, 0)
+	c.Assert(op.Status(), gc.Equals, state.OperationFailed)
+	c.Assert(op.Message(), gc.Equals, `stopped after action failed on unit "dummy/0"`)
+	c.Assert(taskStatuses(op), jc.DeepEquals, []state.ActionStatus{
+		state.ActionFailed, "", "",
+	})
+}
+
+func (s *OperationSuite) TestAdvanceScheduled(c *gc.C) {
+	scheduled := s.Clock.Now().Add(time.Hour).Round(time.Second).UTC()
+	op := s.addOperation(c, state.OperationArgs{Scheduled: scheduled})
+	c.Assert(op.Scheduled(), gc.Equals, scheduled)
+
+	op, progress := s.advance(c, op.Id())
+	c.Assert(progress.NextScheduled.Equal(scheduled), jc.IsTrue)
+	c.Assert(op.Status(), gc.Equals, state.OperationPending)
+	c.Assert(taskStatuses(op), jc.DeepEquals, []state.ActionStatus{"", "", ""})
+
+	s.Clock.Advance(time.Hour)
+	op, progress = s.advance(c, op.Id())
+	c.Assert(progress.NextScheduled.IsZero(), jc.IsTrue)
+	c.Assert(op.Status(), gc.Equals, state.OperationRunning)
+}
+
+func (s *OperationSuite) TestWatchOperations(c *gc.C) {
+	w := s.State.WatchOperations()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	op := s.addOperation(c, state.OperationArgs{})
+	wc.AssertOneChange()
+
+	s.advance(c, op.Id())
+	wc.AssertOneChange()
+
+	// Advancing again without any changes doesn't update the operation.
+	s.advance(c, op.Id())
+	wc.AssertNoChange()
+}
@@ -31,12 +31,15 @@ const (
 )
 
 // PortRange represents a single range of ports opened
-// by one unit.
+// by one unit. When Endpoint is set, the range is opened
+// for that endpoint of the unit's application only;
+// otherwise it is opened for all of its endpoints.
 type PortRange struct {
 	UnitName string
 	FromPort int
 	ToPort   int
 	Protocol string
+	Endpoint string `bson:",omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -125,6 +128,10 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 	if prA == prB {
 		return nil
 	}
+	// The same unit may open the same range for several endpoints.
+	if prA.UnitName == prB.UnitName && prA.sameBounds(prB) {
+		return nil
+	}
 	if prA.Protocol != prB.Protocol {
 		return nil
 	}
@@ -134,13 +141,34 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 	return nil
 }
 
+// sameBounds reports whether the two port ranges cover exactly
+// the same ports.
+func (prA PortRange) sameBounds(prB PortRange) bool {
+	return prA.Protocol == prB.Protocol &&
+		prA.FromPort == prB.FromPort &&
+		prA.ToPort == prB.ToPort
+}
+
+// NetworkPortRange returns the ports covered by the port range.
+func (p PortRange) NetworkPortRange() network.PortRange {
+	return network.PortRange{
+		FromPort: p.FromPort,
+		ToPort:   p.ToPort,
+		Protocol: p.Protocol,
+	}
+}
+
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
 	proto := strings.ToLower(p.Protocol)
+	owner := fmt.Sprintf("%q", p.UnitName)
+	if p.Endpoint != "" {
+		owner = fmt.Sprintf("%q, endpoint %q", p.UnitName, p.Endpoint)
+	}
 	if proto == "icmp" {
-		return fmt.Sprintf("%s (%q)", proto, p.UnitName)
+		return fmt.Sprintf("%s (%s)", proto, owner)
 	}
-	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, proto, p.UnitName)
+	return fmt.Sprintf("%d-%d/%s (%s)", p.FromPort, p.ToPort, proto, owner)
 }
 
 // portsDoc represents the state of ports opened on machines for networks
@@ -354,16 +382,17 @@ func (p *Ports) Refresh() error {
 func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	result := make(map[network.PortRange]string)
 	for _, portRange := range p.doc.Ports {
-		rawRange := network.PortRange{
-			FromPort: portRange.FromPort,
-			ToPort:   portRange.ToPort,
-			Protocol: portRange.Protocol,
-		}
-		result[rawRange] = portRange.UnitName
+		result[portRange.NetworkPortRange()] = portRange.UnitName
 	}
 	return result
 }
 
+// AllPorts returns all the port ranges maintained by this document,
+// including the endpoints they were opened for.
+func (p *Ports) AllPorts() []PortRange {
+	return append([]PortRange(nil), p.doc.Ports...)
+}
+
 // Remove removes the ports document from state.
 func (p *Ports) Remove() error {
 	ports := &Ports{st: p.st, doc: p.doc}
@@ -507,17 +536,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
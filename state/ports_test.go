@@ -315,6 +315,38 @@ func (s *PortsDocSuite) TestAllPortRanges(c *gc.C) {
 	c.Assert(ranges[network.PortRange{100, 200, "TCP"}], gc.Equals, s.unit1.Name())
 }
 
+func (s *PortsDocSuite) TestOpenPortsForEndpoints(c *gc.C) {
+	urlRange := state.PortRange{
+		FromPort: 80,
+		ToPort:   80,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+		Endpoint: "url",
+	}
+	dbRange := urlRange
+	dbRange.Endpoint = "db"
+	err := s.portsWithoutSubnet.OpenPorts(urlRange)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.portsWithoutSubnet.OpenPorts(dbRange)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Another unit still can't open the same range.
+	otherRange := urlRange
+	otherRange.UnitName = s.unit2.Name()
+	err = s.portsWithoutSubnet.OpenPorts(otherRange)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-80/tcp \("wordpress/1", endpoint "url"\): port ranges .* conflict`)
+
+	ports, err := state.GetPorts(s.State, s.machine.Id(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.AllPorts(), jc.SameContents, []state.PortRange{urlRange, dbRange})
+
+	err = ports.ClosePorts(urlRange)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = state.GetPorts(s.State, s.machine.Id(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.AllPorts(), jc.DeepEquals, []state.PortRange{dbRange})
+}
+
 func (s *PortsDocSuite) TestICMP(c *gc.C) {
 	portRange := state.PortRange{
 		FromPort: -1,
@@ -0,0 +1,474 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/quota"
+)
+
+// quotaDoc records the resource limits placed on a model.
+type quotaDoc struct {
+	DocID     string            `bson:"_id"`
+	ModelUUID string            `bson:"model-uuid"`
+	Limits    map[string]uint64 `bson:"limits"`
+
+	// Used records the amount of each limited resource that was
+	// in use when resources were last charged to the quota. It
+	// is asserted and updated by every transaction that charges
+	// the quota, so that concurrent additions are serialised.
+	Used map[string]int64 `bson:"used,omitempty"`
+
+	TxnRevno int64 `bson:"txn-revno"`
+}
+
+// Quota returns the resource limits placed on the model.
+func (m *Model) Quota() (quota.Quota, error) {
+	q, err := readQuota(m.st)
+	return q, errors.Trace(err)
+}
+
+// SetQuota replaces the resource limits placed on the model. The
+// limits are checked whenever machines, units or storage are added to
+// the model; resources that are already in use are not affected.
+//
+// Cores and memory may only be limited if the cores and memory of
+// each of the model's top level machines are known, from either its
+// hardware or its constraints.
+func (m *Model) SetQuota(q quota.Quota) error {
+	if err := q.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	limits := make(map[string]uint64, len(q))
+	for resource, limit := range q {
+		limits[resource] = limit
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		if err := checkMachinesSized(m.st, q); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{assertModelActiveOp(m.UUID())}
+		doc, err := readQuotaDoc(m.st)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc == nil {
+			return append(ops, txn.Op{
+				C:      quotasC,
+				Id:     m.st.docID(modelGlobalKey),
+				Assert: txn.DocMissing,
+				Insert: &quotaDoc{Limits: limits},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      quotasC,
+			Id:     m.st.docID(modelGlobalKey),
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{{"limits", limits}}}},
+		}), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set model quota")
+	}
+	return nil
+}
+
+// QuotaUsage returns the amount of each resource that may be limited
+// by a quota that is currently in use by the model.
+func (m *Model) QuotaUsage() (quota.Usage, error) {
+	usage, err := quotaUsage(m.st, quota.Resources...)
+	return usage, errors.Trace(err)
+}
+
+func readQuota(mb modelBackend) (quota.Quota, error) {
+	doc, err := readQuotaDoc(mb)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.quota(), nil
+}
+
+// readQuotaDoc returns the model's quota document, or nil if no quota
+// has been set.
+func readQuotaDoc(mb modelBackend) (*quotaDoc, error) {
+	quotas, closer := mb.db().GetCollection(quotasC)
+	defer closer()
+	var doc quotaDoc
+	err := quotas.FindId(modelGlobalKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot read model quota")
+	}
+	return &doc, nil
+}
+
+func (doc *quotaDoc) quota() quota.Quota {
+	if doc == nil {
+		return quota.Quota{}
+	}
+	q := make(quota.Quota, len(doc.Limits))
+	for resource, limit := range doc.Limits {
+		q[resource] = limit
+	}
+	return q
+}
+
+// quotaOps returns the operations that charge the requested resources
+// to the model's quota, or an error satisfying quota.IsExceeded if
+// adding them to the resources in use would exceed it.
+//
+// The quota document's count of each limited resource is brought up
+// to date with the resources in use, and increased by the amount
+// requested. The operations assert that neither the count nor the
+// limit has changed since they were read, so the count never exceeds
+// the limit and concurrent additions cannot together exceed the quota.
+// As each transaction may hold only one operation on the quota
+// document, the resources added by a transaction must be charged
+// together.
+func quotaOps(mb modelBackend, requested quota.Usage) ([]txn.Op, error) {
+	doc, err := readQuotaDoc(mb)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if doc == nil {
+		// Ensure that a quota set concurrently is not
+		// bypassed.
+		return []txn.Op{{
+			C:      quotasC,
+			Id:     mb.docID(modelGlobalKey),
+			Assert: txn.DocMissing,
+		}}, nil
+	}
+	q := doc.quota()
+	var resources []string
+	for resource, amount := range requested {
+		if _, ok := q[resource]; ok && amount > 0 {
+			resources = append(resources, resource)
+		}
+	}
+	if len(resources) == 0 {
+		return nil, nil
+	}
+	sort.Strings(resources)
+	used, err := quotaUsage(mb, resources...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := q.Check(used, requested); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var assert, inc bson.D
+	for _, resource := range resources {
+		field := "used." + resource
+		assert = append(assert, bson.DocElem{"limits." + resource, q[resource]})
+		if count, ok := doc.Used[resource]; ok {
+			assert = append(assert, bson.DocElem{field, count})
+		} else {
+			assert = append(assert, bson.DocElem{field, bson.D{{"$exists", false}}})
+		}
+		total := int64(used[resource] + requested[resource])
+		inc = append(inc, bson.DocElem{field, total - doc.Used[resource]})
+	}
+	return []txn.Op{{
+		C:      quotasC,
+		Id:     mb.docID(modelGlobalKey),
+		Assert: assert,
+		Update: bson.D{{"$inc", inc}},
+	}}, nil
+}
+
+// checkMachinesSized returns an error if the quota limits the cores or
+// memory of the model's top level machines, and the cores or memory of
+// any of those machines is not known.
+func checkMachinesSized(mb modelBackend, q quota.Quota) error {
+	_, limitsCores := q[quota.Cores]
+	_, limitsMem := q[quota.Memory]
+	if !limitsCores && !limitsMem {
+		return nil
+	}
+	usage, err := machineQuotaUsage(mb)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, resource := range []string{quota.Cores, quota.Memory} {
+		if _, ok := q[resource]; !ok || len(usage.unsized[resource]) == 0 {
+			continue
+		}
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"cannot limit %s: %s of machines %s not known",
+			resource, resource, strings.Join(usage.unsized[resource], ", "),
+		))
+	}
+	return nil
+}
+
+// checkMachineTemplateSized returns an error if the quota limits the
+// cores or memory of the model's top level machines, and the cores or
+// memory of a machine added from the given template would not be
+// known. Such a machine cannot be charged to the quota, so rather than
+// counting it as using none it is refused. The template's constraints
+// must already have been combined with the model's.
+func checkMachineTemplateSized(mb modelBackend, template MachineTemplate) error {
+	q, err := readQuota(mb)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	usage := machineTemplateQuotaUsage(template, false)
+	for resource, constraint := range map[string]string{
+		quota.Cores:  "cores",
+		quota.Memory: "mem",
+	} {
+		if _, ok := q[resource]; !ok {
+			continue
+		}
+		if _, ok := usage[resource]; !ok {
+			return errors.NewNotValid(nil, fmt.Sprintf(
+				"model quota limits %s, cannot add a machine without a %s constraint",
+				resource, constraint,
+			))
+		}
+	}
+	return nil
+}
+
+// quotaUsage returns the amount of each of the given resources in
+// use by the model.
+func quotaUsage(mb modelBackend, resources ...string) (quota.Usage, error) {
+	usage := make(quota.Usage)
+	var haveMachines bool
+	for _, resource := range resources {
+		var err error
+		switch resource {
+		case quota.Machines:
+			usage[resource], err = countDocs(mb, machinesC, bson.D{
+				{"containertype", bson.D{{"$in", []interface{}{"", nil}}}},
+			})
+		case quota.Containers:
+			usage[resource], err = countDocs(mb, machinesC, bson.D{
+				{"containertype", bson.D{{"$nin", []interface{}{"", nil}}}},
+			})
+		case quota.Units:
+			usage[resource], err = countDocs(mb, unitsC, bson.D{
+				{"principal", bson.D{{"$in", []interface{}{"", nil}}}},
+			})
+		case quota.Cores, quota.Memory:
+			if haveMachines {
+				continue
+			}
+			haveMachines = true
+			var machines machinesQuotaUsage
+			machines, err = machineQuotaUsage(mb)
+			usage[quota.Cores], usage[quota.Memory] = machines.cores, machines.mem
+		case quota.Storage:
+			usage[resource], err = storageQuotaUsage(mb)
+		default:
+			err = errors.NotValidf("quota resource %q", resource)
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot count %s in use", resource)
+		}
+	}
+	return usage, nil
+}
+
+func countDocs(mb modelBackend, collection string, query bson.D) (uint64, error) {
+	coll, closer := mb.db().GetCollection(collection)
+	defer closer()
+	n, err := coll.Find(query).Count()
+	return uint64(n), errors.Trace(err)
+}
+
+// machinesQuotaUsage holds the cores and memory of a model's top
+// level machines.
+type machinesQuotaUsage struct {
+	cores, mem uint64
+
+	// unsized holds the ids of the machines whose cores or
+	// memory are not known, keyed on resource.
+	unsized map[string][]string
+}
+
+// machineQuotaUsage returns the total cores and memory of the model's
+// top level machines. The hardware of provisioned machines is used
+// where it is known, and the machine's constraints otherwise.
+func machineQuotaUsage(mb modelBackend) (machinesQuotaUsage, error) {
+	usage := machinesQuotaUsage{unsized: make(map[string][]string)}
+	machines, closer := mb.db().GetCollection(machinesC)
+	defer closer()
+	var mdocs []struct {
+		Id string `bson:"machineid"`
+	}
+	err := machines.Find(bson.D{
+		{"containertype", bson.D{{"$in", []interface{}{"", nil}}}},
+	}).Select(bson.D{{"machineid", 1}}).All(&mdocs)
+	if err != nil {
+		return usage, errors.Trace(err)
+	}
+
+	instances, closer := mb.db().GetCollection(instanceDataC)
+	defer closer()
+	var idocs []instanceData
+	if err := instances.Find(nil).All(&idocs); err != nil {
+		return usage, errors.Trace(err)
+	}
+	hardware := make(map[string]instanceData, len(idocs))
+	for _, doc := range idocs {
+		hardware[doc.MachineId] = doc
+	}
+
+	for _, mdoc := range mdocs {
+		hw := hardware[mdoc.Id]
+		if hw.CpuCores == nil || hw.Mem == nil {
+			cons, err := readConstraints(mb, machineGlobalKey(mdoc.Id))
+			if err != nil && !errors.IsNotFound(err) {
+				return usage, errors.Trace(err)
+			}
+			if hw.CpuCores == nil {
+				hw.CpuCores = cons.CpuCores
+			}
+			if hw.Mem == nil {
+				hw.Mem = cons.Mem
+			}
+		}
+		if hw.CpuCores != nil {
+			usage.cores += *hw.CpuCores
+		} else {
+			usage.unsized[quota.Cores] = append(usage.unsized[quota.Cores], mdoc.Id)
+		}
+		if hw.Mem != nil {
+			usage.mem += *hw.Mem
+		} else {
+			usage.unsized[quota.Memory] = append(usage.unsized[quota.Memory], mdoc.Id)
+		}
+	}
+	return usage, nil
+}
+
+// storageQuotaUsage returns the total size of the model's volumes and
+// filesystems. Filesystems backed by volumes are counted only once.
+func storageQuotaUsage(mb modelBackend) (uint64, error) {
+	var total uint64
+
+	volumes, closer := mb.db().GetCollection(volumesC)
+	defer closer()
+	var vdocs []volumeDoc
+	if err := volumes.Find(nil).All(&vdocs); err != nil {
+		return 0, errors.Trace(err)
+	}
+	for _, doc := range vdocs {
+		if doc.Info != nil {
+			size := doc.Info.Size
+			if doc.RequestedSize > size {
+				// Count volumes that are being grown
+				// at their requested size.
+				size = doc.RequestedSize
+			}
+			total += size
+		} else if doc.Params != nil {
+			total += doc.Params.Size
+		}
+	}
+
+	filesystems, closer := mb.db().GetCollection(filesystemsC)
+	defer closer()
+	var fdocs []filesystemDoc
+	if err := filesystems.Find(bson.D{
+		{"volumeid", bson.D{{"$in", []interface{}{"", nil}}}},
+	}).All(&fdocs); err != nil {
+		return 0, errors.Trace(err)
+	}
+	for _, doc := range fdocs {
+		if doc.Info != nil {
+			total += doc.Info.Size
+		} else if doc.Params != nil {
+			total += doc.Params.Size
+		}
+	}
+	return total, nil
+}
+
+// machineTemplateQuotaUsage returns the resources that adding a
+// machine from the given template would use. The template's
+// constraints must already have been combined with the model's.
+func machineTemplateQuotaUsage(template MachineTemplate, container bool) quota.Usage {
+	usage := make(quota.Usage)
+	if container {
+		usage[quota.Containers] = 1
+	} else {
+		usage[quota.Machines] = 1
+		cores, mem := template.Constraints.CpuCores, template.Constraints.Mem
+		if template.HardwareCharacteristics.CpuCores != nil {
+			cores = template.HardwareCharacteristics.CpuCores
+		}
+		if template.HardwareCharacteristics.Mem != nil {
+			mem = template.HardwareCharacteristics.Mem
+		}
+		if cores != nil {
+			usage[quota.Cores] = *cores
+		}
+		if mem != nil {
+			usage[quota.Memory] = *mem
+		}
+	}
+	for _, v := range template.Volumes {
+		usage[quota.Storage] += v.Volume.Size
+	}
+	for _, f := range template.Filesystems {
+		usage[quota.Storage] += f.Filesystem.Size
+	}
+	return usage
+}
+
+// addQuotaUsage adds the resources in each of the given usages to
+// total.
+func addQuotaUsage(total quota.Usage, usages ...quota.Usage) {
+	for _, usage := range usages {
+		for resource, amount := range usage {
+			total[resource] += amount
+		}
+	}
+}
+
+// unitsQuotaUsage returns the resources that adding the given number
+// of units of an application with the given charm and storage
+// constraints would use. Subordinate units do not count towards the
+// units quota, but their storage does. Shared storage is created once
+// for the application rather than for each unit, so it is not counted;
+// see sharedStorageQuotaUsage.
+func unitsQuotaUsage(n int, charmMeta *charm.Meta, storageCons map[string]StorageConstraints) quota.Usage {
+	usage := make(quota.Usage)
+	if !charmMeta.Subordinate {
+		usage[quota.Units] = uint64(n)
+	}
+	for name, cons := range storageCons {
+		if charmMeta.Storage[name].Shared {
+			continue
+		}
+		usage[quota.Storage] += uint64(n) * cons.Size * cons.Count
+	}
+	return usage
+}
+
+// sharedStorageQuotaUsage returns the resources that the shared
+// storage created along with an application with the given charm
+// and storage constraints would use.
+func sharedStorageQuotaUsage(charmMeta *charm.Meta, storageCons map[string]StorageConstraints) quota.Usage {
+	usage := make(quota.Usage)
+	for name, cons := range storageCons {
+		if charmMeta.Storage[name].Shared {
+			usage[quota.Storage] += cons.Size * cons.Count
+		}
+	}
+	return usage
+}
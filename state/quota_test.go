@@ -0,0 +1,219 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type QuotaSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&QuotaSuite{})
+
+func (s *QuotaSuite) setQuota(c *gc.C, q quota.Quota) {
+	err := s.Model.SetQuota(q)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuotaSuite) TestQuotaDefaultEmpty(c *gc.C) {
+	q, err := s.Model.Quota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(q, gc.HasLen, 0)
+}
+
+func (s *QuotaSuite) TestSetQuota(c *gc.C) {
+	s.setQuota(c, quota.Quota{quota.Machines: 10, quota.Memory: 8192})
+	q, err := s.Model.Quota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(q, jc.DeepEquals, quota.Quota{quota.Machines: 10, quota.Memory: 8192})
+
+	// Setting the quota again replaces it.
+	s.setQuota(c, quota.Quota{quota.Units: 5})
+	q, err = s.Model.Quota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(q, jc.DeepEquals, quota.Quota{quota.Units: 5})
+}
+
+func (s *QuotaSuite) TestSetQuotaInvalid(c *gc.C) {
+	err := s.Model.SetQuota(quota.Quota{"gpus": 1})
+	c.Assert(err, gc.ErrorMatches, `quota resource "gpus" not valid`)
+}
+
+func (s *QuotaSuite) TestQuotaUsage(c *gc.C) {
+	cores, mem := uint64(2), uint64(4096)
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.Value{CpuCores: &cores, Mem: &mem},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingApplicationWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	})
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, quota.Usage{
+		quota.Machines:   1,
+		quota.Containers: 1,
+		quota.Units:      1,
+		quota.Cores:      2,
+		quota.Memory:     4096,
+		quota.Storage:    1024,
+	})
+}
+
+func (s *QuotaSuite) TestAddMachineExceedsQuota(c *gc.C) {
+	s.setQuota(c, quota.Quota{quota.Machines: 1})
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: model quota exceeded: 1 of 1 machines already in use, cannot add 1 more")
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *QuotaSuite) TestAddMachinesExceedsQuotaTogether(c *gc.C) {
+	s.setQuota(c, quota.Quota{quota.Machines: 2})
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	_, err := s.State.AddMachines(template, template, template)
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: model quota exceeded: 0 of 2 machines already in use, cannot add 3 more")
+
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 0)
+}
+
+func (s *QuotaSuite) TestAddMachineExceedsMemoryQuota(c *gc.C) {
+	s.setQuota(c, quota.Quota{quota.Memory: 4096})
+	mem := uint64(8192)
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.Value{Mem: &mem},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: model quota exceeded: 0M of 4G memory already in use, cannot add 8G more")
+}
+
+func (s *QuotaSuite) TestAddContainerExceedsQuota(c *gc.C) {
+	s.setQuota(c, quota.Quota{quota.Containers: 0})
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m.Id(), instance.LXD)
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: model quota exceeded: 0 of 0 containers already in use, cannot add 1 more")
+}
+
+func (s *QuotaSuite) TestAddUnitExceedsQuota(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.setQuota(c, quota.Quota{quota.Units: 1})
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "wordpress": model quota exceeded: 1 of 1 units already in use, cannot add 1 more`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *QuotaSuite) TestAddApplicationExceedsQuota(c *gc.C) {
+	s.setQuota(c, quota.Quota{quota.Units: 2})
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:     "wordpress",
+		Charm:    s.AddTestingCharm(c, "wordpress"),
+		NumUnits: 3,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "wordpress": model quota exceeded: 0 of 2 units already in use, cannot add 3 more`)
+}
+
+func (s *QuotaSuite) TestAddStorageExceedsQuota(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block2")
+	app := s.AddTestingApplicationWithStorage(c, "storage-block2", ch, map[string]state.StorageConstraints{
+		"multi1to10": makeStorageCons("loop", 1024, 1),
+	})
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.setQuota(c, quota.Quota{quota.Storage: 2048})
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = sb.AddStorageForUnit(u.UnitTag(), "multi1to10", makeStorageCons("loop", 1024, 4))
+	c.Assert(err, gc.ErrorMatches, `adding "multi1to10" storage to storage-block2/0: model quota exceeded: 0M of 2G storage already in use, cannot add 4G more`)
+}
+
+func (s *QuotaSuite) TestAddMachineConcurrentExceedsQuota(c *gc.C) {
+	s.setQuota(c, quota.Quota{quota.Machines: 1})
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: model quota exceeded: 1 of 1 machines already in use, cannot add 1 more")
+
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+}
+
+func (s *QuotaSuite) TestAddUnitConcurrentExceedsQuota(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.setQuota(c, quota.Quota{quota.Units: 1})
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "wordpress": model quota exceeded: 1 of 1 units already in use, cannot add 1 more`)
+
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+}
+
+func (s *QuotaSuite) TestAddMachineWithoutCoresRefused(c *gc.C) {
+	s.setQuota(c, quota.Quota{quota.Cores: 4})
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: model quota limits cores, cannot add a machine without a cores constraint")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	cores := uint64(2)
+	_, err = s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.Value{CpuCores: &cores},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuotaSuite) TestSetQuotaWithoutMachineMemoryRefused(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetQuota(quota.Quota{quota.Memory: 4096})
+	c.Assert(err, gc.ErrorMatches, "cannot set model quota: cannot limit memory: memory of machines 0 not known")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
@@ -351,6 +351,11 @@ func (r *Relation) removeOps(ignoreApplication string, departingUnitName string)
 	}
 	ops = append(ops, removeStatusOp(r.st, r.globalScope()))
 	ops = append(ops, removeRelationNetworksOps(r.st, r.doc.Key)...)
+	secretsOps, err := revokeRelationSecretAccessOps(r.st, r.doc.Key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretsOps...)
 	re := r.st.RemoteEntities()
 	tokenOps := re.removeRemoteEntityOps(r.Tag())
 	ops = append(ops, tokenOps...)
@@ -0,0 +1,463 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+// secretRevisionsKept is the number of revisions of a secret's value
+// that are kept. Older revisions are removed as the secret is updated,
+// once consumers have had the chance to move on to a newer revision.
+const secretRevisionsKept = 3
+
+// secretMetadataDoc records everything about a secret except its value,
+// which is held by a secrets.Backend.
+type secretMetadataDoc struct {
+	DocID       string              `bson:"_id"`
+	ModelUUID   string              `bson:"model-uuid"`
+	Owner       string              `bson:"owner"`
+	Name        string              `bson:"name"`
+	Description string              `bson:"description,omitempty"`
+	Revision    int                 `bson:"revision"`
+	Consumers   []secretConsumerDoc `bson:"consumers"`
+	CreateTime  time.Time           `bson:"create-time"`
+	UpdateTime  time.Time           `bson:"update-time"`
+}
+
+type secretConsumerDoc struct {
+	Application string `bson:"application"`
+	RelationKey string `bson:"relation-key"`
+}
+
+// CreateSecretParams holds the parameters for creating a secret.
+type CreateSecretParams struct {
+	// Owner is the name of the application that owns the secret.
+	Owner string
+
+	// Name is the name of the secret, unique to the owner.
+	Name string
+
+	// Description optionally describes the secret.
+	Description string
+
+	// Value is the initial value of the secret.
+	Value secrets.Value
+}
+
+// Secrets provides access to the secrets stored in a model. Secret
+// metadata and access grants are stored in the model; secret values are
+// stored in a secrets.Backend.
+type Secrets struct {
+	st      *State
+	backend secrets.Backend
+}
+
+// NewSecrets returns a Secrets that stores secret values in the given
+// backend.
+func NewSecrets(st *State, backend secrets.Backend) *Secrets {
+	return &Secrets{st: st, backend: backend}
+}
+
+// CreateSecret creates a new secret owned by an application, with the
+// supplied value as its first revision. An error satisfying
+// errors.IsAlreadyExists is returned if the owner already has a secret
+// with the same name.
+func (s *Secrets) CreateSecret(p CreateSecretParams) (*secrets.Metadata, error) {
+	if !secrets.IsValidName(p.Name) {
+		return nil, errors.NotValidf("secret name %q", p.Name)
+	}
+	if err := validateSecretValue(p.Value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := secrets.NewID(p.Owner, p.Name)
+	now := s.st.clock().Now().Round(time.Second).UTC()
+	doc := &secretMetadataDoc{
+		DocID:       s.st.docID(id),
+		Owner:       p.Owner,
+		Name:        p.Name,
+		Description: p.Description,
+		Revision:    1,
+		CreateTime:  now,
+		UpdateTime:  now,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := s.getDoc(id); err == nil {
+			return nil, errors.AlreadyExistsf("secret %q", id)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		app, err := s.st.Application(p.Owner)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", p.Owner)
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      secretMetadataC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}}
+		valueOps, err := s.saveValueOps(id, 1, p.Value)
+		if err != nil {
+			return nil, errors.Annotate(err, "saving secret value")
+		}
+		return append(ops, valueOps...), nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot create secret %q", id)
+	}
+	return s.toMetadata(doc), nil
+}
+
+// UpdateSecret stores a new revision of the secret's value. Only the
+// most recent revisions are kept; older values are removed.
+func (s *Secrets) UpdateSecret(id string, value secrets.Value) (*secrets.Metadata, error) {
+	if err := validateSecretValue(value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var doc *secretMetadataDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var err error
+		doc, err = s.getDoc(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		revision := doc.Revision + 1
+		now := s.st.clock().Now().Round(time.Second).UTC()
+		ops := []txn.Op{{
+			C:      secretMetadataC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"revision", doc.Revision}},
+			Update: bson.D{{"$set", bson.D{
+				{"revision", revision},
+				{"update-time", now},
+			}}},
+		}}
+		valueOps, err := s.saveValueOps(id, revision, value)
+		if err != nil {
+			return nil, errors.Annotate(err, "saving secret value")
+		}
+		ops = append(ops, valueOps...)
+		if pruned := revision - secretRevisionsKept; pruned > 0 {
+			pruneOps, err := s.deleteValueOps(id, pruned)
+			if err != nil {
+				return nil, errors.Annotate(err, "removing old secret value")
+			}
+			ops = append(ops, pruneOps...)
+		}
+		doc.Revision = revision
+		doc.UpdateTime = now
+		return ops, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot update secret %q", id)
+	}
+	return s.toMetadata(doc), nil
+}
+
+// Secret returns the metadata for the secret with the given ID.
+func (s *Secrets) Secret(id string) (*secrets.Metadata, error) {
+	doc, err := s.getDoc(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.toMetadata(doc), nil
+}
+
+// ListSecrets returns the metadata for the secrets in the model. If
+// owner is not empty, only the secrets owned by that application are
+// returned.
+func (s *Secrets) ListSecrets(owner string) ([]*secrets.Metadata, error) {
+	coll, closer := s.st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	var query bson.D
+	if owner != "" {
+		query = bson.D{{"owner", owner}}
+	}
+	var docs []secretMetadataDoc
+	if err := coll.Find(query).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot list secrets")
+	}
+	result := make([]*secrets.Metadata, len(docs))
+	for i := range docs {
+		result[i] = s.toMetadata(&docs[i])
+	}
+	return result, nil
+}
+
+// SecretValue returns the value of the given revision of the secret.
+// If revision is 0, the latest revision is returned.
+func (s *Secrets) SecretValue(id string, revision int) (secrets.Value, error) {
+	if revision == 0 {
+		doc, err := s.getDoc(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		revision = doc.Revision
+	}
+	value, err := s.backend.Value(id, revision)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("secret %q revision %d", id, revision)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get value of secret %q", id)
+	}
+	return value, nil
+}
+
+// GrantSecretAccess grants read access to the secret to the application
+// at the other end of the relation with the given key. The secret's
+// owner must be a member of the relation.
+func (s *Secrets) GrantSecretAccess(id, relationKey string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := s.getDoc(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rel, err := s.st.KeyRelation(relationKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if rel.Life() != Alive {
+			return nil, errors.Errorf("relation %q is not alive", relationKey)
+		}
+		endpoints, err := rel.RelatedEndpoints(doc.Owner)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		consumer := endpoints[0].ApplicationName
+		if consumer == doc.Owner {
+			return nil, errors.NotValidf("granting access over peer relation %q", relationKey)
+		}
+		for _, c := range doc.Consumers {
+			if c.RelationKey == relationKey {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		return []txn.Op{{
+			C:      relationsC,
+			Id:     rel.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      secretMetadataC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$push", bson.D{{"consumers", secretConsumerDoc{
+				Application: consumer,
+				RelationKey: relationKey,
+			}}}}},
+		}}, nil
+	}
+	return errors.Annotatef(s.st.db().Run(buildTxn), "cannot grant access to secret %q", id)
+}
+
+// RevokeSecretAccess revokes the access to the secret that was granted
+// over the relation with the given key. If relationKey is empty, all
+// access granted to other applications is revoked.
+func (s *Secrets) RevokeSecretAccess(id, relationKey string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := s.getDoc(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(doc.Consumers) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"$set", bson.D{{"consumers", []secretConsumerDoc{}}}}}
+		if relationKey != "" {
+			update = bson.D{{"$pull", bson.D{{"consumers", bson.D{{"relation-key", relationKey}}}}}}
+		}
+		return []txn.Op{{
+			C:      secretMetadataC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: update,
+		}}, nil
+	}
+	return errors.Annotatef(s.st.db().Run(buildTxn), "cannot revoke access to secret %q", id)
+}
+
+// RemoveSecret removes the secret and all of its stored values. It is
+// not an error to remove a secret that does not exist.
+func (s *Secrets) RemoveSecret(id string) error {
+	b, ok := s.backend.(txnSecretsBackend)
+	if !ok {
+		return s.removeSecretThenValues(id)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		valueOps, err := b.deleteValuesOps(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append([]txn.Op{{
+			C:      secretMetadataC,
+			Id:     s.st.docID(id),
+			Remove: true,
+		}}, valueOps...), nil
+	}
+	return errors.Annotatef(s.st.db().Run(buildTxn), "cannot remove secret %q", id)
+}
+
+// removeSecretThenValues removes the secret, and then its values from
+// a backend that can't remove them in the same transaction.
+func (s *Secrets) removeSecretThenValues(id string) error {
+	ops := []txn.Op{{
+		C:      secretMetadataC,
+		Id:     s.st.docID(id),
+		Remove: true,
+	}}
+	if err := s.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot remove secret %q", id)
+	}
+	return errors.Annotatef(s.backend.DeleteValues(id), "cannot remove values of secret %q", id)
+}
+
+// saveValueOps returns the operations needed to store the value of the
+// given revision of a secret. Backends that store values in the
+// controller database supply operations that run in the same
+// transaction as the secret's metadata; other backends store the value
+// immediately, and no operations are returned.
+func (s *Secrets) saveValueOps(id string, revision int, value secrets.Value) ([]txn.Op, error) {
+	if b, ok := s.backend.(txnSecretsBackend); ok {
+		return b.saveValueOps(id, revision, value)
+	}
+	return nil, s.backend.SaveValue(id, revision, value)
+}
+
+// deleteValueOps returns the operations needed to remove the value of
+// the given revision of a secret. As with saveValueOps, other backends
+// remove the value immediately.
+func (s *Secrets) deleteValueOps(id string, revision int) ([]txn.Op, error) {
+	if b, ok := s.backend.(txnSecretsBackend); ok {
+		return b.deleteValueOps(id, revision), nil
+	}
+	return nil, s.backend.DeleteValue(id, revision)
+}
+
+// txnSecretsBackend is implemented by secrets backends that can store
+// and remove values as part of a transaction.
+type txnSecretsBackend interface {
+	saveValueOps(id string, revision int, value secrets.Value) ([]txn.Op, error)
+	deleteValueOps(id string, revision int) []txn.Op
+	deleteValuesOps(id string) ([]txn.Op, error)
+}
+
+func (s *Secrets) getDoc(id string) (*secretMetadataDoc, error) {
+	coll, closer := s.st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	var doc secretMetadataDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	return &doc, nil
+}
+
+func (s *Secrets) toMetadata(doc *secretMetadataDoc) *secrets.Metadata {
+	md := &secrets.Metadata{
+		ID:          s.st.localID(doc.DocID),
+		Owner:       doc.Owner,
+		Name:        doc.Name,
+		Description: doc.Description,
+		Revision:    doc.Revision,
+		CreateTime:  doc.CreateTime.UTC(),
+		UpdateTime:  doc.UpdateTime.UTC(),
+	}
+	for _, c := range doc.Consumers {
+		md.Consumers = append(md.Consumers, secrets.Consumer{
+			Application: c.Application,
+			RelationKey: c.RelationKey,
+		})
+	}
+	return md
+}
+
+func validateSecretValue(value secrets.Value) error {
+	if len(value) == 0 {
+		return errors.NotValidf("empty secret value")
+	}
+	for key := range value {
+		if key == "" {
+			return errors.NotValidf("empty secret key")
+		}
+	}
+	return nil
+}
+
+// revokeRelationSecretAccessOps returns the operations needed to revoke
+// any access to secrets that was granted over the relation with the
+// given key.
+func revokeRelationSecretAccessOps(st *State, relationKey string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	sel := bson.D{{"consumers.relation-key", relationKey}}
+	if err := coll.Find(sel).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      secretMetadataC,
+			Id:     doc.DocID,
+			Update: bson.D{{"$pull", bson.D{{"consumers", bson.D{{"relation-key", relationKey}}}}}},
+		}
+	}
+	return ops, nil
+}
+
+// applicationSecretsCleanupOps returns the operations needed to schedule
+// the removal of the secrets owned by the named application, if it has
+// any.
+func applicationSecretsCleanupOps(st *State, appName string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	count, err := coll.Find(bson.D{{"owner", appName}}).Count()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	return []txn.Op{newCleanupOp(cleanupApplicationSecrets, appName)}, nil
+}
+
+// cleanupApplicationSecrets removes the secrets owned by a removed
+// application, and their values in the controller secrets backend.
+// Removing values doesn't need the controller's secrets key.
+func (st *State) cleanupApplicationSecrets(appName string) error {
+	s := NewSecrets(st, NewControllerSecretsBackend(st, ""))
+	owned, err := s.ListSecrets(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, md := range owned {
+		if err := s.RemoveSecret(md.ID); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
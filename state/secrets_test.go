@@ -0,0 +1,297 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	mysql     *state.Application
+	wordpress *state.Application
+	relation  *state.Relation
+	secrets   *state.Secrets
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	controllerKey, err := state.NewControllerSecretsKey()
+	c.Assert(err, jc.ErrorIsNil)
+	s.secrets = state.NewSecrets(s.State, state.NewControllerSecretsBackend(s.State, controllerKey))
+}
+
+func (s *SecretsSuite) createSecret(c *gc.C) *secrets.Metadata {
+	md, err := s.secrets.CreateSecret(state.CreateSecretParams{
+		Owner:       "mysql",
+		Name:        "password",
+		Description: "root password",
+		Value:       secrets.Value{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return md
+}
+
+func (s *SecretsSuite) TestCreateSecret(c *gc.C) {
+	md := s.createSecret(c)
+	c.Assert(md.ID, gc.Equals, "secret:mysql/password")
+	c.Assert(md.Owner, gc.Equals, "mysql")
+	c.Assert(md.Name, gc.Equals, "password")
+	c.Assert(md.Description, gc.Equals, "root password")
+	c.Assert(md.Revision, gc.Equals, 1)
+
+	got, err := s.secrets.Secret(md.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, md)
+
+	value, err := s.secrets.SecretValue(md.ID, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, secrets.Value{"password": "s3cret"})
+}
+
+func (s *SecretsSuite) TestCreateSecretAlreadyExists(c *gc.C) {
+	s.createSecret(c)
+	_, err := s.secrets.CreateSecret(state.CreateSecretParams{
+		Owner: "mysql",
+		Name:  "password",
+		Value: secrets.Value{"password": "other"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	value, err := s.secrets.SecretValue("secret:mysql/password", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, secrets.Value{"password": "s3cret"})
+}
+
+func (s *SecretsSuite) TestCreateSecretInvalid(c *gc.C) {
+	_, err := s.secrets.CreateSecret(state.CreateSecretParams{
+		Owner: "mysql",
+		Name:  "Bad Name",
+		Value: secrets.Value{"password": "s3cret"},
+	})
+	c.Assert(err, gc.ErrorMatches, `secret name "Bad Name" not valid`)
+
+	_, err = s.secrets.CreateSecret(state.CreateSecretParams{
+		Owner: "mysql",
+		Name:  "password",
+	})
+	c.Assert(err, gc.ErrorMatches, `empty secret value not valid`)
+
+	_, err = s.secrets.CreateSecret(state.CreateSecretParams{
+		Owner: "missing",
+		Name:  "password",
+		Value: secrets.Value{"password": "s3cret"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot create secret "secret:missing/password": application "missing" not found`)
+}
+
+func (s *SecretsSuite) TestValuesEncrypted(c *gc.C) {
+	md := s.createSecret(c)
+	coll := s.MgoSuite.Session.DB("juju").C("secretValues")
+	var doc bson.M
+	err := coll.Find(bson.D{{"secret-id", md.ID}}).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc["revision"], gc.Equals, 1)
+	c.Assert(string(doc["data"].([]byte)), gc.Not(jc.Contains), "s3cret")
+}
+
+func (s *SecretsSuite) TestModelKeyWrapped(c *gc.C) {
+	md := s.createSecret(c)
+
+	var modelKey bson.M
+	coll := s.MgoSuite.Session.DB("juju").C("secretValues")
+	err := coll.FindId(s.State.ModelUUID() + ":#key").One(&modelKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelKey["key"], gc.IsNil)
+	c.Assert(modelKey["wrapped-key"], gc.NotNil)
+
+	// The values can't be read with another controller's key.
+	otherKey, err := state.NewControllerSecretsKey()
+	c.Assert(err, jc.ErrorIsNil)
+	other := state.NewSecrets(s.State, state.NewControllerSecretsBackend(s.State, otherKey))
+	_, err = other.SecretValue(md.ID, 0)
+	c.Assert(err, gc.ErrorMatches, `.*unwrapping model secrets key: .*`)
+}
+
+func (s *SecretsSuite) TestNoControllerKey(c *gc.C) {
+	secrets := state.NewSecrets(s.State, state.NewControllerSecretsBackend(s.State, ""))
+	_, err := secrets.CreateSecret(state.CreateSecretParams{
+		Owner: "mysql",
+		Name:  "password",
+		Value: secrets.Value{"password": "s3cret"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *SecretsSuite) TestUpdateSecret(c *gc.C) {
+	md := s.createSecret(c)
+	updated, err := s.secrets.UpdateSecret(md.ID, secrets.Value{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.Revision, gc.Equals, 2)
+
+	value, err := s.secrets.SecretValue(md.ID, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, secrets.Value{"password": "n3w"})
+	value, err = s.secrets.SecretValue(md.ID, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, secrets.Value{"password": "s3cret"})
+
+	_, err = s.secrets.SecretValue(md.ID, 3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestUpdateSecretPrunesRevisions(c *gc.C) {
+	md := s.createSecret(c)
+	for i := 0; i < 3; i++ {
+		_, err := s.secrets.UpdateSecret(md.ID, secrets.Value{"password": fmt.Sprint(i)})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Only the three most recent revisions are kept.
+	_, err := s.secrets.SecretValue(md.ID, 1)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	for revision := 2; revision <= 4; revision++ {
+		_, err := s.secrets.SecretValue(md.ID, revision)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	count, err := s.MgoSuite.Session.DB("juju").C("secretValues").Find(bson.D{{"secret-id", md.ID}}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 3)
+}
+
+func (s *SecretsSuite) TestUpdateSecretNotFound(c *gc.C) {
+	_, err := s.secrets.UpdateSecret("secret:mysql/missing", secrets.Value{"a": "b"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	s.createSecret(c)
+	_, err := s.secrets.CreateSecret(state.CreateSecretParams{
+		Owner: "wordpress",
+		Name:  "api-key",
+		Value: secrets.Value{"key": "abc"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.secrets.ListSecrets("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Assert(all[0].ID, gc.Equals, "secret:mysql/password")
+	c.Assert(all[1].ID, gc.Equals, "secret:wordpress/api-key")
+
+	owned, err := s.secrets.ListSecrets("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owned, gc.HasLen, 1)
+	c.Assert(owned[0].ID, gc.Equals, "secret:wordpress/api-key")
+}
+
+func (s *SecretsSuite) TestGrantAndRevokeAccess(c *gc.C) {
+	md := s.createSecret(c)
+	key := s.relation.String()
+	err := s.secrets.GrantSecretAccess(md.ID, key)
+	c.Assert(err, jc.ErrorIsNil)
+	// Granting again is a no-op.
+	err = s.secrets.GrantSecretAccess(md.ID, key)
+	c.Assert(err, jc.ErrorIsNil)
+
+	md, err = s.secrets.Secret(md.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.Consumers, jc.DeepEquals, []secrets.Consumer{{
+		Application: "wordpress",
+		RelationKey: key,
+	}})
+	c.Assert(md.CanRead("wordpress"), jc.IsTrue)
+
+	err = s.secrets.RevokeSecretAccess(md.ID, key)
+	c.Assert(err, jc.ErrorIsNil)
+	md, err = s.secrets.Secret(md.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.Consumers, gc.HasLen, 0)
+	c.Assert(md.CanRead("wordpress"), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestRevokeAllAccess(c *gc.C) {
+	md := s.createSecret(c)
+	err := s.secrets.GrantSecretAccess(md.ID, s.relation.String())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.secrets.RevokeSecretAccess(md.ID, "")
+	c.Assert(err, jc.ErrorIsNil)
+	md, err = s.secrets.Secret(md.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.Consumers, gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestGrantAccessNotInRelation(c *gc.C) {
+	_, err := s.secrets.CreateSecret(state.CreateSecretParams{
+		Owner: "wordpress",
+		Name:  "api-key",
+		Value: secrets.Value{"key": "abc"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("wordpress", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.secrets.GrantSecretAccess("secret:mysql/missing", rel.String())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	s.createSecret(c)
+	err = s.secrets.GrantSecretAccess("secret:mysql/password", rel.String())
+	c.Assert(err, gc.ErrorMatches, `cannot grant access to secret "secret:mysql/password": application "mysql" is not a member of .*`)
+}
+
+func (s *SecretsSuite) TestRemoveRelationRevokesAccess(c *gc.C) {
+	md := s.createSecret(c)
+	err := s.secrets.GrantSecretAccess(md.ID, s.relation.String())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	md, err = s.secrets.Secret(md.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.Consumers, gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestRemoveSecret(c *gc.C) {
+	md := s.createSecret(c)
+	err := s.secrets.RemoveSecret(md.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.secrets.Secret(md.ID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	count, err := s.MgoSuite.Session.DB("juju").C("secretValues").Find(bson.D{{"secret-id", md.ID}}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRemovesSecrets(c *gc.C) {
+	md := s.createSecret(c)
+	err := s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.secrets.Secret(md.ID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,309 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+// secretsKeyDocID is the id of the document in the secretValues
+// collection that holds the model's wrapped secret encryption key.
+const secretsKeyDocID = "#key"
+
+// secretValueDoc holds one revision of a secret's value, encrypted with
+// the model's secrets key.
+type secretValueDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	SecretID  string `bson:"secret-id"`
+	Revision  int    `bson:"revision"`
+	Nonce     []byte `bson:"nonce"`
+	Data      []byte `bson:"data"`
+}
+
+// secretsKeyDoc holds the key used to encrypt the secret values stored
+// in a model. The key is itself encrypted with the controller's secrets
+// key, which is never stored in the controller database.
+type secretsKeyDoc struct {
+	DocID      string `bson:"_id"`
+	ModelUUID  string `bson:"model-uuid"`
+	Nonce      []byte `bson:"nonce"`
+	WrappedKey []byte `bson:"wrapped-key"`
+}
+
+// controllerSecretsBackend is a secrets.Backend that stores secret values
+// in the controller database. Values are encrypted with AES-GCM using a
+// key generated for each model, so that they never appear in plain text
+// in the database. The model keys are wrapped with the controller's
+// secrets key, which is held in the agent config of the controller
+// machines, so that the contents of the database are not enough to
+// decrypt any secrets.
+type controllerSecretsBackend struct {
+	st                   *State
+	encodedControllerKey string
+}
+
+// NewControllerSecretsBackend returns the default secrets.Backend, which
+// stores encrypted secret values in the controller database. The
+// controller key is the controller's secrets key, as generated by
+// NewControllerSecretsKey; it may be empty if the backend is only used
+// to remove values.
+func NewControllerSecretsBackend(st *State, controllerKey string) secrets.Backend {
+	return &controllerSecretsBackend{st: st, encodedControllerKey: controllerKey}
+}
+
+// NewControllerSecretsKey returns a new, encoded, controller secrets
+// key, for recording in the agent config of the controller machines.
+func NewControllerSecretsKey() (string, error) {
+	key, err := randomSecretsKey()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func secretValueDocID(id string, revision int) string {
+	return fmt.Sprintf("%s#%d", id, revision)
+}
+
+// SaveValue is part of the secrets.Backend interface.
+func (b *controllerSecretsBackend) SaveValue(id string, revision int, value secrets.Value) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		return b.saveValueOps(id, revision, value)
+	}
+	return errors.Trace(b.st.db().Run(buildTxn))
+}
+
+// saveValueOps returns the operations needed to store the value of the
+// given revision of a secret, so that the value may be written in the
+// same transaction as the secret's metadata.
+func (b *controllerSecretsBackend) saveValueOps(id string, revision int, value secrets.Value) ([]txn.Op, error) {
+	aead, err := b.cipher()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	docID := secretValueDocID(id, revision)
+	doc := &secretValueDoc{
+		DocID:    b.st.docID(docID),
+		SecretID: id,
+		Revision: revision,
+		Nonce:    nonce,
+		Data:     aead.Seal(nil, nonce, plaintext, []byte(docID)),
+	}
+
+	coll, closer := b.st.db().GetCollection(secretValuesC)
+	defer closer()
+	count, err := coll.FindId(docID).Count()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if count == 0 {
+		return []txn.Op{{
+			C:      secretValuesC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}}, nil
+	}
+	return []txn.Op{{
+		C:      secretValuesC,
+		Id:     doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"nonce", doc.Nonce},
+			{"data", doc.Data},
+		}}},
+	}}, nil
+}
+
+// Value is part of the secrets.Backend interface.
+func (b *controllerSecretsBackend) Value(id string, revision int) (secrets.Value, error) {
+	coll, closer := b.st.db().GetCollection(secretValuesC)
+	defer closer()
+
+	docID := secretValueDocID(id, revision)
+	var doc secretValueDoc
+	err := coll.FindId(docID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q revision %d", id, revision)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := b.cipher()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	plaintext, err := aead.Open(nil, doc.Nonce, doc.Data, []byte(docID))
+	if err != nil {
+		return nil, errors.Annotate(err, "decrypting secret value")
+	}
+	var value secrets.Value
+	if err := json.Unmarshal(plaintext, &value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return value, nil
+}
+
+// DeleteValue is part of the secrets.Backend interface.
+func (b *controllerSecretsBackend) DeleteValue(id string, revision int) error {
+	return errors.Trace(b.st.db().RunTransaction(b.deleteValueOps(id, revision)))
+}
+
+// deleteValueOps returns the operations needed to remove the value of
+// the given revision of a secret.
+func (b *controllerSecretsBackend) deleteValueOps(id string, revision int) []txn.Op {
+	return []txn.Op{{
+		C:      secretValuesC,
+		Id:     b.st.docID(secretValueDocID(id, revision)),
+		Remove: true,
+	}}
+}
+
+// DeleteValues is part of the secrets.Backend interface.
+func (b *controllerSecretsBackend) DeleteValues(id string) error {
+	ops, err := b.deleteValuesOps(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Trace(b.st.db().RunTransaction(ops))
+}
+
+// deleteValuesOps returns the operations needed to remove all stored
+// revisions of a secret.
+func (b *controllerSecretsBackend) deleteValuesOps(id string) ([]txn.Op, error) {
+	coll, closer := b.st.db().GetCollection(secretValuesC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	err := coll.Find(bson.D{{"secret-id", id}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      secretValuesC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// cipher returns the AEAD used to encrypt and decrypt the model's secret
+// values, generating the model's key if it does not yet have one.
+func (b *controllerSecretsBackend) cipher() (cipher.AEAD, error) {
+	key, err := b.key()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting secrets key")
+	}
+	return newSecretsCipher(key)
+}
+
+// key returns the model's secrets key, unwrapping it with the
+// controller's secrets key.
+func (b *controllerSecretsBackend) key() ([]byte, error) {
+	controllerKey, err := b.controllerKey()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting controller secrets key")
+	}
+	wrapper, err := newSecretsCipher(controllerKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	coll, closer := b.st.db().GetCollection(secretValuesC)
+	defer closer()
+
+	var doc secretsKeyDoc
+	err = coll.FindId(secretsKeyDocID).One(&doc)
+	if err == mgo.ErrNotFound {
+		key, err := randomSecretsKey()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		nonce := make([]byte, wrapper.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      secretValuesC,
+			Id:     b.st.docID(secretsKeyDocID),
+			Assert: txn.DocMissing,
+			Insert: &secretsKeyDoc{
+				Nonce:      nonce,
+				WrappedKey: wrapper.Seal(nil, nonce, key, []byte(b.st.ModelUUID())),
+			},
+		}}
+		if err := b.st.db().RunTransaction(ops); err == nil {
+			return key, nil
+		} else if err != txn.ErrAborted {
+			return nil, errors.Trace(err)
+		}
+		// Another client created the key first; use theirs.
+		err = coll.FindId(secretsKeyDocID).One(&doc)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	key, err := wrapper.Open(nil, doc.Nonce, doc.WrappedKey, []byte(b.st.ModelUUID()))
+	if err != nil {
+		return nil, errors.Annotate(err, "unwrapping model secrets key")
+	}
+	return key, nil
+}
+
+// controllerKey returns the controller's decoded secrets key.
+func (b *controllerSecretsBackend) controllerKey() ([]byte, error) {
+	if b.encodedControllerKey == "" {
+		return nil, errors.NotSupportedf("secrets on a controller without a secrets key")
+	}
+	key, err := base64.StdEncoding.DecodeString(b.encodedControllerKey)
+	if err != nil {
+		return nil, errors.Annotate(err, "decoding controller secrets key")
+	}
+	return key, nil
+}
+
+func newSecretsCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func randomSecretsKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return key, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// maxSettingsRevisions is the number of revisions kept in the
+// settings history of each application and of the model config.
+// Older revisions are removed as new ones are added.
+const maxSettingsRevisions = 20
+
+// settingsRevisionDoc records the values of a settings document after
+// a change was made to it.
+type settingsRevisionDoc struct {
+	DocID     string      `bson:"_id"`
+	ModelUUID string      `bson:"model-uuid"`
+	GlobalKey string      `bson:"globalkey"`
+	Revision  int         `bson:"revision"`
+	Settings  settingsMap `bson:"settings"`
+	Author    string      `bson:"author,omitempty"`
+	Created   time.Time   `bson:"created"`
+}
+
+// SettingsRevision is a revision in the history of an application's
+// charm config, or of the model config.
+type SettingsRevision struct {
+	// Revision identifies the revision; revisions are numbered from
+	// 1 and increase with every change.
+	Revision int
+
+	// Settings holds all the settings as they were at this revision.
+	Settings map[string]interface{}
+
+	// Author is the name of the user who made the change, if known.
+	// The first revision records the settings as they were before
+	// any history was kept, and has no author.
+	Author string
+
+	// Created is when the revision was made.
+	Created time.Time
+}
+
+func settingsRevisionDocID(globalKey string, revision int) string {
+	return fmt.Sprintf("%s#%d", globalKey, revision)
+}
+
+func (doc *settingsRevisionDoc) revision() SettingsRevision {
+	return SettingsRevision{
+		Revision: doc.Revision,
+		Settings: copyMap(doc.Settings, nil),
+		Author:   doc.Author,
+		Created:  doc.Created,
+	}
+}
+
+// settingsHistory returns the revisions recorded for the entity with the
+// given global key, oldest first.
+func settingsHistory(st *State, globalKey string) ([]SettingsRevision, error) {
+	coll, closer := st.db().GetCollection(settingsHistoryC)
+	defer closer()
+
+	var docs []settingsRevisionDoc
+	if err := coll.Find(bson.D{{"globalkey", globalKey}}).Sort("revision").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading settings history")
+	}
+	result := make([]SettingsRevision, len(docs))
+	for i := range docs {
+		result[i] = docs[i].revision()
+	}
+	return result, nil
+}
+
+// settingsRevision returns the given revision recorded for the entity
+// with the given global key.
+func settingsRevision(st *State, globalKey string, revision int) (*SettingsRevision, error) {
+	coll, closer := st.db().GetCollection(settingsHistoryC)
+	defer closer()
+
+	var doc settingsRevisionDoc
+	err := coll.FindId(settingsRevisionDocID(globalKey, revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("settings revision %d", revision)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := doc.revision()
+	return &result, nil
+}
+
+// addSettingsRevisionOps returns the operations needed to record that
+// the settings of the entity with the given global key are changing
+// from oldSettings to newSettings. If there is no history yet, the old
+// settings are recorded as the first revision. The oldest revisions
+// are removed so that no more than maxSettingsRevisions are kept.
+func addSettingsRevisionOps(
+	st *State,
+	globalKey string,
+	oldSettings, newSettings map[string]interface{},
+	author string,
+) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(settingsHistoryC)
+	defer closer()
+
+	var latest settingsRevisionDoc
+	err := coll.Find(bson.D{{"globalkey", globalKey}}).Sort("-revision").One(&latest)
+	if err != nil && err != mgo.ErrNotFound {
+		return nil, errors.Annotate(err, "reading settings history")
+	}
+
+	now := st.clock().Now().UTC()
+	var ops []txn.Op
+	insertOp := func(revision int, settings map[string]interface{}, author string) {
+		ops = append(ops, txn.Op{
+			C:      settingsHistoryC,
+			Id:     settingsRevisionDocID(globalKey, revision),
+			Assert: txn.DocMissing,
+			Insert: &settingsRevisionDoc{
+				GlobalKey: globalKey,
+				Revision:  revision,
+				Settings:  settingsMap(copyMap(settings, nil)),
+				Author:    author,
+				Created:   now,
+			},
+		})
+	}
+	revision := latest.Revision
+	if revision == 0 {
+		revision++
+		insertOp(revision, oldSettings, "")
+	}
+	revision++
+	insertOp(revision, newSettings, author)
+
+	// Removing a revision that has already gone is harmless, so
+	// there's no assertion on this.
+	if old := revision - maxSettingsRevisions; old > 0 {
+		ops = append(ops, txn.Op{
+			C:      settingsHistoryC,
+			Id:     settingsRevisionDocID(globalKey, old),
+			Remove: true,
+		})
+	}
+	return ops, nil
+}
+
+// removeSettingsHistoryOps returns the operations needed to remove all
+// the settings history for the entity with the given global key.
+func removeSettingsHistoryOps(st *State, globalKey string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(settingsHistoryC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	err := coll.Find(bson.D{{"globalkey", globalKey}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading settings history")
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      settingsHistoryC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
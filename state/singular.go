@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/lease"
@@ -50,6 +51,21 @@ func (s singularSecretary) CheckDuration(duration time.Duration) error {
 	return nil
 }
 
+// controllerSingularSecretary implements lease.Secretary for a manager
+// handling singular leases for all models in the controller; it accepts
+// any model or controller UUID as a lease name.
+type controllerSingularSecretary struct {
+	singularSecretary
+}
+
+// CheckLease is part of the lease.Secretary interface.
+func (controllerSingularSecretary) CheckLease(name string) error {
+	if !utils.IsValidUUIDString(name) {
+		return errors.New("expected controller or model UUID")
+	}
+	return nil
+}
+
 // SingularClaimer returns a lease.Claimer representing the exclusive right to
 // manage the model.
 func (st *State) SingularClaimer() lease.Claimer {
@@ -58,3 +74,12 @@ func (st *State) SingularClaimer() lease.Claimer {
 		return manager.Claimer(singularControllerNamespace, st.modelUUID())
 	}}
 }
+
+// NewSingularClaimer returns a lease.Claimer for singular leases in
+// the given model, backed by the supplied lease manager rather than
+// the state's own.
+func NewSingularClaimer(manager lease.Manager, modelUUID string) lease.Claimer {
+	return lazyLeaseClaimer{func() (lease.Claimer, error) {
+		return manager.Claimer(singularControllerNamespace, modelUUID)
+	}}
+}
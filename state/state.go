@@ -7,6 +7,7 @@
 package state
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"sort"
@@ -551,6 +552,22 @@ func (st *State) db() Database {
 	return st.database
 }
 
+// WithTraceContext returns a State that records each transaction it
+// runs as a span in the trace held by ctx, using the tracer held by
+// ctx. The returned State shares the resources of st, so it must not
+// be closed, and is only valid as long as st is.
+func (st *State) WithTraceContext(ctx context.Context) *State {
+	db, ok := st.database.(*database)
+	if !ok {
+		return st
+	}
+	tracedDB := *db
+	tracedDB.traceContext = ctx
+	traced := *st
+	traced.database = &tracedDB
+	return &traced
+}
+
 // txnLogWatcher returns the TxnLogWatcher for the State. It is part
 // of the modelBackend interface.
 func (st *State) txnLogWatcher() watcher.BaseWatcher {
@@ -1255,12 +1272,21 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			ops = append(ops, resOps...)
 		}
 
+		// Collect shared storage operations. The shared storage is
+		// attached to each of the units added below.
+		sharedStorageOps, sharedStorage, err := app.addSharedStorageOps(args.Charm.Meta(), args.Storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, sharedStorageOps...)
+
 		// Collect unit-adding operations.
 		for x := 0; x < args.NumUnits; x++ {
 			unitName, unitOps, err := app.addApplicationUnitOps(applicationAddUnitOpsArgs{
 				cons:          args.Constraints,
 				storageCons:   args.Storage,
 				attachStorage: args.AttachStorage,
+				sharedStorage: sharedStorage,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -1272,6 +1298,19 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			}
 			ops = append(ops, assignUnitOps(unitName, placement)...)
 		}
+		// Charge the shared storage, and the units along with their
+		// own storage, to the model's quota.
+		requested := sharedStorageQuotaUsage(args.Charm.Meta(), args.Storage)
+		if args.NumUnits > 0 {
+			addQuotaUsage(requested, unitsQuotaUsage(args.NumUnits, args.Charm.Meta(), args.Storage))
+		}
+		if len(requested) > 0 {
+			chargeOps, err := quotaOps(st, requested)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, chargeOps...)
+		}
 		return ops, nil
 	}
 	// At the last moment before inserting the application, prime status history.
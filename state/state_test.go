@@ -4,7 +4,12 @@
 package state_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"sync"
@@ -31,6 +36,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/mongo"
@@ -4427,6 +4433,59 @@ func (s *StateSuite) TestRunTransactionObserver(c *gc.C) {
 	c.Assert(found, jc.IsTrue)
 }
 
+func (s *StateSuite) TestWithTraceContext(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "traces.log")
+	exporter, err := trace.NewFileExporter(path)
+	c.Assert(err, jc.ErrorIsNil)
+	tracer, err := trace.NewTracer(trace.Config{
+		Exporter:      exporter,
+		Clock:         clock.WallClock,
+		BufferSize:    100,
+		BatchSize:     100,
+		FlushInterval: time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	parent := trace.NewSpanContext()
+	ctx := trace.ContextWithTracer(context.Background(), tracer)
+	ctx = trace.ContextWithSpanContext(ctx, parent)
+
+	err = s.State.WithTraceContext(ctx).SetModelConstraints(constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	// Transactions run through the original State aren't traced.
+	err = s.State.SetModelConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = tracer.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	var spans []trace.SpanData
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for decoder.More() {
+		var span trace.SpanData
+		err := decoder.Decode(&span)
+		c.Assert(err, jc.ErrorIsNil)
+		// There may be some leadership txns recorded.
+		// We only care about the constraints txns.
+		if span.Attributes["collections"] == "constraints" {
+			spans = append(spans, span)
+		}
+	}
+	c.Assert(spans, gc.HasLen, 1)
+	span := spans[0]
+	c.Check(span.TraceID, gc.Equals, parent.TraceID)
+	c.Check(span.ParentSpanID, gc.Equals, parent.SpanID)
+	c.Check(span.Name, gc.Equals, "state.txn")
+	c.Check(span.Error, gc.Equals, "")
+	c.Check(span.Attributes, jc.DeepEquals, map[string]string{
+		"database":    "juju",
+		"model-uuid":  s.modelTag.Id(),
+		"collections": "constraints",
+		"ops":         "1",
+	})
+	c.Check(span.End.Before(span.Start), jc.IsFalse)
+}
+
 type SetAdminMongoPasswordSuite struct {
 	testing.BaseSuite
 }
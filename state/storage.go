@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -215,8 +216,9 @@ type storageInstanceDoc struct {
 // storageInstanceConstraints contains a subset of StorageConstraints,
 // for a single storage instance.
 type storageInstanceConstraints struct {
-	Pool string `bson:"pool"`
-	Size uint64 `bson:"size"`
+	Pool     string `bson:"pool"`
+	Size     uint64 `bson:"size"`
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 type storageAttachment struct {
@@ -769,11 +771,28 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				Constraints: storageInstanceConstraints{
-					Pool: cons.Pool,
-					Size: cons.Size,
+					Pool:     cons.Pool,
+					Size:     cons.Size,
+					Snapshot: cons.Snapshot,
 				},
 			}
-			var hostStorageOps []txn.Op
+			var hostStorageOps, sharedStorageOps []txn.Op
+			if _, ok := entityTag.(names.ApplicationTag); ok && kind == StorageKindFilesystem {
+				// Shared filesystems are created along with
+				// the storage instance, so that every unit
+				// of the application attaches the same one.
+				var err error
+				sharedStorageOps, _, err = sb.addSharedFilesystemOps(FilesystemParams{
+					storage: storageTag,
+					Pool:    cons.Pool,
+					Size:    cons.Size,
+				})
+				if err != nil {
+					return fail(errors.Annotatef(
+						err, "creating shared filesystem for storage %s", id,
+					))
+				}
+			}
 			if unitTag, ok := entityTag.(names.UnitTag); ok {
 				doc.AttachmentCount = 1
 				ops = append(ops, createStorageAttachmentOp(storageTag, unitTag))
@@ -816,16 +835,12 @@ func createStorageOps(
 				Insert: doc,
 			})
 			ops = append(ops, hostStorageOps...)
+			ops = append(ops, sharedStorageOps...)
 		}
 	}
 
-	// TODO(axw) create storage attachments for each shared storage
-	// instance owned by the application.
-	//
-	// TODO(axw) prevent creation of shared storage after application
-	// creation, because the only sane time to add storage attachments
-	// is when units are added to said application.
-
+	// Storage attachments for shared storage instances are created
+	// as units are added to the application; see addUnitStorageOps.
 	return ops, storageTags, numStorageAttachments, nil
 }
 
@@ -1529,6 +1544,23 @@ func (sb *storageBackend) detachStorageAttachmentOps(si *storageInstance, unitTa
 			)
 			return nil, nil
 		}
+		if filesystem.Shared() {
+			// A shared filesystem is attached to each host
+			// once, however many of its units are on it.
+			// Leave it attached while other units use it.
+			inUseOps, err := sb.otherHostUnitStorageAttachmentOps(si, unitTag, hostTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if len(inUseOps) > 0 {
+				logger.Debugf(
+					"%s is in use by other units on %s",
+					names.ReadableString(filesystem.Tag()),
+					names.ReadableString(hostTag),
+				)
+				return inUseOps, nil
+			}
+		}
 		return detachFilesystemOps(hostTag, filesystem.FilesystemTag()), nil
 
 	default:
@@ -1536,6 +1568,46 @@ func (sb *storageBackend) detachStorageAttachmentOps(si *storageInstance, unitTa
 	}
 }
 
+// otherHostUnitStorageAttachmentOps returns txn.Ops asserting the
+// existence of the storage instance's attachments to units, other than
+// the one specified, that are assigned to the specified host. If there
+// are no such attachments, no ops are returned.
+func (sb *storageBackend) otherHostUnitStorageAttachmentOps(
+	si *storageInstance, unitTag names.UnitTag, hostTag names.Tag,
+) ([]txn.Op, error) {
+	attachments, err := sb.StorageAttachments(si.StorageTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, a := range attachments {
+		if a.Unit() == unitTag {
+			continue
+		}
+		u, err := sb.unit(a.Unit().Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineId, err := u.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if names.NewMachineTag(machineId) != hostTag {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      storageAttachmentsC,
+			Id:     storageAttachmentId(a.Unit().Id(), si.doc.Id),
+			Assert: txn.DocExists,
+		})
+	}
+	return ops, nil
+}
+
 // removeStorageInstancesOps returns the transaction operations to remove all
 // storage instances owned by the specified entity.
 func removeStorageInstancesOps(im *storageBackend, owner names.Tag) ([]txn.Op, error) {
@@ -1578,6 +1650,10 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// Snapshot is the ID of the volume snapshot from which to
+	// create the storage instances' volumes, if any.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -1634,6 +1710,16 @@ func storageKind(storageType charm.StorageType) storage.StorageKind {
 	return kind
 }
 
+// charmStorageKind returns the kind of storage that a storage provider
+// must support to provide the specified charm storage.
+func charmStorageKind(charmStorage charm.Storage) storage.StorageKind {
+	kind := storageKind(charmStorage.Type)
+	if charmStorage.Shared && kind == storage.StorageKindFilesystem {
+		kind = storage.StorageKindSharedFilesystem
+	}
+	return kind
+}
+
 func validateStorageConstraints(sb *storageBackend, allCons map[string]StorageConstraints, charmMeta *charm.Meta) error {
 	err := validateStorageConstraintsAgainstCharm(sb, allCons, charmMeta)
 	if err != nil {
@@ -1660,11 +1746,18 @@ func validateStorageConstraintsAgainstCharm(
 			return errors.Errorf("charm %q has no store called %q", charmMeta.Name, name)
 		}
 		if charmStorage.Shared {
-			// TODO(axw) implement shared storage support.
-			return errors.Errorf(
-				"charm %q store %q: shared storage support not implemented",
-				charmMeta.Name, name,
-			)
+			if charmStorage.Type != charm.StorageFilesystem {
+				return errors.NotSupportedf(
+					"charm %q store %q: shared %s storage",
+					charmMeta.Name, name, charmStorage.Type,
+				)
+			}
+			if sb.modelType == ModelTypeCAAS {
+				return errors.NotSupportedf(
+					"charm %q store %q: shared storage in a kubernetes model",
+					charmMeta.Name, name,
+				)
+			}
 		}
 		if err := validateCharmStorageCount(charmStorage, cons.Count); err != nil {
 			return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
@@ -1677,14 +1770,79 @@ func validateStorageConstraintsAgainstCharm(
 				humanize.Bytes(cons.Size*humanize.MByte),
 			)
 		}
-		kind := storageKind(charmStorage.Type)
+		kind := charmStorageKind(charmStorage)
 		if err := validateStoragePool(sb, cons.Pool, kind, nil); err != nil {
 			return err
 		}
+		if cons.Snapshot != "" {
+			if err := validateStorageSnapshot(sb, kind, cons); err != nil {
+				return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateStorageSnapshot validates that storage with the given kind
+// and constraints can be created from the volume snapshot identified
+// in the constraints. Filesystem storage may only be created from a
+// snapshot if the filesystem is to be backed by a volume.
+func validateStorageSnapshot(sb *storageBackend, kind storage.StorageKind, cons StorageConstraints) error {
+	if kind != storage.StorageKindBlock && kind != storage.StorageKindFilesystem {
+		return errors.NotSupportedf("creating %s storage from a volume snapshot", kind)
+	}
+	s, err := sb.volumeSnapshotForParams(cons.Snapshot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cons.Pool != s.doc.Pool {
+		return errors.Errorf(
+			"volume snapshot %q is in pool %q, not %q",
+			cons.Snapshot, s.doc.Pool, cons.Pool,
+		)
+	}
+	if kind == storage.StorageKindFilesystem {
+		_, provider, err := poolStorageProvider(sb, cons.Pool)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if provider.Supports(storage.StorageKindFilesystem) {
+			return errors.NotSupportedf(
+				"creating filesystem storage in pool %q from a volume snapshot", cons.Pool,
+			)
+		}
+	}
+	if cons.Size < s.doc.Info.Size {
+		return errors.Errorf(
+			"volume snapshot %q requires at least %s, %s specified",
+			cons.Snapshot,
+			humanize.Bytes(s.doc.Info.Size*humanize.MByte),
+			humanize.Bytes(cons.Size*humanize.MByte),
+		)
 	}
 	return nil
 }
 
+// storageConstraintsWithSnapshotDefaults returns constraints derived
+// from cons, with the pool and size defaulted to those of the volume
+// snapshot identified in the constraints, if any.
+func storageConstraintsWithSnapshotDefaults(sb *storageBackend, cons StorageConstraints) (StorageConstraints, error) {
+	if cons.Snapshot == "" || (cons.Pool != "" && cons.Size != 0) {
+		return cons, nil
+	}
+	s, err := sb.volumeSnapshotForParams(cons.Snapshot)
+	if err != nil {
+		return cons, errors.Trace(err)
+	}
+	if cons.Pool == "" {
+		cons.Pool = s.doc.Pool
+	}
+	if cons.Size == 0 {
+		cons.Size = s.doc.Info.Size
+	}
+	return cons, nil
+}
+
 func validateCharmStorageCountChange(charmStorage charm.Storage, current, n int) error {
 	action := "attach"
 	absn := n
@@ -1824,17 +1982,22 @@ func addDefaultStorageConstraints(sb *storageBackend, allCons map[string]Storage
 
 	for name, charmStorage := range charmMeta.Storage {
 		cons, ok := allCons[name]
-		if !ok {
-			if charmStorage.Shared {
-				// TODO(axw) get the model's default shared storage
-				// pool, and create constraints here.
-				return errors.Errorf(
-					"no constraints specified for shared charm storage %q",
-					name,
-				)
+		if !ok && charmStorage.Shared {
+			// There is no default pool for shared storage,
+			// so it must be requested explicitly.
+			if charmStorage.CountMin == 0 {
+				continue
 			}
+			return errors.Errorf(
+				"no constraints specified for shared charm storage %q",
+				name,
+			)
+		}
+		cons, err := storageConstraintsWithSnapshotDefaults(sb, cons)
+		if err != nil {
+			return errors.Annotatef(err, "getting defaults for %q storage", name)
 		}
-		cons, err := storageConstraintsWithDefaults(sb.modelType, conf, charmStorage, name, cons)
+		cons, err = storageConstraintsWithDefaults(sb.modelType, conf, charmStorage, name, cons)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -1857,7 +2020,7 @@ func storageConstraintsWithDefaults(
 
 	// If no pool is specified, determine the pool from the env config and other constraints.
 	if cons.Pool == "" {
-		kind := storageKind(charmStorage.Type)
+		kind := charmStorageKind(charmStorage)
 		poolName, err := defaultStoragePool(modelType, cfg, kind, cons)
 		if err != nil {
 			return withDefaults, errors.Annotatef(err, "finding default pool for %q storage", name)
@@ -1983,8 +2146,19 @@ func (sb *storageBackend) addStorageForUnitOps(
 	if !ok {
 		return nil, nil, errors.NotFoundf("charm storage %q", storageName)
 	}
+	if charmStorageMeta.Shared {
+		return nil, nil, errors.Errorf(
+			"charm storage %q is shared by the application", storageName,
+		)
+	}
 	ops := u.assertCharmOps(ch)
 
+	// Storage created from a volume snapshot takes its pool
+	// and size from the snapshot, unless otherwise specified.
+	cons, err = storageConstraintsWithSnapshotDefaults(sb, cons)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	if cons.Pool == "" || cons.Size == 0 {
 		// Either pool or size, or both, were not specified. Take the
 		// values from the unit's recorded storage constraints.
@@ -2027,6 +2201,12 @@ func (sb *storageBackend) addStorageForUnitOps(
 	if cons.Count == 0 {
 		return nil, nil, errors.NotValidf("adding storage where instance count is 0")
 	}
+	requested := quota.Usage{quota.Storage: cons.Size * cons.Count}
+	chargeOps, err := quotaOps(sb.mb, requested)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	ops = append(ops, chargeOps...)
 
 	tags, addUnitStorageOps, err := sb.addUnitStorageOps(charmMeta, u, storageName, cons, -1)
 	if err != nil {
@@ -944,6 +944,97 @@ func (s *StorageStateSuite) TestAddApplicationAttachStorage(c *gc.C) {
 	c.Assert(storageAttachments, gc.HasLen, 2)
 }
 
+func (s *StorageStateSuite) TestAddApplicationSharedStorage(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared-filesystem", charm.Storage{
+		Name:     "shared",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	app, err := s.st.AddApplication(state.AddApplicationArgs{
+		Name:  "shared-filesystem",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"shared": makeStorageCons("modelscoped", 1024, 1),
+		},
+		NumUnits: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage is owned by the application, and
+	// attached to each of the application's units.
+	storageInstances, err := s.storageBackend.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstances, gc.HasLen, 1)
+	owner, hasOwner := storageInstances[0].Owner()
+	c.Assert(hasOwner, jc.IsTrue)
+	c.Assert(owner, gc.Equals, app.Tag())
+
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	for _, u := range units {
+		storageAttachments, err := s.storageBackend.UnitStorageAttachments(u.UnitTag())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(storageAttachments, gc.HasLen, 1)
+		c.Assert(storageAttachments[0].StorageInstance(), gc.Equals, storageInstances[0].StorageTag())
+	}
+
+	// Units added later are attached too.
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	storageAttachments, err := s.storageBackend.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageAttachments, gc.HasLen, 1)
+	c.Assert(storageAttachments[0].StorageInstance(), gc.Equals, storageInstances[0].StorageTag())
+}
+
+func (s *StorageStateSuite) TestAddApplicationSharedStorageNoConstraints(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared-filesystem", charm.Storage{
+		Name:     "shared",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	_, err := s.st.AddApplication(state.AddApplicationArgs{Name: "shared-filesystem", Charm: ch})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "shared-filesystem": no constraints specified for shared charm storage "shared"`)
+}
+
+func (s *StorageStateSuite) TestAddApplicationSharedBlockStorage(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared-block", charm.Storage{
+		Name:     "shared",
+		Type:     charm.StorageBlock,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	_, err := s.st.AddApplication(state.AddApplicationArgs{
+		Name:  "shared-block",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"shared": makeStorageCons("modelscoped", 1024, 1),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "shared-block": charm "shared-block" store "shared": shared block storage not supported`)
+}
+
+func (s *StorageStateSuite) TestAddStorageForUnitShared(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared-filesystem", charm.Storage{
+		Name:     "shared",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 0,
+		CountMax: 1,
+	})
+	app := s.AddTestingApplication(c, "shared-filesystem", ch)
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.AddStorageForUnit(u.UnitTag(), "shared", makeStorageCons("modelscoped", 1024, 1))
+	c.Assert(err, gc.ErrorMatches, `.*charm storage "shared" is shared by the application`)
+}
+
 func (s *StorageStateSuite) TestAddApplicationAttachStorageMultipleUnits(c *gc.C) {
 	app, _, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
 	ch, _, _ := app.Charm()
@@ -86,6 +86,7 @@ type unitDoc struct {
 	Application            string
 	Series                 string
 	CharmURL               *charm.URL
+	TargetCharmURL         *charm.URL `bson:"target-charmurl,omitempty"`
 	Principal              string
 	Subordinates           []string
 	StorageAttachmentCount int `bson:"storageattachmentcount"`
@@ -473,6 +474,8 @@ func (op *DestroyUnitOperation) Done(err error) error {
 	if err := op.unit.eraseHistory(); err != nil {
 		logger.Errorf("cannot delete history for unit %q: %v", op.unit.globalKey(), err)
 	}
+	// A unit that never started is removed straight away.
+	retryableAdvanceCharmRollout(op.unit.st, op.unit.doc.Application)
 	return nil
 }
 
@@ -805,7 +808,11 @@ func (u *Unit) Remove() (err error) {
 		}
 		return nil, jujutxn.ErrNoOperations
 	}
-	return unit.st.db().Run(buildTxn)
+	if err := unit.st.db().Run(buildTxn); err != nil {
+		return err
+	}
+	retryableAdvanceCharmRollout(u.st, u.doc.Application)
+	return nil
 }
 
 // Resolved returns the resolved mode for the unit.
@@ -1159,12 +1166,30 @@ func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
 // opening the requested range conflicts with another already opened range on
 // the same subnet and and the unit's assigned machine.
 func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int) (err error) {
+	return u.openPorts(subnetID, "", protocol, fromPort, toPort)
+}
+
+// OpenEndpointPorts opens the given port range and protocol for the given
+// endpoint of the unit's application. If endpoint is empty, the range is
+// opened for all of the application's endpoints. Returns an error if opening
+// the requested range conflicts with another already opened range on the
+// unit's assigned machine.
+func (u *Unit) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return u.openPorts("", endpoint, protocol, fromPort, toPort)
+}
+
+func (u *Unit) openPorts(subnetID, endpoint, protocol string, fromPort, toPort int) (err error) {
 	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	ports.Endpoint = endpoint
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q on subnet %q", ports, u, subnetID)
 
+	if err := u.checkEndpointWhenSet(endpoint); err != nil {
+		return errors.Trace(err)
+	}
+
 	machineID, err := u.AssignedMachineId()
 	if err != nil {
 		return errors.Annotatef(err, "unit %q has no assigned machine", u)
@@ -1182,6 +1207,24 @@ func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int
 	return machinePorts.OpenPorts(ports)
 }
 
+// checkEndpointWhenSet returns an error if endpoint is not empty and
+// is not one of the endpoints of the unit's application.
+func (u *Unit) checkEndpointWhenSet(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := app.Endpoint(endpoint); errors.IsNotFound(err) {
+		return errors.NotValidf("endpoint %q", endpoint)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (u *Unit) checkSubnetAliveWhenSet(subnetID string) error {
 	if subnetID == "" {
 		return nil
@@ -1202,10 +1245,22 @@ func (u *Unit) checkSubnetAliveWhenSet(subnetID string) error {
 // the given subnet, which can be empty. When non-empty, subnetID must refer to
 // an existing, alive subnet, otherwise an error is returned.
 func (u *Unit) ClosePortsOnSubnet(subnetID, protocol string, fromPort, toPort int) (err error) {
+	return u.closePorts(subnetID, "", protocol, fromPort, toPort)
+}
+
+// CloseEndpointPorts closes the given port range and protocol previously
+// opened for the given endpoint of the unit's application. An empty
+// endpoint refers to the range opened for all of the endpoints.
+func (u *Unit) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return u.closePorts("", endpoint, protocol, fromPort, toPort)
+}
+
+func (u *Unit) closePorts(subnetID, endpoint, protocol string, fromPort, toPort int) (err error) {
 	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	ports.Endpoint = endpoint
 	defer errors.DeferredAnnotatef(&err, "cannot close ports %v for unit %q on subnet %q", ports, u, subnetID)
 
 	machineID, err := u.AssignedMachineId()
@@ -1295,13 +1350,15 @@ func (u *Unit) OpenedPortsOnSubnet(subnetID string) ([]network.PortRange, error)
 	} else if err != nil {
 		return nil, errors.Annotatef(err, "failed getting ports for unit %q, subnet %q", u, subnetID)
 	}
-	ports := machinePorts.PortsForUnit(u.Name())
-	for _, port := range ports {
-		result = append(result, network.PortRange{
-			Protocol: port.Protocol,
-			FromPort: port.FromPort,
-			ToPort:   port.ToPort,
-		})
+	// The same range may be open for several endpoints.
+	seen := make(map[network.PortRange]bool)
+	for _, port := range machinePorts.PortsForUnit(u.Name()) {
+		portRange := port.NetworkPortRange()
+		if seen[portRange] {
+			continue
+		}
+		seen[portRange] = true
+		result = append(result, portRange)
 	}
 	network.SortPortRanges(result)
 	return result, nil
@@ -1326,6 +1383,8 @@ func (u *Unit) CharmURL() (*charm.URL, bool) {
 
 // SetCharmURL marks the unit as currently using the supplied charm URL.
 // An error will be returned if the unit is dead, or the charm URL not known.
+// Any charm rollout for the unit's application moves on once the unit is
+// using the new charm.
 func (u *Unit) SetCharmURL(curl *charm.URL) error {
 	if curl == nil {
 		return fmt.Errorf("cannot set nil charm url")
@@ -1390,10 +1449,15 @@ func (u *Unit) SetCharmURL(curl *charm.URL) error {
 		return ops, nil
 	}
 	err := u.st.db().Run(buildTxn)
-	if err == nil {
-		u.doc.CharmURL = curl
+	if err != nil {
+		return err
 	}
-	return err
+	u.doc.CharmURL = curl
+
+	// The unit may have finished upgrading to a charm being rolled
+	// out, letting the rollout move on.
+	retryableAdvanceCharmRollout(u.st, u.doc.Application)
+	return nil
 }
 
 // charm returns the charm for the unit, or the application if the unit's charm
@@ -2221,9 +2285,10 @@ func storageParamsForStorageInstance(
 			}
 		} else if errors.IsNotFound(err) {
 			filesystemParams := FilesystemParams{
-				storage: storage.StorageTag(),
-				Pool:    storage.doc.Constraints.Pool,
-				Size:    storage.doc.Constraints.Size,
+				storage:  storage.StorageTag(),
+				Pool:     storage.doc.Constraints.Pool,
+				Size:     storage.doc.Constraints.Size,
+				Snapshot: storage.doc.Constraints.Snapshot,
 			}
 			filesystems = append(filesystems, HostFilesystemParams{
 				filesystemParams, filesystemAttachmentParams,
@@ -2266,9 +2331,10 @@ func storageParamsForStorageInstance(
 			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
 		} else if errors.IsNotFound(err) {
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				Pool:    storage.doc.Constraints.Pool,
-				Size:    storage.doc.Constraints.Size,
+				storage:  storage.StorageTag(),
+				Pool:     storage.doc.Constraints.Pool,
+				Size:     storage.doc.Constraints.Size,
+				Snapshot: storage.doc.Constraints.Snapshot,
 			}
 			volumes = append(volumes, HostVolumeParams{
 				volumeParams, volumeAttachmentParams,
@@ -2601,8 +2667,48 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	spec, err := u.validateAction(name, payload)
+	if err != nil {
+		return nil, err
+	}
+	payloadWithDefaults, err := spec.InsertDefaults(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := u.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return model.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+}
+
+// addActionOps validates the named action and fills in its default
+// parameters as AddAction does, but returns the new action's document
+// and the transaction operations that add it rather than running them.
+func (u *Unit) addActionOps(name string, payload map[string]interface{}) (actionDoc, []txn.Op, error) {
+	spec, err := u.validateAction(name, payload)
+	if err != nil {
+		return actionDoc{}, nil, err
+	}
+	payloadWithDefaults, err := spec.InsertDefaults(payload)
+	if err != nil {
+		return actionDoc{}, nil, err
+	}
+
+	model, err := u.st.Model()
+	if err != nil {
+		return actionDoc{}, nil, errors.Trace(err)
+	}
+	return model.enqueueActionOps(u.Tag(), name, payloadWithDefaults)
+}
+
+// validateAction checks that the named action is defined for the unit
+// and that the payload is valid for it, returning the action's spec.
+func (u *Unit) validateAction(name string, payload map[string]interface{}) (charm.ActionSpec, error) {
 	if len(name) == 0 {
-		return nil, errors.New("no action name given")
+		return charm.ActionSpec{}, errors.New("no action name given")
 	}
 
 	// If the action is predefined inside juju, get spec from map
@@ -2610,29 +2716,18 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if !ok {
 		specs, err := u.ActionSpecs()
 		if err != nil {
-			return nil, err
+			return charm.ActionSpec{}, err
 		}
 		spec, ok = specs[name]
 		if !ok {
-			return nil, errors.Errorf("action %q not defined on unit %q", name, u.Name())
+			return charm.ActionSpec{}, errors.Errorf("action %q not defined on unit %q", name, u.Name())
 		}
 	}
 	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
-	if err != nil {
-		return nil, err
-	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
-	if err != nil {
-		return nil, err
+	if err := spec.ValidateParams(payload); err != nil {
+		return charm.ActionSpec{}, err
 	}
-
-	model, err := u.st.Model()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	return model.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return spec, nil
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -1295,6 +1295,36 @@ func (s *UnitSuite) testOpenedPorts(c *gc.C, subnetID, expectedErrorCauseMatches
 	}
 }
 
+func (s *UnitSuite) TestOpenCloseEndpointPorts(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenEndpointPorts("db", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenEndpointPorts("bogus", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-80/tcp \("wordpress/0", endpoint "bogus"\) for unit "wordpress/0" on subnet "": endpoint "bogus" not valid`)
+
+	open, err := s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, gc.DeepEquals, []network.PortRange{{80, 80, "tcp"}})
+
+	err = s.unit.CloseEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	open, err = s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, gc.DeepEquals, []network.PortRange{{80, 80, "tcp"}})
+
+	err = s.unit.CloseEndpointPorts("db", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	open, err = s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, gc.HasLen, 0)
+}
+
 func (s *UnitSuite) TestOpenClosePortWhenDying(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,159 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	mongoutils "github.com/juju/juju/mongo/utils"
+)
+
+const (
+	// MaxUnitStateKeySize is the maximum length, in bytes, of a key
+	// in a unit's persistent state.
+	MaxUnitStateKeySize = 256
+
+	// MaxUnitStateSize is the maximum total size, in bytes, of the
+	// keys and values held in a unit's persistent state.
+	MaxUnitStateSize = 64 * 1024
+)
+
+// unitStateDoc records the persistent key/value state stored by a
+// charm on behalf of one of its units. The keys are escaped so that
+// they may be safely stored as mongo document keys.
+type unitStateDoc struct {
+	DocID     string            `bson:"_id"`
+	ModelUUID string            `bson:"model-uuid"`
+	State     map[string]string `bson:"state"`
+}
+
+// State returns the persistent key/value state that the unit's charm
+// has stored on the controller. An empty map is returned if no state
+// has been stored.
+func (u *Unit) State() (map[string]string, error) {
+	unitStates, closer := u.st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	var doc unitStateDoc
+	err := unitStates.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get state for unit %q", u)
+	}
+	return unescapeUnitState(doc.State), nil
+}
+
+// SetState replaces the persistent key/value state stored for the
+// unit. An error satisfying errors.IsNotValid is returned if the state
+// exceeds the size quotas.
+func (u *Unit) SetState(state map[string]string) error {
+	if err := validateUnitState(state); err != nil {
+		return errors.Trace(err)
+	}
+	escaped := escapeUnitState(state)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life == Dead {
+			return nil, errors.Errorf("unit is dead")
+		}
+		unitStates, closer := u.st.db().GetCollection(unitStatesC)
+		defer closer()
+		var doc unitStateDoc
+		err := unitStates.FindId(u.globalKey()).One(&doc)
+		if err == mgo.ErrNotFound {
+			if len(state) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return []txn.Op{
+				{
+					C:      unitsC,
+					Id:     u.doc.DocID,
+					Assert: notDeadDoc,
+				}, {
+					C:      unitStatesC,
+					Id:     u.st.docID(u.globalKey()),
+					Assert: txn.DocMissing,
+					Insert: &unitStateDoc{
+						State: escaped,
+					},
+				},
+			}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{
+			{
+				C:      unitsC,
+				Id:     u.doc.DocID,
+				Assert: notDeadDoc,
+			}, {
+				C:      unitStatesC,
+				Id:     u.st.docID(u.globalKey()),
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"state", escaped}}}},
+			},
+		}, nil
+	}
+	return errors.Annotatef(u.st.db().Run(buildTxn), "cannot set state for unit %q", u)
+}
+
+// removeUnitStateOp returns the operation needed to remove the
+// persistent state of the unit with the given global key.
+func removeUnitStateOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitStatesC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
+
+func validateUnitState(state map[string]string) error {
+	size := 0
+	for key, value := range state {
+		if key == "" {
+			return errors.NotValidf("empty unit state key")
+		}
+		if len(key) > MaxUnitStateKeySize {
+			return errors.NewNotValid(nil, fmt.Sprintf(
+				"unit state key %q exceeds the maximum key size of %d bytes",
+				key, MaxUnitStateKeySize,
+			))
+		}
+		size += len(key) + len(value)
+	}
+	if size > MaxUnitStateSize {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"unit state of %d bytes exceeds the quota of %d bytes",
+			size, MaxUnitStateSize,
+		))
+	}
+	return nil
+}
+
+func escapeUnitState(state map[string]string) map[string]string {
+	result := make(map[string]string, len(state))
+	for key, value := range state {
+		result[mongoutils.EscapeKey(key)] = value
+	}
+	return result
+}
+
+func unescapeUnitState(state map[string]string) map[string]string {
+	result := make(map[string]string, len(state))
+	for key, value := range state {
+		result[mongoutils.UnescapeKey(key)] = value
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UnitStateSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitStateSuite{})
+
+func (s *UnitStateSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = factory.NewFactory(s.State).MakeUnit(c, nil)
+}
+
+func (s *UnitStateSuite) TestStateEmpty(c *gc.C) {
+	st, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, map[string]string{})
+}
+
+func (s *UnitStateSuite) TestSetState(c *gc.C) {
+	err := s.unit.SetState(map[string]string{
+		"foo":        "bar",
+		"dotted.key": "$value",
+		"$dollar":    "baz",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, map[string]string{
+		"foo":        "bar",
+		"dotted.key": "$value",
+		"$dollar":    "baz",
+	})
+
+	err = s.unit.SetState(map[string]string{"foo": "baz"})
+	c.Assert(err, jc.ErrorIsNil)
+	st, err = s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, map[string]string{"foo": "baz"})
+}
+
+func (s *UnitStateSuite) TestSetStateKeyTooLong(c *gc.C) {
+	key := strings.Repeat("k", state.MaxUnitStateKeySize+1)
+	err := s.unit.SetState(map[string]string{key: "value"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `unit state key "k+" exceeds the maximum key size of 256 bytes`)
+}
+
+func (s *UnitStateSuite) TestSetStateQuotaExceeded(c *gc.C) {
+	err := s.unit.SetState(map[string]string{
+		"a": strings.Repeat("v", state.MaxUnitStateSize),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `unit state of 65537 bytes exceeds the quota of 65536 bytes`)
+
+	st, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.HasLen, 0)
+}
+
+func (s *UnitStateSuite) TestSetStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetState(map[string]string{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, `cannot set state for unit "mysql/0": unit is dead`)
+}
+
+func (s *UnitStateSuite) TestRemoveUnitRemovesState(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll := s.MgoSuite.Session.DB("juju").C("unitstates")
+	count, err := coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
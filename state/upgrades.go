@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -1253,6 +1254,10 @@ func AddModelType(st *State) error {
 	return st.db().RunTransaction(ops)
 }
 
+// initialLeaderClaimTime is how long the leases migrated to global
+// time are held for, so that the leaders have some time to settle.
+var initialLeaderClaimTime = time.Minute
+
 // MigrateLeasesToGlobalTime removes old (<2.3-beta2) lease/clock-skew
 // documents, replacing the lease documents with new ones for the
 // existing lease holders.
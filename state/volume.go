@@ -14,6 +14,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 )
@@ -52,6 +53,11 @@ type Volume interface {
 	// Releasing reports whether or not the volume is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// RequestedSize returns the size, in MiB, that the volume has been
+	// requested to grow to. RequestedSize returns true if the volume
+	// is yet to be grown to that size, otherwise false.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -98,6 +104,11 @@ type volumeDoc struct {
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
 
+	// RequestedSize is the size, in MiB, that a provisioned volume
+	// is to be grown to. It is cleared when the volume's info is
+	// updated with a size at least as large.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
+
 	// HostId is the ID of the host that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -130,6 +141,12 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// Snapshot is the ID of the volume snapshot that the volume is
+	// to be created from, if any, and SnapshotId is the snapshot's
+	// provider-specific ID.
+	Snapshot   string `bson:"snapshot,omitempty"`
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -206,6 +223,11 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize > 0
+}
+
 // Releasing is required to imeplement Volume.
 func (v *volume) Releasing() bool {
 	return v.doc.Releasing
@@ -493,7 +515,11 @@ func (sb *storageBackend) removeMachineVolumesOps(m *Machine) ([]txn.Op, error)
 		}
 		ops = append(ops, sb.removeVolumeOps(v.VolumeTag())...)
 	}
-	return ops, nil
+	snapshotOps, err := sb.removeMachineVolumeSnapshotsOps(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, snapshotOps...), nil
 }
 
 // isDetachableVolumeTag reports whether or not the volume with the specified
@@ -919,6 +945,13 @@ func (sb *storageBackend) volumeParamsWithDefaults(params VolumeParams) (VolumeP
 		}
 		params.Pool = poolName
 	}
+	if params.Snapshot != "" && params.SnapshotId == "" {
+		s, err := sb.volumeSnapshotForParams(params.Snapshot)
+		if err != nil {
+			return VolumeParams{}, errors.Trace(err)
+		}
+		params.SnapshotId = s.doc.Info.SnapshotId
+	}
 	return params, nil
 }
 
@@ -931,9 +964,43 @@ func (sb *storageBackend) validateVolumeParams(params VolumeParams, machineId st
 	if params.Size == 0 {
 		return "", errors.New("invalid size 0")
 	}
+	if params.Snapshot != "" {
+		if err := sb.validateVolumeSnapshotParams(params, machineId); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
 	return machineId, nil
 }
 
+// validateVolumeSnapshotParams validates that a volume with the given
+// parameters, scoped to the specified machine, can be created from the
+// volume snapshot identified in the parameters.
+func (sb *storageBackend) validateVolumeSnapshotParams(params VolumeParams, machineId string) error {
+	s, err := sb.volumeSnapshotForParams(params.Snapshot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.doc.Pool != params.Pool {
+		return errors.Errorf(
+			"volume snapshot %q is in pool %q, not %q",
+			params.Snapshot, s.doc.Pool, params.Pool,
+		)
+	}
+	if s.doc.HostId != machineId {
+		return errors.Errorf(
+			"volume snapshot %q cannot be restored on machine %q",
+			params.Snapshot, machineId,
+		)
+	}
+	if params.Size < s.doc.Info.Size {
+		return errors.Errorf(
+			"volume snapshot %q requires a volume of at least %dMiB, %dMiB specified",
+			params.Snapshot, s.doc.Info.Size, params.Size,
+		)
+	}
+	return nil
+}
+
 // volumeAttachmentId returns a volume attachment document ID,
 // given the corresponding volume name and host ID.
 func volumeAttachmentId(hostId, volumeName string) string {
@@ -1116,11 +1183,110 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if requested, ok := v.RequestedSize(); ok && info.Size >= requested {
+			// The volume has been grown to at least the
+			// requested size, so the request is complete.
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"requestedsize", requested}},
+				Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
 }
 
+// ResizeVolume requests that the specified volume be grown to at least
+// the given size in MiB. The volume must be alive and provisioned, and
+// volumes cannot be shrunk. The volume's storage provisioner grows the
+// volume, and records its new size with SetVolumeInfo.
+func (sb *storageBackend) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.NewNotValid(nil, fmt.Sprintf(
+				"new size %dMiB is not larger than current size %dMiB", size, info.Size,
+			))
+		}
+		current := info.Size
+		if requested, ok := v.RequestedSize(); ok {
+			if requested == size {
+				return nil, jujutxn.ErrNoOperations
+			}
+			if requested > current {
+				current = requested
+			}
+		}
+		ops := []txn.Op{{
+			C:  volumesC,
+			Id: tag.Id(),
+			Assert: bson.D{
+				{"life", Alive},
+				{"info.size", info.Size},
+			},
+			Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+		}}
+		if size > current {
+			usage := quota.Usage{quota.Storage: size - current}
+			chargeOps, err := quotaOps(sb.mb, usage)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, chargeOps...)
+		}
+		return ops, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// ResizeStorageInstance requests that the volume backing the specified
+// storage instance be grown to at least the given size in MiB. Only
+// block storage, and filesystem storage backed by a volume, may be
+// resized.
+func (sb *storageBackend) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %q", tag.Id())
+	si, err := sb.storageInstance(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var volumeTag names.VolumeTag
+	switch si.Kind() {
+	case StorageKindBlock:
+		v, err := sb.storageInstanceVolume(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeTag = v.VolumeTag()
+	case StorageKindFilesystem:
+		f, err := sb.storageInstanceFilesystem(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeTag, err = f.Volume()
+		if errors.Cause(err) == ErrNoBackingVolume {
+			return errors.NotSupportedf("resizing filesystem without a backing volume")
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.NotSupportedf("resizing %s storage", si.Kind())
+	}
+	return errors.Trace(sb.ResizeVolume(volumeTag, size))
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
@@ -241,6 +242,119 @@ func (s *VolumeStateSuite) TestSetVolumeInfoImmutable(c *gc.C) {
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) TestResizeVolume(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	err = s.storageBackend.ResizeVolume(volumeTag, 456)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": volume "0/0" not provisioned`)
+
+	volumeInfo := state.VolumeInfo{Size: 123, VolumeId: "vol-ume"}
+	err = s.storageBackend.SetVolumeInfo(volumeTag, volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+
+	err = s.storageBackend.ResizeVolume(volumeTag, 123)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": new size 123MiB is not larger than current size 123MiB`)
+
+	err = s.storageBackend.ResizeVolume(volumeTag, 456)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(456))
+
+	// Setting the info with a smaller size leaves the request in
+	// place; once the volume is at least the requested size, the
+	// request is complete.
+	volumeInfo.Pool = "loop-pool"
+	volumeInfo.Size = 200
+	err = s.storageBackend.SetVolumeInfo(volumeTag, volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+
+	volumeInfo.Size = 512
+	err = s.storageBackend.SetVolumeInfo(volumeTag, volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertVolumeInfo(c, volumeTag, volumeInfo)
+}
+
+func (s *VolumeStateSuite) TestResizeVolumeQuota(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetQuota(quota.Quota{quota.Storage: 2048})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeVolume(volumeTag, 4096)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+	err = s.storageBackend.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeStateSuite) TestResizeStorageInstance(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 123, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 456)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(456))
+}
+
+func (s *VolumeStateSuite) TestResizeStorageInstanceNoBackingVolume(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 456)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *VolumeStateSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	w := s.storageBackend.WatchMachineVolumeResizes(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0/0") // initial
+	wc.AssertNoChange()
+
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 123, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	err = s.storageBackend.ResizeVolume(volumeTag, 456)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	mw := s.storageBackend.WatchMachineVolumeResizes(names.NewMachineTag("1"))
+	defer testing.AssertStop(c, mw)
+	mwc := testing.NewStringsWatcherC(c, s.State, mw)
+	mwc.AssertChangeInSingleEvent()
+	mwc.AssertNoChange()
+}
+
 func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
@@ -0,0 +1,364 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+type VolumeSnapshot interface {
+	Lifer
+
+	// Id returns the snapshot's unique ID. If the snapshot is
+	// scoped to a machine, the ID is prefixed with the machine ID.
+	Id() string
+
+	// StorageInstance returns the tag of the storage instance whose
+	// volume the snapshot was taken of, if any.
+	StorageInstance() (names.StorageTag, bool)
+
+	// Volume returns the tag of the volume the snapshot was taken of.
+	Volume() names.VolumeTag
+
+	// Pool returns the name of the storage pool of the volume the
+	// snapshot was taken of. Volumes created from the snapshot must
+	// be created in the same pool.
+	Pool() string
+
+	// Size returns the size of the volume the snapshot was taken
+	// of, in MiB.
+	Size() uint64
+
+	// Created returns the time at which the snapshot was requested.
+	Created() time.Time
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a
+	// NotProvisioned error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot
+// in the model.
+type volumeSnapshotDoc struct {
+	DocID     string              `bson:"_id"`
+	Name      string              `bson:"name"`
+	ModelUUID string              `bson:"model-uuid"`
+	Life      Life                `bson:"life"`
+	StorageId string              `bson:"storageid,omitempty"`
+	VolumeId  string              `bson:"volumeid"`
+	Pool      string              `bson:"pool"`
+	Size      uint64              `bson:"size"`
+	Created   time.Time           `bson:"created"`
+	Info      *VolumeSnapshotInfo `bson:"info,omitempty"`
+
+	// HostId is the ID of the machine that the snapshot is scoped
+	// to, if the volume it was taken of is machine-scoped. We use
+	// this to determine which snapshots must be removed along with
+	// said machine.
+	HostId string `bson:"hostid,omitempty"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Name
+}
+
+// Life is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// StorageInstance is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) StorageInstance() (names.StorageTag, bool) {
+	if s.doc.StorageId == "" {
+		return names.StorageTag{}, false
+	}
+	return names.NewStorageTag(s.doc.StorageId), true
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.VolumeId)
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Size is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Size() uint64 {
+	return s.doc.Size
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return s.doc.Created
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Name)
+	}
+	return *s.doc.Info, nil
+}
+
+// VolumeSnapshot returns the VolumeSnapshot with the specified ID.
+func (sb *storageBackend) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	s, err := sb.volumeSnapshot(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+func (sb *storageBackend) volumeSnapshot(id string) (*volumeSnapshot, error) {
+	coll, closer := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer closer()
+	var doc volumeSnapshotDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume snapshot %q", id)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// AllVolumeSnapshots returns all volume snapshots in the model.
+func (sb *storageBackend) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	snapshots, err := sb.volumeSnapshots(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]VolumeSnapshot, len(snapshots))
+	for i, s := range snapshots {
+		result[i] = s
+	}
+	return result, nil
+}
+
+func (sb *storageBackend) volumeSnapshots(query interface{}) ([]*volumeSnapshot, error) {
+	coll, closer := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer closer()
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	snapshots := make([]*volumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// CreateVolumeSnapshot records a request to take a snapshot of the
+// volume assigned to the specified storage instance, and returns the
+// new snapshot's ID. For filesystem storage, the snapshot is taken of
+// the filesystem's backing volume; filesystems not backed by a volume
+// cannot be snapshotted. The volume must be alive and provisioned. The
+// volume's storage provisioner takes the snapshot, and records its
+// provider-specific information with SetVolumeSnapshotInfo.
+func (sb *storageBackend) CreateVolumeSnapshot(tag names.StorageTag) (_ string, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot snapshot %s", names.ReadableString(tag))
+	var id string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		kind := si.Kind()
+		if kind != StorageKindBlock && kind != StorageKindFilesystem {
+			return nil, errors.NotSupportedf("snapshotting %s storage", kind)
+		}
+		// A volume-backed filesystem's backing volume is assigned
+		// to the same storage instance as the filesystem.
+		v, err := sb.storageInstanceVolume(tag)
+		if errors.IsNotFound(err) && kind == StorageKindFilesystem {
+			return nil, errors.NotSupportedf("snapshotting filesystem storage not backed by a volume")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.Errorf("volume %s is not alive", v.doc.Name)
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// The snapshot has the same scope as the volume.
+		var hostId string
+		if i := strings.LastIndex(v.doc.Name, "/"); i != -1 {
+			hostId = v.doc.Name[:i]
+		}
+		seq, err := sequence(sb.mb, "volumesnapshot")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		id = fmt.Sprint(seq)
+		if hostId != "" {
+			id = hostId + "/" + id
+		}
+		return []txn.Op{{
+			C:  volumesC,
+			Id: v.doc.Name,
+			Assert: bson.D{
+				{"life", Alive},
+				{"info", bson.D{{"$exists", true}}},
+			},
+		}, {
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: &volumeSnapshotDoc{
+				Name:      id,
+				StorageId: tag.Id(),
+				VolumeId:  v.doc.Name,
+				Pool:      info.Pool,
+				Size:      info.Size,
+				Created:   sb.mb.clock().Now().UTC(),
+				HostId:    hostId,
+			},
+		}}, nil
+	}
+	if err := sb.mb.db().Run(buildTxn); err != nil {
+		return "", errors.Trace(err)
+	}
+	return id, nil
+}
+
+// SetVolumeSnapshotInfo records the provider-specific information for
+// the volume snapshot with the specified ID, once it has been taken.
+func (sb *storageBackend) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Info != nil {
+			if s.doc.Info.SnapshotId != info.SnapshotId {
+				return nil, errors.New("cannot change snapshot ID")
+			}
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"info", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// DestroyVolumeSnapshot ensures that the volume snapshot will be
+// destroyed and removed from state at some point in the future.
+func (sb *storageBackend) DestroyVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(id)
+		if errors.IsNotFound(err) && attempt > 0 {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// RemoveVolumeSnapshot removes the volume snapshot from state.
+// RemoveVolumeSnapshot will fail if the snapshot is Alive; the
+// storage provisioner removes snapshots once it has destroyed them.
+func (sb *storageBackend) RemoveVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() == Alive {
+			return nil, errors.New("volume snapshot is not dying")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"life", bson.D{{"$ne", Alive}}}},
+			Remove: true,
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// removeMachineVolumeSnapshotsOps returns txn.Ops to remove the volume
+// snapshots scoped to the specified machine. This is used when the given
+// machine is being removed from state, as its snapshots can no longer
+// be used or destroyed.
+func (sb *storageBackend) removeMachineVolumeSnapshotsOps(m *Machine) ([]txn.Op, error) {
+	snapshots, err := sb.volumeSnapshots(bson.D{{"hostid", m.Id()}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(snapshots))
+	for i, s := range snapshots {
+		ops[i] = txn.Op{
+			C:      volumeSnapshotsC,
+			Id:     s.doc.Name,
+			Assert: txn.DocExists,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// volumeSnapshotForParams returns the volume snapshot with the specified
+// ID, checking that a volume may be created from it.
+func (sb *storageBackend) volumeSnapshotForParams(id string) (*volumeSnapshot, error) {
+	s, err := sb.volumeSnapshot(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.Life() != Alive {
+		return nil, errors.Errorf("volume snapshot %q is not alive", id)
+	}
+	if s.doc.Info == nil {
+		return nil, errors.NotProvisionedf("volume snapshot %q", id)
+	}
+	return s, nil
+}
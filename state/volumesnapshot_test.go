@@ -0,0 +1,269 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeSnapshotStateSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotStateSuite{})
+
+// setupSnapshot creates a provisioned loop volume on machine 0,
+// and takes a snapshot of it with the specified provider ID.
+func (s *VolumeSnapshotStateSuite) setupSnapshot(c *gc.C, snapshotId string) (names.StorageTag, string) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 123, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, err := s.storageBackend.CreateVolumeSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	if snapshotId != "" {
+		err = s.storageBackend.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			Size:       123,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return storageTag, id
+}
+
+func (s *VolumeSnapshotStateSuite) TestCreateVolumeSnapshot(c *gc.C) {
+	storageTag, id := s.setupSnapshot(c, "")
+	c.Assert(id, gc.Equals, "0/0")
+
+	snapshot, err := s.storageBackend.VolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0/0")
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	c.Assert(snapshot.Volume(), gc.Equals, names.NewVolumeTag("0/0"))
+	c.Assert(snapshot.Pool(), gc.Equals, "loop-pool")
+	c.Assert(snapshot.Size(), gc.Equals, uint64(123))
+	snapshotStorage, ok := snapshot.StorageInstance()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(snapshotStorage, gc.Equals, storageTag)
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	err = s.storageBackend.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 123})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{SnapshotId: "snap-1", Size: 123})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0/0": cannot change snapshot ID`)
+
+	snapshot, err = s.storageBackend.VolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 123})
+
+	all, err := s.storageBackend.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Id(), gc.Equals, "0/0")
+}
+
+func (s *VolumeSnapshotStateSuite) TestCreateVolumeSnapshotUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.CreateVolumeSnapshot(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot storage data/0: volume "0/0" not provisioned`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestCreateVolumeSnapshotFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.CreateVolumeSnapshot(storageTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *VolumeSnapshotStateSuite) TestCreateVolumeSnapshotVolumeBackedFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 123, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, err := s.storageBackend.CreateVolumeSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := s.storageBackend.VolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Assert(snapshot.Size(), gc.Equals, uint64(123))
+	snapshotStorage, ok := snapshot.StorageInstance()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(snapshotStorage, gc.Equals, storageTag)
+}
+
+func (s *VolumeSnapshotStateSuite) TestDestroyRemoveVolumeSnapshot(c *gc.C) {
+	_, id := s.setupSnapshot(c, "snap-0")
+
+	err := s.storageBackend.RemoveVolumeSnapshot(id)
+	c.Assert(err, gc.ErrorMatches, `cannot remove volume snapshot "0/0": volume snapshot is not dying`)
+
+	err = s.storageBackend.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := s.storageBackend.VolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dying)
+	err = s.storageBackend.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.RemoveVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.VolumeSnapshot(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.storageBackend.RemoveVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotStateSuite) TestCreateStorageFromSnapshot(c *gc.C) {
+	_, id := s.setupSnapshot(c, "snap-0")
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingApplicationWithStorage(c, "restored", ch, map[string]state.StorageConstraints{
+		"data": {Snapshot: id, Count: 1},
+	})
+	cons, err := app.StorageConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons["data"], jc.DeepEquals, state.StorageConstraints{
+		Pool:     "loop-pool",
+		Size:     123,
+		Count:    1,
+		Snapshot: "0/0",
+	})
+
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume := s.storageInstanceVolume(c, names.NewStorageTag("data/1"))
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Snapshot, gc.Equals, "0/0")
+	c.Assert(params.SnapshotId, gc.Equals, "snap-0")
+	c.Assert(params.Size, gc.Equals, uint64(123))
+}
+
+func (s *VolumeSnapshotStateSuite) TestExportRefusesSnapshots(c *gc.C) {
+	s.setupSnapshot(c, "snap-0")
+
+	_, err := s.st.Export(map[string]string{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `migrating model with volume snapshots not supported`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestCreateStorageFromSnapshotOtherMachine(c *gc.C) {
+	_, id := s.setupSnapshot(c, "snap-0")
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingApplicationWithStorage(c, "restored", ch, map[string]state.StorageConstraints{
+		"data": {Snapshot: id, Count: 1},
+	})
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignNew)
+	c.Assert(err, gc.ErrorMatches, `.*volume snapshot "0/0" cannot be restored on machine "1"`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestCreateStorageFromSnapshotInvalid(c *gc.C) {
+	_, id := s.setupSnapshot(c, "")
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	_, err := s.st.AddApplication(state.AddApplicationArgs{
+		Name: "restored", Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": {Snapshot: id, Count: 1},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*volume snapshot "0/0" not provisioned`)
+
+	err = s.storageBackend.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 123})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.st.AddApplication(state.AddApplicationArgs{
+		Name: "restored", Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": {Snapshot: id, Size: 100, Count: 1},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*volume snapshot "0/0" requires at least 123 MB, 100 MB specified`)
+	_, err = s.st.AddApplication(state.AddApplicationArgs{
+		Name: "restored", Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": {Snapshot: id, Pool: "loop", Count: 1},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*volume snapshot "0/0" is in pool "loop-pool", not "loop"`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestCreateFilesystemStorageFromSnapshot(c *gc.C) {
+	_, id := s.setupSnapshot(c, "snap-0")
+
+	ch := s.AddTestingCharm(c, "storage-filesystem")
+	app := s.AddTestingApplicationWithStorage(c, "restored", ch, map[string]state.StorageConstraints{
+		"data": {Snapshot: id, Count: 1},
+	})
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The filesystem is backed by a volume created from the snapshot.
+	filesystem := s.storageInstanceFilesystem(c, names.NewStorageTag("data/1"))
+	filesystemParams, ok := filesystem.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(filesystemParams.Snapshot, gc.Equals, "0/0")
+	volumeTag, err := filesystem.Volume()
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := s.storageBackend.Volume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeParams, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(volumeParams.Snapshot, gc.Equals, "0/0")
+	c.Assert(volumeParams.SnapshotId, gc.Equals, "snap-0")
+}
+
+func (s *VolumeSnapshotStateSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	w := s.storageBackend.WatchMachineVolumeSnapshots(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	_, id := s.setupSnapshot(c, "")
+	wc.AssertChangeInSingleEvent(id)
+	wc.AssertNoChange()
+
+	err := s.storageBackend.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(id)
+	wc.AssertNoChange()
+
+	mw := s.storageBackend.WatchModelVolumeSnapshots()
+	defer testing.AssertStop(c, mw)
+	mwc := testing.NewStringsWatcherC(c, s.State, mw)
+	mwc.AssertChangeInSingleEvent()
+	mwc.AssertNoChange()
+}
@@ -228,6 +228,55 @@ func (sb *storageBackend) WatchModelFilesystems() StringsWatcher {
 	return sb.watchModelHostStorage(filesystemsC)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes. The watcher's consumer must check
+// whether a changed volume has been requested to be resized.
+func (sb *storageBackend) WatchModelVolumeResizes() StringsWatcher {
+	mb := sb.mb
+	return newCollectionWatcher(mb, colWCfg{
+		col: volumesC,
+		filter: func(id interface{}) bool {
+			k, err := mb.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return !strings.Contains(k, "/")
+		},
+	})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to volumes scoped to the specified machine. The watcher's
+// consumer must check whether a changed volume has been requested to
+// be resized.
+func (sb *storageBackend) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	mb := sb.mb
+	prefix := m.Id() + "/"
+	return newCollectionWatcher(mb, colWCfg{
+		col: volumesC,
+		filter: func(id interface{}) bool {
+			k, err := mb.strictLocalID(id.(string))
+			if err != nil || !strings.HasPrefix(k, prefix) {
+				return false
+			}
+			return !strings.Contains(k[len(prefix):], "/")
+		},
+	})
+}
+
+// WatchModelVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all model-scoped volume snapshots.
+func (sb *storageBackend) WatchModelVolumeSnapshots() StringsWatcher {
+	return sb.watchModelHostStorage(volumeSnapshotsC)
+}
+
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all volume snapshots scoped to the
+// specified machine.
+func (sb *storageBackend) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	return sb.watchHostStorage(m, volumeSnapshotsC)
+}
+
 var machineOrUnitSnippet = "(" + names.NumberSnippet + "|" + names.UnitSnippet + ")"
 
 func (sb *storageBackend) watchModelHostStorage(collection string) StringsWatcher {
@@ -1647,6 +1696,12 @@ func (sb *storageBackend) WatchVolumeAttachment(host names.Tag, v names.VolumeTa
 	return newEntityWatcher(sb.mb, volumeAttachmentsC, sb.mb.docID(id))
 }
 
+// WatchFilesystem returns a watcher for observing changes to a
+// filesystem, such as its size changing.
+func (sb *storageBackend) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, filesystemsC, sb.mb.docID(f.Id()))
+}
+
 // WatchFilesystemAttachment returns a watcher for observing changes
 // to a filesystem attachment.
 func (sb *storageBackend) WatchFilesystemAttachment(host names.Tag, f names.FilesystemTag) NotifyWatcher {
@@ -112,6 +112,20 @@ func (st *State) newLeaseManager(
 	return manager, nil
 }
 
+// LeaseSecretary returns the lease.Secretary used to validate
+// requests in the given lease namespace. It is intended for lease
+// managers that serve every model in the controller, such as the one
+// backed by the raft lease store.
+func LeaseSecretary(namespace string) (lease.Secretary, error) {
+	switch namespace {
+	case applicationLeadershipNamespace:
+		return leadershipSecretary{}, nil
+	case singularControllerNamespace:
+		return controllerSingularSecretary{}, nil
+	}
+	return nil, errors.NotValidf("lease namespace %q", namespace)
+}
+
 func (ws *workers) txnLogWatcher() watcher.BaseWatcher {
 	w, err := ws.Worker(txnLogWorker, nil)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
 )
 
 var logger = loggo.GetLogger("juju.storage")
@@ -27,19 +28,30 @@ type Constraints struct {
 
 	// Count is the number of instances of the storage to create.
 	Count uint64
+
+	// Snapshot is the ID of the volume snapshot that the storage
+	// should be created from, or "" if the storage should be created
+	// empty.
+	Snapshot string `json:",omitempty"`
 }
 
 var (
 	poolRE  = regexp.MustCompile("^[a-zA-Z]+[-?a-zA-Z0-9]*$")
 	countRE = regexp.MustCompile("^-?[0-9]+$")
 	sizeRE  = regexp.MustCompile("^-?[0-9]+(?:\\.[0-9]+)?[MGTPEZY](?:i?B)?$")
+
+	snapshotIdRE = regexp.MustCompile("^(?:" + names.MachineSnippet + "/)?" + names.NumberSnippet + "$")
 )
 
+// snapshotPrefix is the prefix of a storage constraint field that
+// identifies a volume snapshot.
+const snapshotPrefix = "snapshot:"
+
 // ParseConstraints parses the specified string and creates a
 // Constraints structure.
 //
 // The acceptable format for storage constraints is a comma separated
-// sequence of: POOL, COUNT, and SIZE, where
+// sequence of: POOL, COUNT, SIZE and SNAPSHOT, where
 //
 //    POOL identifies the storage pool. POOL can be a string
 //    starting with a letter, followed by zero or more digits
@@ -53,6 +65,9 @@ var (
 //    create. SIZE is a floating point number and multiplier from
 //    the set (M, G, T, P, E, Z, Y), which are all treated as
 //    powers of 1024.
+//
+//    SNAPSHOT is "snapshot:" followed by the ID of a volume snapshot
+//    from which the storage instances should be created.
 func ParseConstraints(s string) (Constraints, error) {
 	var cons Constraints
 	fields := strings.Split(s, ",")
@@ -60,6 +75,14 @@ func ParseConstraints(s string) (Constraints, error) {
 		if field == "" {
 			continue
 		}
+		if strings.HasPrefix(field, snapshotPrefix) {
+			id := field[len(snapshotPrefix):]
+			if !IsValidSnapshotId(id) {
+				return cons, errors.NotValidf("snapshot ID %q", id)
+			}
+			cons.Snapshot = id
+			continue
+		}
 		if IsValidPoolName(field) {
 			if cons.Pool != "" {
 				logger.Debugf("pool name is already set to %q, ignoring %q", cons.Pool, field)
@@ -84,7 +107,7 @@ func ParseConstraints(s string) (Constraints, error) {
 		}
 		logger.Debugf("ignoring unknown storage constraint %q", field)
 	}
-	if cons.Count == 0 && cons.Size == 0 && cons.Pool == "" {
+	if cons.Count == 0 && cons.Size == 0 && cons.Pool == "" && cons.Snapshot == "" {
 		return Constraints{}, errors.New("storage constraints require at least one field to be specified")
 	}
 	if cons.Count == 0 {
@@ -98,6 +121,12 @@ func IsValidPoolName(s string) bool {
 	return poolRE.MatchString(s)
 }
 
+// IsValidSnapshotId checks if given string is a valid volume
+// snapshot ID.
+func IsValidSnapshotId(s string) bool {
+	return snapshotIdRE.MatchString(s)
+}
+
 // ParseConstraintsMap parses string representation of
 // storage constraints into a map keyed on storage names
 // with constraints as values.
@@ -75,6 +75,30 @@ func (s *ConstraintsSuite) TestParseConstraintsSizeRange(c *gc.C) {
 	s.testParseError(c, "p,-100M", `cannot parse size: expected a non-negative number, got "-100M"`)
 }
 
+func (s *ConstraintsSuite) TestParseConstraintsSnapshot(c *gc.C) {
+	s.testParse(c, "snapshot:3", storage.Constraints{
+		Count:    1,
+		Snapshot: "3",
+	})
+	s.testParse(c, "p,snapshot:0/lxd/1/3,10G", storage.Constraints{
+		Pool:     "p",
+		Count:    1,
+		Size:     10 * 1024,
+		Snapshot: "0/lxd/1/3",
+	})
+	s.testParseError(c, "snapshot:", `snapshot ID "" not valid`)
+	s.testParseError(c, "snapshot:abc", `snapshot ID "abc" not valid`)
+}
+
+func (s *ConstraintsSuite) TestValidSnapshotId(c *gc.C) {
+	for _, id := range []string{"0", "12", "0/1", "0/lxd/1/2"} {
+		c.Check(storage.IsValidSnapshotId(id), jc.IsTrue, gc.Commentf("%q", id))
+	}
+	for _, id := range []string{"", "abc", "0/", "/1", "-1"} {
+		c.Check(storage.IsValidSnapshotId(id), jc.IsFalse, gc.Commentf("%q", id))
+	}
+}
+
 func (*ConstraintsSuite) testParse(c *gc.C, s string, expect storage.Constraints) {
 	cons, err := storage.ParseConstraints(s)
 	c.Check(err, jc.ErrorIsNil)
@@ -216,6 +216,42 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeResizer provides an interface for growing volumes. A
+// VolumeSource that supports growing its volumes, while they are
+// attached and in use, should implement VolumeResizer.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters
+	// to at least the requested size, returning the new size of each
+	// volume. Volumes are never shrunk.
+	//
+	// Growing a volume does not grow the filesystem on it; that is
+	// done on the machine once the larger block device is visible.
+	ResizeVolumes(ctx context.ProviderCallContext, params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// VolumeSnapshotter provides an interface for taking point-in-time
+// snapshots of volumes. A VolumeSource that supports snapshots should
+// implement VolumeSnapshotter, and create volumes from the snapshot
+// identified by VolumeParams.SnapshotId, if any.
+type VolumeSnapshotter interface {
+	// CreateSnapshots takes snapshots of the volumes with the
+	// specified parameters.
+	CreateSnapshots(ctx context.ProviderCallContext, params []VolumeSnapshotParams) ([]CreateSnapshotsResult, error)
+
+	// DestroySnapshots destroys the snapshots with the specified
+	// provider snapshot IDs.
+	DestroySnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error)
+}
+
+// FilesystemResizer provides an interface for growing filesystems to
+// fill the volumes that they are created on.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters to fill their backing volumes, returning the new
+	// size of each filesystem.
+	ResizeFilesystems(ctx context.ProviderCallContext, params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -250,6 +286,13 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId is the unique provider-supplied ID of the snapshot
+	// that the volume should be created from, or "" if the volume
+	// should be created empty. Only volume sources that implement
+	// VolumeSnapshotter will be asked to create volumes from
+	// snapshots.
+	SnapshotId string
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -266,6 +309,39 @@ type VolumeAttachmentParams struct {
 	VolumeId string
 }
 
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Size is the minimum size, in MiB, that the volume should be
+	// grown to.
+	Size uint64
+}
+
+// VolumeSnapshotParams is a set of parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju for the snapshot.
+	Id string
+
+	// Volume is the unique tag assigned by Juju for the volume.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Size is the size of the volume, in MiB.
+	Size uint64
+
+	// ResourceTags is a set of tags to set on the created snapshot,
+	// if the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
 // AttachmentParams describes the parameters for attaching a volume or
 // filesystem to a machine.
 type AttachmentParams struct {
@@ -319,6 +395,22 @@ type FilesystemParams struct {
 	// to initially, or nil if the filesystem should not be attached to any
 	// machine.
 	Attachment *FilesystemAttachmentParams
+
+	// FromSnapshot reports whether the filesystem's backing volume is
+	// created from a volume snapshot, and so already holds a filesystem
+	// that must not be recreated.
+	FromSnapshot bool
+}
+
+// FilesystemResizeParams is a set of parameters for growing a
+// filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the filesystem.
+	Tag names.FilesystemTag
+
+	// FilesystemId is the unique provider-supplied ID for the
+	// filesystem.
+	FilesystemId string
 }
 
 // FilesystemAttachmentParams is a set of parameters for filesystem attachment
@@ -375,3 +467,28 @@ type AttachFilesystemsResult struct {
 	FilesystemAttachment *FilesystemAttachment
 	Error                error
 }
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Size should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	// Size is the size of the volume, in MiB, after resizing.
+	Size  uint64
+	Error error
+}
+
+// CreateSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateSnapshots call for one volume. Snapshot
+// should only be used if Error is nil.
+type CreateSnapshotsResult struct {
+	Snapshot *VolumeSnapshotInfo
+	Error    error
+}
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem. Size
+// should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	// Size is the size of the filesystem, in MiB, after resizing.
+	Size  uint64
+	Error error
+}
@@ -0,0 +1,380 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	azurestorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	AzureFileProviderType = storage.ProviderType("azurefile")
+
+	// AzureFileAccount is the pool configuration key for the name
+	// of the Azure storage account in which file shares are created.
+	AzureFileAccount = "account"
+
+	// AzureFileKey is the pool configuration key for the access
+	// key of the Azure storage account.
+	AzureFileKey = "key"
+
+	// AzureFileEndpoint is the pool configuration key for the
+	// storage service base URL of the Azure cloud. If unspecified,
+	// the Azure public cloud's is used.
+	AzureFileEndpoint = "endpoint"
+)
+
+// azureStorageAccountRE matches valid Azure storage account names.
+var azureStorageAccountRE = regexp.MustCompile("^[a-z0-9]{3,24}$")
+
+// azureFileShares creates Azure file shares.
+type azureFileShares interface {
+	// CreateShare creates the share with the specified name and
+	// quota in GiB, if it does not already exist.
+	CreateShare(name string, quotaGiB int) error
+}
+
+// newAzureFileSharesFunc is the type of a function that returns
+// an azureFileShares for the given storage account.
+type newAzureFileSharesFunc func(account, key, endpoint string) (azureFileShares, error)
+
+// azureFileProvider creates storage sources which provide access to
+// Azure Files shares. The filesystems are shared: a filesystem may be
+// mounted on any number of machines at once.
+//
+// The shares are created and mounted with the storage account key
+// from the pool configuration, by the machines that they are attached
+// to, so the provider is machine-scoped.
+type azureFileProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+
+	// newShares returns an azureFileShares for a storage account.
+	newShares newAzureFileSharesFunc
+}
+
+var (
+	_ storage.Provider = (*azureFileProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (p *azureFileProvider) ValidateConfig(cfg *storage.Config) error {
+	account, _ := cfg.ValueString(AzureFileAccount)
+	if account == "" {
+		return errors.Errorf("%q must be specified", AzureFileAccount)
+	}
+	if !azureStorageAccountRE.MatchString(account) {
+		return errors.NotValidf("storage account %q", account)
+	}
+	key, _ := cfg.ValueString(AzureFileKey)
+	if key == "" {
+		return errors.Errorf("%q must be specified", AzureFileKey)
+	}
+	if endpoint, ok := cfg.ValueString(AzureFileEndpoint); ok && strings.ContainsAny(endpoint, ":/") {
+		return errors.NotValidf("storage endpoint %q", endpoint)
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *azureFileProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *azureFileProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	// The source configuration does not include the pool attributes;
+	// those are passed in with the filesystem parameters.
+	storageDir, ok := sourceConfig.ValueString(storage.ConfigStorageDir)
+	if !ok || storageDir == "" {
+		return nil, errors.New("storage directory not specified")
+	}
+	return &azureFileFilesystemSource{
+		&osDirFuncs{p.run},
+		p.run,
+		p.newShares,
+		storageDir,
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*azureFileProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem || k == storage.StorageKindSharedFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*azureFileProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*azureFileProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*azureFileProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*azureFileProvider) DefaultPools() []*storage.Config {
+	// There is no default storage account.
+	return nil
+}
+
+type azureFileFilesystemSource struct {
+	dirFuncs   dirFuncs
+	run        runCommandFunc
+	newShares  newAzureFileSharesFunc
+	storageDir string
+}
+
+var _ storage.FilesystemSource = (*azureFileFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *azureFileFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	cfg, err := storage.NewConfig("azurefile", AzureFileProviderType, params.Attributes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p := &azureFileProvider{s.run, s.newShares}
+	return p.ValidateConfig(cfg)
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+//
+// Shared filesystems are created by each machine that they are
+// attached to, so creating a share that already exists is not
+// an error.
+func (s *azureFileFilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *azureFileFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	if err := s.ValidateFilesystemParams(params); err != nil {
+		return nil, errors.Trace(err)
+	}
+	account, key, endpoint := azureFileAccount(params.Attributes)
+	modelUUID := params.ResourceTags[tags.JujuModel]
+	if modelUUID == "" {
+		return nil, errors.New("model UUID not specified")
+	}
+
+	// Shares are named for the model and filesystem, so that
+	// several models can share one storage account. Share quotas
+	// are set in GiB, so the size is rounded up.
+	name := azureFileShareName(modelUUID, params.Tag.Id())
+	quotaGiB := (params.Size + 1023) / 1024
+	shares, err := s.newShares(account, key, endpoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := shares.CreateShare(name, int(quotaGiB)); err != nil {
+		return nil, errors.Annotatef(err, "creating Azure file share %q", name)
+	}
+	// The attachment parameters do not include the pool attributes,
+	// so record the account key for mounting the share.
+	if err := s.writeCredentials(account, key); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	info := storage.FilesystemInfo{
+		FilesystemId: fmt.Sprintf("//%s.file.%s/%s", account, endpoint, name),
+		Size:         quotaGiB * 1024,
+	}
+	return &storage.Filesystem{params.Tag, params.Volume, info}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *azureFileFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	// DestroyFilesystems is a no-op; as with NFS exports, the
+	// share is left for the owner of the storage account to
+	// remove.
+	return make([]error, len(filesystemIds)), nil
+}
+
+// ReleaseFilesystems is defined on the FilesystemSource interface.
+func (s *azureFileFilesystemSource) ReleaseFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *azureFileFilesystemSource) AttachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *azureFileFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	mountPoint := arg.Path
+	if mountPoint == "" {
+		return nil, errNoMountPoint
+	}
+	if arg.FilesystemId == "" {
+		return nil, errors.New("filesystem ID not specified")
+	}
+	if err := ensureDir(s.dirFuncs, mountPoint); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Check if the mount already exists.
+	source, err := s.dirFuncs.mountPointSource(mountPoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if source != arg.FilesystemId {
+		if err := ensureEmptyDir(s.dirFuncs, mountPoint); err != nil {
+			return nil, err
+		}
+		// The account key is passed to mount in the credentials
+		// file written when the share was created, so that it is
+		// not logged with the command.
+		account := strings.SplitN(strings.TrimPrefix(arg.FilesystemId, "//"), ".", 2)[0]
+		credentials := s.credentialsPath(account)
+		if _, err := os.Stat(credentials); err != nil {
+			return nil, errors.Annotatef(err, "cannot find credentials for storage account %q", account)
+		}
+		options := []string{
+			"vers=3.0",
+			"credentials=" + credentials,
+			"dir_mode=0777",
+			"file_mode=0777",
+			"serverino",
+		}
+		if arg.ReadOnly {
+			options = append(options, "ro")
+		}
+		args := []string{"-t", "cifs", "-o", strings.Join(options, ","), arg.FilesystemId, mountPoint}
+		if _, err := s.run("mount", args...); err != nil {
+			return nil, errors.Annotate(err, "cannot mount Azure file share")
+		}
+	}
+
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     mountPoint,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// credentialsPath returns the path of the CIFS credentials
+// file for the storage account.
+func (s *azureFileFilesystemSource) credentialsPath(account string) string {
+	return filepath.Join(s.storageDir, "azurefile", account+".cred")
+}
+
+// writeCredentials writes a CIFS credentials file for the
+// storage account, readable only by its owner.
+func (s *azureFileFilesystemSource) writeCredentials(account, key string) error {
+	path := s.credentialsPath(account)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	content := fmt.Sprintf("username=%s\npassword=%s\n", account, key)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		return errors.Annotate(err, "writing storage account credentials")
+	}
+	return nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *azureFileFilesystemSource) DetachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
+
+// azureFileAccount returns the storage account name, key and
+// endpoint from validated pool attributes.
+func azureFileAccount(attrs map[string]interface{}) (account, key, endpoint string) {
+	account, _ = attrs[AzureFileAccount].(string)
+	key, _ = attrs[AzureFileKey].(string)
+	endpoint, _ = attrs[AzureFileEndpoint].(string)
+	if endpoint == "" {
+		endpoint = azurestorage.DefaultBaseURL
+	}
+	return account, key, endpoint
+}
+
+// maxAzureFileShareNameLength is the maximum length of an Azure file
+// share name.
+const maxAzureFileShareNameLength = 63
+
+// azureFileShareName returns the name of the file share for the
+// filesystem with the given ID, in the model with the given UUID.
+// Share names may contain only lower-case letters, digits and
+// single hyphens, and are at most 63 characters long; names that
+// would be longer are truncated, and a hash of the full name is
+// appended to keep them unique.
+func azureFileShareName(modelUUID, filesystemId string) string {
+	name := fmt.Sprintf("juju-%s-fs-%s", modelUUID, strings.Replace(filesystemId, "/", "-", -1))
+	if len(name) <= maxAzureFileShareNameLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(hash[:4])
+	prefix := strings.TrimRight(name[:maxAzureFileShareNameLength-len(suffix)-1], "-")
+	return prefix + "-" + suffix
+}
+
+// newAzureFileShares returns an azureFileShares that creates shares
+// in the given storage account with the Azure storage SDK.
+func newAzureFileShares(account, key, endpoint string) (azureFileShares, error) {
+	client, err := azurestorage.NewClient(
+		account, key, endpoint, azurestorage.DefaultAPIVersion, true,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &azureFileShareClient{client.GetFileService()}, nil
+}
+
+type azureFileShareClient struct {
+	files azurestorage.FileServiceClient
+}
+
+// CreateShare is part of the azureFileShares interface.
+func (c *azureFileShareClient) CreateShare(name string, quotaGiB int) error {
+	share := c.files.GetShareReference(name)
+	share.Properties.Quota = quotaGiB
+	_, err := share.CreateIfNotExists(nil)
+	return errors.Trace(err)
+}
@@ -0,0 +1,262 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&azureFileSuite{})
+
+type azureFileSuite struct {
+	testing.BaseSuite
+	storageDir string
+	commands   *mockRunCommand
+	shares     *fakeAzureFileShares
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *azureFileSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.storageDir = c.MkDir()
+	s.shares = &fakeAzureFileShares{}
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *azureFileSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *azureFileSuite) azureFileProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.AzureFileProvider(s.commands.run)
+}
+
+func (s *azureFileSuite) azureFileFilesystemSource(c *gc.C) storage.FilesystemSource {
+	s.commands = &mockRunCommand{c: c}
+	source, _ := provider.AzureFileFilesystemSource(s.storageDir, s.commands.run, s.shares.new)
+	return source
+}
+
+func (s *azureFileSuite) TestValidateConfig(c *gc.C) {
+	p := s.azureFileProvider(c)
+	for _, test := range []struct {
+		attrs  map[string]interface{}
+		expect string
+	}{{
+		attrs:  map[string]interface{}{"key": "c2VjcmV0"},
+		expect: `"account" must be specified`,
+	}, {
+		attrs:  map[string]interface{}{"account": "Juju.Store", "key": "c2VjcmV0"},
+		expect: `storage account "Juju.Store" not valid`,
+	}, {
+		attrs:  map[string]interface{}{"account": "jujustore"},
+		expect: `"key" must be specified`,
+	}, {
+		attrs:  map[string]interface{}{"account": "jujustore", "key": "c2VjcmV0", "endpoint": "https://core.windows.net"},
+		expect: `storage endpoint "https://core.windows.net" not valid`,
+	}, {
+		attrs: map[string]interface{}{"account": "jujustore", "key": "c2VjcmV0"},
+	}, {
+		attrs: map[string]interface{}{"account": "jujustore", "key": "c2VjcmV0", "endpoint": "core.chinacloudapi.cn"},
+	}} {
+		cfg, err := storage.NewConfig("name", provider.AzureFileProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.expect == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expect)
+		}
+	}
+}
+
+func (s *azureFileSuite) TestSupports(c *gc.C) {
+	p := s.azureFileProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindSharedFilesystem), jc.IsTrue)
+}
+
+func (s *azureFileSuite) TestScope(c *gc.C) {
+	p := s.azureFileProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *azureFileSuite) TestCreateFilesystems(c *gc.C) {
+	source := s.azureFileFilesystemSource(c)
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 1500,
+		Attributes: map[string]interface{}{
+			"account": "jujustore",
+			"key":     "c2VjcmV0",
+		},
+		ResourceTags: map[string]string{
+			tags.JujuModel: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("6"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "//jujustore.file.core.windows.net/juju-deadbeef-0bad-400d-8000-4b1d0d06f00d-fs-6",
+				Size:         2048,
+			},
+		},
+	}})
+	s.shares.CheckCalls(c, []gitjujutesting.StubCall{
+		{"NewShares", []interface{}{"jujustore", "c2VjcmV0", "core.windows.net"}},
+		{"CreateShare", []interface{}{"juju-deadbeef-0bad-400d-8000-4b1d0d06f00d-fs-6", 2}},
+	})
+
+	credentials := filepath.Join(s.storageDir, "azurefile", "jujustore.cred")
+	content, err := ioutil.ReadFile(credentials)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "username=jujustore\npassword=c2VjcmV0\n")
+	info, err := os.Stat(credentials)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+}
+
+func (s *azureFileSuite) TestCreateFilesystemsLongShareName(c *gc.C) {
+	source := s.azureFileFilesystemSource(c)
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("10/lxd/12/lxd/34/567"),
+		Size: 1024,
+		Attributes: map[string]interface{}{
+			"account": "jujustore",
+			"key":     "c2VjcmV0",
+		},
+		ResourceTags: map[string]string{
+			tags.JujuModel: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	// Share names are at most 63 characters long, so the name is
+	// truncated and made unique with a hash of the full name.
+	const shareName = "juju-deadbeef-0bad-400d-8000-4b1d0d06f00d-fs-10-lxd-12-cb8fccd4"
+	c.Assert(results[0].Filesystem.FilesystemId, gc.Equals, "//jujustore.file.core.windows.net/"+shareName)
+	s.shares.CheckCall(c, 1, "CreateShare", shareName, 1)
+}
+
+func (s *azureFileSuite) TestCreateFilesystemsError(c *gc.C) {
+	source := s.azureFileFilesystemSource(c)
+	s.shares.SetErrors(nil, errors.New("quota exceeded"))
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 1024,
+		Attributes: map[string]interface{}{
+			"account": "jujustore",
+			"key":     "c2VjcmV0",
+		},
+		ResourceTags: map[string]string{
+			tags.JujuModel: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`creating Azure file share "juju-deadbeef-0bad-400d-8000-4b1d0d06f00d-fs-6": quota exceeded`)
+}
+
+func (s *azureFileSuite) TestAttachFilesystems(c *gc.C) {
+	source := s.azureFileFilesystemSource(c)
+	credentials := filepath.Join(s.storageDir, "azurefile", "jujustore.cred")
+	err := os.MkdirAll(filepath.Dir(credentials), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(credentials, nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	const mountPoint = "/var/lib/juju/storage/shared"
+	const filesystemId = "//jujustore.file.core.windows.net/juju-uuid-fs-6"
+	cmd := s.commands.expect("df", "--output=source", mountPoint)
+	cmd.respond("header\n/dev/sda1", nil)
+	s.commands.expect(
+		"mount", "-t", "cifs", "-o",
+		"vers=3.0,credentials="+credentials+",dir_mode=0777,file_mode=0777,serverino,ro",
+		filesystemId, mountPoint,
+	)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: filesystemId,
+		Path:         mountPoint,
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("2"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("6"),
+			Machine:    names.NewMachineTag("2"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     mountPoint,
+				ReadOnly: true,
+			},
+		},
+	}})
+}
+
+func (s *azureFileSuite) TestAttachFilesystemsNoCredentials(c *gc.C) {
+	source := s.azureFileFilesystemSource(c)
+	const mountPoint = "/var/lib/juju/storage/shared"
+	cmd := s.commands.expect("df", "--output=source", mountPoint)
+	cmd.respond("header\n/dev/sda1", nil)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "//jujustore.file.core.windows.net/juju-uuid-fs-6",
+		Path:         mountPoint,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `cannot find credentials for storage account "jujustore": .*`)
+}
+
+func (s *azureFileSuite) TestDetachFilesystems(c *gc.C) {
+	source := s.azureFileFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, s.callCtx, true)
+}
+
+type fakeAzureFileShares struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeAzureFileShares) new(account, key, endpoint string) (provider.AzureFileShares, error) {
+	f.MethodCall(f, "NewShares", account, key, endpoint)
+	return f, f.NextErr()
+}
+
+func (f *fakeAzureFileShares) CreateShare(name string, quotaGiB int) error {
+	f.MethodCall(f, "CreateShare", name, quotaGiB)
+	return f.NextErr()
+}
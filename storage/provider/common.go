@@ -13,9 +13,11 @@ var (
 	errNoMountPoint = errors.New("filesystem mount point not specified")
 
 	commonStorageProviders = map[storage.ProviderType]storage.Provider{
-		LoopProviderType:   &loopProvider{logAndExec},
-		RootfsProviderType: &rootfsProvider{logAndExec},
-		TmpfsProviderType:  &tmpfsProvider{logAndExec},
+		AzureFileProviderType: &azureFileProvider{logAndExec, newAzureFileShares},
+		LoopProviderType:      &loopProvider{logAndExec},
+		NFSProviderType:       &nfsProvider{logAndExec},
+		RootfsProviderType:    &rootfsProvider{logAndExec},
+		TmpfsProviderType:     &tmpfsProvider{logAndExec},
 	}
 )
 
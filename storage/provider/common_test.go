@@ -31,7 +31,9 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 		c.Assert(p, gc.NotNil)
 	}
 	c.Assert(common, jc.SameContents, []storage.ProviderType{
+		provider.AzureFileProviderType,
 		provider.LoopProviderType,
+		provider.NFSProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
 	})
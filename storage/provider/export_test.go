@@ -128,3 +128,40 @@ func TmpfsFilesystemSource(storageDir string, run func(string, ...string) (strin
 func TmpfsProvider(run func(string, ...string) (string, error)) storage.Provider {
 	return &tmpfsProvider{run}
 }
+
+func NFSFilesystemSource(storageDir string, run func(string, ...string) (string, error)) (storage.FilesystemSource, *MockDirFuncs) {
+	d := &MockDirFuncs{
+		osDirFuncs{run},
+		set.NewStrings(),
+	}
+	return &nfsFilesystemSource{d, run, storageDir}, d
+}
+
+func NFSProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &nfsProvider{run}
+}
+
+// AzureFileShares is an interface implemented by fakes of the
+// Azure file share client.
+type AzureFileShares interface {
+	CreateShare(name string, quotaGiB int) error
+}
+
+func AzureFileFilesystemSource(
+	storageDir string,
+	run func(string, ...string) (string, error),
+	shares func(account, key, endpoint string) (AzureFileShares, error),
+) (storage.FilesystemSource, *MockDirFuncs) {
+	d := &MockDirFuncs{
+		osDirFuncs{run},
+		set.NewStrings(),
+	}
+	newShares := func(account, key, endpoint string) (azureFileShares, error) {
+		return shares(account, key, endpoint)
+	}
+	return &azureFileFilesystemSource{d, run, newShares, storageDir}, d
+}
+
+func AzureFileProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &azureFileProvider{run, nil}
+}
@@ -104,6 +104,8 @@ type loopVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var _ storage.VolumeResizer = (*loopVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -124,6 +126,17 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	if params.SnapshotId != "" {
+		// Restore the snapshot's contents into the backing file;
+		// fallocate below will grow it to the requested size.
+		snapshotFilePath, err := lvs.snapshotFilePath(params.SnapshotId)
+		if err != nil {
+			return storage.Volume{}, errors.Trace(err)
+		}
+		if err := copyBlockFile(lvs.run, snapshotFilePath, loopFilePath); err != nil {
+			return storage.Volume{}, errors.Annotate(err, "restoring snapshot")
+		}
+	}
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
@@ -248,6 +261,116 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *loopVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		if err := lvs.resizeVolume(arg); err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.Tag.Id())
+			continue
+		}
+		results[i].Size = arg.Size
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) error {
+	loopFilePath := lvs.volumeFilePath(arg.Tag)
+	// fallocate grows the file if the requested length is
+	// larger than the file, and leaves it alone otherwise.
+	if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+		return errors.Trace(err)
+	}
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		// Have the loop device pick up the new size of
+		// the backing file.
+		if _, err := lvs.run("losetup", "-c", path.Join("/dev", deviceName)); err != nil {
+			return errors.Annotatef(err, "updating size of loop device %q", deviceName)
+		}
+	}
+	return nil
+}
+
+// CreateSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) CreateSnapshots(ctx context.ProviderCallContext, args []storage.VolumeSnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %s", arg.Volume.Id())
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createSnapshot(arg storage.VolumeSnapshotParams) (*storage.VolumeSnapshotInfo, error) {
+	// Loop snapshots are files alongside the volumes' backing files,
+	// so they are only usable on the machine they were taken on.
+	snapshotId := "snapshot-" + strings.Replace(arg.Id, "/", "-", -1)
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ensureDir(lvs.dirFuncs, filepath.Dir(snapshotFilePath)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := copyBlockFile(lvs.run, lvs.volumeFilePath(arg.Volume), snapshotFilePath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeSnapshotInfo{
+		SnapshotId: snapshotId,
+		Size:       arg.Size,
+	}, nil
+}
+
+// DestroySnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) DestroySnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := lvs.destroySnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) destroySnapshot(snapshotId string) error {
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(snapshotFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing snapshot file")
+	}
+	return nil
+}
+
+// snapshotFilePath returns the path of the file holding the contents
+// of the snapshot with the specified provider ID.
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) (string, error) {
+	if !strings.HasPrefix(snapshotId, "snapshot-") || strings.ContainsAny(snapshotId, `/\`) {
+		return "", errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	return filepath.Join(lvs.storageDir, "snapshots", snapshotId), nil
+}
+
+// copyBlockFile copies the file at the source path to the destination
+// path, preserving holes so that sparse files remain sparse.
+func copyBlockFile(run runCommandFunc, srcPath, dstPath string) error {
+	_, err := run("cp", "--sparse=always", srcPath, dstPath)
+	if err != nil {
+		return errors.Annotatef(err, "copying %q to %q", srcPath, dstPath)
+	}
+	return nil
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
@@ -249,6 +249,121 @@ func (s *loopSuite) TestDetachVolumes(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	s.commands.expect("fallocate", "-l", "4MiB", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 4}})
+}
+
+func (s *loopSuite) TestResizeVolumesFails(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	cmd := s.commands.expect("fallocate", "-l", "4MiB", fileName)
+	cmd.respond("", errors.New("no space left on device"))
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume 0: allocating loop backing file ".*": no space left on device`)
+}
+
+func (s *loopSuite) TestCreateSnapshots(c *gc.C) {
+	source, dirFuncs := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	s.commands.expect("cp", "--sparse=always",
+		filepath.Join(s.storageDir, "volume-0-1"),
+		filepath.Join(snapshotsDir, "snapshot-0-3"),
+	)
+
+	results, err := source.(storage.VolumeSnapshotter).CreateSnapshots(s.callCtx, []storage.VolumeSnapshotParams{{
+		Id:       "0/3",
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "volume-0-1",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateSnapshotsResult{{
+		Snapshot: &storage.VolumeSnapshotInfo{
+			SnapshotId: "snapshot-0-3",
+			Size:       4,
+		},
+	}})
+	c.Assert(dirFuncs.Dirs.Contains(snapshotsDir), jc.IsTrue)
+}
+
+func (s *loopSuite) TestCreateSnapshotsFails(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	cmd := s.commands.expect("cp", "--sparse=always",
+		filepath.Join(s.storageDir, "volume-0"),
+		filepath.Join(s.storageDir, "snapshots", "snapshot-1"),
+	)
+	cmd.respond("", errors.New("no space left on device"))
+
+	results, err := source.(storage.VolumeSnapshotter).CreateSnapshots(s.callCtx, []storage.VolumeSnapshotParams{{
+		Id:       "1",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating snapshot of volume 0: copying ".*" to ".*": no space left on device`)
+}
+
+func (s *loopSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	s.commands.expect("cp", "--sparse=always", filepath.Join(s.storageDir, "snapshots", "snapshot-1"), fileName)
+	s.commands.expect("fallocate", "-l", "8MiB", fileName)
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       8,
+		SnapshotId: "snapshot-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.Size, gc.Equals, uint64(8))
+}
+
+func (s *loopSuite) TestDestroySnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.MkdirAll(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(snapshotsDir, "snapshot-1")
+	err = ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs, err := source.(storage.VolumeSnapshotter).DestroySnapshots(s.callCtx, []string{
+		"snapshot-1", "snapshot-2", "../volume-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying snapshot "../volume-0": invalid loop snapshot ID "../volume-0"`)
+
+	_, err = os.Stat(fileName)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
 func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
@@ -6,6 +6,7 @@ package provider
 import (
 	"path"
 	"path/filepath"
+	"strings"
 	"unicode"
 
 	"github.com/juju/errors"
@@ -32,6 +33,8 @@ type managedFilesystemSource struct {
 	filesystems        map[names.FilesystemTag]storage.Filesystem
 }
 
+var _ storage.FilesystemResizer = (*managedFilesystemSource)(nil)
+
 // NewManagedFilesystemSource returns a storage.FilesystemSource that manages
 // filesystems on block devices on the host machine.
 //
@@ -88,6 +91,23 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 		return nil, errors.Trace(err)
 	}
 	devicePath := devicePath(blockDevice)
+	if arg.FromSnapshot {
+		// The backing volume was restored from a snapshot, so it
+		// already holds the partition and filesystem created when
+		// the snapshotted volume was first provisioned.
+		if isDiskDevice(devicePath) {
+			devicePath = partitionDevicePath(devicePath)
+		}
+		logger.Debugf("using existing filesystem on %q restored from a snapshot", devicePath)
+		return &storage.Filesystem{
+			arg.Tag,
+			arg.Volume,
+			storage.FilesystemInfo{
+				arg.Tag.String(),
+				blockDevice.Size,
+			},
+		}, nil
+	}
 	if isDiskDevice(devicePath) {
 		if err := destroyPartitions(s.run, devicePath); err != nil {
 			return nil, errors.Trace(err)
@@ -110,6 +130,42 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 	}, nil
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+func (s *managedFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (uint64, error) {
+	filesystem, ok := s.filesystems[arg.Tag]
+	if !ok {
+		return 0, errors.Errorf("filesystem %v is not yet provisioned", arg.Tag.Id())
+	}
+	blockDevice, err := s.backingVolumeBlockDevice(filesystem.Volume)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	devicePath := devicePath(blockDevice)
+	if isDiskDevice(devicePath) {
+		if err := growPartition(s.run, devicePath); err != nil {
+			return 0, errors.Trace(err)
+		}
+		devicePath = partitionDevicePath(devicePath)
+	}
+	if err := growFilesystem(s.run, devicePath); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return blockDevice.Size, nil
+}
+
 // DestroyFilesystems is defined on storage.FilesystemSource.
 func (s *managedFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
 	// DestroyFilesystems is a no-op; there is nothing to destroy,
@@ -192,6 +248,33 @@ func createPartition(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
+// growPartition grows the first (and only) partition of the disk with
+// the specified device path to fill the disk.
+func growPartition(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing partition on %q", devicePath)
+	output, err := run("growpart", devicePath, "1")
+	if err != nil {
+		// growpart exits non-zero if the partition already
+		// fills the disk, which is not an error for us.
+		if strings.Contains(output, "NOCHANGE") {
+			return nil
+		}
+		return errors.Annotate(err, "growpart failed")
+	}
+	return nil
+}
+
+// growFilesystem grows the filesystem on the device with the specified
+// path to fill the device. The filesystem may be mounted.
+func growFilesystem(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing filesystem on %q", devicePath)
+	if _, err := run("resize2fs", devicePath); err != nil {
+		return errors.Annotate(err, "resize2fs failed")
+	}
+	logger.Infof("grew filesystem on %q", devicePath)
+	return nil
+}
+
 func createFilesystem(run runCommandFunc, devicePath string) error {
 	logger.Debugf("attempting to create filesystem on %q", devicePath)
 	mkfscmd := "mkfs." + defaultFilesystemType
@@ -6,6 +6,7 @@ package provider_test
 import (
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -105,6 +106,34 @@ func (s *managedfsSuite) TestCreateFilesystems(c *gc.C) {
 	}})
 }
 
+func (s *managedfsSuite) TestCreateFilesystemsFromSnapshot(c *gc.C) {
+	source := s.initSource(c)
+	// No commands are expected: the volume restored from
+	// the snapshot is neither partitioned nor formatted.
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       2,
+	}
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		Volume:       names.NewVolumeTag("0"),
+		Size:         2,
+		FromSnapshot: true,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			names.NewFilesystemTag("0/0"),
+			names.NewVolumeTag("0"),
+			storage.FilesystemInfo{
+				FilesystemId: "filesystem-0-0",
+				Size:         2,
+			},
+		},
+	}})
+}
+
 func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	source := s.initSource(c)
 	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
@@ -189,3 +218,46 @@ func (s *managedfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.initSource(c)
 	testDetachFilesystems(c, s.commands, source, s.callCtx, false)
 }
+
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	// The partition on sda is grown to fill the disk before the
+	// filesystem on it is grown.
+	cmd := s.commands.expect("growpart", "/dev/sda", "1")
+	cmd.respond("NOCHANGE: partition 1 is size 4094. it cannot be grown", errors.New("exit status 1"))
+	s.commands.expect("resize2fs", "/dev/sda1")
+	// xvdf1 is not partitioned.
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       6,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	s.filesystems[names.NewFilesystemTag("0/1")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/1"),
+		Volume: names.NewVolumeTag("1"),
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+	}, {
+		Tag:          names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+	}, {
+		Tag:          names.NewFilesystemTag("0/2"),
+		FilesystemId: "filesystem-0-2",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeFilesystemsResult{Size: 4})
+	c.Assert(results[1], jc.DeepEquals, storage.ResizeFilesystemsResult{Size: 6})
+	c.Assert(results[2].Error, gc.ErrorMatches, "filesystem 0/2 is not yet provisioned")
+}
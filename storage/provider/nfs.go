@@ -0,0 +1,262 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	NFSProviderType = storage.ProviderType("nfs")
+
+	// NFSServer is the pool configuration key for the
+	// hostname or address of the NFS server.
+	NFSServer = "server"
+
+	// NFSExport is the pool configuration key for the absolute
+	// path of the directory exported by the NFS server. Juju
+	// creates one directory per filesystem beneath it.
+	NFSExport = "export"
+)
+
+// nfsProvider creates storage sources which provide access to
+// directories on an NFS server. The filesystems are shared: a
+// filesystem may be mounted on any number of machines at once.
+type nfsProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider = (*nfsProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (p *nfsProvider) ValidateConfig(cfg *storage.Config) error {
+	server, _ := cfg.ValueString(NFSServer)
+	if server == "" {
+		return errors.Errorf("%q must be specified", NFSServer)
+	}
+	if strings.ContainsAny(server, ":/") {
+		return errors.NotValidf("NFS server %q", server)
+	}
+	export, _ := cfg.ValueString(NFSExport)
+	if export == "" {
+		return errors.Errorf("%q must be specified", NFSExport)
+	}
+	if !path.IsAbs(export) {
+		return errors.Errorf("%q must be an absolute path, got %q", NFSExport, export)
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *nfsProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *nfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	// The source configuration does not include the pool attributes;
+	// those are passed in with the filesystem parameters.
+	storageDir, ok := sourceConfig.ValueString(storage.ConfigStorageDir)
+	if !ok || storageDir == "" {
+		return nil, errors.New("storage directory not specified")
+	}
+	return &nfsFilesystemSource{
+		&osDirFuncs{p.run},
+		p.run,
+		storageDir,
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*nfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem || k == storage.StorageKindSharedFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*nfsProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*nfsProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*nfsProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*nfsProvider) DefaultPools() []*storage.Config {
+	// There is no sensible default NFS server.
+	return nil
+}
+
+type nfsFilesystemSource struct {
+	dirFuncs   dirFuncs
+	run        runCommandFunc
+	storageDir string
+}
+
+var _ storage.FilesystemSource = (*nfsFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	cfg, err := storage.NewConfig("nfs", NFSProviderType, params.Attributes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p := &nfsProvider{s.run}
+	return p.ValidateConfig(cfg)
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+//
+// Shared filesystems are created by each machine that they are
+// attached to, so creating a filesystem that already exists on the
+// server is not an error.
+func (s *nfsFilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	if err := s.ValidateFilesystemParams(params); err != nil {
+		return nil, errors.Trace(err)
+	}
+	server := params.Attributes[NFSServer].(string)
+	export := params.Attributes[NFSExport].(string)
+	modelUUID := params.ResourceTags[tags.JujuModel]
+	if modelUUID == "" {
+		return nil, errors.New("model UUID not specified")
+	}
+
+	// The filesystem is a directory on the server, named for the
+	// model and filesystem so that several models can share one
+	// export. Create it by mounting the export at a staging
+	// location.
+	dir := path.Join(modelUUID, params.Tag.String())
+	staging := filepath.Join(s.storageDir, "nfs", params.Tag.String())
+	if err := ensureDir(s.dirFuncs, staging); err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer os.Remove(staging)
+	if _, err := s.run("mount", "-t", "nfs", server+":"+export, staging); err != nil {
+		return nil, errors.Annotate(err, "cannot mount NFS export")
+	}
+	err := s.dirFuncs.mkDirAll(filepath.Join(staging, dir), 0755)
+	if _, umountErr := s.run("umount", staging); umountErr != nil {
+		logger.Warningf("cannot unmount %q: %v", staging, umountErr)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating %q on NFS export", dir)
+	}
+
+	info := storage.FilesystemInfo{
+		FilesystemId: server + ":" + path.Join(export, dir),
+		// NFS does not enforce quotas, so the size is
+		// recorded as requested.
+		Size: params.Size,
+	}
+	return &storage.Filesystem{params.Tag, params.Volume, info}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	// DestroyFilesystems is a no-op; the data on the server is
+	// left for the administrator of the server to remove.
+	return make([]error, len(filesystemIds)), nil
+}
+
+// ReleaseFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) ReleaseFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) AttachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	mountPoint := arg.Path
+	if mountPoint == "" {
+		return nil, errNoMountPoint
+	}
+	if arg.FilesystemId == "" {
+		return nil, errors.New("filesystem ID not specified")
+	}
+	if err := ensureDir(s.dirFuncs, mountPoint); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Check if the mount already exists.
+	source, err := s.dirFuncs.mountPointSource(mountPoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if source != arg.FilesystemId {
+		if err := ensureEmptyDir(s.dirFuncs, mountPoint); err != nil {
+			return nil, err
+		}
+		args := []string{"-t", "nfs"}
+		if arg.ReadOnly {
+			args = append(args, "-o", "ro")
+		}
+		args = append(args, arg.FilesystemId, mountPoint)
+		if _, err := s.run("mount", args...); err != nil {
+			return nil, errors.Annotate(err, "cannot mount NFS filesystem")
+		}
+	}
+
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     mountPoint,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DetachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&nfsSuite{})
+
+type nfsSuite struct {
+	testing.BaseSuite
+	storageDir string
+	commands   *mockRunCommand
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *nfsSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.storageDir = c.MkDir()
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *nfsSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *nfsSuite) nfsProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NFSProvider(s.commands.run)
+}
+
+func (s *nfsSuite) nfsFilesystemSource(c *gc.C) (storage.FilesystemSource, *provider.MockDirFuncs) {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NFSFilesystemSource(s.storageDir, s.commands.run)
+}
+
+func (s *nfsSuite) TestFilesystemSource(c *gc.C) {
+	p := s.nfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.NFSProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "storage directory not specified")
+	cfg, err = storage.NewConfig("name", provider.NFSProviderType, map[string]interface{}{
+		"storage-dir": c.MkDir(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *nfsSuite) TestValidateConfig(c *gc.C) {
+	p := s.nfsProvider(c)
+	for _, test := range []struct {
+		attrs  map[string]interface{}
+		expect string
+	}{{
+		attrs:  map[string]interface{}{"export": "/srv/juju"},
+		expect: `"server" must be specified`,
+	}, {
+		attrs:  map[string]interface{}{"server": "nfs:2049", "export": "/srv/juju"},
+		expect: `NFS server "nfs:2049" not valid`,
+	}, {
+		attrs:  map[string]interface{}{"server": "nfs.example.com"},
+		expect: `"export" must be specified`,
+	}, {
+		attrs:  map[string]interface{}{"server": "nfs.example.com", "export": "srv/juju"},
+		expect: `"export" must be an absolute path, got "srv/juju"`,
+	}, {
+		attrs: map[string]interface{}{"server": "nfs.example.com", "export": "/srv/juju"},
+	}} {
+		cfg, err := storage.NewConfig("name", provider.NFSProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.expect == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expect)
+		}
+	}
+}
+
+func (s *nfsSuite) TestSupports(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindSharedFilesystem), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestScope(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *nfsSuite) TestCreateFilesystems(c *gc.C) {
+	source, dirFuncs := s.nfsFilesystemSource(c)
+	staging := filepath.Join(s.storageDir, "nfs", "filesystem-6")
+	s.commands.expect("mount", "-t", "nfs", "nfs.example.com:/srv/juju", staging)
+	s.commands.expect("umount", staging)
+
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 1024,
+		Attributes: map[string]interface{}{
+			"server": "nfs.example.com",
+			"export": "/srv/juju",
+		},
+		ResourceTags: map[string]string{
+			tags.JujuModel: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("6"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "nfs.example.com:/srv/juju/deadbeef-0bad-400d-8000-4b1d0d06f00d/filesystem-6",
+				Size:         1024,
+			},
+		},
+	}})
+	c.Assert(dirFuncs.Dirs.Contains(filepath.Join(
+		staging, "deadbeef-0bad-400d-8000-4b1d0d06f00d", "filesystem-6",
+	)), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestCreateFilesystemsMountFails(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	staging := filepath.Join(s.storageDir, "nfs", "filesystem-6")
+	cmd := s.commands.expect("mount", "-t", "nfs", "nfs.example.com:/srv/juju", staging)
+	cmd.respond("", errors.New("access denied"))
+
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 1024,
+		Attributes: map[string]interface{}{
+			"server": "nfs.example.com",
+			"export": "/srv/juju",
+		},
+		ResourceTags: map[string]string{
+			tags.JujuModel: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "cannot mount NFS export: access denied")
+}
+
+func (s *nfsSuite) TestAttachFilesystems(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	const mountPoint = "/var/lib/juju/storage/shared"
+	const filesystemId = "nfs.example.com:/srv/juju/uuid/filesystem-6"
+	cmd := s.commands.expect("df", "--output=source", mountPoint)
+	cmd.respond("header\n/dev/sda1", nil)
+	s.commands.expect("mount", "-t", "nfs", "-o", "ro", filesystemId, mountPoint)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: filesystemId,
+		Path:         mountPoint,
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("2"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("6"),
+			Machine:    names.NewMachineTag("2"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     mountPoint,
+				ReadOnly: true,
+			},
+		},
+	}})
+}
+
+func (s *nfsSuite) TestAttachFilesystemsAlreadyMounted(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	const filesystemId = "nfs.example.com:/srv/juju/uuid/filesystem-6"
+	cmd := s.commands.expect("df", "--output=source", "exists")
+	cmd.respond("header\n"+filesystemId, nil)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: filesystemId,
+		Path:         "exists",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("6"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "exists",
+			},
+		},
+	}})
+}
+
+func (s *nfsSuite) TestDetachFilesystems(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, s.callCtx, true)
+}
+
+func (s *nfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, s.callCtx, false)
+}
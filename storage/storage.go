@@ -13,6 +13,11 @@ const (
 	StorageKindUnknown StorageKind = iota
 	StorageKindBlock
 	StorageKindFilesystem
+
+	// StorageKindSharedFilesystem is a filesystem that may be
+	// attached to multiple machines concurrently. Providers that
+	// support it must also support StorageKindFilesystem.
+	StorageKindSharedFilesystem
 )
 
 func (k StorageKind) String() string {
@@ -21,6 +26,8 @@ func (k StorageKind) String() string {
 		return "block"
 	case StorageKindFilesystem:
 		return "filesystem"
+	case StorageKindSharedFilesystem:
+		return "shared-filesystem"
 	default:
 		return "unknown"
 	}
@@ -47,4 +54,10 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the storage attachment in MiB: the size of
+	// the filesystem for a filesystem-kind storage attachment, and
+	// of the block device for a block-kind. It is zero if the size
+	// is not known.
+	Size uint64
 }
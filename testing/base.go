@@ -50,7 +50,10 @@ func (s *JujuOSEnvSuite) SetUpTest(c *gc.C) {
 		osenv.JujuModelEnvKey,
 		osenv.JujuLoggingConfigEnvKey,
 		osenv.JujuFeatureFlagEnvKey,
+		osenv.JujuStorePassphraseEnvKey,
+		osenv.JujuTraceEnvKey,
 		osenv.XDGDataHome,
+		osenv.XDGRuntimeDir,
 	} {
 		s.oldEnvironment[name] = os.Getenv(name)
 		os.Setenv(name, "")
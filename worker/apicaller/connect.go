@@ -4,6 +4,7 @@
 package apicaller
 
 import (
+	"os"
 	"time"
 
 	"github.com/juju/errors"
@@ -14,6 +15,8 @@ import (
 	apiagent "github.com/juju/juju/api/agent"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/trace"
+	"github.com/juju/juju/juju/osenv"
 )
 
 var (
@@ -101,6 +104,9 @@ func connectFallback(
 			// before responding to the login request, but the pause is
 			// in the realm of five to ten seconds.
 			Timeout: time.Minute,
+			// Agents, like clients, make their requests part of
+			// the trace given by $JUJU_TRACE if it is set.
+			TraceContext: trace.ProcessSpanContext(os.Getenv(osenv.JujuTraceEnvKey)),
 		})
 	}
 
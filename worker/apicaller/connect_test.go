@@ -15,6 +15,7 @@ import (
 	apiagent "github.com/juju/juju/api/agent"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/juju/osenv"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/apicaller"
 )
@@ -70,6 +71,28 @@ func testEntityFine(c *gc.C, life apiagent.Life) {
 	}})
 }
 
+func (s *ScaryConnectSuite) TestTraceContext(c *gc.C) {
+	s.PatchEnvironment(osenv.JujuTraceEnvKey, "0123456789abcdef0123456789abcdef")
+	stub := &testing.Stub{}
+	var dialOpts api.DialOpts
+	apiOpen := func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+		dialOpts = opts
+		return &mockConn{stub: stub}, nil
+	}
+	connect := func() (api.Connection, error) {
+		return apicaller.ScaryConnect(&mockAgent{
+			stub:   stub,
+			model:  coretesting.ModelTag,
+			entity: names.NewApplicationTag("omg"),
+		}, apiOpen)
+	}
+
+	_, err := lifeTest(c, stub, apiagent.Alive, connect)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dialOpts.TraceContext.TraceID, gc.Equals, "0123456789abcdef0123456789abcdef")
+	c.Assert(dialOpts.TraceContext.IsValid(), jc.IsTrue)
+}
+
 func (*ScaryConnectSuite) TestEntityDead(c *gc.C) {
 	// permanent failure case
 	stub := &testing.Stub{}
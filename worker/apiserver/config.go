@@ -4,13 +4,27 @@
 package apiserver
 
 import (
+	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/trace"
+)
+
+const (
+	// tracingServiceName is the service name reported
+	// with spans sent to an OpenTelemetry collector.
+	tracingServiceName = "juju-controller"
+
+	tracerBufferSize    = 10000
+	tracerBatchSize     = 100
+	tracerFlushInterval = 5 * time.Second
 )
 
 func getRateLimitConfig(cfg agent.Config) (apiserver.RateLimitConfig, error) {
@@ -135,3 +149,54 @@ func getLogSinkConfig(cfg agent.Config) (apiserver.LogSinkConfig, error) {
 	}
 	return result, nil
 }
+
+// getEntityLimitConfig returns the limits placed on the API requests
+// and connections made by each user and agent, as configured in the
+// controller config.
+func getEntityLimitConfig(cfg controller.Config) apiserver.EntityLimitConfig {
+	return apiserver.EntityLimitConfig{
+		User: apiserver.EntityLimits{
+			RequestRate:    cfg.APIUserRequestRate(),
+			RequestBurst:   cfg.APIUserRequestBurst(),
+			MaxConnections: cfg.APIUserMaxConnections(),
+		},
+		Agent: apiserver.EntityLimits{
+			RequestRate:    cfg.APIAgentRequestRate(),
+			RequestBurst:   cfg.APIAgentRequestBurst(),
+			MaxConnections: cfg.APIAgentMaxConnections(),
+		},
+	}
+}
+
+// newTracer returns the tracer used to record spans for traced API
+// requests, as configured in the controller config, or nil if
+// tracing is disabled.
+func newTracer(cfg controller.Config, logDir string, clock clock.Clock) (*trace.Tracer, error) {
+	var exporter trace.Exporter
+	switch cfg.TracingExporter() {
+	case "":
+		return nil, nil
+	case controller.TracingExporterFile:
+		var err error
+		exporter, err = trace.NewFileExporter(filepath.Join(logDir, "traces.log"))
+		if err != nil {
+			return nil, errors.Annotate(err, "creating trace file exporter")
+		}
+	case controller.TracingExporterOTLP:
+		exporter = trace.NewOTLPExporter(cfg.TracingOTLPURL(), tracingServiceName)
+	default:
+		return nil, errors.NotValidf("tracing exporter %q", cfg.TracingExporter())
+	}
+	tracer, err := trace.NewTracer(trace.Config{
+		Exporter:      exporter,
+		Clock:         clock,
+		BufferSize:    tracerBufferSize,
+		BatchSize:     tracerBatchSize,
+		FlushInterval: tracerFlushInterval,
+	})
+	if err != nil {
+		exporter.Close()
+		return nil, errors.Trace(err)
+	}
+	return tracer, nil
+}
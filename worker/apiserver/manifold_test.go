@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/apiserver/httpcontext"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
@@ -45,6 +46,7 @@ type ManifoldSuite struct {
 	hub                  pubsub.StructuredHub
 	upgradeGate          stubGateWaiter
 	auditConfig          stubAuditConfig
+	leaseManager         *stubLeaseManager
 
 	stub testing.Stub
 }
@@ -62,6 +64,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	s.prometheusRegisterer = stubPrometheusRegisterer{}
 	s.upgradeGate = stubGateWaiter{}
 	s.auditConfig = stubAuditConfig{}
+	s.leaseManager = &stubLeaseManager{}
 	s.stub.ResetCalls()
 
 	s.context = s.newContext(nil)
@@ -74,6 +77,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 		StateName:                         "state",
 		UpgradeGateName:                   "upgrade",
 		AuditConfigUpdaterName:            "auditconfig-updater",
+		LeaseManagerName:                  "lease-manager",
 		PrometheusRegisterer:              &s.prometheusRegisterer,
 		RegisterIntrospectionHTTPHandlers: func(func(string, http.Handler)) {},
 		Hub:       &s.hub,
@@ -92,6 +96,7 @@ func (s *ManifoldSuite) newContext(overlay map[string]interface{}) dependency.Co
 		"state":               &s.state,
 		"upgrade":             &s.upgradeGate,
 		"auditconfig-updater": s.auditConfig.get,
+		"lease-manager":       s.leaseManager,
 	}
 	for k, v := range overlay {
 		resources[k] = v
@@ -114,6 +119,7 @@ func (s *ManifoldSuite) newWorker(config apiserver.Config) (worker.Worker, error
 
 var expectedInputs = []string{
 	"agent", "authenticator", "clock", "mux", "restore-status", "state", "upgrade", "auditconfig-updater",
+	"lease-manager",
 }
 
 func (s *ManifoldSuite) TestInputs(c *gc.C) {
@@ -178,6 +184,7 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 		StatePool:            &s.state.pool,
 		PrometheusRegisterer: &s.prometheusRegisterer,
 		Hub:                  &s.hub,
+		LeaseManager:         s.leaseManager,
 	})
 }
 
@@ -317,3 +324,7 @@ func (c *stubAuditConfig) get() auditlog.Config {
 type mockAuthenticator struct {
 	httpcontext.LocalMacaroonAuthenticator
 }
+
+type stubLeaseManager struct {
+	lease.Manager
+}
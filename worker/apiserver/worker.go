@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/apiserverhttp"
 	"github.com/juju/juju/apiserver/httpcontext"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/state"
 )
@@ -38,6 +39,7 @@ type Config struct {
 	RestoreStatus                     func() state.RestoreStatus
 	UpgradeComplete                   func() bool
 	GetAuditConfig                    func() auditlog.Config
+	LeaseManager                      lease.Manager
 	NewServer                         NewServerFunc
 }
 
@@ -80,6 +82,9 @@ func (config Config) Validate() error {
 	if config.UpgradeComplete == nil {
 		return errors.NotValidf("nil UpgradeComplete")
 	}
+	if config.LeaseManager == nil {
+		return errors.NotValidf("nil LeaseManager")
+	}
 	if config.NewServer == nil {
 		return errors.NotValidf("nil NewServer")
 	}
@@ -118,6 +123,15 @@ func NewWorker(config Config) (worker.Worker, error) {
 		return nil, errors.Annotate(err, "cannot create RPC observer factory")
 	}
 
+	tracer, err := newTracer(controllerConfig, config.AgentConfig.LogDir(), config.Clock)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create tracer")
+	}
+
+	// The secrets key is only held in the agent config; it is never
+	// written to the database alongside the values it protects.
+	servingInfo, _ := config.AgentConfig.StateServingInfo()
+
 	serverConfig := apiserver.ServerConfig{
 		StatePool:                     config.StatePool,
 		Clock:                         config.Clock,
@@ -138,8 +152,19 @@ func NewWorker(config Config) (worker.Worker, error) {
 		LogSinkConfig:                 &logSinkConfig,
 		PrometheusRegisterer:          config.PrometheusRegisterer,
 		GetAuditConfig:                config.GetAuditConfig,
+		Tracer:                        tracer,
+		EntityLimits:                  getEntityLimitConfig(controllerConfig),
+		LeaseManager:                  config.LeaseManager,
+		SecretsKey:                    servingInfo.SecretsKey,
+	}
+	server, err := config.NewServer(serverConfig)
+	if err != nil {
+		if tracer != nil {
+			tracer.Close()
+		}
+		return nil, errors.Trace(err)
 	}
-	return config.NewServer(serverConfig)
+	return server, nil
 }
 
 func newServerShim(config apiserver.ServerConfig) (worker.Worker, error) {
@@ -4,6 +4,9 @@
 package apiserver_test
 
 import (
+	"os"
+	"path/filepath"
+
 	"github.com/juju/collections/set"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -12,6 +15,7 @@ import (
 
 	coreapiserver "github.com/juju/juju/apiserver"
 	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -119,5 +123,46 @@ func (s *WorkerStateSuite) TestStart(c *gc.C) {
 		RateLimitConfig:      rateLimitConfig,
 		LogSinkConfig:        &logSinkConfig,
 		PrometheusRegisterer: &s.prometheusRegisterer,
+		LeaseManager:         s.leaseManager,
+		SecretsKey:           "c2VjcmV0cy1rZXk=",
+		EntityLimits: coreapiserver.EntityLimitConfig{
+			User: coreapiserver.EntityLimits{
+				RequestRate:    controller.DefaultAPIUserRequestRate,
+				RequestBurst:   controller.DefaultAPIUserRequestBurst,
+				MaxConnections: controller.DefaultAPIUserMaxConnections,
+			},
+			Agent: coreapiserver.EntityLimits{
+				RequestRate:    controller.DefaultAPIAgentRequestRate,
+				RequestBurst:   controller.DefaultAPIAgentRequestBurst,
+				MaxConnections: controller.DefaultAPIAgentMaxConnections,
+			},
+		},
 	})
 }
+
+type WorkerTracingStateSuite struct {
+	WorkerStateSuite
+}
+
+var _ = gc.Suite(&WorkerTracingStateSuite{})
+
+func (s *WorkerTracingStateSuite) SetUpTest(c *gc.C) {
+	s.ControllerConfig = map[string]interface{}{
+		"tracing-exporter": "file",
+	}
+	s.WorkerStateSuite.SetUpTest(c)
+}
+
+func (s *WorkerTracingStateSuite) TestStart(c *gc.C) {
+	w, err := apiserver.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.stub.CheckCallNames(c, "NewServer")
+	config := s.stub.Calls()[0].Args[0].(coreapiserver.ServerConfig)
+	c.Assert(config.Tracer, gc.NotNil)
+	err = config.Tracer.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(s.agentConfig.logDir, "traces.log"))
+	c.Assert(err, jc.ErrorIsNil)
+}
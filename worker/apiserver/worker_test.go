@@ -30,6 +30,7 @@ type workerFixture struct {
 	hub                  pubsub.StructuredHub
 	mux                  *apiserverhttp.Mux
 	prometheusRegisterer stubPrometheusRegisterer
+	leaseManager         *stubLeaseManager
 	config               apiserver.Config
 	stub                 testing.Stub
 }
@@ -41,13 +42,15 @@ func (s *workerFixture) SetUpTest(c *gc.C) {
 		dataDir: c.MkDir(),
 		logDir:  c.MkDir(),
 		info: &params.StateServingInfo{
-			APIPort: 0, // listen on any port
+			APIPort:    0, // listen on any port
+			SecretsKey: "c2VjcmV0cy1rZXk=",
 		},
 	}
 	s.authenticator = &mockAuthenticator{}
 	s.clock = testing.NewClock(time.Time{})
 	s.mux = apiserverhttp.NewMux()
 	s.prometheusRegisterer = stubPrometheusRegisterer{}
+	s.leaseManager = &stubLeaseManager{}
 	s.stub.ResetCalls()
 
 	s.config = apiserver.Config{
@@ -62,6 +65,7 @@ func (s *workerFixture) SetUpTest(c *gc.C) {
 		RegisterIntrospectionHTTPHandlers: func(func(string, http.Handler)) {},
 		UpgradeComplete:                   func() bool { return true },
 		RestoreStatus:                     func() state.RestoreStatus { return "" },
+		LeaseManager:                      s.leaseManager,
 		NewServer:                         s.newServer,
 	}
 }
@@ -117,6 +121,9 @@ func (s *WorkerValidationSuite) TestValidateErrors(c *gc.C) {
 	}, {
 		func(cfg *apiserver.Config) { cfg.RestoreStatus = nil },
 		"nil RestoreStatus not valid",
+	}, {
+		func(cfg *apiserver.Config) { cfg.LeaseManager = nil },
+		"nil LeaseManager not valid",
 	}, {
 		func(cfg *apiserver.Config) { cfg.NewServer = nil },
 		"nil NewServer not valid",
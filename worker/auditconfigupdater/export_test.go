@@ -0,0 +1,6 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditconfigupdater
+
+var NewForwarders = &newForwarders
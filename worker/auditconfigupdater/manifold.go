@@ -4,16 +4,29 @@
 package auditconfigupdater
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 
 	jujuagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/worker/common"
 	workerstate "github.com/juju/juju/worker/state"
 )
 
+var logger = loggo.GetLogger("juju.worker.auditconfigupdater")
+
+// forwardRetryDelay is how long to wait before retrying a record that
+// couldn't be forwarded to a sink.
+const forwardRetryDelay = 5 * time.Second
+
 // ManifoldConfig holds the information needed to run an
 // auditconfigupdater in a dependency.Engine.
 type ManifoldConfig struct {
@@ -73,13 +86,24 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 		}
 	}()
 
-	logDir := agent.CurrentConfig().LogDir()
+	agentConfig := agent.CurrentConfig()
+	logDir := agentConfig.LogDir()
 
 	st := statePool.SystemState()
 
-	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+	// The factory is only called from one goroutine at a time (here
+	// and then by the worker), so the logs can keep track of the log
+	// file and forwarders it has created.
+	logs := &auditLogs{
+		logDir:      logDir,
+		agentConfig: agentConfig,
 	}
+	defer func() {
+		if err != nil {
+			logs.closeForwarders()
+		}
+	}()
+	logFactory := logs.newLog
 	auditConfig, err := initialConfig(st)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -92,7 +116,47 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return common.NewCleanupWorker(w, func() { stTracker.Done() }), nil
+	return common.NewCleanupWorker(w, func() {
+		logs.closeForwarders()
+		stTracker.Done()
+	}), nil
+}
+
+// auditLogs creates the audit logs used by the API server, and closes
+// the forwarders belonging to a log when it's replaced.
+type auditLogs struct {
+	logDir      string
+	agentConfig jujuagent.Config
+
+	fileLog    auditlog.AuditLog
+	forwarders []auditlog.AuditLog
+}
+
+// newLog is an AuditLogFactory.
+func (l *auditLogs) newLog(cfg auditlog.Config) auditlog.AuditLog {
+	// Keep the same log file when the forwarding config changes, so
+	// there's only ever one writer for it.
+	if l.fileLog == nil {
+		l.fileLog = auditlog.NewLogFile(l.logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+	}
+	l.closeForwarders()
+	l.forwarders = newForwarders(cfg, l.agentConfig)
+	if len(l.forwarders) == 0 {
+		return l.fileLog
+	}
+	return auditlog.NewTeeLog(append([]auditlog.AuditLog{l.fileLog}, l.forwarders...)...)
+}
+
+// closeForwarders stops the forwarders created for the most recent
+// log, so their goroutines and connections to the sinks don't outlive
+// it.
+func (l *auditLogs) closeForwarders() {
+	for _, f := range l.forwarders {
+		if err := f.Close(); err != nil {
+			logger.Warningf("closing audit log forwarder: %v", err)
+		}
+	}
+	l.forwarders = nil
 }
 
 type withCurrentConfig interface {
@@ -126,6 +190,75 @@ func initialConfig(source ConfigSource) (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+
+		Syslog:            cfg.AuditLogSyslogConfig(),
+		WebhookURL:        cfg.AuditLogWebhookURL(),
+		ForwardBufferSize: cfg.AuditLogForwardBufferSize(),
 	}
 	return result, nil
 }
+
+// newForwarders is overridden in tests.
+var newForwarders = makeForwarders
+
+// makeForwarders returns audit logs that forward records to the syslog
+// and webhook sinks specified in the config. Problems with a sink are
+// logged rather than returned so that they don't stop records being
+// written to the local log file.
+func makeForwarders(cfg auditlog.Config, agentConfig jujuagent.Config) []auditlog.AuditLog {
+	var sinks []namedSink
+	if cfg.Syslog.Enabled {
+		origin, err := agentOrigin(agentConfig)
+		if err != nil {
+			logger.Errorf("cannot forward audit log records to syslog: %v", err)
+		} else {
+			sinks = append(sinks, namedSink{
+				name: "syslog host " + cfg.Syslog.Host,
+				sink: auditlog.NewSyslogSink(cfg.Syslog, origin, clock.WallClock),
+			})
+		}
+	}
+	if cfg.WebhookURL != "" {
+		sinks = append(sinks, namedSink{
+			name: "webhook " + cfg.WebhookURL,
+			sink: auditlog.NewWebhookSink(cfg.WebhookURL),
+		})
+	}
+
+	var result []auditlog.AuditLog
+	for _, s := range sinks {
+		forwarder, err := auditlog.NewForwarder(auditlog.ForwarderConfig{
+			Name:       s.name,
+			Sink:       s.sink,
+			BufferSize: cfg.ForwardBufferSize,
+			RetryDelay: forwardRetryDelay,
+			Clock:      clock.WallClock,
+		})
+		if err != nil {
+			logger.Errorf("cannot forward audit log records to %s: %v", s.name, err)
+			continue
+		}
+		result = append(result, forwarder)
+	}
+	return result
+}
+
+type namedSink struct {
+	name string
+	sink auditlog.Sink
+}
+
+// agentOrigin returns the origin used for forwarded records, which
+// identifies the controller machine they came from.
+func agentOrigin(agentConfig jujuagent.Config) (logfwd.Origin, error) {
+	tag, ok := agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return logfwd.Origin{}, errors.Errorf("expected machine agent, got %s", agentConfig.Tag())
+	}
+	return logfwd.OriginForMachineAgent(
+		tag,
+		agentConfig.Controller().Id(),
+		agentConfig.Model().Id(),
+		jujuversion.Current,
+	), nil
+}
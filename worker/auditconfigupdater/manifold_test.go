@@ -126,6 +126,8 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 		ExcludeMethods: set.NewStrings("This.Method"),
 		MaxSizeMB:      10,
 		MaxBackups:     10,
+
+		ForwardBufferSize: 1000,
 	})
 
 	c.Assert(args[2], gc.NotNil)
@@ -170,6 +172,28 @@ func (s *manifoldSuite) TestOutput(c *gc.C) {
 	c.Assert(auditConfig, gc.DeepEquals, getConfig())
 }
 
+func (s *manifoldSuite) TestRebuildClosesForwarders(c *gc.C) {
+	var forwarders []*fakeForwarder
+	s.PatchValue(auditconfigupdater.NewForwarders, func(auditlog.Config, agent.Config) []auditlog.AuditLog {
+		f := &fakeForwarder{}
+		forwarders = append(forwarders, f)
+		return []auditlog.AuditLog{f}
+	})
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	c.Assert(forwarders, gc.HasLen, 1)
+
+	factory := s.stub.Calls()[0].Args[2].(auditconfigupdater.AuditLogFactory)
+	factory(auditlog.Config{Enabled: true, WebhookURL: "https://example.com/audit"})
+	c.Assert(forwarders, gc.HasLen, 2)
+	c.Assert(forwarders[0].closed, jc.IsTrue)
+	c.Assert(forwarders[1].closed, jc.IsFalse)
+
+	workertest.CleanKill(c, w)
+	c.Assert(forwarders[1].closed, jc.IsTrue)
+}
+
 func (s *manifoldSuite) TestStopWorkerClosesState(c *gc.C) {
 	w, err := s.manifold.Start(s.context)
 	c.Assert(err, jc.ErrorIsNil)
@@ -182,12 +206,17 @@ func (s *manifoldSuite) TestStopWorkerClosesState(c *gc.C) {
 }
 
 func (s *manifoldSuite) TestClosesStateOnWorkerError(c *gc.C) {
+	forwarder := &fakeForwarder{}
+	s.PatchValue(auditconfigupdater.NewForwarders, func(auditlog.Config, agent.Config) []auditlog.AuditLog {
+		return []auditlog.AuditLog{forwarder}
+	})
 	s.stub.SetErrors(errors.Errorf("splat"))
 	w, err := s.manifold.Start(s.context)
 	c.Assert(err, gc.ErrorMatches, "splat")
 	c.Assert(w, gc.IsNil)
 
 	s.stateTracker.CheckCallNames(c, "Use", "Done")
+	c.Assert(forwarder.closed, jc.IsTrue)
 }
 
 type mockAgent struct {
@@ -237,3 +266,13 @@ func (w *fakeWorker) Wait() error {
 func (w *fakeWorker) CurrentConfig() auditlog.Config {
 	return w.config
 }
+
+type fakeForwarder struct {
+	auditlog.AuditLog
+	closed bool
+}
+
+func (f *fakeForwarder) Close() error {
+	f.closed = true
+	return nil
+}
@@ -30,9 +30,10 @@ type AuditLogFactory func(auditlog.Config) auditlog.AuditLog
 // New returns a worker that will keep an up-to-date audit log config.
 func New(source ConfigSource, initial auditlog.Config, logFactory AuditLogFactory) (worker.Worker, error) {
 	u := &updater{
-		source:     source,
-		current:    initial,
-		logFactory: logFactory,
+		source:       source,
+		current:      initial,
+		targetConfig: initial,
+		logFactory:   logFactory,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -50,6 +51,10 @@ type updater struct {
 	source     ConfigSource
 	current    auditlog.Config
 	logFactory AuditLogFactory
+
+	// targetConfig is the config the current target was created
+	// with, used to detect changes to the forwarding settings.
+	targetConfig auditlog.Config
 }
 
 // Kill is part of the worker.Worker interface.
@@ -95,9 +100,16 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+
+		Syslog:            cfg.AuditLogSyslogConfig(),
+		WebhookURL:        cfg.AuditLogWebhookURL(),
+		ForwardBufferSize: cfg.AuditLogForwardBufferSize(),
 	}
-	if result.Enabled && u.current.Target == nil {
+	if result.Enabled && (u.current.Target == nil || forwardingChanged(u.targetConfig, result)) {
+		// The factory takes care of closing any forwarders
+		// belonging to the previous target.
 		result.Target = u.logFactory(result)
+		u.targetConfig = result
 	} else {
 		// Keep the existing target to avoid file handle leaks from
 		// disabling and enabling auditing - we'll still stop logging
@@ -107,6 +119,21 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 	return result, nil
 }
 
+// forwardingChanged returns whether the settings for forwarding audit
+// records to remote sinks differ between the two configs.
+func forwardingChanged(old, new auditlog.Config) bool {
+	if !forwarding(old) && !forwarding(new) {
+		return false
+	}
+	return old.Syslog != new.Syslog ||
+		old.WebhookURL != new.WebhookURL ||
+		old.ForwardBufferSize != new.ForwardBufferSize
+}
+
+func forwarding(cfg auditlog.Config) bool {
+	return cfg.Syslog.Enabled || cfg.WebhookURL != ""
+}
+
 func (u *updater) update(newConfig auditlog.Config) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
@@ -188,6 +188,53 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestChangingForwardingRecreatesTarget(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	initial := auditlog.Config{
+		Enabled: true,
+		Target:  &apitesting.FakeAuditLog{},
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return &apitesting.FakeAuditLog{}
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-webhook-url"] = "https://audit.example.com/"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.WebhookURL != ""
+	})
+	c.Assert(newConfig.WebhookURL, gc.Equals, "https://audit.example.com/")
+	c.Assert(newConfig.Target, gc.Not(gc.Equals), initial.Target)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].WebhookURL, gc.Equals, "https://audit.example.com/")
+
+	// Other changes keep the same target.
+	cfg = makeControllerConfig(true, true)
+	cfg["audit-log-webhook-url"] = "https://audit.example.com/"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.CaptureAPIArgs
+	})
+	c.Assert(getWorkerConfig(c, w).Target, gc.Equals, newConfig.Target)
+	c.Assert(calls, gc.HasLen, 1)
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
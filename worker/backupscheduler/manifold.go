@@ -0,0 +1,105 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	jujuagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/state"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information needed to run a backup
+// scheduler in a dependency.Engine.
+type ManifoldConfig struct {
+	AgentName string
+	ClockName string
+	StateName string
+
+	NewBackups func(*state.State, jujuagent.Config) Backups
+	NewWorker  func(Config) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.NewBackups == nil {
+		return errors.NotValidf("nil NewBackups")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a backup
+// scheduler.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.ClockName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker, err error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var agent jujuagent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			stTracker.Done()
+		}
+	}()
+
+	st := statePool.SystemState()
+	w, err := config.NewWorker(Config{
+		ConfigSource: st,
+		Backups:      config.NewBackups(st, agent.CurrentConfig()),
+		Clock:        clock,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	go func() {
+		w.Wait()
+		stTracker.Done()
+	}()
+	return w, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewWorkerShim is suitable for use in ManifoldConfig.NewWorker,
+// and simply calls through to NewWorker.
+func NewWorkerShim(config Config) (worker.Worker, error) {
+	return NewWorker(config)
+}
+
+// backupsDB exposes the controller model to state/backups.
+type backupsDB struct {
+	*state.State
+	*state.Model
+}
+
+// stateBackups implements Backups using the controller's state and the
+// machine agent's configuration.
+type stateBackups struct {
+	st          *state.State
+	agentConfig agent.Config
+}
+
+// NewBackups returns a Backups that backs up the controller whose
+// state is given, from the machine whose agent config is given.
+func NewBackups(st *state.State, agentConfig agent.Config) Backups {
+	return &stateBackups{st: st, agentConfig: agentConfig}
+}
+
+func (b *stateBackups) open(targetSpec string) (backups.Backups, func(), error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	db := backupsDB{b.st, model}
+	openTarget := backups.NewControllerTargetOpener(db)
+	var target backups.Target
+	if targetSpec != "" {
+		if target, err = openTarget(targetSpec); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
+	stor := backups.NewStorage(db)
+	return backups.NewBackupsWithTargets(stor, target, openTarget), func() { stor.Close() }, nil
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create(targetSpec, notes string) (*backups.Metadata, error) {
+	api, closer, err := b.open(targetSpec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer()
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.New("no mongo info in agent config")
+	}
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return nil, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	machineID := b.agentConfig.Tag().Id()
+	machine, err := b.st.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := b.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(backupsDB{b.st, model}, machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = notes
+	meta.Scheduled = true
+
+	modelConfig, err := model.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+	if _, err := api.Create(meta, &paths, dbInfo, true, true); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	api, closer, err := b.open("")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer()
+	return api.List()
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	api, closer, err := b.open("")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+	return errors.Trace(api.Remove(id))
+}
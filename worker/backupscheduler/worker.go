@@ -0,0 +1,223 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker that takes controller
+// backups on the schedule set in controller configuration, and removes
+// old scheduled backups according to the configured retention policy.
+package backupscheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/controller"
+	corebackups "github.com/juju/juju/core/backups"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// ConfigSource lets the worker watch and read controller configuration.
+// (Primary implementation is State.)
+type ConfigSource interface {
+	WatchControllerConfig() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
+}
+
+// Backups creates, lists and removes controller backups.
+type Backups interface {
+	// Create takes a scheduled backup of the controller, writing its
+	// archive to the target with the given specification (or to the
+	// controller's storage if it is empty), and returns its metadata.
+	Create(targetSpec, notes string) (*backups.Metadata, error)
+
+	// List returns the metadata for all stored backups.
+	List() ([]*backups.Metadata, error)
+
+	// Remove deletes the backup with the given ID.
+	Remove(id string) error
+}
+
+// Config holds the dependencies and configuration for a Worker.
+type Config struct {
+	ConfigSource ConfigSource
+	Backups      Backups
+	Clock        clock.Clock
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional Worker.
+func (config Config) Validate() error {
+	if config.ConfigSource == nil {
+		return errors.NotValidf("nil ConfigSource")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// policy holds the controller configuration relevant to scheduled
+// backups.
+type policy struct {
+	schedule       corebackups.Schedule
+	target         string
+	retentionCount int
+	retentionAge   time.Duration
+}
+
+// Worker takes scheduled backups of the controller.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a Worker that takes controller backups on the
+// schedule in controller configuration, rescheduling whenever the
+// configuration changes.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher := w.config.ConfigSource.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var current policy
+	var timer <-chan time.Time
+	schedule := func() {
+		timer = nil
+		if current.schedule == nil {
+			return
+		}
+		now := w.config.Clock.Now()
+		next := current.schedule.Next(now)
+		if next.IsZero() {
+			return
+		}
+		logger.Debugf("next scheduled backup at %v", next)
+		timer = w.config.Clock.After(next.Sub(now))
+	}
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			var err error
+			if current, err = w.readPolicy(); err != nil {
+				return errors.Trace(err)
+			}
+			schedule()
+		case <-timer:
+			if err := w.backup(current); err != nil {
+				// A failed backup shouldn't stop the next one
+				// from being attempted.
+				logger.Errorf("scheduled backup failed: %v", err)
+			}
+			schedule()
+		}
+	}
+}
+
+func (w *Worker) readPolicy() (policy, error) {
+	cfg, err := w.config.ConfigSource.ControllerConfig()
+	if err != nil {
+		return policy{}, errors.Annotate(err, "getting controller config")
+	}
+	result := policy{
+		target:         cfg.BackupTarget(),
+		retentionCount: cfg.BackupRetentionCount(),
+		retentionAge:   cfg.BackupRetentionAge(),
+	}
+	if spec := cfg.BackupSchedule(); spec != "" {
+		// The schedule has already been validated.
+		if result.schedule, err = corebackups.ParseSchedule(spec); err != nil {
+			return policy{}, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
+
+// backup takes a scheduled backup and then removes any scheduled
+// backups the retention policy no longer allows.
+func (w *Worker) backup(p policy) error {
+	meta, err := w.config.Backups.Create(p.target, backups.ScheduledBackupNotes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	target := meta.Target
+	if target == "" {
+		target = "controller"
+	}
+	logger.Infof("created scheduled backup %q in %s", meta.ID(), target)
+	return errors.Annotate(w.prune(p), "applying backup retention policy")
+}
+
+func (w *Worker) prune(p policy) error {
+	if p.retentionCount == 0 && p.retentionAge == 0 {
+		return nil
+	}
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var scheduled []*backups.Metadata
+	for _, meta := range all {
+		if meta.Scheduled {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	// Newest first.
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Started.After(scheduled[j].Started)
+	})
+	now := w.config.Clock.Now()
+	for i, meta := range scheduled {
+		tooMany := p.retentionCount > 0 && i >= p.retentionCount
+		tooOld := p.retentionAge > 0 && now.Sub(meta.Started) > p.retentionAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := w.config.Backups.Remove(meta.ID()); err != nil {
+			return errors.Annotatef(err, "removing backup %q", meta.ID())
+		}
+		logger.Infof("removed scheduled backup %q", meta.ID())
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testing.Clock
+	changes chan struct{}
+	source  *configSource
+	backups *fakeBackups
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+// 10:00 on a Thursday.
+var start = time.Date(2019, 3, 14, 10, 0, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(start)
+	s.changes = make(chan struct{}, 1)
+	s.source = &configSource{
+		watcher: watchertest.NewNotifyWatcher(s.changes),
+		cfg:     controller.Config{},
+	}
+	s.backups = &fakeBackups{created: make(chan createCall, 10)}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C, cfg controller.Config) *backupscheduler.Worker {
+	s.source.setConfig(cfg)
+	w, err := backupscheduler.NewWorker(backupscheduler.Config{
+		ConfigSource: s.source,
+		Backups:      s.backups,
+		Clock:        s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.changes <- struct{}{}
+	return w
+}
+
+func (s *WorkerSuite) waitCreate(c *gc.C) createCall {
+	select {
+	case call := <-s.backups.created:
+		return call
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoCreate(c *gc.C) {
+	select {
+	case call := <-s.backups.created:
+		c.Fatalf("unexpected backup %v", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := backupscheduler.NewWorker(backupscheduler.Config{
+		Backups: s.backups,
+		Clock:   s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil ConfigSource not valid")
+	_, err = backupscheduler.NewWorker(backupscheduler.Config{
+		ConfigSource: s.source,
+		Clock:        s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Backups not valid")
+	_, err = backupscheduler.NewWorker(backupscheduler.Config{
+		ConfigSource: s.source,
+		Backups:      s.backups,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *WorkerSuite) TestNoSchedule(c *gc.C) {
+	s.startWorker(c, controller.Config{})
+	s.clock.Advance(24 * time.Hour)
+	s.assertNoCreate(c)
+}
+
+func (s *WorkerSuite) TestScheduledBackup(c *gc.C) {
+	s.startWorker(c, controller.Config{
+		controller.BackupSchedule: "30 * * * *",
+		controller.BackupTarget:   "file:/var/backups/juju",
+	})
+
+	err := s.clock.WaitAdvance(29*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoCreate(c)
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitCreate(c), jc.DeepEquals, createCall{
+		target: "file:/var/backups/juju",
+		notes:  backups.ScheduledBackupNotes,
+	})
+
+	// The next backup is an hour later.
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreate(c)
+}
+
+func (s *WorkerSuite) TestConfigChangeReschedules(c *gc.C) {
+	s.startWorker(c, controller.Config{
+		controller.BackupSchedule: "@daily",
+	})
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.source.setConfig(controller.Config{
+		controller.BackupSchedule: "@every 2h",
+	})
+	s.changes <- struct{}{}
+
+	// The daily timer is abandoned; the new one fires two hours
+	// after the change.
+	err = s.clock.WaitAdvance(2*time.Hour, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitCreate(c).target, gc.Equals, "")
+}
+
+func (s *WorkerSuite) TestFailedBackupKeepsSchedule(c *gc.C) {
+	s.backups.createErr = errors.New("disk full")
+	s.startWorker(c, controller.Config{
+		controller.BackupSchedule: "@hourly",
+	})
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreate(c)
+
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreate(c)
+}
+
+func (s *WorkerSuite) TestRetentionCount(c *gc.C) {
+	s.backups.setExisting(
+		newMeta("manual", start.Add(-100*time.Hour), false),
+		newMeta("s1", start.Add(-4*time.Hour), true),
+		newMeta("s2", start.Add(-3*time.Hour), true),
+		newMeta("s3", start.Add(-2*time.Hour), true),
+	)
+	s.startWorker(c, controller.Config{
+		controller.BackupSchedule:       "@hourly",
+		controller.BackupRetentionCount: 2,
+	})
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreate(c)
+	s.waitRemaining(c, "manual", "s3", "new")
+}
+
+func (s *WorkerSuite) TestRetentionAge(c *gc.C) {
+	s.backups.setExisting(
+		newMeta("manual", start.Add(-100*time.Hour), false),
+		newMeta("s1", start.Add(-50*time.Hour), true),
+		newMeta("s2", start.Add(-47*time.Hour), true),
+	)
+	s.startWorker(c, controller.Config{
+		controller.BackupSchedule:     "@hourly",
+		controller.BackupRetentionAge: "48h",
+	})
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreate(c)
+	s.waitRemaining(c, "manual", "s2", "new")
+}
+
+func (s *WorkerSuite) TestRetentionIgnoresManualBackups(c *gc.C) {
+	// A manual backup isn't pruned, even if its notes look like
+	// those of a scheduled backup.
+	manual := newMeta("manual", start.Add(-2*time.Hour), false)
+	manual.Notes = backups.ScheduledBackupNotes
+	s.backups.setExisting(
+		manual,
+		newMeta("s1", start.Add(-4*time.Hour), true),
+	)
+	s.startWorker(c, controller.Config{
+		controller.BackupSchedule:       "@hourly",
+		controller.BackupRetentionCount: 1,
+	})
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreate(c)
+	s.waitRemaining(c, "manual", "new")
+}
+
+func (s *WorkerSuite) waitRemaining(c *gc.C, ids ...string) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.backups.ids()) == len(ids) {
+			break
+		}
+	}
+	c.Assert(s.backups.ids(), jc.SameContents, ids)
+}
+
+func newMeta(id string, started time.Time, scheduled bool) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Scheduled = scheduled
+	return meta
+}
+
+type configSource struct {
+	mu      sync.Mutex
+	watcher *watchertest.NotifyWatcher
+	cfg     controller.Config
+}
+
+func (s *configSource) WatchControllerConfig() state.NotifyWatcher {
+	return s.watcher
+}
+
+func (s *configSource) ControllerConfig() (controller.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg, nil
+}
+
+func (s *configSource) setConfig(cfg controller.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+}
+
+type createCall struct {
+	target string
+	notes  string
+}
+
+type fakeBackups struct {
+	mu        sync.Mutex
+	existing  []*backups.Metadata
+	createErr error
+	created   chan createCall
+}
+
+func (b *fakeBackups) setExisting(metas ...*backups.Metadata) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.existing = metas
+}
+
+func (b *fakeBackups) ids() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var ids []string
+	for _, meta := range b.existing {
+		ids = append(ids, meta.ID())
+	}
+	return ids
+}
+
+func (b *fakeBackups) Create(target, notes string) (*backups.Metadata, error) {
+	b.mu.Lock()
+	defer func() {
+		b.mu.Unlock()
+		b.created <- createCall{target: target, notes: notes}
+	}()
+	if b.createErr != nil {
+		return nil, b.createErr
+	}
+	meta := newMeta("new", start.Add(time.Hour), true)
+	meta.Notes = notes
+	meta.Target = target
+	b.existing = append(b.existing, meta)
+	return meta, nil
+}
+
+func (b *fakeBackups) List() ([]*backups.Metadata, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*backups.Metadata(nil), b.existing...), nil
+}
+
+func (b *fakeBackups) Remove(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, meta := range b.existing {
+		if meta.ID() == id {
+			b.existing = append(b.existing[:i], b.existing[i+1:]...)
+			return nil
+		}
+	}
+	return errors.NotFoundf("backup %q", id)
+}
@@ -4,6 +4,8 @@
 package caasbroker_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -109,3 +111,74 @@ func (s *TrackerSuite) TestInitialise(c *gc.C) {
 		context.CheckCallNames(c, "CloudSpec", "Model")
 	})
 }
+
+func (s *TrackerSuite) TestWatchFails(c *gc.C) {
+	fix := s.validFixture()
+	fix.observerErrs = []error{
+		nil, nil, errors.New("grrk splat"),
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI:              context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "cannot watch model config: grrk splat")
+		context.CheckCallNames(c, "CloudSpec", "Model", "WatchForModelConfigChanges")
+	})
+}
+
+func (s *TrackerSuite) TestModelConfigWatchCloses(c *gc.C) {
+	fix := s.validFixture()
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI:              context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		context.CloseModelConfigNotify()
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "model config watch closed")
+		context.CheckCallNames(c, "CloudSpec", "Model", "WatchForModelConfigChanges")
+	})
+}
+
+func (s *TrackerSuite) TestModelConfigUpdatesBroker(c *gc.C) {
+	fix := s.validFixture()
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI:              context,
+			NewContainerBrokerFunc: newMockBroker,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.CleanKill(c, tracker)
+
+		attrs := make(map[string]interface{})
+		for k, v := range fix.config {
+			attrs[k] = v
+		}
+		attrs["test-attr"] = "updated"
+		context.SetConfig(attrs)
+		context.SendModelConfigNotify()
+
+		broker := tracker.Broker().(*mockBroker)
+		timeout := time.After(coretesting.LongWait)
+		for {
+			cfg := broker.Config()
+			if cfg.AllAttrs()["test-attr"] == "updated" {
+				break
+			}
+			select {
+			case <-time.After(coretesting.ShortWait):
+			case <-timeout:
+				c.Fatalf("broker config not updated")
+			}
+		}
+		broker.CheckCallNames(c, "SetConfig")
+	})
+}
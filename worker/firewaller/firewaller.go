@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"io"
+	"reflect"
 	"strings"
 	"time"
 
@@ -117,7 +118,10 @@ func (cfg Config) Validate() error {
 	return nil
 }
 
-type portRanges map[network.PortRange]bool
+// portRanges maps the port ranges opened by a unit to the names of the
+// endpoints they were opened for. An empty name means the range was
+// opened for all of the unit's endpoints.
+type portRanges map[network.PortRange]set.Strings
 
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
@@ -304,6 +308,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedEndpoints = change.exposedEndpoints
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -468,22 +473,23 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, exposedEndpoints, err := app.ExposeInfo()
 	if err != nil {
 		return err
 	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:               fw,
+		application:      app,
+		exposed:          exposed,
+		exposedEndpoints: exposedEndpoints,
+		unitds:           make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, exposedEndpoints)
 		},
 	})
 	if err != nil {
@@ -649,13 +655,14 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 		return err
 	}
 
-	ports, err := m.OpenedPorts(subnetTag)
+	ports, err := m.OpenedPortEndpoints(subnetTag)
 	if err != nil {
 		return err
 	}
 
 	newPortRanges := make(map[names.UnitTag]portRanges)
-	for portRange, unitTag := range ports {
+	for portRange, unitEndpoints := range ports {
+		unitTag := unitEndpoints.Unit
 		unitd, ok := machined.unitds[unitTag]
 		if !ok {
 			// It is common to receive port change notification before
@@ -669,7 +676,7 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 			ranges = make(portRanges)
 			newPortRanges[unitd.tag] = ranges
 		}
-		ranges[portRange] = true
+		ranges[portRange] = unitEndpoints.Endpoints
 	}
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
@@ -704,7 +711,7 @@ func portRangesEqual(a, b portRanges) bool {
 		if !exists {
 			return false
 		}
-		if valueA != valueB {
+		if valueA.Size() != valueB.Size() || !valueA.Difference(valueB).IsEmpty() {
 			return false
 		}
 	}
@@ -751,17 +758,29 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				continue
 			}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
+			// If the unit is exposed, allow access to each port range
+			// from the sources its endpoints are exposed to.
 			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
-				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
-					return nil, errors.Trace(err)
+				for portRange, endpoints := range portRanges {
+					cidrs := unitd.applicationd.exposedCIDRs(endpoints)
+					if cidrs.Size() == 0 {
+						continue
+					}
+					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, cidrs.SortedValues()...)
+					if err != nil {
+						return nil, errors.Trace(err)
+					}
+					want = append(want, rule)
 				}
-				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
+				continue
+			}
+
+			// Not exposed, so add any ingress rules required by remote relations.
+			cidrs := set.NewStrings()
+			if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
+				return nil, errors.Trace(err)
 			}
+			logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
 			if cidrs.Size() > 0 {
 				for portRange := range portRanges {
 					sourceCidrs := cidrs.SortedValues()
@@ -1074,23 +1093,72 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and expose settings
+// for one specific application.
 type exposedChange struct {
-	applicationd *applicationData
-	exposed      bool
+	applicationd     *applicationData
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb         catacomb.Catacomb
+	fw               *Firewaller
+	application      *firewaller.Application
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
+	unitds           map[names.UnitTag]*unitData
+}
+
+// exposedCIDRs returns the CIDRs that may access a port range opened
+// by one of the exposed application's units for the given endpoints.
+// If the application has no expose settings, the ports are accessible
+// from anywhere.
+func (ad *applicationData) exposedCIDRs(endpoints set.Strings) set.Strings {
+	if len(ad.exposedEndpoints) == 0 {
+		return set.NewStrings("0.0.0.0/0")
+	}
+	cidrs := set.NewStrings()
+	for _, endpoint := range endpoints.Values() {
+		cidrs = cidrs.Union(ad.endpointCIDRs(endpoint))
+	}
+	return cidrs
+}
+
+// endpointCIDRs returns the CIDRs that may access the ports opened for
+// the given endpoint. Ports opened for all endpoints may be accessed by
+// the sources of any exposed endpoint; ports opened for an endpoint
+// without its own expose settings fall back to the wildcard settings.
+func (ad *applicationData) endpointCIDRs(endpoint string) set.Strings {
+	if endpoint == "" {
+		cidrs := set.NewStrings()
+		for _, ep := range ad.exposedEndpoints {
+			cidrs = cidrs.Union(exposedEndpointCIDRs(ep))
+		}
+		return cidrs
+	}
+	if ep, ok := ad.exposedEndpoints[endpoint]; ok {
+		return exposedEndpointCIDRs(ep)
+	}
+	if ep, ok := ad.exposedEndpoints[""]; ok {
+		return exposedEndpointCIDRs(ep)
+	}
+	return set.NewStrings()
+}
+
+// exposedEndpointCIDRs returns the CIDRs of the given expose settings;
+// settings without any spaces or CIDRs allow access from anywhere.
+func exposedEndpointCIDRs(ep params.ExposedEndpoint) set.Strings {
+	if len(ep.ExposeToSpaces) == 0 && len(ep.ExposeToCIDRs) == 0 {
+		return set.NewStrings("0.0.0.0/0")
+	}
+	return set.NewStrings(ep.ExposeToCIDRs...)
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed flag and expose
+// settings for changes.
+func (ad *applicationData) watchLoop(exposed bool, exposedEndpoints map[string]params.ExposedEndpoint) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1115,19 +1183,20 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := ad.application.IsExposed()
+			newExposed, newExposedEndpoints, err := ad.application.ExposeInfo()
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			if newExposed == exposed && reflect.DeepEqual(newExposedEndpoints, exposedEndpoints) {
 				continue
 			}
 
-			exposed = change
+			exposed = newExposed
+			exposedEndpoints = newExposedEndpoints
 			select {
 			case <-ad.catacomb.Dying():
 				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
+			case ad.fw.exposedChange <- &exposedChange{ad, exposed, exposedEndpoints}:
 			}
 		}
 	}
@@ -557,6 +557,78 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposeToCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24", "192.168.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.0.0/24"),
+	})
+
+	// Changing the settings of an exposed application updates the rules.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+	})
+
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestExposeEndpoints(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenEndpointPorts("db", "tcp", 8080, 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 22)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+		"db":  {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0", "10.0.0.0/24"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "10.0.0.0/24"),
+	})
+
+	// Ports opened for an endpoint without expose settings are closed.
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -1292,6 +1364,30 @@ func (s *GlobalModeSuite) TestStartStop(c *gc.C) {
 	statetesting.AssertKillAndWait(c, fw)
 }
 
+func (s *GlobalModeSuite) TestGlobalModeExposeToCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+	err = u.OpenPort("tcp", 8443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEnvironPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8443, 8443, "10.0.0.0/24"),
+	})
+
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironPorts(c, nil)
+}
+
 func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	// Start firewaller and open ports.
 	fw := s.newFirewaller(c)
@@ -0,0 +1,106 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package globalclockupdater
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/raftlease"
+)
+
+// raftApplyTimeout is how long the updater will wait for a time
+// update to be enqueued for replication.
+const raftApplyTimeout = 5 * time.Second
+
+// RaftManifoldConfig holds the information necessary to run a
+// GlobalClockUpdater worker that advances the global time recorded
+// in the raft lease FSM.
+type RaftManifoldConfig struct {
+	ClockName string
+	RaftName  string
+
+	// FSM is the raft lease FSM shared with the raft worker.
+	FSM *raftlease.FSM
+
+	NewWorker      func(Config) (worker.Worker, error)
+	UpdateInterval time.Duration
+	BackoffDelay   time.Duration
+}
+
+// Validate returns an error if the config is not valid.
+func (config RaftManifoldConfig) Validate() error {
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.RaftName == "" {
+		return errors.NotValidf("empty RaftName")
+	}
+	if config.FSM == nil {
+		return errors.NotValidf("nil FSM")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	if config.UpdateInterval <= 0 {
+		return errors.NotValidf("non-positive UpdateInterval")
+	}
+	if config.BackoffDelay <= 0 {
+		return errors.NotValidf("non-positive BackoffDelay")
+	}
+	return nil
+}
+
+// RaftManifold returns a dependency.Manifold that will run a global
+// clock updater worker backed by the raft lease FSM. As commands can
+// only be applied on the raft leader, it is expected to be run only
+// on the leader.
+func RaftManifold(config RaftManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.ClockName,
+			config.RaftName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on RaftManifoldConfig because it's more readable
+// than a closure.
+func (config RaftManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var r *raft.Raft
+	if err := context.Get(config.RaftName, &r); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	newUpdater := func() (globalclock.Updater, error) {
+		return raftlease.NewStore(raftlease.StoreConfig{
+			FSM:          config.FSM,
+			Raft:         r,
+			Clock:        clock,
+			ApplyTimeout: raftApplyTimeout,
+		})
+	}
+	return config.NewWorker(Config{
+		NewUpdater:     newUpdater,
+		LocalClock:     clock,
+		UpdateInterval: config.UpdateInterval,
+		BackoffDelay:   config.BackoffDelay,
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package globalclockupdater_test
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/worker/globalclockupdater"
+)
+
+type RaftManifoldSuite struct {
+	testing.IsolationSuite
+	stub   testing.Stub
+	config globalclockupdater.RaftManifoldConfig
+	raft   *raft.Raft
+	worker worker.Worker
+}
+
+var _ = gc.Suite(&RaftManifoldSuite{})
+
+func (s *RaftManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub.ResetCalls()
+	s.config = globalclockupdater.RaftManifoldConfig{
+		ClockName:      "clock",
+		RaftName:       "raft",
+		FSM:            raftlease.NewFSM(),
+		NewWorker:      s.newWorker,
+		UpdateInterval: time.Second,
+		BackoffDelay:   time.Second,
+	}
+	s.raft = &raft.Raft{}
+	s.worker = worker.NewRunner(worker.RunnerParams{})
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, s.worker) })
+}
+
+func (s *RaftManifoldSuite) newWorker(config globalclockupdater.Config) (worker.Worker, error) {
+	s.stub.AddCall("NewWorker", config)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.worker, nil
+}
+
+func (s *RaftManifoldSuite) TestInputs(c *gc.C) {
+	manifold := globalclockupdater.RaftManifold(s.config)
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"clock", "raft"})
+}
+
+func (s *RaftManifoldSuite) TestStartValidateRaftName(c *gc.C) {
+	s.config.RaftName = ""
+	s.testStartValidateConfig(c, "empty RaftName not valid")
+}
+
+func (s *RaftManifoldSuite) TestStartValidateFSM(c *gc.C) {
+	s.config.FSM = nil
+	s.testStartValidateConfig(c, "nil FSM not valid")
+}
+
+func (s *RaftManifoldSuite) testStartValidateConfig(c *gc.C, expect string) {
+	manifold := globalclockupdater.RaftManifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"clock": nil,
+		"raft":  nil,
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *RaftManifoldSuite) TestStartMissingRaft(c *gc.C) {
+	manifold := globalclockupdater.RaftManifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"clock": fakeClock{},
+		"raft":  dependency.ErrMissing,
+	})
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *RaftManifoldSuite) TestStartNewWorkerSuccess(c *gc.C) {
+	manifold := globalclockupdater.RaftManifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"clock": fakeClock{},
+		"raft":  s.raft,
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, s.worker)
+
+	s.stub.CheckCallNames(c, "NewWorker")
+	config := s.stub.Calls()[0].Args[0].(globalclockupdater.Config)
+	c.Assert(config.NewUpdater, gc.NotNil)
+	updater, err := config.NewUpdater()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updater, gc.FitsTypeOf, &raftlease.Store{})
+
+	config.NewUpdater = nil
+	c.Assert(config, jc.DeepEquals, globalclockupdater.Config{
+		LocalClock:     fakeClock{},
+		UpdateInterval: s.config.UpdateInterval,
+		BackoffDelay:   s.config.BackoffDelay,
+	})
+}
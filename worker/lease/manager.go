@@ -190,6 +190,18 @@ func (manager *Manager) Claimer(namespace, modelUUID string) (lease.Claimer, err
 	return manager.bind(namespace, modelUUID)
 }
 
+// Reader returns a lease.Reader for the specified namespace and model.
+func (manager *Manager) Reader(namespace, modelUUID string) (lease.Reader, error) {
+	if _, err := manager.config.Secretary(namespace); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &leaseReader{
+		store:     manager.config.Store,
+		namespace: namespace,
+		modelUUID: modelUUID,
+	}, nil
+}
+
 // retryingClaim handles timeouts when claiming, and responds to the
 // claiming party when it eventually succeeds or fails, or if it times
 // out after a number of retries.
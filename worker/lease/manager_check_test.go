@@ -132,3 +132,33 @@ func getChecker(c *gc.C, manager *lease.Manager) corelease.Checker {
 	c.Assert(err, jc.ErrorIsNil)
 	return checker
 }
+
+type ReaderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReaderSuite{})
+
+func (s *ReaderSuite) TestLeases(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder:   "redis/0",
+				Expiry:   offset(time.Second),
+				Trapdoor: corelease.LockedTrapdoor,
+			},
+			key("namespace", "otherModel", "redis"): {
+				Holder:   "redis/1",
+				Expiry:   offset(time.Second),
+				Trapdoor: corelease.LockedTrapdoor,
+			},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testing.Clock) {
+		reader, err := manager.Reader("namespace", "modelUUID")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(reader.Leases(), jc.DeepEquals, map[string]string{
+			"redis": "redis/0",
+		})
+	})
+}
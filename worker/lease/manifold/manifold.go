@@ -0,0 +1,256 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package manifold provides the dependency engine manifold for the
+// raft-backed lease manager. It's separate from the lease package so
+// that it can use state, which depends on the lease package itself.
+package manifold
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/common"
+	"github.com/juju/juju/worker/lease"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+const (
+	// defaultMaxSleep is the longest the raft-backed manager will
+	// sleep before refreshing its leases and checking for expiries.
+	defaultMaxSleep = time.Minute
+
+	// defaultApplyTimeout is how long the raft-backed store will
+	// wait for a lease command to be enqueued for replication.
+	defaultApplyTimeout = 5 * time.Second
+
+	// defaultForwardTimeout is how long the raft-backed store will
+	// wait for the raft leader to apply a forwarded lease command.
+	defaultForwardTimeout = 10 * time.Second
+)
+
+// ManifoldConfig holds the resources needed to run a lease manager
+// backed by the raft cluster in a dependency.Engine.
+type ManifoldConfig struct {
+	AgentName      string
+	ClockName      string
+	RaftName       string
+	CentralHubName string
+	StateName      string
+
+	// FSM is the raft lease FSM shared with the raft worker; the
+	// store reads lease state directly from it.
+	FSM *raftlease.FSM
+
+	// Secretary returns the Secretary for validating requests in
+	// the given namespace.
+	Secretary func(namespace string) (lease.Secretary, error)
+
+	// MaxSleep is passed through to the Manager. If zero,
+	// defaultMaxSleep is used.
+	MaxSleep time.Duration
+
+	// Logger is used to report failures to record lease holders.
+	Logger loggo.Logger
+
+	NewStore  func(raftlease.StoreConfig) (corelease.Store, error)
+	NewWorker func(lease.ManagerConfig) (worker.Worker, error)
+
+	// NewTarget returns the target that records lease holders in
+	// the controller's state, so that transactions can assert on
+	// them.
+	NewTarget func(*state.State, loggo.Logger) raftlease.NotifyTarget
+}
+
+// Validate checks that the config has all the required values.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.RaftName == "" {
+		return errors.NotValidf("empty RaftName")
+	}
+	if config.CentralHubName == "" {
+		return errors.NotValidf("empty CentralHubName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.FSM == nil {
+		return errors.NotValidf("nil FSM")
+	}
+	if config.Secretary == nil {
+		return errors.NotValidf("nil Secretary")
+	}
+	if config.MaxSleep < 0 {
+		return errors.NotValidf("negative MaxSleep")
+	}
+	if config.NewStore == nil {
+		return errors.NotValidf("nil NewStore")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	if config.NewTarget == nil {
+		return errors.NotValidf("nil NewTarget")
+	}
+	return nil
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Raft only runs once the controller is fully upgraded, but the
+	// API server needs the lease manager before then. Until raft is
+	// running locally, every command is forwarded to the raft leader
+	// over the hub; the manager is restarted when raft starts.
+	var r *raft.Raft
+	if err := context.Get(config.RaftName, &r); errors.Cause(err) == dependency.ErrMissing {
+		r = nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var hub *pubsub.StructuredHub
+	if err := context.Get(config.CentralHubName, &hub); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Lease holders are recorded in state as leases are claimed
+	// and expired, and the leases' trapdoors assert on those
+	// records, so that leadership-gated transactions fail once
+	// the lease is lost.
+	var applier raftlease.RaftApplier
+	if r != nil {
+		applier = r
+	}
+	store, err := config.NewStore(raftlease.StoreConfig{
+		FSM:            config.FSM,
+		Raft:           applier,
+		Clock:          clock,
+		ApplyTimeout:   defaultApplyTimeout,
+		Hub:            hub,
+		ForwardTimeout: defaultForwardTimeout,
+		Target:         config.NewTarget(statePool.SystemState(), config.Logger),
+		Trapdoor:       state.LeaseTrapdoorFunc(),
+	})
+	if err != nil {
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+
+	maxSleep := config.MaxSleep
+	if maxSleep == 0 {
+		maxSleep = defaultMaxSleep
+	}
+	w, err := config.NewWorker(lease.ManagerConfig{
+		Secretary:  config.Secretary,
+		Store:      store,
+		Clock:      clock,
+		MaxSleep:   maxSleep,
+		EntityUUID: agent.CurrentConfig().Controller().Id(),
+	})
+	if err != nil {
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, func() { stTracker.Done() }), nil
+}
+
+// output exposes the manager as a lease.Manager, so that the API
+// server can hand out claimers and checkers backed by raft.
+func output(in worker.Worker, out interface{}) error {
+	if w, ok := in.(*common.CleanupWorker); ok {
+		in = w.Worker
+	}
+	manager, ok := in.(corelease.Manager)
+	if !ok {
+		return errors.Errorf("expected input of type lease.Manager, got %T", in)
+	}
+	switch out := out.(type) {
+	case *corelease.Manager:
+		*out = manager
+	default:
+		return errors.Errorf("expected output of type *lease.Manager, got %T", out)
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a lease
+// manager using the raft cluster to store leases. Commands are
+// forwarded to the raft leader over the central hub, so the manager
+// can run on every controller.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.ClockName,
+			config.RaftName,
+			config.CentralHubName,
+			config.StateName,
+		},
+		Start:  config.start,
+		Output: output,
+	}
+}
+
+// NewStore is suitable for use as ManifoldConfig.NewStore; it
+// returns a raft-backed lease.Store.
+func NewStore(config raftlease.StoreConfig) (corelease.Store, error) {
+	store, err := raftlease.NewStore(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return store, nil
+}
+
+// NewTarget is suitable for use as ManifoldConfig.NewTarget; it
+// returns the state's lease notify target.
+func NewTarget(st *state.State, logger loggo.Logger) raftlease.NotifyTarget {
+	return st.LeaseNotifyTarget(logger)
+}
+
+// NewManagerWorker is suitable for use as ManifoldConfig.NewWorker;
+// it simply calls through to lease.NewManager.
+func NewManagerWorker(config lease.ManagerConfig) (worker.Worker, error) {
+	manager, err := lease.NewManager(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return manager, nil
+}
@@ -0,0 +1,269 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manifold_test
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+
+	"github.com/juju/juju/agent"
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/pubsub/centralhub"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/lease"
+	leasemanager "github.com/juju/juju/worker/lease/manifold"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+
+	context  dependency.Context
+	manifold dependency.Manifold
+	agent    *mockAgent
+	clock    *testing.Clock
+	raft     *raft.Raft
+	hub      *pubsub.StructuredHub
+	fsm      *raftlease.FSM
+	store    *fakeStore
+	target   *fakeTarget
+	worker   worker.Worker
+	stub     testing.Stub
+
+	stateTracker *stubStateTracker
+	pool         state.StatePool
+	logger       loggo.Logger
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub.ResetCalls()
+
+	s.agent = &mockAgent{conf: mockAgentConfig{
+		controller: coretesting.ControllerTag,
+	}}
+	s.clock = testing.NewClock(time.Now())
+	s.raft = &raft.Raft{}
+	s.hub = centralhub.New(names.NewMachineTag("0"))
+	s.fsm = raftlease.NewFSM()
+	s.store = &fakeStore{}
+	s.target = &fakeTarget{}
+	s.stateTracker = &stubStateTracker{pool: &s.pool}
+	s.logger = loggo.GetLogger("lease.manifold_test")
+
+	s.worker = &mockWorker{}
+
+	s.context = s.newContext(nil)
+	s.manifold = leasemanager.Manifold(leasemanager.ManifoldConfig{
+		AgentName:      "agent",
+		ClockName:      "clock",
+		RaftName:       "raft",
+		CentralHubName: "hub",
+		StateName:      "state",
+		FSM:            s.fsm,
+		Secretary:      s.secretary,
+		Logger:         s.logger,
+		NewStore:       s.newStore,
+		NewWorker:      s.newWorker,
+		NewTarget:      s.newTarget,
+	})
+}
+
+func (s *manifoldSuite) newContext(overlay map[string]interface{}) dependency.Context {
+	resources := map[string]interface{}{
+		"agent": s.agent,
+		"clock": s.clock,
+		"raft":  s.raft,
+		"hub":   s.hub,
+		"state": s.stateTracker,
+	}
+	for k, v := range overlay {
+		resources[k] = v
+	}
+	return dt.StubContext(nil, resources)
+}
+
+func (s *manifoldSuite) secretary(namespace string) (lease.Secretary, error) {
+	return nil, errors.NotImplementedf("secretary")
+}
+
+func (s *manifoldSuite) newStore(config raftlease.StoreConfig) (corelease.Store, error) {
+	s.stub.MethodCall(s, "NewStore", config)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.store, nil
+}
+
+func (s *manifoldSuite) newTarget(st *state.State, logger loggo.Logger) raftlease.NotifyTarget {
+	s.stub.MethodCall(s, "NewTarget", st, logger)
+	return s.target
+}
+
+func (s *manifoldSuite) newWorker(config lease.ManagerConfig) (worker.Worker, error) {
+	s.stub.MethodCall(s, "NewWorker", config)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.worker, nil
+}
+
+var expectedInputs = []string{"agent", "clock", "raft", "hub", "state"}
+
+func (s *manifoldSuite) TestInputs(c *gc.C) {
+	c.Assert(s.manifold.Inputs, jc.SameContents, expectedInputs)
+}
+
+func (s *manifoldSuite) TestMissingInputs(c *gc.C) {
+	for _, input := range expectedInputs {
+		if input == "raft" {
+			// Raft is optional; see TestStartWithoutRaft.
+			continue
+		}
+		context := s.newContext(map[string]interface{}{
+			input: dependency.ErrMissing,
+		})
+		_, err := s.manifold.Start(context)
+		c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	}
+}
+
+func (s *manifoldSuite) TestStart(c *gc.C) {
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "NewTarget", "NewStore", "NewWorker")
+	s.stub.CheckCall(c, 0, "NewTarget", s.pool.SystemState(), s.logger)
+
+	storeConfig := s.stub.Calls()[1].Args[0].(raftlease.StoreConfig)
+	c.Assert(storeConfig.FSM, gc.Equals, s.fsm)
+	c.Assert(storeConfig.Raft, gc.Equals, s.raft)
+	c.Assert(storeConfig.Clock, gc.Equals, s.clock)
+	c.Assert(storeConfig.ApplyTimeout, gc.Equals, 5*time.Second)
+	c.Assert(storeConfig.Hub, gc.Equals, s.hub)
+	c.Assert(storeConfig.ForwardTimeout, gc.Equals, 10*time.Second)
+	c.Assert(storeConfig.Target, gc.Equals, s.target)
+	c.Assert(storeConfig.Trapdoor, gc.NotNil)
+
+	config := s.stub.Calls()[2].Args[0].(lease.ManagerConfig)
+	c.Assert(config.Secretary, gc.NotNil)
+	config.Secretary = nil
+	c.Assert(config, jc.DeepEquals, lease.ManagerConfig{
+		Store:      s.store,
+		Clock:      s.clock,
+		MaxSleep:   time.Minute,
+		EntityUUID: coretesting.ControllerTag.Id(),
+	})
+
+	// The state is released when the worker stops.
+	s.stateTracker.CheckCallNames(c, "Use")
+	w.Kill()
+	c.Assert(w.Wait(), jc.ErrorIsNil)
+	s.stateTracker.CheckCallNames(c, "Use", "Done")
+}
+
+func (s *manifoldSuite) TestStartWithoutRaft(c *gc.C) {
+	context := s.newContext(map[string]interface{}{
+		"raft": dependency.ErrMissing,
+	})
+	w, err := s.manifold.Start(context)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		w.Kill()
+		c.Assert(w.Wait(), jc.ErrorIsNil)
+	}()
+
+	// Without raft, every command is forwarded over the hub.
+	s.stub.CheckCallNames(c, "NewTarget", "NewStore", "NewWorker")
+	storeConfig := s.stub.Calls()[1].Args[0].(raftlease.StoreConfig)
+	c.Assert(storeConfig.Raft, gc.IsNil)
+	c.Assert(storeConfig.Hub, gc.Equals, s.hub)
+}
+
+func (s *manifoldSuite) TestOutput(c *gc.C) {
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var manager corelease.Manager
+	err = s.manifold.Output(w, &manager)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manager, gc.Equals, s.worker)
+
+	var other worker.Worker
+	err = s.manifold.Output(w, &other)
+	c.Assert(err, gc.ErrorMatches, `expected output of type \*lease.Manager, got \*worker.Worker`)
+}
+
+func (s *manifoldSuite) TestStoreError(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	_, err := s.manifold.Start(s.context)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.stub.CheckCallNames(c, "NewTarget", "NewStore")
+	s.stateTracker.CheckCallNames(c, "Use", "Done")
+}
+
+type mockAgent struct {
+	agent.Agent
+	conf mockAgentConfig
+}
+
+func (ma *mockAgent) CurrentConfig() agent.Config {
+	return &ma.conf
+}
+
+type mockAgentConfig struct {
+	agent.Config
+	controller names.ControllerTag
+}
+
+func (c *mockAgentConfig) Controller() names.ControllerTag {
+	return c.controller
+}
+
+type fakeStore struct {
+	corelease.Store
+}
+
+type mockWorker struct {
+	corelease.Manager
+}
+
+func (*mockWorker) Kill() {}
+
+func (*mockWorker) Wait() error {
+	return nil
+}
+
+type fakeTarget struct {
+	raftlease.NotifyTarget
+}
+
+type stubStateTracker struct {
+	testing.Stub
+	pool *state.StatePool
+}
+
+func (s *stubStateTracker) Use() (*state.StatePool, error) {
+	s.MethodCall(s, "Use")
+	return s.pool, s.NextErr()
+}
+
+func (s *stubStateTracker) Done() error {
+	s.MethodCall(s, "Done")
+	return s.NextErr()
+}
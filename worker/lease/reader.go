@@ -0,0 +1,27 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"github.com/juju/juju/core/lease"
+)
+
+// leaseReader implements lease.Reader for a specific namespace and
+// model, reading directly from the manager's store.
+type leaseReader struct {
+	store     lease.Store
+	namespace string
+	modelUUID string
+}
+
+// Leases is part of the lease.Reader interface.
+func (r *leaseReader) Leases() map[string]string {
+	results := make(map[string]string)
+	for key, info := range r.store.Leases() {
+		if key.Namespace == r.namespace && key.ModelUUID == r.modelUUID {
+			results[key.Lease] = info.Holder
+		}
+	}
+	return results
+}
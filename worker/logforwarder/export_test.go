@@ -0,0 +1,17 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"gopkg.in/juju/worker.v1"
+)
+
+// NewOrchestratorForController exposes the orchestrator for testing.
+func NewOrchestratorForController(args OrchestratorArgs) (worker.Worker, error) {
+	o, err := newOrchestratorForController(args)
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
@@ -5,6 +5,7 @@ package logforwarder
 
 import (
 	"io"
+	"reflect"
 	"sync"
 
 	"github.com/juju/errors"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
 )
 
 var logger = loggo.GetLogger("juju.worker.logforwarder")
@@ -40,17 +42,16 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
-// to a sender.
+// to a single sender. Each sink has its own LogForwarder, so that a
+// slow sink doesn't hold up the others.
 type LogForwarder struct {
 	catacomb  catacomb.Catacomb
 	args      OpenLogForwarderArgs
 	enabledCh chan bool
 	mu        sync.Mutex
 	enabled   bool
+	config    sinkconfig.Config
 }
 
 // OpenLogForwarderArgs holds the info needed to open a LogForwarder.
@@ -64,7 +65,7 @@ type OpenLogForwarderArgs struct {
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
-	// Name is the name given to the log sink.
+	// Name is the name of the log sink in the log forwarding config.
 	Name string
 
 	// OpenSink is the function that opens the underlying log sink that
@@ -76,7 +77,7 @@ type OpenLogForwarderArgs struct {
 	OpenLogStream LogStreamFn
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -91,15 +92,28 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 
 	// Get the new config and set up log forwarding if enabled.
-	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	sinks, err := lf.args.LogForwardConfig.LogForwardSinks()
 	if err != nil {
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
-		logger.Infof("config change - log forwarding not enabled")
+	var cfg sinkconfig.Config
+	var ok bool
+	for _, sinkCfg := range sinks {
+		if sinkCfg.Name == lf.args.Name {
+			cfg, ok = sinkCfg, true
+			break
+		}
+	}
+	if !ok {
+		logger.Infof("config change - log forwarding to %q not enabled", lf.args.Name)
 		return nil, closeExisting()
 	}
+	// Other model config changes also trigger the watcher; there's
+	// no need to reopen the sink if its own config is unchanged.
+	if currentSender != nil && reflect.DeepEqual(cfg, lf.config) {
+		return currentSender, nil
+	}
 	// If the config is not valid, we don't want to exit with an error
 	// and bounce the worker; we'll just log the issue and wait for another
 	// config change to come through.
@@ -122,6 +136,7 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	lf.config = cfg
 	lf.enabledCh <- true
 	return sink, nil
 }
@@ -145,7 +160,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to %q", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -230,7 +245,7 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
@@ -4,6 +4,7 @@
 package logforwarder_test
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
@@ -68,9 +70,9 @@ func (s *LogForwarderSuite) newLogForwarderArgs(
 	stream logforwarder.LogStream,
 	sender *stubSender,
 ) logforwarder.OpenLogForwarderArgs {
-	api := &mockLogForwardConfig{
-		enabled: stream != nil,
-		host:    "10.0.0.1",
+	api := newMockLogForwardConfig()
+	if stream != nil {
+		api.sinks = []sinkconfig.Config{syslogSinkConfig("test-sink", "10.0.0.1")}
 	}
 	return s.newLogForwarderArgsWithAPI(c, api, stream, sender)
 }
@@ -85,8 +87,9 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		Name:             "test-sink",
+		OpenSink: func(cfg sinkconfig.Config) (*logforwarder.LogSink, error) {
+			sender.host = cfg.Syslog.Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -118,10 +121,7 @@ func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	rec1 := s.rec
 	rec1.ID = 11
 
-	api := &mockLogForwardConfig{
-		enabled: true,
-		host:    "10.0.0.1",
-	}
+	api := newMockLogForwardConfig(syslogSinkConfig("test-sink", "10.0.0.1"))
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
 	defer workertest.DirtyKill(c, lf)
@@ -131,8 +131,7 @@ func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	s.sender.waitForSend(c)
 
 	// Config change.
-	api.host = "10.0.0.2"
-	api.changes <- struct{}{}
+	api.setSinks(syslogSinkConfig("test-sink", "10.0.0.2"))
 	s.sender.waitForClose(c)
 
 	// Send the second record.
@@ -152,6 +151,50 @@ func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestUnrelatedConfigChange(c *gc.C) {
+	api := newMockLogForwardConfig(
+		syslogSinkConfig("test-sink", "10.0.0.1"),
+		syslogSinkConfig("other-sink", "10.0.0.2"),
+	)
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, s.rec)
+	s.sender.waitForSend(c)
+
+	// Changing another sink's config doesn't reopen this one.
+	api.setSinks(syslogSinkConfig("test-sink", "10.0.0.1"))
+	time.Sleep(coretesting.ShortWait)
+
+	workertest.CleanKill(c, lf)
+	s.sender.stub.CheckCallNames(c, "Send", "Close")
+}
+
+func (s *LogForwarderSuite) TestFilter(c *gc.C) {
+	cfg := syslogSinkConfig("test-sink", "10.0.0.1")
+	cfg.Filter = sinkconfig.Filter{Level: loggo.WARNING}
+	api := newMockLogForwardConfig(cfg)
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+	rec1.Level = loggo.ERROR
+	s.stream.addRecords(c, rec0, rec1)
+	s.sender.waitForSend(c)
+
+	workertest.CleanKill(c, lf)
+
+	// Only the record that matched the filter was sent.
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec1}}},
+		{"Close", nil},
+	})
+}
+
 func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, nil, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
@@ -207,9 +250,40 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 }
 
 type mockLogForwardConfig struct {
-	enabled bool
-	host    string
-	changes chan struct{}
+	mu       sync.Mutex
+	sinks    []sinkconfig.Config
+	watchers []chan struct{}
+}
+
+func newMockLogForwardConfig(sinks ...sinkconfig.Config) *mockLogForwardConfig {
+	return &mockLogForwardConfig{sinks: sinks}
+}
+
+// setSinks changes the sink config, and notifies the watchers.
+func (c *mockLogForwardConfig) setSinks(sinks ...sinkconfig.Config) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sinks = sinks
+	for _, changes := range c.watchers {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func syslogSinkConfig(name, host string) sinkconfig.Config {
+	return sinkconfig.Config{
+		Name: name,
+		Type: sinkconfig.TypeSyslog,
+		Syslog: syslog.RawConfig{
+			Enabled:    true,
+			Host:       host,
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+	}
 }
 
 type mockWatcher struct {
@@ -241,21 +315,20 @@ func (*mockCaller) BestFacadeVersion(facade string) int {
 }
 
 func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error) {
-	c.changes = make(chan struct{}, 1)
-	c.changes <- struct{}{}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	c.watchers = append(c.watchers, changes)
 	return &mockWatcher{
-		changes: c.changes,
+		changes: changes,
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
-	}, true, nil
+func (c *mockLogForwardConfig) LogForwardSinks() ([]sinkconfig.Config, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sinks, nil
 }
 
 type stubStream struct {
@@ -288,6 +361,9 @@ type stubSender struct {
 	stub     *testing.Stub
 	activity chan string
 	host     string
+
+	// unblock, if set, must be closed before Send returns.
+	unblock chan struct{}
 }
 
 func newStubSender() *stubSender {
@@ -298,6 +374,9 @@ func newStubSender() *stubSender {
 }
 
 func (s *stubSender) Send(records []logfwd.Record) error {
+	if s.unblock != nil {
+		<-s.unblock
+	}
 	for i, rec := range records {
 		rec.Message = "send to " + s.host
 		records[i] = rec
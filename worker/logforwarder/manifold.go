@@ -20,9 +20,9 @@ type ManifoldConfig struct {
 	// These are the dependency resource names.
 	APICallerName string
 
-	// Sinks are the named functions that opens the underlying log sinks
+	// Sinks holds the functions that open the underlying log sinks
 	// to which log records will be forwarded.
-	Sinks []LogSinkSpec
+	Sinks SinkRegistry
 
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
@@ -74,7 +74,10 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				OpenLogStream:    openLogStream,
 				OpenLogForwarder: openForwarder,
 			})
-			return orchestrator, errors.Annotate(err, "creating log forwarding orchestrator")
+			if err != nil {
+				return nil, errors.Annotate(err, "creating log forwarding orchestrator")
+			}
+			return orchestrator, nil
 		},
 	}
 }
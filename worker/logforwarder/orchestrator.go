@@ -4,13 +4,20 @@
 package logforwarder
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/api/base"
+	jworker "github.com/juju/juju/worker"
 )
 
+// orchestrator runs a LogForwarder for each configured log sink.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	catacomb catacomb.Catacomb
+	args     OrchestratorArgs
+	runner   *worker.Runner
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -25,9 +32,9 @@ type OrchestratorArgs struct {
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
-	// Sinks are the named functions that open the underlying log sinks
+	// Sinks holds the functions that open the underlying log sinks
 	// to which log records will be forwarded.
-	Sinks []LogSinkSpec
+	Sinks SinkRegistry
 
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
@@ -38,21 +45,95 @@ type OrchestratorArgs struct {
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
-	if len(args.Sinks) == 0 {
-		return nil, nil
+	o := &orchestrator{
+		args: args,
+		runner: worker.NewRunner(worker.RunnerParams{
+			// One of the log forwarders failing should not
+			// prevent the others from running.
+			IsFatal:      func(error) bool { return false },
+			RestartDelay: jworker.RestartDelay,
+		}),
 	}
-	if len(args.Sinks) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: o.loop,
+		Init: []worker.Worker{o.runner},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
+}
+
+func (o *orchestrator) loop() error {
+	configWatcher, err := o.args.LogForwardConfig.WatchForLogForwardConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := o.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	running := set.NewStrings()
+	for {
+		select {
+		case <-o.catacomb.Dying():
+			return o.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("log forward configuration watcher closed")
+			}
+			sinks, err := o.args.LogForwardConfig.LogForwardSinks()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			configured := set.NewStrings()
+			for _, cfg := range sinks {
+				configured.Add(cfg.Name)
+			}
+			for _, name := range running.Difference(configured).SortedValues() {
+				logger.Infof("stopping log forwarding to %q", name)
+				if err := o.runner.StopWorker(name); err != nil {
+					return errors.Trace(err)
+				}
+				running.Remove(name)
+			}
+			// Each forwarder watches the config for changes to its
+			// own sink, so only new sinks need to be started here.
+			for _, name := range configured.Difference(running).SortedValues() {
+				logger.Infof("starting log forwarding to %q", name)
+				if err := o.startForwarder(name); err != nil {
+					return errors.Trace(err)
+				}
+				running.Add(name)
+			}
+		}
 	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		ControllerUUID:   args.ControllerUUID,
-		LogForwardConfig: args.LogForwardConfig,
-		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
-		OpenLogStream:    args.OpenLogStream,
+}
+
+func (o *orchestrator) startForwarder(name string) error {
+	return o.runner.StartWorker(name, func() (worker.Worker, error) {
+		lf, err := o.args.OpenLogForwarder(OpenLogForwarderArgs{
+			ControllerUUID:   o.args.ControllerUUID,
+			LogForwardConfig: o.args.LogForwardConfig,
+			Caller:           o.args.Caller,
+			Name:             name,
+			OpenSink:         o.args.Sinks.Open,
+			OpenLogStream:    o.args.OpenLogStream,
+		})
+		if err != nil {
+			return nil, errors.Annotatef(err, "opening log forwarder for %q", name)
+		}
+		return lf, nil
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+}
+
+// Kill implements Worker.Kill()
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
 }
@@ -0,0 +1,91 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+type OrchestratorSuite struct {
+	testing.IsolationSuite
+
+	streams map[string]*stubStream
+	senders map[string]*stubSender
+}
+
+var _ = gc.Suite(&OrchestratorSuite{})
+
+func (s *OrchestratorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.streams = map[string]*stubStream{
+		"a": newStubStream(),
+		"b": newStubStream(),
+	}
+	s.senders = map[string]*stubSender{
+		"a": newStubSender(),
+		"b": newStubSender(),
+	}
+}
+
+func (s *OrchestratorSuite) orchestratorArgs(c *gc.C, api logforwarder.LogForwardConfig) logforwarder.OrchestratorArgs {
+	return logforwarder.OrchestratorArgs{
+		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		LogForwardConfig: api,
+		Caller:           &mockCaller{},
+		Sinks: logforwarder.SinkRegistry{
+			sinkconfig.TypeSyslog: func(cfg sinkconfig.Config) (*logforwarder.LogSink, error) {
+				sender := s.senders[cfg.Name]
+				sender.host = cfg.Syslog.Host
+				return &logforwarder.LogSink{sender}, nil
+			},
+		},
+		OpenLogStream: func(_ base.APICaller, cfg params.LogStreamConfig, _ string) (logforwarder.LogStream, error) {
+			return s.streams[cfg.Sink], nil
+		},
+		OpenLogForwarder: logforwarder.NewLogForwarder,
+	}
+}
+
+func (s *OrchestratorSuite) TestSinksForwardIndependently(c *gc.C) {
+	api := newMockLogForwardConfig(
+		syslogSinkConfig("a", "10.0.0.1"),
+		syslogSinkConfig("b", "10.0.0.2"),
+	)
+	s.senders["a"].unblock = make(chan struct{})
+	w, err := logforwarder.NewOrchestratorForController(s.orchestratorArgs(c, api))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	rec := logfwd.Record{
+		ID:      10,
+		Origin:  logfwd.Origin{ModelUUID: "deadbeef-2f18-4fd2-967d-db9663db7bea"},
+		Message: "hello",
+	}
+	s.streams["a"].addRecords(c, rec)
+	s.streams["b"].addRecords(c, rec)
+
+	// Sink "a" is stuck sending, but that doesn't stop
+	// records being forwarded to sink "b".
+	s.senders["b"].waitForSend(c)
+	close(s.senders["a"].unblock)
+	s.senders["a"].waitForSend(c)
+
+	// Removing sink "b" stops forwarding to it, leaving sink "a"
+	// alone.
+	api.setSinks(syslogSinkConfig("a", "10.0.0.1"))
+	s.senders["b"].waitForClose(c)
+
+	workertest.CleanKill(c, w)
+	s.senders["a"].stub.CheckCallNames(c, "Send", "Close")
+	s.senders["b"].stub.CheckCallNames(c, "Send", "Close")
+}
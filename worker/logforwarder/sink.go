@@ -4,7 +4,9 @@
 package logforwarder
 
 import (
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/sinkconfig"
 	"github.com/juju/juju/watcher"
 )
 
@@ -14,20 +16,27 @@ type LogForwardConfig interface {
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
-}
-
-type LogSinkSpec struct {
-	// Name is the name of the log sink.
-	Name string
-
-	// OpenFn is a function that opens a log sink.
-	OpenFn LogSinkFn
+	// LogForwardSinks returns the current configuration of each
+	// log forwarding sink.
+	LogForwardSinks() ([]sinkconfig.Config, error)
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg sinkconfig.Config) (*LogSink, error)
+
+// SinkRegistry holds the function that opens each type of log sink.
+type SinkRegistry map[sinkconfig.Type]LogSinkFn
+
+// Open opens a log sink using the function registered for the
+// sink's type.
+func (r SinkRegistry) Open(cfg sinkconfig.Config) (*LogSink, error) {
+	open, ok := r[cfg.Type]
+	if !ok {
+		return nil, errors.NotSupportedf("log sink type %q", cfg.Type)
+	}
+	sink, err := open(cfg)
+	return sink, errors.Trace(err)
+}
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
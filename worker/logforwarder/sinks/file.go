@@ -0,0 +1,78 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenFile returns a sink that appends log records to the file
+// <logDir>/forward/<modelUUID>/<name>.log on the controller. The file
+// is rotated when it grows too large.
+func OpenFile(logDir, modelUUID string, cfg sinkconfig.Config) (*logforwarder.LogSink, error) {
+	if err := cfg.File.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	path := filepath.Join(logDir, "forward", modelUUID, cfg.File.Name+".log")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Create the file up front so that it isn't readable by everyone.
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f.Close()
+	return &logforwarder.LogSink{
+		SendCloser: &fileSender{
+			writer: &lumberjack.Logger{
+				Filename:   path,
+				MaxSize:    300, // MB
+				MaxBackups: 2,
+				Compress:   true,
+			},
+		},
+	}, nil
+}
+
+type fileSender struct {
+	writer io.WriteCloser
+}
+
+// Send implements logforwarder.SendCloser.
+func (s *fileSender) Send(records []logfwd.Record) error {
+	// Each record is written in the same format as the
+	// controller's logsink.log.
+	var buf bytes.Buffer
+	for _, rec := range records {
+		buf.WriteString(strings.Join([]string{
+			rec.Origin.ModelUUID,
+			rec.Origin.EntityTag(),
+			rec.Timestamp.In(time.UTC).Format("2006-01-02 15:04:05"),
+			rec.Level.String(),
+			rec.Location.Module,
+			rec.Location.String(),
+			rec.Message,
+		}, " ") + "\n")
+	}
+	_, err := s.writer.Write(buf.Bytes())
+	return errors.Trace(err)
+}
+
+// Close implements logforwarder.SendCloser.
+func (s *fileSender) Close() error {
+	return errors.Trace(s.writer.Close())
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type FileSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FileSuite{})
+
+func (s *FileSuite) TestSend(c *gc.C) {
+	logDir := c.MkDir()
+	sink, err := sinks.OpenFile(logDir, "deadbeef-2f18-4fd2-967d-db9663db7bea", sinkconfig.Config{
+		Name: "test",
+		Type: sinkconfig.TypeFile,
+		File: sinkconfig.FileConfig{Name: "test"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	path := filepath.Join(logDir, "forward", "deadbeef-2f18-4fd2-967d-db9663db7bea", "test.log")
+
+	info, err := os.Stat(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	rec := testRecord(10)
+	err = sink.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+	rec.Message = "goodbye"
+	err = sink.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	prefix := "deadbeef-2f18-4fd2-967d-db9663db7bea unit-mysql-0 2019-03-04 05:06:07 WARNING juju.worker.uniter uniter.go:42 "
+	c.Check(string(data), gc.Equals, prefix+"hello\n"+prefix+"goodbye\n")
+}
+
+func (s *FileSuite) TestOpenInvalidName(c *gc.C) {
+	logDir := c.MkDir()
+	_, err := sinks.OpenFile(logDir, "deadbeef-2f18-4fd2-967d-db9663db7bea", sinkconfig.Config{
+		Name: "test",
+		Type: sinkconfig.TypeFile,
+		File: sinkconfig.FileConfig{Name: "../../escape"},
+	})
+	c.Assert(err, gc.ErrorMatches, `file name "../../escape" not valid`)
+	_, err = os.Stat(filepath.Join(logDir, "forward"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
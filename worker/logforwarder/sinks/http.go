@@ -0,0 +1,220 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// httpTimeout is how long an HTTP sink waits for a response before
+// treating the batch of records as failed.
+const httpTimeout = 30 * time.Second
+
+// OpenHTTP returns a sink that POSTs each batch of log records to a
+// remote log store, encoded as configured.
+func OpenHTTP(cfg sinkconfig.Config) (*logforwarder.LogSink, error) {
+	sender := &httpSender{
+		url:    cfg.HTTP.URL,
+		client: &http.Client{Timeout: httpTimeout},
+	}
+	switch cfg.HTTP.Format {
+	case sinkconfig.FormatElasticsearch:
+		sender.encode = encodeElasticsearch
+		sender.checkResponse = checkElasticsearchResponse
+	case sinkconfig.FormatLoki:
+		sender.encode = encodeLoki
+	default:
+		return nil, errors.NotSupportedf("HTTP log sink format %q", cfg.HTTP.Format)
+	}
+	if cfg.HTTP.CACert != "" {
+		caCert, err := cert.ParseCert(cfg.HTTP.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(caCert)
+		sender.client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs},
+		}
+	}
+	return &logforwarder.LogSink{
+		SendCloser: sender,
+	}, nil
+}
+
+type httpSender struct {
+	url    string
+	client *http.Client
+
+	// encode returns the request body and its content type.
+	encode func([]logfwd.Record) ([]byte, string, error)
+
+	// checkResponse, if set, checks the body of a successful
+	// response for errors.
+	checkResponse func(io.Reader) error
+}
+
+// Send implements logforwarder.SendCloser.
+func (s *httpSender) Send(records []logfwd.Record) error {
+	body, contentType, err := s.encode(records)
+	if err != nil {
+		return errors.Annotate(err, "encoding log records")
+	}
+	resp, err := s.client.Post(s.url, contentType, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	defer io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("sending log records: %s", resp.Status)
+	}
+	if s.checkResponse != nil {
+		return errors.Trace(s.checkResponse(resp.Body))
+	}
+	return nil
+}
+
+// Close implements logforwarder.SendCloser.
+func (s *httpSender) Close() error {
+	return nil
+}
+
+// elasticsearchDoc is the document indexed for each log record.
+type elasticsearchDoc struct {
+	Timestamp      time.Time `json:"@timestamp"`
+	ControllerUUID string    `json:"controller-uuid"`
+	ModelUUID      string    `json:"model-uuid"`
+	Entity         string    `json:"entity,omitempty"`
+	Level          string    `json:"level"`
+	Module         string    `json:"module,omitempty"`
+	Location       string    `json:"location,omitempty"`
+	Message        string    `json:"message"`
+}
+
+// encodeElasticsearch encodes the records as a bulk API request,
+// indexing each record as a document in the index named by the URL.
+func encodeElasticsearch(records []logfwd.Record) ([]byte, string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range records {
+		if _, err := buf.WriteString(`{"index":{}}` + "\n"); err != nil {
+			return nil, "", errors.Trace(err)
+		}
+		err := enc.Encode(elasticsearchDoc{
+			Timestamp:      rec.Timestamp.UTC(),
+			ControllerUUID: rec.Origin.ControllerUUID,
+			ModelUUID:      rec.Origin.ModelUUID,
+			Entity:         rec.Origin.EntityTag(),
+			Level:          rec.Level.String(),
+			Module:         rec.Location.Module,
+			Location:       rec.Location.String(),
+			Message:        rec.Message,
+		})
+		if err != nil {
+			return nil, "", errors.Trace(err)
+		}
+	}
+	return buf.Bytes(), "application/x-ndjson", nil
+}
+
+// checkElasticsearchResponse returns an error if any of the documents
+// in a bulk request failed to be indexed; the request as a whole
+// succeeds even if they do.
+func checkElasticsearchResponse(body io.Reader) error {
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Error *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return errors.Annotate(err, "decoding bulk response")
+	}
+	if !resp.Errors {
+		return nil
+	}
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				return errors.Errorf("indexing log record: %s: %s", result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+	return errors.New("indexing log records failed")
+}
+
+type lokiPush struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// encodeLoki encodes the records as a push API request. Records are
+// grouped into streams labelled by model, entity and level, keeping
+// the records in each stream in order.
+func encodeLoki(records []logfwd.Record) ([]byte, string, error) {
+	var push lokiPush
+	streams := make(map[[3]string]*lokiStream)
+	for _, rec := range records {
+		key := [3]string{rec.Origin.ModelUUID, rec.Origin.EntityTag(), strings.ToLower(rec.Level.String())}
+		stream, ok := streams[key]
+		if !ok {
+			stream = &lokiStream{
+				Stream: map[string]string{
+					"juju_model_uuid": key[0],
+					"juju_entity":     key[1],
+					"level":           key[2],
+				},
+			}
+			streams[key] = stream
+			push.Streams = append(push.Streams, stream)
+		}
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+			formatLine(rec),
+		})
+	}
+	body, err := json.Marshal(push)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return body, "application/json", nil
+}
+
+// formatLine formats the parts of a record that aren't otherwise
+// recorded as a single line of text.
+func formatLine(rec logfwd.Record) string {
+	var parts []string
+	for _, part := range []string{rec.Location.Module, rec.Location.String(), rec.Message} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
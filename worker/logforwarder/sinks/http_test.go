@@ -0,0 +1,154 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type HTTPSuite struct {
+	testing.IsolationSuite
+
+	server   *httptest.Server
+	requests chan *http.Request
+	bodies   chan string
+	response string
+	status   int
+}
+
+var _ = gc.Suite(&HTTPSuite{})
+
+func (s *HTTPSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = make(chan *http.Request, 1)
+	s.bodies = make(chan string, 1)
+	s.response = "{}"
+	s.status = http.StatusOK
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests <- req
+		s.bodies <- string(body)
+		w.WriteHeader(s.status)
+		w.Write([]byte(s.response))
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *HTTPSuite) open(c *gc.C, format sinkconfig.HTTPFormat) *logforwarder.LogSink {
+	sink, err := sinks.OpenHTTP(sinkconfig.Config{
+		Name: "test",
+		Type: sinkconfig.TypeHTTP,
+		HTTP: sinkconfig.HTTPConfig{
+			URL:    s.server.URL + "/push",
+			Format: format,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return sink
+}
+
+func (s *HTTPSuite) TestElasticsearch(c *gc.C) {
+	sink := s.open(c, sinkconfig.FormatElasticsearch)
+	err := sink.Send([]logfwd.Record{testRecord(10), testRecord(11)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	req := <-s.requests
+	c.Check(req.Method, gc.Equals, "POST")
+	c.Check(req.URL.Path, gc.Equals, "/push")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+	doc := `{"@timestamp":"2019-03-04T05:06:07Z","controller-uuid":"feebdaed-2f18-4fd2-967d-db9663db7bea",` +
+		`"model-uuid":"deadbeef-2f18-4fd2-967d-db9663db7bea","entity":"unit-mysql-0","level":"WARNING",` +
+		`"module":"juju.worker.uniter","location":"uniter.go:42","message":"hello"}`
+	c.Check(<-s.bodies, gc.Equals, `{"index":{}}`+"\n"+doc+"\n"+`{"index":{}}`+"\n"+doc+"\n")
+}
+
+func (s *HTTPSuite) TestElasticsearchItemErrors(c *gc.C) {
+	s.response = `{"errors":true,"items":[{"index":{"status":201}},` +
+		`{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}]}`
+	sink := s.open(c, sinkconfig.FormatElasticsearch)
+	err := sink.Send([]logfwd.Record{testRecord(10), testRecord(11)})
+	c.Assert(err, gc.ErrorMatches, "indexing log record: mapper_parsing_exception: failed to parse")
+}
+
+func (s *HTTPSuite) TestLoki(c *gc.C) {
+	sink := s.open(c, sinkconfig.FormatLoki)
+	rec0 := testRecord(10)
+	rec1 := testRecord(11)
+	rec1.Level = loggo.ERROR
+	rec2 := testRecord(12)
+	rec2.Timestamp = rec2.Timestamp.Add(time.Second)
+	err := sink.Send([]logfwd.Record{rec0, rec1, rec2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	req := <-s.requests
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+	var push struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][]string        `json:"values"`
+		} `json:"streams"`
+	}
+	err = json.Unmarshal([]byte(<-s.bodies), &push)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(push.Streams, gc.HasLen, 2)
+	labels := func(level string) map[string]string {
+		return map[string]string{
+			"juju_model_uuid": "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			"juju_entity":     "unit-mysql-0",
+			"level":           level,
+		}
+	}
+	line := "juju.worker.uniter uniter.go:42 hello"
+	c.Check(push.Streams[0].Stream, jc.DeepEquals, labels("warning"))
+	c.Check(push.Streams[0].Values, jc.DeepEquals, [][]string{
+		{"1551675967000000000", line},
+		{"1551675968000000000", line},
+	})
+	c.Check(push.Streams[1].Stream, jc.DeepEquals, labels("error"))
+	c.Check(push.Streams[1].Values, jc.DeepEquals, [][]string{
+		{"1551675967000000000", line},
+	})
+}
+
+func (s *HTTPSuite) TestErrorStatus(c *gc.C) {
+	s.status = http.StatusServiceUnavailable
+	sink := s.open(c, sinkconfig.FormatLoki)
+	err := sink.Send([]logfwd.Record{testRecord(10)})
+	c.Assert(err, gc.ErrorMatches, "sending log records: 503 Service Unavailable")
+}
+
+func testRecord(id int64) logfwd.Record {
+	return logfwd.Record{
+		ID: id,
+		Origin: logfwd.Origin{
+			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeUnit,
+			Name:           "mysql/0",
+		},
+		Timestamp: time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC),
+		Level:     loggo.WARNING,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.uniter",
+			Filename: "uniter.go",
+			Line:     42,
+		},
+		Message: "hello",
+	}
+}
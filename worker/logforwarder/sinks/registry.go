@@ -0,0 +1,22 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// Registry returns the functions that open each of the types of log
+// sink that logs may be forwarded to. File sinks for the model with
+// the given UUID are written under logDir.
+func Registry(logDir, modelUUID string) logforwarder.SinkRegistry {
+	return logforwarder.SinkRegistry{
+		sinkconfig.TypeSyslog: OpenSyslog,
+		sinkconfig.TypeHTTP:   OpenHTTP,
+		sinkconfig.TypeFile: func(cfg sinkconfig.Config) (*logforwarder.LogSink, error) {
+			return OpenFile(logDir, modelUUID, cfg)
+		},
+	}
+}
@@ -7,16 +7,17 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/worker/logforwarder"
 )
 
 // OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-	if !cfg.Enabled {
+func OpenSyslog(cfg sinkconfig.Config) (*logforwarder.LogSink, error) {
+	if !cfg.Syslog.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
-	client, err := syslog.Open(*cfg)
+	client, err := syslog.Open(cfg.Syslog)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
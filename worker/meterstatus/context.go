@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -78,6 +79,17 @@ func (ctx *limitedContext) Flush(_ string, err error) error {
 // HasExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 
+// HookTimeouts implements runner.Context.
+func (ctx *limitedContext) HookTimeouts() (time.Duration, time.Duration) { return 0, 0 }
+
+// Clock implements runner.Context.
+func (ctx *limitedContext) Clock() context.Clock { return clock.WallClock }
+
+// SetExecutingStatus implements runner.Context.
+func (ctx *limitedContext) SetExecutingStatus(message string) error {
+	return jujuc.ErrRestrictedContext
+}
+
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/worker/metrics/spool"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -85,6 +86,17 @@ func (ctx *hookContext) ActionData() (*context.ActionData, error) {
 // HasExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 
+// HookTimeouts implements runner.Context.
+func (ctx *hookContext) HookTimeouts() (time.Duration, time.Duration) { return 0, 0 }
+
+// Clock implements runner.Context.
+func (ctx *hookContext) Clock() context.Clock { return clock.WallClock }
+
+// SetExecutingStatus implements runner.Context.
+func (ctx *hookContext) SetExecutingStatus(message string) error {
+	return jujuc.ErrRestrictedContext
+}
+
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 
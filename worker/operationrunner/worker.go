@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationrunner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/api/operationrunner"
+	"github.com/juju/juju/watcher"
+)
+
+const (
+	// pollPeriod is how often operations are advanced while any of
+	// them have actions running. Actions finishing doesn't change the
+	// operations themselves, so the watcher won't notice.
+	pollPeriod = 5 * time.Second
+
+	// idlePeriod is how often operations are advanced when none are
+	// running, in case an earlier attempt failed.
+	idlePeriod = time.Minute
+)
+
+var logger = loggo.GetLogger("juju.worker.operationrunner")
+
+// Facade exposes the controller functionality needed to run
+// operations.
+type Facade interface {
+	AdvanceOperations() (operationrunner.Progress, error)
+	WatchOperations() (watcher.NotifyWatcher, error)
+}
+
+// Runner is a worker that enqueues the actions of each operation in
+// the model as the operation's batch size and parallelism limits
+// allow.
+type Runner struct {
+	catacomb catacomb.Catacomb
+	facade   Facade
+	watcher  watcher.NotifyWatcher
+	clock    clock.Clock
+}
+
+// NewRunner returns a worker.Worker that advances the model's
+// operations whenever they change, periodically while any are running,
+// and when a scheduled operation is due to start.
+func NewRunner(facade Facade, clock clock.Clock) (worker.Worker, error) {
+	watcher, err := facade.WatchOperations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := &Runner{
+		facade:  facade,
+		watcher: watcher,
+		clock:   clock,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &r.catacomb,
+		Work: r.loop,
+		Init: []worker.Worker{watcher},
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r, nil
+}
+
+func (r *Runner) loop() error {
+	timer := r.clock.NewTimer(idlePeriod)
+	defer timer.Stop()
+	for {
+		select {
+		case <-r.catacomb.Dying():
+			return r.catacomb.ErrDying()
+		case _, ok := <-r.watcher.Changes():
+			if !ok {
+				return errors.New("change channel closed")
+			}
+		case <-timer.Chan():
+		}
+		progress, err := r.facade.AdvanceOperations()
+		if err != nil {
+			// Failing to advance the operations isn't fatal;
+			// try again shortly.
+			logger.Errorf("cannot advance operations: %v", err)
+			timer.Reset(pollPeriod)
+			continue
+		}
+		timer.Reset(r.nextWait(progress))
+	}
+}
+
+// nextWait returns how long to wait before advancing the operations
+// again, given their current progress.
+func (r *Runner) nextWait(progress operationrunner.Progress) time.Duration {
+	wait := idlePeriod
+	if progress.Running > 0 {
+		wait = pollPeriod
+	}
+	if !progress.NextScheduled.IsZero() {
+		if untilDue := progress.NextScheduled.Sub(r.clock.Now()); untilDue < wait {
+			wait = untilDue
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// Kill is part of the worker.Worker interface.
+func (r *Runner) Kill() {
+	r.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (r *Runner) Wait() error {
+	return r.catacomb.Wait()
+}
@@ -0,0 +1,187 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationrunner_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	apioperationrunner "github.com/juju/juju/api/operationrunner"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/operationrunner"
+)
+
+type RunnerSuite struct {
+	coretesting.BaseSuite
+	facade *mockFacade
+	clock  *testing.Clock
+}
+
+var _ = gc.Suite(&RunnerSuite{})
+
+func (s *RunnerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &mockFacade{
+		calls: make(chan string, 1),
+	}
+	s.facade.watcher = s.newMockNotifyWatcher()
+}
+
+func (s *RunnerSuite) AssertReceived(c *gc.C, expect string) {
+	select {
+	case call := <-s.facade.calls:
+		c.Assert(call, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %s", expect)
+	}
+}
+
+func (s *RunnerSuite) AssertEmpty(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *RunnerSuite) startRunner(c *gc.C) worker.Worker {
+	w, err := operationrunner.NewRunner(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		c.Check(worker.Stop(w), jc.ErrorIsNil)
+	})
+	s.AssertReceived(c, "WatchOperations")
+	return w
+}
+
+func (s *RunnerSuite) TestAdvancesOnChange(c *gc.C) {
+	s.startRunner(c)
+	s.AssertReceived(c, "AdvanceOperations")
+	s.AssertEmpty(c)
+
+	s.facade.watcher.Change()
+	s.AssertReceived(c, "AdvanceOperations")
+	s.AssertEmpty(c)
+}
+
+func (s *RunnerSuite) TestPollsWhileRunning(c *gc.C) {
+	s.facade.progress = []apioperationrunner.Progress{{Running: 1}, {}}
+	s.startRunner(c)
+	s.AssertReceived(c, "AdvanceOperations")
+
+	s.clock.WaitAdvance(4*time.Second, coretesting.LongWait, 1)
+	s.AssertEmpty(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.AssertReceived(c, "AdvanceOperations")
+
+	// Nothing is running now, so the runner waits for longer.
+	s.clock.WaitAdvance(59*time.Second, coretesting.LongWait, 1)
+	s.AssertEmpty(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.AssertReceived(c, "AdvanceOperations")
+}
+
+func (s *RunnerSuite) TestWakesForScheduledOperation(c *gc.C) {
+	s.facade.progress = []apioperationrunner.Progress{{
+		NextScheduled: s.clock.Now().Add(20 * time.Second),
+	}}
+	s.startRunner(c)
+	s.AssertReceived(c, "AdvanceOperations")
+
+	s.clock.WaitAdvance(19*time.Second, coretesting.LongWait, 1)
+	s.AssertEmpty(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.AssertReceived(c, "AdvanceOperations")
+}
+
+func (s *RunnerSuite) TestWatchOperationsError(c *gc.C) {
+	s.facade.err = []error{errors.New("boom")}
+	_, err := operationrunner.NewRunner(s.facade, s.clock)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.AssertReceived(c, "WatchOperations")
+}
+
+func (s *RunnerSuite) TestAdvanceOperationsErrorRetries(c *gc.C) {
+	s.facade.err = []error{nil, errors.New("boom")}
+	s.startRunner(c)
+	s.AssertReceived(c, "AdvanceOperations")
+
+	s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	s.AssertReceived(c, "AdvanceOperations")
+	c.Assert(c.GetTestLog(), jc.Contains, "ERROR juju.worker.operationrunner cannot advance operations: boom")
+}
+
+func (s *RunnerSuite) newMockNotifyWatcher() *mockNotifyWatcher {
+	m := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	m.tomb.Go(func() error {
+		<-m.tomb.Dying()
+		return nil
+	})
+	s.AddCleanup(func(c *gc.C) {
+		c.Check(worker.Stop(m), jc.ErrorIsNil)
+	})
+	m.Change()
+	return m
+}
+
+type mockNotifyWatcher struct {
+	watcher.NotifyWatcher
+
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func (m *mockNotifyWatcher) Kill() {
+	m.tomb.Kill(nil)
+}
+
+func (m *mockNotifyWatcher) Wait() error {
+	return m.tomb.Wait()
+}
+
+func (m *mockNotifyWatcher) Changes() watcher.NotifyChannel {
+	return m.changes
+}
+
+func (m *mockNotifyWatcher) Change() {
+	m.changes <- struct{}{}
+}
+
+type mockFacade struct {
+	watcher  *mockNotifyWatcher
+	calls    chan string
+	err      []error
+	progress []apioperationrunner.Progress
+}
+
+func (m *mockFacade) nextError() (err error) {
+	if len(m.err) > 0 {
+		err, m.err = m.err[0], m.err[1:]
+	}
+	return err
+}
+
+func (m *mockFacade) AdvanceOperations() (apioperationrunner.Progress, error) {
+	m.calls <- "AdvanceOperations"
+	var progress apioperationrunner.Progress
+	if len(m.progress) > 0 {
+		progress, m.progress = m.progress[0], m.progress[1:]
+	}
+	return progress, m.nextError()
+}
+
+func (m *mockFacade) WatchOperations() (watcher.NotifyWatcher, error) {
+	m.calls <- "WatchOperations"
+	return m.watcher, m.nextError()
+}
@@ -635,6 +635,7 @@ func (task *provisionerTask) constructStartInstanceParams(
 				},
 				Volume: volumeTag,
 			},
+			v.SnapshotId,
 		}
 	}
 	volumeAttachments := make([]storage.VolumeAttachmentParams, len(provisioningInfo.VolumeAttachments))
@@ -0,0 +1,100 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/common"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a worker for
+// applying forwarded lease commands in a dependency.Engine.
+type ManifoldConfig struct {
+	RaftName       string
+	CentralHubName string
+	StateName      string
+
+	ApplyTimeout time.Duration
+	Logger       loggo.Logger
+	NewWorker    func(Config) (worker.Worker, error)
+	NewTarget    func(*state.State, loggo.Logger) raftlease.NotifyTarget
+}
+
+// Validate checks that the config has all the required values.
+func (config ManifoldConfig) Validate() error {
+	if config.RaftName == "" {
+		return errors.NotValidf("empty RaftName")
+	}
+	if config.CentralHubName == "" {
+		return errors.NotValidf("empty CentralHubName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	if config.NewTarget == nil {
+		return errors.NotValidf("nil NewTarget")
+	}
+	return nil
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var r *raft.Raft
+	if err := context.Get(config.RaftName, &r); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var hub *pubsub.StructuredHub
+	if err := context.Get(config.CentralHubName, &hub); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := config.NewWorker(Config{
+		Raft:         r,
+		Hub:          hub,
+		Logger:       config.Logger,
+		ApplyTimeout: config.ApplyTimeout,
+		Target:       config.NewTarget(statePool.SystemState(), config.Logger),
+	})
+	if err != nil {
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, func() { stTracker.Done() }), nil
+}
+
+// Manifold returns a dependency.Manifold for running a raftforwarder
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.RaftName,
+			config.CentralHubName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
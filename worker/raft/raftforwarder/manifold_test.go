@@ -0,0 +1,158 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder_test
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/raft/raftforwarder"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+
+	manifold dependency.Manifold
+	context  dependency.Context
+	raft     *raft.Raft
+	hub      *pubsub.StructuredHub
+	logger   loggo.Logger
+	worker   worker.Worker
+	target   *fakeTarget
+	stub     testing.Stub
+
+	stateTracker *stubStateTracker
+	pool         state.StatePool
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.raft = &raft.Raft{}
+	s.hub = &pubsub.StructuredHub{}
+	s.stub.ResetCalls()
+
+	s.worker = &mockWorker{}
+	s.target = &fakeTarget{}
+	s.stateTracker = &stubStateTracker{pool: &s.pool}
+	s.logger = loggo.GetLogger("raftforwarder_test")
+
+	s.context = s.newContext(nil)
+	s.manifold = raftforwarder.Manifold(raftforwarder.ManifoldConfig{
+		RaftName:       "raft",
+		CentralHubName: "central-hub",
+		StateName:      "state",
+		ApplyTimeout:   time.Second,
+		Logger:         s.logger,
+		NewWorker:      s.newWorker,
+		NewTarget:      s.newTarget,
+	})
+}
+
+func (s *manifoldSuite) newContext(overlay map[string]interface{}) dependency.Context {
+	resources := map[string]interface{}{
+		"raft":        s.raft,
+		"central-hub": s.hub,
+		"state":       s.stateTracker,
+	}
+	for k, v := range overlay {
+		resources[k] = v
+	}
+	return dt.StubContext(nil, resources)
+}
+
+func (s *manifoldSuite) newWorker(config raftforwarder.Config) (worker.Worker, error) {
+	s.stub.MethodCall(s, "NewWorker", config)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.worker, nil
+}
+
+func (s *manifoldSuite) newTarget(st *state.State, logger loggo.Logger) raftlease.NotifyTarget {
+	s.stub.MethodCall(s, "NewTarget", st, logger)
+	return s.target
+}
+
+var expectedInputs = []string{"raft", "central-hub", "state"}
+
+func (s *manifoldSuite) TestInputs(c *gc.C) {
+	c.Assert(s.manifold.Inputs, jc.SameContents, expectedInputs)
+}
+
+func (s *manifoldSuite) TestMissingInputs(c *gc.C) {
+	for _, input := range expectedInputs {
+		context := s.newContext(map[string]interface{}{
+			input: dependency.ErrMissing,
+		})
+		_, err := s.manifold.Start(context)
+		c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	}
+}
+
+func (s *manifoldSuite) TestStart(c *gc.C) {
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "NewTarget", "NewWorker")
+	s.stub.CheckCall(c, 0, "NewTarget", s.pool.SystemState(), s.logger)
+	config := s.stub.Calls()[1].Args[0].(raftforwarder.Config)
+	c.Assert(config, jc.DeepEquals, raftforwarder.Config{
+		Raft:         s.raft,
+		Hub:          s.hub,
+		Logger:       s.logger,
+		ApplyTimeout: time.Second,
+		Target:       s.target,
+	})
+
+	// The state is released when the worker stops.
+	s.stateTracker.CheckCallNames(c, "Use")
+	w.Kill()
+	c.Assert(w.Wait(), jc.ErrorIsNil)
+	s.stateTracker.CheckCallNames(c, "Use", "Done")
+}
+
+func (s *manifoldSuite) TestStartError(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	_, err := s.manifold.Start(s.context)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.stateTracker.CheckCallNames(c, "Use", "Done")
+}
+
+type mockWorker struct{}
+
+func (*mockWorker) Kill() {}
+
+func (*mockWorker) Wait() error {
+	return nil
+}
+
+type stubStateTracker struct {
+	testing.Stub
+	pool *state.StatePool
+}
+
+func (s *stubStateTracker) Use() (*state.StatePool, error) {
+	s.MethodCall(s, "Use")
+	return s.pool, s.NextErr()
+}
+
+func (s *stubStateTracker) Done() error {
+	s.MethodCall(s, "Done")
+	return s.NextErr()
+}
@@ -0,0 +1,135 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/raftlease"
+)
+
+// Logger represents the logging methods called.
+type Logger interface {
+	Debugf(message string, args ...interface{})
+	Warningf(message string, args ...interface{})
+}
+
+// This worker runs on the raft leader, applying the lease commands
+// forwarded by lease stores on the other controllers and publishing
+// the results back to them.
+
+// Config holds the values needed by the worker.
+type Config struct {
+	Raft         raftlease.RaftApplier
+	Hub          *pubsub.StructuredHub
+	Logger       Logger
+	ApplyTimeout time.Duration
+
+	// Target is told about changes to lease holders made by the
+	// forwarded commands.
+	Target raftlease.NotifyTarget
+}
+
+// Validate validates the raft forwarder configuration.
+func (config Config) Validate() error {
+	if config.Raft == nil {
+		return errors.NotValidf("nil Raft")
+	}
+	if config.Hub == nil {
+		return errors.NotValidf("nil Hub")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.ApplyTimeout <= 0 {
+		return errors.NotValidf("non-positive ApplyTimeout")
+	}
+	if config.Target == nil {
+		return errors.NotValidf("nil Target")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that applies forwarded lease commands
+// to raft.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &forwarder{
+		config:   config,
+		requests: make(chan raftlease.ForwardRequest),
+	}
+	unsubscribe, err := config.Hub.Subscribe(raftlease.RequestTopic, w.handleRequest)
+	if err != nil {
+		return nil, errors.Annotate(err, "subscribing to lease requests")
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: func() error {
+			defer unsubscribe()
+			return w.loop()
+		},
+	}); err != nil {
+		unsubscribe()
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type forwarder struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	requests chan raftlease.ForwardRequest
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *forwarder) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *forwarder) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *forwarder) handleRequest(_ string, req raftlease.ForwardRequest, err error) {
+	if err != nil {
+		w.config.Logger.Warningf("unable to handle lease request: %v", err)
+		return
+	}
+	select {
+	case <-w.catacomb.Dying():
+	case w.requests <- req:
+	}
+}
+
+func (w *forwarder) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case req := <-w.requests:
+			if err := w.apply(req); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *forwarder) apply(req raftlease.ForwardRequest) error {
+	err := raftlease.ApplyCommand(w.config.Raft, []byte(req.Command), w.config.ApplyTimeout, w.config.Target)
+	if err != nil {
+		w.config.Logger.Debugf("forwarded lease command failed: %v", err)
+	}
+	_, err = w.config.Hub.Publish(req.ResponseTopic, raftlease.ForwardResponse{
+		Error: raftlease.AsResponseError(err),
+	})
+	return errors.Annotate(err, "publishing lease response")
+}
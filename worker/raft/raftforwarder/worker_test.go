@@ -0,0 +1,190 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder_test
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/pubsub/centralhub"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/raft/raftforwarder"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+	fsm    *raftlease.FSM
+	raft   *fakeRaft
+	hub    *pubsub.StructuredHub
+	target *fakeTarget
+	config raftforwarder.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.fsm = raftlease.NewFSM()
+	s.raft = &fakeRaft{fsm: s.fsm}
+	s.hub = centralhub.New(names.NewMachineTag("0"))
+	s.target = &fakeTarget{}
+	s.config = raftforwarder.Config{
+		Raft:         s.raft,
+		Hub:          s.hub,
+		Logger:       loggo.GetLogger("raftforwarder_test"),
+		ApplyTimeout: time.Second,
+		Target:       s.target,
+	}
+}
+
+func (s *workerSuite) TestValidateErrors(c *gc.C) {
+	type test struct {
+		f      func(*raftforwarder.Config)
+		expect string
+	}
+	tests := []test{{
+		func(cfg *raftforwarder.Config) { cfg.Raft = nil },
+		"nil Raft not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.Hub = nil },
+		"nil Hub not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.ApplyTimeout = 0 },
+		"non-positive ApplyTimeout not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.Target = nil },
+		"nil Target not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test #%d (%s)", i, test.expect)
+		config := s.config
+		test.f(&config)
+		w, err := raftforwarder.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(w, gc.IsNil)
+	}
+}
+
+func (s *workerSuite) TestAppliesForwardedCommands(c *gc.C) {
+	w, err := raftforwarder.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	responses := make(chan raftlease.ForwardResponse, 2)
+	unsubscribe, err := s.hub.Subscribe("lease.response.test",
+		func(_ string, resp raftlease.ForwardResponse, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			responses <- resp
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	command := claimCommand(c, "penfold")
+	s.publish(c, command)
+	resp := s.nextResponse(c, responses)
+	c.Assert(resp.Error, gc.IsNil)
+	c.Assert(s.raft.applied, gc.Equals, 1)
+
+	// A second claim is invalid; the error is sent back.
+	s.publish(c, claimCommand(c, "baron"))
+	resp = s.nextResponse(c, responses)
+	c.Assert(resp.Error.AsError(), gc.Equals, lease.ErrInvalid)
+
+	// Only the successful claim changed the holder.
+	s.target.CheckCalls(c, []testing.StubCall{{
+		"Claimed", []interface{}{
+			lease.Key{Namespace: "singular-controller", ModelUUID: "model-uuid", Lease: "lease"},
+			"penfold",
+			uint64(1),
+		},
+	}})
+}
+
+func (s *workerSuite) publish(c *gc.C, command string) {
+	_, err := s.hub.Publish(raftlease.RequestTopic, raftlease.ForwardRequest{
+		Command:       command,
+		ResponseTopic: "lease.response.test",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) nextResponse(c *gc.C, responses <-chan raftlease.ForwardResponse) raftlease.ForwardResponse {
+	select {
+	case resp := <-responses:
+		return resp
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for lease response")
+	}
+	panic("unreachable")
+}
+
+func claimCommand(c *gc.C, holder string) string {
+	command := raftlease.Command{
+		Version:   raftlease.CommandVersion,
+		Operation: raftlease.OperationClaim,
+		Namespace: "singular-controller",
+		ModelUUID: "model-uuid",
+		Lease:     "lease",
+		Holder:    holder,
+		Duration:  time.Minute,
+	}
+	data, err := command.Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
+
+// fakeRaft applies commands directly to an FSM, as a single-node
+// raft cluster would.
+type fakeRaft struct {
+	fsm     *raftlease.FSM
+	applied int
+}
+
+func (r *fakeRaft) Apply(cmd []byte, timeout time.Duration) raft.ApplyFuture {
+	r.applied++
+	response := r.fsm.Apply(&raft.Log{
+		Index: uint64(r.applied),
+		Data:  cmd,
+	})
+	return &fakeApplyFuture{response: response}
+}
+
+type fakeApplyFuture struct {
+	raft.ApplyFuture
+	response interface{}
+}
+
+func (f *fakeApplyFuture) Error() error {
+	return nil
+}
+
+func (f *fakeApplyFuture) Response() interface{} {
+	return f.response
+}
+
+type fakeTarget struct {
+	testing.Stub
+}
+
+func (t *fakeTarget) Claimed(key lease.Key, holder string, index uint64) {
+	t.AddCall("Claimed", key, holder, index)
+}
+
+func (t *fakeTarget) Expired(key lease.Key, index uint64) {
+	t.AddCall("Expired", key, index)
+}
@@ -57,6 +57,24 @@ func machineBlockDevicesChanged(ctx *context) error {
 			volumeTags = append(volumeTags, filesystem.Volume)
 		}
 	}
+	// Refresh the block devices backing provisioned filesystems too,
+	// so that the filesystems may be grown when the volumes are.
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume == (names.VolumeTag{}) {
+			// Filesystem is not volume-backed.
+			continue
+		}
+		var found bool
+		for _, tag := range volumeTags {
+			if filesystem.Volume == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			volumeTags = append(volumeTags, filesystem.Volume)
+		}
+	}
 	if len(volumeTags) == 0 {
 		return nil
 	}
@@ -118,6 +136,7 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 					updatePendingFilesystemAttachment(ctx, id, params)
 				}
 			}
+			maybeResizeFilesystems(ctx, volumeTags[i])
 		} else if params.IsCodeNotProvisioned(result.Error) || params.IsCodeNotFound(result.Error) {
 			// Either the volume (attachment) isn't provisioned,
 			// or the corresponding block device is not yet known.
@@ -133,3 +152,26 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 	}
 	return nil
 }
+
+// maybeResizeFilesystems schedules the growing of any provisioned
+// filesystems backed by the specified volume, whose block device has
+// grown larger than the filesystem.
+func maybeResizeFilesystems(ctx *context, volumeTag names.VolumeTag) {
+	blockDevice, ok := ctx.volumeBlockDevices[volumeTag]
+	if !ok {
+		return
+	}
+	for tag, filesystem := range ctx.filesystems {
+		if filesystem.Volume != volumeTag || filesystem.Size >= blockDevice.Size {
+			continue
+		}
+		logger.Debugf(
+			"block device for %s has grown to %dMiB, resizing %s",
+			names.ReadableString(volumeTag), blockDevice.Size,
+			names.ReadableString(tag),
+		)
+		key := resizeFilesystemKey{tag}
+		ctx.schedule.Remove(key)
+		scheduleOperations(ctx, &resizeFilesystemOp{tag: tag})
+	}
+}
@@ -117,6 +117,15 @@ func processDyingFilesystems(ctx *context, tags []names.FilesystemTag, filesyste
 
 func updateFilesystem(ctx *context, info storage.Filesystem) {
 	ctx.filesystems[info.Tag] = info
+	if _, ok := ctx.config.Scope.(names.MachineTag); ok && info.Volume != (names.VolumeTag{}) {
+		// The filesystem is volume-backed: force a one-time
+		// update of the block device, so that we can tell
+		// whether the volume has grown since the filesystem
+		// was last resized.
+		if _, ok := ctx.volumeBlockDevices[info.Volume]; !ok {
+			ctx.pendingVolumeBlockDevices.Add(info.Volume)
+		}
+	}
 	for id, params := range ctx.incompleteFilesystemAttachmentParams {
 		if params.FilesystemId == "" && id.AttachmentTag == info.Tag.String() {
 			updatePendingFilesystemAttachment(ctx, id, params)
@@ -488,6 +497,7 @@ func filesystemParamsFromParams(in params.FilesystemParams) (storage.FilesystemP
 		Provider:     providerType,
 		Attributes:   in.Attributes,
 		ResourceTags: in.Tags,
+		FromSnapshot: in.FromSnapshot,
 	}, nil
 }
 
@@ -115,6 +115,97 @@ func createFilesystems(ctx *context, ops map[names.FilesystemTag]*createFilesyst
 	return nil
 }
 
+// resizeFilesystems grows the volume-backed filesystems with the
+// specified tags to fill their backing volumes.
+func resizeFilesystems(ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp) error {
+	filesystemResizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		return nil
+	}
+	args := make([]storage.FilesystemResizeParams, 0, len(ops))
+	for tag := range ops {
+		filesystem, ok := ctx.filesystems[tag]
+		if !ok {
+			continue
+		}
+		args = append(args, storage.FilesystemResizeParams{
+			Tag:          tag,
+			FilesystemId: filesystem.FilesystemId,
+		})
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	logger.Debugf("resizing filesystems: %v", args)
+	results, err := filesystemResizer.ResizeFilesystems(ctx.config.CloudCallContext, args)
+	if err != nil {
+		return errors.Annotate(err, "resizing filesystems")
+	}
+	var reschedule []scheduleOp
+	var resized []names.FilesystemTag
+	sizes := make(map[names.FilesystemTag]uint64)
+	for i, result := range results {
+		tag := args[i].Tag
+		if result.Error != nil {
+			// Reschedule the filesystem resize.
+			reschedule = append(reschedule, ops[tag])
+			logger.Warningf(
+				"failed to resize %s: %v",
+				names.ReadableString(tag),
+				result.Error,
+			)
+			continue
+		}
+		resized = append(resized, tag)
+		sizes[tag] = result.Size
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(resized) == 0 {
+		return nil
+	}
+
+	// Record the new sizes in state, keeping the
+	// remainder of each filesystem's info intact.
+	filesystemResults, err := ctx.config.Filesystems.Filesystems(resized)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem information")
+	}
+	filesystems := make([]params.Filesystem, 0, len(resized))
+	for i, result := range filesystemResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"getting information for resized filesystem %s: %v",
+				resized[i].Id(),
+				result.Error,
+			)
+			continue
+		}
+		filesystem := result.Result
+		filesystem.Info.Size = sizes[resized[i]]
+		filesystems = append(filesystems, filesystem)
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystems)
+	if err != nil {
+		return errors.Annotate(err, "publishing filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing filesystem %s to state: %v",
+				filesystems[i].FilesystemTag,
+				result.Error,
+			)
+			continue
+		}
+		filesystem, err := filesystemFromParams(filesystems[i])
+		if err != nil {
+			return errors.Annotate(err, "getting filesystem info")
+		}
+		updateFilesystem(ctx, filesystem)
+	}
+	return nil
+}
+
 // attachFilesystems creates filesystem attachments with the specified parameters.
 func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFilesystemOp) error {
 	filesystemAttachmentParams := make([]storage.FilesystemAttachmentParams, 0, len(ops))
@@ -544,6 +635,22 @@ func (op *removeFilesystemOp) key() interface{} {
 	return op.tag
 }
 
+type resizeFilesystemOp struct {
+	exponentialBackoff
+	tag names.FilesystemTag
+}
+
+// resizeFilesystemKey is the schedule key for a resizeFilesystemOp.
+// Resizes are keyed separately from other operations on the same
+// filesystem.
+type resizeFilesystemKey struct {
+	tag names.FilesystemTag
+}
+
+func (op *resizeFilesystemOp) key() interface{} {
+	return resizeFilesystemKey{op.tag}
+}
+
 type attachFilesystemOp struct {
 	exponentialBackoff
 	args storage.FilesystemAttachmentParams
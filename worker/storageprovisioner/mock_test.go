@@ -121,15 +121,21 @@ func (w *mockAttachmentsWatcher) Changes() watcher.MachineStorageIdsChannel {
 
 type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
+	volumeResizesWatcher   *mockStringsWatcher
+	volumeSnapshotsWatcher *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	requestedSizes         map[string]uint64
+	volumeSnapshots        map[string]params.VolumeSnapshotParams
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeSnapshotInfo   func([]params.VolumeSnapshotInfo) ([]params.ErrorResult, error)
+	removeVolumeSnapshots   func([]string) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -147,6 +153,14 @@ func (w *mockVolumeAccessor) WatchVolumes(names.Tag) (watcher.StringsWatcher, er
 	return w.volumesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes(names.Tag) (watcher.StringsWatcher, error) {
+	return w.volumeResizesWatcher, nil
+}
+
+func (w *mockVolumeAccessor) WatchVolumeSnapshots(names.Tag) (watcher.StringsWatcher, error) {
+	return w.volumeSnapshotsWatcher, nil
+}
+
 func (w *mockVolumeAccessor) WatchVolumeAttachments(names.Tag) (watcher.MachineStorageIdsWatcher, error) {
 	return w.attachmentsWatcher, nil
 }
@@ -245,6 +259,38 @@ func (v *mockVolumeAccessor) RemoveVolumeParams(volumes []names.VolumeTag) ([]pa
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) ResizeVolumeParams(volumes []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	var result []params.ResizeVolumeParamsResult
+	for _, tag := range volumes {
+		vol, ok := v.provisionedVolumes[tag.String()]
+		size := v.requestedSizes[tag.String()]
+		if !ok || vol.Info.Size >= size {
+			result = append(result, params.ResizeVolumeParamsResult{})
+			continue
+		}
+		result = append(result, params.ResizeVolumeParamsResult{Result: &params.ResizeVolumeParams{
+			VolumeTag: tag.String(),
+			Provider:  "dummy",
+			VolumeId:  vol.Info.VolumeId,
+			Size:      size,
+		}})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	var result []params.VolumeSnapshotParamsResult
+	for _, id := range ids {
+		snapshot, ok := v.volumeSnapshots[id]
+		if !ok {
+			result = append(result, params.VolumeSnapshotParamsResult{})
+			continue
+		}
+		result = append(result, params.VolumeSnapshotParamsResult{Result: &snapshot})
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error) {
 	var result []params.VolumeAttachmentParamsResult
 	for _, id := range ids {
@@ -276,15 +322,33 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 	return make([]params.ErrorResult, len(volumeAttachments)), nil
 }
 
+func (v *mockVolumeAccessor) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshotInfo) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotInfo != nil {
+		return v.setVolumeSnapshotInfo(snapshots)
+	}
+	return make([]params.ErrorResult, len(snapshots)), nil
+}
+
+func (v *mockVolumeAccessor) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if v.removeVolumeSnapshots != nil {
+		return v.removeVolumeSnapshots(ids)
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
+
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
+		volumeResizesWatcher:   newMockStringsWatcher(),
+		volumeSnapshotsWatcher: newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		requestedSizes:         make(map[string]uint64),
+		volumeSnapshots:        make(map[string]params.VolumeSnapshotParams),
 	}
 }
 
@@ -508,6 +572,9 @@ type dummyProvider struct {
 	attachVolumesFunc            func([]storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	attachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error)
 	detachVolumesFunc            func([]storage.VolumeAttachmentParams) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+	createSnapshotsFunc          func([]storage.VolumeSnapshotParams) ([]storage.CreateSnapshotsResult, error)
+	destroySnapshotsFunc         func([]string) ([]error, error)
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
 	releaseVolumesFunc           func([]string) ([]error, error)
@@ -630,6 +697,41 @@ func (s *dummyVolumeSource) DetachVolumes(ctx context.ProviderCallContext, param
 	return make([]error, len(params)), nil
 }
 
+// ResizeVolumes grows volumes to their requested size.
+func (s *dummyVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Size = p.Size
+	}
+	return results, nil
+}
+
+// CreateSnapshots takes snapshots of volumes.
+func (s *dummyVolumeSource) CreateSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	if s.provider.createSnapshotsFunc != nil {
+		return s.provider.createSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].Snapshot = &storage.VolumeSnapshotInfo{
+			SnapshotId: "snap-" + p.VolumeId,
+			Size:       p.Size,
+		}
+	}
+	return results, nil
+}
+
+// DestroySnapshots destroys volume snapshots.
+func (s *dummyVolumeSource) DestroySnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	if s.provider.destroySnapshotsFunc != nil {
+		return s.provider.destroySnapshotsFunc(snapshotIds)
+	}
+	return make([]error, len(snapshotIds)), nil
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
@@ -780,6 +882,24 @@ func (s *mockManagedFilesystemSource) DetachFilesystems(ctx context.ProviderCall
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, ok := s.filesystems[arg.Tag]
+		if !ok {
+			results[i].Error = errors.Errorf("filesystem %v has not been created", arg.Tag.Id())
+			continue
+		}
+		blockDevice, ok := s.blockDevices[filesystem.Volume]
+		if !ok {
+			results[i].Error = errors.Errorf("filesystem %v's backing-volume is not attached", arg.Tag.Id())
+			continue
+		}
+		results[i].Size = blockDevice.Size
+	}
+	return results, nil
+}
+
 type mockMachineAccessor struct {
 	instanceIds map[names.MachineTag]instance.Id
 	watcher     *mockNotifyWatcher
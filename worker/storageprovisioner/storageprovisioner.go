@@ -55,6 +55,15 @@ type VolumeAccessor interface {
 	// provisioner is responsible for.
 	WatchVolumes(scope names.Tag) (watcher.StringsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, so that volumes that
+	// have been requested to be resized may be identified.
+	WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// WatchVolumeSnapshots watches for changes to volume snapshots
+	// that this storage provisioner is responsible for.
+	WatchVolumeSnapshots(scope names.Tag) (watcher.StringsWatcher, error)
+
 	// WatchVolumeAttachments watches for changes to volume attachments
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments(scope names.Tag) (watcher.MachineStorageIdsWatcher, error)
@@ -78,6 +87,14 @@ type VolumeAccessor interface {
 	// releasing the volumes with the specified tags.
 	RemoveVolumeParams([]names.VolumeTag) ([]params.RemoveVolumeParamsResult, error)
 
+	// ResizeVolumeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	ResizeVolumeParams([]names.VolumeTag) ([]params.ResizeVolumeParamsResult, error)
+
+	// VolumeSnapshotParams returns the parameters for taking, or
+	// destroying, the volume snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
 	// VolumeAttachmentParams returns the parameters for creating the
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
@@ -88,6 +105,14 @@ type VolumeAccessor interface {
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// SetVolumeSnapshotInfo records the details of newly taken volume
+	// snapshots.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshotInfo) ([]params.ErrorResult, error)
+
+	// RemoveVolumeSnapshots removes the volume snapshots with the
+	// specified IDs, once they have been destroyed.
+	RemoveVolumeSnapshots([]string) ([]params.ErrorResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -205,6 +230,8 @@ func (w *storageProvisioner) Wait() error {
 func (w *storageProvisioner) loop() error {
 	var (
 		volumesChanges               watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
@@ -236,6 +263,24 @@ func (w *storageProvisioner) loop() error {
 			return errors.Trace(err)
 		}
 		volumesChanges = volumesWatcher.Changes()
+
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes(w.config.Scope)
+		if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		}
+		if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeResizesChanges = volumeResizesWatcher.Changes()
+
+		volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshots(w.config.Scope)
+		if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		}
+		if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
 	}
 
 	filesystemsWatcher, err := w.config.Filesystems.WatchFilesystems(w.config.Scope)
@@ -307,6 +352,20 @@ func (w *storageProvisioner) loop() error {
 			if err := volumesChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-volumeAttachmentsChanges:
 			if !ok {
 				return errors.New("volume attachments watcher closed")
@@ -353,10 +412,14 @@ func processSchedule(ctx *context) error {
 	ready := ctx.schedule.Ready(ctx.config.Clock.Now())
 	createVolumeOps := make(map[names.VolumeTag]*createVolumeOp)
 	removeVolumeOps := make(map[names.VolumeTag]*removeVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
+	createVolumeSnapshotOps := make(map[string]*createVolumeSnapshotOp)
+	destroyVolumeSnapshotOps := make(map[string]*destroyVolumeSnapshotOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
 	removeFilesystemOps := make(map[names.FilesystemTag]*removeFilesystemOp)
+	resizeFilesystemOps := make(map[names.FilesystemTag]*resizeFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
 	for _, item := range ready {
@@ -367,6 +430,12 @@ func processSchedule(ctx *context) error {
 			createVolumeOps[key.(names.VolumeTag)] = op
 		case *removeVolumeOp:
 			removeVolumeOps[key.(names.VolumeTag)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.tag] = op
+		case *createVolumeSnapshotOp:
+			createVolumeSnapshotOps[op.id] = op
+		case *destroyVolumeSnapshotOp:
+			destroyVolumeSnapshotOps[op.id] = op
 		case *attachVolumeOp:
 			attachVolumeOps[key.(params.MachineStorageId)] = op
 		case *detachVolumeOp:
@@ -375,6 +444,8 @@ func processSchedule(ctx *context) error {
 			createFilesystemOps[key.(names.FilesystemTag)] = op
 		case *removeFilesystemOp:
 			removeFilesystemOps[key.(names.FilesystemTag)] = op
+		case *resizeFilesystemOp:
+			resizeFilesystemOps[op.tag] = op
 		case *attachFilesystemOp:
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
@@ -391,6 +462,21 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "creating volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
+	if len(destroyVolumeSnapshotOps) > 0 {
+		if err := destroyVolumeSnapshots(ctx, destroyVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "destroying volume snapshots")
+		}
+	}
+	if len(createVolumeSnapshotOps) > 0 {
+		if err := createVolumeSnapshots(ctx, createVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "creating volume snapshots")
+		}
+	}
 	if len(detachVolumeOps) > 0 {
 		if err := detachVolumes(ctx, detachVolumeOps); err != nil {
 			return errors.Annotate(err, "detaching volumes")
@@ -411,6 +497,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "creating filesystems")
 		}
 	}
+	if len(resizeFilesystemOps) > 0 {
+		if err := resizeFilesystems(ctx, resizeFilesystemOps); err != nil {
+			return errors.Annotate(err, "resizing filesystems")
+		}
+	}
 	if len(detachFilesystemOps) > 0 {
 		if err := detachFilesystems(ctx, detachFilesystemOps); err != nil {
 			return errors.Annotate(err, "detaching filesystems")
@@ -1108,6 +1108,193 @@ func (s *storageProvisionerSuite) TestAttachVolumeBackedFilesystem(c *gc.C) {
 	}})
 }
 
+func (s *storageProvisionerSuite) TestResizeVolume(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedVolumes["volume-1"] = params.Volume{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Pool:     "dummy",
+			Size:     1024,
+		},
+	}
+	volumeAccessor.requestedSizes["volume-1"] = 2048
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return nil, nil
+	}
+
+	var resizeArgs []storage.VolumeResizeParams
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeArgs = append(resizeArgs, args...)
+		return []storage.ResizeVolumesResult{{Size: 2050}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumeResizesWatcher.changes <- []string{"1"}
+	volumeInfo := waitChannel(
+		c, volumeInfoSet, "waiting for volume info to be set",
+	).([]params.Volume)
+	c.Assert(volumeInfo, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Pool:     "dummy",
+			Size:     2050,
+		},
+	}})
+	c.Assert(resizeArgs, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshot(c *gc.C) {
+	snapshotInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshots["2"] = params.VolumeSnapshotParams{
+		Id:        "2",
+		Life:      params.Alive,
+		VolumeTag: "volume-1",
+		Provider:  "dummy",
+		VolumeId:  "vol-1",
+		Size:      1024,
+		Tags:      map[string]string{"foo": "bar"},
+	}
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshotInfo) ([]params.ErrorResult, error) {
+		snapshotInfoSet <- snapshots
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	var snapshotArgs []storage.VolumeSnapshotParams
+	s.provider.createSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+		snapshotArgs = append(snapshotArgs, args...)
+		return []storage.CreateSnapshotsResult{{
+			Snapshot: &storage.VolumeSnapshotInfo{SnapshotId: "snap-2", Size: 1024},
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumeSnapshotsWatcher.changes <- []string{"2"}
+	snapshotInfo := waitChannel(
+		c, snapshotInfoSet, "waiting for volume snapshot info to be set",
+	).([]params.VolumeSnapshotInfo)
+	c.Assert(snapshotInfo, jc.DeepEquals, []params.VolumeSnapshotInfo{{
+		Id:         "2",
+		SnapshotId: "snap-2",
+		Size:       1024,
+	}})
+	c.Assert(snapshotArgs, jc.DeepEquals, []storage.VolumeSnapshotParams{{
+		Id:           "2",
+		Volume:       names.NewVolumeTag("1"),
+		VolumeId:     "vol-1",
+		Size:         1024,
+		ResourceTags: map[string]string{"foo": "bar"},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	snapshotsRemoved := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshots["2"] = params.VolumeSnapshotParams{
+		Id:         "2",
+		Life:       params.Dying,
+		VolumeTag:  "volume-1",
+		Provider:   "dummy",
+		Size:       1024,
+		SnapshotId: "snap-2",
+	}
+	volumeAccessor.volumeSnapshots["3"] = params.VolumeSnapshotParams{
+		Id:        "3",
+		Life:      params.Dying,
+		VolumeTag: "volume-1",
+		Provider:  "dummy",
+		Size:      1024,
+	}
+	volumeAccessor.removeVolumeSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		snapshotsRemoved <- ids
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	var destroyed []string
+	s.provider.destroySnapshotsFunc = func(snapshotIds []string) ([]error, error) {
+		destroyed = append(destroyed, snapshotIds...)
+		return make([]error, len(snapshotIds)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Snapshot 3 was never taken, so it is removed
+	// without involving the storage provider.
+	volumeAccessor.volumeSnapshotsWatcher.changes <- []string{"2", "3"}
+	removed := waitChannel(
+		c, snapshotsRemoved, "waiting for volume snapshots to be removed",
+	).([]string)
+	c.Assert(removed, jc.SameContents, []string{"2", "3"})
+	c.Assert(destroyed, jc.DeepEquals, []string{"snap-2"})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeBackedFilesystem(c *gc.C) {
+	filesystemInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "filesystem-0-0",
+			Size:         123,
+		},
+	}
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		filesystemInfoSet <- filesystems
+		return nil, nil
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The backing volume has been grown, so its
+	// block device is larger than the filesystem.
+	args.volumes.blockDevices[params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       246,
+	}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+	filesystemInfo := waitChannel(
+		c, filesystemInfoSet, "waiting for filesystem info to be set",
+	).([]params.Filesystem)
+	c.Assert(filesystemInfo, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "filesystem-0-0",
+			Size:         246,
+		},
+	}})
+}
+
 func (s *storageProvisionerSuite) TestResourceTags(c *gc.C) {
 	volumeInfoSet := make(chan interface{})
 	volumeAccessor := newMockVolumeAccessor()
@@ -54,6 +54,59 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided IDs
+// have been seen to have changed, and may have been requested to be
+// resized.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	resizeParams, err := volumeResizeParams(ctx, tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, args := range resizeParams {
+		logger.Debugf("volume %s requested to be resized to %dMiB", args.Tag.Id(), args.Size)
+		// Replace any previously scheduled resize, so
+		// that the volume is grown to the latest size
+		// requested without delay.
+		key := resizeVolumeKey{args.Tag}
+		ctx.schedule.Remove(key)
+		scheduleOperations(ctx, &resizeVolumeOp{tag: args.Tag})
+	}
+	return nil
+}
+
+// volumeSnapshotsChanged is called when the lifecycle states of the
+// volume snapshots with the provided IDs have been seen to have changed.
+func volumeSnapshotsChanged(ctx *context, changes []string) error {
+	snapshotParams, err := volumeSnapshotParams(ctx, changes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, args := range snapshotParams {
+		var op scheduleOp
+		switch {
+		case args.life != params.Alive:
+			logger.Debugf("volume snapshot %s requested to be destroyed", args.Id)
+			op = &destroyVolumeSnapshotOp{id: args.Id}
+		case args.snapshotId == "":
+			logger.Debugf("volume snapshot %s requested for %s", args.Id, names.ReadableString(args.Volume))
+			op = &createVolumeSnapshotOp{id: args.Id}
+		default:
+			// The snapshot has already been taken.
+			continue
+		}
+		// Replace any previously scheduled operation on the
+		// snapshot, so that a snapshot which is destroyed
+		// before it is taken is never taken.
+		ctx.schedule.Remove(volumeSnapshotKey{args.Id})
+		scheduleOperations(ctx, op)
+	}
+	return nil
+}
+
 // volumeAttachmentsChanged is called when the lifecycle states of the volume
 // attachments with the provided IDs have been seen to have changed.
 func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageId) error {
@@ -416,6 +469,74 @@ func removeVolumeParams(ctx *context, tags []names.VolumeTag) ([]params.RemoveVo
 	return allParams, nil
 }
 
+// volumeResizeParams obtains the parameters for resizing the volumes
+// with the specified tags. Volumes that do not need to be resized are
+// omitted from the result.
+func volumeResizeParams(ctx *context, tags []names.VolumeTag) ([]resizeVolumeParams, error) {
+	results, err := ctx.config.Volumes.ResizeVolumeParams(tags)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting volume resize params")
+	}
+	var resizeParams []resizeVolumeParams
+	for i, result := range results {
+		if result.Error != nil {
+			return nil, errors.Annotatef(
+				result.Error, "getting resize parameters for volume %q", tags[i].Id(),
+			)
+		}
+		if result.Result == nil {
+			continue
+		}
+		resizeParams = append(resizeParams, resizeVolumeParams{
+			VolumeResizeParams: storage.VolumeResizeParams{
+				Tag:      tags[i],
+				VolumeId: result.Result.VolumeId,
+				Size:     result.Result.Size,
+			},
+			provider: storage.ProviderType(result.Result.Provider),
+		})
+	}
+	return resizeParams, nil
+}
+
+// volumeSnapshotParams returns the parameters for taking, or destroying,
+// the volume snapshots with the specified IDs. Snapshots which no longer
+// exist are omitted.
+func volumeSnapshotParams(ctx *context, ids []string) ([]volumeSnapshotArgs, error) {
+	results, err := ctx.config.Volumes.VolumeSnapshotParams(ids)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting volume snapshot params")
+	}
+	var snapshotParams []volumeSnapshotArgs
+	for i, result := range results {
+		if result.Error != nil {
+			return nil, errors.Annotatef(
+				result.Error, "getting parameters for volume snapshot %q", ids[i],
+			)
+		}
+		if result.Result == nil {
+			continue
+		}
+		volumeTag, err := names.ParseVolumeTag(result.Result.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshotParams = append(snapshotParams, volumeSnapshotArgs{
+			VolumeSnapshotParams: storage.VolumeSnapshotParams{
+				Id:           result.Result.Id,
+				Volume:       volumeTag,
+				VolumeId:     result.Result.VolumeId,
+				Size:         result.Result.Size,
+				ResourceTags: result.Result.Tags,
+			},
+			life:       result.Result.Life,
+			provider:   storage.ProviderType(result.Result.Provider),
+			snapshotId: result.Result.SnapshotId,
+		})
+	}
+	return snapshotParams, nil
+}
+
 func volumesFromStorage(in []storage.Volume) []params.Volume {
 	out := make([]params.Volume, len(in))
 	for i, v := range in {
@@ -512,6 +633,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		in.Attributes,
 		in.Tags,
 		attachment,
+		in.SnapshotId,
 	}, nil
 }
 
@@ -271,6 +271,278 @@ func removeVolumes(ctx *context, ops map[names.VolumeTag]*removeVolumeOp) error
 	return nil
 }
 
+// resizeVolumes grows the volumes with the specified tags.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	tags := make([]names.VolumeTag, 0, len(ops))
+	for tag := range ops {
+		tags = append(tags, tag)
+	}
+	// Fetch the parameters again, as the volume may have been
+	// removed or resized since the operation was scheduled.
+	resizeParams, err := volumeResizeParams(ctx, tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumeParams := make([]storage.VolumeParams, len(resizeParams))
+	resizeParamsByTag := make(map[names.VolumeTag]storage.VolumeResizeParams)
+	for i, args := range resizeParams {
+		resizeParamsByTag[args.Tag] = args.VolumeResizeParams
+		volumeParams[i] = storage.VolumeParams{
+			Tag:      args.Tag,
+			Provider: args.provider,
+		}
+	}
+	paramsBySource, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var resized []names.VolumeTag
+	sizes := make(map[names.VolumeTag]uint64)
+	for sourceName, volumeParams := range paramsBySource {
+		volumeResizer, ok := volumeSources[sourceName].(storage.VolumeResizer)
+		if !ok {
+			logger.Warningf("storage provider %q does not support resizing volumes", sourceName)
+			continue
+		}
+		args := make([]storage.VolumeResizeParams, len(volumeParams))
+		for i, params := range volumeParams {
+			args[i] = resizeParamsByTag[params.Tag]
+		}
+		logger.Debugf("resizing volumes: %v", args)
+		results, err := volumeResizer.ResizeVolumes(ctx.config.CloudCallContext, args)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := args[i].Tag
+			if result.Error != nil {
+				// Reschedule the volume resize.
+				reschedule = append(reschedule, ops[tag])
+				logger.Warningf(
+					"failed to resize %s: %v",
+					names.ReadableString(tag),
+					result.Error,
+				)
+				continue
+			}
+			resized = append(resized, tag)
+			sizes[tag] = result.Size
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(resized) == 0 {
+		return nil
+	}
+
+	// Record the new sizes in state, keeping the
+	// remainder of each volume's info intact.
+	volumeResults, err := ctx.config.Volumes.Volumes(resized)
+	if err != nil {
+		return errors.Annotate(err, "getting volume information")
+	}
+	volumes := make([]params.Volume, 0, len(resized))
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"getting information for resized volume %s: %v",
+				resized[i].Id(),
+				result.Error,
+			)
+			continue
+		}
+		volume := result.Result
+		volume.Info.Size = sizes[resized[i]]
+		volumes = append(volumes, volume)
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumes)
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume %s to state: %v",
+				volumes[i].VolumeTag,
+				result.Error,
+			)
+			continue
+		}
+		volume, err := volumeFromParams(volumes[i])
+		if err != nil {
+			return errors.Annotate(err, "getting volume info")
+		}
+		updateVolume(ctx, volume)
+	}
+	return nil
+}
+
+// createVolumeSnapshots takes snapshots of volumes, and records the
+// snapshots' details in state.
+func createVolumeSnapshots(ctx *context, ops map[string]*createVolumeSnapshotOp) error {
+	ids := make([]string, 0, len(ops))
+	for id := range ops {
+		ids = append(ids, id)
+	}
+	// Fetch the parameters again, as the snapshot may have been
+	// destroyed or taken since the operation was scheduled.
+	snapshotParams, err := volumeSnapshotParams(ctx, ids)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	paramsByProvider := make(map[storage.ProviderType][]storage.VolumeSnapshotParams)
+	for _, args := range snapshotParams {
+		if args.life != params.Alive || args.snapshotId != "" {
+			continue
+		}
+		paramsByProvider[args.provider] = append(paramsByProvider[args.provider], args.VolumeSnapshotParams)
+	}
+	var reschedule []scheduleOp
+	var infos []params.VolumeSnapshotInfo
+	for providerType, args := range paramsByProvider {
+		volumeSnapshotter, err := volumeSnapshotterForProvider(ctx, providerType)
+		if err != nil {
+			return errors.Trace(err)
+		} else if volumeSnapshotter == nil {
+			continue
+		}
+		logger.Debugf("creating volume snapshots: %v", args)
+		results, err := volumeSnapshotter.CreateSnapshots(ctx.config.CloudCallContext, args)
+		if err != nil {
+			return errors.Annotatef(err, "creating volume snapshots from source %q", providerType)
+		}
+		for i, result := range results {
+			id := args[i].Id
+			if result.Error != nil {
+				// Reschedule the snapshot.
+				reschedule = append(reschedule, ops[id])
+				logger.Warningf(
+					"failed to snapshot %s: %v",
+					names.ReadableString(args[i].Volume),
+					result.Error,
+				)
+				continue
+			}
+			infos = append(infos, params.VolumeSnapshotInfo{
+				Id:         id,
+				SnapshotId: result.Snapshot.SnapshotId,
+				Size:       result.Snapshot.Size,
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(infos) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotInfo(infos)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing volume snapshot %s to state",
+				infos[i].Id,
+			)
+		}
+	}
+	return nil
+}
+
+// destroyVolumeSnapshots destroys volume snapshots, and removes them
+// from state.
+func destroyVolumeSnapshots(ctx *context, ops map[string]*destroyVolumeSnapshotOp) error {
+	ids := make([]string, 0, len(ops))
+	for id := range ops {
+		ids = append(ids, id)
+	}
+	snapshotParams, err := volumeSnapshotParams(ctx, ids)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var remove []string
+	snapshotIdsByProvider := make(map[storage.ProviderType][]string)
+	idsByProvider := make(map[storage.ProviderType][]string)
+	for _, args := range snapshotParams {
+		if args.life == params.Alive {
+			continue
+		}
+		if args.snapshotId == "" {
+			// The snapshot was never taken, so there is
+			// nothing to destroy.
+			remove = append(remove, args.Id)
+			continue
+		}
+		snapshotIdsByProvider[args.provider] = append(snapshotIdsByProvider[args.provider], args.snapshotId)
+		idsByProvider[args.provider] = append(idsByProvider[args.provider], args.Id)
+	}
+	var reschedule []scheduleOp
+	for providerType, snapshotIds := range snapshotIdsByProvider {
+		volumeSnapshotter, err := volumeSnapshotterForProvider(ctx, providerType)
+		if err != nil {
+			return errors.Trace(err)
+		} else if volumeSnapshotter == nil {
+			continue
+		}
+		logger.Debugf("destroying volume snapshots: %v", snapshotIds)
+		errs, err := volumeSnapshotter.DestroySnapshots(ctx.config.CloudCallContext, snapshotIds)
+		if err != nil {
+			return errors.Annotatef(err, "destroying volume snapshots from source %q", providerType)
+		}
+		for i, err := range errs {
+			id := idsByProvider[providerType][i]
+			if err != nil {
+				// Reschedule the snapshot destruction.
+				reschedule = append(reschedule, ops[id])
+				logger.Warningf("failed to destroy volume snapshot %s: %v", id, err)
+				continue
+			}
+			remove = append(remove, id)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(remove) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.RemoveVolumeSnapshots(remove)
+	if err != nil {
+		return errors.Annotate(err, "removing volume snapshots from state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "removing volume snapshot %s from state",
+				remove[i],
+			)
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotterForProvider returns the VolumeSnapshotter for the
+// volume source of the specified storage provider, or nil if the
+// volume source cannot take snapshots.
+func volumeSnapshotterForProvider(ctx *context, providerType storage.ProviderType) (storage.VolumeSnapshotter, error) {
+	sourceName := string(providerType)
+	source, err := volumeSource(
+		ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry,
+	)
+	if errors.Cause(err) == errNonDynamic {
+		logger.Warningf("storage provider %q does not support volume snapshots", sourceName)
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting volume source")
+	}
+	volumeSnapshotter, ok := source.(storage.VolumeSnapshotter)
+	if !ok {
+		logger.Warningf("storage provider %q does not support volume snapshots", sourceName)
+		return nil, nil
+	}
+	return volumeSnapshotter, nil
+}
+
 func partitionRemoveVolumeParams(removeTags []names.VolumeTag, removeParams []params.RemoveVolumeParams) (
 	destroyTags []names.VolumeTag, destroyIds []string,
 	releaseTags []names.VolumeTag, releaseIds []string,
@@ -499,6 +771,63 @@ func (op *removeVolumeOp) key() interface{} {
 	return op.tag
 }
 
+// resizeVolumeParams holds the parameters for growing a volume,
+// along with the type of the storage provider that manages it.
+type resizeVolumeParams struct {
+	storage.VolumeResizeParams
+	provider storage.ProviderType
+}
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	tag names.VolumeTag
+}
+
+// resizeVolumeKey is the schedule key for a resizeVolumeOp. Resizes
+// are keyed separately from other operations on the same volume.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.tag}
+}
+
+// volumeSnapshotArgs holds the parameters for taking, or destroying, a
+// volume snapshot, along with the type of the storage provider that
+// manages it.
+type volumeSnapshotArgs struct {
+	storage.VolumeSnapshotParams
+	life       params.Life
+	provider   storage.ProviderType
+	snapshotId string
+}
+
+type createVolumeSnapshotOp struct {
+	exponentialBackoff
+	id string
+}
+
+func (op *createVolumeSnapshotOp) key() interface{} {
+	return volumeSnapshotKey{op.id}
+}
+
+type destroyVolumeSnapshotOp struct {
+	exponentialBackoff
+	id string
+}
+
+func (op *destroyVolumeSnapshotOp) key() interface{} {
+	return volumeSnapshotKey{op.id}
+}
+
+// volumeSnapshotKey is the schedule key for operations on a volume
+// snapshot. Creating and destroying a snapshot share a key, so that
+// at most one operation is scheduled for a snapshot at a time.
+type volumeSnapshotKey struct {
+	id string
+}
+
 type attachVolumeOp struct {
 	exponentialBackoff
 	args storage.VolumeAttachmentParams
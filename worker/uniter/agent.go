@@ -18,7 +18,11 @@ func setAgentStatus(u *Uniter, agentStatus status.Status, info string, data map[
 	u.lastReportedStatus = agentStatus
 	u.lastReportedMessage = info
 	logger.Debugf("[AGENT-STATUS] %s: %s", agentStatus, info)
-	return u.unit.SetAgentStatus(agentStatus, info, data)
+	if err := u.unit.SetAgentStatus(agentStatus, info, data); err != nil {
+		return err
+	}
+	u.metrics.setAgentStatus(agentStatus)
+	return nil
 }
 
 // reportAgentError reports if there was an error performing an agent operation.
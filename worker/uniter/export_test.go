@@ -0,0 +1,42 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+// MetricsCollector exposes the uniter's metrics collector for testing.
+type MetricsCollector interface {
+	prometheus.Collector
+	operation.MetricsRecorder
+	SetAgentStatus(status.Status)
+	ObserveResolverLatency(time.Duration)
+}
+
+func NewMetricsCollector(unitName string, clock clock.Clock) MetricsCollector {
+	return metricsCollectorShim{newMetricsCollector(unitName, clock)}
+}
+
+type metricsCollectorShim struct {
+	*metricsCollector
+}
+
+func (s metricsCollectorShim) SetAgentStatus(agentStatus status.Status) {
+	s.setAgentStatus(agentStatus)
+}
+
+func (s metricsCollectorShim) ObserveResolverLatency(elapsed time.Duration) {
+	s.observeResolverLatency(elapsed)
+}
+
+func RegisterMetrics(registerer prometheus.Registerer, collector MetricsCollector) error {
+	return registerMetrics(registerer, collector)
+}
@@ -16,8 +16,17 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// StorageResized is run when a storage attachment has grown.
+	StorageResized hooks.Kind = "storage-resized"
 )
 
+// IsStorage returns whether the specified hook kind is a storage hook,
+// including those storage hooks not yet defined in juju/charm/hooks.
+func IsStorage(kind hooks.Kind) bool {
+	return kind.IsStorage() || kind == StorageResized
+}
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -37,6 +46,11 @@ type Info struct {
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// StorageSize is the size of the storage attachment, in MiB, when
+	// the hook was queued. It is only set when Kind indicates a storage
+	// hook other than storage-detaching.
+	StorageSize uint64 `yaml:"storage-size,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -52,7 +66,7 @@ func (hi Info) Validate() error {
 		return nil
 	case hooks.Action:
 		return fmt.Errorf("hooks.Kind Action is deprecated")
-	case hooks.StorageAttached, hooks.StorageDetaching:
+	case hooks.StorageAttached, hooks.StorageDetaching, StorageResized:
 		if !names.IsValidStorage(hi.StorageId) {
 			return fmt.Errorf("invalid storage ID %q", hi.StorageId)
 		}
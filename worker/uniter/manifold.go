@@ -6,6 +6,7 @@ package uniter
 import (
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
@@ -32,6 +33,10 @@ type ManifoldConfig struct {
 	CharmDirName          string
 	HookRetryStrategyName string
 	TranslateResolverErr  func(error) error
+
+	// PrometheusRegisterer, if set, is used to register the
+	// uniter's hook and action metrics.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // Manifold returns a dependency manifold that runs a uniter worker,
@@ -103,6 +108,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				NewOperationExecutor: operation.NewExecutor,
 				TranslateResolverErr: config.TranslateResolverErr,
 				Clock:                manifoldConfig.Clock,
+				PrometheusRegisterer: manifoldConfig.PrometheusRegisterer,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -0,0 +1,176 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/status"
+)
+
+const (
+	uniterMetricsNamespace = "juju_uniter"
+)
+
+// metricsCollector is a prometheus.Collector that collects metrics
+// about the hooks and actions run by a single unit. Each unit's
+// metrics are distinguished by a "unit" label, so that the collectors
+// for all units running in an agent can be registered together.
+type metricsCollector struct {
+	clock clock.Clock
+
+	hookRuns        *prometheus.CounterVec
+	hookDuration    *prometheus.HistogramVec
+	actionDuration  *prometheus.HistogramVec
+	resolverLatency prometheus.Histogram
+	inErrorGauge    prometheus.Gauge
+	timeInError     prometheus.Counter
+
+	mu         sync.Mutex
+	inError    bool
+	errorSince time.Time
+	errorTotal time.Duration
+}
+
+// newMetricsCollector returns a new metricsCollector for the named
+// unit.
+func newMetricsCollector(unitName string, clock clock.Clock) *metricsCollector {
+	labels := prometheus.Labels{"unit": unitName}
+	return &metricsCollector{
+		clock: clock,
+		hookRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   uniterMetricsNamespace,
+			Name:        "hook_runs_total",
+			Help:        "Total number of hooks run, by hook kind and outcome",
+			ConstLabels: labels,
+		}, []string{"hook", "outcome"}),
+		hookDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   uniterMetricsNamespace,
+			Name:        "hook_duration_seconds",
+			Help:        "Time taken to run hooks, by hook kind and outcome",
+			ConstLabels: labels,
+			Buckets:     prometheus.ExponentialBuckets(0.1, 2, 14),
+		}, []string{"hook", "outcome"}),
+		actionDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   uniterMetricsNamespace,
+			Name:        "action_duration_seconds",
+			Help:        "Time taken to run actions, by action name and outcome",
+			ConstLabels: labels,
+			Buckets:     prometheus.ExponentialBuckets(0.1, 2, 14),
+		}, []string{"action", "outcome"}),
+		resolverLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace:   uniterMetricsNamespace,
+			Name:        "resolver_loop_latency_seconds",
+			Help:        "Time taken for the resolver loop to act on a remote state change",
+			ConstLabels: labels,
+			Buckets:     prometheus.ExponentialBuckets(0.01, 2, 16),
+		}),
+		inErrorGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   uniterMetricsNamespace,
+			Name:        "in_error",
+			Help:        "Whether the unit agent is currently in an error state",
+			ConstLabels: labels,
+		}),
+		timeInError: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   uniterMetricsNamespace,
+			Name:        "time_in_error_seconds_total",
+			Help:        "Total time the unit agent has spent in an error state",
+			ConstLabels: labels,
+		}),
+	}
+}
+
+// RecordHook is part of the operation.MetricsRecorder interface.
+func (c *metricsCollector) RecordHook(kind hooks.Kind, outcome string, elapsed time.Duration) {
+	c.hookRuns.WithLabelValues(string(kind), outcome).Inc()
+	c.hookDuration.WithLabelValues(string(kind), outcome).Observe(elapsed.Seconds())
+}
+
+// RecordAction is part of the operation.MetricsRecorder interface.
+func (c *metricsCollector) RecordAction(name, outcome string, elapsed time.Duration) {
+	c.actionDuration.WithLabelValues(name, outcome).Observe(elapsed.Seconds())
+}
+
+// observeResolverLatency records the time taken by the resolver loop
+// to act on a remote state change.
+func (c *metricsCollector) observeResolverLatency(elapsed time.Duration) {
+	c.resolverLatency.Observe(elapsed.Seconds())
+}
+
+// setAgentStatus records a change of the unit agent's status, so that
+// the time spent in an error state can be tracked.
+func (c *metricsCollector) setAgentStatus(agentStatus status.Status) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	inError := agentStatus == status.Error
+	switch {
+	case inError && !c.inError:
+		c.errorSince = c.clock.Now()
+	case !inError && c.inError:
+		c.errorTotal += c.clock.Now().Sub(c.errorSince)
+	}
+	c.inError = inError
+}
+
+// errorStats returns whether the unit agent is in an error state, and
+// the total time it has spent in one.
+func (c *metricsCollector) errorStats() (bool, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.inError {
+		return false, c.errorTotal
+	}
+	return true, c.errorTotal + c.clock.Now().Sub(c.errorSince)
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.hookRuns.Describe(ch)
+	c.hookDuration.Describe(ch)
+	c.actionDuration.Describe(ch)
+	c.resolverLatency.Describe(ch)
+	c.inErrorGauge.Describe(ch)
+	c.timeInError.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	inError, total := c.errorStats()
+	if inError {
+		c.inErrorGauge.Set(1)
+	} else {
+		c.inErrorGauge.Set(0)
+	}
+
+	c.hookRuns.Collect(ch)
+	c.hookDuration.Collect(ch)
+	c.actionDuration.Collect(ch)
+	c.resolverLatency.Collect(ch)
+	c.inErrorGauge.Collect(ch)
+	ch <- prometheus.MustNewConstMetric(
+		c.timeInError.Desc(),
+		prometheus.CounterValue,
+		total.Seconds(),
+	)
+}
+
+// registerMetrics registers the collector with the registerer. If a
+// collector for the same unit is still registered, left behind by an
+// earlier uniter, it is replaced; otherwise the new collector's metrics
+// would never be reported.
+func registerMetrics(registerer prometheus.Registerer, collector prometheus.Collector) error {
+	err := registerer.Register(collector)
+	if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		logger.Debugf("replacing previously registered uniter metrics")
+		registerer.Unregister(are.ExistingCollector)
+		err = registerer.Register(collector)
+	}
+	return errors.Annotate(err, "registering uniter metrics")
+}
@@ -0,0 +1,164 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type metricsSuite struct {
+	testing.IsolationSuite
+	clock     *testing.Clock
+	collector uniter.MetricsCollector
+	registry  *prometheus.Registry
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.collector = uniter.NewMetricsCollector("mysql/0", s.clock)
+	s.registry = prometheus.NewPedanticRegistry()
+	err := s.registry.Register(s.collector)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *metricsSuite) gather(c *gc.C) map[string]*dto.MetricFamily {
+	families, err := s.registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	result := make(map[string]*dto.MetricFamily)
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			c.Assert(labelValue(m, "unit"), gc.Equals, "mysql/0")
+		}
+		result[f.GetName()] = f
+	}
+	return result
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+func (s *metricsSuite) TestHooks(c *gc.C) {
+	s.collector.RecordHook(hooks.Install, operation.OutcomeCompleted, 2*time.Second)
+	s.collector.RecordHook(hooks.ConfigChanged, operation.OutcomeFailed, time.Second)
+	s.collector.RecordHook(hooks.ConfigChanged, operation.OutcomeFailed, 3*time.Second)
+
+	families := s.gather(c)
+	runs := make(map[string]float64)
+	for _, m := range families["juju_uniter_hook_runs_total"].GetMetric() {
+		runs[labelValue(m, "hook")+" "+labelValue(m, "outcome")] = m.GetCounter().GetValue()
+	}
+	c.Assert(runs, jc.DeepEquals, map[string]float64{
+		"install completed":     1,
+		"config-changed failed": 2,
+	})
+
+	durations := make(map[string]float64)
+	for _, m := range families["juju_uniter_hook_duration_seconds"].GetMetric() {
+		durations[labelValue(m, "hook")] = m.GetHistogram().GetSampleSum()
+	}
+	c.Assert(durations, jc.DeepEquals, map[string]float64{
+		"install":        2,
+		"config-changed": 4,
+	})
+}
+
+func (s *metricsSuite) TestActionsAndLatency(c *gc.C) {
+	s.collector.RecordAction("backup", operation.OutcomeCompleted, 5*time.Second)
+	s.collector.ObserveResolverLatency(500 * time.Millisecond)
+
+	families := s.gather(c)
+	actions := families["juju_uniter_action_duration_seconds"].GetMetric()
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(labelValue(actions[0], "action"), gc.Equals, "backup")
+	c.Assert(labelValue(actions[0], "outcome"), gc.Equals, "completed")
+	c.Assert(actions[0].GetHistogram().GetSampleSum(), gc.Equals, 5.0)
+
+	latency := families["juju_uniter_resolver_loop_latency_seconds"].GetMetric()
+	c.Assert(latency, gc.HasLen, 1)
+	c.Assert(latency[0].GetHistogram().GetSampleCount(), gc.Equals, uint64(1))
+	c.Assert(latency[0].GetHistogram().GetSampleSum(), gc.Equals, 0.5)
+}
+
+func (s *metricsSuite) errorMetrics(c *gc.C) (float64, float64) {
+	families := s.gather(c)
+	inError := families["juju_uniter_in_error"].GetMetric()[0].GetGauge().GetValue()
+	total := families["juju_uniter_time_in_error_seconds_total"].GetMetric()[0].GetCounter().GetValue()
+	return inError, total
+}
+
+func (s *metricsSuite) TestTimeInError(c *gc.C) {
+	inError, total := s.errorMetrics(c)
+	c.Assert(inError, gc.Equals, 0.0)
+	c.Assert(total, gc.Equals, 0.0)
+
+	s.collector.SetAgentStatus(status.Error)
+	s.clock.Advance(10 * time.Second)
+	inError, total = s.errorMetrics(c)
+	c.Assert(inError, gc.Equals, 1.0)
+	c.Assert(total, gc.Equals, 10.0)
+
+	// Leaving the error state stops the clock.
+	s.collector.SetAgentStatus(status.Executing)
+	s.clock.Advance(5 * time.Second)
+	inError, total = s.errorMetrics(c)
+	c.Assert(inError, gc.Equals, 0.0)
+	c.Assert(total, gc.Equals, 10.0)
+
+	s.collector.SetAgentStatus(status.Error)
+	s.clock.Advance(time.Second)
+	_, total = s.errorMetrics(c)
+	c.Assert(total, gc.Equals, 11.0)
+}
+
+func (s *metricsSuite) TestRegisterReplacesExisting(c *gc.C) {
+	s.collector.RecordAction("backup", operation.OutcomeCompleted, time.Second)
+
+	// A new uniter for the same unit replaces the collector left
+	// registered by the old one, rather than failing to report.
+	collector := uniter.NewMetricsCollector("mysql/0", s.clock)
+	err := uniter.RegisterMetrics(s.registry, collector)
+	c.Assert(err, jc.ErrorIsNil)
+	collector.RecordAction("restore", operation.OutcomeCompleted, time.Second)
+
+	actions := s.gather(c)["juju_uniter_action_duration_seconds"].GetMetric()
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(labelValue(actions[0], "action"), gc.Equals, "restore")
+}
+
+func (s *metricsSuite) TestRegisterOtherUnit(c *gc.C) {
+	collector := uniter.NewMetricsCollector("mysql/1", s.clock)
+	err := uniter.RegisterMetrics(s.registry, collector)
+	c.Assert(err, jc.ErrorIsNil)
+
+	families, err := s.registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	units := set.NewStrings()
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			units.Add(labelValue(m, "unit"))
+		}
+	}
+	c.Assert(units.SortedValues(), jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+}
@@ -0,0 +1,37 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"time"
+
+	"gopkg.in/juju/charm.v6/hooks"
+)
+
+// The outcomes recorded for hooks and actions.
+const (
+	OutcomeCompleted = "completed"
+	OutcomeFailed    = "failed"
+)
+
+// MetricsRecorder is told about each hook and action run by an
+// operation, so that their outcomes and durations can be exposed as
+// metrics. Hooks that are missing from the charm are not recorded.
+type MetricsRecorder interface {
+	// RecordHook records that a hook of the given kind finished
+	// with the given outcome after running for the given time.
+	RecordHook(kind hooks.Kind, outcome string, elapsed time.Duration)
+
+	// RecordAction records that the named action finished with the
+	// given outcome after running for the given time.
+	RecordAction(name, outcome string, elapsed time.Duration)
+}
+
+type nopMetricsRecorder struct{}
+
+// RecordHook is part of the MetricsRecorder interface.
+func (nopMetricsRecorder) RecordHook(hooks.Kind, string, time.Duration) {}
+
+// RecordAction is part of the MetricsRecorder interface.
+func (nopMetricsRecorder) RecordAction(string, string, time.Duration) {}
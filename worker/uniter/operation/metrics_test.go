@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/worker/common/charmrunner"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type MetricsSuite struct {
+	testing.IsolationSuite
+	metrics *fakeMetricsRecorder
+	clock   *steppingClock
+}
+
+var _ = gc.Suite(&MetricsSuite{})
+
+func (s *MetricsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.metrics = &fakeMetricsRecorder{}
+	s.clock = &steppingClock{step: 3 * time.Second}
+}
+
+func (s *MetricsSuite) runHook(c *gc.C, kind hooks.Kind, runErr error) error {
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: NewRunHookRunnerFactory(runErr),
+		Callbacks: &ExecuteHookCallbacks{
+			PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+			MockNotifyHookCompleted: &MockNotify{},
+			MockNotifyHookFailed:    &MockNotify{},
+		},
+		Metrics: s.metrics,
+		Clock:   s.clock,
+	})
+	op, err := factory.NewRunHook(hook.Info{Kind: kind})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Execute(operation.State{})
+	return err
+}
+
+func (s *MetricsSuite) TestHookCompleted(c *gc.C) {
+	err := s.runHook(c, hooks.ConfigChanged, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.metrics.CheckCalls(c, []testing.StubCall{{
+		"RecordHook", []interface{}{hooks.ConfigChanged, operation.OutcomeCompleted, 3 * time.Second},
+	}})
+}
+
+func (s *MetricsSuite) TestHookFailed(c *gc.C) {
+	err := s.runHook(c, hooks.ConfigChanged, errors.New("graaargh"))
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	s.metrics.CheckCalls(c, []testing.StubCall{{
+		"RecordHook", []interface{}{hooks.ConfigChanged, operation.OutcomeFailed, 3 * time.Second},
+	}})
+}
+
+func (s *MetricsSuite) TestMissingHookNotRecorded(c *gc.C) {
+	err := s.runHook(c, hooks.ConfigChanged, charmrunner.NewMissingHookError("config-changed"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.metrics.CheckNoCalls(c)
+}
+
+func (s *MetricsSuite) runAction(c *gc.C, runErr error, failed bool) error {
+	runnerFactory := NewRunActionRunnerFactory(runErr)
+	runnerFactory.MockNewActionRunner.runner.context.(*MockContext).actionData.Failed = failed
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
+		Metrics:       s.metrics,
+		Clock:         s.clock,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Execute(*midState)
+	return err
+}
+
+func (s *MetricsSuite) TestActionCompleted(c *gc.C) {
+	err := s.runAction(c, nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	s.metrics.CheckCalls(c, []testing.StubCall{{
+		"RecordAction", []interface{}{"some-action-name", operation.OutcomeCompleted, 3 * time.Second},
+	}})
+}
+
+func (s *MetricsSuite) TestActionFailed(c *gc.C) {
+	err := s.runAction(c, nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	s.metrics.CheckCalls(c, []testing.StubCall{{
+		"RecordAction", []interface{}{"some-action-name", operation.OutcomeFailed, 3 * time.Second},
+	}})
+}
+
+func (s *MetricsSuite) TestActionRunnerError(c *gc.C) {
+	err := s.runAction(c, errors.New("kerblooie"), false)
+	c.Assert(err, gc.ErrorMatches, `running action "some-action-name": kerblooie`)
+	s.metrics.CheckCalls(c, []testing.StubCall{{
+		"RecordAction", []interface{}{"some-action-name", operation.OutcomeFailed, 3 * time.Second},
+	}})
+}
+
+type fakeMetricsRecorder struct {
+	testing.Stub
+}
+
+func (r *fakeMetricsRecorder) RecordHook(kind hooks.Kind, outcome string, elapsed time.Duration) {
+	r.MethodCall(r, "RecordHook", kind, outcome, elapsed)
+}
+
+func (r *fakeMetricsRecorder) RecordAction(name, outcome string, elapsed time.Duration) {
+	r.MethodCall(r, "RecordAction", name, outcome, elapsed)
+}
+
+// steppingClock is a clock whose time moves on by a fixed step each
+// time it is read.
+type steppingClock struct {
+	clock.Clock
+	now  time.Time
+	step time.Duration
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.now = c.now.Add(c.step)
+	return c.now
+}
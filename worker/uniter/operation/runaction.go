@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -17,6 +18,8 @@ type runAction struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	metrics       MetricsRecorder
+	clock         clock.Clock
 
 	name   string
 	runner runner.Runner
@@ -73,12 +76,20 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
+	start := ra.clock.Now()
 	err := ra.runner.RunAction(ra.name)
+	elapsed := ra.clock.Now().Sub(start)
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
+		ra.metrics.RecordAction(ra.name, OutcomeFailed, elapsed)
 		return nil, errors.Annotatef(err, "running action %q", ra.name)
 	}
+	outcome := OutcomeCompleted
+	if actionData, err := ra.runner.Context().ActionData(); err == nil && actionData.Failed {
+		outcome = OutcomeFailed
+	}
+	ra.metrics.RecordAction(ra.name, outcome, elapsed)
 	return stateChange{
 		Kind:     RunAction,
 		Step:     Done,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charm.v6/hooks"
 
 	"github.com/juju/juju/core/model"
@@ -24,6 +25,8 @@ type runHook struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	metrics       MetricsRecorder
+	clock         clock.Clock
 
 	name   string
 	runner runner.Runner
@@ -41,7 +44,7 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
@@ -99,7 +102,9 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	ranHook := true
 	step := Done
 
+	start := rh.clock.Now()
 	err := rh.runner.RunHook(rh.name)
+	elapsed := rh.clock.Now().Sub(start)
 	cause := errors.Cause(err)
 	switch {
 	case charmrunner.IsMissingHookError(cause):
@@ -113,12 +118,14 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case err == nil:
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
+		rh.metrics.RecordHook(rh.info.Kind, OutcomeFailed, elapsed)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
 
 	if ranHook {
 		logger.Infof("ran %q hook", rh.name)
+		rh.metrics.RecordHook(rh.info.Kind, OutcomeCompleted, elapsed)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
 		logger.Infof("skipped %q hook (missing)", rh.name)
@@ -199,6 +199,7 @@ type mockUnit struct {
 	life                             params.Life
 	resolved                         params.ResolvedMode
 	series                           string
+	targetCharmURL                   *charm.URL
 	application                      mockApplication
 	unitWatcher                      *mockNotifyWatcher
 	addressesWatcher                 *mockNotifyWatcher
@@ -234,6 +235,13 @@ func (u *mockUnit) Tag() names.UnitTag {
 	return u.tag
 }
 
+func (u *mockUnit) TargetCharmURL() (*charm.URL, bool, error) {
+	if u.targetCharmURL != nil {
+		return u.targetCharmURL, false, nil
+	}
+	return u.application.CharmURL()
+}
+
 func (u *mockUnit) Watch() (watcher.NotifyWatcher, error) {
 	return u.unitWatcher, nil
 }
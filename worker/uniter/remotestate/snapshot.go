@@ -93,4 +93,5 @@ type StorageSnapshot struct {
 	Life     params.Life
 	Attached bool
 	Location string
+	Size     uint64
 }
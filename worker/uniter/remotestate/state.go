@@ -38,6 +38,9 @@ type Unit interface {
 	Application() (Application, error)
 	Series() string
 	Tag() names.UnitTag
+	// TargetCharmURL returns the url for the charm the unit should be
+	// running, and whether to upgrade to it even if in an error state.
+	TargetCharmURL() (*charm.URL, bool, error)
 	Watch() (watcher.NotifyWatcher, error)
 	WatchAddresses() (watcher.NotifyWatcher, error)
 	WatchConfigSettings() (watcher.NotifyWatcher, error)
@@ -625,10 +625,15 @@ func (w *RemoteStateWatcher) unitChanged() error {
 		return errors.Trace(err)
 	}
 	w.mu.Lock()
-	defer w.mu.Unlock()
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = w.unit.Resolved()
 	w.current.Series = w.unit.Series()
+	w.mu.Unlock()
+	// The unit may have been chosen to run a charm being
+	// rolled out to the application.
+	if w.modelType == model.IAAS {
+		return errors.Trace(w.targetCharmChanged())
+	}
 	return nil
 }
 
@@ -637,18 +642,25 @@ func (w *RemoteStateWatcher) applicationChanged() error {
 	if err := w.application.Refresh(); err != nil {
 		return errors.Trace(err)
 	}
-	url, force, err := w.application.CharmURL()
+	ver, err := w.application.CharmModifiedVersion()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	ver, err := w.application.CharmModifiedVersion()
+	w.mu.Lock()
+	w.current.CharmModifiedVersion = ver
+	w.mu.Unlock()
+	return errors.Trace(w.targetCharmChanged())
+}
+
+// targetCharmChanged records the charm the unit should be running.
+func (w *RemoteStateWatcher) targetCharmChanged() error {
+	url, force, err := w.unit.TargetCharmURL()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	w.mu.Lock()
 	w.current.CharmURL = url
 	w.current.ForceCharmUpgrade = force
-	w.current.CharmModifiedVersion = ver
 	w.mu.Unlock()
 	return nil
 }
@@ -287,6 +287,13 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 		s.applicationWatcher.changes <- struct{}{}
 		assertOneChange()
 		c.Assert(s.watcher.Snapshot().ForceCharmUpgrade, jc.IsTrue)
+
+		// A unit chosen for a charm rollout is told about it
+		// through changes to the unit.
+		s.st.unit.targetCharmURL = charm.MustParseURL("cs:trusty/mysql-2")
+		s.st.unit.unitWatcher.changes <- struct{}{}
+		assertOneChange()
+		c.Assert(s.watcher.Snapshot().CharmURL, jc.DeepEquals, s.st.unit.targetCharmURL)
 	}
 
 	s.clock.Advance(5 * time.Minute)
@@ -4,7 +4,10 @@
 package resolver
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charm.v6/hooks"
 
 	"github.com/juju/juju/worker/fortress"
@@ -25,6 +28,15 @@ type LoopConfig struct {
 	Abort         <-chan struct{}
 	OnIdle        func() error
 	CharmDirGuard fortress.Guard
+
+	// ObserveLatency, if set, is called with the time taken to act
+	// on each remote state change, from the loop noticing the change
+	// until it is waiting for the next one.
+	ObserveLatency func(time.Duration)
+
+	// Clock is used to time ObserveLatency. It defaults to the wall
+	// clock.
+	Clock clock.Clock
 }
 
 // Loop repeatedly waits for remote state changes, feeding the local and
@@ -55,7 +67,12 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 		return errors.Trace(err)
 	}
 
+	clk := cfg.Clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
 	for {
+		start := clk.Now()
 		rf.RemoteState = cfg.Watcher.Snapshot()
 		rf.LocalState.State = cfg.Executor.State()
 
@@ -92,6 +109,9 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 		default:
 			return err
 		}
+		if cfg.ObserveLatency != nil {
+			cfg.ObserveLatency(clk.Now().Sub(start))
+		}
 
 		select {
 		case <-cfg.Abort:
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
 
@@ -179,6 +180,44 @@ func (s *LoopSuite) TestLoop(c *gc.C) {
 	c.Assert(s.executor.Calls()[2].Args, jc.SameContents, []interface{}{theOp})
 }
 
+func (s *LoopSuite) TestObserveLatency(c *gc.C) {
+	var resolverCalls int
+	s.resolver = resolver.ResolverFunc(func(
+		_ resolver.LocalState,
+		_ remotestate.Snapshot,
+		_ operation.Factory,
+	) (operation.Operation, error) {
+		resolverCalls++
+		switch resolverCalls {
+		case 1:
+			return &mockOp{}, nil
+		case 2:
+			s.watcher.changes <- struct{}{}
+		case 3:
+			close(s.abort)
+		}
+		return nil, resolver.ErrNoOperation
+	})
+
+	clk := &steppingClock{now: time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)}
+	var observed []time.Duration
+	localState := resolver.LocalState{CharmURL: s.charmURL}
+	err := resolver.Loop(resolver.LoopConfig{
+		Resolver:      s.resolver,
+		Factory:       s.opFactory,
+		Watcher:       s.watcher,
+		Executor:      s.executor,
+		Abort:         s.abort,
+		CharmDirGuard: &mockCharmDirGuard{},
+		Clock:         clk,
+		ObserveLatency: func(d time.Duration) {
+			observed = append(observed, d)
+		},
+	}, &localState)
+	c.Assert(err, gc.Equals, resolver.ErrLoopAborted)
+	c.Assert(observed, jc.DeepEquals, []time.Duration{time.Second, time.Second})
+}
+
 func (s *LoopSuite) TestRunFails(c *gc.C) {
 	s.executor.SetErrors(errors.New("Run fails"))
 	s.resolver = resolver.ResolverFunc(func(
@@ -213,3 +252,14 @@ func waitChannel(c *gc.C, ch <-chan interface{}, activity string) interface{} {
 		panic("unreachable")
 	}
 }
+
+// steppingClock advances by a second each time Now is called.
+type steppingClock struct {
+	clock.Clock
+	now time.Time
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/version"
@@ -197,6 +198,14 @@ type HookContext struct {
 	// like a juju-run command or a hook
 	process HookProcess
 
+	// hookTimeout is the maximum time the hook may run before it is
+	// killed. Zero means the hook is never timed out.
+	hookTimeout time.Duration
+
+	// hookWarningThreshold is how long the hook may run before it is
+	// reported as long-running. Zero means it is never reported.
+	hookWarningThreshold time.Duration
+
 	// rebootPriority tells us when the hook wants to reboot. If rebootPriority is hooks.RebootNow
 	// the hook will be killed and requeued
 	rebootPriority jujuc.RebootPriority
@@ -231,6 +240,20 @@ type HookContext struct {
 
 	// The cloud specification
 	cloudSpec *params.CloudSpec
+
+	// unitState holds the unit's persistent state as read from the
+	// controller and modified by the running hook. It is nil until
+	// first accessed.
+	unitState map[string]string
+
+	// unitStateChanged is true if the running hook has modified the
+	// unit's persistent state. The state is written back to the
+	// controller in a flush.
+	unitStateChanged bool
+
+	// secrets provides access to the secrets owned by, or shared with,
+	// the unit's application.
+	secrets SecretsAccessor
 }
 
 // Component implements hooks.Context.
@@ -302,6 +325,24 @@ func (ctx *HookContext) Id() string {
 	return ctx.id
 }
 
+// HookTimeouts returns the maximum time the hook may run before it is
+// killed, and how long it may run before it is reported as
+// long-running. Zero values disable the respective behaviour.
+func (ctx *HookContext) HookTimeouts() (timeout, warningThreshold time.Duration) {
+	return ctx.hookTimeout, ctx.hookWarningThreshold
+}
+
+// Clock returns the clock used to time the hook's execution.
+func (ctx *HookContext) Clock() Clock {
+	return ctx.clock
+}
+
+// SetExecutingStatus updates the message of the unit agent's
+// executing status while a hook is running.
+func (ctx *HookContext) SetExecutingStatus(message string) error {
+	return ctx.unit.SetAgentStatus(status.Executing, message, nil)
+}
+
 func (ctx *HookContext) UnitName() string {
 	return ctx.unitName
 }
@@ -458,16 +499,24 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 }
 
 func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
+	return ctx.OpenEndpointPorts("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error {
+	return ctx.CloseEndpointPorts("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		protocol, fromPort, toPort, endpoint,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
 }
 
-func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error {
+func (ctx *HookContext) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
 	return tryClosePorts(
-		protocol, fromPort, toPort,
+		protocol, fromPort, toPort, endpoint,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -757,14 +806,16 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 			var e error
 			var op string
 			if rangeInfo.ShouldOpen {
-				e = ctx.unit.OpenPorts(
+				e = ctx.unit.OpenEndpointPorts(
+					rangeKey.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
 				)
 				op = "open"
 			} else {
-				e = ctx.unit.ClosePorts(
+				e = ctx.unit.CloseEndpointPorts(
+					rangeKey.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
@@ -793,6 +844,17 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	if ctx.unitStateChanged && writeChanges {
+		err := ctx.unit.SetState(ctx.unitState)
+		if err != nil {
+			err = errors.Annotatef(err, "cannot write unit state")
+			logger.Errorf("%v", err)
+			if ctxErr == nil {
+				ctxErr = err
+			}
+		}
+	}
+
 	// TODO (tasdomas) 2014 09 03: context finalization needs to modified to apply all
 	//                             changes in one api call to minimize the risk
 	//                             of partial failures.
@@ -804,6 +866,104 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 	return ctxErr
 }
 
+// UnitState implements jujuc.Context.
+func (ctx *HookContext) UnitState() (map[string]string, error) {
+	if err := ctx.ensureUnitState(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string, len(ctx.unitState))
+	for key, value := range ctx.unitState {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// SetUnitStateValue implements jujuc.Context.
+func (ctx *HookContext) SetUnitStateValue(key, value string) error {
+	if err := ctx.ensureUnitState(); err != nil {
+		return errors.Trace(err)
+	}
+	if current, ok := ctx.unitState[key]; ok && current == value {
+		return nil
+	}
+	ctx.unitState[key] = value
+	ctx.unitStateChanged = true
+	return nil
+}
+
+// DeleteUnitStateValue implements jujuc.Context.
+func (ctx *HookContext) DeleteUnitStateValue(key string) error {
+	if err := ctx.ensureUnitState(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := ctx.unitState[key]; !ok {
+		return nil
+	}
+	delete(ctx.unitState, key)
+	ctx.unitStateChanged = true
+	return nil
+}
+
+// CreateSecret implements jujuc.Context.
+func (ctx *HookContext) CreateSecret(name string, args jujuc.SecretCreateArgs) (string, error) {
+	if ctx.secrets == nil {
+		return "", errors.NotSupportedf("secrets")
+	}
+	relations := make([]names.RelationTag, len(args.RelationIds))
+	for i, id := range args.RelationIds {
+		tag, err := ctx.relationTag(id)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		relations[i] = tag
+	}
+	return ctx.secrets.CreateSecret(name, args.Description, args.Value, relations...)
+}
+
+// GetSecret implements jujuc.Context.
+func (ctx *HookContext) GetSecret(id string, revision int) (secrets.Value, error) {
+	if ctx.secrets == nil {
+		return nil, errors.NotSupportedf("secrets")
+	}
+	return ctx.secrets.GetSecretValue(id, revision)
+}
+
+// RevokeSecret implements jujuc.Context.
+func (ctx *HookContext) RevokeSecret(id string, relationId int) error {
+	if ctx.secrets == nil {
+		return errors.NotSupportedf("secrets")
+	}
+	var relation *names.RelationTag
+	if relationId != -1 {
+		tag, err := ctx.relationTag(relationId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		relation = &tag
+	}
+	return ctx.secrets.RevokeSecret(id, relation)
+}
+
+func (ctx *HookContext) relationTag(id int) (names.RelationTag, error) {
+	r, found := ctx.relations[id]
+	if !found {
+		return names.RelationTag{}, errors.NotFoundf("relation %d", id)
+	}
+	return r.ru.Relation().Tag(), nil
+}
+
+func (ctx *HookContext) ensureUnitState() error {
+	if ctx.unitState != nil {
+		return nil
+	}
+	unitState, err := ctx.unit.State()
+	if err != nil {
+		return errors.Annotate(err, "cannot read unit state")
+	}
+	ctx.unitState = unitState
+	return nil
+}
+
 // finalizeAction passes back the final status of an Action hook to state.
 // It wraps any errors which occurred in normal behavior of the Action run;
 // only errors passed in unhandledErr will be returned.
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -56,6 +57,22 @@ type StorageContextAccessor interface {
 	Storage(names.StorageTag) (jujuc.ContextStorageAttachment, error)
 }
 
+// SecretsAccessor is an interface providing access to the secrets
+// owned by, or shared with, the unit's application.
+type SecretsAccessor interface {
+
+	// CreateSecret creates a secret, or a new revision of an existing
+	// secret, and grants access to it over the supplied relations.
+	CreateSecret(name, description string, value secrets.Value, relations ...names.RelationTag) (string, error)
+
+	// GetSecretValue returns the value of the given revision of a secret.
+	GetSecretValue(id string, revision int) (secrets.Value, error)
+
+	// RevokeSecret revokes access to a secret granted over the supplied
+	// relation, or over all relations if relation is nil.
+	RevokeSecret(id string, relation *names.RelationTag) error
+}
+
 // RelationsFunc is used to get snapshots of relation membership at context
 // creation time.
 type RelationsFunc func() map[int]*RelationInfo
@@ -73,6 +90,7 @@ type contextFactory struct {
 	modelType  model.ModelType
 	machineTag names.MachineTag
 	storage    StorageContextAccessor
+	secrets    SecretsAccessor
 	clock      Clock
 	zone       string
 	principal  string
@@ -93,6 +111,7 @@ type FactoryConfig struct {
 	Tracker          leadership.Tracker
 	GetRelationInfos RelationsFunc
 	Storage          StorageContextAccessor
+	Secrets          SecretsAccessor
 	Paths            Paths
 	Clock            Clock
 }
@@ -141,6 +160,7 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 		getRelationInfos: config.GetRelationInfos,
 		relationCaches:   map[int]*RelationCache{},
 		storage:          config.Storage,
+		secrets:          config.Secrets,
 		rand:             rand.New(rand.NewSource(time.Now().Unix())),
 		clock:            config.Clock,
 		zone:             zone,
@@ -175,6 +195,7 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		relationId:         -1,
 		pendingPorts:       make(map[PortRange]PortRangeInfo),
 		storage:            f.storage,
+		secrets:            f.secrets,
 		clock:              f.clock,
 		componentDir:       f.paths.ComponentDir,
 		componentFuncs:     registeredComponentFuncs,
@@ -223,7 +244,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
-	if hookInfo.Kind.IsStorage() {
+	if hook.IsStorage(hookInfo.Kind) {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		if _, err := ctx.storage.Storage(ctx.storageTag); err != nil {
 			return nil, errors.Annotatef(err, "could not retrieve storage for id: %v", hookInfo.StorageId)
@@ -234,6 +255,13 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	ctx.hookTimeout, ctx.hookWarningThreshold, err = f.unit.HookTimeouts()
+	if errors.IsNotImplemented(err) {
+		// Older controllers don't support hook timeouts.
+		ctx.hookTimeout, ctx.hookWarningThreshold = 0, 0
+	} else if err != nil {
+		return nil, errors.Annotate(err, "could not retrieve hook timeouts")
+	}
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -133,6 +133,20 @@ func (s *ContextFactorySuite) TestNewHookContextRetrievesSLALevel(c *gc.C) {
 	c.Assert(ctx.SLALevel(), gc.Equals, "essential")
 }
 
+func (s *ContextFactorySuite) TestNewHookContextRetrievesHookTimeouts(c *gc.C) {
+	err := s.Model(c).UpdateModelConfig(map[string]interface{}{
+		"hook-timeout":           "20m",
+		"hook-warning-threshold": "2m",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	timeout, warningThreshold := ctx.HookTimeouts()
+	c.Assert(timeout, gc.Equals, 20*time.Minute)
+	c.Assert(warningThreshold, gc.Equals, 2*time.Minute)
+}
+
 func (s *ContextFactorySuite) TestNewHookContextLeadershipContext(c *gc.C) {
 	s.testLeadershipContextWiring(c, func() *context.HookContext {
 		ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
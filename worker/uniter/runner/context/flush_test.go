@@ -212,6 +212,44 @@ func (s *FlushContextSuite) TestRunHookAddUnitStorageOnSuccess(c *gc.C) {
 	c.Assert(all, gc.HasLen, 0)
 }
 
+func (s *FlushContextSuite) TestRunHookUnitStateOnFailure(c *gc.C) {
+	ctx := s.context(c)
+	err := ctx.SetUnitStateValue("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with an error.
+	err = ctx.Flush("some badge", errors.New("blam pow"))
+	c.Assert(err, gc.ErrorMatches, "blam pow")
+
+	// Check that the changes have not been written to state.
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, gc.HasLen, 0)
+}
+
+func (s *FlushContextSuite) TestRunHookUnitStateOnSuccess(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"foo": "bar", "baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.context(c)
+	err = ctx.SetUnitStateValue("foo", "new")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteUnitStateValue("baz")
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err := ctx.UnitState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"foo": "new"})
+
+	// Flush the context with a success.
+	err = ctx.Flush("success", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Check that the changes have been written to state.
+	unitState, err = s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"foo": "new"})
+}
+
 func (s *HookContextSuite) context(c *gc.C) *context.HookContext {
 	uuid, err := utils.NewUUID()
 	c.Assert(err, jc.ErrorIsNil)
@@ -21,11 +21,13 @@ type PortRangeInfo struct {
 	RelationTag names.RelationTag
 }
 
-// PortRange contains a port range and a relation id. Used as key to
-// pendingRelations and is only exported for testing.
+// PortRange contains a port range, a relation id and the endpoint the
+// range applies to, if any. Used as key to pendingRelations and is only
+// exported for testing.
 type PortRange struct {
 	Ports      network.PortRange
 	RelationId int
+	Endpoint   string
 }
 
 func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange, error) {
@@ -44,6 +46,7 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 func tryOpenPorts(
 	protocol string,
 	fromPort, toPort int,
+	endpoint string,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
 	pendingPorts map[PortRange]PortRangeInfo,
@@ -61,6 +64,7 @@ func tryOpenPorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -87,9 +91,13 @@ func tryOpenPorts(
 		}
 		if newRange.ConflictsWith(portRange) {
 			if portRange == newRange && relUnitTag == unitTag {
-				// The same unit trying to open the same range is just
-				// ignored.
-				return nil
+				if endpoint == "" {
+					// The same unit trying to open the same range is
+					// just ignored.
+					return nil
+				}
+				// The range may not be open for this endpoint yet.
+				continue
 			}
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with existing %v (unit %q)",
@@ -99,6 +107,10 @@ func tryOpenPorts(
 	}
 	// Ensure other pending port ranges do not conflict with this one.
 	for rangeKey, rangeInfo := range pendingPorts {
+		if rangeKey.Ports == newRange {
+			// The same range may be opened for other endpoints.
+			continue
+		}
 		if newRange.ConflictsWith(rangeKey.Ports) && rangeInfo.ShouldOpen {
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with %v requested earlier",
@@ -116,6 +128,7 @@ func tryOpenPorts(
 func tryClosePorts(
 	protocol string,
 	fromPort, toPort int,
+	endpoint string,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
 	pendingPorts map[PortRange]PortRangeInfo,
@@ -133,6 +146,7 @@ func tryClosePorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
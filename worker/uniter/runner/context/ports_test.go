@@ -116,6 +116,12 @@ func makeMachinePorts(
 
 func makePendingPorts(
 	proto string, fromPort, toPort int, shouldOpen bool,
+) map[context.PortRange]context.PortRangeInfo {
+	return makeEndpointPendingPorts("", proto, fromPort, toPort, shouldOpen)
+}
+
+func makeEndpointPendingPorts(
+	endpoint, proto string, fromPort, toPort int, shouldOpen bool,
 ) map[context.PortRange]context.PortRangeInfo {
 	result := make(map[context.PortRange]context.PortRangeInfo)
 	portRange := network.PortRange{
@@ -126,6 +132,7 @@ func makePendingPorts(
 	key := context.PortRange{
 		Ports:      portRange,
 		RelationId: -1,
+		Endpoint:   endpoint,
 	}
 	result[key] = context.PortRangeInfo{
 		ShouldOpen: shouldOpen,
@@ -137,6 +144,7 @@ type portsTest struct {
 	about         string
 	proto         string
 	ports         []int
+	endpoint      string
 	machinePorts  map[network.PortRange]params.RelationUnit
 	pendingPorts  map[context.PortRange]context.PortRangeInfo
 	expectErr     string
@@ -196,6 +204,24 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
 		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with 5-25/tcp requested earlier`,
+	}, {
+		about:         "open an existing range for an endpoint",
+		endpoint:      "website",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: makeEndpointPendingPorts("website", "tcp", 10, 20, true),
+	}, {
+		about:        "open a range for an endpoint when pending for another endpoint",
+		endpoint:     "website",
+		pendingPorts: makeEndpointPendingPorts("admin", "tcp", 10, 20, true),
+		expectPending: map[context.PortRange]context.PortRangeInfo{
+			{Ports: network.PortRange{10, 20, "tcp"}, RelationId: -1, Endpoint: "admin"}:   {ShouldOpen: true},
+			{Ports: network.PortRange{10, 20, "tcp"}, RelationId: -1, Endpoint: "website"}: {ShouldOpen: true},
+		},
+	}, {
+		about:        "try opening a range for an endpoint conflicting with another unit",
+		endpoint:     "website",
+		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
+		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with existing 10-20/tcp \(unit "u/1"\)`,
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
@@ -205,6 +231,7 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 			test.proto,
 			test.ports[0],
 			test.ports[1],
+			test.endpoint,
 			names.NewUnitTag("u/0"),
 			test.machinePorts,
 			test.pendingPorts,
@@ -250,6 +277,11 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 		about:        "try closing a range of another unit",
 		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
 		expectErr:    `cannot close 10-20/tcp \(opened by "u/1"\) from "u/0"`,
+	}, {
+		about:         "close an existing range for an endpoint",
+		endpoint:      "website",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: makeEndpointPendingPorts("website", "tcp", 10, 20, false),
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
@@ -259,6 +291,7 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 			test.proto,
 			test.ports[0],
 			test.ports[1],
+			test.endpoint,
 			names.NewUnitTag("u/0"),
 			test.machinePorts,
 			test.pendingPorts,
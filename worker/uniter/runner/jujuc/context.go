@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
@@ -41,6 +42,8 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextUnitState
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -154,6 +157,14 @@ type ContextNetworking interface {
 	// separately by a co- located unit).
 	ClosePorts(protocol string, fromPort, toPort int) error
 
+	// OpenEndpointPorts marks the supplied port range for opening for
+	// the given endpoint when the endpoint is exposed.
+	OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error
+
+	// CloseEndpointPorts ensures the supplied port range is closed for
+	// the given endpoint.
+	CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error
+
 	// OpenedPorts returns all port ranges currently opened by this
 	// unit on its assigned machine. The result is sorted first by
 	// protocol, then by number.
@@ -289,6 +300,11 @@ type ContextStorageAttachment interface {
 	// Location returns the location of the storage: the mount point for
 	// filesystem-kind stores, and the device path for block-kind stores.
 	Location() string
+
+	// UnitLocations returns the location of shared storage on each of
+	// the units it is attached to, keyed by unit name. The result is
+	// empty for storage that is not shared.
+	UnitLocations() (map[string]string, error)
 }
 
 // ContextVersion expresses the parts of a hook context related to
@@ -303,6 +319,56 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextUnitState expresses the parts of a hook context related to
+// the unit's persistent state, which is stored on the controller.
+type ContextUnitState interface {
+
+	// UnitState returns a copy of the unit's persistent state.
+	UnitState() (map[string]string, error)
+
+	// SetUnitStateValue sets the value of the given key in the unit's
+	// persistent state. The change is written to the controller once
+	// the hook completes successfully.
+	SetUnitStateValue(key, value string) error
+
+	// DeleteUnitStateValue removes the given key from the unit's
+	// persistent state. The change is written to the controller once
+	// the hook completes successfully.
+	DeleteUnitStateValue(key string) error
+}
+
+// ContextSecrets expresses the parts of a hook context related to
+// secrets, which are stored on the controller.
+type ContextSecrets interface {
+
+	// CreateSecret creates a secret owned by the unit's application,
+	// or adds a new revision to it if it already exists, and returns
+	// the secret's ID.
+	CreateSecret(name string, args SecretCreateArgs) (string, error)
+
+	// GetSecret returns the value of the given revision of a secret.
+	// A zero revision denotes the latest revision.
+	GetSecret(id string, revision int) (secrets.Value, error)
+
+	// RevokeSecret revokes the access to a secret that was granted
+	// over the relation with the given id. If relationId is -1, all
+	// access granted to other applications is revoked.
+	RevokeSecret(id string, relationId int) error
+}
+
+// SecretCreateArgs holds the arguments used to create a secret.
+type SecretCreateArgs struct {
+	// Description optionally describes the secret.
+	Description string
+
+	// Value holds the key/value pairs of the secret.
+	Value secrets.Value
+
+	// RelationIds holds the ids of the relations over which access
+	// to the secret is granted to the remote application.
+	RelationIds []int
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
@@ -23,6 +23,8 @@ type ContextInfo struct {
 	RelationHook
 	ActionHook
 	Version
+	UnitState
+	Secrets
 }
 
 // Context returns a Context that wraps the info.
@@ -63,6 +65,8 @@ type Context struct {
 	ContextRelationHook
 	ContextActionHook
 	ContextVersion
+	ContextUnitState
+	ContextSecrets
 }
 
 // NewContext builds a jujuc.Context test double.
@@ -92,5 +96,9 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextActionHook.info = &info.ActionHook
 	ctx.ContextVersion.stub = stub
 	ctx.ContextVersion.info = &info.Version
+	ctx.ContextUnitState.stub = stub
+	ctx.ContextUnitState.info = &info.UnitState
+	ctx.ContextSecrets.stub = stub
+	ctx.ContextSecrets.info = &info.Secrets
 	return &ctx
 }
@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	// Owner is the name of the unit's application.
+	Owner string
+
+	// Values holds the latest value of each secret, keyed on ID.
+	Values map[string]secrets.Value
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) CreateSecret(name string, args jujuc.SecretCreateArgs) (string, error) {
+	c.stub.AddCall("CreateSecret", name, args)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	id := secrets.NewID(c.info.Owner, name)
+	if c.info.Values == nil {
+		c.info.Values = make(map[string]secrets.Value)
+	}
+	c.info.Values[id] = args.Value
+	return id, nil
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(id string, revision int) (secrets.Value, error) {
+	c.stub.AddCall("GetSecret", id, revision)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, ok := c.info.Values[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	return value, nil
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) RevokeSecret(id string, relationId int) error {
+	c.stub.AddCall("RevokeSecret", id, relationId)
+	return c.stub.NextErr()
+}
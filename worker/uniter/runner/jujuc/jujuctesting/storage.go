@@ -37,7 +37,18 @@ func (s *Storage) SetAttachment(attach jujuc.ContextStorageAttachment) {
 func (s *Storage) SetNewAttachment(name, location string, kind storage.StorageKind, stub *testing.Stub) {
 	tag := names.NewStorageTag(name)
 	attachment := &ContextStorageAttachment{
-		info: &StorageAttachment{tag, kind, location},
+		info: &StorageAttachment{tag, kind, location, nil},
+	}
+	attachment.stub = stub
+	s.SetAttachment(attachment)
+}
+
+// SetSharedFilesystemStorage adds an attachment for shared filesystem
+// storage with the given locations on each unit, keyed by unit name.
+func (s *Storage) SetSharedFilesystemStorage(name, location string, unitLocations map[string]string, stub *testing.Stub) {
+	tag := names.NewStorageTag(name)
+	attachment := &ContextStorageAttachment{
+		info: &StorageAttachment{tag, storage.StorageKindFilesystem, location, unitLocations},
 	}
 	attachment.stub = stub
 	s.SetAttachment(attachment)
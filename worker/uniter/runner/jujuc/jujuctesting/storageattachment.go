@@ -11,9 +11,10 @@ import (
 
 // StorageAttachment holds the data for the test double.
 type StorageAttachment struct {
-	Tag      names.StorageTag
-	Kind     storage.StorageKind
-	Location string
+	Tag           names.StorageTag
+	Kind          storage.StorageKind
+	Location      string
+	UnitLocations map[string]string
 }
 
 // ContextStorageAttachment is a test double for jujuc.ContextStorageAttachment.
@@ -45,3 +46,13 @@ func (c *ContextStorageAttachment) Location() string {
 
 	return c.info.Location
 }
+
+// UnitLocations implements jujuc.StorageAttachement.
+func (c *ContextStorageAttachment) UnitLocations() (map[string]string, error) {
+	c.stub.AddCall("UnitLocations")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, err
+	}
+
+	return c.info.UnitLocations, nil
+}
@@ -128,6 +128,8 @@ type portCommand struct {
 	Protocol   string
 	FromPort   int
 	ToPort     int
+	Endpoints  []string
+	endpoints  string
 	formatFlag string // deprecated
 }
 
@@ -137,6 +139,7 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	f.StringVar(&c.endpoints, "endpoints", "", "a comma-delimited list of application endpoints to target with this operation")
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -152,6 +155,17 @@ func (c *portCommand) Init(args []string) error {
 	c.FromPort = portRange.fromPort
 	c.ToPort = portRange.toPort
 	c.Protocol = portRange.protocol
+
+	c.Endpoints = nil
+	if c.endpoints != "" {
+		for _, endpoint := range strings.Split(c.endpoints, ",") {
+			endpoint = strings.TrimSpace(endpoint)
+			if endpoint == "" {
+				return errors.Errorf("invalid endpoints %q", c.endpoints)
+			}
+			c.Endpoints = append(c.Endpoints, endpoint)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -166,14 +180,28 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+
+By default the port range is opened for all of the application's
+endpoints. With --endpoints, it is opened for the listed endpoints
+only, and is reachable from the sources the application exposes
+those endpoints to.`[1:],
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: openPortInfo,
 		action: func(c *portCommand) error {
-			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			if len(c.Endpoints) == 0 {
+				return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			for _, endpoint := range c.Endpoints {
+				if err := ctx.OpenEndpointPorts(endpoint, c.Protocol, c.FromPort, c.ToPort); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
 		},
 	}, nil
 }
@@ -182,13 +210,24 @@ var closePortInfo = &cmd.Info{
 	Name:    "close-port",
 	Args:    portFormat,
 	Purpose: "ensure a port or range is always closed",
+	Doc: `
+With --endpoints, only the port range opened for the listed endpoints
+is closed.`[1:],
 }
 
 func NewClosePortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: closePortInfo,
 		action: func(c *portCommand) error {
-			return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			if len(c.Endpoints) == 0 {
+				return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			for _, endpoint := range c.Endpoints {
+				if err := ctx.CloseEndpointPorts(endpoint, c.Protocol, c.FromPort, c.ToPort); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
 		},
 	}, nil
 }
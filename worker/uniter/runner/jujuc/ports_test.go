@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -136,6 +137,11 @@ register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+
+By default the port range is opened for all of the application's
+endpoints. With --endpoints, it is opened for the listed endpoints
+only, and is reachable from the sources the application exposes
+those endpoints to.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
@@ -145,9 +151,39 @@ Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp
 
 Summary:
 ensure a port or range is always closed
+
+Details:
+With --endpoints, only the port range opened for the listed endpoints
+is closed.
 `[1:])
 }
 
+func (s *PortsSuite) TestOpenCloseEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for _, name := range []string{"open-port", "close-port"} {
+		com, err := jujuc.NewCommand(hctx, cmdString(name))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, []string{"--endpoints", "website, admin", "80"})
+		c.Check(code, gc.Equals, 0)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	}
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"OpenEndpointPorts", []interface{}{"website", "tcp", 80, 80}},
+		{"OpenEndpointPorts", []interface{}{"admin", "tcp", 80, 80}},
+		{"CloseEndpointPorts", []interface{}{"website", "tcp", 80, 80}},
+		{"CloseEndpointPorts", []interface{}{"admin", "tcp", 80, 80}},
+	})
+}
+
+func (s *PortsSuite) TestBadEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("open-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, []string{"--endpoints", "website,,admin", "80"})
+	c.Assert(err, gc.ErrorMatches, `invalid endpoints "website,,admin"`)
+}
+
 // Since the deprecation warning gets output during Run, we really need
 // some valid commands to run
 var portsFormatDeprectaionTests = []struct {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/network"
 )
 
@@ -82,6 +83,16 @@ func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) erro
 	return ErrRestrictedContext
 }
 
+// OpenEndpointPorts implements hooks.Context.
+func (*RestrictedContext) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
+// CloseEndpointPorts implements hooks.Context.
+func (*RestrictedContext) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
 // OpenedPorts implements hooks.Context.
 func (*RestrictedContext) OpenedPorts() []network.PortRange { return nil }
 
@@ -173,3 +184,33 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// UnitState implements hooks.Context.
+func (*RestrictedContext) UnitState() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// SetUnitStateValue implements hooks.Context.
+func (*RestrictedContext) SetUnitStateValue(string, string) error {
+	return ErrRestrictedContext
+}
+
+// DeleteUnitStateValue implements hooks.Context.
+func (*RestrictedContext) DeleteUnitStateValue(string) error {
+	return ErrRestrictedContext
+}
+
+// CreateSecret implements hooks.Context.
+func (*RestrictedContext) CreateSecret(string, SecretCreateArgs) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// GetSecret implements hooks.Context.
+func (*RestrictedContext) GetSecret(string, int) (secrets.Value, error) {
+	return nil, ErrRestrictedContext
+}
+
+// RevokeSecret implements hooks.Context.
+func (*RestrictedContext) RevokeSecret(string, int) error {
+	return ErrRestrictedContext
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+// secretAddCommand implements the secret-add command.
+type secretAddCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	name        string
+	description string
+	value       map[string]string
+	relationId  int
+	relation    *relationIdValue
+}
+
+// NewSecretAddCommand returns a new secretAddCommand with the given context.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	c := &secretAddCommand{ctx: ctx, relationId: -1}
+	c.relation = &relationIdValue{result: &c.relationId, ctx: ctx}
+	return c, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+secret-add creates a secret owned by the unit's application, holding the
+supplied key/value pairs, and prints the secret's ID. If the application
+already owns a secret with the given name, a new revision of the secret is
+created instead.
+
+The secret's value is stored encrypted on the controller; pass the ID to
+other applications, for example in relation data, so that they can read it
+with secret-get once they have been granted access.
+
+If a relation is specified, the application at the other end of that
+relation is granted access to the secret.
+`
+	return &cmd.Info{
+		Name:    "secret-add",
+		Args:    "<name> <key>=<value> [...]",
+		Purpose: "add a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.description, "description", "", "the secret description")
+	f.Var(c.relation, "r", "grant access to the remote application of this relation")
+	f.Var(c.relation, "relation", "")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretAddCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret name specified")
+	}
+	c.name = args[0]
+	if !secrets.IsValidName(c.name) {
+		return errors.NotValidf("secret name %q", c.name)
+	}
+	if len(args) == 1 {
+		return errors.New("no key/value pairs specified")
+	}
+	c.value, err = keyvalues.Parse(args[1:], true)
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	args := SecretCreateArgs{
+		Description: c.description,
+		Value:       c.value,
+	}
+	if c.relationId != -1 {
+		args.RelationIds = []int{c.relationId}
+	}
+	id, err := c.ctx.CreateSecret(c.name, args)
+	if err != nil {
+		return errors.Annotate(err, "cannot add secret")
+	}
+	fmt.Fprintln(ctx.Stdout, id)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type secretAddSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&secretAddSuite{})
+
+func (s *secretAddSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Secrets.Owner = "mysql"
+	hctx.info.SetNewRelation(1, "db", s.Stub)
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *secretAddSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret name specified",
+	}, {
+		args: []string{"Password"},
+		err:  `secret name "Password" not valid`,
+	}, {
+		args: []string{"password"},
+		err:  "no key/value pairs specified",
+	}, {
+		args: []string{"password", "foo"},
+		err:  `expected "key=value", got "foo"`,
+	}, {
+		args: []string{"-r", "99", "password", "foo=bar"},
+		err:  `invalid value "99" for flag -r: relation not found`,
+	}} {
+		_, com := s.createCommand(c, nil)
+		err := cmdtesting.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *secretAddSuite) TestAdd(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--description", "db password", "password", "user=admin", "pass=s3cret"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "secret:mysql/password\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, len(s.Stub.Calls())-1, "CreateSecret", "password", jujuc.SecretCreateArgs{
+		Description: "db password",
+		Value:       map[string]string{"user": "admin", "pass": "s3cret"},
+	})
+	c.Check(hctx.info.Secrets.Values, jc.DeepEquals, map[string]secrets.Value{
+		"secret:mysql/password": {"user": "admin", "pass": "s3cret"},
+	})
+}
+
+func (s *secretAddSuite) TestAddWithRelation(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"-r", "db:1", "password", "pass=s3cret"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "secret:mysql/password\n")
+	s.Stub.CheckCall(c, len(s.Stub.Calls())-1, "CreateSecret", "password", jujuc.SecretCreateArgs{
+		Value:       map[string]string{"pass": "s3cret"},
+		RelationIds: []int{1},
+	})
+}
+
+func (s *secretAddSuite) TestAddError(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"password", "pass=s3cret"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot add secret: zap\n")
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+// secretGetCommand implements the secret-get command.
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id       string
+	key      string
+	revision int
+	out      cmd.Output
+}
+
+// NewSecretGetCommand returns a new secretGetCommand with the given context.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+secret-get prints the value of the secret with the given ID. If a key is
+given, only the value of that key is printed.
+
+The unit's application must own the secret, or have been granted access to
+it by the secret's owner. By default the latest revision of the secret is
+printed.
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "<ID> [<key>]",
+		Purpose: "print a secret value",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.IntVar(&c.revision, "revision", 0, "the revision of the secret to print")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGetCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret ID specified")
+	}
+	c.id, args = args[0], args[1:]
+	if _, _, err := secrets.ParseID(c.id); err != nil {
+		return errors.Trace(err)
+	}
+	if c.revision < 0 {
+		return errors.NotValidf("negative revision")
+	}
+	if len(args) > 0 {
+		c.key, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	value, err := c.ctx.GetSecret(c.id, c.revision)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get secret %q", c.id)
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, map[string]string(value))
+	}
+	if v, ok := value[c.key]; ok {
+		return c.out.Write(ctx, v)
+	}
+	return errors.NotFoundf("key %q in secret %q", c.key, c.id)
+}
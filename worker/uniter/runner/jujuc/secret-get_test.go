@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type secretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&secretGetSuite{})
+
+func (s *secretGetSuite) createCommand(c *gc.C, err error) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Secrets.Values = map[string]secrets.Value{
+		"secret:mysql/password": {"user": "admin", "pass": "s3cret"},
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *secretGetSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret ID specified",
+	}, {
+		args: []string{"mysql/password"},
+		err:  `secret ID "mysql/password" not valid`,
+	}, {
+		args: []string{"--revision", "-1", "secret:mysql/password"},
+		err:  "negative revision not valid",
+	}, {
+		args: []string{"secret:mysql/password", "user", "pass"},
+		err:  `unrecognized args: \["pass"\]`,
+	}} {
+		com := s.createCommand(c, nil)
+		err := cmdtesting.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *secretGetSuite) TestGetKey(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret:mysql/password", "pass"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "s3cret\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, 0, "GetSecret", "secret:mysql/password", 0)
+}
+
+func (s *secretGetSuite) TestGetAll(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "yaml", "--revision", "2", "secret:mysql/password"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), jc.YAMLEquals, map[string]string{
+		"user": "admin",
+		"pass": "s3cret",
+	})
+	s.Stub.CheckCall(c, 0, "GetSecret", "secret:mysql/password", 2)
+}
+
+func (s *secretGetSuite) TestGetMissingKey(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret:mysql/password", "token"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR key "token" in secret "secret:mysql/password" not found`+"\n")
+}
+
+func (s *secretGetSuite) TestGetError(c *gc.C) {
+	com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret:mysql/password"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR cannot get secret "secret:mysql/password": zap`+"\n")
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+// secretRevokeCommand implements the secret-revoke command.
+type secretRevokeCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id         string
+	relationId int
+	relation   *relationIdValue
+}
+
+// NewSecretRevokeCommand returns a new secretRevokeCommand with the given
+// context.
+func NewSecretRevokeCommand(ctx Context) (cmd.Command, error) {
+	c := &secretRevokeCommand{ctx: ctx, relationId: -1}
+	c.relation = &relationIdValue{result: &c.relationId, ctx: ctx}
+	return c, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretRevokeCommand) Info() *cmd.Info {
+	doc := `
+secret-revoke revokes access to a secret owned by the unit's application.
+If a relation is specified, only the access granted to the application at
+the other end of that relation is revoked; otherwise, all other applications
+lose access to the secret.
+`
+	return &cmd.Info{
+		Name:    "secret-revoke",
+		Args:    "<ID>",
+		Purpose: "revoke access to a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretRevokeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(c.relation, "r", "revoke access granted over this relation")
+	f.Var(c.relation, "relation", "")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretRevokeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret ID specified")
+	}
+	c.id = args[0]
+	if _, _, err := secrets.ParseID(c.id); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretRevokeCommand) Run(_ *cmd.Context) error {
+	if err := c.ctx.RevokeSecret(c.id, c.relationId); err != nil {
+		return errors.Annotatef(err, "cannot revoke access to secret %q", c.id)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type secretRevokeSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&secretRevokeSuite{})
+
+func (s *secretRevokeSuite) createCommand(c *gc.C, err error) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.SetNewRelation(1, "db", s.Stub)
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-revoke"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *secretRevokeSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret ID specified",
+	}, {
+		args: []string{"password"},
+		err:  `secret ID "password" not valid`,
+	}, {
+		args: []string{"secret:mysql/password", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		com := s.createCommand(c, nil)
+		err := cmdtesting.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *secretRevokeSuite) TestRevokeRelation(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"-r", "1", "secret:mysql/password"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, len(s.Stub.Calls())-1, "RevokeSecret", "secret:mysql/password", 1)
+}
+
+func (s *secretRevokeSuite) TestRevokeAll(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret:mysql/password"})
+	c.Check(code, gc.Equals, 0)
+	s.Stub.CheckCall(c, len(s.Stub.Calls())-1, "RevokeSecret", "secret:mysql/password", -1)
+}
+
+func (s *secretRevokeSuite) TestRevokeError(c *gc.C) {
+	com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret:mysql/password"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR cannot revoke access to secret "secret:mysql/password": zap`+"\n")
+}
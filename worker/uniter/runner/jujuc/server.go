@@ -80,6 +80,18 @@ var leaderCommands = map[string]creator{
 	"leader-set" + cmdSuffix: NewLeaderSetCommand,
 }
 
+var unitStateCommands = map[string]creator{
+	"state-delete" + cmdSuffix: NewStateDeleteCommand,
+	"state-get" + cmdSuffix:    NewStateGetCommand,
+	"state-set" + cmdSuffix:    NewStateSetCommand,
+}
+
+var secretsCommands = map[string]creator{
+	"secret-add" + cmdSuffix:    NewSecretAddCommand,
+	"secret-get" + cmdSuffix:    NewSecretGetCommand,
+	"secret-revoke" + cmdSuffix: NewSecretRevokeCommand,
+}
+
 func allEnabledCommands() map[string]creator {
 	all := map[string]creator{}
 	add := func(m map[string]creator) {
@@ -90,6 +102,8 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(unitStateCommands)
+	add(secretsCommands)
 	add(registeredCommands)
 	return all
 }
@@ -0,0 +1,62 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// stateDeleteCommand implements the state-delete command.
+type stateDeleteCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	keys []string
+}
+
+// NewStateDeleteCommand returns a new stateDeleteCommand with the given context.
+func NewStateDeleteCommand(ctx Context) (cmd.Command, error) {
+	return &stateDeleteCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Info() *cmd.Info {
+	doc := `
+state-delete removes the specified keys from the unit's persistent state.
+Keys that are not set are ignored. The change is stored on the controller
+when the hook completes successfully.
+`
+	return &cmd.Info{
+		Name:    "state-delete",
+		Args:    "<key> [...]",
+		Purpose: "delete unit state",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no keys specified")
+	}
+	for _, key := range args {
+		if key == "" || strings.Contains(key, "=") {
+			return errors.Errorf("invalid key %q", key)
+		}
+	}
+	c.keys = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Run(_ *cmd.Context) error {
+	for _, key := range c.keys {
+		if err := c.ctx.DeleteUnitStateValue(key); err != nil {
+			return errors.Annotate(err, "cannot delete unit state")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateDeleteSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateDeleteSuite{})
+
+func (s *stateDeleteSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.UnitState.State = map[string]string{
+		"one":   "two",
+		"three": "four",
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("state-delete"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *stateDeleteSuite) TestInitNoArguments(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := cmdtesting.InitCommand(com, nil)
+	c.Assert(err, gc.ErrorMatches, "no keys specified")
+}
+
+func (s *stateDeleteSuite) TestInitInvalidKey(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := cmdtesting.InitCommand(com, []string{"one=two"})
+	c.Assert(err, gc.ErrorMatches, `invalid key "one=two"`)
+}
+
+func (s *stateDeleteSuite) TestDelete(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"one", "five"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.UnitState.State, jc.DeepEquals, map[string]string{"three": "four"})
+}
+
+func (s *stateDeleteSuite) TestDeleteError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"one"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot delete unit state: zap\n")
+	c.Check(hctx.info.UnitState.State, gc.HasLen, 2)
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// stateGetCommand implements the state-get command.
+type stateGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	key string
+	out cmd.Output
+}
+
+// NewStateGetCommand returns a new stateGetCommand with the given context.
+func NewStateGetCommand(ctx Context) (cmd.Command, error) {
+	return &stateGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateGetCommand) Info() *cmd.Info {
+	doc := `
+state-get prints the value of the unit's persistent state specified by key.
+If no key is given, or if the key is "-", all keys and values will be printed.
+
+The unit's state is stored on the controller, so it survives the unit's
+machine or pod being replaced.
+`
+	return &cmd.Info{
+		Name:    "state-get",
+		Args:    "[<key>]",
+		Purpose: "print unit state",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *stateGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateGetCommand) Init(args []string) error {
+	c.key = ""
+	if len(args) == 0 {
+		return nil
+	}
+	key := args[0]
+	if key == "-" {
+		key = ""
+	} else if strings.Contains(key, "=") {
+		return errors.Errorf("invalid key %q", key)
+	}
+	c.key = key
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateGetCommand) Run(ctx *cmd.Context) error {
+	state, err := c.ctx.UnitState()
+	if err != nil {
+		return errors.Annotate(err, "cannot read unit state")
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, state)
+	}
+	if value, ok := state[c.key]; ok {
+		return c.out.Write(ctx, value)
+	}
+	return c.out.Write(ctx, nil)
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateGetSuite{})
+
+func (s *stateGetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.UnitState.State = map[string]string{
+		"one":   "two",
+		"three": "four",
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("state-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *stateGetSuite) TestInitError(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := cmdtesting.InitCommand(com, []string{"x=x"})
+	c.Assert(err, gc.ErrorMatches, `invalid key "x=x"`)
+}
+
+func (s *stateGetSuite) TestGetKey(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"one"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "two\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *stateGetSuite) TestGetMissingKey(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"five"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
+
+func (s *stateGetSuite) TestGetAll(c *gc.C) {
+	for _, args := range [][]string{nil, {"-"}} {
+		_, com := s.createCommand(c, nil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, append([]string{"--format", "yaml"}, args...))
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stdout), jc.YAMLEquals, map[string]string{
+			"one":   "two",
+			"three": "four",
+		})
+	}
+}
+
+func (s *stateGetSuite) TestGetError(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot read unit state: zap\n")
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// stateSetCommand implements the state-set command.
+type stateSetCommand struct {
+	cmd.CommandBase
+	ctx      Context
+	settings map[string]string
+}
+
+// NewStateSetCommand returns a new stateSetCommand with the given context.
+func NewStateSetCommand(ctx Context) (cmd.Command, error) {
+	return &stateSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateSetCommand) Info() *cmd.Info {
+	doc := `
+state-set sets the supplied key/value pairs in the unit's persistent state.
+The state is stored on the controller when the hook completes successfully,
+and is limited in size by the controller.
+`
+	return &cmd.Info{
+		Name:    "state-set",
+		Args:    "<key>=<value> [...]",
+		Purpose: "set unit state",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateSetCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no key/value pairs specified")
+	}
+	c.settings, err = keyvalues.Parse(args, true)
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateSetCommand) Run(_ *cmd.Context) error {
+	keys := make([]string, 0, len(c.settings))
+	for key := range c.settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := c.ctx.SetUnitStateValue(key, c.settings[key]); err != nil {
+			return errors.Annotate(err, "cannot set unit state")
+		}
+	}
+	return nil
+}
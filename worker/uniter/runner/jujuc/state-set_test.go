@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateSetSuite{})
+
+func (s *stateSetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.UnitState.State = map[string]string{"one": "two"}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *stateSetSuite) TestInitNoArguments(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := cmdtesting.InitCommand(com, nil)
+	c.Assert(err, gc.ErrorMatches, "no key/value pairs specified")
+}
+
+func (s *stateSetSuite) TestInitInvalidArgument(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := cmdtesting.InitCommand(com, []string{"five"})
+	c.Assert(err, gc.ErrorMatches, `expected "key=value", got "five"`)
+}
+
+func (s *stateSetSuite) TestSet(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"three=four", "one=", "five=six=seven"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.UnitState.State, jc.DeepEquals, map[string]string{
+		"one":   "",
+		"three": "four",
+		"five":  "six=seven",
+	})
+	s.Stub.CheckCall(c, 0, "SetUnitStateValue", "five", "six=seven")
+}
+
+func (s *stateSetSuite) TestSetError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"three=four"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot set unit state: zap\n")
+	c.Check(hctx.info.UnitState.State, jc.DeepEquals, map[string]string{"one": "two"})
+}
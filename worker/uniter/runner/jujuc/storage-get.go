@@ -33,6 +33,9 @@ func NewStorageGetCommand(ctx Context) (cmd.Command, error) {
 func (c *StorageGetCommand) Info() *cmd.Info {
 	doc := `
 When no <key> is supplied, all keys values are printed.
+
+For shared storage, the "attachments" key holds the location
+of the storage on each of the units it is attached to.
 `
 	return &cmd.Info{
 		Name:    "storage-get",
@@ -68,6 +71,15 @@ func (c *StorageGetCommand) Run(ctx *cmd.Context) error {
 		"kind":     storage.Kind().String(),
 		"location": storage.Location(),
 	}
+	unitLocations, err := storage.UnitLocations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(unitLocations) > 0 {
+		// Shared storage is attached to many units; report
+		// where it is mounted on each of them.
+		values["attachments"] = unitLocations
+	}
 	if c.key == "" {
 		return c.out.Write(ctx, values)
 	}
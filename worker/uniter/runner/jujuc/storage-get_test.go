@@ -83,6 +83,9 @@ Options:
 
 Details:
 When no <key> is supplied, all keys values are printed.
+
+For shared storage, the "attachments" key holds the location
+of the storage on each of the units it is attached to.
 `)
 	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
 }
@@ -103,3 +106,43 @@ func (s *storageGetSuite) TestOutputPath(c *gc.C) {
 	c.Assert(goyaml.Unmarshal(content, &out), gc.IsNil)
 	c.Assert(out, gc.DeepEquals, storageAttributes)
 }
+
+func (s *storageGetSuite) TestOutputSharedStorage(c *gc.C) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SetSharedFilesystemStorage("shared/1", "/srv/shared", map[string]string{
+		"wordpress/0": "/srv/shared",
+		"wordpress/1": "/var/lib/shared",
+	}, s.Stub)
+	info.SetStorageTag("shared/1")
+	com, err := jujuc.NewCommand(hctx, cmdString("storage-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "yaml"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+
+	var out map[string]interface{}
+	c.Assert(goyaml.Unmarshal(bufferBytes(ctx.Stdout), &out), gc.IsNil)
+	c.Assert(out, gc.DeepEquals, map[string]interface{}{
+		"kind":     "filesystem",
+		"location": "/srv/shared",
+		"attachments": map[interface{}]interface{}{
+			"wordpress/0": "/srv/shared",
+			"wordpress/1": "/var/lib/shared",
+		},
+	})
+}
+
+func (s *storageGetSuite) TestOutputSharedStorageKey(c *gc.C) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SetSharedFilesystemStorage("shared/1", "/srv/shared", map[string]string{
+		"wordpress/0": "/srv/shared",
+	}, s.Stub)
+	info.SetStorageTag("shared/1")
+	com, err := jujuc.NewCommand(hctx, cmdString("storage-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "json", "attachments"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, `{"wordpress/0":"/srv/shared"}`+"\n")
+}
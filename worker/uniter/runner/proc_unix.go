@@ -0,0 +1,23 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner
+
+import (
+	"os"
+	"syscall"
+)
+
+// hookSysProcAttr returns the attributes used to start a hook in its
+// own process group.
+func hookSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the process group led by the given process,
+// which includes any children the process has started.
+func killProcessGroup(p *os.Process) error {
+	return syscall.Kill(-p.Pid, syscall.SIGKILL)
+}
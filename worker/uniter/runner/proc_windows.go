@@ -0,0 +1,22 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"syscall"
+)
+
+// hookSysProcAttr returns the attributes used to start a hook in its
+// own process group.
+func hookSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// killProcessGroup kills the given process. Windows has no portable way
+// to kill a whole process group, so any children the process has
+// started are left running.
+func killProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
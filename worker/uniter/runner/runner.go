@@ -51,6 +51,9 @@ type Context interface {
 	HookVars(paths context.Paths) ([]string, error)
 	ActionData() (*context.ActionData, error)
 	SetProcess(process context.HookProcess)
+	HookTimeouts() (timeout, warningThreshold time.Duration)
+	Clock() context.Clock
+	SetExecutingStatus(message string) error
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
 
@@ -242,6 +245,9 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	// Run the hook in its own process group, so that any processes
+	// it starts can be killed along with it if it times out.
+	ps.SysProcAttr = hookSysProcAttr()
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -255,13 +261,57 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
+		var timeout, warningThreshold time.Duration
+		if charmLocation == "hooks" {
+			timeout, warningThreshold = runner.context.HookTimeouts()
+		}
 		// Block until execution finishes
-		err = ps.Wait()
+		err = runner.waitHook(hookName, ps, timeout, warningThreshold)
 	}
 	hookLogger.Stop()
 	return errors.Trace(err)
 }
 
+// waitHook waits for the hook process to finish. If the hook runs for
+// longer than warningThreshold, the unit agent's status is updated to
+// say so; if it runs for longer than timeout, the hook's process group
+// is killed and an error is returned. Zero durations are ignored, and
+// the durations are measured with the context's clock.
+func (runner *runner) waitHook(hookName string, ps *exec.Cmd, timeout, warningThreshold time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+
+	var timedOut, warn <-chan time.Time
+	if timeout > 0 {
+		timedOut = runner.context.Clock().After(timeout)
+	}
+	if warningThreshold > 0 && (timeout == 0 || warningThreshold < timeout) {
+		warn = runner.context.Clock().After(warningThreshold)
+	}
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-warn:
+			warn = nil
+			message := fmt.Sprintf("running %s hook for over %v", hookName, warningThreshold)
+			logger.Warningf("%s: %s", runner.context.UnitName(), message)
+			if err := runner.context.SetExecutingStatus(message); err != nil {
+				logger.Errorf("cannot report long-running %q hook: %v", hookName, err)
+			}
+		case <-timedOut:
+			logger.Errorf("%q hook timed out after %v, killing it", hookName, timeout)
+			if err := killProcessGroup(ps.Process); err != nil {
+				logger.Errorf("cannot kill %q hook: %v", hookName, err)
+			}
+			<-done
+			return errors.Errorf("timed out after %v", timeout)
+		}
+	}
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
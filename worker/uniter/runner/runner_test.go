@@ -19,6 +19,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6/hooks"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -148,14 +149,18 @@ func (s *RunHookSuite) TestRunHook(c *gc.C) {
 
 type MockContext struct {
 	runner.Context
-	actionData      *context.ActionData
-	actionParams    map[string]interface{}
-	actionParamsErr error
-	actionResults   map[string]interface{}
-	expectPid       int
-	flushBadge      string
-	flushFailure    error
-	flushResult     error
+	actionData           *context.ActionData
+	actionParams         map[string]interface{}
+	actionParamsErr      error
+	actionResults        map[string]interface{}
+	expectPid            int
+	flushBadge           string
+	flushFailure         error
+	flushResult          error
+	hookTimeout          time.Duration
+	hookWarningThreshold time.Duration
+	clock                *envtesting.Clock
+	executingStatus      chan string
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -177,6 +182,19 @@ func (ctx *MockContext) SetProcess(process context.HookProcess) {
 	ctx.expectPid = process.Pid()
 }
 
+func (ctx *MockContext) HookTimeouts() (time.Duration, time.Duration) {
+	return ctx.hookTimeout, ctx.hookWarningThreshold
+}
+
+func (ctx *MockContext) Clock() context.Clock {
+	return ctx.clock
+}
+
+func (ctx *MockContext) SetExecutingStatus(message string) error {
+	ctx.executingStatus <- message
+	return nil
+}
+
 func (ctx *MockContext) Prepare() error {
 	return nil
 }
@@ -253,6 +271,103 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook scripts sleep using bash")
+	}
+	ctx := &MockContext{
+		hookTimeout: time.Minute,
+		clock:       envtesting.NewClock(time.Time{}),
+	}
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: "100",
+	}, s.paths.GetCharmDir())
+	result := make(chan error, 1)
+	go func() {
+		result <- runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	}()
+
+	err := ctx.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-result:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("hook was not killed")
+	}
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "timed out after 1m0s")
+}
+
+func (s *RunMockContextSuite) TestRunHookWarningThreshold(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook scripts sleep using bash")
+	}
+	ctx := &MockContext{
+		hookTimeout:          10 * time.Minute,
+		hookWarningThreshold: time.Minute,
+		clock:                envtesting.NewClock(time.Time{}),
+		executingStatus:      make(chan string, 1),
+	}
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: "100",
+	}, s.paths.GetCharmDir())
+	result := make(chan error, 1)
+	go func() {
+		result <- runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	}()
+
+	// The hook is reported as long-running, but carries on.
+	err := ctx.clock.WaitAdvance(time.Minute, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case message := <-ctx.executingStatus:
+		c.Assert(message, gc.Equals, "running something-happened hook for over 1m0s")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("hook not reported as long-running")
+	}
+	select {
+	case <-result:
+		c.Fatalf("hook stopped early")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	err = ctx.clock.WaitAdvance(9*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-result:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("hook was not killed")
+	}
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "timed out after 10m0s")
+}
+
+func (s *RunMockContextSuite) TestRunActionIgnoresHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook scripts sleep using bash")
+	}
+	ctx := &MockContext{
+		actionData:  &context.ActionData{},
+		hookTimeout: 100 * time.Millisecond,
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: "0.5",
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -99,9 +99,10 @@ func (s *StorageContextAccessor) Storage(tag names.StorageTag) (jujuc.ContextSto
 }
 
 type ContextStorage struct {
-	CTag      names.StorageTag
-	CKind     storage.StorageKind
-	CLocation string
+	CTag           names.StorageTag
+	CKind          storage.StorageKind
+	CLocation      string
+	CUnitLocations map[string]string
 }
 
 func (c *ContextStorage) Tag() names.StorageTag {
@@ -116,6 +117,10 @@ func (c *ContextStorage) Location() string {
 	return c.CLocation
 }
 
+func (c *ContextStorage) UnitLocations() (map[string]string, error) {
+	return c.CUnitLocations, nil
+}
+
 type FakeTracker struct {
 	leadership.Tracker
 }
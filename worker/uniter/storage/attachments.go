@@ -136,6 +136,8 @@ func (a *Attachments) init() error {
 				tag:      storageTag,
 				kind:     storage.StorageKind(attachment.Kind),
 				location: attachment.Location,
+				st:       a.st,
+				unitTag:  a.unitTag,
 			},
 		}
 	}
@@ -234,7 +236,7 @@ func (a *Attachments) removeStorageAttachment(tag names.StorageTag) error {
 }
 
 func (a *Attachments) storageStateForHook(hi hook.Info) (*stateFile, error) {
-	if !hi.Kind.IsStorage() {
+	if !hook.IsStorage(hi.Kind) {
 		return nil, errors.Errorf("not a storage hook: %#v", hi)
 	}
 	storageAttachment, ok := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
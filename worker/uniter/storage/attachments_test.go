@@ -270,6 +270,61 @@ func (s *attachmentsSuite) TestAttachmentsStorage(c *gc.C) {
 	c.Assert(ctx.Location(), gc.Equals, "/dev/sdb")
 }
 
+func (s *attachmentsSuite) TestAttachmentsStorageUnitLocations(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("wordpress/0")
+	abort := make(chan struct{})
+
+	storageTag := names.NewStorageTag("shared/0")
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+		storageAttachment: func(s names.StorageTag, u names.UnitTag) (params.StorageAttachment, error) {
+			c.Assert(s, gc.Equals, storageTag)
+			c.Assert(u, gc.Equals, unitTag)
+			return params.StorageAttachment{
+				Kind:     params.StorageKindFilesystem,
+				Life:     params.Alive,
+				Location: "/srv/shared",
+				UnitLocations: map[string]string{
+					"unit-wordpress-0": "/srv/shared",
+					"unit-wordpress-1": "/var/lib/shared",
+				},
+			}, nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(att, s.modelType)
+
+	localState := resolver.LocalState{State: operation.State{
+		Kind: operation.Continue,
+	}}
+	_, err = r.NextOp(localState, remotestate.Snapshot{
+		Life: params.Alive,
+		Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+			storageTag: {
+				Kind:     params.StorageKindFilesystem,
+				Life:     params.Alive,
+				Location: "/srv/shared",
+				Attached: true,
+			},
+		},
+	}, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := att.Storage(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	unitLocations, err := ctx.UnitLocations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitLocations, jc.DeepEquals, map[string]string{
+		"wordpress/0": "/srv/shared",
+		"wordpress/1": "/var/lib/shared",
+	})
+}
+
 func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 	stateDir := c.MkDir()
 	unitTag := names.NewUnitTag("mysql/0")
@@ -334,6 +389,134 @@ func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 	c.Assert(removed, jc.IsTrue)
 }
 
+func (s *attachmentsSuite) TestAttachmentsResized(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	storageTag := names.NewStorageTag("data/0")
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(att, s.modelType)
+
+	localState := resolver.LocalState{State: operation.State{
+		Kind: operation.Continue,
+	}}
+	nextOp := func(size uint64) (operation.Operation, error) {
+		return r.NextOp(localState, remotestate.Snapshot{
+			Life: params.Alive,
+			Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+				storageTag: {
+					Kind:     params.StorageKindBlock,
+					Life:     params.Alive,
+					Location: "/dev/sdb",
+					Attached: true,
+					Size:     size,
+				},
+			},
+		}, &mockOperations{})
+	}
+
+	op, err := nextOp(1024)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+	err = att.CommitHook(hook.Info{
+		Kind:        hooks.StorageAttached,
+		StorageId:   storageTag.Id(),
+		StorageSize: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The size the charm was told of has not changed.
+	_, err = nextOp(1024)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	op, err = nextOp(2048)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+	err = att.CommitHook(hook.Info{
+		Kind:        hook.StorageResized,
+		StorageId:   storageTag.Id(),
+		StorageSize: 2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(filepath.Join(stateDir, "data-0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 2048\n")
+
+	_, err = nextOp(2048)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *attachmentsSuite) TestAttachmentsRecordsUnknownSize(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	storageTag := names.NewStorageTag("data/0")
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return []params.StorageAttachmentId{{
+				StorageTag: storageTag.String(),
+				UnitTag:    unitTag.String(),
+			}}, nil
+		},
+		storageAttachment: func(s names.StorageTag, u names.UnitTag) (params.StorageAttachment, error) {
+			return params.StorageAttachment{
+				StorageTag: storageTag.String(),
+				UnitTag:    unitTag.String(),
+				Life:       params.Alive,
+				Kind:       params.StorageKindBlock,
+				Location:   "/dev/sdb",
+				Size:       1024,
+			}, nil
+		},
+	}
+
+	// State files written by older agents do not record the size.
+	stateFile := filepath.Join(stateDir, "data-0")
+	err := ioutil.WriteFile(stateFile, []byte("attached: true\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(att, s.modelType)
+	nextOp := func(size uint64) (operation.Operation, error) {
+		return r.NextOp(resolver.LocalState{State: operation.State{
+			Kind: operation.Continue,
+		}}, remotestate.Snapshot{
+			Life: params.Alive,
+			Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+				storageTag: {
+					Kind:     params.StorageKindBlock,
+					Life:     params.Alive,
+					Location: "/dev/sdb",
+					Attached: true,
+					Size:     size,
+				},
+			},
+		}, &mockOperations{})
+	}
+
+	// The size is recorded without running a hook, as the
+	// charm has not been told of the size before.
+	_, err = nextOp(1024)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	data, err := ioutil.ReadFile(stateFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 1024\n")
+
+	op, err := nextOp(2048)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+}
+
 func (s *attachmentsSuite) TestAttachmentsSetDying(c *gc.C) {
 	stateDir := c.MkDir()
 	unitTag := names.NewUnitTag("mysql/0")
@@ -4,6 +4,7 @@
 package storage
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/storage"
@@ -14,6 +15,11 @@ type contextStorage struct {
 	tag      names.StorageTag
 	kind     storage.StorageKind
 	location string
+
+	// st and unitTag are used to query the locations of shared
+	// storage, which change as units come and go.
+	st      StorageAccessor
+	unitTag names.UnitTag
 }
 
 func (ctx *contextStorage) Tag() names.StorageTag {
@@ -27,3 +33,19 @@ func (ctx *contextStorage) Kind() storage.StorageKind {
 func (ctx *contextStorage) Location() string {
 	return ctx.location
 }
+
+func (ctx *contextStorage) UnitLocations() (map[string]string, error) {
+	attachment, err := ctx.st.StorageAttachment(ctx.tag, ctx.unitTag)
+	if err != nil {
+		return nil, errors.Annotate(err, "querying storage attachment")
+	}
+	unitLocations := make(map[string]string)
+	for unitTagString, location := range attachment.UnitLocations {
+		unitTag, err := names.ParseUnitTag(unitTagString)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitLocations[unitTag.Id()] = location
+	}
+	return unitLocations, nil
+}
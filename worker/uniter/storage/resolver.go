@@ -144,8 +144,25 @@ func (s *storageResolver) nextHookOp(
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if ok && storageAttachment.attached {
 			// Once the storage is attached, we only care about
-			// lifecycle state changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle state changes, and the storage growing.
+			if storageAttachment.size == 0 {
+				// The size of the storage was not known
+				// when the charm was told of it. Record
+				// the size without running a hook, so the
+				// charm is only told of later resizes.
+				if snap.Size > 0 {
+					if err := storageAttachment.write(snap.Size); err != nil {
+						return nil, errors.Annotatef(err, "recording storage %q size", tag.Id())
+					}
+				}
+				return nil, resolver.ErrNoOperation
+			}
+			if snap.Size <= storageAttachment.size {
+				return nil, resolver.ErrNoOperation
+			}
+			hookInfo.Kind = hook.StorageResized
+			hookInfo.StorageSize = snap.Size
+			break
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -159,6 +176,7 @@ func (s *storageResolver) nextHookOp(
 		// The storage is alive, but we haven't previously run the
 		// "storage-attached" hook. Do so now.
 		hookInfo.Kind = hooks.StorageAttached
+		hookInfo.StorageSize = snap.Size
 	case params.Dying:
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if !ok || !storageAttachment.attached {
@@ -183,6 +201,8 @@ func (s *storageResolver) nextHookOp(
 			tag:      tag,
 			kind:     storage.StorageKind(snap.Kind),
 			location: snap.Location,
+			st:       s.storage.st,
+			unitTag:  s.storage.unitTag,
 		},
 	}
 
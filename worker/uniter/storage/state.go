@@ -25,6 +25,12 @@ type state struct {
 	// attached records the uniter's knowledge of the
 	// storage attachment state.
 	attached bool
+
+	// size records the size of the storage attachment, in MiB,
+	// most recently reported to the charm by a storage hook. It
+	// is zero if the size is not known, as for storage attached
+	// by older agents, whose state files do not record it.
+	size uint64
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -41,7 +47,7 @@ func (s *state) ValidateHook(hi hook.Info) (err error) {
 		if s.attached {
 			return errors.New("storage already attached")
 		}
-	case hooks.StorageDetaching:
+	case hooks.StorageDetaching, hook.StorageResized:
 		if !s.attached {
 			return errors.New("storage not attached")
 		}
@@ -85,6 +91,7 @@ func readStateFile(dirPath string, tag names.StorageTag) (d *stateFile, err erro
 		return nil, errors.Errorf("invalid storage state file %q: missing 'attached'", d.path)
 	}
 	d.state.attached = *info.Attached
+	d.state.size = info.Size
 	return d, nil
 }
 
@@ -135,13 +142,20 @@ func (d *stateFile) CommitHook(hi hook.Info) (err error) {
 	if hi.Kind == hooks.StorageDetaching {
 		return d.Remove()
 	}
+	return d.write(hi.StorageSize)
+}
+
+// write atomically writes to disk that the storage is attached, with
+// the specified size.
+func (d *stateFile) write(size uint64) error {
 	attached := true
-	di := diskInfo{&attached}
+	di := diskInfo{&attached, size}
 	if err := utils.WriteYaml(d.path, &di); err != nil {
 		return err
 	}
 	// If write was successful, update own state.
 	d.state.attached = true
+	d.state.size = size
 	return nil
 }
 
@@ -152,10 +166,12 @@ func (d *stateFile) Remove() error {
 	}
 	// If atomic delete succeeded, update own state.
 	d.state.attached = false
+	d.state.size = 0
 	return nil
 }
 
 // diskInfo defines the storage attachment data serialization.
 type diskInfo struct {
-	Attached *bool `yaml:"attached,omitempty"`
+	Attached *bool  `yaml:"attached,omitempty"`
+	Size     uint64 `yaml:"size,omitempty"`
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/exec"
+	"github.com/prometheus/client_golang/prometheus"
 	corecharm "gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v2"
@@ -20,6 +21,7 @@ import (
 	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/agent/tools"
+	"github.com/juju/juju/api/secretsmanager"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
@@ -109,6 +111,12 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// metrics collects Prometheus metrics about the hooks and actions
+	// run by the uniter. It is registered with prometheusRegisterer,
+	// if that is set, while the uniter is running.
+	metrics              *metricsCollector
+	prometheusRegisterer prometheus.Registerer
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -126,6 +134,7 @@ type UniterParams struct {
 	TranslateResolverErr func(error) error
 	Clock                clock.Clock
 	ApplicationChannel   watcher.NotifyChannel
+	PrometheusRegisterer prometheus.Registerer
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -172,6 +181,8 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
 		applicationChannel:   uniterParams.ApplicationChannel,
+		metrics:              newMetricsCollector(uniterParams.UnitTag.Id(), uniterParams.Clock),
+		prometheusRegisterer: uniterParams.PrometheusRegisterer,
 	}
 	startFunc := func() (worker.Worker, error) {
 		if err := catacomb.Invoke(catacomb.Plan{
@@ -188,6 +199,12 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 }
 
 func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
+	if u.prometheusRegisterer != nil {
+		if err := registerMetrics(u.prometheusRegisterer, u.metrics); err != nil {
+			return errors.Trace(err)
+		}
+		defer u.prometheusRegisterer.Unregister(u.metrics)
+	}
 	if err := u.init(unitTag); err != nil {
 		if err == jworker.ErrTerminateAgent {
 			return err
@@ -347,13 +364,15 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		}
 		for err == nil {
 			err = resolver.Loop(resolver.LoopConfig{
-				Resolver:      uniterResolver,
-				Watcher:       watcher,
-				Executor:      u.operationExecutor,
-				Factory:       u.operationFactory,
-				Abort:         u.catacomb.Dying(),
-				OnIdle:        onIdle,
-				CharmDirGuard: u.charmDirGuard,
+				Resolver:       uniterResolver,
+				Watcher:        watcher,
+				Executor:       u.operationExecutor,
+				Factory:        u.operationFactory,
+				Abort:          u.catacomb.Dying(),
+				OnIdle:         onIdle,
+				CharmDirGuard:  u.charmDirGuard,
+				Clock:          u.clock,
+				ObserveLatency: u.metrics.observeResolverLatency,
 			}, &localState)
 
 			err = u.translateResolverErr(err)
@@ -527,6 +546,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Tracker:          u.leadershipTracker,
 		GetRelationInfos: u.relations.GetInfo,
 		Storage:          u.storage,
+		Secrets:          secretsmanager.NewClient(u.st.Facade().RawAPICaller()),
 		Paths:            u.paths,
 		Clock:            u.clock,
 	})
@@ -545,6 +565,8 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Callbacks:      &operationCallbacks{u},
 		Abort:          u.catacomb.Dying(),
 		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
+		Metrics:        u.metrics,
+		Clock:          u.clock,
 	})
 
 	charmURL, err := u.getApplicationCharmURL()